@@ -1,20 +1,29 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"text/tabwriter"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/izll/agent-session-manager/session"
+	"github.com/izll/agent-session-manager/session/events"
 	"github.com/izll/agent-session-manager/ui"
 	"github.com/izll/agent-session-manager/updater"
 )
 
 func main() {
+	session.ManagerVersion = ui.AppVersion
+	accessible := os.Getenv("ASMGR_ACCESSIBLE") != ""
 	if len(os.Args) > 1 {
 		switch os.Args[1] {
+		case "--accessible", "-a":
+			accessible = true
 		case "--version", "-v":
 			fmt.Printf("%s version %s\n", ui.AppName, ui.AppVersion)
 			return
@@ -27,6 +36,81 @@ func main() {
 		case "--help", "-h":
 			printHelp()
 			return
+		case "events":
+			follow := len(os.Args) > 2 && (os.Args[2] == "--follow" || os.Args[2] == "-f")
+			if err := events.Stream(os.Stdout, follow); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "tail":
+			if err := runTail(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "list", "status":
+			if err := runState(os.Args[1], os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "sync":
+			if err := runSync(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "cast":
+			if err := runCast(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "handover":
+			if err := runHandover(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "export":
+			if err := runExport(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "import":
+			if err := runImport(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "import-claude":
+			if err := runImportClaude(os.Args[2:]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		case "record-pane":
+			// Internal: receives a session's pane output from tmux pipe-pane
+			if len(os.Args) < 6 {
+				os.Exit(1)
+			}
+			width, _ := strconv.Atoi(os.Args[3])
+			height, _ := strconv.Atoi(os.Args[4])
+			if err := session.RecordPane(os.Stdin, os.Args[2], width, height, os.Args[5]); err != nil {
+				os.Exit(1)
+			}
+			return
+		case "watch-log":
+			// Internal: appends a session's new output lines to its log (started by Ctrl+L)
+			if len(os.Args) < 3 {
+				os.Exit(1)
+			}
+			if err := runWatchLog(os.Args[2]); err != nil {
+				os.Exit(1)
+			}
+			return
 		case "yolo":
 			if len(os.Args) < 4 {
 				fmt.Fprintf(os.Stderr, "Usage: %s yolo <tmux-session-name> <window-index>\n", os.Args[0])
@@ -37,6 +121,15 @@ func main() {
 				os.Exit(1)
 			}
 			return
+		case "yolo-expire":
+			// Internal: run by the tmux timer of a time-limited YOLO mode
+			if len(os.Args) < 4 {
+				os.Exit(1)
+			}
+			if err := expireYolo(os.Args[2], os.Args[3]); err != nil {
+				os.Exit(1)
+			}
+			return
 		case "refresh-status":
 			if len(os.Args) < 3 {
 				os.Exit(1)
@@ -45,11 +138,16 @@ func main() {
 			return
 		case "yolo-confirm":
 			if len(os.Args) < 5 {
-				fmt.Fprintf(os.Stderr, "Usage: %s yolo-confirm <tmux-session> <window-index> <on|off>\n", os.Args[0])
+				fmt.Fprintf(os.Stderr, "Usage: %s yolo-confirm <tmux-session> <window-index> <on|off> [minutes]\n", os.Args[0])
 				os.Exit(1)
 			}
 			enable := os.Args[4] == "on"
-			if err := confirmYolo(os.Args[2], os.Args[3], enable); err != nil {
+			var limit time.Duration
+			if len(os.Args) > 5 {
+				minutes, _ := strconv.Atoi(os.Args[5])
+				limit = time.Duration(minutes) * time.Minute
+			}
+			if err := confirmYolo(os.Args[2], os.Args[3], enable, limit); err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 				os.Exit(1)
 			}
@@ -63,26 +161,531 @@ func main() {
 		os.Exit(1)
 	}
 
-	p := tea.NewProgram(model, tea.WithAltScreen())
+	// Mirror bus events to the log read by `events --follow`
+	if closeLog, err := events.EnableLog(); err == nil {
+		defer closeLog()
+	}
+
+	// Screen-reader mode renders inline, so the terminal keeps a readable history
+	// Output shared with the bells and OSC sequences the UI writes itself
+	options := []tea.ProgramOption{tea.WithOutput(ui.TerminalOutput)}
+	if accessible {
+		model.SetAccessible(true)
+	} else {
+		options = append(options, tea.WithAltScreen())
+	}
+	p := tea.NewProgram(model, options...)
 
 	if _, err := p.Run(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+
+	// Hand this machine's changes to the others
+	if config, err := session.LoadSyncConfig(); err == nil && config != nil && config.Auto {
+		if err := syncNow(config); err != nil {
+			fmt.Fprintf(os.Stderr, "Sync failed: %v\n", err)
+		}
+	}
 }
 
 func printHelp() {
 	fmt.Printf(`%s - Agent Session Manager
 
 Usage: %s [options]
+       %s events [--follow]
+       %s tail <session> [--follow] [--strip] [-n lines] [-w tab]
+       %s cast <session> [-o file.cast] [--idle seconds] [--list]
+       %s handover <session> [--summarize] [-o file.md]
+       %s list [--json] [-p project]
+       %s status [--json] [--popup [session]]
+       %s sync
+       %s export <file.tar.gz>
+       %s import <file.tar.gz> [--merge|--replace] [--scripts]
+       %s import-claude <export.zip|conversations.json> [path] [--match text] [--list]
 
 Options:
   -v, --version    Show version
   -u, --update     Update to latest version
   -h, --help       Show this help
+  -a, --accessible Screen-reader mode: plain lines describing the selected item,
+                   no colors or boxes (also ASMGR_ACCESSIBLE=1)
+
+Commands:
+  events           Print the event log as JSON lines (--follow streams new events)
+  tail             Print a session's output (name or ID); --follow streams new lines,
+                   --strip removes colors, -n limits existing lines, -w picks a tab
+  cast             Export a session's latest recording (Ctrl+R in the TUI) as an
+                   asciinema cast; --idle caps pauses (default 2s, 0 = off)
+  handover         Print a Markdown handover of a session (summary, todo list,
+                   branch, changes, resume command); --summarize asks the agent
+                   for a fresh summary, -o writes it to a file
+  list             List sessions of all projects with their status; --json prints
+                   them as a JSON array, -p limits to one project (name or ID)
+  status           Summarize projects (running/waiting sessions); --json prints the
+                   full project, group, session and tab state, --popup shows the
+                   running sessions until a key is pressed (tmux prefix+a)
+  sync             Merge projects, sessions and config with the sync directory of
+                   [sync] in config.toml (a git repo or a Syncthing/Dropbox folder)
+  export           Write projects, sessions, groups, settings, notes and config
+                   files to a .tar.gz archive (backup or moving to another machine)
+  import           Read an archive from export; --merge (default) adds what is
+                   missing and keeps local versions, --replace overwrites them,
+                   --scripts installs its Lua hooks too
+  import-claude    Turn the conversations of a claude.ai / Claude Desktop data
+                   export into Claude Code transcripts of path (searchable and
+                   resumable there); --match picks conversations by title,
+                   --list only lists them
 
 Run without arguments to start the TUI.
-`, ui.AppName, ui.AppName)
+`, ui.AppName, ui.AppName, ui.AppName, ui.AppName, ui.AppName, ui.AppName, ui.AppName, ui.AppName, ui.AppName, ui.AppName, ui.AppName, ui.AppName)
+}
+
+// runState prints sessions (list) or projects (status), as a table or as JSON
+func runState(command string, args []string) error {
+	usage := fmt.Errorf("usage: %s %s [--json] [--popup [session]]", ui.AppName, command)
+	if command == "list" {
+		usage = fmt.Errorf("usage: %s list [--json] [-p project]", ui.AppName)
+	}
+	asJSON, popup := false, false
+	project, current := "", ""
+	for idx := 0; idx < len(args); idx++ {
+		switch arg := args[idx]; {
+		case arg == "--json":
+			asJSON = true
+		case arg == "--popup" && command == "status":
+			popup = true
+			if idx+1 < len(args) && !strings.HasPrefix(args[idx+1], "-") {
+				idx++
+				current = args[idx]
+			}
+		case (arg == "-p" || arg == "--project") && command == "list" && idx+1 < len(args):
+			idx++
+			project = args[idx]
+		default:
+			return usage
+		}
+	}
+	if popup {
+		// Older tmux versions pass the format unexpanded
+		if current == "" || strings.Contains(current, "#{") {
+			out, _ := session.Tmux.Output("display-message", "-p", "#{session_name}")
+			current = strings.TrimSpace(string(out))
+		}
+		return ui.RunStatusPopup(current)
+	}
+
+	storage, err := session.NewStorage()
+	if err != nil {
+		return fmt.Errorf("failed to load storage: %w", err)
+	}
+	snapshot, err := storage.Snapshot()
+	if err != nil {
+		return err
+	}
+
+	if command == "status" {
+		if asJSON {
+			return writeJSON(snapshot)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "PROJECT\tSESSIONS\tRUNNING\tWAITING\tPATH")
+		for _, p := range snapshot.Projects {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\n", p.Name, len(p.Sessions), p.Running, p.Waiting, p.Path)
+		}
+		return w.Flush()
+	}
+
+	sessions := []session.SessionState{}
+	found := project == ""
+	for _, p := range snapshot.Projects {
+		if project != "" && p.Name != project && p.ID != project {
+			continue
+		}
+		found = true
+		sessions = append(sessions, p.Sessions...)
+	}
+	if !found {
+		return fmt.Errorf("project not found: %s", project)
+	}
+	if asJSON {
+		return writeJSON(sessions)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tPROJECT\tGROUP\tAGENT\tSTATUS\tACTIVITY\tTABS\tPATH")
+	for _, s := range sessions {
+		activity := s.Activity
+		if activity == "" {
+			activity = "-"
+		}
+		group := s.GroupName
+		if group == "" {
+			group = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%d\t%s\n", s.Name, s.ProjectName, group, s.Agent, s.Status, activity, len(s.Tabs), s.Path)
+	}
+	return w.Flush()
+}
+
+// runWatchLog logs a session's output until the session stops
+func runWatchLog(id string) error {
+	storage, err := session.NewStorage()
+	if err != nil {
+		return err
+	}
+	inst, err := storage.FindInstanceAnyProject(id)
+	if err != nil {
+		return err
+	}
+	return inst.RunOutputLog()
+}
+
+// runSync merges the local state with the sync directory
+func runSync(args []string) error {
+	if len(args) > 0 {
+		return fmt.Errorf("usage: %s sync", ui.AppName)
+	}
+	config, err := session.LoadSyncConfig()
+	if err != nil {
+		return err
+	}
+	if config == nil {
+		return fmt.Errorf("sync is not set up: add a [sync] section with dir = \"~/path/to/shared/dir\" to %s", session.ConfigPath())
+	}
+	return syncNow(config)
+}
+
+// syncNow runs a sync and prints what it changed
+func syncNow(config *session.SyncConfig) error {
+	storage, err := session.NewStorage()
+	if err != nil {
+		return fmt.Errorf("failed to load storage: %w", err)
+	}
+	report, err := storage.Sync(config)
+	if report != nil {
+		fmt.Printf("Synced with %s: %d received, %d sent\n", config.Dir, report.Received, report.Sent)
+		for _, conflict := range report.Conflicts {
+			fmt.Printf("  conflict: %s\n", conflict)
+		}
+		for _, project := range report.Skipped {
+			fmt.Printf("  skipped project %s: open in another %s\n", project, ui.AppName)
+		}
+	}
+	return err
+}
+
+// runExport writes the whole state to a .tar.gz archive
+func runExport(args []string) error {
+	if len(args) != 1 || strings.HasPrefix(args[0], "-") {
+		return fmt.Errorf("usage: %s export <file.tar.gz>", ui.AppName)
+	}
+	storage, err := session.NewStorage()
+	if err != nil {
+		return fmt.Errorf("failed to load storage: %w", err)
+	}
+	report, err := storage.ExportArchive(args[0])
+	if err != nil {
+		os.Remove(args[0])
+		return err
+	}
+	fmt.Printf("Exported %d projects, %d sessions, %d groups and %d config files to %s\n", report.Projects, report.Sessions, report.Groups, report.Files, args[0])
+	return nil
+}
+
+// runImport reads an archive written by export, merging with or replacing the state
+func runImport(args []string) error {
+	usage := fmt.Errorf("usage: %s import <file.tar.gz> [--merge|--replace] [--scripts]", ui.AppName)
+	var file string
+	mode := session.ImportMerge
+	scripts := false
+	for _, arg := range args {
+		switch arg {
+		case "--merge":
+			mode = session.ImportMerge
+		case "--replace":
+			mode = session.ImportReplace
+		case "--scripts":
+			scripts = true
+		default:
+			if strings.HasPrefix(arg, "-") || file != "" {
+				return usage
+			}
+			file = arg
+		}
+	}
+	if file == "" {
+		return usage
+	}
+
+	manifest, err := session.ReadArchiveManifest(file)
+	if err != nil {
+		return err
+	}
+	storage, err := session.NewStorage()
+	if err != nil {
+		return fmt.Errorf("failed to load storage: %w", err)
+	}
+	report, err := storage.ImportArchive(file, mode, scripts)
+	if report != nil {
+		verb := "Merged"
+		if mode == session.ImportReplace {
+			verb = "Replaced with"
+		}
+		fmt.Printf("%s the archive of %s (%s): %d projects, %d sessions, %d groups and %d config files changed\n",
+			verb, manifest.Machine, manifest.CreatedAt.Local().Format("2006-01-02 15:04"), report.Projects, report.Sessions, report.Groups, report.Files)
+		for _, conflict := range report.Conflicts {
+			fmt.Printf("  conflict: %s\n", conflict)
+		}
+		for _, project := range report.Skipped {
+			fmt.Printf("  skipped project %s: open in another %s\n", project, ui.AppName)
+		}
+		for _, script := range report.Scripts {
+			fmt.Printf("  skipped %s: Lua hooks are only imported with --scripts\n", script)
+		}
+	}
+	return err
+}
+
+// runImportClaude imports web conversations as Claude Code transcripts of a directory
+func runImportClaude(args []string) error {
+	usage := fmt.Errorf("usage: %s import-claude <export.zip|conversations.json> [path] [--match text] [--list]", ui.AppName)
+	var file, path, match string
+	list := false
+	for idx := 0; idx < len(args); idx++ {
+		switch arg := args[idx]; {
+		case arg == "--list" || arg == "-l":
+			list = true
+		case (arg == "--match" || arg == "-m") && idx+1 < len(args):
+			idx++
+			match = strings.ToLower(args[idx])
+		case strings.HasPrefix(arg, "-"):
+			return usage
+		case file == "":
+			file = arg
+		case path == "":
+			path = arg
+		default:
+			return usage
+		}
+	}
+	if file == "" || (path == "" && !list) {
+		return usage
+	}
+
+	conversations, err := session.ReadWebExport(file)
+	if err != nil {
+		return err
+	}
+	var picked []session.WebConversation
+	for _, conv := range conversations {
+		if match == "" || strings.Contains(strings.ToLower(conv.Name), match) {
+			picked = append(picked, conv)
+		}
+	}
+	if list {
+		for _, conv := range picked {
+			name := conv.Name
+			if name == "" {
+				name = "(untitled)"
+			}
+			fmt.Printf("%s  %4d messages  %s\n", conv.UpdatedAt.Local().Format("2006-01-02 15:04"), len(conv.Messages), name)
+		}
+		return nil
+	}
+	if len(picked) == 0 {
+		return fmt.Errorf("no conversations match '%s'", match)
+	}
+
+	report, err := session.ImportWebConversations(picked, path)
+	if report != nil {
+		fmt.Printf("Imported %d conversations (%d imported before, %d empty)\n", len(report.Imported), report.Existing, report.Empty)
+		if len(report.Imported) > 0 {
+			fmt.Printf("Resume one in %s with: claude --resume %s\n", path, report.Imported[0])
+		}
+	}
+	return err
+}
+
+// writeJSON prints a value as indented JSON
+func writeJSON(value interface{}) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(value)
+}
+
+// runCast exports a session's latest recording as an asciinema cast file
+func runCast(args []string) error {
+	usage := fmt.Errorf("usage: %s cast <session> [-o file.cast] [--idle seconds] [--list]", ui.AppName)
+	var name, output string
+	idle := session.DefaultCastIdleLimit
+	list := false
+	for idx := 0; idx < len(args); idx++ {
+		switch arg := args[idx]; arg {
+		case "--list", "-l":
+			list = true
+		case "-o", "--idle":
+			if idx+1 >= len(args) {
+				return usage
+			}
+			idx++
+			if arg == "-o" {
+				output = args[idx]
+				continue
+			}
+			value, err := strconv.ParseFloat(args[idx], 64)
+			if err != nil || value < 0 {
+				return fmt.Errorf("--idle needs seconds, got '%s'", args[idx])
+			}
+			idle = value
+		default:
+			if strings.HasPrefix(arg, "-") || name != "" {
+				return usage
+			}
+			name = arg
+		}
+	}
+	if name == "" {
+		return usage
+	}
+
+	storage, err := session.NewStorage()
+	if err != nil {
+		return fmt.Errorf("failed to load storage: %w", err)
+	}
+	inst, err := storage.FindInstanceAnyProject(name)
+	if err != nil {
+		return err
+	}
+	recordings := inst.Recordings()
+	if list {
+		for _, path := range recordings {
+			fmt.Println(path)
+		}
+		return nil
+	}
+	if len(recordings) == 0 {
+		return fmt.Errorf("no recordings for '%s' - press Ctrl+R on the session to start one", inst.Name)
+	}
+	src := recordings[len(recordings)-1]
+	if output == "" {
+		output = strings.TrimSuffix(filepath.Base(src), ".cast")
+		output = fmt.Sprintf("%s-%s.cast", sanitizeFileName(inst.Name), output)
+	}
+	if err := session.ExportCast(src, output, idle); err != nil {
+		return err
+	}
+	fmt.Printf("Exported %s\nPlay it with: asciinema play %s\n", output, output)
+	return nil
+}
+
+// runHandover prints or writes the handover document of a session
+func runHandover(args []string) error {
+	usage := fmt.Errorf("usage: %s handover <session> [--summarize] [-o file.md]", ui.AppName)
+	var name, output string
+	summarize := false
+	for idx := 0; idx < len(args); idx++ {
+		switch arg := args[idx]; arg {
+		case "--summarize", "-s":
+			summarize = true
+		case "-o":
+			if idx+1 >= len(args) {
+				return usage
+			}
+			idx++
+			output = args[idx]
+		default:
+			if strings.HasPrefix(arg, "-") || name != "" {
+				return usage
+			}
+			name = arg
+		}
+	}
+	if name == "" {
+		return usage
+	}
+
+	storage, err := session.NewStorage()
+	if err != nil {
+		return fmt.Errorf("failed to load storage: %w", err)
+	}
+	inst, err := storage.FindInstanceAnyProject(name)
+	if err != nil {
+		return err
+	}
+	summary := ""
+	if summarize {
+		if summary, err = inst.Summarize(session.LoadSummaryConfig(), inst.SummaryInput()); err != nil {
+			return err
+		}
+	}
+	doc := inst.Handover(summary)
+	if output == "" {
+		fmt.Print(doc)
+		return nil
+	}
+	if err := os.WriteFile(output, []byte(doc), 0644); err != nil {
+		return err
+	}
+	fmt.Printf("Wrote %s\n", output)
+	return nil
+}
+
+// sanitizeFileName replaces characters that are awkward in file names
+func sanitizeFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case '/', '\\', ':', '*', '?', '"', '<', '>', '|', ' ':
+			return '-'
+		}
+		return r
+	}, name)
+}
+
+// runTail prints a session's pane output, optionally following it
+func runTail(args []string) error {
+	usage := fmt.Errorf("usage: %s tail <session> [--follow] [--strip] [-n lines] [-w tab]", ui.AppName)
+	var name string
+	var opts session.TailOptions
+	for idx := 0; idx < len(args); idx++ {
+		switch arg := args[idx]; arg {
+		case "--follow", "-f":
+			opts.Follow = true
+		case "--strip", "-s":
+			opts.Strip = true
+		case "-n", "-w":
+			if idx+1 >= len(args) {
+				return usage
+			}
+			idx++
+			value, err := strconv.Atoi(args[idx])
+			if err != nil || value < 0 {
+				return fmt.Errorf("%s needs a number, got '%s'", arg, args[idx])
+			}
+			if arg == "-n" {
+				opts.Lines = value
+			} else {
+				opts.Window = value
+			}
+		default:
+			if strings.HasPrefix(arg, "-") || name != "" {
+				return usage
+			}
+			name = arg
+		}
+	}
+	if name == "" {
+		return usage
+	}
+
+	storage, err := session.NewStorage()
+	if err != nil {
+		return fmt.Errorf("failed to load storage: %w", err)
+	}
+	inst, err := storage.FindInstanceAnyProject(name)
+	if err != nil {
+		return err
+	}
+	return inst.Tail(os.Stdout, opts)
 }
 
 func runUpdate() error {
@@ -208,6 +811,11 @@ func toggleYolo(tmuxSessionName, windowIndex string) error {
 		return fmt.Errorf("session not found: %s", tmuxSessionName)
 	}
 
+	if inst.Sandbox != "" {
+		session.Tmux.Run("display-message", "-t", tmuxSessionName, "Sandboxed session - YOLO mode is disabled")
+		return nil
+	}
+
 	// Determine agent type for the active window
 	var agentType session.AgentType
 	var currentYolo bool
@@ -218,13 +826,13 @@ func toggleYolo(tmuxSessionName, windowIndex string) error {
 		if agentType == "" {
 			agentType = session.AgentClaude
 		}
-		currentYolo = inst.AutoYes
+		currentYolo = inst.AutoYes || inst.YoloGrant != nil
 	} else {
 		// Check if it's a followed window
 		for _, fw := range inst.FollowedWindows {
 			if fmt.Sprintf("%d", fw.Index) == windowIndex {
 				agentType = fw.Agent
-				currentYolo = fw.AutoYes
+				currentYolo = fw.AutoYes || fw.YoloGrant != nil
 				break
 			}
 		}
@@ -240,14 +848,14 @@ func toggleYolo(tmuxSessionName, windowIndex string) error {
 
 	// Terminal windows don't support YOLO
 	if agentType == session.AgentTerminal {
-		exec.Command("tmux", "display-message", "-t", tmuxSessionName, "Terminal windows don't support YOLO mode").Run()
+		session.Tmux.Run("display-message", "-t", tmuxSessionName, "Terminal windows don't support YOLO mode")
 		return nil
 	}
 
 	// Check if agent supports AutoYes
 	config := session.AgentConfigs[agentType]
 	if !config.SupportsAutoYes {
-		exec.Command("tmux", "display-message", "-t", tmuxSessionName, fmt.Sprintf("YOLO mode not supported for %s", agentType)).Run()
+		session.Tmux.Run("display-message", "-t", tmuxSessionName, fmt.Sprintf("YOLO mode not supported for %s", agentType))
 		return nil
 	}
 
@@ -263,123 +871,102 @@ func toggleYolo(tmuxSessionName, windowIndex string) error {
 		newState = "on"
 	}
 
-	// Show tmux menu for confirmation
+	// Show tmux menu for confirmation (enabling also offers time limits)
 	confirmCmd := fmt.Sprintf("asmgr yolo-confirm %s %s %s", tmuxSessionName, windowIndex, newState)
-	exec.Command("tmux", "display-menu", "-t", tmuxSessionName,
+	menu := []string{"-t", tmuxSessionName,
 		"-T", fmt.Sprintf(" %s ", menuTitle),
 		fmt.Sprintf(" %s ", menuAction), "", fmt.Sprintf("run-shell '%s'", confirmCmd),
-		" Cancel ", "", "",
-	).Run()
+	}
+	if !currentYolo {
+		for _, d := range session.YoloDurations {
+			minutes := int(d.Minutes())
+			menu = append(menu, fmt.Sprintf(" Enable for %d min ", minutes), "", fmt.Sprintf("run-shell '%s %d'", confirmCmd, minutes))
+		}
+	}
+	menu = append(menu, " Cancel ", "", "")
+	session.Tmux.Run(append([]string{"display-menu"}, menu...)...)
 
 	return nil
 }
 
 // confirmYolo performs the actual YOLO toggle after user confirmation
-func confirmYolo(tmuxSessionName, windowIndex string, enableYolo bool) error {
-	// Load storage
+// (limit > 0 switches it off again after that long)
+func confirmYolo(tmuxSessionName, windowIndex string, enableYolo bool, limit time.Duration) error {
 	storage, err := session.NewStorage()
 	if err != nil {
 		return fmt.Errorf("failed to load storage: %w", err)
 	}
-
-	// Search in all projects for the session
-	var inst *session.Instance
-
-	// First check default project
-	instances, _, _ := storage.LoadAll()
-	for _, i := range instances {
-		if i.TmuxSessionName() == tmuxSessionName {
-			inst = i
-			break
-		}
+	storage.MarkHelper()
+	inst, err := storage.FindInstanceAnyProject(tmuxSessionName)
+	if err != nil {
+		return err
+	}
+	if enableYolo && inst.Sandbox != "" {
+		return fmt.Errorf("sandboxed session - YOLO mode is disabled")
+	}
+	window, err := strconv.Atoi(windowIndex)
+	if err != nil {
+		return fmt.Errorf("invalid window index: %s", windowIndex)
 	}
 
-	// If not found, search in other projects
-	if inst == nil {
-		projectsData, err := storage.LoadProjects()
-		if err == nil && projectsData != nil {
-			for _, project := range projectsData.Projects {
-				storage.SetActiveProject(project.ID)
-				instances, _, _ := storage.LoadAll()
-				for _, i := range instances {
-					if i.TmuxSessionName() == tmuxSessionName {
-						inst = i
-						break
-					}
-				}
-				if inst != nil {
-					break
-				}
-			}
+	var grant *session.YoloGrant
+	change := "YOLO off"
+	if enableYolo {
+		change = "YOLO on"
+		if limit > 0 {
+			grant = &session.YoloGrant{Until: time.Now().Add(limit)}
+			change = fmt.Sprintf("YOLO on for %d min", int(limit.Minutes()))
 		}
 	}
+	return applyYolo(storage, inst, window, enableYolo, grant, change)
+}
 
-	if inst == nil {
-		return fmt.Errorf("session not found: %s", tmuxSessionName)
+// expireYolo switches a window's YOLO mode off when its time limit has passed
+// (run by the tmux timer ScheduleYoloExpiry starts)
+func expireYolo(tmuxSessionName, windowIndex string) error {
+	storage, err := session.NewStorage()
+	if err != nil {
+		return fmt.Errorf("failed to load storage: %w", err)
 	}
-
-	// Determine agent type and update YOLO state
-	var agentType session.AgentType
-	var isFollowedWindow bool
-	var followedWindowIdx int
-
-	if windowIndex == "0" {
-		agentType = inst.Agent
-		if agentType == "" {
-			agentType = session.AgentClaude
-		}
-		inst.AutoYes = enableYolo
-	} else {
-		for idx, fw := range inst.FollowedWindows {
-			if fmt.Sprintf("%d", fw.Index) == windowIndex {
-				agentType = fw.Agent
-				isFollowedWindow = true
-				followedWindowIdx = idx
-				inst.FollowedWindows[idx].AutoYes = enableYolo
-				break
-			}
-		}
+	storage.MarkHelper()
+	inst, err := storage.FindInstanceAnyProject(tmuxSessionName)
+	if err != nil {
+		return err
+	}
+	window, err := strconv.Atoi(windowIndex)
+	if err != nil {
+		return fmt.Errorf("invalid window index: %s", windowIndex)
+	}
+	// Switched off or extended in the meantime
+	if !inst.WindowYoloGrant(window).Expired(time.Now()) {
+		return nil
 	}
+	return applyYolo(storage, inst, window, false, nil, "YOLO expired")
+}
 
-	// Save changes
+// applyYolo saves and logs a window's new YOLO state and respawns its agent
+// (resuming its conversation) so the auto-yes flag takes effect
+func applyYolo(storage *session.Storage, inst *session.Instance, window int, enableYolo bool, grant *session.YoloGrant, change string) error {
+	inst.SetWindowYolo(window, enableYolo, grant)
 	if err := storage.UpdateInstance(inst); err != nil {
 		return fmt.Errorf("failed to save: %w", err)
 	}
+	inst.LogYolo(window, change)
 
-	// Get agent config
-	config := session.AgentConfigs[agentType]
-
-	// Build restart command
-	var args []string
-	args = append(args, config.Command)
-	if enableYolo && config.AutoYesFlag != "" {
-		args = append(args, config.AutoYesFlag)
+	inst.UpdateStatus()
+	if inst.Status != session.StatusRunning {
+		return nil
 	}
-
-	// Add resume flag if supported
-	if config.SupportsResume && config.ResumeFlag != "" {
-		if !isFollowedWindow && inst.ResumeSessionID != "" {
-			args = append(args, config.ResumeFlag, inst.ResumeSessionID)
-		} else if isFollowedWindow && inst.FollowedWindows[followedWindowIdx].ResumeSessionID != "" {
-			args = append(args, config.ResumeFlag, inst.FollowedWindows[followedWindowIdx].ResumeSessionID)
-		}
+	resumeID := inst.ResumeSessionID
+	if fw := inst.GetFollowedWindow(window); window != 0 && fw != nil {
+		resumeID = fw.ResumeSessionID
 	}
-
-	// Respawn the pane with new command
-	target := fmt.Sprintf("%s:%s", tmuxSessionName, windowIndex)
-	cmdStr := strings.Join(args, " ")
-	exec.Command("tmux", "respawn-pane", "-t", target, "-k", cmdStr).Run()
+	inst.RespawnWindowWithResume(window, resumeID)
+	inst.ScheduleYoloExpiry(window)
 
 	// Refresh status bar
-	refreshStatusBar(tmuxSessionName)
-
-	// Show confirmation message
-	status := "OFF"
-	if enableYolo {
-		status = "ON"
-	}
-	exec.Command("tmux", "display-message", "-t", tmuxSessionName, fmt.Sprintf("YOLO mode %s for window %s", status, windowIndex)).Run()
-
+	refreshStatusBar(inst.TmuxSessionName())
+	session.Tmux.Run("display-message", "-t", inst.TmuxSessionName(), fmt.Sprintf("%s for window %d", change, window))
 	return nil
 }
 
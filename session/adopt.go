@@ -0,0 +1,134 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// ForeignSession is a tmux session on the server that no session of any project manages
+type ForeignSession struct {
+	Name     string
+	Path     string // Working directory of its active pane
+	Command  string // Program running in its active pane
+	Windows  int
+	Attached bool
+}
+
+// shells are pane commands that mean a plain terminal
+var shells = map[string]bool{"bash": true, "zsh": true, "fish": true, "sh": true, "dash": true, "ksh": true, "tcsh": true, "nu": true}
+
+// IsShell reports whether the session's active pane runs a plain shell
+func (f ForeignSession) IsShell() bool {
+	return shells[strings.TrimPrefix(f.Command, "-")]
+}
+
+// UserShell returns the program name of the user's login shell
+func UserShell() string {
+	if shell := filepath.Base(os.Getenv("SHELL")); shell != "" && shell != "." && shell != "/" {
+		return shell
+	}
+	return "bash"
+}
+
+// managedSessionIDs returns the IDs of the sessions of all projects
+func (s *Storage) managedSessionIDs() (map[string]bool, error) {
+	projectsData, err := s.LoadProjects()
+	if err != nil {
+		return nil, err
+	}
+	projectIDs := []string{""}
+	if projectsData != nil {
+		for _, p := range projectsData.Projects {
+			projectIDs = append(projectIDs, p.ID)
+		}
+	}
+
+	ids := make(map[string]bool)
+	for _, projectID := range projectIDs {
+		err := s.inProject(projectID, func() error {
+			instances, _, err := s.LoadAll()
+			for _, inst := range instances {
+				ids[inst.ID] = true
+			}
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ids, nil
+}
+
+// ForeignSessions lists the tmux sessions that belong to no session of any project
+func (s *Storage) ForeignSessions() ([]ForeignSession, error) {
+	out, err := Tmux.Output("list-sessions", "-F",
+		"#{session_name}\t#{session_windows}\t#{session_attached}\t#{pane_current_path}\t#{pane_current_command}")
+	if err != nil {
+		return nil, nil // No tmux server
+	}
+	managed, err := s.managedSessionIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []ForeignSession
+	for _, line := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 5 || managed[fields[0]] || strings.HasPrefix(fields[0], "asm_") {
+			continue
+		}
+		windows, _ := strconv.Atoi(fields[1])
+		attached, _ := strconv.Atoi(fields[2])
+		sessions = append(sessions, ForeignSession{
+			Name:     fields[0],
+			Windows:  windows,
+			Attached: attached > 0,
+			Path:     fields[3],
+			Command:  fields[4],
+		})
+	}
+	return sessions, nil
+}
+
+// AdoptSession brings a foreign tmux session under management as a Custom
+// session running command. The tmux session is renamed to the new session's
+// ID; its first window becomes the main window and the others terminal tabs.
+func AdoptSession(f ForeignSession, command string) (*Instance, error) {
+	if strings.TrimSpace(command) == "" {
+		return nil, fmt.Errorf("no command for '%s'", f.Name)
+	}
+	inst, err := NewInstance(f.Name, f.Path, false, AgentCustom)
+	if err != nil {
+		return nil, err
+	}
+	inst.CustomCommand = command
+
+	if out, err := Tmux.CombinedOutput("rename-session", "-t", "="+f.Name, inst.ID); err != nil {
+		return nil, fmt.Errorf("failed to rename tmux session '%s': %s", f.Name, strings.TrimSpace(string(out)))
+	}
+	inst.Status = StatusRunning
+
+	// The main window must be window 0 (base-index may start at 1)
+	windows := inst.GetWindowList()
+	if len(windows) > 0 && windows[0].Index != 0 {
+		source := fmt.Sprintf("%s:%d", inst.ID, windows[0].Index)
+		Tmux.Run("move-window", "-s", source, "-t", inst.ID+":0")
+		windows = inst.GetWindowList()
+	}
+	for _, w := range windows {
+		if w.Index != 0 {
+			inst.FollowedWindows = append(inst.FollowedWindows, FollowedWindow{Index: w.Index, Agent: AgentTerminal, Name: w.Name})
+		}
+	}
+
+	// Same tmux setup as sessions started here, but keep the window names
+	for _, args := range inst.sessionSetupArgs() {
+		if args[0] != "rename-window" {
+			Tmux.Run(args...)
+		}
+	}
+	return inst, nil
+}
@@ -0,0 +1,68 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AgentDisplay overrides how an agent is shown in the UI
+type AgentDisplay struct {
+	Icon  string `json:"icon,omitempty"`  // Emoji or short text shown in lists, tabs and pickers
+	Label string `json:"label,omitempty"` // Name shown in the preview and pickers
+}
+
+// AgentDisplayConfig holds the user's agent icon and label overrides (agents.json)
+type AgentDisplayConfig struct {
+	Agents map[AgentType]AgentDisplay `json:"agents,omitempty"` // By agent type (claude, gemini, ...)
+	Custom map[string]AgentDisplay    `json:"custom,omitempty"` // Custom agents by program name of their command
+}
+
+// AgentDisplayPath returns the path of the agent display overrides file
+func AgentDisplayPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "agent-session-manager", "agents.json")
+}
+
+// LoadAgentDisplay reads agents.json (an empty config when missing)
+func LoadAgentDisplay() (*AgentDisplayConfig, error) {
+	config := &AgentDisplayConfig{}
+	data, err := os.ReadFile(AgentDisplayPath())
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+	if err := json.Unmarshal(data, config); err != nil {
+		return &AgentDisplayConfig{}, fmt.Errorf("invalid %s: %w", filepath.Base(AgentDisplayPath()), err)
+	}
+	return config, nil
+}
+
+// Lookup returns the overrides for an agent. Custom agents are also matched by
+// the program name of their command, which takes precedence over the "custom" entry.
+func (c *AgentDisplayConfig) Lookup(agent AgentType, customCommand string) AgentDisplay {
+	if c == nil {
+		return AgentDisplay{}
+	}
+	display := c.Agents[agent]
+	if agent != AgentCustom {
+		return display
+	}
+	if fields := strings.Fields(customCommand); len(fields) > 0 {
+		custom, ok := c.Custom[filepath.Base(fields[0])]
+		if !ok {
+			custom = c.Custom[fields[0]]
+		}
+		if custom.Icon != "" {
+			display.Icon = custom.Icon
+		}
+		if custom.Label != "" {
+			display.Label = custom.Label
+		}
+	}
+	return display
+}
@@ -12,3 +12,20 @@ type AgentSession struct {
 	UpdatedAt    time.Time `json:"updated_at"`
 	AgentType    AgentType `json:"agent_type"`
 }
+
+// ListSessionsForAgent lists the resumable sessions of an agent type for a project
+func ListSessionsForAgent(agent AgentType, projectPath string) ([]AgentSession, error) {
+	switch agent {
+	case AgentGemini:
+		return ListGeminiSessions(projectPath)
+	case AgentCodex:
+		return ListCodexSessions(projectPath)
+	case AgentOpenCode:
+		return ListOpenCodeSessions(projectPath)
+	case AgentAmazonQ:
+		return ListAmazonQSessions(projectPath)
+	default:
+		// Claude and others
+		return ListAgentSessions(projectPath)
+	}
+}
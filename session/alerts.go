@@ -0,0 +1,129 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// AlertEvent is an activity change that can play a sound
+type AlertEvent string
+
+const (
+	AlertWaiting  AlertEvent = "waiting"  // Agent needs input (permission prompt, question)
+	AlertFinished AlertEvent = "finished" // Agent stopped working and is idle again
+	AlertError    AlertEvent = "error"    // An error message appeared in the output
+)
+
+// AlertEvents lists the alert events in display order
+var AlertEvents = []AlertEvent{AlertWaiting, AlertFinished, AlertError}
+
+// AlertBell plays the terminal bell instead of a sound file
+const AlertBell = "bell"
+
+// AttachedAlerts configure how a window of another session that starts waiting
+// for input is announced while you are attached to a session
+type AttachedAlerts struct {
+	Bell    bool   `toml:"bell"`    // Ring the terminal bell
+	Sound   string `toml:"sound"`   // Play a sound file instead of the bell
+	Message bool   `toml:"message"` // Show a tmux message in the attached session's status line
+}
+
+// Enabled reports whether any attached alert is switched on
+func (a AttachedAlerts) Enabled() bool {
+	return a.Bell || a.Sound != "" || a.Message
+}
+
+// errorLineRe matches status lines that report a failure
+var errorLineRe = regexp.MustCompile(`(?i)\b(api error|error:|fatal:|panic:|traceback \(most recent call last\)|rate limit(ed)?|overloaded)`)
+
+// IsErrorLine reports whether an output line looks like an error message
+func IsErrorLine(line string) bool {
+	return errorLineRe.MatchString(stripANSI(line))
+}
+
+// Alert returns the sound configured for an event ("" = silent, "bell" or a sound file path)
+func (i *Instance) Alert(event AlertEvent) string {
+	return i.Alerts[event]
+}
+
+// SetAlert configures the sound for an event ("" removes it)
+func (i *Instance) SetAlert(event AlertEvent, sound string) {
+	if sound == "" {
+		delete(i.Alerts, event)
+		if len(i.Alerts) == 0 {
+			i.Alerts = nil
+		}
+		return
+	}
+	if i.Alerts == nil {
+		i.Alerts = make(map[AlertEvent]string)
+	}
+	i.Alerts[event] = sound
+}
+
+// ExpandSoundPath resolves a leading ~ in a sound file path
+func ExpandSoundPath(path string) string {
+	if strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[2:])
+		}
+	}
+	return path
+}
+
+// ValidateSoundFile checks that a sound file exists and a player is installed
+func ValidateSoundFile(path string) error {
+	if path == "" || path == AlertBell {
+		return nil
+	}
+	info, err := os.Stat(ExpandSoundPath(path))
+	if err != nil {
+		return fmt.Errorf("sound file not found: %s", path)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("not a file: %s", path)
+	}
+	if soundPlayer() == nil {
+		return fmt.Errorf("no audio player found (install paplay, pw-play, aplay or ffplay)")
+	}
+	return nil
+}
+
+// soundPlayer returns the command prefix of the first installed audio player
+func soundPlayer() []string {
+	players := [][]string{
+		{"paplay"},
+		{"pw-play"},
+		{"aplay", "-q"},
+		{"ffplay", "-nodisp", "-autoexit", "-loglevel", "quiet"},
+	}
+	if runtime.GOOS == "darwin" {
+		players = append([][]string{{"afplay"}}, players...)
+	}
+	for _, p := range players {
+		if _, err := exec.LookPath(p[0]); err == nil {
+			return p
+		}
+	}
+	return nil
+}
+
+// PlaySound plays a sound file in the background
+func PlaySound(path string) error {
+	player := soundPlayer()
+	if player == nil {
+		return fmt.Errorf("no audio player found")
+	}
+	args := append(append([]string{}, player[1:]...), ExpandSoundPath(path))
+	cmd := exec.Command(player[0], args...)
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait() // Reap the player when it finishes
+	return nil
+}
@@ -0,0 +1,454 @@
+package session
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ArchiveFormat is the version of the state archive layout
+const ArchiveFormat = 1
+
+// maxArchiveEntry bounds the size of a file read from an archive
+const maxArchiveEntry = 64 << 20
+
+// archivedFiles are the config and history files an archive carries besides the
+// shared config of sync (scripts/*.lua too); machine-local files like
+// logs and locks stay out
+var archivedFiles = []string{"prompt-history.json", "search-history.json"}
+
+// ImportMode tells what an import does with the existing state
+type ImportMode int
+
+const (
+	// ImportMerge adds the projects, sessions, groups and files the machine doesn't
+	// have; what exists on both sides keeps the local version and is reported
+	ImportMerge ImportMode = iota
+	// ImportReplace overwrites the state with the archive's
+	ImportReplace
+)
+
+// ArchiveManifest describes an archive (manifest.json)
+type ArchiveManifest struct {
+	Format         int       `json:"format"`
+	ManagerVersion string    `json:"manager_version,omitempty"`
+	Machine        string    `json:"machine,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// ArchiveReport tells what an export or import took
+type ArchiveReport struct {
+	Projects  int      // Projects written or added
+	Sessions  int      // Sessions written or added
+	Groups    int      // Groups written or added
+	Files     int      // Config files written or added
+	Conflicts []string // Differed on both sides; the local version was kept (merge)
+	Skipped   []string // Projects open in another running asmgr
+	Scripts   []string // Lua hooks left out of the import (see ImportArchive)
+}
+
+// archiveSessionsFile is one project's sessions in an archive
+type archiveSessionsFile struct {
+	Instances syncRecords `json:"instances"`
+	Groups    syncRecords `json:"groups,omitempty"`
+	Settings  *Settings   `json:"settings,omitempty"`
+}
+
+// archiveSessionsName returns the archive entry of a project's sessions ("" = default)
+func archiveSessionsName(projectID string) string {
+	if projectID == "" {
+		projectID = "default"
+	}
+	return "sessions/" + projectID + ".json"
+}
+
+// ExportArchive writes projects, sessions, groups, settings, notes and the
+// shared config files to a .tar.gz file. Paths under the home directory are
+// stored as ~/..., so the archive works on a machine with another home.
+func (s *Storage) ExportArchive(file string) (*ArchiveReport, error) {
+	f, err := os.Create(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	report := &ArchiveReport{}
+
+	add := func(name string, data []byte) error {
+		header := &tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), ModTime: time.Now()}
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+	addJSON := func(name string, v interface{}) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		return add(name, append(data, '\n'))
+	}
+
+	machine, _ := os.Hostname()
+	if err := addJSON("manifest.json", ArchiveManifest{Format: ArchiveFormat, ManagerVersion: ManagerVersion, Machine: machine, CreatedAt: time.Now()}); err != nil {
+		return nil, err
+	}
+
+	projects, err := s.LoadProjects()
+	if err != nil {
+		return nil, err
+	}
+	projectRecords, err := toRecords(projects.Projects)
+	if err != nil {
+		return nil, err
+	}
+	if err := addJSON("projects.json", syncProjectsFile{Projects: shareRecords(projectRecords, nil)}); err != nil {
+		return nil, err
+	}
+	report.Projects = len(projects.Projects)
+
+	originalProject := s.projectID
+	defer s.SetActiveProject(originalProject)
+	ids := []string{""}
+	for _, p := range projects.Projects {
+		ids = append(ids, p.ID)
+	}
+	for _, id := range ids {
+		if err := s.SetActiveProject(id); err != nil {
+			return nil, err
+		}
+		instances, groups, settings, err := s.LoadAllWithSettings()
+		if err != nil {
+			return nil, err
+		}
+		instanceRecords, err := toRecords(instances)
+		if err != nil {
+			return nil, err
+		}
+		groupRecords, err := toRecords(groups)
+		if err != nil {
+			return nil, err
+		}
+		archived := archiveSessionsFile{
+			Instances: shareRecords(instanceRecords, nil),
+			Groups:    shareRecords(groupRecords, nil),
+			Settings:  settings,
+		}
+		if err := addJSON(archiveSessionsName(id), archived); err != nil {
+			return nil, err
+		}
+		report.Sessions += len(instances)
+		report.Groups += len(groups)
+	}
+
+	for _, name := range s.archivedFileNames() {
+		data, err := os.ReadFile(filepath.Join(s.configDir, name))
+		if err != nil {
+			continue
+		}
+		if err := add("config/"+filepath.ToSlash(name), data); err != nil {
+			return nil, err
+		}
+		report.Files++
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return report, f.Close()
+}
+
+// archivedFileNames returns the config files of this machine that go into an archive
+func (s *Storage) archivedFileNames() []string {
+	names := append(append([]string{}, syncedFiles...), archivedFiles...)
+	scripts, _ := filepath.Glob(filepath.Join(s.configDir, "scripts", "*.lua"))
+	sort.Strings(scripts)
+	for _, script := range scripts {
+		names = append(names, filepath.Join("scripts", filepath.Base(script)))
+	}
+	return names
+}
+
+// readArchive returns the files of a .tar.gz archive by name
+func readArchive(file string) (map[string][]byte, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("not a .tar.gz archive: %w", err)
+	}
+	tr := tar.NewReader(gz)
+	files := make(map[string][]byte)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(header.Name)
+		if path.IsAbs(name) || strings.HasPrefix(name, "..") {
+			return nil, fmt.Errorf("unsafe path in archive: %s", header.Name)
+		}
+		data, err := io.ReadAll(io.LimitReader(tr, maxArchiveEntry+1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", name, err)
+		}
+		if len(data) > maxArchiveEntry {
+			return nil, fmt.Errorf("%s is too large", name)
+		}
+		files[name] = data
+	}
+	return files, nil
+}
+
+// ReadArchiveManifest returns the manifest of an archive
+func ReadArchiveManifest(file string) (*ArchiveManifest, error) {
+	files, err := readArchive(file)
+	if err != nil {
+		return nil, err
+	}
+	return archiveManifest(files)
+}
+
+// archiveManifest parses and checks the manifest of read archive files
+func archiveManifest(files map[string][]byte) (*ArchiveManifest, error) {
+	data, ok := files["manifest.json"]
+	if !ok {
+		return nil, fmt.Errorf("not an asmgr archive (no manifest.json)")
+	}
+	var manifest ArchiveManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("invalid manifest.json: %w", err)
+	}
+	if manifest.Format > ArchiveFormat {
+		return nil, fmt.Errorf("archive format %d is newer than this version supports (%d) - update first", manifest.Format, ArchiveFormat)
+	}
+	return &manifest, nil
+}
+
+// ImportArchive reads an archive written by ExportArchive into this machine's
+// state. Projects open in a running asmgr are skipped, as their sessions
+// would be overwritten when it saves. The archive's Lua hooks run on every
+// event once installed, so they are only imported with scripts set.
+func (s *Storage) ImportArchive(file string, mode ImportMode, scripts bool) (*ArchiveReport, error) {
+	files, err := readArchive(file)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := archiveManifest(files); err != nil {
+		return nil, err
+	}
+	report := &ArchiveReport{}
+
+	var archived syncProjectsFile
+	if data, ok := files["projects.json"]; ok {
+		if err := json.Unmarshal(data, &archived); err != nil {
+			return nil, fmt.Errorf("invalid projects.json in archive: %w", err)
+		}
+	}
+	ids, err := s.importProjects(expandRecords(archived.Projects), mode, report)
+	if err != nil {
+		return report, err
+	}
+
+	originalProject := s.projectID
+	defer s.SetActiveProject(originalProject)
+	for _, id := range append([]string{""}, ids...) {
+		data, ok := files[archiveSessionsName(id)]
+		if !ok {
+			continue
+		}
+		if locked, pid := s.IsProjectLocked(id); locked && pid != os.Getpid() {
+			report.Skipped = append(report.Skipped, s.projectName(id))
+			continue
+		}
+		var sessions archiveSessionsFile
+		if err := json.Unmarshal(data, &sessions); err != nil {
+			return report, fmt.Errorf("invalid %s in archive: %w", archiveSessionsName(id), err)
+		}
+		if err := s.importSessions(id, sessions, mode, report); err != nil {
+			return report, err
+		}
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		if strings.HasPrefix(name, "config/") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		name := strings.TrimPrefix(name, "config/")
+		if isScriptFile(name) && !scripts {
+			report.Scripts = append(report.Scripts, name)
+			continue
+		}
+		if err := s.importFile(name, files["config/"+name], mode, report); err != nil {
+			return report, err
+		}
+	}
+	return report, nil
+}
+
+// importProjects merges or replaces the project list and returns the IDs of the archive's projects
+func (s *Storage) importProjects(archived syncRecords, mode ImportMode, report *ArchiveReport) ([]string, error) {
+	for _, record := range archived {
+		if err := ValidateProjectID(recordID(record)); err != nil {
+			return nil, fmt.Errorf("projects.json in archive: %w", err)
+		}
+	}
+	local, err := s.LoadProjects()
+	if err != nil {
+		return nil, err
+	}
+	localRecords, err := toRecords(local.Projects)
+	if err != nil {
+		return nil, err
+	}
+	merged := archived
+	if mode == ImportMerge {
+		merged = mergeImported(localRecords, archived, nil, "project", report)
+	}
+	report.Projects += countChanges(localRecords, merged)
+	var projects []*Project
+	if err := fromRecords(merged, &projects); err != nil {
+		return nil, err
+	}
+	local.Projects = projects
+	if err := s.SaveProjects(local); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(archived))
+	for _, record := range archived {
+		ids = append(ids, recordID(record))
+	}
+	return ids, nil
+}
+
+// importSessions merges or replaces the sessions, groups and settings of one project
+func (s *Storage) importSessions(projectID string, archived archiveSessionsFile, mode ImportMode, report *ArchiveReport) error {
+	if err := s.SetActiveProject(projectID); err != nil {
+		return err
+	}
+	instances, groups, settings, err := s.LoadAllWithSettings()
+	if err != nil {
+		return err
+	}
+	localInstances, err := toRecords(instances)
+	if err != nil {
+		return err
+	}
+	localGroups, err := toRecords(groups)
+	if err != nil {
+		return err
+	}
+
+	// A merge keeps the local settings, unless the project has none saved yet
+	if archived.Settings != nil && (mode == ImportReplace || !fileExists(s.configPath)) {
+		settings = archived.Settings
+	}
+	mergedInstances := expandRecords(archived.Instances)
+	mergedGroups := expandRecords(archived.Groups)
+	if mode == ImportMerge {
+		mergedInstances = mergeImported(localInstances, mergedInstances, localInstanceFields, "session", report)
+		mergedGroups = mergeImported(localGroups, mergedGroups, localGroupFields, "group", report)
+	}
+	report.Sessions += countChanges(localInstances, mergedInstances)
+	report.Groups += countChanges(localGroups, mergedGroups)
+
+	var newInstances []*Instance
+	if err := fromRecords(mergedInstances, &newInstances); err != nil {
+		return err
+	}
+	for _, inst := range newInstances {
+		inst.UpdateStatus()
+	}
+	var newGroups []*Group
+	if err := fromRecords(mergedGroups, &newGroups); err != nil {
+		return err
+	}
+	return s.SaveAll(newInstances, newGroups, settings)
+}
+
+// importFile writes a config file of the archive. A merge keeps a differing
+// local file and puts the archive's version next to it as <name>.import-conflict.
+func (s *Storage) importFile(name string, data []byte, mode ImportMode, report *ArchiveReport) error {
+	if !s.isArchivedFile(name) {
+		return nil // Not written by export - never let an archive put files elsewhere
+	}
+	localPath := filepath.Join(s.configDir, filepath.FromSlash(name))
+	local, err := os.ReadFile(localPath)
+	switch {
+	case err == nil && bytes.Equal(local, data):
+		return nil
+	case err == nil && mode == ImportMerge:
+		report.Conflicts = append(report.Conflicts, fmt.Sprintf("%s: differs, the archive's version is in %s.import-conflict", name, name))
+		return writeSyncFile(localPath+".import-conflict", data, true)
+	}
+	report.Files++
+	return writeSyncFile(localPath, data, true)
+}
+
+// isArchivedFile reports whether an archive entry under config/ is one export writes
+func (s *Storage) isArchivedFile(name string) bool {
+	if isScriptFile(name) {
+		return true
+	}
+	for _, known := range append(append([]string{}, syncedFiles...), archivedFiles...) {
+		if name == known {
+			return true
+		}
+	}
+	return false
+}
+
+// isScriptFile reports whether an archive entry under config/ is a Lua hook (scripts/*.lua)
+func isScriptFile(name string) bool {
+	dir, file := path.Split(name)
+	return dir == "scripts/" && strings.HasSuffix(file, ".lua")
+}
+
+// mergeImported adds the archive's records the machine doesn't have; records
+// on both sides keep the local version, reported when they differ in more
+// than their machine-local fields
+func mergeImported(local, archived syncRecords, localFields []string, kind string, report *ArchiveReport) syncRecords {
+	localByID := make(map[string]map[string]json.RawMessage, len(local))
+	for _, record := range local {
+		localByID[recordID(record)] = record
+	}
+	merged := append(syncRecords{}, local...)
+	for _, record := range archived {
+		existing, ok := localByID[recordID(record)]
+		switch {
+		case !ok:
+			merged = append(merged, record)
+		case !sameRecord(shareRecords(syncRecords{existing}, localFields)[0], shareRecords(syncRecords{record}, localFields)[0]):
+			report.Conflicts = append(report.Conflicts, fmt.Sprintf("%s '%s': differs from the archive, kept the local one", kind, recordName(existing)))
+		}
+	}
+	return merged
+}
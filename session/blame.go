@@ -0,0 +1,174 @@
+package session
+
+import (
+	"bufio"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// HunkOrigin tells who wrote the lines a diff hunk replaces
+type HunkOrigin int
+
+const (
+	HunkNew   HunkOrigin = iota // Only adds lines
+	HunkOwn                     // Replaces lines committed during this session (agent revised its own change)
+	HunkHuman                   // Replaces lines that predate the session
+	HunkMixed                   // Replaces both
+)
+
+// hunkHeaderRe parses the old range of a hunk header ("@@ -12,5 +12,7 @@")
+var hunkHeaderRe = regexp.MustCompile(`^@@ -(\d+)(?:,\d+)? \+\d+(?:,\d+)? @@`)
+
+// blameLineRe matches a porcelain blame header ("<sha> <orig-line> <final-line> [<count>]")
+var blameLineRe = regexp.MustCompile(`^([0-9a-f]{40}) \d+ (\d+)`)
+
+// diffHunk is a hunk with the old-side line numbers it removes
+type diffHunk struct {
+	header  int   // Line index of the hunk header in the diff
+	removed []int // Old-side line numbers of removed lines
+}
+
+// HunkOrigins classifies each hunk of a diff by who wrote the lines it replaces,
+// using git blame on the diff's old side and the commits made since the session started.
+// The result is keyed by the line index of each hunk header in stats.Content.
+func (i *Instance) HunkOrigins(stats *DiffStats) map[int]HunkOrigin {
+	if stats == nil || stats.Error != nil || stats.Content == "" {
+		return nil
+	}
+	rev := stats.BaseRef
+	if rev == "" {
+		rev = "HEAD"
+	}
+	own := i.sessionCommits()
+
+	origins := make(map[int]HunkOrigin)
+	for file, hunks := range parseDiffHunks(stats.Content) {
+		var ranges []string
+		for _, h := range hunks {
+			// Removed lines are ascending - blame each consecutive run once
+			for start := 0; start < len(h.removed); {
+				end := start
+				for end+1 < len(h.removed) && h.removed[end+1] == h.removed[end]+1 {
+					end++
+				}
+				ranges = append(ranges, "-L", strconv.Itoa(h.removed[start])+","+strconv.Itoa(h.removed[end]))
+				start = end + 1
+			}
+		}
+		var authors map[int]string
+		if file != "" && len(ranges) > 0 {
+			authors = i.blameLines(rev, file, ranges)
+		}
+
+		for _, h := range hunks {
+			origin := HunkNew
+			for _, line := range h.removed {
+				sha, ok := authors[line]
+				if !ok {
+					continue // Not blamed (renamed or unreadable file)
+				}
+				lineOrigin := HunkHuman
+				if own[sha] {
+					lineOrigin = HunkOwn
+				}
+				switch {
+				case origin == HunkNew:
+					origin = lineOrigin
+				case origin != lineOrigin:
+					origin = HunkMixed
+				}
+			}
+			origins[h.header] = origin
+		}
+	}
+	return origins
+}
+
+// parseDiffHunks groups the hunks of a unified diff by old file path ("" for new files)
+func parseDiffHunks(diff string) map[string][]diffHunk {
+	files := make(map[string][]diffHunk)
+	var file string
+	var current *diffHunk
+	oldLine := 0
+
+	flush := func() {
+		if current != nil {
+			files[file] = append(files[file], *current)
+			current = nil
+		}
+	}
+
+	for idx, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git"):
+			flush()
+			file = ""
+		case strings.HasPrefix(line, "--- ") && current == nil:
+			file = strings.TrimPrefix(line, "--- ")
+			if file == "/dev/null" {
+				file = ""
+			} else {
+				file = strings.TrimPrefix(file, "a/")
+			}
+		case strings.HasPrefix(line, "@@"):
+			flush()
+			m := hunkHeaderRe.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			oldLine, _ = strconv.Atoi(m[1])
+			current = &diffHunk{header: idx}
+		case current == nil:
+			continue
+		case strings.HasPrefix(line, "-"):
+			current.removed = append(current.removed, oldLine)
+			oldLine++
+		case strings.HasPrefix(line, " "):
+			oldLine++
+		}
+	}
+	flush()
+	return files
+}
+
+// blameLines returns the commit that last changed each requested line of a file at rev
+func (i *Instance) blameLines(rev, file string, ranges []string) map[int]string {
+	args := append([]string{"-C", i.Path, "blame", "--porcelain"}, ranges...)
+	args = append(args, rev, "--", file)
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil
+	}
+
+	authors := make(map[int]string)
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		if m := blameLineRe.FindStringSubmatch(scanner.Text()); m != nil {
+			line, _ := strconv.Atoi(m[2])
+			authors[line] = m[1]
+		}
+	}
+	return authors
+}
+
+// sessionCommits returns the commits made since the session started
+func (i *Instance) sessionCommits() map[string]bool {
+	args := []string{"-C", i.Path, "rev-list"}
+	if i.BaseCommitSHA != "" {
+		args = append(args, i.BaseCommitSHA+"..HEAD")
+	} else {
+		args = append(args, "--since="+i.CreatedAt.Format("2006-01-02 15:04:05 -0700"), "HEAD")
+	}
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil
+	}
+	commits := make(map[string]bool)
+	for _, sha := range strings.Fields(string(out)) {
+		commits[sha] = true
+	}
+	return commits
+}
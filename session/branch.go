@@ -0,0 +1,42 @@
+package session
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// CurrentBranch returns the checked out branch of the repo at path: the short
+// commit hash when HEAD is detached, "" outside a git repository
+func CurrentBranch(path string) string {
+	if branch := commandLine(exec.Command("git", "-C", path, "symbolic-ref", "--short", "-q", "HEAD")); branch != "" {
+		return branch
+	}
+	if commit := commandLine(exec.Command("git", "-C", path, "rev-parse", "--short", "HEAD")); commit != "" {
+		return "@" + commit
+	}
+	return ""
+}
+
+// Branches returns the local branches of the repo at path, most recently committed first
+func Branches(path string) ([]string, error) {
+	out, err := exec.Command("git", "-C", path, "for-each-ref", "--sort=-committerdate", "--format=%(refname:short)", "refs/heads").Output()
+	if err != nil {
+		return nil, fmt.Errorf("not a git repository: %s", path)
+	}
+	var branches []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line != "" {
+			branches = append(branches, line)
+		}
+	}
+	return branches, nil
+}
+
+// CheckoutBranch checks out a local branch in the repo at path
+func CheckoutBranch(path, branch string) error {
+	if out, err := exec.Command("git", "-C", path, "checkout", branch).CombinedOutput(); err != nil {
+		return fmt.Errorf("git checkout %s failed: %s", branch, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
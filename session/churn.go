@@ -0,0 +1,46 @@
+package session
+
+// Churn is how much a session changed its repository since it started
+type Churn struct {
+	Files   int
+	Added   int
+	Removed int
+	Err     error // Not a git repository or git failed
+}
+
+// Lines returns the lines added and removed together
+func (c Churn) Lines() int {
+	return c.Added + c.Removed
+}
+
+// Churn sums the changed files of the session (see ChangedFiles)
+func (i *Instance) Churn() Churn {
+	files, err := i.ChangedFiles()
+	if err != nil {
+		return Churn{Err: err}
+	}
+	c := Churn{Files: len(files)}
+	for _, f := range files {
+		c.Added += f.Added
+		c.Removed += f.Removed
+	}
+	return c
+}
+
+// ChurnTotal sums the churn of sessions (Instance ID -> churn). Sessions working
+// in the same directory see the same changes, so each directory counts once.
+func ChurnTotal(instances []*Instance, churn map[string]Churn) Churn {
+	var total Churn
+	seen := make(map[string]bool)
+	for _, inst := range instances {
+		c, ok := churn[inst.ID]
+		if !ok || c.Err != nil || seen[inst.Path] {
+			continue
+		}
+		seen[inst.Path] = true
+		total.Files += c.Files
+		total.Added += c.Added
+		total.Removed += c.Removed
+	}
+	return total
+}
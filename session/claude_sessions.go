@@ -51,7 +51,11 @@ func GetClaudeProjectDir(projectPath string) string {
 	if err == nil {
 		projectPath = realPath
 	}
+	return filepath.Join(homeDir, ".claude", "projects", claudeDirName(projectPath))
+}
 
+// claudeDirName converts a path to the name of Claude's project directory for it
+func claudeDirName(projectPath string) string {
 	// Convert path to Claude's format: /home/user/project_name -> -home-user-project-name
 	// Claude replaces: / -> -, _ -> -, space -> -, and accented chars -> -
 	var result strings.Builder
@@ -70,7 +74,7 @@ func GetClaudeProjectDir(projectPath string) string {
 		sanitized = sanitized[1:] // Remove leading dash
 	}
 	sanitized = "-" + sanitized // Add back the leading dash Claude uses
-	return filepath.Join(homeDir, ".claude", "projects", sanitized)
+	return sanitized
 }
 
 func ListAgentSessions(projectPath string) ([]AgentSession, error) {
@@ -0,0 +1,87 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// MaxRecentlyClosed is how many closed sessions are kept for reopening
+const MaxRecentlyClosed = 20
+
+// ClosedSession is an archived snapshot of a stopped or deleted session
+type ClosedSession struct {
+	Instance Instance  `json:"instance"`
+	ResumeID string    `json:"resume_id,omitempty"` // Conversation to resume on reopen
+	Deleted  bool      `json:"deleted,omitempty"`   // Session was deleted (not just stopped)
+	ClosedAt time.Time `json:"closed_at"`
+}
+
+// closedPath returns the recently-closed file of the active project
+func (s *Storage) closedPath() string {
+	return filepath.Join(filepath.Dir(s.configPath), "recently_closed.json")
+}
+
+// LoadClosed returns the recently closed sessions, most recent last
+func (s *Storage) LoadClosed() ([]ClosedSession, error) {
+	data, err := os.ReadFile(s.closedPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read recently closed sessions: %w", err)
+	}
+	var closed []ClosedSession
+	if err := json.Unmarshal(data, &closed); err != nil {
+		return nil, fmt.Errorf("failed to parse recently closed sessions: %w", err)
+	}
+	return closed, nil
+}
+
+// saveClosed writes the recently closed stack
+func (s *Storage) saveClosed(closed []ClosedSession) error {
+	data, err := json.MarshalIndent(closed, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal recently closed sessions: %w", err)
+	}
+	return os.WriteFile(s.closedPath(), data, 0644)
+}
+
+// PushClosed archives a session that was just stopped or deleted
+func (s *Storage) PushClosed(inst *Instance, deleted bool) error {
+	if projectID, ok := s.routeOf(inst.ID); ok {
+		return s.inProject(projectID, func() error { return s.PushClosed(inst, deleted) })
+	}
+	closed, _ := s.LoadClosed()
+
+	// Remember which conversation to resume, even if it was never picked explicitly
+	resumeID := inst.LastResumeID()
+
+	// A session appears only once, with its latest state
+	kept := closed[:0]
+	for _, c := range closed {
+		if c.Instance.ID != inst.ID {
+			kept = append(kept, c)
+		}
+	}
+	kept = append(kept, ClosedSession{Instance: *inst, ResumeID: resumeID, Deleted: deleted, ClosedAt: time.Now()})
+	if len(kept) > MaxRecentlyClosed {
+		kept = kept[len(kept)-MaxRecentlyClosed:]
+	}
+	return s.saveClosed(kept)
+}
+
+// PopClosed removes and returns the most recently closed session (nil if none)
+func (s *Storage) PopClosed() (*ClosedSession, error) {
+	closed, err := s.LoadClosed()
+	if err != nil || len(closed) == 0 {
+		return nil, err
+	}
+	last := closed[len(closed)-1]
+	if err := s.saveClosed(closed[:len(closed)-1]); err != nil {
+		return nil, err
+	}
+	return &last, nil
+}
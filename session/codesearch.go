@@ -0,0 +1,129 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// CodeSearchTimeout bounds one ripgrep run in a session's directory
+const CodeSearchTimeout = 30 * time.Second
+
+// MaxCodeMatches is how many matches are kept per repository (the rest are only counted)
+const MaxCodeMatches = 50
+
+// DefaultFixPrompt is the prompt template offered for sending the matches to the agents
+const DefaultFixPrompt = "Update the {{count}} matches of `{{pattern}}` in this repository: {{matches}}"
+
+// CodeSearchVars lists the placeholders a fix prompt can use besides the usual ones
+var CodeSearchVars = []string{"pattern", "count", "files", "matches"}
+
+// CodeMatch is a line ripgrep found
+type CodeMatch struct {
+	File string // Relative to the searched directory
+	Line int
+	Text string
+}
+
+// RepoMatches are the matches of a search in one directory
+type RepoMatches struct {
+	Path    string
+	Matches []CodeMatch // The first MaxCodeMatches
+	Total   int         // All matching lines
+	Err     error       // rg failed (bad pattern, not installed)
+}
+
+// Files returns the distinct files with kept matches, in order
+func (r RepoMatches) Files() []string {
+	var files []string
+	seen := make(map[string]bool)
+	for _, m := range r.Matches {
+		if !seen[m.File] {
+			seen[m.File] = true
+			files = append(files, m.File)
+		}
+	}
+	return files
+}
+
+// SearchRepo runs ripgrep for pattern (a regex, smart case, .gitignore respected) in dir
+func SearchRepo(dir, pattern string) RepoMatches {
+	result := RepoMatches{Path: dir}
+	ctx, cancel := context.WithTimeout(context.Background(), CodeSearchTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "rg", "--line-number", "--no-heading", "--null", "--color=never",
+		"--smart-case", "--max-columns=300", "--max-columns-preview", "-e", pattern, ".")
+	cmd.Dir = dir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		switch {
+		case errors.Is(err, exec.ErrNotFound):
+			result.Err = fmt.Errorf("ripgrep (rg) is not installed")
+			return result
+		case errors.As(err, &exitErr) && exitErr.ExitCode() == 1:
+			return result // No matches
+		case len(out) == 0:
+			// Exit code 2 with output means some files could not be read
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				err = errors.New(strings.Join(strings.Fields(msg), " "))
+			}
+			result.Err = err
+			return result
+		}
+	}
+
+	for _, line := range strings.Split(strings.TrimRight(string(out), "\n"), "\n") {
+		// file\0line:text
+		file, rest, ok := strings.Cut(line, "\x00")
+		if !ok {
+			continue
+		}
+		num, text, ok := strings.Cut(rest, ":")
+		if !ok {
+			continue
+		}
+		n, err := strconv.Atoi(num)
+		if err != nil {
+			continue
+		}
+		result.Total++
+		if len(result.Matches) < MaxCodeMatches {
+			result.Matches = append(result.Matches, CodeMatch{File: strings.TrimPrefix(file, "./"), Line: n, Text: strings.TrimSpace(text)})
+		}
+	}
+	return result
+}
+
+// FixPrompt resolves the session's placeholders of a fix prompt template, then
+// fills the search placeholders with a repository's matches (matched code is
+// inserted last so braces in it are never taken for placeholders)
+func (i *Instance) FixPrompt(template, pattern string, repo RepoMatches) string {
+	template, _ = i.ResolvePrompt(template)
+	var matches strings.Builder
+	for _, m := range repo.Matches {
+		fmt.Fprintf(&matches, "\n- %s:%d: %s", m.File, m.Line, m.Text)
+	}
+	if more := repo.Total - len(repo.Matches); more > 0 {
+		fmt.Fprintf(&matches, "\n- ... and %d more (rg -e '%s')", more, pattern)
+	}
+	values := map[string]string{
+		"pattern": pattern,
+		"count":   strconv.Itoa(repo.Total),
+		"files":   strings.Join(repo.Files(), ", "),
+		"matches": matches.String(),
+	}
+	return templateVarRe.ReplaceAllStringFunc(template, func(match string) string {
+		if value, ok := values[templateVarRe.FindStringSubmatch(match)[1]]; ok {
+			return value
+		}
+		return match
+	})
+}
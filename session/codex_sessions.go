@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 )
 
 // codexSessionMeta represents the first line of a Codex JSONL session file
@@ -20,8 +21,9 @@ type codexSessionMeta struct {
 
 // codexMessage represents a user message in the Codex JSONL session file
 type codexMessage struct {
-	Type    string `json:"type"`
-	Payload struct {
+	Type      string `json:"type"`
+	Timestamp string `json:"timestamp"`
+	Payload   struct {
 		Type    string `json:"type"`
 		Role    string `json:"role"`
 		Content []struct {
@@ -45,6 +47,8 @@ func ListCodexSessions(projectPath string) ([]AgentSession, error) {
 	}
 
 	var sessions []AgentSession
+	projectKey := PathKey(projectPath)
+	cwdKeys := make(map[string]string) // Raw CWD -> canonical path
 
 	// Walk through ~/.codex/sessions/YYYY/MM/DD/*.jsonl files
 	err = filepath.Walk(sessionDir, func(path string, info os.FileInfo, err error) error {
@@ -71,20 +75,15 @@ func ListCodexSessions(projectPath string) ([]AgentSession, error) {
 		// Filter by CWD using path hierarchy matching
 		// Accept if: CWD matches exactly, or CWD is ancestor of projectPath, or projectPath is ancestor of CWD
 		if projectPath != "" && cwd != "" {
-			// Normalize paths (add trailing slash for comparison)
-			normalizedCWD := cwd
-			if !strings.HasSuffix(normalizedCWD, "/") {
-				normalizedCWD += "/"
-			}
-			normalizedProject := projectPath
-			if !strings.HasSuffix(normalizedProject, "/") {
-				normalizedProject += "/"
+			// Compare canonical paths so symlinked or differently cased aliases match
+			cwdKey, ok := cwdKeys[cwd]
+			if !ok {
+				cwdKey = PathKey(cwd)
+				cwdKeys[cwd] = cwdKey
 			}
 
 			// Check if paths are related (one is ancestor of the other)
-			if cwd != projectPath &&
-			   !strings.HasPrefix(normalizedProject, normalizedCWD) &&
-			   !strings.HasPrefix(normalizedCWD, normalizedProject) {
+			if !pathWithin(cwdKey, projectKey) && !pathWithin(projectKey, cwdKey) {
 				return nil // Skip sessions from unrelated directories
 			}
 		}
@@ -178,3 +177,108 @@ func parseCodexSession(path string) (sessionID, firstPrompt, cwd string) {
 
 	return sessionID, firstPrompt, cwd
 }
+
+// codexSessionsDir returns the directory holding Codex rollout files
+func codexSessionsDir() string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".codex", "sessions")
+}
+
+// readCodexRollout reads the session ID, CWD and conversation of a Codex rollout file
+func readCodexRollout(path string) (sessionID, cwd string, messages []ConversationMessage) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", "", nil
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, 4*1024*1024)
+
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if sessionID == "" {
+			var meta codexSessionMeta
+			if err := json.Unmarshal(line, &meta); err == nil && meta.Type == "session_meta" {
+				sessionID = meta.Payload.ID
+				cwd = meta.Payload.CWD
+				continue
+			}
+		}
+
+		var msg codexMessage
+		if err := json.Unmarshal(line, &msg); err != nil {
+			continue
+		}
+		if msg.Type != "response_item" || msg.Payload.Type != "message" {
+			continue
+		}
+		if msg.Payload.Role != "user" && msg.Payload.Role != "assistant" {
+			continue
+		}
+		var texts []string
+		for _, content := range msg.Payload.Content {
+			if content.Text == "" {
+				continue
+			}
+			// Skip AGENTS.md instructions and environment context
+			if strings.HasPrefix(content.Text, "# AGENTS.md") || strings.HasPrefix(content.Text, "<environment_context>") {
+				continue
+			}
+			texts = append(texts, content.Text)
+		}
+		if len(texts) == 0 {
+			continue
+		}
+		ts := time.Now()
+		if parsed, err := time.Parse(time.RFC3339, msg.Timestamp); err == nil {
+			ts = parsed
+		}
+		messages = append(messages, ConversationMessage{
+			Role:      msg.Payload.Role,
+			Content:   strings.Join(texts, "\n"),
+			Timestamp: ts,
+		})
+	}
+	return sessionID, cwd, messages
+}
+
+// parseCodexHistory indexes the user prompts of all Codex rollout files
+func (h *HistoryIndex) parseCodexHistory() []HistoryEntry {
+	var entries []HistoryEntry
+	sessionDir := codexSessionsDir()
+	if sessionDir == "" {
+		return entries
+	}
+
+	// Walk through ~/.codex/sessions/YYYY/MM/DD/*.jsonl files
+	filepath.Walk(sessionDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(path, ".jsonl") {
+			return nil
+		}
+		sessionID, cwd, messages := readCodexRollout(path)
+		if sessionID == "" {
+			return nil
+		}
+		for _, msg := range messages {
+			if msg.Role != "user" {
+				continue
+			}
+			entries = append(entries, HistoryEntry{
+				ID:          generateHistoryID(),
+				Agent:       AgentCodex,
+				Content:     msg.Content,
+				Path:        cwd,
+				Timestamp:   msg.Timestamp,
+				SessionFile: path,
+				SessionID:   sessionID,
+			})
+		}
+		return nil
+	})
+	return entries
+}
@@ -0,0 +1,254 @@
+package session
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// agentCommand builds the shell command that launches the agent and the binary to check for
+func (i *Instance) agentCommand(resumeID string) (agentCmd string, cmdToCheck string) {
+	config := i.GetAgentConfig()
+
+	if i.Agent == AgentCustom {
+		// Use custom command directly
+		agentCmd = i.CustomCommand
+		// Extract the base command (first word) to check
+		parts := strings.Fields(i.CustomCommand)
+		if len(parts) > 0 {
+			cmdToCheck = parts[0]
+		}
+	} else {
+		cmdToCheck = config.Command
+
+		// Explicit resume ID wins over the stored one
+		if resumeID == "" {
+			resumeID = i.ResumeSessionID
+		}
+		agentCmd = agentLaunchCommand(config, i.autoYesEnabled(i.AutoYes), resumeID) + i.agentFlags(i.Agent, i.Model) + launchFlagsSuffix(i.sandboxedFlags(i.LaunchFlags))
+	}
+
+	// Container sandboxes run the agent from the image, not the host
+	if w, ok := FindSandboxWrapper(i.Sandbox); ok && w.InContainer() {
+		cmdToCheck = w.Binary()
+	}
+	return i.sandboxCommand(agentCmd), cmdToCheck
+}
+
+// agentLaunchCommand builds the command that runs an agent, optionally resuming a conversation
+func agentLaunchCommand(config AgentConfig, autoYes bool, resumeID string) string {
+	args := []string{}
+	yoloFlag := autoYes && config.SupportsAutoYes && config.AutoYesFlag != ""
+
+	// Handle resume subcommands (codex resume, q chat --resume) vs flags (claude --resume)
+	if config.SupportsResume && config.ResumeIsSubcommand && resumeID != "" {
+		// Resume is a subcommand - put it first, then flags, then session ID
+		args = append(args, config.ResumeFlag)
+		if yoloFlag {
+			args = append(args, config.AutoYesFlag)
+		}
+		args = append(args, resumeID)
+	} else {
+		// Resume is a flag - add auto-yes first, then resume flag
+		if yoloFlag {
+			args = append(args, config.AutoYesFlag)
+		}
+		if config.SupportsResume && !config.ResumeIsSubcommand && config.ResumeFlag != "" && resumeID != "" {
+			args = append(args, config.ResumeFlag, resumeID)
+		}
+	}
+
+	if len(args) == 0 {
+		return config.Command
+	}
+	return config.Command + " " + strings.Join(args, " ")
+}
+
+// resumeSupported reports whether agentCommand passes a resume ID to this agent
+func (i *Instance) resumeSupported() bool {
+	if i.Agent == AgentCustom {
+		return false
+	}
+	config := i.GetAgentConfig()
+	return config.SupportsResume && (config.ResumeIsSubcommand || config.ResumeFlag != "")
+}
+
+// newSessionArgs returns the tmux arguments that create the session running agentCmd.
+// tmux sizes a pane's scrollback when the pane is created, so the global
+// history-limit is raised around new-session for window 0 and then restored.
+func (i *Instance) newSessionArgs(agentCmd string) []string {
+	limit := strconv.Itoa(i.EffectiveHistoryLimit())
+	args := []string{
+		"set-option", "-g", "history-limit", limit, ";",
+		"new-session", "-d", "-s", i.TmuxSessionName(), "-c", i.Path,
+	}
+	args = append(args, i.envArgs(nil)...)
+	return append(args, agentCmd, ";",
+		"set-option", "-g", "history-limit", globalHistoryLimit(),
+	)
+}
+
+// StatusPopupKey is the key after the tmux prefix that opens the `asmgr status --popup` overview
+const StatusPopupKey = "a"
+
+// sessionSetupArgs returns the tmux commands run right after a session is created
+func (i *Instance) sessionSetupArgs() [][]string {
+	sessionName := i.TmuxSessionName()
+	return [][]string{
+		// Better scrolling (the session option sizes the scrollback of new tabs)
+		{"set-option", "-t", sessionName, "history-limit", strconv.Itoa(i.EffectiveHistoryLimit())},
+		{"set-option", "-t", sessionName, "mouse", "on"},
+
+		// Use latest client size and aggressive resize for proper terminal following
+		{"set-option", "-t", sessionName, "window-size", "latest"},
+		{"set-option", "-t", sessionName, "aggressive-resize", "on"},
+
+		// Enable xterm keys for Shift+PageUp/Down support
+		{"set-option", "-t", sessionName, "-g", "xterm-keys", "on"},
+
+		// Set terminal overrides for better key support
+		{"set-option", "-t", sessionName, "-ga", "terminal-overrides", ",xterm*:smcup@:rmcup@"},
+
+		// Bind Shift+PageUp/Down for scrolling in copy mode
+		{"bind-key", "-T", "root", "S-PageUp", "copy-mode", "-eu"},
+		{"bind-key", "-T", "root", "S-PageDown", "send-keys", "PageDown"},
+		{"bind-key", "-T", "copy-mode-vi", "S-PageUp", "send-keys", "-X", "page-up"},
+		{"bind-key", "-T", "copy-mode-vi", "S-PageDown", "send-keys", "-X", "page-down"},
+
+		// Bind Ctrl+Y for yolo mode toggle (passes both session name and window index)
+		{"bind-key", "-n", "C-y", "run-shell", `asmgr yolo "$(tmux display-message -p '#{session_name}')" "$(tmux display-message -p '#{window_index}')" 2>/dev/null`},
+
+		// Prefix+a shows the other sessions in a popup without detaching (tmux 3.2+)
+		{"bind-key", StatusPopupKey, "display-popup", "-E", "-w", "80%", "-h", "70%", `asmgr status --popup '#{session_name}'`},
+
+		// Ctrl+q will be set up with resize in UpdateDetachBinding
+
+		// Set window 0 name to agent type (session name is shown in status bar)
+		{"rename-window", "-t", sessionName + ":0", i.WindowName()},
+	}
+}
+
+// followedWindowArgs returns the tmux arguments that recreate a followed window (tab)
+// with its name, agent, auto-yes flag and conversation. The new window's index is printed.
+func (i *Instance) followedWindowArgs(fw FollowedWindow) []string {
+	args := []string{"new-window", "-P", "-F", "#{window_index}", "-t", i.TmuxSessionName(), "-c", i.Path, "-n", fw.Name}
+	args = append(args, i.envArgs(fw.Env)...)
+
+	if fw.Agent == AgentTerminal {
+		// Terminal window - just create empty shell
+		return args
+	}
+
+	// Agent window - build agent command
+	var agentCmd string
+	if fw.Agent == AgentCustom {
+		agentCmd = fw.CustomCommand
+	} else {
+		agentCmd = agentLaunchCommand(AgentConfigs[fw.Agent], i.autoYesEnabled(fw.AutoYes), fw.ResumeSessionID) + i.agentFlags(fw.Agent, fw.Model) + launchFlagsSuffix(i.sandboxedFlags(fw.LaunchFlags))
+	}
+	return append(args, i.sandboxCommand(agentCmd))
+}
+
+// StartCommandLines returns the command lines StartWithResume would execute, for dry-run display
+func (i *Instance) StartCommandLines(resumeID string) []string {
+	agentCmd, _ := i.agentCommand(resumeID)
+
+	lines := []string{i.commandLine(i.newSessionArgs(agentCmd))}
+	for _, args := range i.sessionSetupArgs() {
+		lines = append(lines, i.commandLine(args))
+	}
+	for _, fw := range i.FollowedWindows {
+		lines = append(lines, i.commandLine(i.followedWindowArgs(fw)))
+	}
+	if i.MainSplit != "" {
+		lines = append(lines, i.commandLine(i.splitWindowArgs(0, i.MainSplit)))
+	}
+	for _, fw := range i.FollowedWindows {
+		if fw.Split != "" {
+			lines = append(lines, i.commandLine(i.splitWindowArgs(fw.Index, fw.Split)))
+		}
+	}
+	return lines
+}
+
+// StopCommandLines returns the command lines Stop would execute, for dry-run display
+func (i *Instance) StopCommandLines() []string {
+	return []string{i.commandLine([]string{"kill-session", "-t", i.TmuxSessionName()})}
+}
+
+// commandLine formats tmux arguments as the shell command that runs them for the session
+func (i *Instance) commandLine(args []string) string {
+	line := tmuxCommandLine(maskEnvArgs(args))
+	if i.IsRemote() {
+		return "ssh " + ShellQuote(i.Host) + " " + ShellQuote(line)
+	}
+	return line
+}
+
+// tmuxCommandLine formats tmux arguments as a copy-pasteable shell command
+func tmuxCommandLine(args []string) string {
+	quoted := make([]string, 0, len(args)+1)
+	quoted = append(quoted, "tmux")
+	for _, arg := range args {
+		quoted = append(quoted, ShellQuote(arg))
+	}
+	return strings.Join(quoted, " ")
+}
+
+// SplitCommandLine splits a line into words honoring single/double quotes and backslashes
+func SplitCommandLine(line string) ([]string, error) {
+	var args []string
+	var cur strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+
+	for _, r := range line {
+		switch {
+		case escaped:
+			cur.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+			inWord = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				cur.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == ' ' || r == '\t':
+			if inWord {
+				args = append(args, cur.String())
+				cur.Reset()
+				inWord = false
+			}
+		default:
+			cur.WriteRune(r)
+			inWord = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated quote")
+	}
+	if inWord {
+		args = append(args, cur.String())
+	}
+	return args, nil
+}
+
+// ShellQuote quotes a string for POSIX shells when it contains special characters
+func ShellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+	if strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:=@%+,", r))
+	}) == -1 {
+		return s
+	}
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(s, "'", `'\''`))
+}
@@ -0,0 +1,170 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+)
+
+// Config holds the user's defaults from config.toml
+type Config struct {
+	DefaultAgent     AgentType               `toml:"default_agent"`         // Preselected agent for new sessions and tabs
+	PathRoots        []string                `toml:"path_roots"`            // Directories relative project paths are looked up in
+	Theme            string                  `toml:"theme"`                 // Accent color: a theme name or #rrggbb
+	CompactMode      *bool                   `toml:"compact_mode"`          // Compact list for projects without saved settings
+	Keys             map[string]KeyList      `toml:"keys"`                  // Action name -> keys
+	Limits           Limits                  `toml:"limits"`                // Soft limits on session counts
+	Attached         AttachedAlerts          `toml:"attached_alerts"`       // Alerts for other sessions while attached
+	HistoryLimit     int                     `toml:"history_limit"`         // Scrollback lines per window (0 = DefaultHistoryLimit)
+	NetworkIndicator bool                    `toml:"network_indicator"`     // Show whether busy agents are talking to their API (Linux)
+	PresetsURL       string                  `toml:"presets_url"`           // Presets gallery to fetch instead of the bundled one
+	ColorMode        string                  `toml:"color_mode"`            // auto, truecolor, 256, 16 or none
+	IdleStopMinutes  int                     `toml:"idle_stop_minutes"`     // Stop sessions idle this long (0 = never)
+	PreviewFilters   map[string][]FilterRule `toml:"preview_filters"`       // Preview filter pipelines by name
+	AgentFilters     map[AgentType]string    `toml:"agent_preview_filters"` // Agent -> default preview filter
+	Profiles         map[string]AgentProfile `toml:"profiles"`              // Named agent launch setups
+	Sync             *SyncConfig             `toml:"sync"`                  // Sync with other machines (nil = not set up)
+	Prices           map[string]ModelPrice   `toml:"prices"`                // Model name prefix -> price overriding or adding to the built-in ones
+	Presets          []Preset                `toml:"presets"`               // Presets installed from the gallery
+}
+
+// KeyList is the keys of an action in config.toml: one key or an array of keys
+type KeyList []string
+
+// UnmarshalTOML accepts a single key or an array of keys
+func (k *KeyList) UnmarshalTOML(value interface{}) error {
+	switch v := value.(type) {
+	case string:
+		*k = KeyList{v}
+	case []interface{}:
+		keys := make(KeyList, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return fmt.Errorf("keys must be strings, got %v", item)
+			}
+			keys = append(keys, s)
+		}
+		*k = keys
+	default:
+		return fmt.Errorf("expected a key or an array of keys, got %v", value)
+	}
+	return nil
+}
+
+// ConfigPath returns the path of the user config file
+func ConfigPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "agent-session-manager", "config.toml")
+}
+
+// LoadConfig reads config.toml (an empty config when missing)
+func LoadConfig() (*Config, error) {
+	config := &Config{}
+	data, err := os.ReadFile(ConfigPath())
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+	meta, err := toml.Decode(string(data), config)
+	if err != nil {
+		return &Config{}, fmt.Errorf("invalid %s: %w", filepath.Base(ConfigPath()), err)
+	}
+	if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+		return &Config{}, fmt.Errorf("unknown setting '%s' in %s", undecoded[0], filepath.Base(ConfigPath()))
+	}
+	if config.Limits.MaxRunning < 0 || config.Limits.MaxPerProject < 0 || config.HistoryLimit < 0 || config.IdleStopMinutes < 0 {
+		return &Config{}, fmt.Errorf("negative limit in %s", filepath.Base(ConfigPath()))
+	}
+	if config.DefaultAgent != "" {
+		if _, ok := AgentConfigs[config.DefaultAgent]; !ok || config.DefaultAgent == AgentTerminal {
+			return &Config{}, fmt.Errorf("unknown default_agent '%s' in %s", config.DefaultAgent, filepath.Base(ConfigPath()))
+		}
+	}
+	if err := config.compilePreviewFilters(); err != nil {
+		return &Config{}, err
+	}
+	if err := config.validateProfiles(); err != nil {
+		return &Config{}, fmt.Errorf("%w in %s", err, filepath.Base(ConfigPath()))
+	}
+	if config.Sync != nil {
+		if err := config.Sync.validate(); err != nil {
+			return &Config{}, fmt.Errorf("%w in %s", err, filepath.Base(ConfigPath()))
+		}
+	}
+	return config, nil
+}
+
+// writeConfigTables replaces the tables of key in config.toml ([key], [key.*],
+// [[key]] and their sub-tables) with value encoded as TOML. The rest of the
+// file, comments included, stays as the user wrote it.
+func writeConfigTables(key string, value interface{}) error {
+	path := ConfigPath()
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	var kept []string
+	inKey, inString := false, false
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		// Lines of multi-line strings are never table headers
+		if !inString && strings.HasPrefix(strings.TrimSpace(line), "[") {
+			header := strings.Trim(strings.TrimSpace(strings.SplitN(line, "#", 2)[0]), "[] ")
+			inKey = header == key || strings.HasPrefix(header, key+".")
+		}
+		if (strings.Count(line, `"""`)+strings.Count(line, "'''"))%2 == 1 {
+			inString = !inString
+		}
+		if !inKey {
+			kept = append(kept, line)
+		}
+	}
+
+	var tables strings.Builder
+	encoder := toml.NewEncoder(&tables)
+	encoder.Indent = ""
+	if err := encoder.Encode(value); err != nil {
+		return fmt.Errorf("failed to encode %s: %w", key, err)
+	}
+	text := strings.TrimSpace(strings.Join(kept, "\n"))
+	if encoded := strings.TrimSpace(tables.String()); encoded != "" {
+		if text != "" {
+			text += "\n\n"
+		}
+		text += encoded
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, []byte(text+"\n"), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// ResolvePath expands ~ and looks a relative path up in the path roots: the
+// first root containing it wins, otherwise the path is returned unchanged.
+// Paths starting with "." stay relative to the working directory.
+func (c *Config) ResolvePath(path string) string {
+	if _, _, ok := SplitRemotePath(path); ok {
+		return strings.TrimSpace(path) // Resolved on the host
+	}
+	path = expandTilde(strings.TrimSpace(path))
+	if c == nil || path == "" || filepath.IsAbs(path) || strings.HasPrefix(path, ".") {
+		return path
+	}
+	for _, root := range c.PathRoots {
+		candidate := filepath.Join(expandTilde(root), path)
+		if info, err := os.Stat(candidate); err == nil && info.IsDir() {
+			return candidate
+		}
+	}
+	return path
+}
@@ -0,0 +1,232 @@
+package session
+
+import (
+	"fmt"
+	"html"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// windowResumeID returns the conversation a window resumed ("" if none)
+func (i *Instance) windowResumeID(windowIdx int) string {
+	if windowIdx == 0 {
+		return i.ResumeSessionID
+	}
+	for _, fw := range i.FollowedWindows {
+		if fw.Index == windowIdx {
+			return fw.ResumeSessionID
+		}
+	}
+	return ""
+}
+
+// ConversationEntry finds the transcript of a window's Claude or Gemini
+// conversation: the one it resumed, otherwise the newest in its directory
+func (i *Instance) ConversationEntry(windowIdx int) (*HistoryEntry, error) {
+	agent := i.WindowAgent(windowIdx)
+	resumeID := i.windowResumeID(windowIdx)
+	entry := &HistoryEntry{Agent: agent, Path: i.Path, SessionID: resumeID}
+
+	switch agent {
+	case AgentClaude:
+		claudeDir := GetClaudeProjectDir(i.Path)
+		if resumeID != "" {
+			if path := filepath.Join(claudeDir, resumeID+".jsonl"); fileExists(path) {
+				entry.SessionFile = path
+			}
+		}
+		if entry.SessionFile == "" {
+			entry.SessionFile = newestClaudeTranscript(claudeDir)
+			entry.SessionID = strings.TrimSuffix(filepath.Base(entry.SessionFile), ".jsonl")
+		}
+	case AgentGemini:
+		chats := geminiChatsDir(i.Path)
+		dirEntries, _ := os.ReadDir(chats)
+		var newestMod int64
+		for _, de := range dirEntries {
+			info, err := de.Info()
+			if err != nil || !strings.HasPrefix(de.Name(), "session-") || !strings.HasSuffix(de.Name(), ".json") {
+				continue
+			}
+			path := filepath.Join(chats, de.Name())
+			if resumeID != "" && cachedTranscript(path, info, parseGeminiUsage).id == resumeID {
+				entry.SessionFile = path
+				break
+			}
+			if mod := info.ModTime().UnixNano(); mod > newestMod {
+				newestMod = mod
+				entry.SessionFile = path
+			}
+		}
+	default:
+		return nil, fmt.Errorf("conversation export supports Claude and Gemini, not %s", agent)
+	}
+
+	if entry.SessionFile == "" {
+		return nil, fmt.Errorf("no %s conversation found for %s", agent, i.Path)
+	}
+	if info, err := os.Stat(entry.SessionFile); err == nil {
+		entry.Timestamp = info.ModTime()
+	}
+	return entry, nil
+}
+
+// fileExists reports whether a regular file exists at path
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
+
+// messageRole returns the header of a message's role
+func messageRole(msg ConversationMessage) string {
+	if msg.Role == "user" {
+		return "User"
+	}
+	return "Assistant"
+}
+
+// closeFences closes a code block left open at the end of a message
+func closeFences(text string) string {
+	open := false
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			open = !open
+		}
+	}
+	if open {
+		text += "\n```"
+	}
+	return text
+}
+
+// ExportConversation writes a conversation to path (~ expanded), as HTML for
+// .html/.htm files and as Markdown otherwise, and returns the path written
+func ExportConversation(path, title string, entry *HistoryEntry, messages []ConversationMessage) (string, error) {
+	path = expandTilde(strings.TrimSpace(path))
+	if path == "" {
+		return "", fmt.Errorf("no output path")
+	}
+	text := ConversationMarkdown(title, entry, messages)
+	if IsHTMLPath(path) {
+		text = ConversationHTML(title, entry, messages)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// IsHTMLPath reports whether a conversation exported to path is written as HTML
+func IsHTMLPath(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".html" || ext == ".htm"
+}
+
+// conversationCSS styles exported HTML conversations
+const conversationCSS = `body{font-family:system-ui,sans-serif;max-width:52em;margin:2em auto;padding:0 1em;line-height:1.5;color:#222}
+header ul{color:#666;padding-left:1.2em}
+section{border-left:4px solid #ccc;padding:.2em 1em;margin:1.5em 0}
+section.user{border-color:#7D56F4;background:#f6f3ff}
+section.assistant{border-color:#2E8B57}
+h2{font-size:1em;margin:.6em 0}
+time{color:#888;font-weight:normal;font-size:.85em;margin-left:.6em}
+pre{background:#1e1e2e;color:#e0e0e0;padding:.8em;overflow-x:auto;border-radius:4px}
+code{font-family:ui-monospace,monospace;font-size:.9em}
+p code{background:#eee;padding:0 .2em;border-radius:3px}`
+
+// inlineCodeRe matches `code` spans in text
+var inlineCodeRe = regexp.MustCompile("`([^`\n]+)`")
+
+// ConversationHTML renders a conversation as a standalone HTML page: role
+// headers with timestamps, fenced code blocks as <pre>, other text as paragraphs
+func ConversationHTML(title string, entry *HistoryEntry, messages []ConversationMessage) string {
+	if title == "" {
+		title = string(entry.Agent) + " conversation"
+	}
+	var b strings.Builder
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>%s</title>\n<style>\n%s\n</style>\n</head>\n<body>\n<header>\n", html.EscapeString(title), conversationCSS)
+	fmt.Fprintf(&b, "<h1>%s</h1>\n<ul>\n", html.EscapeString(title))
+	fmt.Fprintf(&b, "<li>Agent: %s</li>\n", html.EscapeString(string(entry.Agent)))
+	if entry.Path != "" {
+		fmt.Fprintf(&b, "<li>Project: <code>%s</code></li>\n", html.EscapeString(entry.Path))
+	}
+	if entry.SessionID != "" {
+		fmt.Fprintf(&b, "<li>Session: <code>%s</code></li>\n", html.EscapeString(entry.SessionID))
+	}
+	fmt.Fprintf(&b, "<li>Last activity: %s</li>\n</ul>\n</header>\n", entry.Timestamp.Format("2006-01-02 15:04"))
+
+	if len(messages) == 0 {
+		fmt.Fprintf(&b, "<pre><code>%s</code></pre>\n", html.EscapeString(entry.Content))
+	}
+	for _, msg := range messages {
+		class := "assistant"
+		if msg.Role == "user" {
+			class = "user"
+		}
+		fmt.Fprintf(&b, "<section class=\"%s\">\n<h2>%s", class, messageRole(msg))
+		if !msg.Timestamp.IsZero() {
+			fmt.Fprintf(&b, "<time datetime=\"%s\">%s</time>", msg.Timestamp.Format("2006-01-02T15:04:05Z07:00"), msg.Timestamp.Local().Format("2006-01-02 15:04"))
+		}
+		b.WriteString("</h2>\n")
+		b.WriteString(textToHTML(strings.TrimSpace(msg.Content)))
+		b.WriteString("</section>\n")
+	}
+	b.WriteString("</body>\n</html>\n")
+	return b.String()
+}
+
+// textToHTML converts message text: ``` fences become code blocks, blank
+// lines separate paragraphs and `code` spans are kept
+func textToHTML(text string) string {
+	var b strings.Builder
+	var para, code []string
+	inCode, lang := false, ""
+	flushPara := func() {
+		if len(para) == 0 {
+			return
+		}
+		escaped := html.EscapeString(strings.Join(para, "\n"))
+		escaped = inlineCodeRe.ReplaceAllString(escaped, "<code>$1</code>")
+		b.WriteString("<p>" + strings.ReplaceAll(escaped, "\n", "<br>\n") + "</p>\n")
+		para = nil
+	}
+	flushCode := func() {
+		class := ""
+		if lang != "" {
+			class = fmt.Sprintf(" class=\"language-%s\"", html.EscapeString(lang))
+		}
+		fmt.Fprintf(&b, "<pre><code%s>%s</code></pre>\n", class, html.EscapeString(strings.Join(code, "\n")))
+		code, lang = nil, ""
+	}
+	for _, line := range strings.Split(text, "\n") {
+		if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "```") {
+			if inCode {
+				flushCode()
+			} else {
+				flushPara()
+				lang = strings.TrimSpace(strings.TrimPrefix(trimmed, "```"))
+			}
+			inCode = !inCode
+			continue
+		}
+		switch {
+		case inCode:
+			code = append(code, line)
+		case strings.TrimSpace(line) == "":
+			flushPara()
+		default:
+			para = append(para, line)
+		}
+	}
+	if inCode {
+		flushCode()
+	}
+	flushPara()
+	return b.String()
+}
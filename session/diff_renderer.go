@@ -0,0 +1,178 @@
+package session
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Diff renderer modes
+const (
+	DiffRenderPipe     = "pipe"     // `git diff` output is piped to the command (delta, diff-so-fancy)
+	DiffRenderExternal = "external" // The command is git's external diff driver (difftastic)
+)
+
+// DiffRendererBuiltin selects the built-in renderer in diff.json
+const DiffRendererBuiltin = "builtin"
+
+// DiffRenderTimeout limits how long an external renderer may run
+const DiffRenderTimeout = 5 * time.Second
+
+// DiffRenderer renders git diffs with an external tool.
+// {width} in Command is replaced by the diff pane width.
+type DiffRenderer struct {
+	Name    string `json:"name"`
+	Command string `json:"command"`
+	Mode    string `json:"mode,omitempty"` // "pipe" (default) or "external"
+}
+
+// DefaultDiffRenderers are available without any configuration
+var DefaultDiffRenderers = []DiffRenderer{
+	{
+		Name:    "delta",
+		Command: "delta --paging=never --width={width}",
+		Mode:    DiffRenderPipe,
+	},
+	{
+		Name:    "difftastic",
+		Command: "difft --color=always --display=inline --width={width}",
+		Mode:    DiffRenderExternal,
+	},
+	{
+		Name:    "diff-so-fancy",
+		Command: "diff-so-fancy",
+		Mode:    DiffRenderPipe,
+	},
+}
+
+// DiffConfigPath returns the path of the optional diff renderer config
+func DiffConfigPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "agent-session-manager", "diff.json")
+}
+
+// LoadDiffRenderer returns the renderer chosen in diff.json, or the one git is
+// already set up with (delta as pager, difftastic as external diff).
+// Returns nil for the built-in renderer or when the tool isn't installed.
+func LoadDiffRenderer() *DiffRenderer {
+	renderers := append([]DiffRenderer{}, DefaultDiffRenderers...)
+	var config struct {
+		Renderer  string         `json:"renderer"`
+		Renderers []DiffRenderer `json:"renderers"`
+	}
+	if data, err := os.ReadFile(DiffConfigPath()); err == nil {
+		json.Unmarshal(data, &config)
+	}
+	for _, custom := range config.Renderers {
+		if custom.Name == "" || custom.Command == "" {
+			continue
+		}
+		replaced := false
+		for idx := range renderers {
+			if renderers[idx].Name == custom.Name {
+				renderers[idx] = custom
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			renderers = append(renderers, custom)
+		}
+	}
+
+	name := config.Renderer
+	if name == "" {
+		name = gitDiffRenderer()
+	}
+	if name == "" || name == DiffRendererBuiltin {
+		return nil
+	}
+	for _, r := range renderers {
+		if r.Name == name && r.Available() {
+			return &r
+		}
+	}
+	return nil
+}
+
+// gitDiffRenderer detects a renderer from the user's git config ("" if none)
+func gitDiffRenderer() string {
+	gitConfig := func(key string) string {
+		out, _ := exec.Command("git", "config", "--get", key).Output()
+		return strings.TrimSpace(string(out))
+	}
+	if strings.Contains(gitConfig("diff.external"), "difft") {
+		return "difftastic"
+	}
+	for _, key := range []string{"pager.diff", "core.pager", "interactive.diffFilter"} {
+		value := gitConfig(key)
+		switch {
+		case strings.Contains(value, "delta"):
+			return "delta"
+		case strings.Contains(value, "diff-so-fancy"):
+			return "diff-so-fancy"
+		}
+	}
+	return ""
+}
+
+// Binary returns the program the renderer runs
+func (r DiffRenderer) Binary() string {
+	fields := strings.Fields(r.Command)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// Available reports whether the renderer's program is installed
+func (r DiffRenderer) Available() bool {
+	_, err := exec.LookPath(r.Binary())
+	return err == nil
+}
+
+// command expands the template for a pane width
+func (r DiffRenderer) command(width int) string {
+	return strings.ReplaceAll(r.Command, "{width}", strconv.Itoa(width))
+}
+
+// Render renders a session's diff for a pane width, returning the tool's ANSI output
+func (r DiffRenderer) Render(inst *Instance, stats *DiffStats, width int) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), DiffRenderTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if r.Mode == DiffRenderExternal {
+		// Let git call the tool per file, like `git diff` does with diff.external
+		args := []string{"-C", inst.Path, "--no-pager", "diff", "--ext-diff"}
+		if stats.BaseRef != "" {
+			args = append(args, stats.BaseRef)
+		}
+		cmd = exec.CommandContext(ctx, "git", args...)
+		cmd.Env = append(os.Environ(), "GIT_EXTERNAL_DIFF="+r.command(width))
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", r.command(width))
+		cmd.Dir = inst.Path
+		cmd.Stdin = strings.NewReader(stats.Content)
+	}
+	// Tools disable colors when they don't write to a terminal unless told otherwise
+	cmd.Env = append(cmd.Environ(), "COLUMNS="+strconv.Itoa(width), "DFT_COLOR=always", "CLICOLOR_FORCE=1")
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s: %s", r.Name, msg)
+		}
+		return "", fmt.Errorf("%s: %w", r.Name, err)
+	}
+	return strings.TrimRight(string(out), "\n"), nil
+}
@@ -0,0 +1,97 @@
+package session
+
+import (
+	"sort"
+	"time"
+)
+
+// DigestDays is how far back the weekly digest looks
+const DigestDays = 7
+
+// DigestEntry is a session that stopped or was deleted within the digest period
+type DigestEntry struct {
+	Instance  *Instance
+	EndedAt   time.Time     // When it stopped or was deleted
+	Archived  bool          // Deleted - only left in the recently closed list
+	TimeSpent time.Duration // Running time of all its runs
+	Summary   string        // Latest summary from its notes ("" = none)
+}
+
+// EndRun closes the current run at the given time and adds it to RunSeconds
+// (a zero time when its end is unknown, e.g. it ended while the manager was
+// closed; the run is then not counted)
+func (i *Instance) EndRun(at time.Time) {
+	i.RunSeconds = int64(i.timeSpentUntil(at) / time.Second)
+	i.StartedAt = time.Time{}
+	i.EndedAt = at
+	if at.IsZero() {
+		i.EndedAt = time.Now()
+	}
+}
+
+// timeSpentUntil returns the running time of the finished runs plus the
+// current one up to end
+func (i *Instance) timeSpentUntil(end time.Time) time.Duration {
+	spent := time.Duration(i.RunSeconds) * time.Second
+	if !i.StartedAt.IsZero() && end.After(i.StartedAt) {
+		spent += end.Sub(i.StartedAt)
+	}
+	return spent
+}
+
+// TimeSpent returns how long the session has run in total, the current run included
+func (i *Instance) TimeSpent() time.Duration {
+	if i.Status != StatusRunning {
+		return i.timeSpentUntil(time.Time{})
+	}
+	return i.timeSpentUntil(time.Now())
+}
+
+// lastEnded returns when a stopped session's last run ended (zero = never ran)
+func (i *Instance) lastEnded() time.Time {
+	switch {
+	case !i.EndedAt.IsZero():
+		return i.EndedAt
+	case !i.StoppedAt.IsZero():
+		return i.StoppedAt
+	}
+	return time.Time{}
+}
+
+// CollectDigest returns the sessions that stopped or were deleted since the
+// given time, most recent first. Stopped sessions come from instances, deleted
+// ones from the recently closed list.
+func CollectDigest(instances []*Instance, closed []ClosedSession, since time.Time) []DigestEntry {
+	var entries []DigestEntry
+	known := make(map[string]bool, len(instances))
+	for _, inst := range instances {
+		known[inst.ID] = true
+		ended := inst.lastEnded()
+		if inst.Status == StatusRunning || ended.Before(since) {
+			continue
+		}
+		entries = append(entries, DigestEntry{
+			Instance:  inst,
+			EndedAt:   ended,
+			TimeSpent: inst.TimeSpent(),
+			Summary:   LatestSummary(inst.Notes),
+		})
+	}
+	for idx := range closed {
+		c := &closed[idx]
+		if !c.Deleted || known[c.Instance.ID] || c.ClosedAt.Before(since) {
+			continue
+		}
+		entries = append(entries, DigestEntry{
+			Instance:  &c.Instance,
+			EndedAt:   c.ClosedAt,
+			Archived:  true,
+			TimeSpent: c.Instance.timeSpentUntil(c.ClosedAt),
+			Summary:   LatestSummary(c.Instance.Notes),
+		})
+	}
+	sort.SliceStable(entries, func(a, b int) bool {
+		return entries[a].EndedAt.After(entries[b].EndedAt)
+	})
+	return entries
+}
@@ -0,0 +1,150 @@
+package session
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ManagerVersion is the manager version recorded in environment snapshots (set by main)
+var ManagerVersion string
+
+// versionTimeout bounds how long `<agent> --version` may take at session start
+const versionTimeout = 3 * time.Second
+
+// Environment is a snapshot of what a session was started with
+type Environment struct {
+	CapturedAt     time.Time         `json:"captured_at"`
+	AgentVersion   string            `json:"agent_version,omitempty"` // Output of `<agent> --version`
+	Model          string            `json:"model,omitempty"`         // From the agent's env vars or settings
+	GitBranch      string            `json:"git_branch,omitempty"`
+	GitCommit      string            `json:"git_commit,omitempty"` // HEAD when the session started
+	GitDirty       bool              `json:"git_dirty,omitempty"`  // Uncommitted changes at start
+	OS             string            `json:"os"`                   // GOOS/GOARCH and kernel release
+	Shell          string            `json:"shell,omitempty"`
+	TmuxVersion    string            `json:"tmux_version,omitempty"`
+	ManagerVersion string            `json:"manager_version,omitempty"`
+	Env            map[string]string `json:"env,omitempty"` // Key environment variables; secrets only as "(set)"
+}
+
+// snapshotEnvVars are the environment variables worth recording
+var snapshotEnvVars = []string{
+	"ANTHROPIC_MODEL", "ANTHROPIC_SMALL_FAST_MODEL", "ANTHROPIC_BASE_URL", "CLAUDE_CODE_USE_BEDROCK",
+	"CLAUDE_CODE_USE_VERTEX", "AWS_REGION", "GEMINI_MODEL", "OPENAI_MODEL", "OPENAI_BASE_URL",
+	"AIDER_MODEL", "HTTPS_PROXY", "NODE_OPTIONS", "LANG", "TERM",
+}
+
+// secretEnvVars are recorded only as set or not, never by value
+var secretEnvVars = []string{"ANTHROPIC_API_KEY", "OPENAI_API_KEY", "GEMINI_API_KEY", "GOOGLE_API_KEY", "AWS_PROFILE"}
+
+// modelEnvVars are the env vars an agent takes its model from
+var modelEnvVars = map[AgentType][]string{
+	AgentClaude: {"ANTHROPIC_MODEL"},
+	AgentGemini: {"GEMINI_MODEL"},
+	AgentAider:  {"AIDER_MODEL"},
+	AgentCodex:  {"OPENAI_MODEL"},
+}
+
+// captureEnvironment records the environment of the session's main agent
+func (i *Instance) captureEnvironment() {
+	env := &Environment{
+		CapturedAt:     time.Now(),
+		OS:             runtime.GOOS + "/" + runtime.GOARCH,
+		Shell:          UserShell(),
+		ManagerVersion: ManagerVersion,
+		Env:            make(map[string]string),
+	}
+	if release := commandLine(exec.Command("uname", "-r")); release != "" {
+		env.OS += " " + release
+	}
+	env.TmuxVersion = strings.TrimPrefix(commandLine(exec.Command("tmux", "-V")), "tmux ")
+
+	if i.Agent != AgentCustom && i.Agent != AgentTerminal {
+		if command := i.GetAgentConfig().Command; command != "" {
+			ctx, cancel := context.WithTimeout(context.Background(), versionTimeout)
+			env.AgentVersion = commandLine(exec.CommandContext(ctx, command, "--version"))
+			cancel()
+		}
+	}
+	env.Model = i.agentModel()
+
+	if commit := commandLine(exec.Command("git", "-C", i.Path, "rev-parse", "HEAD")); commit != "" {
+		env.GitCommit = commit
+		env.GitBranch = commandLine(exec.Command("git", "-C", i.Path, "rev-parse", "--abbrev-ref", "HEAD"))
+		env.GitDirty = commandLine(exec.Command("git", "-C", i.Path, "status", "--porcelain")) != ""
+	}
+
+	for _, name := range snapshotEnvVars {
+		if value := os.Getenv(name); value != "" {
+			env.Env[name] = value
+		}
+	}
+	for _, name := range secretEnvVars {
+		if os.Getenv(name) != "" {
+			env.Env[name] = "(set)"
+		}
+	}
+	i.Environment = env
+}
+
+// agentModel returns the model the agent is configured to use ("" if unknown)
+func (i *Instance) agentModel() string {
+	agent := i.Agent
+	if agent == "" {
+		agent = AgentClaude
+	}
+	for _, name := range modelEnvVars[agent] {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	if agent != AgentClaude {
+		return ""
+	}
+
+	// Claude settings, most specific first
+	homeDir, _ := os.UserHomeDir()
+	for _, path := range []string{
+		filepath.Join(i.Path, ".claude", "settings.local.json"),
+		filepath.Join(i.Path, ".claude", "settings.json"),
+		filepath.Join(homeDir, ".claude", "settings.json"),
+	} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var settings struct {
+			Model string `json:"model"`
+		}
+		if json.Unmarshal(data, &settings) == nil && settings.Model != "" {
+			return settings.Model
+		}
+	}
+	return ""
+}
+
+// commandLine runs a command and returns the first line of its output ("" on failure)
+func commandLine(cmd *exec.Cmd) string {
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	line, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+	return strings.TrimSpace(line)
+}
+
+// EnvNames returns the recorded environment variable names, sorted
+func (e *Environment) EnvNames() []string {
+	names := make([]string, 0, len(e.Env))
+	for name := range e.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,156 @@
+package session
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// envNameRe matches a valid environment variable name
+var envNameRe = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ParseEnvVars parses NAME=value lines; blank lines and # comments are skipped
+// and surrounding quotes of a value are removed
+func ParseEnvVars(text string) (map[string]string, error) {
+	env := make(map[string]string)
+	for n, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !ok || !envNameRe.MatchString(name) {
+			return nil, fmt.Errorf("line %d: use NAME=value", n+1)
+		}
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		env[name] = value
+	}
+	if len(env) == 0 {
+		return nil, nil
+	}
+	return env, nil
+}
+
+// FormatEnvVars formats variables as sorted NAME=value lines, the inverse of ParseEnvVars
+func FormatEnvVars(env map[string]string) string {
+	lines := make([]string, 0, len(env))
+	for _, name := range sortedEnvNames(env) {
+		lines = append(lines, name+"="+env[name])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// sortedEnvNames returns the names of env in order
+func sortedEnvNames(env map[string]string) []string {
+	names := make([]string, 0, len(env))
+	for name := range env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SecretEnvName reports whether a variable looks like it holds a credential,
+// so its value is masked where commands are shown
+func SecretEnvName(name string) bool {
+	upper := strings.ToUpper(name)
+	for _, word := range []string{"KEY", "TOKEN", "SECRET", "PASSWORD", "PASSWD", "CREDENTIAL", "AUTH"} {
+		if strings.Contains(upper, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// TabEnv returns the variables of a tab (nil for window 0 and unknown windows)
+func (i *Instance) TabEnv(window int) map[string]string {
+	if window == 0 {
+		return nil
+	}
+	if fw := i.GetFollowedWindow(window); fw != nil {
+		return fw.Env
+	}
+	return nil
+}
+
+// SetTabEnv replaces the variables of a tab (window 0 sets the session's)
+func (i *Instance) SetTabEnv(window int, env map[string]string) {
+	if window == 0 {
+		i.Env = env
+		return
+	}
+	for idx := range i.FollowedWindows {
+		if i.FollowedWindows[idx].Index == window {
+			i.FollowedWindows[idx].Env = env
+			return
+		}
+	}
+}
+
+// envArgs returns the tmux -e flags exporting the profile's variables, the
+// session's and a tab's own ones (later ones win) into a new pane
+func (i *Instance) envArgs(tabEnv map[string]string) []string {
+	merged := make(map[string]string, len(i.Env)+len(tabEnv))
+	if profile := i.agentProfile(); profile != nil {
+		for name, value := range profile.Env {
+			merged[name] = value
+		}
+	}
+	for name, value := range i.Env {
+		merged[name] = value
+	}
+	for name, value := range tabEnv {
+		merged[name] = value
+	}
+	var args []string
+	for _, name := range sortedEnvNames(merged) {
+		args = append(args, "-e", name+"="+merged[name])
+	}
+	return args
+}
+
+// windowEnvArgs returns the tmux -e flags for a window (0 = main)
+func (i *Instance) windowEnvArgs(window int) []string {
+	return i.envArgs(i.TabEnv(window))
+}
+
+// containerEnvNames returns every variable name of the session and its tabs,
+// forwarded from the pane into the container by name
+func (i *Instance) containerEnvNames() []string {
+	all := make(map[string]string)
+	if profile := i.agentProfile(); profile != nil {
+		for name := range profile.Env {
+			all[name] = ""
+		}
+	}
+	for name := range i.Env {
+		all[name] = ""
+	}
+	for _, fw := range i.FollowedWindows {
+		for name := range fw.Env {
+			all[name] = ""
+		}
+	}
+	return sortedEnvNames(all)
+}
+
+// maskEnvArgs hides the values of secret variables in -e flags for display
+func maskEnvArgs(args []string) []string {
+	masked := make([]string, len(args))
+	copy(masked, args)
+	for n := 0; n+1 < len(masked); n++ {
+		if masked[n] != "-e" {
+			continue
+		}
+		if name, _, ok := strings.Cut(masked[n+1], "="); ok && SecretEnvName(name) {
+			masked[n+1] = name + "=***"
+		}
+	}
+	return masked
+}
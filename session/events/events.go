@@ -0,0 +1,96 @@
+// Package events is the workspace event bus.
+//
+// The session manager publishes lifecycle and activity events here. Go code embedding
+// the manager can react to them by registering a Handler with Subscribe before the TUI
+// starts; external tools can read the same events as JSON lines from the event log
+// (see LogPath), e.g. via `asmgr events --follow`.
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Type identifies the kind of event
+type Type string
+
+const (
+	SessionStarted  Type = "session_started"  // A session's tmux session was started
+	SessionStopped  Type = "session_stopped"  // A session was stopped
+	ActivityChanged Type = "activity_changed" // Activity state changed (idle/busy/waiting)
+	PromptSent      Type = "prompt_sent"      // A prompt was sent to a session
+	OutputLine      Type = "output_line"      // A session printed a new last output line
+)
+
+// Event is a single workspace event
+type Event struct {
+	Type      Type      `json:"type"`
+	Time      time.Time `json:"time"`
+	SessionID string    `json:"session_id,omitempty"`
+	Session   string    `json:"session,omitempty"`  // Session display name
+	Agent     string    `json:"agent,omitempty"`    // Agent type (claude, gemini, ...)
+	Path      string    `json:"path,omitempty"`     // Session working directory
+	Activity  string    `json:"activity,omitempty"` // New activity state for ActivityChanged
+	Text      string    `json:"text,omitempty"`     // Prompt text or output line
+	Preset    string    `json:"preset,omitempty"`   // ID of the preset the session was created from
+}
+
+// Handler receives published events. Handlers run synchronously on the publisher's
+// goroutine (often the UI loop), so they must return quickly.
+type Handler func(Event)
+
+// Bus is a simple in-process pub/sub event bus
+type Bus struct {
+	mu       sync.RWMutex
+	nextID   int
+	handlers map[int]Handler
+}
+
+// NewBus creates an empty event bus
+func NewBus() *Bus {
+	return &Bus{handlers: make(map[int]Handler)}
+}
+
+// Subscribe registers a handler and returns a function that removes it
+func (b *Bus) Subscribe(h Handler) func() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	id := b.nextID
+	b.nextID++
+	b.handlers[id] = h
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		delete(b.handlers, id)
+	}
+}
+
+// Publish delivers an event to all handlers, stamping the time if unset
+func (b *Bus) Publish(e Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+	b.mu.RLock()
+	handlers := make([]Handler, 0, len(b.handlers))
+	for _, h := range b.handlers {
+		handlers = append(handlers, h)
+	}
+	b.mu.RUnlock()
+
+	for _, h := range handlers {
+		h(e)
+	}
+}
+
+// Default is the bus the session manager publishes to
+var Default = NewBus()
+
+// Subscribe registers a handler on the default bus
+func Subscribe(h Handler) func() {
+	return Default.Subscribe(h)
+}
+
+// Publish delivers an event on the default bus
+func Publish(e Event) {
+	Default.Publish(e)
+}
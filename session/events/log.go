@@ -0,0 +1,136 @@
+package events
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// MaxLogSize is the event log size at which it is rotated to events.jsonl.1
+const MaxLogSize = 5 * 1024 * 1024
+
+// LogPath returns the path of the JSON lines event log
+func LogPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "agent-session-manager", "events.jsonl")
+}
+
+// EnableLog appends every event on the default bus to the event log, rotating
+// it once it grows past MaxLogSize.
+// Returns a function that stops logging and closes the file.
+func EnableLog() (func(), error) {
+	path := LogPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(path); err == nil && info.Size() > MaxLogSize {
+		os.Rename(path, path+".1")
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	var mu sync.Mutex
+	unsubscribe := Subscribe(func(e Event) {
+		data, err := json.Marshal(e)
+		if err != nil {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if f == nil {
+			return
+		}
+		f.Write(append(data, '\n'))
+		if info, err := f.Stat(); err == nil && info.Size() > MaxLogSize {
+			f = rotateLog(f, path)
+		}
+	})
+	return func() {
+		unsubscribe()
+		mu.Lock()
+		defer mu.Unlock()
+		if f != nil {
+			f.Close()
+		}
+	}, nil
+}
+
+// rotateLog moves a full event log to events.jsonl.1 and opens a new one
+// (nil when it can't be opened). When another process rotated the log
+// already, f is the old file and only the new one is opened.
+func rotateLog(f *os.File, path string) *os.File {
+	current, _ := f.Stat()
+	f.Close()
+	if info, err := os.Stat(path); err == nil && current != nil && os.SameFile(current, info) {
+		os.Rename(path, path+".1")
+	}
+	nf, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil
+	}
+	return nf
+}
+
+// Stream copies the event log to w. With follow it skips existing events and
+// keeps streaming new ones until the process is interrupted.
+func Stream(w io.Writer, follow bool) error {
+	path := LogPath()
+	f, err := os.Open(path)
+	if err != nil {
+		if !os.IsNotExist(err) || !follow {
+			return err
+		}
+		// Wait for the first session manager to create the log
+		for {
+			time.Sleep(500 * time.Millisecond)
+			if f, err = os.Open(path); err == nil {
+				break
+			}
+		}
+	} else if follow {
+		f.Seek(0, io.SeekEnd)
+	}
+	defer func() { f.Close() }()
+
+	if !follow {
+		_, err := io.Copy(w, f)
+		return err
+	}
+
+	reader := bufio.NewReader(f)
+	var partial []byte
+	for {
+		line, err := reader.ReadBytes('\n')
+		partial = append(partial, line...)
+		if err == nil {
+			if _, werr := w.Write(partial); werr != nil {
+				return werr
+			}
+			partial = nil
+			continue
+		}
+		if err != io.EOF {
+			return err
+		}
+
+		time.Sleep(200 * time.Millisecond)
+
+		// Log was rotated or truncated - start over with the new file
+		pos, _ := f.Seek(0, io.SeekCurrent)
+		current, _ := f.Stat()
+		if info, statErr := os.Stat(path); statErr == nil && (current == nil || !os.SameFile(current, info) || info.Size() < pos) {
+			if nf, openErr := os.Open(path); openErr == nil {
+				f.Close()
+				f = nf
+				reader = bufio.NewReader(f)
+				partial = nil
+			}
+		}
+	}
+}
@@ -0,0 +1,255 @@
+package session
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"sort"
+)
+
+// ErrExternalChange is returned by saves while the watched session file holds
+// changes made by another process that were not merged yet
+var ErrExternalChange = errors.New("sessions.json was changed by another process - merge the changes first")
+
+// Kinds of SessionChange
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeChanged = "changed"
+)
+
+// runtimeFields change with every status refresh and are not listed as changes
+var runtimeFields = map[string]bool{"status": true, "updated_at": true}
+
+// SessionChange is one session another process added, removed or changed
+type SessionChange struct {
+	Name      string
+	Kind      string   // ChangeAdded, ChangeRemoved or ChangeChanged
+	Fields    []string // Fields changed on disk (ChangeChanged only)
+	Conflicts []string // Of those, fields also changed here to another value (kept on merge)
+}
+
+// ExternalChange is what another process changed in the watched session file
+type ExternalChange struct {
+	Sessions []SessionChange
+	Groups   int  // Groups added, removed or changed
+	Helper   bool // Written by a helper process only (see MarkHelper), safe to merge without asking
+
+	disk []byte // File contents the change was read from
+}
+
+// WatchExternalChanges guards the active project's session file: from now on
+// saves are refused while the file holds changes of another process, until
+// they are merged, kept or dropped
+func (s *Storage) WatchExternalChanges() {
+	s.watchPath = s.configPath
+	s.base, _ = os.ReadFile(s.configPath)
+}
+
+// MarkHelper marks this storage's saves as made by a helper process (the YOLO
+// toggle and expiry run from tmux): each save leaves a stamp next to the file,
+// so a process watching it merges the change without asking
+func (s *Storage) MarkHelper() {
+	s.helper = true
+}
+
+// helperStampPath is where the stamp of the last helper save of the session file is kept
+func (s *Storage) helperStampPath(file string) string {
+	return file + ".helper"
+}
+
+// stampHelperWrite records the contents a helper process just wrote
+func (s *Storage) stampHelperWrite(data []byte) {
+	sum := sha256.Sum256(data)
+	os.WriteFile(s.helperStampPath(s.configPath), []byte(hex.EncodeToString(sum[:])), 0644)
+}
+
+// writtenByHelper reports whether disk is exactly what a helper process last wrote
+func (s *Storage) writtenByHelper(disk []byte) bool {
+	stamp, err := os.ReadFile(s.helperStampPath(s.watchPath))
+	if err != nil {
+		return false
+	}
+	sum := sha256.Sum256(disk)
+	return string(stamp) == hex.EncodeToString(sum[:])
+}
+
+// RefusedSave reports whether a save was refused with ErrExternalChange since
+// the last call, so callers that don't check every save can still tell the user
+func (s *Storage) RefusedSave() bool {
+	refused := s.refused
+	s.refused = false
+	return refused
+}
+
+// StopWatching turns the guard of WatchExternalChanges off
+func (s *Storage) StopWatching() {
+	s.watchPath = ""
+	s.base = nil
+}
+
+// changedOnDisk reports whether the storage points at the watched file and
+// another process wrote it since it was last loaded or written here
+func (s *Storage) changedOnDisk() bool {
+	if s.watchPath == "" || s.configPath != s.watchPath {
+		return false
+	}
+	disk, _ := os.ReadFile(s.watchPath)
+	return !bytes.Equal(disk, s.base)
+}
+
+// sessionRecords parses the sessions and groups of a session file's contents
+func sessionRecords(data []byte) (syncSessionsFile, *Settings) {
+	var file syncSessionsFile
+	var settings struct {
+		Settings *Settings `json:"settings"`
+	}
+	if len(data) > 0 {
+		json.Unmarshal(data, &file)
+		json.Unmarshal(data, &settings)
+	}
+	return file, settings.Settings
+}
+
+// ExternalChanges compares the watched file with what this process last loaded
+// or wrote. local are the sessions as they are in memory here, used to find
+// fields changed on both sides. It returns nil when no session or group differs;
+// changes of the settings only are taken over silently.
+func (s *Storage) ExternalChanges(local []*Instance) (*ExternalChange, error) {
+	if !s.changedOnDisk() {
+		return nil, nil
+	}
+	disk, err := os.ReadFile(s.watchPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	base, _ := sessionRecords(s.base)
+	remote, _ := sessionRecords(disk)
+	localRecords, err := toRecords(local)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &ExternalChange{disk: disk}
+	localByID := make(map[string]map[string]json.RawMessage, len(localRecords))
+	for _, record := range localRecords {
+		localByID[recordID(record)] = record
+	}
+	baseByID := make(map[string]map[string]json.RawMessage, len(base.Instances))
+	for _, record := range base.Instances {
+		baseByID[recordID(record)] = record
+	}
+	for _, r := range remote.Instances {
+		b, ok := baseByID[recordID(r)]
+		delete(baseByID, recordID(r))
+		if !ok {
+			change.Sessions = append(change.Sessions, SessionChange{Name: recordName(r), Kind: ChangeAdded})
+			continue
+		}
+		c := SessionChange{Name: recordName(r), Kind: ChangeChanged}
+		l := localByID[recordID(r)]
+		for _, k := range changedFields(b, r) {
+			c.Fields = append(c.Fields, k)
+			if l != nil && !sameJSON(l[k], b[k]) && !sameJSON(l[k], r[k]) {
+				c.Conflicts = append(c.Conflicts, k)
+			}
+		}
+		if len(c.Fields) > 0 {
+			change.Sessions = append(change.Sessions, c)
+		}
+	}
+	for _, b := range base.Instances {
+		if _, ok := baseByID[recordID(b)]; ok {
+			change.Sessions = append(change.Sessions, SessionChange{Name: recordName(b), Kind: ChangeRemoved})
+		}
+	}
+	change.Groups = countChanges(base.Groups, remote.Groups)
+
+	if len(change.Sessions) == 0 && change.Groups == 0 {
+		// Settings, status or formatting only - nothing worth asking about
+		s.base = disk
+		return nil, nil
+	}
+	change.Helper = s.writtenByHelper(disk)
+	return change, nil
+}
+
+// changedFields returns the fields that differ between two records, sorted (runtime fields left out)
+func changedFields(a, b map[string]json.RawMessage) []string {
+	var fields []string
+	for k := range a {
+		if !runtimeFields[k] && !sameJSON(a[k], b[k]) {
+			fields = append(fields, k)
+		}
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok && !runtimeFields[k] {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// MergeExternal merges a change of another process with the sessions and groups
+// in memory here, field by field against what both started from: a field
+// changed on one side takes that change, one changed on both sides keeps the
+// value from here. The merged lists are saved and returned.
+func (s *Storage) MergeExternal(change *ExternalChange, instances []*Instance, groups []*Group) ([]*Instance, []*Group, error) {
+	base, _ := sessionRecords(s.base)
+	remote, settings := sessionRecords(change.disk)
+	localInstances, err := toRecords(instances)
+	if err != nil {
+		return nil, nil, err
+	}
+	localGroups, err := toRecords(groups)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report := &SyncReport{} // Conflicts are listed by ExternalChanges already
+	var merged []*Instance
+	if err := fromRecords(mergeRecords(localInstances, remote.Instances, base.Instances, "session", report), &merged); err != nil {
+		return nil, nil, err
+	}
+	for _, inst := range merged {
+		if inst.Status == "" {
+			inst.Status = StatusStopped
+		}
+	}
+	var mergedGroups []*Group
+	if err := fromRecords(mergeRecords(localGroups, remote.Groups, base.Groups, "group", report), &mergedGroups); err != nil {
+		return nil, nil, err
+	}
+	if settings == nil {
+		defaults := s.defaults
+		settings = &defaults
+	}
+
+	s.base = change.disk
+	if err := s.SaveAll(merged, mergedGroups, settings); err != nil {
+		return nil, nil, err
+	}
+	return merged, mergedGroups, nil
+}
+
+// KeepLocal drops a change of another process: the sessions and groups in
+// memory here overwrite the file (its settings are kept)
+func (s *Storage) KeepLocal(change *ExternalChange, instances []*Instance, groups []*Group) error {
+	s.base = change.disk
+	_, _, settings, err := s.LoadAllWithSettings()
+	if err != nil {
+		return err
+	}
+	return s.SaveAll(instances, groups, settings)
+}
+
+// TakeExternal accepts a change of another process and returns the sessions and
+// groups from the file; changes made here since the last save are lost
+func (s *Storage) TakeExternal(change *ExternalChange) ([]*Instance, []*Group, error) {
+	s.base = change.disk
+	return s.LoadAll()
+}
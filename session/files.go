@@ -0,0 +1,209 @@
+package session
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// MaxFilePreviewBytes is how much of a file the file browser reads for its preview
+const MaxFilePreviewBytes = 256 * 1024
+
+// MaxFileMentions is how many transcript mentions of a file are kept (newest)
+const MaxFileMentions = 50
+
+// FileNode is an entry of the file browser tree
+type FileNode struct {
+	Path    string // Relative to the session directory ("cmd/main.go")
+	Name    string
+	Dir     bool
+	Depth   int // Nesting level (0 = top level)
+	Size    int64
+	ModTime time.Time
+}
+
+// readDirNodes lists one directory of the tree: directories first, then files, by name.
+// .git is left out, as are other dot entries unless hidden is set.
+func readDirNodes(root, rel string, depth int, hidden bool) ([]FileNode, error) {
+	entries, err := os.ReadDir(filepath.Join(root, rel))
+	if err != nil {
+		return nil, err
+	}
+	var nodes []FileNode
+	for _, e := range entries {
+		name := e.Name()
+		if name == ".git" || (!hidden && strings.HasPrefix(name, ".")) {
+			continue
+		}
+		node := FileNode{Path: filepath.Join(rel, name), Name: name, Depth: depth}
+		if info, err := os.Stat(filepath.Join(root, node.Path)); err == nil {
+			node.Dir = info.IsDir()
+			node.Size = info.Size()
+			node.ModTime = info.ModTime()
+		}
+		nodes = append(nodes, node)
+	}
+	sort.SliceStable(nodes, func(a, b int) bool {
+		if nodes[a].Dir != nodes[b].Dir {
+			return nodes[a].Dir
+		}
+		return strings.ToLower(nodes[a].Name) < strings.ToLower(nodes[b].Name)
+	})
+	return nodes, nil
+}
+
+// FileTree returns the entries of root with the expanded directories
+// (relative paths) opened in place, ready to be shown as an indented list
+func FileTree(root string, expanded map[string]bool, hidden bool) ([]FileNode, error) {
+	var walk func(rel string, depth int) ([]FileNode, error)
+	walk = func(rel string, depth int) ([]FileNode, error) {
+		nodes, err := readDirNodes(root, rel, depth, hidden)
+		if err != nil {
+			return nil, err
+		}
+		var tree []FileNode
+		for _, n := range nodes {
+			tree = append(tree, n)
+			if n.Dir && expanded[n.Path] {
+				children, err := walk(n.Path, depth+1)
+				if err == nil {
+					tree = append(tree, children...)
+				}
+			}
+		}
+		return tree, nil
+	}
+	return walk("", 0)
+}
+
+// FilePreview returns the first lines of a text file (tabs expanded), or an
+// error for binary files
+func FilePreview(path string, maxLines int) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	buf := make([]byte, MaxFilePreviewBytes)
+	n, _ := f.Read(buf)
+	buf = buf[:n]
+	if bytes.IndexByte(buf, 0) != -1 {
+		return nil, fmt.Errorf("binary file")
+	}
+	var lines []string
+	for _, line := range strings.Split(string(buf), "\n") {
+		if len(lines) == maxLines {
+			break
+		}
+		lines = append(lines, strings.ReplaceAll(strings.TrimRight(line, "\r"), "\t", "    "))
+	}
+	return lines, nil
+}
+
+// FileMention is a place in the agent's conversation that refers to a file
+type FileMention struct {
+	Time    time.Time
+	Role    string // "user" or "assistant"
+	Tool    string // Tool that used the file (Edit, Write, Read, ...); "" = mentioned in text
+	Snippet string // The text around the mention, or the tool's description
+}
+
+// FileMentions returns where the session's Claude conversation used or
+// mentioned a file (relative to the session directory), oldest first
+func (i *Instance) FileMentions(rel string) ([]FileMention, error) {
+	transcript := i.ClaudeTranscriptPath()
+	if transcript == "" {
+		return nil, fmt.Errorf("no Claude conversation found for %s", i.Path)
+	}
+	file, err := os.Open(transcript)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	abs := filepath.Join(i.Path, rel)
+	var mentions []FileMention
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if !bytes.Contains(line, []byte(rel)) {
+			continue
+		}
+		var entry struct {
+			Type      string    `json:"type"`
+			Timestamp time.Time `json:"timestamp"`
+			Message   struct {
+				Role    string          `json:"role"`
+				Content json.RawMessage `json:"content"`
+			} `json:"message"`
+		}
+		if json.Unmarshal(line, &entry) != nil || (entry.Type != "user" && entry.Type != "assistant") {
+			continue
+		}
+		for _, m := range contentMentions(entry.Message.Content, abs, rel) {
+			m.Time = entry.Timestamp
+			m.Role = entry.Message.Role
+			mentions = append(mentions, m)
+		}
+	}
+	if len(mentions) > MaxFileMentions {
+		mentions = mentions[len(mentions)-MaxFileMentions:]
+	}
+	return mentions, scanner.Err()
+}
+
+// contentMentions finds the tool calls on a file and the text mentioning it in a message
+func contentMentions(raw json.RawMessage, abs, rel string) []FileMention {
+	var text string
+	if json.Unmarshal(raw, &text) == nil {
+		if snippet := mentionSnippet(text, rel); snippet != "" {
+			return []FileMention{{Snippet: snippet}}
+		}
+		return nil
+	}
+
+	var blocks []struct {
+		Type  string                 `json:"type"`
+		Text  string                 `json:"text"`
+		Name  string                 `json:"name"`
+		Input map[string]interface{} `json:"input"`
+	}
+	if json.Unmarshal(raw, &blocks) != nil {
+		return nil
+	}
+	var mentions []FileMention
+	for _, b := range blocks {
+		switch b.Type {
+		case "text":
+			if snippet := mentionSnippet(b.Text, rel); snippet != "" {
+				mentions = append(mentions, FileMention{Snippet: snippet})
+			}
+		case "tool_use":
+			for _, key := range []string{"file_path", "notebook_path", "path"} {
+				if path, _ := b.Input[key].(string); path == abs || path == rel {
+					desc, _ := b.Input["description"].(string)
+					mentions = append(mentions, FileMention{Tool: b.Name, Snippet: desc})
+					break
+				}
+			}
+		}
+	}
+	return mentions
+}
+
+// mentionSnippet returns the line of text naming the file ("" if it doesn't)
+func mentionSnippet(text, rel string) string {
+	for _, line := range strings.Split(text, "\n") {
+		if strings.Contains(line, rel) {
+			return strings.TrimSpace(line)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,105 @@
+package session
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseLaunchFlags splits the flags typed in the launch flags editor, checking
+// them for an agent: they must start with an option, and auto-yes, resume and
+// the model stay with their own keys
+func ParseLaunchFlags(agent AgentType, line string) ([]string, error) {
+	flags, err := SplitCommandLine(strings.TrimSpace(line))
+	if err != nil {
+		return nil, err
+	}
+	if len(flags) == 0 {
+		return nil, nil
+	}
+	if err := checkLaunchFlags(agent, flags); err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
+// checkLaunchFlags checks flags appended to an agent's command line, from the
+// launch flags editor or a profile's args
+func checkLaunchFlags(agent AgentType, flags []string) error {
+	if len(flags) == 0 {
+		return nil
+	}
+	if !strings.HasPrefix(flags[0], "-") {
+		return fmt.Errorf("flags start with an option like --model, not '%s'", flags[0])
+	}
+	config, ok := AgentConfigs[agent]
+	if !ok {
+		config = AgentConfigs[AgentClaude]
+	}
+	for _, flag := range flags {
+		name, _, _ := strings.Cut(flag, "=")
+		switch {
+		case config.AutoYesFlag != "" && name == config.AutoYesFlag:
+			return fmt.Errorf("%s is set by auto-yes (Ctrl+Y)", name)
+		case config.ResumeFlag != "" && !config.ResumeIsSubcommand && name == config.ResumeFlag:
+			return fmt.Errorf("%s is set when resuming (r)", name)
+		case config.ModelFlag != "" && name == config.ModelFlag:
+			return fmt.Errorf("%s is set with the model picker (M)", name)
+		case strings.ContainsAny(flag, "\n\r"):
+			return fmt.Errorf("flags can't span lines")
+		}
+	}
+	return nil
+}
+
+// FormatLaunchFlags joins flags into the line the editor shows, quoting where needed
+func FormatLaunchFlags(flags []string) string {
+	quoted := make([]string, len(flags))
+	for idx, flag := range flags {
+		quoted[idx] = ShellQuote(flag)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// launchFlagsSuffix returns flags ready to append to an agent command ("" for none)
+func launchFlagsSuffix(flags []string) string {
+	if len(flags) == 0 {
+		return ""
+	}
+	return " " + FormatLaunchFlags(flags)
+}
+
+// WindowLaunchFlags returns the flags appended to a window's agent command (0 = main)
+func (i *Instance) WindowLaunchFlags(window int) []string {
+	if window == 0 {
+		return i.LaunchFlags
+	}
+	if fw := i.GetFollowedWindow(window); fw != nil {
+		return fw.LaunchFlags
+	}
+	return nil
+}
+
+// SetWindowLaunchFlags replaces the flags of a window (0 = main)
+func (i *Instance) SetWindowLaunchFlags(window int, flags []string) {
+	if window == 0 {
+		i.LaunchFlags = flags
+		return
+	}
+	for idx := range i.FollowedWindows {
+		if i.FollowedWindows[idx].Index == window {
+			i.FollowedWindows[idx].LaunchFlags = flags
+			return
+		}
+	}
+}
+
+// LaunchCommandPreview returns the agent command a window starts with when it
+// gets the given flags, for showing in the editor
+func (i *Instance) LaunchCommandPreview(window int, flags []string) string {
+	agent := i.WindowAgent(window)
+	autoYes := i.autoYesEnabled(i.AutoYes)
+	if fw := i.GetFollowedWindow(window); window != 0 && fw != nil {
+		autoYes = i.autoYesEnabled(fw.AutoYes)
+	}
+	return agentLaunchCommand(AgentConfigs[agent], autoYes, "") + i.agentFlags(agent, i.WindowModel(window)) + launchFlagsSuffix(i.sandboxedFlags(flags))
+}
@@ -0,0 +1,114 @@
+package session
+
+import "fmt"
+
+// LoadGlobal switches the storage to the cross-project list and returns the
+// sessions of all projects (sessions without a project first). Until
+// LeaveGlobal, changes to a session are written to the project it belongs to.
+func (s *Storage) LoadGlobal() ([]*Instance, error) {
+	projectsData, err := s.LoadProjects()
+	if err != nil {
+		return nil, err
+	}
+	projectIDs := []string{""}
+	for _, p := range projectsData.Projects {
+		projectIDs = append(projectIDs, p.ID)
+	}
+
+	s.global = false
+	owners := make(map[string]string)
+	var all []*Instance
+	for _, projectID := range projectIDs {
+		err := s.inProject(projectID, func() error {
+			instances, err := s.Load()
+			if err != nil {
+				return err
+			}
+			for _, inst := range instances {
+				owners[inst.ID] = projectID
+			}
+			all = append(all, instances...)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to load project sessions: %w", err)
+		}
+	}
+	s.global = true
+	s.owners = owners
+	// Each session is saved to its own project, which the guard doesn't cover
+	s.StopWatching()
+	return all, nil
+}
+
+// LeaveGlobal switches back to the active project's own session list
+func (s *Storage) LeaveGlobal() {
+	s.global = false
+	s.owners = nil
+}
+
+// IsGlobal reports whether the cross-project list is active
+func (s *Storage) IsGlobal() bool {
+	return s.global
+}
+
+// ProjectOf returns the project a session is stored in ("" = no project)
+func (s *Storage) ProjectOf(id string) string {
+	if owner, ok := s.owners[id]; ok {
+		return owner
+	}
+	return s.projectID
+}
+
+// routeOf returns the project a session change must be written to when that is
+// not the active project's own list (all projects list, or a session filed elsewhere)
+func (s *Storage) routeOf(id string) (string, bool) {
+	owner, ok := s.owners[id]
+	if s.global {
+		return s.ProjectOf(id), true
+	}
+	return owner, ok && owner != s.projectID
+}
+
+// inProject runs fn with the storage pointed at a project's session file
+func (s *Storage) inProject(projectID string, fn func() error) error {
+	projectIDBefore, configPathBefore, globalBefore := s.projectID, s.configPath, s.global
+	defer func() {
+		s.projectID, s.configPath, s.global = projectIDBefore, configPathBefore, globalBefore
+	}()
+	s.global = false
+	if err := s.SetActiveProject(projectID); err != nil {
+		return err
+	}
+	return fn()
+}
+
+// saveGlobal writes the cross-project list back, each session to its own project
+// (keeping every project's order, groups and settings)
+func (s *Storage) saveGlobal(instances []*Instance) error {
+	byProject := map[string][]*Instance{s.projectID: {}}
+	for _, owner := range s.owners {
+		byProject[owner] = []*Instance{}
+	}
+	for _, inst := range instances {
+		owner := s.ProjectOf(inst.ID)
+		byProject[owner] = append(byProject[owner], inst)
+	}
+	for projectID, projectInstances := range byProject {
+		projectInstances := projectInstances
+		err := s.inProject(projectID, func() error {
+			// Keep sessions added by another asmgr since the list was loaded
+			current, _ := s.Load()
+			for _, inst := range current {
+				if _, known := s.owners[inst.ID]; !known {
+					projectInstances = append(projectInstances, inst)
+				}
+			}
+			return s.Save(projectInstances)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,267 @@
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// HandoverMaxCommits caps the commits listed in a handover document
+const HandoverMaxCommits = 20
+
+// HandoverPrompt is sent to a session started from a handover document (%s = document path)
+const HandoverPrompt = "Read the handover document at %s and continue the work described there. Start with the open items of its todo list."
+
+// Todo is one item of an agent's todo list
+type Todo struct {
+	Content string `json:"content"`
+	Status  string `json:"status"` // pending, in_progress or completed
+}
+
+// FileChange is the line counts of one changed file
+type FileChange struct {
+	Path    string
+	Added   int
+	Removed int
+}
+
+// HandoverDir returns the directory handover documents are written to
+func HandoverDir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "agent-session-manager", "handovers")
+}
+
+// HandoverPath returns the handover document of a session (rewritten on every handover)
+func (i *Instance) HandoverPath() string {
+	return filepath.Join(HandoverDir(), i.ID+".md")
+}
+
+// conversationID returns the agent conversation the session continues: the
+// stored resume ID, or the newest Claude transcript of its project
+func (i *Instance) conversationID() string {
+	if i.ResumeSessionID != "" {
+		return i.ResumeSessionID
+	}
+	if path := i.ClaudeTranscriptPath(); path != "" {
+		return strings.TrimSuffix(filepath.Base(path), ".jsonl")
+	}
+	return ""
+}
+
+// Todos returns the latest todo list the agent wrote (Claude's TodoWrite tool), nil if none
+func (i *Instance) Todos() []Todo {
+	path := i.ClaudeTranscriptPath()
+	if path == "" {
+		return nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	var todos []Todo
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if !strings.Contains(string(line), `"TodoWrite"`) {
+			continue
+		}
+		var entry struct {
+			Message struct {
+				Content []struct {
+					Type  string `json:"type"`
+					Name  string `json:"name"`
+					Input struct {
+						Todos []Todo `json:"todos"`
+					} `json:"input"`
+				} `json:"content"`
+			} `json:"message"`
+		}
+		if json.Unmarshal(line, &entry) != nil {
+			continue
+		}
+		for _, block := range entry.Message.Content {
+			if block.Type == "tool_use" && block.Name == "TodoWrite" {
+				todos = block.Input.Todos
+			}
+		}
+	}
+	return todos
+}
+
+// handoverBase returns the commit changes are counted from: the session's
+// start commit, or HEAD (uncommitted changes only) when it has none
+func (i *Instance) handoverBase() string {
+	if i.BaseCommitSHA != "" {
+		return i.BaseCommitSHA
+	}
+	return "HEAD"
+}
+
+// ChangedFiles returns the line counts of the files changed since the session started
+func (i *Instance) ChangedFiles() ([]FileChange, error) {
+	if !i.isGitRepo() {
+		return nil, fmt.Errorf("not a git repository")
+	}
+	i.stageUntrackedFiles()
+	output, err := exec.Command("git", "-C", i.Path, "--no-pager", "diff", "--numstat", i.handoverBase()).Output()
+	if err != nil {
+		return nil, fmt.Errorf("git diff failed: %w", err)
+	}
+	var files []FileChange
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		fields := strings.SplitN(line, "\t", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		f := FileChange{Path: fields[2]}
+		// Binary files show "-" instead of counts
+		fmt.Sscan(fields[0], &f.Added)
+		fmt.Sscan(fields[1], &f.Removed)
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// commitsSinceStart returns the one-line commits made since the session started (newest first)
+func (i *Instance) commitsSinceStart() []string {
+	if i.BaseCommitSHA == "" {
+		return nil
+	}
+	output, err := exec.Command("git", "-C", i.Path, "log", "--oneline", fmt.Sprintf("-%d", HandoverMaxCommits), i.BaseCommitSHA+"..HEAD").Output()
+	if err != nil || strings.TrimSpace(string(output)) == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSpace(string(output)), "\n")
+}
+
+// ResumeCommand returns the shell command that continues the session's conversation in its directory
+func (i *Instance) ResumeCommand() string {
+	var agentCmd string
+	if i.Agent == AgentCustom {
+		agentCmd = i.CustomCommand
+	} else {
+		id := ""
+		if i.resumeSupported() {
+			id = i.conversationID()
+		}
+		agentCmd = agentLaunchCommand(i.GetAgentConfig(), false, id) + i.agentFlags(i.Agent, i.Model) + launchFlagsSuffix(i.sandboxedFlags(i.LaunchFlags))
+	}
+	return "cd " + ShellQuote(i.Path) + " && " + agentCmd
+}
+
+// latestSummary returns the newest summary added to the notes with S ("" if none)
+func latestSummary(notes string) string {
+	idx := strings.LastIndex(notes, "] Summary: ")
+	if idx == -1 {
+		return ""
+	}
+	summary := notes[idx+len("] Summary: "):]
+	if end := strings.Index(summary, "\n\n"); end != -1 {
+		summary = summary[:end]
+	}
+	return strings.TrimSpace(summary)
+}
+
+// Handover compiles a Markdown document another person or a fresh agent
+// session can pick the work up from. summary is used when set, otherwise the
+// newest summary in the notes.
+func (i *Instance) Handover(summary string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Handover: %s\n\n", i.Name)
+	fmt.Fprintf(&b, "- Project: `%s`\n", i.Path)
+	fmt.Fprintf(&b, "- Agent: %s\n", i.Agent)
+	if branch := CurrentBranch(i.Path); branch != "" {
+		fmt.Fprintf(&b, "- Branch: `%s`\n", branch)
+	}
+	if last := i.LastActivity(); !last.IsZero() {
+		fmt.Fprintf(&b, "- Last activity: %s\n", last.Format("2006-01-02 15:04"))
+	}
+	fmt.Fprintf(&b, "- Written: %s\n\n", time.Now().Format("2006-01-02 15:04"))
+
+	b.WriteString("## Summary\n\n")
+	if summary == "" {
+		summary = latestSummary(i.Notes)
+	}
+	if summary == "" {
+		summary = "_No summary yet - summarize the session (S) or run `asmgr handover --summarize`._"
+	}
+	b.WriteString(summary + "\n\n")
+
+	if todos := i.Todos(); len(todos) > 0 {
+		b.WriteString("## Todo list\n\n")
+		for _, todo := range todos {
+			switch todo.Status {
+			case "completed":
+				fmt.Fprintf(&b, "- [x] %s\n", todo.Content)
+			case "in_progress":
+				fmt.Fprintf(&b, "- [ ] %s (in progress)\n", todo.Content)
+			default:
+				fmt.Fprintf(&b, "- [ ] %s\n", todo.Content)
+			}
+		}
+		b.WriteString("\n")
+	}
+
+	if files, err := i.ChangedFiles(); err == nil {
+		b.WriteString("## Changes\n\n")
+		since := "uncommitted"
+		if i.BaseCommitSHA != "" {
+			since = "since `" + shortSHA(i.BaseCommitSHA) + "` (session start)"
+		}
+		added, removed := 0, 0
+		for _, f := range files {
+			added += f.Added
+			removed += f.Removed
+		}
+		fmt.Fprintf(&b, "%d file(s) changed %s, +%d -%d\n\n", len(files), since, added, removed)
+		if len(files) > 0 {
+			b.WriteString("| File | + | - |\n|---|---:|---:|\n")
+			for _, f := range files {
+				fmt.Fprintf(&b, "| `%s` | %d | %d |\n", f.Path, f.Added, f.Removed)
+			}
+			b.WriteString("\n")
+		}
+		if commits := i.commitsSinceStart(); len(commits) > 0 {
+			b.WriteString("Commits:\n\n")
+			for _, c := range commits {
+				fmt.Fprintf(&b, "- %s\n", c)
+			}
+			b.WriteString("\n")
+		}
+	}
+
+	if notes := strings.TrimSpace(i.Notes); notes != "" {
+		b.WriteString("## Notes\n\n" + notes + "\n\n")
+	}
+
+	b.WriteString("## Resume\n\n```sh\n" + i.ResumeCommand() + "\n```\n")
+	return b.String()
+}
+
+// shortSHA abbreviates a commit hash
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// WriteHandover writes the handover document to HandoverPath and returns the path
+func (i *Instance) WriteHandover(summary string) (string, error) {
+	path := i.HandoverPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(i.Handover(summary)), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
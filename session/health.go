@@ -0,0 +1,113 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SessionHealth is the cached status of one session
+type SessionHealth struct {
+	Activity   string    `json:"activity,omitempty"`    // busy, waiting or idle
+	Error      bool      `json:"error,omitempty"`       // Status line shows an error
+	LastActive time.Time `json:"last_active,omitempty"` // Last output seen
+}
+
+// ProjectHealth is the cached status of a project's sessions, written while the project is open
+type ProjectHealth struct {
+	Sessions  map[string]SessionHealth `json:"sessions"` // By session ID
+	UpdatedAt time.Time                `json:"updated_at"`
+}
+
+// HealthSummary is what the project selector shows for a project
+type HealthSummary struct {
+	Running    int
+	Waiting    int
+	Errors     int
+	LastActive time.Time
+}
+
+// getHealthPath returns the status cache file path for a project
+func (s *Storage) getHealthPath(projectID string) string {
+	if projectID == "" {
+		return filepath.Join(s.configDir, "health.json")
+	}
+	return filepath.Join(s.configDir, "projects", projectID, "health.json")
+}
+
+// LoadProjectHealth reads a project's status cache (empty when it was never written)
+func (s *Storage) LoadProjectHealth(projectID string) ProjectHealth {
+	var health ProjectHealth
+	data, err := os.ReadFile(s.getHealthPath(projectID))
+	if err == nil {
+		json.Unmarshal(data, &health)
+	}
+	return health
+}
+
+// SaveProjectHealth writes a project's status cache
+func (s *Storage) SaveProjectHealth(projectID string, health ProjectHealth) error {
+	data, err := json.Marshal(health)
+	if err != nil {
+		return fmt.Errorf("failed to marshal health: %w", err)
+	}
+	path := s.getHealthPath(projectID)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create project directory: %w", err)
+	}
+	// Write through a temp file so the selector never reads a partial file
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write health: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// LiveSessions returns the running tmux sessions with their last activity time
+func LiveSessions() map[string]time.Time {
+	live := make(map[string]time.Time)
+	out, err := Tmux.Output("list-sessions", "-F", "#{session_name} #{session_activity}")
+	if err != nil {
+		return live // No tmux server
+	}
+	for _, line := range strings.Split(string(out), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		secs, _ := strconv.ParseInt(fields[1], 10, 64)
+		live[fields[0]] = time.Unix(secs, 0)
+	}
+	return live
+}
+
+// Summary counts the cached sessions that are still running. The cached
+// activity and error flags are used for those; running state and last
+// activity come from tmux, so they stay current while the project is closed.
+func (h ProjectHealth) Summary(live map[string]time.Time) HealthSummary {
+	var summary HealthSummary
+	for id, s := range h.Sessions {
+		if s.LastActive.After(summary.LastActive) {
+			summary.LastActive = s.LastActive
+		}
+		active, ok := live[id] // tmux session name is the instance ID
+		if !ok {
+			continue
+		}
+		summary.Running++
+		if s.Activity == ActivityWaiting.String() {
+			summary.Waiting++
+		}
+		if s.Error {
+			summary.Errors++
+		}
+		if active.After(summary.LastActive) {
+			summary.LastActive = active
+		}
+	}
+	return summary
+}
@@ -8,7 +8,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -28,7 +27,8 @@ type HistoryEntry struct {
 	Timestamp   time.Time
 	Score       int    // Relevance score for sorting
 	SessionFile string // Full path to session file (for Claude - to load conversation)
-	SessionID   string // Claude session ID (for resume)
+	SessionID   string // Agent session ID (for resume)
+	DBPath      string // OpenCode database holding the session
 }
 
 // ConversationMessage represents a single message in a conversation
@@ -49,6 +49,12 @@ func (e *HistoryEntry) LoadConversation() ([]ConversationMessage, error) {
 		return e.loadGeminiConversation()
 	}
 
+	// Handle Codex rollout files (JSONL format)
+	if e.Agent == AgentCodex {
+		_, _, messages := readCodexRollout(e.SessionFile)
+		return messages, nil
+	}
+
 	// Handle Claude sessions (JSONL format)
 	file, err := os.Open(e.SessionFile)
 	if err != nil {
@@ -144,6 +150,11 @@ func NewHistoryIndex() *HistoryIndex {
 	}
 }
 
+// NewHistoryIndexFrom creates an index over given entries instead of the agents' history
+func NewHistoryIndexFrom(entries []HistoryEntry) *HistoryIndex {
+	return &HistoryIndex{entries: entries, loaded: true}
+}
+
 // SetInstances sets the live instances for terminal search
 func (h *HistoryIndex) SetInstances(instances []*Instance) {
 	h.instances = instances
@@ -171,6 +182,9 @@ func (h *HistoryIndex) Load() error {
 	geminiEntries := h.parseGeminiHistory()
 	h.entries = append(h.entries, geminiEntries...)
 
+	codexEntries := h.parseCodexHistory()
+	h.entries = append(h.entries, codexEntries...)
+
 	terminalEntries := h.parseTerminalHistory()
 	h.entries = append(h.entries, terminalEntries...)
 
@@ -393,16 +407,19 @@ func (h *HistoryIndex) parseClaudeHistory() []HistoryEntry {
 
 	// Build set of Claude directory names from ASMGR instance paths
 	// Claude uses URL-encoded paths like: -home-izll-NetBeansProjects-project
+	// Both the path as stored and its canonical form (symlinks resolved, trailing
+	// slash dropped) are known, since Claude names the directory after its cwd
+	ignoreCase := caseInsensitiveFS(claudeDir)
 	knownDirs := make(map[string]bool)
 	for _, inst := range h.instances {
 		if inst.Path != "" {
-			// Convert path to Claude's directory naming: /home/izll/foo -> -home-izll-foo
-			claudeDirName := strings.ReplaceAll(inst.Path, "/", "-")
-			if strings.HasPrefix(claudeDirName, "-") {
-				claudeDirName = claudeDirName[1:] // Remove leading dash
+			for _, path := range []string{filepath.Clean(inst.Path), CanonicalPath(inst.Path)} {
+				name := claudeDirName(path)
+				if ignoreCase {
+					name = strings.ToLower(name)
+				}
+				knownDirs[name] = true
 			}
-			claudeDirName = "-" + claudeDirName // Add back single leading dash
-			knownDirs[claudeDirName] = true
 		}
 	}
 
@@ -413,7 +430,11 @@ func (h *HistoryIndex) parseClaudeHistory() []HistoryEntry {
 				continue
 			}
 			// Only process if this directory matches an ASMGR session
-			if !knownDirs[dir.Name()] {
+			name := dir.Name()
+			if ignoreCase {
+				name = strings.ToLower(name)
+			}
+			if !knownDirs[name] {
 				continue
 			}
 			projPath := filepath.Join(projectsDir, dir.Name())
@@ -609,7 +630,7 @@ func (h *HistoryIndex) parseOpenCodeDBFile(dbPath, projectPath string) []History
 	// Query messages with session info (both user and assistant)
 	// Note: created_at is Unix timestamp in milliseconds, parts is JSON
 	query := `
-		SELECT m.parts, m.role, m.created_at, s.title
+		SELECT m.session_id, m.parts, m.role, m.created_at, s.title
 		FROM messages m
 		LEFT JOIN sessions s ON m.session_id = s.id
 		WHERE m.role IN ('user', 'assistant')
@@ -624,10 +645,10 @@ func (h *HistoryIndex) parseOpenCodeDBFile(dbPath, projectPath string) []History
 	defer rows.Close()
 
 	for rows.Next() {
-		var partsJSON, role string
+		var sessionID, partsJSON, role string
 		var createdAtMs int64
 		var title sql.NullString
-		if err := rows.Scan(&partsJSON, &role, &createdAtMs, &title); err == nil {
+		if err := rows.Scan(&sessionID, &partsJSON, &role, &createdAtMs, &title); err == nil {
 			// Convert Unix milliseconds to time
 			ts := time.UnixMilli(createdAtMs)
 
@@ -655,6 +676,8 @@ func (h *HistoryIndex) parseOpenCodeDBFile(dbPath, projectPath string) []History
 				Snippet:   snippet,
 				Path:      path,
 				Timestamp: ts,
+				SessionID: sessionID,
+				DBPath:    dbPath,
 			})
 		}
 	}
@@ -693,10 +716,12 @@ func (h *HistoryIndex) buildGeminiHashMap() map[string]string {
 		if inst.Path == "" {
 			continue
 		}
-		// Compute SHA256 hash of the path (same as Gemini does)
-		hash := sha256.Sum256([]byte(inst.Path))
-		hashStr := hex.EncodeToString(hash[:])
-		hashMap[hashStr] = inst.Path
+		// Compute SHA256 hash of the path (same as Gemini does), for the
+		// path as stored and its canonical form
+		for _, path := range []string{inst.Path, filepath.Clean(inst.Path), CanonicalPath(inst.Path)} {
+			hash := sha256.Sum256([]byte(path))
+			hashMap[hex.EncodeToString(hash[:])] = inst.Path
+		}
 	}
 	return hashMap
 }
@@ -848,7 +873,7 @@ func (h *HistoryIndex) parseTerminalHistory() []HistoryEntry {
 
 			// Capture tmux pane content (last 500 lines)
 			target := fmt.Sprintf("%s:%d", inst.TmuxSessionName(), fw.Index)
-			output, err := captureTerminalPane(target, 500)
+			output, err := captureTerminalPane(inst.Tmux(), target, 500)
 			if err != nil || strings.TrimSpace(output) == "" {
 				continue
 			}
@@ -878,9 +903,8 @@ func (h *HistoryIndex) parseTerminalHistory() []HistoryEntry {
 }
 
 // captureTerminalPane captures the scrollback buffer from a tmux pane
-func captureTerminalPane(target string, lines int) (string, error) {
-	cmd := exec.Command("tmux", "capture-pane", "-t", target, "-p", "-S", fmt.Sprintf("-%d", lines))
-	output, err := cmd.Output()
+func captureTerminalPane(tmux Runner, target string, lines int) (string, error) {
+	output, err := tmux.Output("capture-pane", "-t", target, "-p", "-S", fmt.Sprintf("-%d", lines))
 	if err != nil {
 		return "", err
 	}
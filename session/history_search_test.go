@@ -0,0 +1,49 @@
+package session
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+)
+
+// benchWords make up the generated history entries
+var benchWords = strings.Fields("refactor the parser add tests fix flaky login handler update docs migrate database " +
+	"schema review pull request bump dependencies cache invalidation retry logic rate limit error budget")
+
+// benchHistory generates n history entries of a few hundred characters each
+func benchHistory(n int) []HistoryEntry {
+	entries := make([]HistoryEntry, n)
+	now := time.Now()
+	for i := range entries {
+		var content strings.Builder
+		for j := 0; j < 40; j++ {
+			content.WriteString(benchWords[(i*13+j*7)%len(benchWords)])
+			content.WriteString(" ")
+		}
+		entries[i] = HistoryEntry{
+			ID:        fmt.Sprintf("entry-%d", i),
+			Agent:     AgentClaude,
+			Content:   content.String(),
+			Path:      "/tmp/bench",
+			Timestamp: now.Add(-time.Duration(i) * time.Minute),
+		}
+	}
+	return entries
+}
+
+// BenchmarkHistorySearch times the global history search over 1,000 to 50,000
+// entries, with a substring hit and a typo that falls back to fuzzy search
+func BenchmarkHistorySearch(b *testing.B) {
+	for _, n := range []int{1000, 10000, 50000} {
+		index := NewHistoryIndexFrom(benchHistory(n))
+		for _, query := range []string{"rate limit", "ratelimt"} {
+			b.Run(fmt.Sprintf("%d/%s", n, strings.ReplaceAll(query, " ", "_")), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					index.Search(query)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package session
+
+import (
+	"strings"
+	"time"
+)
+
+// IdleStopNever turns idle auto-stop off for one session, whatever config.toml says
+const IdleStopNever = -1
+
+// IdleStopChoices are the per-session timeouts Ctrl+T cycles through (minutes;
+// 0 = idle_stop_minutes of config.toml, IdleStopNever = off)
+var IdleStopChoices = []int{0, 15, 30, 60, 120, 240, IdleStopNever}
+
+// IdleStopAfter returns how long the session may stay idle before it is stopped
+// (0 = never); global is idle_stop_minutes of config.toml
+func (i *Instance) IdleStopAfter(global int) time.Duration {
+	minutes := i.IdleStopMinutes
+	if minutes == 0 {
+		minutes = global
+	}
+	if minutes <= 0 {
+		return 0
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// NextIdleStop returns the per-session timeout after the current one in IdleStopChoices
+func (i *Instance) NextIdleStop() int {
+	for idx, minutes := range IdleStopChoices {
+		if minutes == i.IdleStopMinutes {
+			return IdleStopChoices[(idx+1)%len(IdleStopChoices)]
+		}
+	}
+	return 0
+}
+
+// HasClients reports whether a tmux client is attached to the session (e.g. from another terminal)
+func (i *Instance) HasClients() bool {
+	out, err := i.Tmux().Output("display-message", "-p", "-t", i.TmuxSessionName(), "#{session_attached}")
+	if err != nil {
+		return false
+	}
+	n := strings.TrimSpace(string(out))
+	return n != "" && n != "0"
+}
+
+// StopIdle stops a session that has been idle too long. The conversation to
+// resume is stored first, so starting the session again picks it up.
+func (i *Instance) StopIdle() error {
+	if i.GetAgentConfig().SupportsResume {
+		if resumeID := i.LastResumeID(); resumeID != "" {
+			i.ResumeSessionID = resumeID
+		}
+	}
+	if err := i.Stop(); err != nil {
+		return err
+	}
+	i.IdleStopped = true
+	return nil
+}
@@ -0,0 +1,94 @@
+package session
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// ProjectMatch is the project (and group) a new session's path most likely belongs to
+type ProjectMatch struct {
+	ProjectID   string // "" = no project
+	ProjectName string
+	GroupID     string // Group of the closest session ("" = none)
+	GroupName   string
+	Dir         string // Directory that matched
+}
+
+// pathWithin reports whether path is dir or below it
+func pathWithin(path, dir string) bool {
+	if dir == "" {
+		return false
+	}
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// InferProject finds the project whose directory or sessions contain path.
+// The most specific directory wins; on a tie the active project is preferred.
+// Sessions without a project are only searched when they are the active list,
+// so a session is never suggested to move out of a project.
+func (s *Storage) InferProject(path string) (*ProjectMatch, error) {
+	path = PathKey(path)
+	projectsData, err := s.LoadProjects()
+	if err != nil {
+		return nil, err
+	}
+
+	// Active project first so it wins ties
+	candidates := []*Project{{ID: s.projectID}}
+	for _, p := range projectsData.Projects {
+		if p.ID == s.projectID {
+			candidates[0] = p
+		} else {
+			candidates = append(candidates, p)
+		}
+	}
+
+	var best *ProjectMatch
+	consider := func(p *Project, dir, groupID string, groups []*Group) {
+		dir = PathKey(dir)
+		if !pathWithin(path, dir) || (best != nil && len(dir) <= len(best.Dir)) {
+			return
+		}
+		best = &ProjectMatch{ProjectID: p.ID, ProjectName: p.Name, Dir: dir}
+		for _, g := range groups {
+			if g.ID == groupID {
+				best.GroupID, best.GroupName = g.ID, g.Name
+				break
+			}
+		}
+	}
+
+	for _, p := range candidates {
+		var instances []*Instance
+		var groups []*Group
+		err := s.inProject(p.ID, func() error {
+			var err error
+			instances, groups, _, err = s.LoadAllWithSettings()
+			return err
+		})
+		if err != nil {
+			continue
+		}
+		if p.Path != "" {
+			consider(p, expandTilde(p.Path), "", groups)
+		}
+		for _, inst := range instances {
+			consider(p, inst.Path, inst.GroupID, groups)
+		}
+	}
+	return best, nil
+}
+
+// AddInstanceTo adds a session to another project's session list. Later
+// changes to the session are saved to that project.
+func (s *Storage) AddInstanceTo(projectID string, instance *Instance) error {
+	if projectID == s.projectID {
+		return s.AddInstance(instance)
+	}
+	if s.owners == nil {
+		s.owners = make(map[string]string)
+	}
+	s.owners[instance.ID] = projectID
+	return s.inProject(projectID, func() error { return s.AddInstance(instance) })
+}
@@ -7,9 +7,11 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/izll/agent-session-manager/session/events"
 	"github.com/izll/agent-session-manager/session/filters"
 	"github.com/mattn/go-runewidth"
 )
@@ -30,6 +32,9 @@ const (
 	StatusStopped Status = "stopped"
 )
 
+// SnapshotLines is how many lines of output are kept when a session stops
+const SnapshotLines = 20
+
 // AgentType represents the type of AI agent
 type AgentType string
 
@@ -47,12 +52,14 @@ const (
 
 // AgentConfig contains configuration for each agent type
 type AgentConfig struct {
-	Command            string // Base command to run
-	SupportsResume     bool   // Whether agent supports session resume
-	SupportsAutoYes    bool   // Whether agent has auto-approve flag
-	AutoYesFlag        string // The flag for auto-approve (e.g., "--dangerously-skip-permissions")
-	ResumeFlag         string // The flag for resume (e.g., "--resume")
-	ResumeIsSubcommand bool   // If true, resume is a subcommand (e.g., "codex resume") not a flag
+	Command            string   // Base command to run
+	SupportsResume     bool     // Whether agent supports session resume
+	SupportsAutoYes    bool     // Whether agent has auto-approve flag
+	AutoYesFlag        string   // The flag for auto-approve (e.g., "--dangerously-skip-permissions")
+	ResumeFlag         string   // The flag for resume (e.g., "--resume")
+	ResumeIsSubcommand bool     // If true, resume is a subcommand (e.g., "codex resume") not a flag
+	ModelFlag          string   // The flag that selects the model (e.g., "--model"); "" = not supported
+	Models             []string // Models offered by the model picker (others can be typed)
 }
 
 // AgentConfigs maps agent types to their configurations
@@ -63,18 +70,23 @@ var AgentConfigs = map[AgentType]AgentConfig{
 		SupportsAutoYes: true,
 		AutoYesFlag:     "--dangerously-skip-permissions",
 		ResumeFlag:      "--resume",
+		ModelFlag:       "--model",
+		Models:          []string{"opus", "sonnet", "haiku"},
 	},
 	AgentGemini: {
 		Command:         "gemini",
 		SupportsResume:  true,
 		SupportsAutoYes: false,
 		ResumeFlag:      "--resume",
+		ModelFlag:       "--model",
+		Models:          []string{"gemini-2.5-pro", "gemini-2.5-flash", "gemini-2.5-flash-lite"},
 	},
 	AgentAider: {
 		Command:         "aider",
 		SupportsResume:  false,
 		SupportsAutoYes: true,
 		AutoYesFlag:     "--yes",
+		ModelFlag:       "--model",
 	},
 	AgentCodex: {
 		Command:            "codex",
@@ -83,6 +95,8 @@ var AgentConfigs = map[AgentType]AgentConfig{
 		AutoYesFlag:        "--full-auto",
 		ResumeFlag:         "resume",
 		ResumeIsSubcommand: true,
+		ModelFlag:          "--model",
+		Models:             []string{"gpt-5-codex", "gpt-5", "o3", "o4-mini", "gpt-4.1"},
 	},
 	AgentAmazonQ: {
 		Command:            "q",
@@ -97,6 +111,7 @@ var AgentConfigs = map[AgentType]AgentConfig{
 		SupportsResume:  true,
 		SupportsAutoYes: false,
 		ResumeFlag:      "--session",
+		ModelFlag:       "--model",
 	},
 	AgentCursor: {
 		Command:         "cursor",
@@ -129,6 +144,43 @@ type Instance struct {
 	FollowedWindows []FollowedWindow `json:"followed_windows,omitempty"`  // Windows tracked as agents (window 0 is main agent)
 	BaseCommitSHA   string           `json:"base_commit_sha,omitempty"`   // Git HEAD commit at session start (for diff)
 	Favorite        bool             `json:"favorite,omitempty"`          // Whether session is marked as favorite
+	LastOutput      string           `json:"last_output,omitempty"`       // Last lines of output captured at stop time
+	StoppedAt       time.Time        `json:"stopped_at,omitempty"`        // When LastOutput was captured
+	Protected       bool             `json:"protected,omitempty"`         // Deletion requires typing the session name; skipped by bulk deletes
+	Sandbox         string           `json:"sandbox,omitempty"`           // Sandbox wrapper name; sandboxed sessions never use auto-yes
+	Timezone        string           `json:"timezone,omitempty"`          // IANA timezone for client-local times (e.g. "America/New_York")
+	ScheduledPrompts []ScheduledPrompt `json:"scheduled_prompts,omitempty"` // Prompts queued to be sent later
+	Alerts          map[AlertEvent]string `json:"alerts,omitempty"`         // Sound per activity event ("bell" or a sound file path)
+	Recording       bool             `json:"recording,omitempty"`         // Record the agent pane to an asciicast file (resumed on start)
+	OutputLog       bool             `json:"output_log,omitempty"`        // Append new output lines to logs/<id>.log (resumed on start)
+	YoloGrant       *YoloGrant       `json:"yolo_grant,omitempty"`        // Time or approval limit of the main window's auto-yes
+	HistoryLimit    int              `json:"history_limit,omitempty"`     // Scrollback lines per window (0 = config.toml or DefaultHistoryLimit)
+	Environment     *Environment     `json:"environment,omitempty"`       // What the current run was started with
+	Worktree        *Worktree        `json:"worktree,omitempty"`          // Dedicated git worktree of a parallel session
+	RestartPolicy   RestartPolicy    `json:"restart_policy,omitempty"`    // Restart the agent when it exits (never, on-crash, always)
+	Restarts        int              `json:"restarts,omitempty"`          // Automatic restarts so far
+	Preset          string           `json:"preset,omitempty"`            // ID of the preset the session was created from
+	IdleStopMinutes int              `json:"idle_stop_minutes,omitempty"` // Stop after this long idle (0 = config.toml, -1 = never)
+	IdleStopped     bool             `json:"idle_stopped,omitempty"`      // The last stop was the idle auto-stop
+	PreviewFilter   string           `json:"preview_filter,omitempty"`    // Preview filter pipeline ("" = agent default, "off" = raw output)
+	ParentID        string           `json:"parent_id,omitempty"`         // Session this one was forked or started in parallel from
+	ChildrenCollapsed bool           `json:"children_collapsed,omitempty"` // Sessions nested under this one are hidden
+	Host            string           `json:"host,omitempty"`              // SSH host the session's tmux runs on ("" = local)
+	Runtime         string           `json:"runtime,omitempty"`           // Where the agent runs: "" = host, "docker" or "devcontainer"
+	Container       string           `json:"container,omitempty"`         // Docker container the agent is exec'd into (docker runtime)
+	ReadinessChecks []ReadinessCheck `json:"readiness_checks,omitempty"`  // Services that must be up before the agent starts or a scheduled prompt is sent
+	MainSplit       string           `json:"main_split,omitempty"`        // Terminal pane beside the main agent: "h" (right) or "v" (below)
+	Permissions     *PermissionStats `json:"permissions,omitempty"`       // Permission prompts the agent showed, by kind
+	Env             map[string]string `json:"env,omitempty"`              // Variables exported into every window of the session
+	Profile         string           `json:"profile,omitempty"`           // Agent profile from config.toml the agent is launched with
+	LaunchFlags     []string         `json:"launch_flags,omitempty"`      // Extra flags appended to the main agent's command
+	Model           string           `json:"model,omitempty"`             // Model the main agent is started with ("" = profile or agent default)
+	StartedAt       time.Time        `json:"started_at,omitempty"`        // When the current run started (zero = not running)
+	EndedAt         time.Time        `json:"ended_at,omitempty"`          // When the last run ended
+	RunSeconds      int64            `json:"run_seconds,omitempty"`       // Running time of the finished runs
+	MCPDisabled     []string         `json:"mcp_disabled,omitempty"`      // MCP servers switched off for the session's Claude windows
+
+	aliveAt time.Time // Last time UpdateStatus found the tmux session (not saved)
 }
 
 // DiffStats contains git diff statistics and content
@@ -136,6 +188,7 @@ type DiffStats struct {
 	Added   int    // Number of added lines
 	Removed int    // Number of removed lines
 	Content string // Raw diff content
+	BaseRef string // Commit the diff was taken against ("" = uncommitted changes)
 	Error   error  // Error if diff failed
 }
 
@@ -146,13 +199,18 @@ func (d *DiffStats) IsEmpty() bool {
 
 // FollowedWindow represents a tmux window tracked as an agent
 type FollowedWindow struct {
-	Index           int       `json:"index"`
-	Agent           AgentType `json:"agent"`
-	Name            string    `json:"name"`              // Tab name for display
-	CustomCommand   string    `json:"custom_command"`    // For custom agents
-	AutoYes         bool      `json:"auto_yes"`          // YOLO mode for this tab
-	ResumeSessionID string    `json:"resume_session_id"` // Resume session ID for this tab
-	Notes           string    `json:"notes,omitempty"`   // User notes for this tab
+	Index           int        `json:"index"`
+	Agent           AgentType  `json:"agent"`
+	Name            string     `json:"name"`                 // Tab name for display
+	CustomCommand   string     `json:"custom_command"`       // For custom agents
+	AutoYes         bool       `json:"auto_yes"`             // YOLO mode for this tab
+	ResumeSessionID string     `json:"resume_session_id"`    // Resume session ID for this tab
+	Notes           string     `json:"notes,omitempty"`      // User notes for this tab
+	YoloGrant       *YoloGrant `json:"yolo_grant,omitempty"` // Time or approval limit of this tab's auto-yes
+	Split           string     `json:"split,omitempty"`      // Terminal pane beside the agent: "h" (right) or "v" (below)
+	Env             map[string]string `json:"env,omitempty"` // Variables of this tab, on top of the session's
+	LaunchFlags     []string   `json:"launch_flags,omitempty"` // Extra flags appended to this tab's agent command
+	Model           string     `json:"model,omitempty"`        // Model this tab's agent is started with ("" = default)
 }
 
 // GetAgentConfig returns the agent configuration for this instance
@@ -193,6 +251,16 @@ func expandTilde(path string) string {
 }
 
 func NewInstance(name, path string, autoYes bool, agent AgentType) (*Instance, error) {
+	// host:path runs the session's tmux on that host over SSH
+	if host, remotePath, ok := SplitRemotePath(path); ok {
+		inst := newInstance(name, remotePath, autoYes, agent)
+		inst.Host = host
+		if err := inst.resolveRemotePath(); err != nil {
+			return nil, err
+		}
+		return inst, nil
+	}
+
 	// Expand ~ to home directory
 	path = expandTilde(path)
 
@@ -205,19 +273,22 @@ func NewInstance(name, path string, autoYes bool, agent AgentType) (*Instance, e
 		return nil, fmt.Errorf("path does not exist: %s", absPath)
 	}
 
-	id := generateID(name, agent)
-	now := time.Now()
+	return newInstance(name, absPath, autoYes, agent), nil
+}
 
+// newInstance creates a stopped instance for a checked path
+func newInstance(name, path string, autoYes bool, agent AgentType) *Instance {
+	now := time.Now()
 	return &Instance{
-		ID:        id,
+		ID:        generateID(name, agent),
 		Name:      name,
-		Path:      absPath,
+		Path:      path,
 		Status:    StatusStopped,
 		CreatedAt: now,
 		UpdatedAt: now,
 		AutoYes:   autoYes,
 		Agent:     agent,
-	}, nil
+	}
 }
 
 func generateID(name string, agent AgentType) string {
@@ -254,10 +325,31 @@ func CheckAgentCommand(inst *Instance) error {
 		return fmt.Errorf("no command specified")
 	}
 
-	if _, err := exec.LookPath(cmdToCheck); err != nil {
-		return fmt.Errorf("command '%s' not found - is it installed?", cmdToCheck)
+	if err := inst.checkSandbox(); err != nil {
+		return err
+	}
+	if err := inst.checkRuntime(); err != nil {
+		return err
+	}
+	if w, ok := FindSandboxWrapper(inst.Sandbox); ok && w.InContainer() {
+		// The agent comes from the container image
+		return nil
 	}
 
+	return inst.lookPath(cmdToCheck)
+}
+
+// lookPath checks that a command is installed where the session runs
+func (i *Instance) lookPath(command string) error {
+	if i.Runtime != "" {
+		return i.containerLookPath(command)
+	}
+	if i.IsRemote() {
+		return i.remoteLookPath(command)
+	}
+	if _, err := exec.LookPath(command); err != nil {
+		return fmt.Errorf("command '%s' not found - is it installed?", command)
+	}
 	return nil
 }
 
@@ -265,6 +357,38 @@ func (i *Instance) Start() error {
 	return i.StartWithResume("")
 }
 
+// Snapshot returns a copy of the instance that work off the UI loop (a start,
+// a fork) can use and change while the UI keeps using the instance itself
+func (i *Instance) Snapshot() *Instance {
+	snapshot := *i
+	snapshot.FollowedWindows = append([]FollowedWindow(nil), i.FollowedWindows...)
+	if i.Env != nil {
+		snapshot.Env = make(map[string]string, len(i.Env))
+		for name, value := range i.Env {
+			snapshot.Env[name] = value
+		}
+	}
+	return &snapshot
+}
+
+// ApplyStart takes the run state a start on a snapshot left behind: status,
+// run times, conversation, tabs and what the run was started with
+func (i *Instance) ApplyStart(started *Instance) {
+	i.Status = started.Status
+	i.UpdatedAt = started.UpdatedAt
+	i.StartedAt = started.StartedAt
+	i.EndedAt = started.EndedAt
+	i.RunSeconds = started.RunSeconds
+	i.aliveAt = started.aliveAt
+	i.ResumeSessionID = started.ResumeSessionID
+	i.FollowedWindows = started.FollowedWindows
+	i.BaseCommitSHA = started.BaseCommitSHA
+	i.Environment = started.Environment
+	i.LastOutput = started.LastOutput
+	i.StoppedAt = started.StoppedAt
+	i.IdleStopped = started.IdleStopped
+}
+
 func (i *Instance) StartWithResume(resumeID string) error {
 	// Update status based on actual tmux session state
 	// This handles cases where session was killed externally
@@ -277,122 +401,49 @@ func (i *Instance) StartWithResume(resumeID string) error {
 	sessionName := i.TmuxSessionName()
 
 	// Check if tmux session already exists
-	checkCmd := exec.Command("tmux", "has-session", "-t", sessionName)
-	sessionExists := checkCmd.Run() == nil
+	sessionExists := i.Tmux().Run("has-session", "-t", sessionName) == nil
 
 	if !sessionExists {
-		// Build command based on agent type
-		config := i.GetAgentConfig()
-		var agentCmd string
-		var cmdToCheck string
-
-		if i.Agent == AgentCustom {
-			// Use custom command directly
-			agentCmd = i.CustomCommand
-			// Extract the base command (first word) to check
-			parts := strings.Fields(i.CustomCommand)
-			if len(parts) > 0 {
-				cmdToCheck = parts[0]
-			}
-		} else {
-			cmdToCheck = config.Command
-			args := []string{}
-
-			// Handle resume subcommands (codex resume, q chat --resume) vs flags (claude --resume)
-			if config.SupportsResume && config.ResumeIsSubcommand {
-				// Resume is a subcommand - put it first, then flags, then session ID
-				if resumeID != "" || i.ResumeSessionID != "" {
-					// Add resume subcommand
-					args = append(args, config.ResumeFlag)
-
-					// Add auto-yes flag after subcommand if supported
-					if i.AutoYes && config.SupportsAutoYes && config.AutoYesFlag != "" {
-						args = append(args, config.AutoYesFlag)
-					}
-
-					// Add session ID
-					if resumeID != "" {
-						args = append(args, resumeID)
-						i.ResumeSessionID = resumeID
-					} else if i.ResumeSessionID != "" {
-						args = append(args, i.ResumeSessionID)
-					}
-				} else {
-					// No resume - just add auto-yes flag if needed
-					if i.AutoYes && config.SupportsAutoYes && config.AutoYesFlag != "" {
-						args = append(args, config.AutoYesFlag)
-					}
-				}
-			} else {
-				// Resume is a flag - add auto-yes first, then resume flag
-				// Add auto-yes flag if supported and enabled
-				if i.AutoYes && config.SupportsAutoYes && config.AutoYesFlag != "" {
-					args = append(args, config.AutoYesFlag)
-				}
-
-				// Add resume flag if supported and specified
-				if config.SupportsResume && config.ResumeFlag != "" {
-					if resumeID != "" {
-						args = append(args, config.ResumeFlag, resumeID)
-						i.ResumeSessionID = resumeID
-					} else if i.ResumeSessionID != "" {
-						args = append(args, config.ResumeFlag, i.ResumeSessionID)
-					}
-				}
-			}
+		if err := i.checkSandbox(); err != nil {
+			return err
+		}
+		if err := i.checkRuntime(); err != nil {
+			return err
+		}
+		if err := i.writeMCPConfig(); err != nil {
+			return err
+		}
 
-			agentCmd = config.Command + " " + strings.Join(args, " ")
+		// Build command based on agent type
+		agentCmd, cmdToCheck := i.agentCommand(resumeID)
+		if resumeID != "" && i.resumeSupported() {
+			i.ResumeSessionID = resumeID
 		}
 
 		// Check if the command exists
 		if cmdToCheck != "" {
-			if _, err := exec.LookPath(cmdToCheck); err != nil {
-				return fmt.Errorf("command '%s' not found - is it installed?", cmdToCheck)
+			if err := i.lookPath(cmdToCheck); err != nil {
+				return err
 			}
 		}
 
 		// Create new tmux session
-		cmd := exec.Command("tmux", "new-session", "-d", "-s", sessionName, "-c", i.Path, agentCmd)
-		if err := cmd.Run(); err != nil {
+		if err := i.Tmux().Run(i.newSessionArgs(agentCmd)...); err != nil {
 			return fmt.Errorf("failed to create tmux session: %w", err)
 		}
 
 		// Wait for session to be ready
 		for j := 0; j < 20; j++ {
-			checkCmd := exec.Command("tmux", "has-session", "-t", sessionName)
-			if checkCmd.Run() == nil {
+			if i.Tmux().Run("has-session", "-t", sessionName) == nil {
 				break
 			}
 			time.Sleep(50 * time.Millisecond)
 		}
 
-		// Configure tmux session for better scrolling
-		exec.Command("tmux", "set-option", "-t", sessionName, "history-limit", "50000").Run()
-		exec.Command("tmux", "set-option", "-t", sessionName, "mouse", "on").Run()
-
-		// Use latest client size and aggressive resize for proper terminal following
-		exec.Command("tmux", "set-option", "-t", sessionName, "window-size", "latest").Run()
-		exec.Command("tmux", "set-option", "-t", sessionName, "aggressive-resize", "on").Run()
-
-		// Enable xterm keys for Shift+PageUp/Down support
-		exec.Command("tmux", "set-option", "-t", sessionName, "-g", "xterm-keys", "on").Run()
-
-		// Set terminal overrides for better key support
-		exec.Command("tmux", "set-option", "-t", sessionName, "-ga", "terminal-overrides", ",xterm*:smcup@:rmcup@").Run()
-
-		// Bind Shift+PageUp/Down for scrolling in copy mode
-		exec.Command("tmux", "bind-key", "-T", "root", "S-PageUp", "copy-mode", "-eu").Run()
-		exec.Command("tmux", "bind-key", "-T", "root", "S-PageDown", "send-keys", "PageDown").Run()
-		exec.Command("tmux", "bind-key", "-T", "copy-mode-vi", "S-PageUp", "send-keys", "-X", "page-up").Run()
-		exec.Command("tmux", "bind-key", "-T", "copy-mode-vi", "S-PageDown", "send-keys", "-X", "page-down").Run()
-
-		// Bind Ctrl+Y for yolo mode toggle (passes both session name and window index)
-		exec.Command("tmux", "bind-key", "-n", "C-y", "run-shell", `asmgr yolo "$(tmux display-message -p '#{session_name}')" "$(tmux display-message -p '#{window_index}')" 2>/dev/null`).Run()
-
-		// Ctrl+q will be set up with resize in UpdateDetachBinding
-
-		// Set window 0 name to agent type (session name is shown in status bar)
-		exec.Command("tmux", "rename-window", "-t", sessionName+":0", i.WindowName()).Run()
+		// Configure tmux session (scrolling, resize, key bindings, window name)
+		for _, args := range i.sessionSetupArgs() {
+			i.Tmux().Run(args...)
+		}
 
 		// Check if session is still alive after a short delay (detect immediate exit)
 		time.Sleep(300 * time.Millisecond)
@@ -400,24 +451,49 @@ func (i *Instance) StartWithResume(resumeID string) error {
 			// Session died immediately - try to get output for error message
 			return fmt.Errorf("session exited immediately - check if login or API key is required")
 		}
+
+		// Agent version, model, git and OS this run was started with (read locally)
+		if !i.IsRemote() && i.Runtime == "" {
+			i.captureEnvironment()
+		}
 	}
 
 	i.Status = StatusRunning
 	i.UpdatedAt = time.Now()
+	i.StartedAt = i.UpdatedAt
+
+	// Snapshot from the previous run is stale now
+	i.LastOutput = ""
+	i.StoppedAt = time.Time{}
+	i.IdleStopped = false
 
 	// Save git HEAD commit for diff tracking (if in a git repo)
 	i.saveBaseCommit()
 
-	// Restore followed windows (tabs) if any
-	i.restoreFollowedWindows()
+	// Restore followed windows (tabs) if any, then their pane splits. A tmux
+	// session that was still there has them already.
+	if !sessionExists {
+		i.restoreFollowedWindows()
+		i.restoreSplits()
+	}
+
+	// Keep recording across restarts (a new file per run)
+	if i.Recording {
+		i.StartRecording()
+	}
+	if i.OutputLog {
+		i.StartOutputLog()
+	}
+	i.scheduleYoloExpiries()
 
+	events.Publish(i.Event(events.SessionStarted))
 	return nil
 }
 
 // saveBaseCommit saves the current git HEAD commit SHA for diff tracking
 func (i *Instance) saveBaseCommit() {
 	// Only save if not already set (preserve original base on restart)
-	if i.BaseCommitSHA != "" {
+	if i.BaseCommitSHA != "" || i.IsRemote() {
 		return
 	}
 
@@ -432,7 +508,8 @@ func (i *Instance) saveBaseCommit() {
 	i.BaseCommitSHA = strings.TrimSpace(string(output))
 }
 
-// restoreFollowedWindows recreates agent tabs after session restart
+// restoreFollowedWindows recreates the tabs of a newly created session with
+// their names, agents, auto-yes flags, YOLO limits, notes and conversations
 func (i *Instance) restoreFollowedWindows() {
 	if len(i.FollowedWindows) == 0 {
 		return
@@ -445,57 +522,30 @@ func (i *Instance) restoreFollowedWindows() {
 	i.FollowedWindows = nil
 
 	for _, fw := range oldWindows {
-		var cmd *exec.Cmd
-
-		if fw.Agent == AgentTerminal {
-			// Terminal window - just create empty shell
-			cmd = exec.Command("tmux", "new-window", "-t", sessionName, "-c", i.Path, "-n", fw.Name)
-		} else {
-			// Agent window - build agent command
-			config := AgentConfigs[fw.Agent]
-			var agentCmd string
-
-			if fw.Agent == AgentCustom {
-				agentCmd = fw.CustomCommand
-			} else {
-				args := []string{}
-				if i.AutoYes && config.SupportsAutoYes && config.AutoYesFlag != "" {
-					args = append(args, config.AutoYesFlag)
-				}
-				agentCmd = config.Command
-				if len(args) > 0 {
-					agentCmd = agentCmd + " " + strings.Join(args, " ")
-				}
-			}
-
-			// Create new window with agent command
-			cmd = exec.Command("tmux", "new-window", "-t", sessionName, "-c", i.Path, "-n", fw.Name, agentCmd)
-		}
-
-		if err := cmd.Run(); err != nil {
+		out, err := i.Tmux().Output(i.followedWindowArgs(fw)...)
+		if err != nil {
 			continue // Skip failed windows
 		}
 
 		// Get the new window index
-		newIdx := i.GetCurrentWindowIndex()
+		newIdx, err := strconv.Atoi(strings.TrimSpace(string(out)))
+		if err != nil {
+			newIdx = i.GetCurrentWindowIndex()
+		}
 
 		// Set remain-on-exit so window stays open when command exits (shows as stopped)
 		target := fmt.Sprintf("%s:%d", sessionName, newIdx)
-		exec.Command("tmux", "set-option", "-t", target, "remain-on-exit", "on").Run()
+		i.Tmux().Run("set-option", "-t", target, "remain-on-exit", "on")
 		// Disable automatic-rename so the window keeps the user-specified name
-		exec.Command("tmux", "set-option", "-t", target, "automatic-rename", "off").Run()
+		i.Tmux().Run("set-option", "-t", target, "automatic-rename", "off")
 
 		// Re-add to followed windows with updated index
-		i.FollowedWindows = append(i.FollowedWindows, FollowedWindow{
-			Index:         newIdx,
-			Agent:         fw.Agent,
-			Name:          fw.Name,
-			CustomCommand: fw.CustomCommand,
-		})
+		fw.Index = newIdx
+		i.FollowedWindows = append(i.FollowedWindows, fw)
 	}
 
 	// Switch back to window 0 (main agent)
-	exec.Command("tmux", "select-window", "-t", sessionName+":0").Run()
+	i.Tmux().Run("select-window", "-t", sessionName+":0")
 }
 
 func (i *Instance) Stop() error {
@@ -503,15 +553,19 @@ func (i *Instance) Stop() error {
 		return nil
 	}
 
+	// Keep the last output so the preview isn't blank while stopped
+	i.SaveOutputSnapshot()
+
 	sessionName := i.TmuxSessionName()
-	cmd := exec.Command("tmux", "kill-session", "-t", sessionName)
-	if err := cmd.Run(); err != nil {
+	if err := i.Tmux().Run("kill-session", "-t", sessionName); err != nil {
 		return fmt.Errorf("failed to kill tmux session: %w", err)
 	}
 
 	i.Status = StatusStopped
 	i.UpdatedAt = time.Now()
+	i.EndRun(i.UpdatedAt)
 
+	events.Publish(i.Event(events.SessionStopped))
 	return nil
 }
 
@@ -521,7 +575,7 @@ func (i *Instance) Attach() error {
 	}
 
 	sessionName := i.TmuxSessionName()
-	cmd := exec.Command("tmux", "attach-session", "-t", sessionName)
+	cmd := i.TmuxCommand("attach-session", "-t", sessionName)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -536,8 +590,7 @@ func (i *Instance) NewWindow() error {
 	}
 
 	sessionName := i.TmuxSessionName()
-	cmd := exec.Command("tmux", "new-window", "-t", sessionName, "-c", i.Path)
-	return cmd.Run()
+	return i.Tmux().Run(append([]string{"new-window", "-t", sessionName, "-c", i.Path}, i.envArgs(nil)...)...)
 }
 
 // NewWindowWithName creates a new tmux window with a specific name
@@ -547,8 +600,7 @@ func (i *Instance) NewWindowWithName(name string) error {
 	}
 
 	sessionName := i.TmuxSessionName()
-	cmd := exec.Command("tmux", "new-window", "-t", sessionName, "-c", i.Path, "-n", name)
-	if err := cmd.Run(); err != nil {
+	if err := i.Tmux().Run(append([]string{"new-window", "-t", sessionName, "-c", i.Path, "-n", name}, i.envArgs(nil)...)...); err != nil {
 		return err
 	}
 
@@ -562,9 +614,9 @@ func (i *Instance) NewWindowWithName(name string) error {
 
 	// Set remain-on-exit so window stays open when command exits (shows as stopped)
 	target := fmt.Sprintf("%s:%d", sessionName, newIdx)
-	exec.Command("tmux", "set-option", "-t", target, "remain-on-exit", "on").Run()
+	i.Tmux().Run("set-option", "-t", target, "remain-on-exit", "on")
 	// Disable automatic-rename so the window keeps the user-specified name
-	exec.Command("tmux", "set-option", "-t", target, "automatic-rename", "off").Run()
+	i.Tmux().Run("set-option", "-t", target, "automatic-rename", "off")
 
 	return nil
 }
@@ -587,7 +639,7 @@ func (i *Instance) RespawnWindow(windowIdx int) error {
 			agentCmd = i.CustomCommand
 		} else {
 			args := []string{}
-			if i.AutoYes && config.SupportsAutoYes && config.AutoYesFlag != "" {
+			if i.autoYesEnabled(i.AutoYes) && config.SupportsAutoYes && config.AutoYesFlag != "" {
 				args = append(args, config.AutoYesFlag)
 			}
 			agentCmd = config.Command
@@ -607,7 +659,7 @@ func (i *Instance) RespawnWindow(windowIdx int) error {
 				} else {
 					config := AgentConfigs[fw.Agent]
 					args := []string{}
-					if i.AutoYes && config.SupportsAutoYes && config.AutoYesFlag != "" {
+					if i.autoYesEnabled(fw.AutoYes) && config.SupportsAutoYes && config.AutoYesFlag != "" {
 						args = append(args, config.AutoYesFlag)
 					}
 					agentCmd = config.Command
@@ -621,11 +673,12 @@ func (i *Instance) RespawnWindow(windowIdx int) error {
 	}
 
 	// Respawn the pane with the command
+	args := append([]string{"respawn-pane", "-k", "-t", target}, i.windowEnvArgs(windowIdx)...)
 	if agentCmd != "" {
-		return exec.Command("tmux", "respawn-pane", "-k", "-t", target, agentCmd).Run()
+		return i.Tmux().Run(append(args, i.sandboxCommand(agentCmd))...)
 	}
 	// Empty command = default shell
-	return exec.Command("tmux", "respawn-pane", "-k", "-t", target).Run()
+	return i.Tmux().Run(args...)
 }
 
 // RespawnWindowWithResume restarts a window's process with a specific resume session ID
@@ -633,6 +686,9 @@ func (i *Instance) RespawnWindowWithResume(windowIdx int, resumeID string) error
 	if i.Status != StatusRunning {
 		return fmt.Errorf("instance not running")
 	}
+	if err := i.writeMCPConfig(); err != nil {
+		return err
+	}
 
 	sessionName := i.TmuxSessionName()
 	target := fmt.Sprintf("%s:%d", sessionName, windowIdx)
@@ -641,21 +697,10 @@ func (i *Instance) RespawnWindowWithResume(windowIdx int, resumeID string) error
 	var agentCmd string
 	if windowIdx == 0 {
 		// Main window - use instance's agent
-		config := i.GetAgentConfig()
 		if i.Agent == AgentCustom {
 			agentCmd = i.CustomCommand
 		} else {
-			var args []string
-			if i.AutoYes && config.SupportsAutoYes && config.AutoYesFlag != "" {
-				args = append(args, config.AutoYesFlag)
-			}
-			if config.SupportsResume && config.ResumeFlag != "" && resumeID != "" {
-				args = append(args, config.ResumeFlag, resumeID)
-			}
-			agentCmd = config.Command
-			if len(args) > 0 {
-				agentCmd = agentCmd + " " + strings.Join(args, " ")
-			}
+			agentCmd = agentLaunchCommand(i.GetAgentConfig(), i.autoYesEnabled(i.AutoYes), resumeID) + i.agentFlags(i.Agent, i.Model) + launchFlagsSuffix(i.sandboxedFlags(i.LaunchFlags))
 		}
 	} else {
 		// Followed window - find the agent type
@@ -667,18 +712,7 @@ func (i *Instance) RespawnWindowWithResume(windowIdx int, resumeID string) error
 				} else if fw.Agent == AgentCustom {
 					agentCmd = fw.CustomCommand
 				} else {
-					config := AgentConfigs[fw.Agent]
-					var args []string
-					if fw.AutoYes && config.SupportsAutoYes && config.AutoYesFlag != "" {
-						args = append(args, config.AutoYesFlag)
-					}
-					if config.SupportsResume && config.ResumeFlag != "" && resumeID != "" {
-						args = append(args, config.ResumeFlag, resumeID)
-					}
-					agentCmd = config.Command
-					if len(args) > 0 {
-						agentCmd = agentCmd + " " + strings.Join(args, " ")
-					}
+					agentCmd = agentLaunchCommand(AgentConfigs[fw.Agent], i.autoYesEnabled(fw.AutoYes), resumeID) + i.agentFlags(fw.Agent, fw.Model) + launchFlagsSuffix(i.sandboxedFlags(fw.LaunchFlags))
 				}
 				break
 			}
@@ -686,11 +720,27 @@ func (i *Instance) RespawnWindowWithResume(windowIdx int, resumeID string) error
 	}
 
 	// Respawn the pane with the command
+	args := append([]string{"respawn-pane", "-k", "-t", target}, i.windowEnvArgs(windowIdx)...)
 	if agentCmd != "" {
-		return exec.Command("tmux", "respawn-pane", "-k", "-t", target, agentCmd).Run()
+		return i.Tmux().Run(append(args, i.sandboxCommand(agentCmd))...)
 	}
 	// Empty command = default shell
-	return exec.Command("tmux", "respawn-pane", "-k", "-t", target).Run()
+	return i.Tmux().Run(args...)
+}
+
+// WindowAgent returns the agent type running in a window (main agent for window 0)
+func (i *Instance) WindowAgent(windowIdx int) AgentType {
+	if windowIdx != 0 {
+		for _, fw := range i.FollowedWindows {
+			if fw.Index == windowIdx {
+				return fw.Agent
+			}
+		}
+	}
+	if i.Agent == "" {
+		return AgentClaude
+	}
+	return i.Agent
 }
 
 // StopWindow kills the process in a tmux window (keeps window due to remain-on-exit)
@@ -703,11 +753,11 @@ func (i *Instance) StopWindow(windowIdx int) error {
 	target := fmt.Sprintf("%s:%d", sessionName, windowIdx)
 
 	// Send Ctrl+C to interrupt the process gracefully
-	exec.Command("tmux", "send-keys", "-t", target, "C-c").Run()
+	i.Tmux().Run("send-keys", "-t", target, "C-c")
 
 	// Wait briefly then send Ctrl+D (EOF) to terminate shell if it's still running
 	time.Sleep(100 * time.Millisecond)
-	exec.Command("tmux", "send-keys", "-t", target, "C-d").Run()
+	i.Tmux().Run("send-keys", "-t", target, "C-d")
 
 	return nil
 }
@@ -727,8 +777,7 @@ func (i *Instance) CloseWindow(windowIdx int) error {
 	target := fmt.Sprintf("%s:%d", sessionName, windowIdx)
 
 	// Kill the tmux window
-	cmd := exec.Command("tmux", "kill-window", "-t", target)
-	if err := cmd.Run(); err != nil {
+	if err := i.Tmux().Run("kill-window", "-t", target); err != nil {
 		return fmt.Errorf("failed to close window: %w", err)
 	}
 
@@ -750,8 +799,7 @@ func (i *Instance) GetWindowCount() int {
 	}
 
 	sessionName := i.TmuxSessionName()
-	cmd := exec.Command("tmux", "list-windows", "-t", sessionName)
-	output, err := cmd.Output()
+	output, err := i.Tmux().Output("list-windows", "-t", sessionName)
 	if err != nil {
 		return 0
 	}
@@ -771,8 +819,7 @@ func (i *Instance) GetCurrentWindowIndex() int {
 	}
 
 	sessionName := i.TmuxSessionName()
-	cmd := exec.Command("tmux", "display-message", "-t", sessionName, "-p", "#{window_index}")
-	output, err := cmd.Output()
+	output, err := i.Tmux().Output("display-message", "-t", sessionName, "-p", "#{window_index}")
 	if err != nil {
 		return 0
 	}
@@ -789,8 +836,7 @@ func (i *Instance) SelectWindow(index int) error {
 	}
 
 	sessionName := i.TmuxSessionName()
-	cmd := exec.Command("tmux", "select-window", "-t", fmt.Sprintf("%s:%d", sessionName, index))
-	return cmd.Run()
+	return i.Tmux().Run("select-window", "-t", fmt.Sprintf("%s:%d", sessionName, index))
 }
 
 // NextWindow switches to the next tmux window
@@ -800,8 +846,7 @@ func (i *Instance) NextWindow() error {
 	}
 
 	sessionName := i.TmuxSessionName()
-	cmd := exec.Command("tmux", "next-window", "-t", sessionName)
-	return cmd.Run()
+	return i.Tmux().Run("next-window", "-t", sessionName)
 }
 
 // PrevWindow switches to the previous tmux window
@@ -811,8 +856,7 @@ func (i *Instance) PrevWindow() error {
 	}
 
 	sessionName := i.TmuxSessionName()
-	cmd := exec.Command("tmux", "previous-window", "-t", sessionName)
-	return cmd.Run()
+	return i.Tmux().Run("previous-window", "-t", sessionName)
 }
 
 // RenameCurrentWindow renames the current tmux window
@@ -822,8 +866,7 @@ func (i *Instance) RenameCurrentWindow(name string) error {
 	}
 
 	sessionName := i.TmuxSessionName()
-	cmd := exec.Command("tmux", "rename-window", "-t", sessionName, name)
-	return cmd.Run()
+	return i.Tmux().Run("rename-window", "-t", sessionName, name)
 }
 
 // WindowInfo contains information about a tmux window
@@ -906,8 +949,7 @@ func (i *Instance) GetWindowList() []WindowInfo {
 
 	sessionName := i.TmuxSessionName()
 	// Format: index:name:active_flag:pane_dead
-	cmd := exec.Command("tmux", "list-windows", "-t", sessionName, "-F", "#{window_index}:#{window_name}:#{window_active}:#{pane_dead}")
-	output, err := cmd.Output()
+	output, err := i.Tmux().Output("list-windows", "-t", sessionName, "-F", "#{window_index}:#{window_name}:#{window_active}:#{pane_dead}")
 	if err != nil {
 		return nil
 	}
@@ -960,20 +1002,16 @@ func (i *Instance) NewAgentWindow(name string, agent AgentType, customCmd string
 	if agent == AgentCustom {
 		agentCmd = customCmd
 	} else {
-		args := []string{}
 		// Use instance's AutoYes setting for the new agent too
-		if i.AutoYes && config.SupportsAutoYes && config.AutoYesFlag != "" {
-			args = append(args, config.AutoYesFlag)
-		}
-		agentCmd = config.Command
-		if len(args) > 0 {
-			agentCmd = agentCmd + " " + strings.Join(args, " ")
+		agentCmd = agentLaunchCommand(config, i.autoYesEnabled(i.AutoYes), "") + i.agentFlags(agent, "")
+		if err := i.writeMCPConfig(); err != nil {
+			return -1, err
 		}
 	}
 
 	// Create new window with agent command
-	cmd := exec.Command("tmux", "new-window", "-t", sessionName, "-c", i.Path, "-n", name, agentCmd)
-	if err := cmd.Run(); err != nil {
+	args := append([]string{"new-window", "-t", sessionName, "-c", i.Path, "-n", name}, i.envArgs(nil)...)
+	if err := i.Tmux().Run(append(args, i.sandboxCommand(agentCmd))...); err != nil {
 		return -1, err
 	}
 
@@ -990,9 +1028,9 @@ func (i *Instance) NewAgentWindow(name string, agent AgentType, customCmd string
 
 	// Set remain-on-exit so window stays open when command exits (shows as stopped)
 	target := fmt.Sprintf("%s:%d", sessionName, newIdx)
-	exec.Command("tmux", "set-option", "-t", target, "remain-on-exit", "on").Run()
+	i.Tmux().Run("set-option", "-t", target, "remain-on-exit", "on")
 	// Disable automatic-rename so the window keeps the user-specified name
-	exec.Command("tmux", "set-option", "-t", target, "automatic-rename", "off").Run()
+	i.Tmux().Run("set-option", "-t", target, "automatic-rename", "off")
 
 	return newIdx, nil
 }
@@ -1009,7 +1047,16 @@ func (i *Instance) ForkSession() (string, error) {
 	if sessionID == "" {
 		return "", fmt.Errorf("no session ID to fork - session may not have started yet")
 	}
+	return i.ForkConversation(sessionID)
+}
 
+// ForkConversation forks any Claude conversation of the instance's project
+// Returns the new session ID
+func (i *Instance) ForkConversation(sessionID string) (string, error) {
+	if i.IsRemote() {
+		// The conversation lives on the remote host, out of reach of the local claude
+		return "", fmt.Errorf("fork is not available for sessions on %s", i.Host)
+	}
 	// Run claude with --fork-session to get new session ID
 	// This doesn't actually run the agent, just creates the fork and returns the ID
 	cmd := exec.Command("claude", "--resume", sessionID, "--fork-session", "--output-format", "json", "-p", ".")
@@ -1037,56 +1084,64 @@ func (i *Instance) ForkSession() (string, error) {
 
 // NewForkedTab creates a new tab with a forked Claude session
 func (i *Instance) NewForkedTab(name string, sessionID string) error {
+	return i.NewResumedTab(name, AgentClaude, sessionID, "Forked session")
+}
+
+// NewResumedTab creates a new tab that resumes a past conversation of the given agent
+func (i *Instance) NewResumedTab(name string, agent AgentType, sessionID string, notes string) error {
 	if i.Status != StatusRunning {
 		return fmt.Errorf("instance not running")
 	}
+	config := AgentConfigs[agent]
+	if !config.SupportsResume {
+		return fmt.Errorf("resume not supported for %s agent", agent)
+	}
 
 	sessionName := i.TmuxSessionName()
 
-	// Build claude command with resume
-	config := AgentConfigs[AgentClaude]
-	args := []string{}
-
-	// Add auto-yes flag if the main session has it enabled
-	if i.AutoYes && config.AutoYesFlag != "" {
-		args = append(args, config.AutoYesFlag)
+	// Build agent command with resume (auto-yes follows the main session)
+	autoYes := i.autoYesEnabled(i.AutoYes) && config.SupportsAutoYes
+	agentCmd := agentLaunchCommand(config, autoYes, sessionID) + i.agentFlags(agent, "")
+	if err := i.writeMCPConfig(); err != nil {
+		return err
 	}
 
-	// Add resume flag with forked session ID
-	args = append(args, config.ResumeFlag, sessionID)
-
-	agentCmd := config.Command + " " + strings.Join(args, " ")
-
-	// Create new window with forked agent
-	cmd := exec.Command("tmux", "new-window", "-t", sessionName, "-c", i.Path, "-n", name, agentCmd)
-	if err := cmd.Run(); err != nil {
+	// Create new window with resumed agent
+	args := append([]string{"new-window", "-t", sessionName, "-c", i.Path, "-n", name}, i.envArgs(nil)...)
+	if err := i.Tmux().Run(append(args, i.sandboxCommand(agentCmd))...); err != nil {
 		return err
 	}
 
 	// Get the new window index
 	newIdx := i.GetCurrentWindowIndex()
 
-	// Add to followed windows with fork info
+	// Add to followed windows with resume info
 	i.FollowedWindows = append(i.FollowedWindows, FollowedWindow{
 		Index:           newIdx,
-		Agent:           AgentClaude,
+		Agent:           agent,
 		Name:            name,
+		AutoYes:         autoYes,
 		ResumeSessionID: sessionID,
-		Notes:           "Forked session",
+		Notes:           notes,
 	})
 
 	// Set remain-on-exit so window stays open when command exits
 	target := fmt.Sprintf("%s:%d", sessionName, newIdx)
-	exec.Command("tmux", "set-option", "-t", target, "remain-on-exit", "on").Run()
-	exec.Command("tmux", "set-option", "-t", target, "automatic-rename", "off").Run()
+	i.Tmux().Run("set-option", "-t", target, "remain-on-exit", "on")
+	i.Tmux().Run("set-option", "-t", target, "automatic-rename", "off")
 
 	return nil
 }
 
 func (i *Instance) IsAlive() bool {
 	sessionName := i.TmuxSessionName()
-	cmd := exec.Command("tmux", "has-session", "-t", sessionName)
-	return cmd.Run() == nil
+	return i.Tmux().Run("has-session", "-t", sessionName) == nil
+}
+
+// MainPaneDead reports whether the agent in window 0 has exited (kept by remain-on-exit)
+func (i *Instance) MainPaneDead() bool {
+	out, err := i.Tmux().Output("display-message", "-p", "-t", i.TmuxSessionName()+":0", "#{pane_dead}")
+	return err == nil && strings.TrimSpace(string(out)) == "1"
 }
 
 // ResizePane resizes the tmux pane to the specified dimensions
@@ -1095,7 +1150,7 @@ func (i *Instance) ResizePane(width, height int) error {
 		return nil
 	}
 	sessionName := i.TmuxSessionName()
-	return exec.Command("tmux", "resize-window", "-t", sessionName, "-x", fmt.Sprintf("%d", width), "-y", fmt.Sprintf("%d", height)).Run()
+	return i.Tmux().Run("resize-window", "-t", sessionName, "-x", fmt.Sprintf("%d", width), "-y", fmt.Sprintf("%d", height))
 }
 
 // UpdateDetachBinding updates Ctrl+Q to resize to preview size before detaching
@@ -1112,7 +1167,13 @@ if echo "$SESSION" | grep -q '^asm_'; then
 fi
 tmux detach-client
 `, sessionName, previewWidth, previewHeight)
-	exec.Command("tmux", "bind-key", "-n", "C-q", "run-shell", shellScript).Run()
+	i.Tmux().Run("bind-key", "-n", "C-q", "run-shell", shellScript)
+}
+
+// ReadOnlyDetachBinding binds Ctrl+Q to a plain detach for read-only clients,
+// which ignore run-shell bindings (and the window size, so no resize is needed)
+func (i *Instance) ReadOnlyDetachBinding() {
+	i.Tmux().Run("bind-key", "-n", "C-q", "detach-client")
 }
 
 
@@ -1127,8 +1188,7 @@ func (i *Instance) GetPreview(lines int) (string, error) {
 	// -S -lines means start from 'lines' back in history
 	// -e preserves colors, -J joins wrapped lines
 	startLine := fmt.Sprintf("-%d", lines)
-	cmd := exec.Command("tmux", "capture-pane", "-t", sessionName, "-p", "-e", "-J", "-S", startLine)
-	output, err := cmd.Output()
+	output, err := i.Tmux().Output("capture-pane", "-t", sessionName, "-p", "-e", "-J", "-S", startLine)
 	if err != nil {
 		return "", fmt.Errorf("failed to capture pane: %w", err)
 	}
@@ -1139,6 +1199,31 @@ func (i *Instance) GetPreview(lines int) (string, error) {
 	return strings.TrimRight(result, "\n"), nil
 }
 
+// SaveOutputSnapshot stores the last lines of the main window for display while stopped
+func (i *Instance) SaveOutputSnapshot() {
+	target := i.TmuxSessionName() + ":0"
+	startLine := fmt.Sprintf("-%d", SnapshotLines)
+	output, err := i.Tmux().Output("capture-pane", "-t", target, "-p", "-e", "-J", "-S", startLine)
+	if err != nil {
+		return
+	}
+
+	// capture-pane pads the visible area with empty lines - keep only the last real ones
+	lines := strings.Split(strings.TrimRight(removeWideCharPadding(string(output)), "\n"), "\n")
+	for len(lines) > 0 && strings.TrimSpace(stripANSI(lines[len(lines)-1])) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	if len(lines) > SnapshotLines {
+		lines = lines[len(lines)-SnapshotLines:]
+	}
+	if len(lines) == 0 {
+		return
+	}
+
+	i.LastOutput = strings.Join(lines, "\n")
+	i.StoppedAt = time.Now()
+}
+
 // removeWideCharPadding removes extra spaces after wide characters (emojis)
 // that tmux -J flag adds when capturing panes
 func removeWideCharPadding(s string) string {
@@ -1192,8 +1277,7 @@ func (i *Instance) GetLastLine() string {
 	target := sessionName + ":0"
 	// Capture last 50 lines with colors (-e flag preserves ANSI escape sequences)
 	// -J flag joins wrapped lines (prevents terminal width wrapping issues)
-	cmd := exec.Command("tmux", "capture-pane", "-t", target, "-p", "-e", "-J", "-S", "-50")
-	output, err := cmd.Output()
+	output, err := i.Tmux().Output("capture-pane", "-t", target, "-p", "-e", "-J", "-S", "-50")
 	if err != nil {
 		return "..."
 	}
@@ -1249,8 +1333,7 @@ func (i *Instance) GetLastLineForWindow(windowIdx int, agent AgentType) string {
 
 	sessionName := i.TmuxSessionName()
 	target := fmt.Sprintf("%s:%d", sessionName, windowIdx)
-	cmd := exec.Command("tmux", "capture-pane", "-t", target, "-p", "-e", "-J", "-S", "-50")
-	output, err := cmd.Output()
+	output, err := i.Tmux().Output("capture-pane", "-t", target, "-p", "-e", "-J", "-S", "-50")
 	if err != nil {
 		return "..."
 	}
@@ -1301,8 +1384,7 @@ func (i *Instance) SendKeys(keys string) error {
 	}
 
 	sessionName := i.TmuxSessionName()
-	cmd := exec.Command("tmux", "send-keys", "-t", sessionName, keys)
-	return cmd.Run()
+	return i.Tmux().Run("send-keys", "-t", sessionName, keys)
 }
 
 // SendText sends text literally (not interpreted as key names)
@@ -1313,8 +1395,7 @@ func (i *Instance) SendText(text string) error {
 
 	sessionName := i.TmuxSessionName()
 	// Use -l flag to send text literally without interpreting key names
-	cmd := exec.Command("tmux", "send-keys", "-l", "-t", sessionName, text)
-	return cmd.Run()
+	return i.Tmux().Run("send-keys", "-l", "-t", sessionName, text)
 }
 
 // SendPrompt sends a prompt text followed by Enter key
@@ -1326,8 +1407,7 @@ func (i *Instance) SendPrompt(text string) error {
 	sessionName := i.TmuxSessionName()
 
 	// First send text literally with -l flag to avoid key interpretation
-	cmd := exec.Command("tmux", "send-keys", "-l", "-t", sessionName, text)
-	if err := cmd.Run(); err != nil {
+	if err := i.Tmux().Run("send-keys", "-l", "-t", sessionName, text); err != nil {
 		return err
 	}
 
@@ -1335,16 +1415,42 @@ func (i *Instance) SendPrompt(text string) error {
 	time.Sleep(50 * time.Millisecond)
 
 	// Then send Enter separately
-	cmd = exec.Command("tmux", "send-keys", "-t", sessionName, "Enter")
-	return cmd.Run()
+	if err := i.Tmux().Run("send-keys", "-t", sessionName, "Enter"); err != nil {
+		return err
+	}
+
+	e := i.Event(events.PromptSent)
+	e.Text = text
+	events.Publish(e)
+	return nil
+}
+
+// Event returns a bus event of the given type describing this session
+func (i *Instance) Event(t events.Type) events.Event {
+	agent := i.Agent
+	if agent == "" {
+		agent = AgentClaude
+	}
+	return events.Event{Type: t, SessionID: i.ID, Session: i.Name, Agent: string(agent), Path: i.Path, Preset: i.Preset}
 }
 
 func (i *Instance) UpdateStatus() {
-	if i.IsAlive() {
+	i.SetAlive(i.IsAlive())
+}
+
+// SetAlive updates the status from whether the tmux session was found, e.g.
+// by a check that ran off the UI loop
+func (i *Instance) SetAlive(alive bool) {
+	if alive {
 		i.Status = StatusRunning
-	} else {
-		i.Status = StatusStopped
+		i.aliveAt = time.Now()
+		return
+	}
+	if i.Status == StatusRunning && !i.StartedAt.IsZero() {
+		// Counted up to when it was last seen running
+		i.EndRun(i.aliveAt)
 	}
+	i.Status = StatusStopped
 }
 
 // Git diff functions
@@ -1364,8 +1470,12 @@ func (i *Instance) GetFullDiff() *DiffStats {
 
 // getDiff executes git diff and parses the result
 func (i *Instance) getDiff(baseRef string) *DiffStats {
-	stats := &DiffStats{}
+	stats := &DiffStats{BaseRef: baseRef}
 
+	if i.IsRemote() {
+		stats.Error = fmt.Errorf("diff is not available for sessions on %s", i.Host)
+		return stats
+	}
 	if !i.isGitRepo() {
 		stats.Error = fmt.Errorf("not a git repository")
 		return stats
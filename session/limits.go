@@ -0,0 +1,23 @@
+package session
+
+import (
+	"strings"
+	"time"
+)
+
+// Limits are soft limits on session counts from config.toml (0 = no limit)
+type Limits struct {
+	MaxRunning    int `toml:"max_running"`     // Running sessions across all projects
+	MaxPerProject int `toml:"max_per_project"` // Sessions in one project
+}
+
+// RunningSessionCount counts the running sessions of all projects
+func RunningSessionCount(live map[string]time.Time) int {
+	count := 0
+	for name := range live {
+		if strings.HasPrefix(name, "asm_") {
+			count++
+		}
+	}
+	return count
+}
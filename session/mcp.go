@@ -0,0 +1,174 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// MCP server scopes, from Claude's lowest to highest precedence
+const (
+	MCPScopeUser    = "user"    // ~/.claude.json, every project
+	MCPScopeProject = "project" // .mcp.json in the session directory
+	MCPScopeLocal   = "local"   // ~/.claude.json, this directory only
+)
+
+// MCPServer is an MCP server Claude starts in the session's directory
+type MCPServer struct {
+	Name   string
+	Scope  string
+	Config json.RawMessage // Server definition as Claude reads it
+}
+
+// Target returns what the server runs or connects to ("npx -y @x/server", a URL)
+func (s MCPServer) Target() string {
+	var def struct {
+		Type    string   `json:"type"`
+		Command string   `json:"command"`
+		Args    []string `json:"args"`
+		URL     string   `json:"url"`
+	}
+	if json.Unmarshal(s.Config, &def) != nil {
+		return ""
+	}
+	if def.URL != "" {
+		return def.URL
+	}
+	return strings.TrimSpace(def.Command + " " + strings.Join(def.Args, " "))
+}
+
+// MCPConfigDir returns the directory holding the sessions' generated MCP configs
+func MCPConfigDir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "agent-session-manager", "mcp")
+}
+
+// mcpConfigPath returns the MCP config Claude is started with when servers are disabled
+func (i *Instance) mcpConfigPath() string {
+	return filepath.Join(MCPConfigDir(), i.ID+".json")
+}
+
+// readMCPServers reads an "mcpServers" object into servers, overriding the
+// ones of lower scopes with the same name
+func readMCPServers(raw map[string]json.RawMessage, scope string, servers map[string]MCPServer) {
+	for name, config := range raw {
+		servers[name] = MCPServer{Name: name, Scope: scope, Config: config}
+	}
+}
+
+// MCPServers returns the MCP servers Claude would start for the session: the
+// user's and the directory's from ~/.claude.json and the project's .mcp.json
+func (i *Instance) MCPServers() ([]MCPServer, error) {
+	if i.IsRemote() {
+		return nil, fmt.Errorf("MCP servers of sessions on %s can't be read from here", i.Host)
+	}
+	servers := make(map[string]MCPServer)
+
+	homeDir, _ := os.UserHomeDir()
+	var user struct {
+		MCPServers map[string]json.RawMessage `json:"mcpServers"`
+		Projects   map[string]struct {
+			MCPServers map[string]json.RawMessage `json:"mcpServers"`
+		} `json:"projects"`
+	}
+	if data, err := os.ReadFile(filepath.Join(homeDir, ".claude.json")); err == nil {
+		if err := json.Unmarshal(data, &user); err != nil {
+			return nil, fmt.Errorf("failed to parse ~/.claude.json: %w", err)
+		}
+	}
+	readMCPServers(user.MCPServers, MCPScopeUser, servers)
+
+	var project struct {
+		MCPServers map[string]json.RawMessage `json:"mcpServers"`
+	}
+	path := filepath.Join(i.Path, ".mcp.json")
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &project); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+	readMCPServers(project.MCPServers, MCPScopeProject, servers)
+	readMCPServers(user.Projects[filepath.Clean(i.Path)].MCPServers, MCPScopeLocal, servers)
+
+	list := make([]MCPServer, 0, len(servers))
+	for _, s := range servers {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(a, b int) bool { return list[a].Name < list[b].Name })
+	return list, nil
+}
+
+// MCPDisabledServer reports whether a server is switched off for the session
+func (i *Instance) MCPDisabledServer(name string) bool {
+	for _, disabled := range i.MCPDisabled {
+		if disabled == name {
+			return true
+		}
+	}
+	return false
+}
+
+// SetMCPDisabled switches servers off for the session's Claude windows (nil =
+// all on); it is used from their next start
+func (i *Instance) SetMCPDisabled(names []string) {
+	sort.Strings(names)
+	i.MCPDisabled = names
+	if len(names) == 0 {
+		i.removeMCPConfig()
+	}
+}
+
+// usesMCPConfig reports whether the session's Claude windows start with a
+// config of their own (servers are switched off; local sessions only)
+func (i *Instance) usesMCPConfig() bool {
+	return len(i.MCPDisabled) > 0 && !i.IsRemote() && i.Runtime == ""
+}
+
+// writeMCPConfig writes the enabled servers to the config the session's Claude
+// windows are started with; it runs right before they start, so later edits of
+// .mcp.json and ~/.claude.json are picked up
+func (i *Instance) writeMCPConfig() error {
+	if !i.usesMCPConfig() {
+		return nil
+	}
+	servers, err := i.MCPServers()
+	if err != nil {
+		return err
+	}
+	enabled := make(map[string]json.RawMessage)
+	for _, s := range servers {
+		if !i.MCPDisabledServer(s.Name) {
+			enabled[s.Name] = s.Config
+		}
+	}
+	data, err := json.MarshalIndent(map[string]interface{}{"mcpServers": enabled}, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := i.mcpConfigPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return fmt.Errorf("failed to create MCP config directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0600); err != nil {
+		return fmt.Errorf("failed to write MCP config: %w", err)
+	}
+	return nil
+}
+
+// removeMCPConfig deletes the session's MCP config (server definitions may hold secrets)
+func (i *Instance) removeMCPConfig() {
+	os.Remove(i.mcpConfigPath())
+}
+
+// mcpFlags returns the flags starting Claude with only the enabled servers
+// (--strict-mcp-config skips the others); sessions without switched off
+// servers start with Claude's usual ones
+func (i *Instance) mcpFlags() string {
+	if !i.usesMCPConfig() {
+		return ""
+	}
+	return " --strict-mcp-config --mcp-config " + ShellQuote(i.mcpConfigPath())
+}
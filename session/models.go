@@ -0,0 +1,41 @@
+package session
+
+import "strings"
+
+// WindowModel returns the model chosen for a window (0 = main, "" = default)
+func (i *Instance) WindowModel(window int) string {
+	if window == 0 {
+		return i.Model
+	}
+	if fw := i.GetFollowedWindow(window); fw != nil {
+		return fw.Model
+	}
+	return ""
+}
+
+// SetWindowModel changes the model of a window (0 = main, "" = default);
+// it is used from the window's next start
+func (i *Instance) SetWindowModel(window int, model string) {
+	model = strings.TrimSpace(model)
+	if window == 0 {
+		i.Model = model
+		return
+	}
+	for idx := range i.FollowedWindows {
+		if i.FollowedWindows[idx].Index == window {
+			i.FollowedWindows[idx].Model = model
+			return
+		}
+	}
+}
+
+// RestartWindow respawns a window's agent, resuming its conversation when known
+func (i *Instance) RestartWindow(window int) error {
+	if window == 0 {
+		return i.RestartAgent()
+	}
+	if id := i.windowResumeID(window); id != "" && AgentConfigs[i.WindowAgent(window)].SupportsResume {
+		return i.RespawnWindowWithResume(window, id)
+	}
+	return i.RespawnWindow(window)
+}
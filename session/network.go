@@ -0,0 +1,186 @@
+package session
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// NetActiveBytes is how much a process with a remote connection has to read or
+// write between two samples to count as talking to the network
+const NetActiveBytes = 256
+
+// NetState is what a session's processes are doing on the network
+type NetState int
+
+const (
+	NetNone   NetState = iota // No connection to a remote host
+	NetIdle                   // Remote connections open, nothing moved since the last sample
+	NetActive                 // A process with a remote connection moved data since the last sample
+)
+
+// NetworkSampler watches the remote TCP connections of session process trees.
+// It reads /proc, so it only works on Linux.
+type NetworkSampler struct {
+	prevIO map[int]int64 // PID -> bytes read + written at the last sample
+}
+
+// NewNetworkSampler creates a sampler with no history
+func NewNetworkSampler() *NetworkSampler {
+	return &NetworkSampler{prevIO: make(map[int]int64)}
+}
+
+// NetworkSupported reports whether network sampling works on this system
+func NetworkSupported() bool {
+	return runtime.GOOS == "linux"
+}
+
+// Sample returns the network state of the given instances' pane process trees.
+// A process only counts as active from its second sample on; instances
+// without a tmux session are omitted.
+func (s *NetworkSampler) Sample(instances []*Instance) (map[string]NetState, error) {
+	if !NetworkSupported() {
+		return nil, fmt.Errorf("network sampling needs /proc (Linux)")
+	}
+	remote, err := remoteSocketInodes()
+	if err != nil {
+		return nil, err
+	}
+	procs, err := readProcessTable()
+	if err != nil {
+		return nil, err
+	}
+	roots, err := paneRootPIDs()
+	if err != nil {
+		return nil, err
+	}
+	children := childrenOf(procs)
+
+	states := make(map[string]NetState)
+	io := make(map[int]int64)
+	for _, inst := range instances {
+		pids, ok := roots[inst.TmuxSessionName()]
+		if !ok {
+			continue
+		}
+		state := NetNone
+		seen := make(map[int]bool)
+		stack := append([]int{}, pids...)
+		for len(stack) > 0 {
+			pid := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if seen[pid] {
+				continue
+			}
+			seen[pid] = true
+			stack = append(stack, children[pid]...)
+			if !hasRemoteSocket(pid, remote) {
+				continue
+			}
+			if state == NetNone {
+				state = NetIdle
+			}
+			moved, ok := procIOBytes(pid)
+			if !ok {
+				continue
+			}
+			io[pid] = moved
+			if prev, ok := s.prevIO[pid]; ok && moved-prev >= NetActiveBytes {
+				state = NetActive
+			}
+		}
+		states[inst.ID] = state
+	}
+	s.prevIO = io
+	return states, nil
+}
+
+// remoteSocketInodes returns the inodes of established TCP connections to
+// non-loopback addresses, from /proc/net/tcp and /proc/net/tcp6
+func remoteSocketInodes() (map[string]bool, error) {
+	inodes := make(map[string]bool)
+	found := false
+	for _, path := range []string{"/proc/net/tcp", "/proc/net/tcp6"} {
+		file, err := os.Open(path)
+		if err != nil {
+			continue
+		}
+		found = true
+		scanner := bufio.NewScanner(file)
+		scanner.Scan() // Header
+		for scanner.Scan() {
+			// sl local_address rem_address st tx_queue:rx_queue tr:tm->when retrnsmt uid timeout inode
+			fields := strings.Fields(scanner.Text())
+			if len(fields) < 10 || fields[3] != "01" { // 01 = ESTABLISHED
+				continue
+			}
+			addr, _, _ := strings.Cut(fields[2], ":")
+			if ip := procNetIP(addr); ip != nil && !ip.IsLoopback() {
+				inodes[fields[9]] = true
+			}
+		}
+		file.Close()
+	}
+	if !found {
+		return nil, fmt.Errorf("no /proc/net/tcp")
+	}
+	return inodes, nil
+}
+
+// procNetIP decodes an address from /proc/net/tcp*: hex, in 32-bit words of host (little-endian) byte order
+func procNetIP(hexAddr string) net.IP {
+	raw, err := hex.DecodeString(hexAddr)
+	if err != nil || (len(raw) != net.IPv4len && len(raw) != net.IPv6len) {
+		return nil
+	}
+	for word := 0; word < len(raw); word += 4 {
+		raw[word], raw[word+1], raw[word+2], raw[word+3] = raw[word+3], raw[word+2], raw[word+1], raw[word]
+	}
+	return net.IP(raw)
+}
+
+// hasRemoteSocket reports whether a process holds one of the given socket inodes
+func hasRemoteSocket(pid int, remote map[string]bool) bool {
+	dir := fmt.Sprintf("/proc/%d/fd", pid)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		link, err := os.Readlink(dir + "/" + entry.Name())
+		if err != nil || !strings.HasPrefix(link, "socket:[") {
+			continue
+		}
+		if remote[strings.TrimSuffix(strings.TrimPrefix(link, "socket:["), "]")] {
+			return true
+		}
+	}
+	return false
+}
+
+// procIOBytes returns the bytes a process has read and written so far (files,
+// pipes and sockets alike), from /proc/<pid>/io
+func procIOBytes(pid int) (int64, bool) {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/io", pid))
+	if err != nil {
+		return 0, false
+	}
+	var total int64
+	for _, line := range strings.Split(string(data), "\n") {
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok || (key != "rchar" && key != "wchar") {
+			continue
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			return 0, false
+		}
+		total += n
+	}
+	return total, true
+}
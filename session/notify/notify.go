@@ -0,0 +1,196 @@
+// Package notify routes session notifications to channels.
+//
+// Rules in notify.json map a session's project, group, tags and agent plus the
+// event type to the channels that should alert (desktop, system, slack or none). When
+// several rules match, the one with the highest priority wins, so a broad quiet
+// rule for experimental sessions can be overridden for production repos.
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// Event is a notification-worthy session event
+type Event string
+
+const (
+	Waiting  Event = "waiting"   // Agent needs input
+	Finished Event = "finished"  // Agent stopped working and is idle again
+	Error    Event = "error"     // An error message appeared in the output
+	Stuck    Event = "stuck"     // Agent has been busy without new output for too long
+	Died     Event = "died"      // The agent process exited without being stopped
+	IdleStop Event = "idle_stop" // The session was stopped after being idle too long
+)
+
+// Notification channels
+const (
+	Desktop = "desktop" // Terminal notification (OSC 9/777)
+	System  = "system"  // Native notification (notify-send, osascript on macOS)
+	Slack   = "slack"   // Slack incoming webhook
+	None    = "none"    // Stay quiet
+)
+
+// SlackTimeout limits how long a Slack webhook call may take
+const SlackTimeout = 5 * time.Second
+
+// Rule maps matching sessions and events to channels.
+// Empty match fields match everything; project and group accept * wildcards.
+type Rule struct {
+	Session  string   `json:"session,omitempty"`  // Session name
+	Project  string   `json:"project,omitempty"`  // Project name ("default" for sessions outside projects)
+	Group    string   `json:"group,omitempty"`    // Group name
+	Tag      string   `json:"tag,omitempty"`      // #tag in the session's notes (without #)
+	Agent    string   `json:"agent,omitempty"`    // Agent type (claude, gemini, ...)
+	Event    string   `json:"event,omitempty"`    // waiting, finished, error, stuck, died, idle_stop or *
+	Channels []string `json:"channels"`           // desktop, system, slack and/or none
+	Priority int      `json:"priority,omitempty"` // Higher wins when several rules match
+}
+
+// Config is the contents of notify.json
+type Config struct {
+	SlackWebhook string `json:"slack_webhook,omitempty"` // Incoming webhook URL for the slack channel
+	Rules        []Rule `json:"rules,omitempty"`
+}
+
+// Target describes the session an event comes from
+type Target struct {
+	Session string
+	Project string
+	Group   string
+	Agent   string
+	Tags    []string
+}
+
+// DefaultChannels are used when no rule matches
+var DefaultChannels = map[Event][]string{
+	Waiting: {Desktop},
+	Died:    {Desktop},
+}
+
+// ConfigPath returns the path of the notification rules file
+func ConfigPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "agent-session-manager", "notify.json")
+}
+
+// Load reads notify.json (an empty config when missing)
+func Load() (*Config, error) {
+	config := &Config{}
+	data, err := os.ReadFile(ConfigPath())
+	if os.IsNotExist(err) {
+		return config, nil
+	}
+	if err != nil {
+		return config, err
+	}
+	if err := json.Unmarshal(data, config); err != nil {
+		return &Config{}, fmt.Errorf("invalid %s: %w", filepath.Base(ConfigPath()), err)
+	}
+	return config, nil
+}
+
+// matchField compares a rule field (with * wildcards) to a value, case-insensitively
+func matchField(pattern, value string) bool {
+	if pattern == "" || pattern == "*" {
+		return true
+	}
+	ok, err := filepath.Match(strings.ToLower(pattern), strings.ToLower(value))
+	return err == nil && ok
+}
+
+// Matches reports whether the rule applies to an event from a target
+func (r Rule) Matches(t Target, e Event) bool {
+	if !matchField(r.Session, t.Session) || !matchField(r.Project, t.Project) || !matchField(r.Group, t.Group) ||
+		!matchField(r.Agent, t.Agent) || !matchField(r.Event, string(e)) {
+		return false
+	}
+	if r.Tag == "" {
+		return true
+	}
+	for _, tag := range t.Tags {
+		if strings.EqualFold(strings.TrimPrefix(r.Tag, "#"), tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// Route returns the channels an event should be sent to (nil = stay quiet)
+func (c *Config) Route(t Target, e Event) []string {
+	var best *Rule
+	for idx := range c.Rules {
+		rule := &c.Rules[idx]
+		if !rule.Matches(t, e) {
+			continue
+		}
+		// Earlier rules win ties
+		if best == nil || rule.Priority > best.Priority {
+			best = rule
+		}
+	}
+	channels := DefaultChannels[e]
+	if best != nil {
+		channels = best.Channels
+	}
+
+	var result []string
+	for _, ch := range channels {
+		if ch == None {
+			return nil
+		}
+		result = append(result, ch)
+	}
+	return result
+}
+
+// SendSlack posts a message to the configured Slack webhook
+func (c *Config) SendSlack(text string) error {
+	if c.SlackWebhook == "" {
+		return fmt.Errorf("slack_webhook is not set in %s", filepath.Base(ConfigPath()))
+	}
+	body, _ := json.Marshal(map[string]string{"text": text})
+	client := &http.Client{Timeout: SlackTimeout}
+	resp, err := client.Post(c.SlackWebhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("slack webhook returned %s", resp.Status)
+	}
+	return nil
+}
+
+// SendSystem shows a native desktop notification (osascript on macOS, notify-send elsewhere)
+func SendSystem(title, body string) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "darwin" {
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(body), appleScriptString(title))
+		cmd = exec.Command("osascript", "-e", script)
+	} else {
+		if _, err := exec.LookPath("notify-send"); err != nil {
+			return fmt.Errorf("notify-send not found (install libnotify)")
+		}
+		cmd = exec.Command("notify-send", "--app-name=asmgr", title, body)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait() // Reap it when it finishes
+	return nil
+}
+
+// appleScriptString quotes a string for an AppleScript literal
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -1,14 +1,21 @@
 package session
 
 import (
+	"database/sql"
 	"os"
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 )
 
 // ListOpenCodeSessions lists all OpenCode sessions for the given project path
 func ListOpenCodeSessions(projectPath string) ([]AgentSession, error) {
+	// Project-local DB has real session IDs and titles
+	if sessions := listOpenCodeDBSessions(projectPath); len(sessions) > 0 {
+		return sessions, nil
+	}
+
 	// OpenCode stores sessions at ~/.local/share/opencode/storage/session
 	homeDir, err := os.UserHomeDir()
 	if err != nil {
@@ -58,3 +65,52 @@ func ListOpenCodeSessions(projectPath string) ([]AgentSession, error) {
 
 	return sessions, nil
 }
+
+// listOpenCodeDBSessions lists the top-level sessions of a project's .opencode/opencode.db
+func listOpenCodeDBSessions(projectPath string) []AgentSession {
+	dbPath := filepath.Join(projectPath, ".opencode", "opencode.db")
+	if _, err := os.Stat(dbPath); err != nil {
+		return nil
+	}
+	db, err := sql.Open("sqlite3", dbPath+"?mode=ro")
+	if err != nil {
+		return nil
+	}
+	defer db.Close()
+
+	// Note: timestamps are Unix milliseconds; child sessions (tasks) are skipped
+	rows, err := db.Query(`
+		SELECT id, title, message_count, created_at, updated_at
+		FROM sessions
+		WHERE parent_session_id IS NULL OR parent_session_id = ''
+		ORDER BY updated_at DESC
+	`)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	var sessions []AgentSession
+	for rows.Next() {
+		var id string
+		var title sql.NullString
+		var messageCount, createdAt, updatedAt int64
+		if err := rows.Scan(&id, &title, &messageCount, &createdAt, &updatedAt); err != nil {
+			continue
+		}
+		prompt := id
+		if title.Valid && title.String != "" {
+			prompt = title.String
+		}
+		sessions = append(sessions, AgentSession{
+			SessionID:    id,
+			FirstPrompt:  prompt,
+			LastPrompt:   prompt,
+			MessageCount: int(messageCount),
+			CreatedAt:    time.UnixMilli(createdAt),
+			UpdatedAt:    time.UnixMilli(updatedAt),
+			AgentType:    AgentOpenCode,
+		})
+	}
+	return sessions
+}
@@ -0,0 +1,172 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// OutputLogMaxSize is the size at which a session's output log is rotated
+const OutputLogMaxSize = 10 * 1024 * 1024
+
+// OutputLogKeep is how many rotated output logs are kept (<id>.log.1 is the newest)
+const OutputLogKeep = 3
+
+// OutputLogDir returns the directory holding the sessions' output logs
+func OutputLogDir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "agent-session-manager", "logs")
+}
+
+// OutputLogPath returns the path of a session's output log
+func (i *Instance) OutputLogPath() string {
+	return filepath.Join(OutputLogDir(), i.ID+".log")
+}
+
+// outputLoggerPIDPath returns the file holding the PID of a session's logger
+func (i *Instance) outputLoggerPIDPath() string {
+	return filepath.Join(OutputLogDir(), i.ID+".pid")
+}
+
+// outputLoggerPID returns the PID of the session's running logger (0 = none)
+func (i *Instance) outputLoggerPID() int {
+	data, err := os.ReadFile(i.outputLoggerPIDPath())
+	if err != nil {
+		return 0
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil || pid <= 0 {
+		return 0
+	}
+	// Signal 0 only checks that the process exists
+	if syscall.Kill(pid, 0) != nil {
+		return 0
+	}
+	return pid
+}
+
+// StartOutputLog starts a background logger (asmgr watch-log) that appends the
+// agent pane's new output lines to the session's output log
+func (i *Instance) StartOutputLog() error {
+	if !i.IsAlive() {
+		return fmt.Errorf("session not running")
+	}
+	if i.IsRemote() {
+		// The pipe runs asmgr on the tmux host
+		return fmt.Errorf("output logs are not available for sessions on %s", i.Host)
+	}
+	if i.outputLoggerPID() != 0 {
+		return nil
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot find the asmgr binary: %w", err)
+	}
+	if err := os.MkdirAll(OutputLogDir(), 0755); err != nil {
+		return fmt.Errorf("failed to create logs directory: %w", err)
+	}
+	cmd := exec.Command(exe, "watch-log", i.ID)
+	// Own session, so it outlives the TUI
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start the logger: %w", err)
+	}
+	return cmd.Process.Release()
+}
+
+// StopOutputLog stops the session's logger
+func (i *Instance) StopOutputLog() error {
+	pid := i.outputLoggerPID()
+	if pid == 0 {
+		return nil
+	}
+	if err := syscall.Kill(pid, syscall.SIGTERM); err != nil {
+		return err
+	}
+	os.Remove(i.outputLoggerPIDPath())
+	return nil
+}
+
+// RunOutputLog appends the session's new output lines, stripped of colors and
+// prefixed with the time they were seen, to its output log until the session stops
+func (i *Instance) RunOutputLog() error {
+	if err := os.MkdirAll(OutputLogDir(), 0755); err != nil {
+		return err
+	}
+	pidPath := i.outputLoggerPIDPath()
+	if err := os.WriteFile(pidPath, []byte(strconv.Itoa(os.Getpid())), 0644); err != nil {
+		return err
+	}
+	defer func() {
+		// A newer logger may have taken over
+		if data, err := os.ReadFile(pidPath); err == nil && strings.TrimSpace(string(data)) == strconv.Itoa(os.Getpid()) {
+			os.Remove(pidPath)
+		}
+	}()
+
+	log := &outputLog{path: i.OutputLogPath()}
+	defer log.Close()
+	fmt.Fprintf(log, "--- %s started logging ---\n", i.Name)
+	return i.Tail(log, TailOptions{Follow: true, Strip: true})
+}
+
+// outputLog is an append-only log file that timestamps each line and rotates
+// itself once it grows past OutputLogMaxSize
+type outputLog struct {
+	path string
+	file *os.File
+	size int64
+}
+
+// Write appends one line (Tail writes whole lines)
+func (l *outputLog) Write(p []byte) (int, error) {
+	if l.file == nil || l.size >= OutputLogMaxSize {
+		if err := l.open(); err != nil {
+			return 0, err
+		}
+	}
+	line := append([]byte(time.Now().Format("2006-01-02 15:04:05")+"  "), p...)
+	n, err := l.file.Write(line)
+	l.size += int64(n)
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// open opens the log for appending, rotating it first when it is full
+func (l *outputLog) open() error {
+	l.Close()
+	if info, err := os.Stat(l.path); err == nil && info.Size() >= OutputLogMaxSize {
+		for n := OutputLogKeep - 1; n >= 1; n-- {
+			os.Rename(fmt.Sprintf("%s.%d", l.path, n), fmt.Sprintf("%s.%d", l.path, n+1))
+		}
+		os.Rename(l.path, l.path+".1")
+	}
+	f, err := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+	l.file, l.size = f, info.Size()
+	return nil
+}
+
+// Close closes the current log file
+func (l *outputLog) Close() error {
+	if l.file == nil {
+		return nil
+	}
+	err := l.file.Close()
+	l.file = nil
+	return err
+}
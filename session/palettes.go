@@ -0,0 +1,124 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// MaxGradientStops caps the stop colors of a custom gradient
+const MaxGradientStops = 12
+
+// Palette is a named gradient defined by its stop colors
+type Palette struct {
+	Name  string   `json:"name"`
+	Stops []string `json:"stops"` // #RRGGBB colors, first to last
+}
+
+// palettesFile is the contents of palettes.json
+type palettesFile struct {
+	Gradients []Palette `json:"gradients"`
+}
+
+// PopularPalettes are well-known color schemes that can be imported as gradients
+var PopularPalettes = []Palette{
+	{Name: "dracula", Stops: []string{"#BD93F9", "#FF79C6", "#FFB86C", "#F1FA8C", "#50FA7B", "#8BE9FD"}},
+	{Name: "nord", Stops: []string{"#5E81AC", "#81A1C1", "#88C0D0", "#8FBCBB", "#A3BE8C", "#EBCB8B"}},
+	{Name: "catppuccin", Stops: []string{"#F5E0DC", "#F5C2E7", "#CBA6F7", "#89B4FA", "#94E2D5", "#A6E3A1"}},
+	{Name: "gruvbox", Stops: []string{"#CC241D", "#D65D0E", "#D79921", "#98971A", "#689D6A", "#458588"}},
+	{Name: "solarized", Stops: []string{"#B58900", "#CB4B16", "#DC322F", "#D33682", "#6C71C4", "#268BD2"}},
+	{Name: "tokyo-night", Stops: []string{"#7AA2F7", "#BB9AF7", "#F7768E", "#FF9E64", "#E0AF68", "#9ECE6A"}},
+	{Name: "one-dark", Stops: []string{"#E06C75", "#D19A66", "#E5C07B", "#98C379", "#56B6C2", "#61AFEF"}},
+	{Name: "monokai", Stops: []string{"#F92672", "#FD971F", "#E6DB74", "#A6E22E", "#66D9EF", "#AE81FF"}},
+	{Name: "rose-pine", Stops: []string{"#EB6F92", "#F6C177", "#EBBCBA", "#31748F", "#9CCFD8", "#C4A7E7"}},
+	{Name: "everforest", Stops: []string{"#E67E80", "#E69875", "#DBBC7F", "#A7C080", "#83C092", "#7FBBB3"}},
+	{Name: "synthwave", Stops: []string{"#F92AAD", "#FF7EDB", "#FEDE5D", "#72F1B8", "#36F9F6"}},
+	{Name: "vaporwave", Stops: []string{"#FF71CE", "#01CDFE", "#05FFA1", "#B967FF", "#FFFB96"}},
+}
+
+var (
+	hexColorRe     = regexp.MustCompile(`^#?([0-9a-fA-F]{6}|[0-9a-fA-F]{3})$`)
+	paletteNameRe  = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+	stopSeparators = strings.NewReplacer(",", " ", ";", " ")
+)
+
+// PalettesPath returns the path of the custom gradients file
+func PalettesPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "agent-session-manager", "palettes.json")
+}
+
+// LoadPalettes reads the custom gradients (none when the file is missing)
+func LoadPalettes() ([]Palette, error) {
+	data, err := os.ReadFile(PalettesPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var file palettesFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", filepath.Base(PalettesPath()), err)
+	}
+	var valid []Palette
+	for _, p := range file.Gradients {
+		if ValidatePalette(p) == nil {
+			valid = append(valid, p)
+		}
+	}
+	return valid, nil
+}
+
+// SavePalettes writes the custom gradients
+func SavePalettes(palettes []Palette) error {
+	data, err := json.MarshalIndent(palettesFile{Gradients: palettes}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal palettes: %w", err)
+	}
+	path := PalettesPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write palettes: %w", err)
+	}
+	return os.Rename(tmp, path)
+}
+
+// ParseStops parses stop colors separated by spaces or commas into #RRGGBB form
+func ParseStops(text string) ([]string, error) {
+	var stops []string
+	for _, field := range strings.Fields(stopSeparators.Replace(text)) {
+		m := hexColorRe.FindStringSubmatch(field)
+		if m == nil {
+			return nil, fmt.Errorf("not a hex color: %s", field)
+		}
+		hex := strings.ToUpper(m[1])
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		stops = append(stops, "#"+hex)
+	}
+	return stops, nil
+}
+
+// ValidatePalette checks a gradient's name and stop count
+func ValidatePalette(p Palette) error {
+	if !paletteNameRe.MatchString(p.Name) {
+		return fmt.Errorf("name must be lowercase letters, digits and dashes")
+	}
+	if len(p.Stops) < 2 || len(p.Stops) > MaxGradientStops {
+		return fmt.Errorf("a gradient needs 2-%d stop colors", MaxGradientStops)
+	}
+	for _, stop := range p.Stops {
+		if !hexColorRe.MatchString(stop) || !strings.HasPrefix(stop, "#") || len(stop) != 7 {
+			return fmt.Errorf("not a #RRGGBB color: %s", stop)
+		}
+	}
+	return nil
+}
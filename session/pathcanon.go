@@ -0,0 +1,79 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+// caseInsensitiveDirs caches whether the filesystem holding a directory ignores case
+var caseInsensitiveDirs sync.Map
+
+// CanonicalPath resolves a path to the form used for comparisons: ~ expanded,
+// absolute, without trailing slashes and with symlinks resolved (when it exists)
+func CanonicalPath(path string) string {
+	if path == "" {
+		return ""
+	}
+	path = expandTilde(path)
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+	if real, err := filepath.EvalSymlinks(path); err == nil {
+		path = real
+	}
+	return filepath.Clean(path)
+}
+
+// PathKey returns a canonical path usable as a map key; on case-insensitive
+// filesystems (macOS and Windows defaults) it is lowercased
+func PathKey(path string) string {
+	path = CanonicalPath(path)
+	if caseInsensitiveFS(path) {
+		return strings.ToLower(path)
+	}
+	return path
+}
+
+// SamePath reports whether two paths name the same directory - through
+// symlinks, trailing slashes, case-insensitive filesystems or bind mounts
+func SamePath(a, b string) bool {
+	if a == "" || b == "" {
+		return false
+	}
+	if a == b || PathKey(a) == PathKey(b) {
+		return true
+	}
+	infoA, errA := os.Stat(a)
+	infoB, errB := os.Stat(b)
+	return errA == nil && errB == nil && os.SameFile(infoA, infoB)
+}
+
+// caseInsensitiveFS reports whether the filesystem holding path ignores case,
+// by looking the path up with its case flipped
+func caseInsensitiveFS(path string) bool {
+	dir := ExistingAncestor(path)
+	if cached, ok := caseInsensitiveDirs.Load(dir); ok {
+		return cached.(bool)
+	}
+	insensitive := false
+	if flipped := flipCase(dir); flipped != dir {
+		infoA, errA := os.Stat(dir)
+		infoB, errB := os.Stat(flipped)
+		insensitive = errA == nil && errB == nil && os.SameFile(infoA, infoB)
+	}
+	caseInsensitiveDirs.Store(dir, insensitive)
+	return insensitive
+}
+
+// flipCase swaps upper and lower case letters
+func flipCase(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsUpper(r) {
+			return unicode.ToLower(r)
+		}
+		return unicode.ToUpper(r)
+	}, s)
+}
@@ -0,0 +1,347 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Kinds of permission prompts
+const (
+	PermissionEdit  = "edit"  // File edits and writes
+	PermissionBash  = "bash"  // Shell commands
+	PermissionWeb   = "web"   // Web fetches and searches
+	PermissionRead  = "read"  // File reads outside the project
+	PermissionMCP   = "mcp"   // MCP server tools
+	PermissionOther = "other" // Anything not recognized
+)
+
+// PermissionKinds lists the kinds in display order
+var PermissionKinds = []string{PermissionEdit, PermissionBash, PermissionWeb, PermissionRead, PermissionMCP, PermissionOther}
+
+// MaxRecentPermissions is how many prompts a session keeps for allowlist suggestions
+const MaxRecentPermissions = 200
+
+// PermissionSuggestMin is how often the same kind of prompt must repeat before
+// an allow rule is suggested for it
+const PermissionSuggestMin = 3
+
+// PermissionPrompt is one permission prompt an agent showed
+type PermissionPrompt struct {
+	Kind   string    `json:"kind"`
+	Target string    `json:"target,omitempty"` // Command, file, domain or MCP tool asked about
+	At     time.Time `json:"at"`
+}
+
+// PermissionStats counts the permission prompts of a session
+type PermissionStats struct {
+	Since        time.Time          `json:"since"`
+	Counts       map[string]int     `json:"counts"`                  // Prompts per kind
+	AutoApproved int                `json:"auto_approved,omitempty"` // Prompts answered by an approval-limited YOLO grant
+	Recent       []PermissionPrompt `json:"recent,omitempty"`        // Newest last
+}
+
+// Total returns the number of prompts counted
+func (s *PermissionStats) Total() int {
+	if s == nil {
+		return 0
+	}
+	total := 0
+	for _, n := range s.Counts {
+		total += n
+	}
+	return total
+}
+
+// RecordPermission counts a permission prompt of the session
+func (i *Instance) RecordPermission(q *PendingQuestion, now time.Time) PermissionPrompt {
+	p := ClassifyPermission(q)
+	p.At = now
+	if i.Permissions == nil {
+		i.Permissions = &PermissionStats{Since: now, Counts: make(map[string]int)}
+	}
+	s := i.Permissions
+	if s.Counts == nil {
+		s.Counts = make(map[string]int)
+	}
+	s.Counts[p.Kind]++
+	s.Recent = append(s.Recent, p)
+	if len(s.Recent) > MaxRecentPermissions {
+		s.Recent = s.Recent[len(s.Recent)-MaxRecentPermissions:]
+	}
+	return p
+}
+
+var (
+	// editQuestionRe matches Claude's edit questions ("Do you want to make this edit to main.go?")
+	editQuestionRe = regexp.MustCompile(`(?i)(?:edit to|create|write to|overwrite)\s+(\S+?)\??$`)
+	// mcpToolRe matches Claude's MCP tool line ("github - create_issue(title: ...) (MCP)")
+	mcpToolRe = regexp.MustCompile(`^(\S+) - (\S+?)\(`)
+	// urlRe finds a URL in a prompt
+	urlRe = regexp.MustCompile(`https?://[^\s'"` + "`" + `)]+`)
+	// quotedRe finds a quoted command in other agents' prompts ("Allow execution of: 'ls'?")
+	quotedRe = regexp.MustCompile("['`\"]([^'`\"]+)['`\"]")
+)
+
+// ClassifyPermission tells what a permission prompt asks for, from the tool
+// header Claude shows above the question or from the wording of other agents
+func ClassifyPermission(q *PendingQuestion) PermissionPrompt {
+	header, detail := "", ""
+	if len(q.Context) > 0 {
+		header = strings.ToLower(q.Context[0])
+	}
+	if len(q.Context) > 1 {
+		detail = q.Context[1]
+	}
+	question := strings.ToLower(q.Question)
+	text := q.Question + " " + strings.Join(q.Context, " ")
+
+	switch {
+	case strings.Contains(header, "(mcp)") || strings.Contains(detail, "(MCP)") || strings.Contains(text, "mcp__"):
+		return PermissionPrompt{Kind: PermissionMCP, Target: mcpTool(q.Context)}
+	case strings.HasPrefix(header, "bash"):
+		return PermissionPrompt{Kind: PermissionBash, Target: detail}
+	case strings.HasPrefix(header, "fetch") || strings.HasPrefix(header, "web"):
+		if strings.Contains(header, "search") {
+			return PermissionPrompt{Kind: PermissionWeb}
+		}
+		return PermissionPrompt{Kind: PermissionWeb, Target: urlHost(text)}
+	case strings.HasPrefix(header, "read"):
+		return PermissionPrompt{Kind: PermissionRead, Target: detail}
+	case strings.HasPrefix(header, "edit") || strings.HasPrefix(header, "create") || strings.HasPrefix(header, "write") || strings.HasPrefix(header, "overwrite"):
+		return PermissionPrompt{Kind: PermissionEdit, Target: editTarget(q.Question)}
+	}
+
+	// Other agents name the action in the question itself
+	switch {
+	case editQuestionRe.MatchString(q.Question) || strings.Contains(question, "edit") || strings.Contains(question, "write") || strings.Contains(question, "modify"):
+		return PermissionPrompt{Kind: PermissionEdit, Target: editTarget(q.Question)}
+	case strings.Contains(question, "command") || strings.Contains(question, "execut") || strings.Contains(question, "run "):
+		target := detail
+		if m := quotedRe.FindStringSubmatch(q.Question); m != nil {
+			target = m[1]
+		}
+		return PermissionPrompt{Kind: PermissionBash, Target: target}
+	case strings.Contains(question, "fetch") || urlRe.MatchString(q.Question):
+		return PermissionPrompt{Kind: PermissionWeb, Target: urlHost(text)}
+	case strings.Contains(question, "read"):
+		return PermissionPrompt{Kind: PermissionRead}
+	}
+	return PermissionPrompt{Kind: PermissionOther, Target: q.Question}
+}
+
+// editTarget returns the file named by an edit question ("" if none)
+func editTarget(question string) string {
+	if m := editQuestionRe.FindStringSubmatch(question); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// urlHost returns the host of the first URL in text ("" if none)
+func urlHost(text string) string {
+	u, err := url.Parse(urlRe.FindString(text))
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
+
+// mcpTool returns the Claude tool name of an MCP call ("mcp__github__create_issue")
+func mcpTool(context []string) string {
+	for _, line := range context {
+		if strings.HasPrefix(line, "mcp__") {
+			name, _, _ := strings.Cut(line, "(")
+			return name
+		}
+		if m := mcpToolRe.FindStringSubmatch(line); m != nil {
+			return "mcp__" + m[1] + "__" + m[2]
+		}
+	}
+	return ""
+}
+
+// riskyCommands are never suggested for the allowlist: they delete, escalate,
+// publish or run arbitrary code, so each use should still be confirmed
+var riskyCommands = map[string]bool{
+	"rm": true, "sudo": true, "su": true, "dd": true, "mkfs": true, "chmod": true, "chown": true,
+	"curl": true, "wget": true, "ssh": true, "scp": true, "rsync": true, "kill": true, "pkill": true,
+	"sh": true, "bash": true, "zsh": true, "eval": true, "exec": true, "xargs": true, "env": true,
+	"git push": true, "git reset": true, "git clean": true, "git checkout": true, "git rebase": true,
+	"npm publish": true, "docker rm": true, "docker run": true, "kubectl delete": true, "terraform apply": true,
+}
+
+// commandPrefix returns the program and its subcommand ("go test ./..." -> "go test")
+func commandPrefix(command string) string {
+	fields := strings.Fields(command)
+	if len(fields) == 0 {
+		return ""
+	}
+	if len(fields) > 1 && !strings.HasPrefix(fields[1], "-") && !strings.ContainsAny(fields[1], "./=~$") {
+		return fields[0] + " " + fields[1]
+	}
+	return fields[0]
+}
+
+// compoundCommand reports whether a command chains, pipes or substitutes commands
+func compoundCommand(command string) bool {
+	for _, op := range []string{"&&", "||", ";", "|", ">", "<", "$(", "`"} {
+		if strings.Contains(command, op) {
+			return true
+		}
+	}
+	return false
+}
+
+// PermissionRule is a suggested Claude allow rule for prompts that keep coming back
+type PermissionRule struct {
+	Rule  string // Claude Code permission rule ("Bash(go test:*)", "WebFetch(domain:go.dev)")
+	Kind  string
+	Count int    // Recent prompts the rule would have answered
+	Risky string // Why the rule is better left asking ("" = safe to allow)
+}
+
+// permissionRule returns the allow rule covering a prompt ("" = none fits)
+func permissionRule(p PermissionPrompt) (rule, risky string) {
+	switch p.Kind {
+	case PermissionEdit:
+		return "Edit", ""
+	case PermissionRead:
+		return "Read", ""
+	case PermissionWeb:
+		if p.Target == "" {
+			return "WebSearch", ""
+		}
+		return "WebFetch(domain:" + p.Target + ")", ""
+	case PermissionMCP:
+		return p.Target, ""
+	case PermissionBash:
+		prefix := commandPrefix(p.Target)
+		if prefix == "" {
+			return "", ""
+		}
+		if compoundCommand(p.Target) {
+			return "Bash(" + p.Target + ")", "chains several commands"
+		}
+		rule := "Bash(" + prefix + ":*)"
+		program := strings.Fields(prefix)[0]
+		if riskyCommands[prefix] || riskyCommands[program] {
+			return rule, "can delete, publish or run arbitrary code"
+		}
+		return rule, ""
+	}
+	return "", ""
+}
+
+// Suggestions returns allow rules for prompts seen at least PermissionSuggestMin
+// times, most frequent first. Rules in allowed are left out.
+func (s *PermissionStats) Suggestions(allowed map[string]bool) []PermissionRule {
+	if s == nil {
+		return nil
+	}
+	byRule := make(map[string]*PermissionRule)
+	for _, p := range s.Recent {
+		rule, risky := permissionRule(p)
+		if rule == "" || allowed[rule] {
+			continue
+		}
+		if r, ok := byRule[rule]; ok {
+			r.Count++
+			continue
+		}
+		byRule[rule] = &PermissionRule{Rule: rule, Kind: p.Kind, Count: 1, Risky: risky}
+	}
+	var rules []PermissionRule
+	for _, r := range byRule {
+		if r.Count >= PermissionSuggestMin {
+			rules = append(rules, *r)
+		}
+	}
+	sort.Slice(rules, func(a, b int) bool {
+		if rules[a].Count != rules[b].Count {
+			return rules[a].Count > rules[b].Count
+		}
+		return rules[a].Rule < rules[b].Rule
+	})
+	return rules
+}
+
+// claudeSettingsPaths returns the Claude settings files of the session's
+// project that can allow tools, the local (uncommitted) one first
+func (i *Instance) claudeSettingsPaths() []string {
+	return []string{
+		filepath.Join(i.Path, ".claude", "settings.local.json"),
+		filepath.Join(i.Path, ".claude", "settings.json"),
+	}
+}
+
+// AllowedPermissions returns the allow rules of the project's and the user's Claude settings
+func (i *Instance) AllowedPermissions() map[string]bool {
+	allowed := make(map[string]bool)
+	if i.IsRemote() {
+		return allowed
+	}
+	homeDir, _ := os.UserHomeDir()
+	paths := append(i.claudeSettingsPaths(), filepath.Join(homeDir, ".claude", "settings.json"))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		var settings struct {
+			Permissions struct {
+				Allow []string `json:"allow"`
+			} `json:"permissions"`
+		}
+		if json.Unmarshal(data, &settings) != nil {
+			continue
+		}
+		for _, rule := range settings.Permissions.Allow {
+			allowed[rule] = true
+		}
+	}
+	return allowed
+}
+
+// AllowPermission adds a rule to the allow list of the project's
+// .claude/settings.local.json (created if missing), keeping its other settings.
+// The agent picks it up on its next start.
+func (i *Instance) AllowPermission(rule string) error {
+	if i.IsRemote() {
+		return fmt.Errorf("settings of sessions on %s can't be changed from here", i.Host)
+	}
+	path := i.claudeSettingsPaths()[0]
+	settings := make(map[string]interface{})
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &settings); err != nil {
+			return fmt.Errorf("failed to parse %s: %w", path, err)
+		}
+	}
+	permissions, _ := settings["permissions"].(map[string]interface{})
+	if permissions == nil {
+		permissions = make(map[string]interface{})
+	}
+	allow, _ := permissions["allow"].([]interface{})
+	for _, existing := range allow {
+		if existing == rule {
+			return nil
+		}
+	}
+	permissions["allow"] = append(allow, rule)
+	settings["permissions"] = permissions
+
+	data, err := json.MarshalIndent(settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0644)
+}
@@ -0,0 +1,283 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// PresetFetchTimeout bounds downloading the presets gallery
+const PresetFetchTimeout = 10 * time.Second
+
+// maxGallerySize caps the size of a downloaded gallery
+const maxGallerySize = 1 << 20
+
+// Preset is a ready-made session setup: the main agent, extra tabs, a restart
+// policy and watcher rules (a Lua script reacting to the session's events)
+type Preset struct {
+	ID            string        `json:"id" toml:"id"`
+	Name          string        `json:"name" toml:"name"`
+	Description   string        `json:"description,omitempty" toml:"description,omitempty"`
+	Agent         AgentType     `json:"agent" toml:"agent"`                                       // Main agent
+	CustomCommand string        `json:"custom_command,omitempty" toml:"custom_command,omitempty"` // For AgentCustom
+	AutoYes       bool          `json:"auto_yes,omitempty" toml:"auto_yes,omitempty"`
+	RestartPolicy RestartPolicy `json:"restart_policy,omitempty" toml:"restart_policy,omitempty"`
+	Tabs          []PresetTab   `json:"tabs,omitempty" toml:"tabs,omitempty"`
+	Script        string        `json:"script,omitempty" toml:"script,omitempty"` // Lua watcher rules, installed into the scripts directory
+}
+
+// PresetTab is a tab opened with a preset's sessions
+type PresetTab struct {
+	Name          string    `json:"name" toml:"name"`
+	Agent         AgentType `json:"agent" toml:"agent"`                                       // An agent type or terminal
+	CustomCommand string    `json:"custom_command,omitempty" toml:"custom_command,omitempty"` // For AgentCustom
+}
+
+// presetsFile is the contents of a gallery
+type presetsFile struct {
+	Presets []Preset `json:"presets"`
+}
+
+// presetIDRe matches preset IDs (also used in script file names)
+var presetIDRe = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// BundledPresets is the gallery used when no presets_url is configured or it can't be fetched
+var BundledPresets = []Preset{
+	{
+		ID:          "claude-tests-review",
+		Name:        "Claude + tests watcher + reviewer tab",
+		Description: "Claude works in the main tab, a second Claude reviews in 'review', a shell waits in 'tests'. The row turns red when the output shows failing tests and green when they pass.",
+		Agent:       AgentClaude,
+		Tabs: []PresetTab{
+			{Name: "review", Agent: AgentClaude},
+			{Name: "tests", Agent: AgentTerminal},
+		},
+		Script: `-- Color sessions of this preset by the test results in their output
+asm.on("output_line", function(ev)
+  if ev.preset ~= "claude-tests-review" then return end
+  local text = ev.text:lower()
+  if text:match("^%-%-%- fail") or text:match("^fail%s") or text:match("[1-9]%d* failed") then
+    asm.set_color(ev.session_id, "red")
+  elseif text:match("^ok%s") or text:match("%d+ passed") or text:match("all tests passed") then
+    asm.set_color(ev.session_id, "green")
+  end
+end)
+`,
+	},
+	{
+		ID:          "codex-gemini-pair",
+		Name:        "Codex + Gemini second opinion",
+		Description: "Codex in the main tab and Gemini in a 'second-opinion' tab for cross-checking plans and diffs.",
+		Agent:       AgentCodex,
+		Tabs: []PresetTab{
+			{Name: "second-opinion", Agent: AgentGemini},
+		},
+	},
+	{
+		ID:            "claude-unattended",
+		Name:          "Unattended Claude",
+		Description:   "Claude that is restarted when it crashes, with a shell tab. A toast tells when it stops to ask something.",
+		Agent:         AgentClaude,
+		RestartPolicy: RestartOnCrash,
+		Tabs: []PresetTab{
+			{Name: "shell", Agent: AgentTerminal},
+		},
+		Script: `-- Tell when an unattended session needs an answer
+asm.on("activity_changed", function(ev)
+  if ev.preset == "claude-unattended" and ev.activity == "waiting" then
+    asm.notify(ev.session .. " is waiting for input")
+  end
+end)
+`,
+	},
+}
+
+// ValidatePreset checks a preset's ID, agents and restart policy
+func ValidatePreset(p Preset) error {
+	if !presetIDRe.MatchString(p.ID) {
+		return fmt.Errorf("invalid preset id %q (lowercase letters, digits and dashes)", p.ID)
+	}
+	if p.Name == "" {
+		return fmt.Errorf("preset %s has no name", p.ID)
+	}
+	if err := validatePresetAgent(p.Agent, p.CustomCommand, false); err != nil {
+		return fmt.Errorf("preset %s: %w", p.ID, err)
+	}
+	switch p.RestartPolicy {
+	case RestartNever, RestartOnCrash, RestartAlways:
+	default:
+		return fmt.Errorf("preset %s: unknown restart policy %q", p.ID, p.RestartPolicy)
+	}
+	for _, tab := range p.Tabs {
+		if tab.Name == "" {
+			return fmt.Errorf("preset %s: a tab has no name", p.ID)
+		}
+		if err := validatePresetAgent(tab.Agent, tab.CustomCommand, true); err != nil {
+			return fmt.Errorf("preset %s, tab %s: %w", p.ID, tab.Name, err)
+		}
+	}
+	return nil
+}
+
+// validatePresetAgent checks that an agent is known (terminal only for tabs)
+func validatePresetAgent(agent AgentType, customCmd string, tab bool) error {
+	switch {
+	case agent == AgentTerminal && tab:
+		return nil
+	case agent == AgentCustom && customCmd == "":
+		return fmt.Errorf("custom agent without custom_command")
+	}
+	if _, ok := AgentConfigs[agent]; !ok {
+		return fmt.Errorf("unknown agent %q", agent)
+	}
+	return nil
+}
+
+// parsePresets reads presets from gallery data, dropping invalid ones
+func parsePresets(data []byte) ([]Preset, error) {
+	var file presetsFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	return validPresets(file.Presets), nil
+}
+
+// validPresets returns the presets that pass ValidatePreset
+func validPresets(presets []Preset) []Preset {
+	var valid []Preset
+	for _, p := range presets {
+		if ValidatePreset(p) == nil {
+			valid = append(valid, p)
+		}
+	}
+	return valid
+}
+
+// FetchPresetGallery downloads the presets gallery at url (the bundled one when url
+// is empty). On failure the bundled gallery is returned together with the error.
+func FetchPresetGallery(url string) ([]Preset, error) {
+	if url == "" {
+		return BundledPresets, nil
+	}
+	client := &http.Client{Timeout: PresetFetchTimeout}
+	resp, err := client.Get(url)
+	if err != nil {
+		return BundledPresets, fmt.Errorf("failed to fetch presets: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return BundledPresets, fmt.Errorf("failed to fetch presets: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxGallerySize))
+	if err != nil {
+		return BundledPresets, fmt.Errorf("failed to fetch presets: %w", err)
+	}
+	presets, err := parsePresets(data)
+	if err != nil {
+		return BundledPresets, fmt.Errorf("invalid presets gallery: %w", err)
+	}
+	return presets, nil
+}
+
+// PresetScriptPath returns where a preset's watcher rules are installed
+func PresetScriptPath(id string) string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "agent-session-manager", "scripts", "preset-"+id+".lua")
+}
+
+// LoadPresets reads the installed presets ([[presets]] in config.toml)
+func LoadPresets() ([]Preset, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return validPresets(config.Presets), nil
+}
+
+// SavePresets writes the installed presets to config.toml
+func SavePresets(presets []Preset) error {
+	return writeConfigTables("presets", struct {
+		Presets []Preset `toml:"presets"`
+	}{presets})
+}
+
+// InstallPreset adds a preset to config.toml (replacing one with the same ID)
+// and writes its watcher rules to the scripts directory
+func InstallPreset(p Preset) error {
+	if err := ValidatePreset(p); err != nil {
+		return err
+	}
+	installed, err := LoadPresets()
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for idx := range installed {
+		if installed[idx].ID == p.ID {
+			installed[idx] = p
+			replaced = true
+		}
+	}
+	if !replaced {
+		installed = append(installed, p)
+	}
+
+	script := PresetScriptPath(p.ID)
+	if p.Script == "" {
+		os.Remove(script)
+	} else {
+		if err := os.MkdirAll(filepath.Dir(script), 0755); err != nil {
+			return fmt.Errorf("failed to create scripts directory: %w", err)
+		}
+		if err := os.WriteFile(script, []byte(p.Script), 0644); err != nil {
+			return fmt.Errorf("failed to write preset script: %w", err)
+		}
+	}
+	return SavePresets(installed)
+}
+
+// UninstallPreset removes a preset and its watcher rules
+func UninstallPreset(id string) error {
+	installed, err := LoadPresets()
+	if err != nil {
+		return err
+	}
+	var kept []Preset
+	for _, p := range installed {
+		if p.ID != id {
+			kept = append(kept, p)
+		}
+	}
+	os.Remove(PresetScriptPath(id))
+	return SavePresets(kept)
+}
+
+// Equal reports whether two presets set up sessions the same way
+func (p Preset) Equal(o Preset) bool {
+	a, _ := json.Marshal(p)
+	b, _ := json.Marshal(o)
+	return string(a) == string(b)
+}
+
+// Apply sets up a new session as the preset describes; the tabs open when it starts
+func (p Preset) Apply(inst *Instance) {
+	inst.Preset = p.ID
+	inst.Agent = p.Agent
+	inst.CustomCommand = p.CustomCommand
+	inst.AutoYes = inst.AutoYes || p.AutoYes
+	inst.RestartPolicy = p.RestartPolicy
+	inst.FollowedWindows = nil
+	for idx, tab := range p.Tabs {
+		inst.FollowedWindows = append(inst.FollowedWindows, FollowedWindow{
+			Index:         idx + 1,
+			Agent:         tab.Agent,
+			Name:          tab.Name,
+			CustomCommand: tab.CustomCommand,
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package session
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// PreviewFilterOff is the preview filter of a session that shows raw output
+// even though its agent has a default pipeline
+const PreviewFilterOff = "off"
+
+// Preview filter actions
+const (
+	FilterDrop      = "drop"      // Hide the line
+	FilterCollapse  = "collapse"  // Keep only the last of consecutive matching lines
+	FilterHighlight = "highlight" // Show the line in a color
+	FilterDim       = "dim"       // Show the line dimmed
+	FilterReplace   = "replace"   // Rewrite the matched text, then try the next rules
+)
+
+// FilterRule is one rule of a preview filter pipeline in config.toml
+type FilterRule struct {
+	Match  string `toml:"match"`  // Regex tested against the line without colors
+	Action string `toml:"action"` // drop, collapse, highlight, dim or replace
+	Color  string `toml:"color"`  // highlight: color name or #rrggbb (default red)
+	With   string `toml:"with"`   // replace: replacement text ($1 for groups)
+
+	re *regexp.Regexp
+}
+
+// FilteredLine is a preview line that passed a filter pipeline
+type FilteredLine struct {
+	Text  string      // The original line, or the rewritten text without colors
+	Style *FilterRule // The highlight or dim rule that matched (nil = shown as is)
+}
+
+// compilePreviewFilters checks the preview filter pipelines and the agent defaults of a config
+func (c *Config) compilePreviewFilters() error {
+	file := filepath.Base(ConfigPath())
+	for name, rules := range c.PreviewFilters {
+		if name == "" || name == PreviewFilterOff {
+			return fmt.Errorf("invalid preview filter name '%s' in %s", name, file)
+		}
+		for idx := range rules {
+			rule := &rules[idx]
+			switch rule.Action {
+			case FilterDrop, FilterCollapse, FilterHighlight, FilterDim, FilterReplace:
+			default:
+				return fmt.Errorf("preview filter '%s' rule %d: unknown action '%s' in %s", name, idx+1, rule.Action, file)
+			}
+			if rule.Match == "" {
+				return fmt.Errorf("preview filter '%s' rule %d: missing match in %s", name, idx+1, file)
+			}
+			re, err := regexp.Compile(rule.Match)
+			if err != nil {
+				return fmt.Errorf("preview filter '%s' rule %d: %w", name, idx+1, err)
+			}
+			rule.re = re
+		}
+	}
+	for agent, name := range c.AgentFilters {
+		if _, ok := AgentConfigs[agent]; !ok {
+			return fmt.Errorf("unknown agent '%s' in agent_preview_filters of %s", agent, file)
+		}
+		if _, ok := c.PreviewFilters[name]; !ok {
+			return fmt.Errorf("unknown preview filter '%s' for %s in %s", name, agent, file)
+		}
+	}
+	return nil
+}
+
+// PreviewFilterNames returns the names of the preview filter pipelines, sorted
+func (c *Config) PreviewFilterNames() []string {
+	if c == nil {
+		return nil
+	}
+	names := make([]string, 0, len(c.PreviewFilters))
+	for name := range c.PreviewFilters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PreviewFilter returns the pipeline applied to a session's preview: its own
+// choice, otherwise its agent's default ("" and nil when output is shown raw)
+func (c *Config) PreviewFilter(inst *Instance) (string, []FilterRule) {
+	if c == nil || inst.PreviewFilter == PreviewFilterOff {
+		return "", nil
+	}
+	name := inst.PreviewFilter
+	if name == "" {
+		name = c.AgentFilters[inst.WindowAgent(0)]
+	}
+	rules, ok := c.PreviewFilters[name]
+	if !ok {
+		return "", nil // Pipeline removed from config.toml since it was chosen
+	}
+	return name, rules
+}
+
+// ApplyPreviewFilter runs the lines of captured output through a pipeline.
+// Rules are tried in order on the line without colors; the first drop,
+// collapse, highlight or dim rule that matches decides, replace rules rewrite
+// the line and let the next rules see the result.
+func ApplyPreviewFilter(rules []FilterRule, content string) []FilteredLine {
+	var lines []FilteredLine
+	lastCollapse := -1 // Rule that collapsed the previous kept line
+	for _, line := range strings.Split(content, "\n") {
+		plain := stripANSI(line)
+		rewritten := false
+		kept := FilteredLine{Text: line}
+		decided := -1
+		for idx := range rules {
+			rule := &rules[idx]
+			if !rule.re.MatchString(plain) {
+				continue
+			}
+			if rule.Action == FilterReplace {
+				plain = rule.re.ReplaceAllString(plain, rule.With)
+				rewritten = true
+				continue
+			}
+			decided = idx
+			break
+		}
+		if rewritten {
+			kept.Text = plain
+		}
+
+		if decided == -1 {
+			lines = append(lines, kept)
+			lastCollapse = -1
+			continue
+		}
+		switch rule := &rules[decided]; rule.Action {
+		case FilterDrop:
+			continue // The previous kept line stays collapsible
+		case FilterCollapse:
+			if lastCollapse == decided {
+				lines[len(lines)-1] = kept
+				continue
+			}
+			lines = append(lines, kept)
+			lastCollapse = decided
+			continue
+		case FilterHighlight, FilterDim:
+			kept.Text = plain
+			kept.Style = rule
+		}
+		lines = append(lines, kept)
+		lastCollapse = -1
+	}
+	return lines
+}
@@ -0,0 +1,145 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Process is one process running inside a session's panes
+type Process struct {
+	PID     int
+	PPID    int
+	Depth   int // Tree depth below the pane's root process (0 = pane root)
+	Window  int // Window index of the pane the process runs in
+	CPU     float64
+	MemKB   int64
+	Command string
+}
+
+// IsPaneRoot reports whether the process is the pane's own agent/shell process
+func (p Process) IsPaneRoot() bool {
+	return p.Depth == 0
+}
+
+// panePID is the root process of one tmux pane
+type panePID struct {
+	window int
+	pid    int
+}
+
+// sessionPanePIDs returns the root processes of all panes of a session's tmux session
+func sessionPanePIDs(inst *Instance) ([]panePID, error) {
+	if inst.IsRemote() {
+		return nil, fmt.Errorf("processes of sessions on %s are not available", inst.Host)
+	}
+	out, err := Tmux.Output("list-panes", "-s", "-t", inst.TmuxSessionName(), "-F", "#{window_index} #{pane_pid}")
+	if err != nil {
+		return nil, fmt.Errorf("session not running")
+	}
+	var panes []panePID
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		window, err1 := strconv.Atoi(fields[0])
+		pid, err2 := strconv.Atoi(fields[1])
+		if err1 == nil && err2 == nil {
+			panes = append(panes, panePID{window: window, pid: pid})
+		}
+	}
+	return panes, nil
+}
+
+// Processes lists the process trees of an instance's panes, parents before children.
+// On Linux CPU is measured since the previous call for the same process.
+func (s *ResourceSampler) Processes(inst *Instance) ([]Process, error) {
+	panes, err := sessionPanePIDs(inst)
+	if err != nil {
+		return nil, err
+	}
+	procs, err := readProcessTable()
+	if err != nil {
+		return nil, err
+	}
+	children := childrenOf(procs)
+	for _, pids := range children {
+		sort.Ints(pids)
+	}
+
+	now := time.Now()
+	elapsed := now.Sub(s.prevPidAt).Seconds()
+	pidSecs := make(map[int]float64)
+
+	var result []Process
+	var walk func(pid, ppid, depth, window int)
+	walk = func(pid, ppid, depth, window int) {
+		p, ok := procs[pid]
+		if !ok {
+			return
+		}
+		proc := Process{PID: pid, PPID: ppid, Depth: depth, Window: window, CPU: p.cpu, MemKB: p.rssKB, Command: p.command}
+		if p.cpuSecs >= 0 {
+			pidSecs[pid] = p.cpuSecs
+			if prev, ok := s.prevPidSecs[pid]; ok && elapsed > 0 {
+				proc.CPU = (p.cpuSecs - prev) / elapsed * 100
+			}
+		}
+		result = append(result, proc)
+		for _, child := range children[pid] {
+			walk(child, pid, depth+1, window)
+		}
+	}
+	for _, pane := range panes {
+		walk(pane.pid, procs[pane.pid].ppid, 0, pane.window)
+	}
+
+	s.prevPidSecs = pidSecs
+	s.prevPidAt = now
+	return result, nil
+}
+
+// SignalProcess sends a signal to one process of the instance (not the whole session).
+// The PID must belong to one of the session's pane process trees.
+func (i *Instance) SignalProcess(pid int, sig syscall.Signal) error {
+	panes, err := sessionPanePIDs(i)
+	if err != nil {
+		return err
+	}
+	procs, err := readProcessTable()
+	if err != nil {
+		return err
+	}
+
+	// Walk up from pid until a pane root is reached
+	roots := make(map[int]bool, len(panes))
+	for _, pane := range panes {
+		roots[pane.pid] = true
+	}
+	belongs := false
+	for cur, hops := pid, 0; cur > 1 && hops < 256; hops++ {
+		if roots[cur] {
+			belongs = true
+			break
+		}
+		p, ok := procs[cur]
+		if !ok {
+			break
+		}
+		cur = p.ppid
+	}
+	if !belongs {
+		return fmt.Errorf("process %d is not part of session '%s'", pid, i.Name)
+	}
+
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return err
+	}
+	return process.Signal(sig)
+}
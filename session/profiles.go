@@ -0,0 +1,141 @@
+package session
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// AgentProfile is a named launch setup from config.toml ([profiles.<name>]),
+// e.g. an API key and model for work and another for personal projects
+type AgentProfile struct {
+	Agent AgentType         `toml:"agent"` // Agent the profile launches ("" = claude)
+	Model string            `toml:"model"` // Passed with the agent's model flag
+	Args  []string          `toml:"args"`  // Extra command line arguments
+	Env   map[string]string `toml:"env"`   // Variables exported into the session's windows
+}
+
+// ProfileAgent returns the agent the profile launches
+func (p AgentProfile) ProfileAgent() AgentType {
+	if p.Agent == "" {
+		return AgentClaude
+	}
+	return p.Agent
+}
+
+// Describe summarizes the profile in one line ("claude · opus · 2 args · 1 variable")
+func (p AgentProfile) Describe() string {
+	parts := []string{string(p.ProfileAgent())}
+	if p.Model != "" {
+		parts = append(parts, p.Model)
+	}
+	if len(p.Args) > 0 {
+		parts = append(parts, fmt.Sprintf("%d args", len(p.Args)))
+	}
+	if len(p.Env) == 1 {
+		parts = append(parts, "1 variable")
+	} else if len(p.Env) > 1 {
+		parts = append(parts, fmt.Sprintf("%d variables", len(p.Env)))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// ProfileNames returns the names of the configured profiles in order
+func (c *Config) ProfileNames() []string {
+	if c == nil {
+		return nil
+	}
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// validateProfiles checks that every profile launches a known agent with a model flag when it sets a model
+func (c *Config) validateProfiles() error {
+	for _, name := range c.ProfileNames() {
+		p := c.Profiles[name]
+		config, ok := AgentConfigs[p.ProfileAgent()]
+		if !ok || p.ProfileAgent() == AgentTerminal || p.ProfileAgent() == AgentCustom {
+			return fmt.Errorf("unknown agent '%s' in profile '%s'", p.Agent, name)
+		}
+		if p.Model != "" && config.ModelFlag == "" {
+			return fmt.Errorf("profile '%s': %s has no model flag, pass it in args", name, p.ProfileAgent())
+		}
+		if err := checkLaunchFlags(p.ProfileAgent(), p.Args); err != nil {
+			return fmt.Errorf("profile '%s': %w", name, err)
+		}
+		for envName := range p.Env {
+			if !envNameRe.MatchString(envName) {
+				return fmt.Errorf("profile '%s': invalid variable name '%s'", name, envName)
+			}
+		}
+	}
+	return nil
+}
+
+// profileConfig is the loaded config.toml profiles are looked up in (see UseConfig)
+var profileConfig *Config
+
+// UseConfig hands the config.toml the app loaded to the sessions, so building
+// an agent command doesn't read the file again. Without it (one-off commands)
+// the file is read when a session has a profile.
+func UseConfig(config *Config) {
+	profileConfig = config
+}
+
+// agentProfile returns the profile the session is launched with (nil when it
+// has none or the profile was removed from config.toml)
+func (i *Instance) agentProfile() *AgentProfile {
+	if i.Profile == "" {
+		return nil
+	}
+	config := profileConfig
+	if config == nil {
+		loaded, err := LoadConfig()
+		if err != nil {
+			return nil
+		}
+		config = loaded
+	}
+	if p, ok := config.Profiles[i.Profile]; ok {
+		return &p
+	}
+	return nil
+}
+
+// agentFlags returns the model flag, the profile's extra arguments and, for
+// Claude, the MCP config for agent's command line. A window's own model wins
+// over the profile's, whose arguments are only added when the profile is for
+// that agent.
+func (i *Instance) agentFlags(agent AgentType, model string) string {
+	if agent == "" {
+		agent = AgentClaude // Sessions created before the agent was recorded
+	}
+	p := i.agentProfile()
+	if p != nil && p.ProfileAgent() != agent {
+		p = nil
+	}
+	if model == "" && p != nil {
+		model = p.Model
+	}
+	var flags []string
+	if flag := AgentConfigs[agent].ModelFlag; model != "" && flag != "" {
+		flags = append(flags, flag, ShellQuote(model))
+	}
+	if p != nil {
+		for _, arg := range i.sandboxedFlags(p.Args) {
+			flags = append(flags, ShellQuote(arg))
+		}
+	}
+	mcp := ""
+	if agent == AgentClaude {
+		mcp = i.mcpFlags()
+	}
+	if len(flags) == 0 {
+		return mcp
+	}
+	return " " + strings.Join(flags, " ") + mcp
+}
@@ -34,12 +34,21 @@ func NewProject(name string) *Project {
 // generateProjectID creates a sanitized ID from the project name
 func generateProjectID(name string) string {
 	sanitized := strings.ToLower(name)
-	sanitized = strings.ReplaceAll(sanitized, " ", "-")
-	sanitized = strings.ReplaceAll(sanitized, "_", "-")
+	sanitized = strings.NewReplacer(" ", "-", "_", "-", "/", "-", "\\", "-", "\x00", "").Replace(sanitized)
 	timestamp := time.Now().UnixNano()
 	return fmt.Sprintf("proj_%s_%d", sanitized, timestamp)
 }
 
+// ValidateProjectID checks that a project ID names a single directory under
+// projects/; IDs from archives and the sync directory are checked before use,
+// so they can't point outside the config directory
+func ValidateProjectID(id string) error {
+	if id == "" || id == "." || id == ".." || strings.ContainsAny(id, "/\\\x00") {
+		return fmt.Errorf("invalid project ID '%s'", id)
+	}
+	return nil
+}
+
 // GetSessionCount returns the number of sessions in a project
 // This requires loading the project's sessions file
 func (s *Storage) GetProjectSessionCount(projectID string) int {
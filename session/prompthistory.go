@@ -0,0 +1,92 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// MaxPromptHistory is how many sent prompts are kept (all sessions together)
+const MaxPromptHistory = 500
+
+// SentPrompt is a prompt sent to a session from the prompt dialog
+type SentPrompt struct {
+	Text      string    `json:"text"`
+	SessionID string    `json:"session_id"`
+	SentAt    time.Time `json:"sent_at"`
+}
+
+// PromptHistoryPath returns the path of the prompt history (shared by all projects)
+func PromptHistoryPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "agent-session-manager", "prompt-history.json")
+}
+
+// LoadPromptHistory returns the sent prompts of all sessions, oldest first
+func LoadPromptHistory() ([]SentPrompt, error) {
+	data, err := os.ReadFile(PromptHistoryPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read prompt history: %w", err)
+	}
+	var history []SentPrompt
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("failed to parse prompt history: %w", err)
+	}
+	return history, nil
+}
+
+// RecordPrompt appends a prompt sent to a session to the history. Sending the
+// same text again moves it to the end instead of adding a duplicate.
+func RecordPrompt(sessionID, text string) error {
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	history, _ := LoadPromptHistory()
+	kept := history[:0]
+	for _, p := range history {
+		if p.SessionID != sessionID || p.Text != text {
+			kept = append(kept, p)
+		}
+	}
+	kept = append(kept, SentPrompt{Text: text, SessionID: sessionID, SentAt: time.Now()})
+	if len(kept) > MaxPromptHistory {
+		kept = kept[len(kept)-MaxPromptHistory:]
+	}
+	data, err := json.MarshalIndent(kept, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal prompt history: %w", err)
+	}
+	return os.WriteFile(PromptHistoryPath(), data, 0644)
+}
+
+// SessionPrompts returns the texts sent to one session, oldest first
+func SessionPrompts(history []SentPrompt, sessionID string) []string {
+	var texts []string
+	for _, p := range history {
+		if p.SessionID == sessionID {
+			texts = append(texts, p.Text)
+		}
+	}
+	return texts
+}
+
+// SearchPrompts returns the index of the newest prompt before `before` that
+// contains query (case-insensitive), or -1
+func SearchPrompts(history []SentPrompt, query string, before int) int {
+	query = strings.ToLower(query)
+	if before > len(history) {
+		before = len(history)
+	}
+	for idx := before - 1; idx >= 0; idx-- {
+		if strings.Contains(strings.ToLower(history[idx].Text), query) {
+			return idx
+		}
+	}
+	return -1
+}
@@ -0,0 +1,155 @@
+package session
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Readiness check kinds
+const (
+	CheckPort    = "port" // TCP connect to host:port (a bare port means localhost)
+	CheckURL     = "url"  // HTTP GET answering 200
+	CheckCommand = "cmd"  // Shell command exiting 0, run in the session directory
+)
+
+// ReadinessCheckTimeout bounds a single attempt of a check
+const ReadinessCheckTimeout = 3 * time.Second
+
+// ReadinessStartTimeout is how long a start waits for the checks before giving up
+const ReadinessStartTimeout = 5 * time.Minute
+
+// ReadinessCheck is an external service that must be up before the agent
+// starts or a scheduled prompt is sent (e.g. the dev database container)
+type ReadinessCheck struct {
+	Kind   string `json:"kind"`   // port, url or cmd
+	Target string `json:"target"` // host:port, URL or shell command
+}
+
+// String formats the check as it is entered ("port 5432")
+func (c ReadinessCheck) String() string {
+	return c.Kind + " " + c.Target
+}
+
+// ParseReadinessChecks parses checks separated by ';', each "<kind> <target>":
+// "port 5432; url http://localhost:3000/health; cmd pg_isready -h localhost"
+func ParseReadinessChecks(text string) ([]ReadinessCheck, error) {
+	var checks []ReadinessCheck
+	for _, part := range strings.Split(text, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		kind, target, _ := strings.Cut(part, " ")
+		target = strings.TrimSpace(target)
+		if target == "" {
+			return nil, fmt.Errorf("'%s' needs a target, e.g. 'port 5432'", part)
+		}
+		switch kind {
+		case CheckPort:
+			if _, _, err := net.SplitHostPort(portAddress(target)); err != nil {
+				return nil, fmt.Errorf("invalid port check '%s': use port or host:port", target)
+			}
+		case CheckURL:
+			if !strings.HasPrefix(target, "http://") && !strings.HasPrefix(target, "https://") {
+				return nil, fmt.Errorf("invalid url check '%s': use http:// or https://", target)
+			}
+		case CheckCommand:
+		default:
+			return nil, fmt.Errorf("unknown check '%s' (use port, url or cmd)", kind)
+		}
+		checks = append(checks, ReadinessCheck{Kind: kind, Target: target})
+	}
+	return checks, nil
+}
+
+// FormatReadinessChecks formats checks for editing, the inverse of ParseReadinessChecks
+func FormatReadinessChecks(checks []ReadinessCheck) string {
+	parts := make([]string, len(checks))
+	for idx, c := range checks {
+		parts[idx] = c.String()
+	}
+	return strings.Join(parts, "; ")
+}
+
+// portAddress adds localhost to a bare port
+func portAddress(target string) string {
+	if !strings.Contains(target, ":") {
+		return "localhost:" + target
+	}
+	return target
+}
+
+// Run makes one attempt of the check (dir is the working directory of commands)
+func (c ReadinessCheck) Run(dir string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), ReadinessCheckTimeout)
+	defer cancel()
+
+	switch c.Kind {
+	case CheckPort:
+		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", portAddress(c.Target))
+		if err != nil {
+			return fmt.Errorf("not accepting connections")
+		}
+		conn.Close()
+		return nil
+	case CheckURL:
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.Target, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return fmt.Errorf("no response")
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("HTTP %d", resp.StatusCode)
+		}
+		return nil
+	case CheckCommand:
+		cmd := exec.CommandContext(ctx, "sh", "-c", c.Target)
+		cmd.Dir = dir
+		if err := cmd.Run(); err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("timed out")
+			}
+			return fmt.Errorf("failed (%v)", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown check '%s'", c.Kind)
+}
+
+// CheckReadiness runs the session's readiness checks once and returns the
+// first one that failed (nil when all pass). Checks run on this machine, also
+// for sessions on a remote host.
+func (i *Instance) CheckReadiness() (*ReadinessCheck, error) {
+	dir := i.Path
+	if i.IsRemote() {
+		dir = ""
+	}
+	for idx := range i.ReadinessChecks {
+		if err := i.ReadinessChecks[idx].Run(dir); err != nil {
+			return &i.ReadinessChecks[idx], err
+		}
+	}
+	return nil, nil
+}
+
+// ReadinessBackoff returns the wait before the given retry of a failed check:
+// 1s, doubling up to 15s
+func ReadinessBackoff(attempt int) time.Duration {
+	wait := time.Second
+	for n := 1; n < attempt && wait < 15*time.Second; n++ {
+		wait *= 2
+	}
+	if wait > 15*time.Second {
+		wait = 15 * time.Second
+	}
+	return wait
+}
@@ -0,0 +1,198 @@
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// DefaultCastIdleLimit caps pauses in exported recordings (seconds)
+const DefaultCastIdleLimit = 2.0
+
+// castHeader is the first line of an asciicast v2 file
+type castHeader struct {
+	Version       int               `json:"version"`
+	Width         int               `json:"width"`
+	Height        int               `json:"height"`
+	Timestamp     int64             `json:"timestamp"`
+	IdleTimeLimit float64           `json:"idle_time_limit,omitempty"`
+	Title         string            `json:"title,omitempty"`
+	Env           map[string]string `json:"env,omitempty"`
+}
+
+// RecordingsDir returns the directory holding a session's recordings
+func RecordingsDir(id string) string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "agent-session-manager", "recordings", id)
+}
+
+// Recordings returns a session's recording files, oldest first
+func (i *Instance) Recordings() []string {
+	files, _ := filepath.Glob(filepath.Join(RecordingsDir(i.ID), "*.cast"))
+	sort.Strings(files) // Names are timestamps
+	return files
+}
+
+// StartRecording pipes the main agent pane's output into a new asciicast file
+func (i *Instance) StartRecording() error {
+	if !i.IsAlive() {
+		return fmt.Errorf("session not running")
+	}
+	if i.IsRemote() {
+		// The pipe runs asmgr on the tmux host
+		return fmt.Errorf("recording is not available for sessions on %s", i.Host)
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("cannot find the asmgr binary: %w", err)
+	}
+	dir := RecordingsDir(i.ID)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create recordings directory: %w", err)
+	}
+
+	target := i.TmuxSessionName() + ":0"
+	out, err := i.Tmux().Output("display-message", "-p", "-t", target, "#{pane_width} #{pane_height}")
+	if err != nil {
+		return fmt.Errorf("pane not found: %s", target)
+	}
+	size := strings.Fields(string(out))
+	if len(size) != 2 {
+		return fmt.Errorf("unexpected tmux output: %q", out)
+	}
+
+	path := filepath.Join(dir, time.Now().Format("2006-01-02T15-04-05")+".cast")
+	recorder := strings.Join([]string{"exec", ShellQuote(exe), "record-pane", ShellQuote(path), size[0], size[1], ShellQuote(i.Name)}, " ")
+	if err := i.Tmux().Run("pipe-pane", "-t", target, recorder); err != nil {
+		return fmt.Errorf("failed to start recording: %w", err)
+	}
+	return nil
+}
+
+// StopRecording closes the pane pipe, which ends the recorder
+func (i *Instance) StopRecording() error {
+	if !i.IsAlive() {
+		return nil
+	}
+	return i.Tmux().Run("pipe-pane", "-t", i.TmuxSessionName()+":0")
+}
+
+// RecordPane writes pane output read from r to an asciicast v2 file until r is closed
+func RecordPane(r io.Reader, path string, width, height int, title string) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	start := time.Now()
+	header, _ := json.Marshal(castHeader{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: start.Unix(),
+		Title:     title,
+		Env:       map[string]string{"TERM": "xterm-256color"},
+	})
+	if _, err := f.Write(append(header, '\n')); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 32*1024)
+	var pending []byte // Incomplete UTF-8 sequence carried to the next read
+	for {
+		n, readErr := r.Read(buf)
+		if n > 0 {
+			data := append(pending, buf[:n]...)
+			cut := len(data)
+			// Keep a multi-byte character split across reads for the next event
+			for back := 1; back <= 3 && back <= len(data); back++ {
+				if b := data[len(data)-back]; utf8.RuneStart(b) {
+					if !utf8.FullRune(data[len(data)-back:]) {
+						cut = len(data) - back
+					}
+					break
+				}
+			}
+			pending = append([]byte{}, data[cut:]...)
+			if cut > 0 {
+				event, _ := json.Marshal([]interface{}{roundSeconds(time.Since(start)), "o", string(data[:cut])})
+				if _, err := f.Write(append(event, '\n')); err != nil {
+					return err
+				}
+			}
+		}
+		if readErr != nil {
+			if readErr == io.EOF {
+				return nil
+			}
+			return readErr
+		}
+	}
+}
+
+// roundSeconds converts a duration to seconds with microsecond precision
+func roundSeconds(d time.Duration) float64 {
+	return float64(d.Microseconds()) / 1e6
+}
+
+// ExportCast copies a recording to dst, shortening pauses longer than idleLimit seconds
+// (0 keeps the original timing)
+func ExportCast(src, dst string, idleLimit float64) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	scanner := bufio.NewScanner(in)
+	scanner.Buffer(make([]byte, 1024*1024), 16*1024*1024)
+	if !scanner.Scan() {
+		return fmt.Errorf("empty recording: %s", src)
+	}
+	var header castHeader
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil || header.Version != 2 {
+		return fmt.Errorf("not an asciicast v2 recording: %s", src)
+	}
+	data, _ := json.Marshal(header)
+	if _, err := out.Write(append(data, '\n')); err != nil {
+		return err
+	}
+
+	var last, shifted float64
+	for scanner.Scan() {
+		var event []json.RawMessage
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil || len(event) != 3 {
+			continue // Truncated last line of a recording that was cut off
+		}
+		t, err := strconv.ParseFloat(string(event[0]), 64)
+		if err != nil {
+			continue
+		}
+		gap := t - last
+		if idleLimit > 0 && gap > idleLimit {
+			gap = idleLimit
+		}
+		last = t
+		shifted += gap
+		event[0] = json.RawMessage(strconv.FormatFloat(shifted, 'f', 6, 64))
+		line, _ := json.Marshal(event)
+		if _, err := out.Write(append(line, '\n')); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
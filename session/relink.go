@@ -0,0 +1,147 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// relinkSearchDepth is how deep below the nearest existing ancestor moved repos are looked for
+const relinkSearchDepth = 3
+
+// maxRelinkSuggestions caps the candidate paths offered by the relink wizard
+const maxRelinkSuggestions = 8
+
+// PathMissing reports whether the session's working directory no longer exists
+func (i *Instance) PathMissing() bool {
+	if i.IsRemote() {
+		return false // Checked on the host when the session starts
+	}
+	_, err := os.Stat(i.Path)
+	return os.IsNotExist(err)
+}
+
+// ExistingAncestor returns the closest parent of path that still exists
+func ExistingAncestor(path string) string {
+	dir := filepath.Clean(path)
+	for {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
+
+// RelinkSuggestions returns directories that may be where a moved session path went:
+// directories with the same name below its closest existing ancestor (and the home
+// directory), closest first
+func RelinkSuggestions(oldPath string) []string {
+	base := filepath.Base(oldPath)
+	roots := []string{ExistingAncestor(oldPath)}
+	if home, err := os.UserHomeDir(); err == nil && home != roots[0] {
+		roots = append(roots, home)
+	}
+
+	seen := make(map[string]bool)
+	var found []string
+	for _, root := range roots {
+		rootDepth := strings.Count(root, string(filepath.Separator))
+		filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+			if err != nil || !d.IsDir() {
+				return nil
+			}
+			if path != root && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir // Hidden directories (.git, .cache, ...)
+			}
+			if strings.Count(path, string(filepath.Separator))-rootDepth > relinkSearchDepth {
+				return filepath.SkipDir
+			}
+			if path != root && strings.EqualFold(d.Name(), base) && !seen[path] {
+				seen[path] = true
+				found = append(found, path)
+			}
+			return nil
+		})
+	}
+	sort.SliceStable(found, func(a, b int) bool {
+		return strings.Count(found[a], string(filepath.Separator)) < strings.Count(found[b], string(filepath.Separator))
+	})
+	if len(found) > maxRelinkSuggestions {
+		found = found[:maxRelinkSuggestions]
+	}
+	return found
+}
+
+// Relink points the session at a new working directory. Claude keeps its
+// conversations in a directory named after the working directory, so those
+// are moved along to keep resume working. Returns how many conversations moved.
+func (i *Instance) Relink(newPath string) (int, error) {
+	newPath = expandTilde(newPath)
+	absPath, err := filepath.Abs(newPath)
+	if err != nil {
+		return 0, fmt.Errorf("invalid path: %w", err)
+	}
+	info, err := os.Stat(absPath)
+	if err != nil || !info.IsDir() {
+		return 0, fmt.Errorf("not a directory: %s", absPath)
+	}
+
+	moved := 0
+	if i.usesClaudeTranscripts() {
+		moved, err = moveClaudeTranscripts(GetClaudeProjectDir(i.Path), GetClaudeProjectDir(absPath))
+		if err != nil {
+			return moved, fmt.Errorf("failed to move conversations: %w", err)
+		}
+	}
+	i.Path = absPath
+	return moved, nil
+}
+
+// usesClaudeTranscripts reports whether the session or one of its tabs runs Claude
+func (i *Instance) usesClaudeTranscripts() bool {
+	if i.Agent == AgentClaude || i.Agent == "" {
+		return true
+	}
+	for _, fw := range i.FollowedWindows {
+		if fw.Agent == AgentClaude {
+			return true
+		}
+	}
+	return false
+}
+
+// moveClaudeTranscripts moves conversation files between Claude project directories,
+// leaving files that already exist at the destination alone
+func moveClaudeTranscripts(oldDir, newDir string) (int, error) {
+	entries, err := os.ReadDir(oldDir)
+	if os.IsNotExist(err) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	if err := os.MkdirAll(newDir, 0755); err != nil {
+		return 0, err
+	}
+	moved := 0
+	for _, e := range entries {
+		dst := filepath.Join(newDir, e.Name())
+		if _, err := os.Stat(dst); err == nil {
+			continue
+		}
+		if err := os.Rename(filepath.Join(oldDir, e.Name()), dst); err != nil {
+			return moved, err
+		}
+		if strings.HasSuffix(e.Name(), ".jsonl") {
+			moved++
+		}
+	}
+	os.Remove(oldDir) // Only succeeds when everything moved
+	return moved, nil
+}
@@ -0,0 +1,197 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// SSHConnectTimeout is how long (seconds) ssh waits for a remote host before a tmux command fails
+const SSHConnectTimeout = "5"
+
+// HostRetryInterval is how long a host that could not be reached is skipped, so
+// polling a session on an offline host doesn't stall the UI on every tick
+const HostRetryInterval = 30 * time.Second
+
+var (
+	hostsMu   sync.Mutex
+	hostsDown = make(map[string]time.Time) // Host -> when to try connecting again
+)
+
+// remoteHostRe matches an ssh destination: an alias from ~/.ssh/config, host or user@host
+var remoteHostRe = regexp.MustCompile(`^[A-Za-z0-9_][A-Za-z0-9._@-]*$`)
+
+// SplitRemotePath splits an scp-style "host:path" into its host and path.
+// Local paths (absolute, relative with a slash before any colon, ~) return ok = false.
+func SplitRemotePath(path string) (host, remotePath string, ok bool) {
+	host, remotePath, found := strings.Cut(strings.TrimSpace(path), ":")
+	if !found || strings.Contains(host, "/") || !remoteHostRe.MatchString(host) {
+		return "", path, false
+	}
+	if remotePath == "" {
+		remotePath = "~"
+	}
+	return host, remotePath, true
+}
+
+// IsRemote reports whether the session's tmux runs on another host over SSH
+func (i *Instance) IsRemote() bool {
+	return i.Host != ""
+}
+
+// DisplayPath returns the session's directory, as host:path for remote sessions
+func (i *Instance) DisplayPath() string {
+	if i.IsRemote() {
+		return i.Host + ":" + i.Path
+	}
+	return i.Path
+}
+
+// Tmux returns the runner for the session's tmux commands: the local tmux
+// server, or the one on the session's host through ssh
+func (i *Instance) Tmux() Runner {
+	if i.IsRemote() {
+		return sshRunner{host: i.Host}
+	}
+	return Tmux
+}
+
+// TmuxCommand builds an interactive tmux command (attach) for the session's host
+func (i *Instance) TmuxCommand(args ...string) *exec.Cmd {
+	if i.IsRemote() {
+		// -t: attaching needs a terminal on the remote side
+		sshArgs := append(sshOptions(false), "-t", i.Host, tmuxCommandLine(args))
+		return exec.Command("ssh", sshArgs...)
+	}
+	return exec.Command("tmux", args...)
+}
+
+// sshOptions are the ssh flags for tmux commands. Connections to a host share
+// one master connection, so polling a remote session doesn't pay for a new
+// handshake each time. Batch mode fails instead of asking for a password.
+func sshOptions(batch bool) []string {
+	opts := []string{
+		"-o", "ConnectTimeout=" + SSHConnectTimeout,
+		"-o", "ControlMaster=auto",
+		"-o", "ControlPath=" + filepath.Join(os.TempDir(), "asmgr-ssh-%C"),
+		"-o", "ControlPersist=10m",
+	}
+	if batch {
+		opts = append(opts, "-o", "BatchMode=yes")
+	}
+	return opts
+}
+
+// sshRunner runs tmux commands on a remote host
+type sshRunner struct {
+	host string
+}
+
+// command builds the ssh command running tmux with args on the host
+func (r sshRunner) command(args ...string) *exec.Cmd {
+	sshArgs := append(sshOptions(true), r.host, tmuxCommandLine(args))
+	return exec.Command("ssh", sshArgs...)
+}
+
+// Run runs a tmux command on the host
+func (r sshRunner) Run(args ...string) error {
+	_, err := runSSH(r.host, r.command(args...), nil)
+	return err
+}
+
+// Output runs a tmux command on the host and returns its standard output
+func (r sshRunner) Output(args ...string) ([]byte, error) {
+	return runSSH(r.host, r.command(args...), (*exec.Cmd).Output)
+}
+
+// CombinedOutput runs a tmux command on the host and returns its standard output and error
+func (r sshRunner) CombinedOutput(args ...string) ([]byte, error) {
+	return runSSH(r.host, r.command(args...), (*exec.Cmd).CombinedOutput)
+}
+
+// runSSH runs an ssh command unless its host recently couldn't be reached.
+// ssh exits with 255 when the connection itself fails.
+func runSSH(host string, cmd *exec.Cmd, output func(*exec.Cmd) ([]byte, error)) ([]byte, error) {
+	hostsMu.Lock()
+	retryAt, down := hostsDown[host]
+	hostsMu.Unlock()
+	if down && time.Now().Before(retryAt) {
+		return nil, fmt.Errorf("%s is unreachable", host)
+	}
+
+	var out []byte
+	var err error
+	if output != nil {
+		out, err = output(cmd)
+	} else {
+		err = cmd.Run()
+	}
+
+	var exitErr *exec.ExitError
+	unreachable := errors.As(err, &exitErr) && exitErr.ExitCode() == 255
+	hostsMu.Lock()
+	if unreachable {
+		hostsDown[host] = time.Now().Add(HostRetryInterval)
+	} else {
+		delete(hostsDown, host)
+	}
+	hostsMu.Unlock()
+	if unreachable {
+		return out, fmt.Errorf("cannot connect to %s - check ssh access (key or agent, no password prompt)", host)
+	}
+	return out, err
+}
+
+// remoteShell runs a shell command line on the session's host
+func (i *Instance) remoteShell(command string) ([]byte, error) {
+	sshArgs := append(sshOptions(true), i.Host, command)
+	return runSSH(i.Host, exec.Command("ssh", sshArgs...), (*exec.Cmd).Output)
+}
+
+// remoteDirCommand is a shell fragment that changes to path, expanding a leading ~
+func remoteDirCommand(path string) string {
+	switch {
+	case path == "~":
+		return "cd"
+	case strings.HasPrefix(path, "~/"):
+		return "cd ~/" + ShellQuote(strings.TrimPrefix(path, "~/"))
+	}
+	return "cd " + ShellQuote(path)
+}
+
+// resolveRemotePath checks that a directory exists on the session's host and
+// stores its absolute path (tmux doesn't expand ~ in start directories)
+func (i *Instance) resolveRemotePath() error {
+	out, err := i.remoteShell(remoteDirCommand(i.Path) + " && pwd")
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return fmt.Errorf("path does not exist on %s: %s", i.Host, i.Path)
+	}
+	if err != nil {
+		return err // Unreachable host
+	}
+	i.Path = strings.TrimSpace(string(out))
+	return nil
+}
+
+// loginShell wraps a command line to run in the user's login shell, so PATH
+// additions from the profile (~/.local/bin and the like) apply on a remote host
+func loginShell(command string) string {
+	return `exec "${SHELL:-sh}" -lc ` + ShellQuote(command)
+}
+
+// remoteLookPath checks that a command is installed on the session's host
+func (i *Instance) remoteLookPath(command string) error {
+	_, err := i.remoteShell(loginShell("command -v " + ShellQuote(command)))
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return fmt.Errorf("command '%s' not found on %s - is it installed?", command, i.Host)
+	}
+	return err // nil, or the host is unreachable
+}
@@ -0,0 +1,112 @@
+package session
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ReplyOption is one numbered answer of a pending agent question
+type ReplyOption struct {
+	Key   string // Key that selects the option ("1", "2", ...)
+	Label string
+}
+
+// PendingQuestion is a question or permission prompt an agent is waiting on
+type PendingQuestion struct {
+	Window   int // Window index the question is shown in
+	Question string
+	Options  []ReplyOption
+	Context  []string // Non-empty lines above the question inside its box (tool, command, URL)
+}
+
+// replyOptionRe matches numbered menu lines like "❯ 1. Yes" or "│  2) No"
+var replyOptionRe = regexp.MustCompile(`^[│┃|\s]*(?:[❯>›●○▶]\s*)?([1-9])[.)]\s+(.+?)[│┃|\s]*$`)
+
+// PendingQuestion parses the numbered choice menu at the bottom of a window (nil if none)
+func (i *Instance) PendingQuestion(windowIdx int) *PendingQuestion {
+	target := fmt.Sprintf("%s:%d", i.TmuxSessionName(), windowIdx)
+	output, err := i.Tmux().Output("capture-pane", "-t", target, "-p", "-S", "-40")
+	if err != nil {
+		return nil
+	}
+	q := parsePendingQuestion(strings.Split(string(output), "\n"))
+	if q != nil {
+		q.Window = windowIdx
+	}
+	return q
+}
+
+// parsePendingQuestion finds the last numbered option block (1, 2, ...) and the question above it
+func parsePendingQuestion(lines []string) *PendingQuestion {
+	// Ignore the trailing blank lines of the pane
+	end := len(lines)
+	for end > 0 && strings.TrimSpace(stripANSIForDetect(lines[end-1])) == "" {
+		end--
+	}
+
+	// The menu is near the bottom - scan upwards for the option starting at "1"
+	var options []ReplyOption
+	first := -1
+	for idx := end - 1; idx >= 0 && idx >= end-20; idx-- {
+		clean := stripANSIForDetect(lines[idx])
+		match := replyOptionRe.FindStringSubmatch(clean)
+		if match == nil {
+			continue
+		}
+		options = append([]ReplyOption{{Key: match[1], Label: strings.TrimSpace(match[2])}}, options...)
+		if match[1] == "1" {
+			first = idx
+			break
+		}
+	}
+	if first == -1 || len(options) < 2 {
+		return nil
+	}
+
+	// Options must be numbered 1..n without gaps (rules out numbered lists in prose)
+	for n, opt := range options {
+		if opt.Key != fmt.Sprintf("%d", n+1) {
+			return nil
+		}
+	}
+
+	// The question is the closest non-decoration line above the menu
+	question := ""
+	questionIdx := first
+	for idx := first - 1; idx >= 0 && idx >= first-6; idx-- {
+		clean := strings.Trim(strings.TrimSpace(stripANSIForDetect(lines[idx])), "│┃|╭╮╰╯─ ")
+		if clean != "" {
+			question = clean
+			questionIdx = idx
+			break
+		}
+	}
+
+	// What is asked about is shown above the question, up to the top of the box
+	var context []string
+	for idx := questionIdx - 1; idx >= 0 && idx >= questionIdx-12; idx-- {
+		raw := strings.TrimSpace(stripANSIForDetect(lines[idx]))
+		if strings.HasPrefix(raw, "╭") {
+			break
+		}
+		if clean := strings.Trim(raw, "│┃|╮╰╯─ "); clean != "" {
+			context = append([]string{clean}, context...)
+		}
+	}
+	return &PendingQuestion{Question: question, Options: options, Context: context}
+}
+
+// IsPermission reports whether the question asks to allow a tool call ("1. Yes" first)
+func (q *PendingQuestion) IsPermission() bool {
+	return len(q.Options) > 0 && strings.HasPrefix(strings.ToLower(q.Options[0].Label), "yes")
+}
+
+// SendReply presses an option key in a window to answer its pending question
+func (i *Instance) SendReply(windowIdx int, key string) error {
+	if !i.IsAlive() {
+		return fmt.Errorf("session not running")
+	}
+	target := fmt.Sprintf("%s:%d", i.TmuxSessionName(), windowIdx)
+	return i.Tmux().Run("send-keys", "-t", target, key)
+}
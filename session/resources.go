@@ -0,0 +1,204 @@
+package session
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// clockTicks is the kernel's USER_HZ used for /proc CPU times (100 on all common Linux builds)
+const clockTicks = 100
+
+// ResourceUsage is the combined CPU/memory of the processes running in a session's panes
+type ResourceUsage struct {
+	CPU   float64 // Percent of one core (can exceed 100 on multi-core)
+	MemKB int64   // Resident memory in KiB
+	Procs int     // Number of processes in the pane process trees
+}
+
+// FormatMem formats resident memory as a short human-readable string
+func (r ResourceUsage) FormatMem() string {
+	switch {
+	case r.MemKB >= 1024*1024:
+		return fmt.Sprintf("%.1f GB", float64(r.MemKB)/(1024*1024))
+	case r.MemKB >= 1024:
+		return fmt.Sprintf("%d MB", r.MemKB/1024)
+	default:
+		return fmt.Sprintf("%d KB", r.MemKB)
+	}
+}
+
+// processInfo is one row of the process table
+type processInfo struct {
+	ppid    int
+	cpu     float64 // ps %cpu (lifetime average on Linux, recent on macOS)
+	cpuSecs float64 // Cumulative CPU seconds from /proc (Linux only, -1 if unknown)
+	rssKB   int64
+	command string
+}
+
+// procCPUSeconds reads a process's cumulative user+system CPU time from /proc
+func procCPUSeconds(pid int) float64 {
+	data, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", pid))
+	if err != nil {
+		return -1
+	}
+	// The command name may contain spaces - fields are counted after its closing paren
+	idx := bytes.LastIndexByte(data, ')')
+	if idx == -1 {
+		return -1
+	}
+	fields := strings.Fields(string(data[idx+1:]))
+	if len(fields) < 13 {
+		return -1
+	}
+	utime, err1 := strconv.ParseFloat(fields[11], 64)
+	stime, err2 := strconv.ParseFloat(fields[12], 64)
+	if err1 != nil || err2 != nil {
+		return -1
+	}
+	return (utime + stime) / clockTicks
+}
+
+// readProcessTable returns all processes keyed by PID (one ps call)
+func readProcessTable() (map[int]processInfo, error) {
+	out, err := exec.Command("ps", "-A", "-o", "pid=,ppid=,pcpu=,rss=,args=").Output()
+	if err != nil {
+		return nil, err
+	}
+	procs := make(map[int]processInfo)
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 4 {
+			continue
+		}
+		pid, err1 := strconv.Atoi(fields[0])
+		ppid, err2 := strconv.Atoi(fields[1])
+		if err1 != nil || err2 != nil {
+			continue
+		}
+		cpu, _ := strconv.ParseFloat(strings.Replace(fields[2], ",", ".", 1), 64)
+		rss, _ := strconv.ParseInt(fields[3], 10, 64)
+		procs[pid] = processInfo{ppid: ppid, cpu: cpu, cpuSecs: -1, rssKB: rss, command: strings.Join(fields[4:], " ")}
+	}
+	if runtime.GOOS == "linux" {
+		for pid, p := range procs {
+			p.cpuSecs = procCPUSeconds(pid)
+			procs[pid] = p
+		}
+	}
+	return procs, nil
+}
+
+// childrenOf indexes a process table by parent PID
+func childrenOf(procs map[int]processInfo) map[int][]int {
+	children := make(map[int][]int, len(procs))
+	for pid, p := range procs {
+		children[p.ppid] = append(children[p.ppid], pid)
+	}
+	return children
+}
+
+// paneRootPIDs returns the shell/agent PIDs of every tmux pane, keyed by tmux session name
+func paneRootPIDs() (map[string][]int, error) {
+	out, err := Tmux.Output("list-panes", "-a", "-F", "#{session_name} #{pane_pid}")
+	if err != nil {
+		return nil, err
+	}
+	roots := make(map[string][]int)
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		if pid, err := strconv.Atoi(fields[1]); err == nil {
+			roots[fields[0]] = append(roots[fields[0]], pid)
+		}
+	}
+	return roots, nil
+}
+
+// ResourceSampler measures session process trees. On Linux CPU is computed from
+// the CPU time used between two samples, so the first sample reports ps averages.
+type ResourceSampler struct {
+	prevCPUSecs map[string]float64 // Instance ID -> cumulative CPU seconds at the last sample
+	prevAt      time.Time
+	prevPidSecs map[int]float64 // PID -> cumulative CPU seconds at the last process listing
+	prevPidAt   time.Time
+}
+
+// NewResourceSampler creates a sampler with no history
+func NewResourceSampler() *ResourceSampler {
+	return &ResourceSampler{prevCPUSecs: make(map[string]float64)}
+}
+
+// Sample measures the process trees of all running instances' panes.
+// Instances without a tmux session are omitted from the result.
+func (s *ResourceSampler) Sample(instances []*Instance) (map[string]ResourceUsage, error) {
+	procs, err := readProcessTable()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	elapsed := now.Sub(s.prevAt).Seconds()
+	cpuSecs := make(map[string]float64)
+	roots, err := paneRootPIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	children := childrenOf(procs)
+
+	usage := make(map[string]ResourceUsage)
+	for _, inst := range instances {
+		pids, ok := roots[inst.TmuxSessionName()]
+		if !ok {
+			continue
+		}
+		var u ResourceUsage
+		total, exact := 0.0, true
+		seen := make(map[int]bool)
+		stack := append([]int{}, pids...)
+		for len(stack) > 0 {
+			pid := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			if seen[pid] {
+				continue
+			}
+			seen[pid] = true
+			if p, ok := procs[pid]; ok {
+				u.CPU += p.cpu
+				u.MemKB += p.rssKB
+				u.Procs++
+				if p.cpuSecs < 0 {
+					exact = false
+				}
+				total += p.cpuSecs
+			}
+			stack = append(stack, children[pid]...)
+		}
+
+		// Recent CPU from the CPU time used since the last sample
+		if exact {
+			cpuSecs[inst.ID] = total
+			if prev, ok := s.prevCPUSecs[inst.ID]; ok && elapsed > 0 {
+				u.CPU = (total - prev) / elapsed * 100
+				if u.CPU < 0 {
+					// Processes exited since the last sample
+					u.CPU = 0
+				}
+			}
+		}
+		usage[inst.ID] = u
+	}
+	s.prevCPUSecs = cpuSecs
+	s.prevAt = now
+	return usage, nil
+}
@@ -0,0 +1,90 @@
+package session
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RestartPolicy decides whether an agent that exits is started again
+type RestartPolicy string
+
+const (
+	RestartNever   RestartPolicy = ""         // Leave exited agents alone (default)
+	RestartOnCrash RestartPolicy = "on-crash" // Restart when the agent exits with an error or the tmux session vanishes
+	RestartAlways  RestartPolicy = "always"   // Restart whenever the agent exits
+)
+
+// RestartPolicies lists the policies in the order Ctrl+K cycles through them
+var RestartPolicies = []RestartPolicy{RestartNever, RestartOnCrash, RestartAlways}
+
+// MaxRestartAttempts is how many restarts in a row are tried before giving up
+const MaxRestartAttempts = 5
+
+// RestartStableAfter is how long a restarted agent has to keep running for
+// the next exit to count as a new failure instead of another attempt in a row
+const RestartStableAfter = 5 * time.Minute
+
+// String returns the policy as shown in the UI
+func (p RestartPolicy) String() string {
+	if p == RestartNever {
+		return "never"
+	}
+	return string(p)
+}
+
+// Next returns the policy after p in RestartPolicies
+func (p RestartPolicy) Next() RestartPolicy {
+	for idx, policy := range RestartPolicies {
+		if policy == p {
+			return RestartPolicies[(idx+1)%len(RestartPolicies)]
+		}
+	}
+	return RestartNever
+}
+
+// ShouldRestart reports whether an exit is restarted under the policy.
+// code is the agent's exit status, known is false when it could not be read
+// (the tmux session is gone); unknown exits count as crashes.
+func (p RestartPolicy) ShouldRestart(code int, known bool) bool {
+	switch p {
+	case RestartAlways:
+		return true
+	case RestartOnCrash:
+		return !known || code != 0
+	}
+	return false
+}
+
+// RestartBackoff returns how long to wait before restart attempt n (1-based):
+// 2s, 4s, 8s, ... up to a minute
+func RestartBackoff(attempt int) time.Duration {
+	delay := 2 * time.Second
+	for n := 1; n < attempt && delay < time.Minute; n++ {
+		delay *= 2
+	}
+	if delay > time.Minute {
+		delay = time.Minute
+	}
+	return delay
+}
+
+// MainPaneExitStatus returns the exit status of the agent in window 0 once its
+// pane is dead (kept by remain-on-exit); ok is false when it can't be read
+func (i *Instance) MainPaneExitStatus() (code int, ok bool) {
+	out, err := i.Tmux().Output("display-message", "-p", "-t", i.TmuxSessionName()+":0", "#{pane_dead_status}")
+	if err != nil {
+		return 0, false
+	}
+	code, err = strconv.Atoi(strings.TrimSpace(string(out)))
+	return code, err == nil
+}
+
+// RestoreResumeID returns the conversation the main agent resumes when the
+// session is brought back after the tmux server went away ("" = start fresh)
+func (i *Instance) RestoreResumeID() string {
+	if !i.resumeSupported() || (i.ResumeSessionID == "" && i.WindowAgent(0) != AgentClaude) {
+		return ""
+	}
+	return i.conversationID()
+}
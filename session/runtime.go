@@ -0,0 +1,127 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Container runtimes an agent can be launched in (the empty runtime is the host)
+const (
+	RuntimeDocker       = "docker"
+	RuntimeDevcontainer = "devcontainer"
+)
+
+// Runtimes lists the runtimes in the order the new session dialog cycles through them
+var Runtimes = []string{"", RuntimeDocker, RuntimeDevcontainer}
+
+// RuntimeLabel returns a display name for a runtime
+func RuntimeLabel(runtime string) string {
+	if runtime == "" {
+		return "host"
+	}
+	return runtime
+}
+
+// RuntimeDescription describes where the session's agent runs ("" on the host)
+func (i *Instance) RuntimeDescription() string {
+	switch i.Runtime {
+	case RuntimeDocker:
+		return "docker exec in " + i.Container
+	case RuntimeDevcontainer:
+		return "devcontainer of the session directory"
+	}
+	return ""
+}
+
+// containerExec returns the command prefix that runs a program in the session's
+// container (interactive: with a terminal, for the agent itself)
+func (i *Instance) containerExec(interactive bool) string {
+	// The session's variables are set in the pane; pass them on by name
+	var forward string
+	if interactive {
+		for _, name := range i.containerEnvNames() {
+			if i.Runtime == RuntimeDevcontainer {
+				forward += ` --remote-env "` + name + `=$` + name + `"`
+			} else {
+				forward += " -e " + name
+			}
+		}
+	}
+	if i.Runtime == RuntimeDevcontainer {
+		return "devcontainer exec --workspace-folder " + ShellQuote(i.Path) + forward
+	}
+	if interactive {
+		return "docker exec -it" + forward + " " + ShellQuote(i.Container)
+	}
+	return "docker exec " + ShellQuote(i.Container)
+}
+
+// runtimeCommand runs an agent command in a login shell inside the session's
+// container, so the container's profile (PATH, nvm and the like) applies
+func (i *Instance) runtimeCommand(agentCmd string) string {
+	return i.containerExec(true) + " sh -lc " + ShellQuote(agentCmd)
+}
+
+// checkRuntime verifies the session's container is running before it starts
+func (i *Instance) checkRuntime() error {
+	switch i.Runtime {
+	case "":
+		return nil
+	case RuntimeDocker, RuntimeDevcontainer:
+	default:
+		return fmt.Errorf("unknown runtime '%s'", i.Runtime)
+	}
+	if i.IsRemote() {
+		return fmt.Errorf("container runtimes are not supported for sessions on %s", i.Host)
+	}
+	if i.Sandbox != "" {
+		return fmt.Errorf("sandbox '%s' can't be combined with the %s runtime", i.Sandbox, i.Runtime)
+	}
+	if _, err := exec.LookPath("docker"); err != nil {
+		return fmt.Errorf("command 'docker' not found - is it installed?")
+	}
+
+	if i.Runtime == RuntimeDevcontainer {
+		if _, err := exec.LookPath("devcontainer"); err != nil {
+			return fmt.Errorf("command 'devcontainer' not found - install @devcontainers/cli")
+		}
+		// The devcontainer CLI labels the container with the workspace folder
+		out, err := exec.Command("docker", "ps", "-q", "--filter", "label=devcontainer.local_folder="+i.Path).Output()
+		if err != nil {
+			return fmt.Errorf("failed to list containers: %w", err)
+		}
+		if strings.TrimSpace(string(out)) == "" {
+			return fmt.Errorf("no devcontainer running for %s - start it with: devcontainer up --workspace-folder %s", i.Path, ShellQuote(i.Path))
+		}
+		return nil
+	}
+
+	if i.Container == "" {
+		return fmt.Errorf("no container set for the docker runtime")
+	}
+	out, err := exec.Command("docker", "inspect", "-f", "{{.State.Running}}", i.Container).Output()
+	if err != nil {
+		return fmt.Errorf("container '%s' not found", i.Container)
+	}
+	if strings.TrimSpace(string(out)) != "true" {
+		return fmt.Errorf("container '%s' is not running - start it with: docker start %s", i.Container, ShellQuote(i.Container))
+	}
+	return nil
+}
+
+// containerLookPath checks that a command is installed in the session's
+// (running, see checkRuntime) container
+func (i *Instance) containerLookPath(command string) error {
+	line := i.containerExec(false) + " sh -lc " + ShellQuote("command -v "+ShellQuote(command))
+	err := exec.Command("sh", "-c", line).Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return fmt.Errorf("command '%s' not found in the %s container - is it installed?", command, i.Runtime)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check the %s container: %w", i.Runtime, err)
+	}
+	return nil
+}
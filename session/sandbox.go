@@ -0,0 +1,249 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// SandboxPlain is the built-in sandbox that only disables auto-yes
+const SandboxPlain = "plain"
+
+// SandboxWrapper wraps agent commands for a sandboxed start.
+// Command is a template: {cmd} is the agent command, {path} the session directory
+// and {home} the user's home directory (both shell-quoted). Without {cmd} the
+// agent command is appended.
+type SandboxWrapper struct {
+	Name        string `json:"name"`
+	Command     string `json:"command"`
+	Description string `json:"description,omitempty"`
+}
+
+// DefaultSandboxWrappers are available without any configuration
+var DefaultSandboxWrappers = []SandboxWrapper{
+	{
+		Name:        SandboxPlain,
+		Command:     "{cmd}",
+		Description: "No isolation, auto-yes disabled",
+	},
+	{
+		Name:        "firejail",
+		Command:     "firejail --quiet --noroot --caps.drop=all --private-tmp --read-only={home} --read-write={path} --read-write={home}/.claude --read-write={home}/.claude.json {cmd}",
+		Description: "Home read-only except the project and agent config",
+	},
+	{
+		Name:        "bubblewrap",
+		Command:     "bwrap --ro-bind / / --dev /dev --proc /proc --tmpfs /tmp --bind {path} {path} --bind-try {home}/.claude {home}/.claude --bind-try {home}/.claude.json {home}/.claude.json --unshare-pid --die-with-parent --chdir {path} {cmd}",
+		Description: "Read-only root, writable project and agent config",
+	},
+	{
+		Name:        "docker",
+		Command:     "docker run --rm -it -v {path}:/workspace -w /workspace asmgr-sandbox {cmd}",
+		Description: "Project mounted into the asmgr-sandbox image",
+	},
+}
+
+// SandboxConfigPath returns the path of the optional sandbox wrapper config
+func SandboxConfigPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "agent-session-manager", "sandbox.json")
+}
+
+// LoadSandboxWrappers returns the built-in wrappers merged with sandbox.json
+// (a wrapper with an existing name replaces the built-in one)
+func LoadSandboxWrappers() []SandboxWrapper {
+	wrappers := append([]SandboxWrapper{}, DefaultSandboxWrappers...)
+
+	data, err := os.ReadFile(SandboxConfigPath())
+	if err != nil {
+		return wrappers
+	}
+	var config struct {
+		Wrappers []SandboxWrapper `json:"wrappers"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return wrappers
+	}
+
+	for _, custom := range config.Wrappers {
+		if custom.Name == "" || custom.Command == "" {
+			continue
+		}
+		replaced := false
+		for idx := range wrappers {
+			if wrappers[idx].Name == custom.Name {
+				wrappers[idx] = custom
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			wrappers = append(wrappers, custom)
+		}
+	}
+	return wrappers
+}
+
+// FindSandboxWrapper looks up a wrapper by name
+func FindSandboxWrapper(name string) (SandboxWrapper, bool) {
+	for _, w := range LoadSandboxWrappers() {
+		if w.Name == name {
+			return w, true
+		}
+	}
+	return SandboxWrapper{}, false
+}
+
+// Binary returns the program the wrapper runs ("" for a plain wrapper)
+func (w SandboxWrapper) Binary() string {
+	fields := strings.Fields(w.Command)
+	if len(fields) == 0 || strings.HasPrefix(fields[0], "{") {
+		return ""
+	}
+	return fields[0]
+}
+
+// InContainer reports whether the agent runs inside a container image instead of the host
+func (w SandboxWrapper) InContainer() bool {
+	switch filepath.Base(w.Binary()) {
+	case "docker", "podman":
+		return true
+	}
+	return false
+}
+
+// Available reports whether the wrapper's program is installed
+func (w SandboxWrapper) Available() bool {
+	if w.Binary() == "" {
+		return true
+	}
+	_, err := exec.LookPath(w.Binary())
+	return err == nil
+}
+
+// Wrap expands the template around an agent command for a session directory
+func (w SandboxWrapper) Wrap(agentCmd, path string) string {
+	homeDir, _ := os.UserHomeDir()
+	template := w.Command
+	if !strings.Contains(template, "{cmd}") {
+		template += " {cmd}"
+	}
+	return strings.NewReplacer(
+		"{path}", ShellQuote(path),
+		"{home}", ShellQuote(homeDir),
+		"{cmd}", agentCmd,
+	).Replace(template)
+}
+
+// autoYesEnabled applies the sandbox rule that agents never run with auto-yes
+func (i *Instance) autoYesEnabled(autoYes bool) bool {
+	return autoYes && i.Sandbox == ""
+}
+
+// permissionBypassFlags are the flags of any agent, besides the agents' auto-yes
+// flags, that skip its permission prompts or its own sandbox. A flag listed
+// with values only bypasses with one of them.
+var permissionBypassFlags = map[string][]string{
+	"--dangerously-skip-permissions":             nil,
+	"--allow-dangerously-skip-permissions":       nil,
+	"--permission-mode":                          {"bypassPermissions"},
+	"--dangerously-bypass-approvals-and-sandbox": nil,
+	"--sandbox":          {"danger-full-access"},
+	"-s":                 {"danger-full-access"},
+	"--ask-for-approval": {"never"},
+	"-a":                 {"never"},
+	"--yolo":             nil,
+	"--approval-mode":    {"yolo"},
+	"--yes-always":       nil,
+}
+
+// bypassFlagValues returns the values a flag bypasses permissions with (nil =
+// the flag alone) and whether it is a bypass flag at all
+func bypassFlagValues(name string) ([]string, bool) {
+	if values, ok := permissionBypassFlags[name]; ok {
+		return values, true
+	}
+	for _, config := range AgentConfigs {
+		if config.AutoYesFlag != "" && config.AutoYesFlag == name {
+			return nil, true
+		}
+	}
+	return nil, false
+}
+
+// sandboxedFlags drops every agent's permission-bypass flags (and their values)
+// from flags appended to a sandboxed session's agent command
+func (i *Instance) sandboxedFlags(flags []string) []string {
+	if i.Sandbox == "" {
+		return flags
+	}
+	kept := make([]string, 0, len(flags))
+	for idx := 0; idx < len(flags); idx++ {
+		name, value, inline := strings.Cut(flags[idx], "=")
+		values, ok := bypassFlagValues(name)
+		if !ok {
+			kept = append(kept, flags[idx])
+			continue
+		}
+		if len(values) == 0 {
+			continue
+		}
+		if !inline && idx+1 < len(flags) {
+			value = flags[idx+1]
+		}
+		if !slices.Contains(values, value) {
+			kept = append(kept, flags[idx])
+			continue
+		}
+		if !inline {
+			idx++ // The value goes too
+		}
+	}
+	return kept
+}
+
+// sandboxCommand wraps an agent command in the session's sandbox or container,
+// or in a login shell on a remote host (unchanged otherwise)
+func (i *Instance) sandboxCommand(agentCmd string) string {
+	if i.IsRemote() && agentCmd != "" {
+		return loginShell(agentCmd)
+	}
+	if i.Runtime != "" && i.Sandbox != "" && agentCmd != "" {
+		// Fail closed - the container would skip the sandbox (checkRuntime refuses this too)
+		return "echo " + ShellQuote(fmt.Sprintf("asmgr: sandbox '%s' can't be combined with the %s runtime", i.Sandbox, i.Runtime)) + "; exec cat"
+	}
+	if i.Runtime != "" && agentCmd != "" {
+		return i.runtimeCommand(agentCmd)
+	}
+	if i.Sandbox == "" || agentCmd == "" {
+		return agentCmd
+	}
+	w, ok := FindSandboxWrapper(i.Sandbox)
+	if !ok {
+		// Fail closed - never run an unwrapped agent in a sandboxed session
+		return "echo " + ShellQuote(fmt.Sprintf("asmgr: unknown sandbox '%s'", i.Sandbox)) + "; exec cat"
+	}
+	return w.Wrap(agentCmd, i.Path)
+}
+
+// checkSandbox verifies the session's sandbox wrapper exists and is installed
+func (i *Instance) checkSandbox() error {
+	if i.Sandbox == "" {
+		return nil
+	}
+	if i.IsRemote() {
+		return fmt.Errorf("sandboxes are not supported for sessions on %s", i.Host)
+	}
+	w, ok := FindSandboxWrapper(i.Sandbox)
+	if !ok {
+		return fmt.Errorf("unknown sandbox '%s' (see %s)", i.Sandbox, SandboxConfigPath())
+	}
+	if !w.Available() {
+		return fmt.Errorf("sandbox '%s' needs '%s' - is it installed?", w.Name, w.Binary())
+	}
+	return nil
+}
@@ -0,0 +1,295 @@
+// Package scripts runs user Lua scripts that react to workspace events.
+//
+// Each *.lua file in the scripts directory gets its own sandboxed interpreter
+// (no os/io libraries) and an `asm` module:
+//
+//	asm.on(type, fn)                  -- call fn(event) for an event type ("*" = all)
+//	asm.send_prompt(session_id, text) -- send a prompt to a running session
+//	asm.rename(session_id, name)      -- rename a session
+//	asm.set_color(session_id, color)  -- set a session's color (name or #RRGGBB)
+//	asm.notify(text)                  -- show a toast in the session manager
+//	asm.git_branch(path)              -- current git branch of a directory ("" if none)
+//
+// Handlers run on a worker goroutine, so a slow script never holds up the
+// publisher; events that arrive while HookQueueSize are waiting are dropped.
+// Actions are queued and applied by the UI on its own loop (see Engine.Drain).
+package scripts
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/izll/agent-session-manager/session/events"
+	lua "github.com/yuin/gopher-lua"
+)
+
+// HookTimeout bounds how long a single event handler may run
+const HookTimeout = time.Second
+
+// HookQueueSize is how many events may wait for the handlers before new ones are dropped
+const HookQueueSize = 256
+
+// ActionKind identifies what a script asked the UI to do
+type ActionKind string
+
+const (
+	ActionSendPrompt ActionKind = "send_prompt"
+	ActionRename     ActionKind = "rename"
+	ActionSetColor   ActionKind = "set_color"
+	ActionNotify     ActionKind = "notify"
+	ActionError      ActionKind = "error" // A script failed; Text holds the message
+)
+
+// Action is a request queued by a script
+type Action struct {
+	Kind      ActionKind
+	Script    string // Script file name
+	SessionID string
+	Text      string // Prompt, new name, color or message
+}
+
+// script is one loaded Lua file with its event handlers
+type script struct {
+	name     string
+	state    *lua.LState
+	handlers map[string][]*lua.LFunction
+}
+
+// Engine owns the loaded scripts and the queue of pending actions
+type Engine struct {
+	runMu       sync.Mutex // Guards scripts; interpreters aren't goroutine-safe
+	scripts     []*script
+	mu          sync.Mutex // Guards actions
+	actions     []Action
+	unsubscribe func()
+
+	events  chan events.Event // Events waiting for the worker
+	stop    chan struct{}     // Closed to stop the worker
+	done    chan struct{}     // Closed when the worker has stopped
+	dropped atomic.Int64      // Events dropped since the last report
+}
+
+// Dir returns the directory user scripts are loaded from
+func Dir() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "agent-session-manager", "scripts")
+}
+
+// Load loads every *.lua file in Dir and subscribes them to the default event bus.
+// Scripts that fail to load are reported as queued ActionError entries.
+func Load() *Engine {
+	e := &Engine{}
+	files, _ := filepath.Glob(filepath.Join(Dir(), "*.lua"))
+	sort.Strings(files)
+	for _, file := range files {
+		s, err := e.loadScript(file)
+		if err != nil {
+			e.queue(Action{Kind: ActionError, Script: filepath.Base(file), Text: err.Error()})
+			continue
+		}
+		e.scripts = append(e.scripts, s)
+	}
+	if len(e.scripts) > 0 {
+		e.events = make(chan events.Event, HookQueueSize)
+		e.stop = make(chan struct{})
+		e.done = make(chan struct{})
+		go e.run()
+		e.unsubscribe = events.Subscribe(e.enqueue)
+	}
+	return e
+}
+
+// Count returns the number of loaded scripts
+func (e *Engine) Count() int {
+	e.runMu.Lock()
+	defer e.runMu.Unlock()
+	return len(e.scripts)
+}
+
+// Close unsubscribes from the event bus and shuts down all interpreters
+func (e *Engine) Close() {
+	if e.unsubscribe != nil {
+		e.unsubscribe()
+	}
+	if e.stop != nil {
+		close(e.stop)
+		<-e.done
+		e.stop = nil
+	}
+	e.runMu.Lock()
+	defer e.runMu.Unlock()
+	for _, s := range e.scripts {
+		s.state.Close()
+	}
+	e.scripts = nil
+}
+
+// Drain returns and clears the queued actions
+func (e *Engine) Drain() []Action {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	actions := e.actions
+	e.actions = nil
+	return actions
+}
+
+// queue appends an action
+func (e *Engine) queue(a Action) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.actions = append(e.actions, a)
+}
+
+// loadScript creates a sandboxed interpreter and runs the script's top level
+func (e *Engine) loadScript(file string) (*script, error) {
+	L := lua.NewState(lua.Options{SkipOpenLibs: true})
+	for _, lib := range []struct {
+		name string
+		fn   lua.LGFunction
+	}{
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.StringLibName, lua.OpenString},
+		{lua.MathLibName, lua.OpenMath},
+	} {
+		L.Push(L.NewFunction(lib.fn))
+		L.Push(lua.LString(lib.name))
+		L.Call(1, 0)
+	}
+	// No loading of other files from inside the sandbox
+	for _, name := range []string{"dofile", "loadfile", "require"} {
+		L.SetGlobal(name, lua.LNil)
+	}
+
+	s := &script{name: filepath.Base(file), state: L, handlers: make(map[string][]*lua.LFunction)}
+	L.SetGlobal("asm", e.apiModule(s))
+
+	ctx, cancel := context.WithTimeout(context.Background(), HookTimeout)
+	defer cancel()
+	L.SetContext(ctx)
+	if err := L.DoFile(file); err != nil {
+		L.Close()
+		return nil, err
+	}
+	L.RemoveContext()
+	return s, nil
+}
+
+// apiModule builds the `asm` table exposed to a script
+func (e *Engine) apiModule(s *script) *lua.LTable {
+	L := s.state
+	queueFor := func(kind ActionKind) lua.LGFunction {
+		return func(L *lua.LState) int {
+			e.queue(Action{Kind: kind, Script: s.name, SessionID: L.CheckString(1), Text: L.CheckString(2)})
+			return 0
+		}
+	}
+
+	return L.SetFuncs(L.NewTable(), map[string]lua.LGFunction{
+		"on": func(L *lua.LState) int {
+			eventType := L.CheckString(1)
+			s.handlers[eventType] = append(s.handlers[eventType], L.CheckFunction(2))
+			return 0
+		},
+		"send_prompt": queueFor(ActionSendPrompt),
+		"rename":      queueFor(ActionRename),
+		"set_color":   queueFor(ActionSetColor),
+		"notify": func(L *lua.LState) int {
+			e.queue(Action{Kind: ActionNotify, Script: s.name, Text: L.CheckString(1)})
+			return 0
+		},
+		"git_branch": func(L *lua.LState) int {
+			out, err := exec.Command("git", "-C", L.CheckString(1), "rev-parse", "--abbrev-ref", "HEAD").Output()
+			if err != nil {
+				L.Push(lua.LString(""))
+			} else {
+				L.Push(lua.LString(strings.TrimSpace(string(out))))
+			}
+			return 1
+		},
+	})
+}
+
+// eventTable converts a bus event to a Lua table
+func eventTable(L *lua.LState, ev events.Event) *lua.LTable {
+	t := L.NewTable()
+	t.RawSetString("type", lua.LString(ev.Type))
+	t.RawSetString("time", lua.LNumber(ev.Time.Unix()))
+	t.RawSetString("session_id", lua.LString(ev.SessionID))
+	t.RawSetString("session", lua.LString(ev.Session))
+	t.RawSetString("agent", lua.LString(ev.Agent))
+	t.RawSetString("path", lua.LString(ev.Path))
+	t.RawSetString("activity", lua.LString(ev.Activity))
+	t.RawSetString("text", lua.LString(ev.Text))
+	t.RawSetString("preset", lua.LString(ev.Preset))
+	return t
+}
+
+// enqueue hands an event to the worker without waiting; the publisher may be
+// the UI loop, so an event is dropped when the queue is full
+func (e *Engine) enqueue(ev events.Event) {
+	select {
+	case e.events <- ev:
+	default:
+		e.dropped.Add(1)
+	}
+}
+
+// run dispatches queued events until the engine is closed, reporting events
+// dropped while the handlers were behind
+func (e *Engine) run() {
+	defer close(e.done)
+	for {
+		select {
+		case <-e.stop:
+			return
+		case ev := <-e.events:
+			select {
+			case <-e.stop:
+				return // Closed while the event waited
+			default:
+			}
+			e.dispatch(ev)
+			if n := e.dropped.Swap(0); n > 0 {
+				e.queue(Action{Kind: ActionError, Script: "hooks", Text: fmt.Sprintf("%d event(s) dropped - handlers are too slow to keep up", n)})
+			}
+		}
+	}
+}
+
+// dispatch runs the matching handlers of every script for an event
+func (e *Engine) dispatch(ev events.Event) {
+	e.runMu.Lock()
+	defer e.runMu.Unlock()
+
+	for _, s := range e.scripts {
+		fns := append(append([]*lua.LFunction{}, s.handlers[string(ev.Type)]...), s.handlers["*"]...)
+		for _, fn := range fns {
+			if err := callHandler(s.state, fn, ev); err != nil {
+				e.queue(Action{Kind: ActionError, Script: s.name, Text: err.Error()})
+			}
+		}
+	}
+}
+
+// callHandler invokes one handler with a timeout
+func callHandler(L *lua.LState, fn *lua.LFunction, ev events.Event) error {
+	ctx, cancel := context.WithTimeout(context.Background(), HookTimeout)
+	defer cancel()
+	L.SetContext(ctx)
+	defer L.RemoveContext()
+
+	err := L.CallByParam(lua.P{Fn: fn, NRet: 0, Protect: true}, eventTable(L, ev))
+	if err != nil {
+		return fmt.Errorf("%s handler: %w", ev.Type, err)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package session
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultHistoryLimit is the scrollback (lines per window) sessions get unless
+// config.toml or the session sets one. tmux's own default of 2000 lines loses
+// the start of long agent runs.
+const DefaultHistoryLimit = 50000
+
+// HistoryLimits are the choices offered when changing a session's scrollback limit
+var HistoryLimits = []int{10000, 25000, 50000, 100000, 250000, 500000}
+
+// ScrollbackFullRatio is the share of the limit at which scrollback counts as nearly full
+const ScrollbackFullRatio = 0.9
+
+// Scrollback is the scrollback usage of one window (tab)
+type Scrollback struct {
+	Window int    // tmux window index
+	Name   string // Window name
+	Used   int    // Lines scrolled off the screen
+	Limit  int    // history-limit the pane was created with
+}
+
+// Full reports whether the window is about to drop its oldest lines
+func (s Scrollback) Full() bool {
+	return s.Limit > 0 && float64(s.Used) >= float64(s.Limit)*ScrollbackFullRatio
+}
+
+// Percent returns how much of the limit is in use
+func (s Scrollback) Percent() int {
+	if s.Limit <= 0 {
+		return 0
+	}
+	return s.Used * 100 / s.Limit
+}
+
+// EffectiveHistoryLimit returns the scrollback limit new windows of the session
+// get: its own setting, then history_limit from config.toml, then DefaultHistoryLimit
+func (i *Instance) EffectiveHistoryLimit() int {
+	if i.HistoryLimit > 0 {
+		return i.HistoryLimit
+	}
+	if config, err := LoadConfig(); err == nil && config.HistoryLimit > 0 {
+		return config.HistoryLimit
+	}
+	return DefaultHistoryLimit
+}
+
+// globalHistoryLimit returns the tmux server's global history-limit. The server
+// is started for the query so a limit from ~/.tmux.conf is seen even when no
+// session is running yet.
+func globalHistoryLimit() string {
+	out, err := Tmux.Output("start-server", ";", "show-options", "-gv", "history-limit")
+	if value := strings.TrimSpace(string(out)); err == nil && value != "" {
+		return value
+	}
+	return "2000"
+}
+
+// SetHistoryLimit changes the session's scrollback limit (0 = default). Tabs
+// opened afterwards get it right away; panes that already exist keep theirs
+// until the session is restarted.
+func (i *Instance) SetHistoryLimit(limit int) error {
+	if limit < 0 {
+		return fmt.Errorf("invalid scrollback limit: %d", limit)
+	}
+	i.HistoryLimit = limit
+	if !i.IsAlive() {
+		return nil
+	}
+	return i.Tmux().Run("set-option", "-t", i.TmuxSessionName(), "history-limit", strconv.Itoa(i.EffectiveHistoryLimit()))
+}
+
+// Scrollback returns the scrollback usage of each window of a running session
+func (i *Instance) Scrollback() ([]Scrollback, error) {
+	out, err := i.Tmux().Output("list-windows", "-t", i.TmuxSessionName(), "-F", "#{window_index}\t#{history_size}\t#{history_limit}\t#{window_name}")
+	if err != nil {
+		return nil, fmt.Errorf("session '%s' is not running", i.Name)
+	}
+	var usage []Scrollback
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.SplitN(line, "\t", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		s := Scrollback{Name: fields[3]}
+		s.Window, _ = strconv.Atoi(fields[0])
+		s.Used, _ = strconv.Atoi(fields[1])
+		s.Limit, _ = strconv.Atoi(fields[2])
+		usage = append(usage, s)
+	}
+	return usage, nil
+}
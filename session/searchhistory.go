@@ -0,0 +1,69 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// MaxSearchHistory is how many global search queries are kept
+const MaxSearchHistory = 20
+
+// PastSearch is a query run in the global history search (Ctrl+F)
+type PastSearch struct {
+	Query      string    `json:"query"`
+	Results    int       `json:"results"`
+	SearchedAt time.Time `json:"searched_at"`
+}
+
+// SearchHistoryPath returns the path of the global search history (shared by all projects)
+func SearchHistoryPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "agent-session-manager", "search-history.json")
+}
+
+// LoadSearchHistory returns the recent global search queries, newest first
+func LoadSearchHistory() ([]PastSearch, error) {
+	data, err := os.ReadFile(SearchHistoryPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read search history: %w", err)
+	}
+	var history []PastSearch
+	if err := json.Unmarshal(data, &history); err != nil {
+		return nil, fmt.Errorf("failed to parse search history: %w", err)
+	}
+	return history, nil
+}
+
+// RecordSearch puts a global search query at the front of the history.
+// Searching the same text again (in any case) moves it instead of adding a duplicate.
+func RecordSearch(query string, results int) error {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil
+	}
+	history, _ := LoadSearchHistory()
+	kept := []PastSearch{{Query: query, Results: results, SearchedAt: time.Now()}}
+	for _, s := range history {
+		if !strings.EqualFold(s.Query, query) {
+			kept = append(kept, s)
+		}
+	}
+	if len(kept) > MaxSearchHistory {
+		kept = kept[:MaxSearchHistory]
+	}
+	data, err := json.MarshalIndent(kept, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal search history: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(SearchHistoryPath()), 0755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	return os.WriteFile(SearchHistoryPath(), data, 0644)
+}
@@ -0,0 +1,176 @@
+package session
+
+import (
+	"time"
+)
+
+// TabState is a session tab in a state snapshot
+type TabState struct {
+	Index    int       `json:"index"`
+	Name     string    `json:"name"`
+	Agent    AgentType `json:"agent"`
+	Activity string    `json:"activity,omitempty"` // busy, waiting or idle (running sessions only)
+	AutoYes  bool      `json:"auto_yes,omitempty"`
+}
+
+// SessionState is a session in a state snapshot
+type SessionState struct {
+	ID              string     `json:"id"`
+	Name            string     `json:"name"`
+	ProjectID       string     `json:"project_id"` // "" = default sessions
+	ProjectName     string     `json:"project_name"`
+	GroupID         string     `json:"group_id,omitempty"`
+	GroupName       string     `json:"group_name,omitempty"`
+	Path            string     `json:"path"`
+	Agent           AgentType  `json:"agent"`
+	CustomCommand   string     `json:"custom_command,omitempty"`
+	Status          Status     `json:"status"`
+	Activity        string     `json:"activity,omitempty"` // Across all tabs: waiting > busy > idle
+	LastActive      *time.Time `json:"last_active,omitempty"`
+	AutoYes         bool       `json:"auto_yes,omitempty"`
+	Sandbox         string     `json:"sandbox,omitempty"`
+	Favorite        bool       `json:"favorite,omitempty"`
+	Protected       bool       `json:"protected,omitempty"`
+	Tags            []string   `json:"tags,omitempty"`
+	Notes           string     `json:"notes,omitempty"`
+	ResumeSessionID string     `json:"resume_session_id,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	Tabs            []TabState `json:"tabs,omitempty"`
+}
+
+// GroupState is a group in a state snapshot
+type GroupState struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	Notes    string `json:"notes,omitempty"`
+	Sessions int    `json:"sessions"`
+}
+
+// ProjectState is a project (or the default session list) in a state snapshot
+type ProjectState struct {
+	ID       string         `json:"id"` // "" = default sessions
+	Name     string         `json:"name"`
+	Path     string         `json:"path,omitempty"`
+	Running  int            `json:"running"`
+	Waiting  int            `json:"waiting"`
+	Groups   []GroupState   `json:"groups"`
+	Sessions []SessionState `json:"sessions"`
+}
+
+// StateSnapshot is the state of all projects, for scripts and dashboards
+type StateSnapshot struct {
+	GeneratedAt time.Time      `json:"generated_at"`
+	Projects    []ProjectState `json:"projects"`
+}
+
+// Snapshot collects the sessions, groups and projects with their live tmux state.
+// Activity is detected from the panes, so it needs no running TUI.
+func (s *Storage) Snapshot() (*StateSnapshot, error) {
+	projectsData, err := s.LoadProjects()
+	if err != nil {
+		return nil, err
+	}
+	projects := []*Project{{ID: "", Name: "default"}}
+	if projectsData != nil {
+		projects = append(projects, projectsData.Projects...)
+	}
+
+	live := LiveSessions()
+	snapshot := &StateSnapshot{GeneratedAt: time.Now(), Projects: []ProjectState{}}
+	for _, p := range projects {
+		var instances []*Instance
+		var groups []*Group
+		err := s.inProject(p.ID, func() error {
+			var err error
+			instances, groups, err = s.LoadAll()
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		project := ProjectState{ID: p.ID, Name: p.Name, Path: p.Path, Groups: []GroupState{}, Sessions: []SessionState{}}
+		groupNames := make(map[string]string)
+		for _, g := range groups {
+			groupNames[g.ID] = g.Name
+		}
+		for _, inst := range instances {
+			state := snapshotInstance(inst, live)
+			state.ProjectID, state.ProjectName = p.ID, p.Name
+			state.GroupName = groupNames[inst.GroupID]
+			if state.Status == StatusRunning {
+				project.Running++
+			}
+			if state.Activity == ActivityWaiting.String() {
+				project.Waiting++
+			}
+			project.Sessions = append(project.Sessions, state)
+		}
+		for _, g := range groups {
+			group := GroupState{ID: g.ID, Name: g.Name, Notes: g.Notes}
+			for _, inst := range instances {
+				if inst.GroupID == g.ID {
+					group.Sessions++
+				}
+			}
+			project.Groups = append(project.Groups, group)
+		}
+		snapshot.Projects = append(snapshot.Projects, project)
+	}
+	return snapshot, nil
+}
+
+// snapshotInstance describes one session, detecting its activity when it runs
+func snapshotInstance(inst *Instance, live map[string]time.Time) SessionState {
+	agent := inst.Agent
+	if agent == "" {
+		agent = AgentClaude
+	}
+	state := SessionState{
+		ID:              inst.ID,
+		Name:            inst.Name,
+		GroupID:         inst.GroupID,
+		Path:            inst.Path,
+		Agent:           agent,
+		CustomCommand:   inst.CustomCommand,
+		Status:          StatusStopped,
+		AutoYes:         inst.AutoYes && inst.Sandbox == "",
+		Sandbox:         inst.Sandbox,
+		Favorite:        inst.Favorite,
+		Protected:       inst.Protected,
+		Tags:            inst.Tags(),
+		Notes:           inst.Notes,
+		ResumeSessionID: inst.ResumeSessionID,
+		CreatedAt:       inst.CreatedAt,
+	}
+
+	active, running := live[inst.TmuxSessionName()]
+	if running {
+		state.Status = StatusRunning
+		state.LastActive = &active
+	}
+
+	overall := ActivityIdle
+	detect := func(index int) string {
+		if !running {
+			return ""
+		}
+		activity := inst.DetectActivityForWindow(index)
+		if activity == ActivityWaiting || (activity == ActivityBusy && overall == ActivityIdle) {
+			overall = activity
+		}
+		return activity.String()
+	}
+	detect(0)
+	for _, fw := range inst.FollowedWindows {
+		tab := TabState{Index: fw.Index, Name: fw.Name, Agent: fw.Agent, AutoYes: fw.AutoYes}
+		if fw.Agent != AgentTerminal {
+			tab.Activity = detect(fw.Index)
+		}
+		state.Tabs = append(state.Tabs, tab)
+	}
+	if running {
+		state.Activity = overall.String()
+	}
+	return state
+}
@@ -0,0 +1,106 @@
+package session
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Pane split directions of a window: a terminal pane next to the window's agent
+const (
+	SplitHorizontal = "h" // Terminal to the right of the agent (side by side)
+	SplitVertical   = "v" // Terminal below the agent
+)
+
+// WindowSplit returns the persisted split direction of a window ("" = not split)
+func (i *Instance) WindowSplit(windowIdx int) string {
+	if windowIdx == 0 {
+		return i.MainSplit
+	}
+	for _, fw := range i.FollowedWindows {
+		if fw.Index == windowIdx {
+			return fw.Split
+		}
+	}
+	return ""
+}
+
+// setWindowSplit persists the split direction of a window
+func (i *Instance) setWindowSplit(windowIdx int, dir string) {
+	if windowIdx == 0 {
+		i.MainSplit = dir
+		return
+	}
+	for idx := range i.FollowedWindows {
+		if i.FollowedWindows[idx].Index == windowIdx {
+			i.FollowedWindows[idx].Split = dir
+			return
+		}
+	}
+}
+
+// splitWindowArgs returns the tmux command adding the terminal pane to a window.
+// -d keeps the agent pane active, so previews and prompts keep going to it.
+func (i *Instance) splitWindowArgs(windowIdx int, dir string) []string {
+	target := fmt.Sprintf("%s:%d", i.TmuxSessionName(), windowIdx)
+	args := []string{"split-window", "-d", "-" + dir, "-t", target, "-c", i.Path}
+	return append(args, i.windowEnvArgs(windowIdx)...)
+}
+
+// paneCount returns the number of panes in a window
+func (i *Instance) paneCount(windowIdx int) int {
+	target := fmt.Sprintf("%s:%d", i.TmuxSessionName(), windowIdx)
+	out, err := i.Tmux().Output("display-message", "-p", "-t", target, "#{window_panes}")
+	if err != nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(strings.TrimSpace(string(out)))
+	return n
+}
+
+// SplitWindow opens a terminal pane next to the agent of a window and remembers
+// the split, so it is recreated when the session starts again
+func (i *Instance) SplitWindow(windowIdx int, dir string) error {
+	if dir != SplitHorizontal && dir != SplitVertical {
+		return fmt.Errorf("unknown split direction '%s'", dir)
+	}
+	if !i.IsAlive() {
+		return fmt.Errorf("session not running")
+	}
+	if i.paneCount(windowIdx) > 1 {
+		return fmt.Errorf("window %d is already split", windowIdx)
+	}
+	if err := i.Tmux().Run(i.splitWindowArgs(windowIdx, dir)...); err != nil {
+		return fmt.Errorf("failed to split window: %w", err)
+	}
+	i.setWindowSplit(windowIdx, dir)
+	return nil
+}
+
+// UnsplitWindow closes the terminal pane of a window and forgets its split
+func (i *Instance) UnsplitWindow(windowIdx int) error {
+	if !i.IsAlive() {
+		return fmt.Errorf("session not running")
+	}
+	if i.paneCount(windowIdx) > 1 {
+		// The terminal pane is always last, whatever pane-base-index is
+		target := fmt.Sprintf("%s:%d.{bottom-right}", i.TmuxSessionName(), windowIdx)
+		if err := i.Tmux().Run("kill-pane", "-t", target); err != nil {
+			return fmt.Errorf("failed to close pane: %w", err)
+		}
+	}
+	i.setWindowSplit(windowIdx, "")
+	return nil
+}
+
+// restoreSplits recreates the persisted pane splits after the session started
+func (i *Instance) restoreSplits() {
+	if i.MainSplit != "" && i.paneCount(0) == 1 {
+		i.Tmux().Run(i.splitWindowArgs(0, i.MainSplit)...)
+	}
+	for _, fw := range i.FollowedWindows {
+		if fw.Split != "" && i.paneCount(fw.Index) == 1 {
+			i.Tmux().Run(i.splitWindowArgs(fw.Index, fw.Split)...)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package session
+
+import (
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// DiffHunk is one hunk of a file diff
+type DiffHunk struct {
+	Header  string   // @@ line
+	Lines   []string // Hunk body
+	Added   int
+	Removed int
+}
+
+// FileDiff is the diff of one file, split into hunks that can be staged on their own
+type FileDiff struct {
+	Path    string
+	Header  []string // From "diff --git" up to the +++ line
+	Hunks   []DiffHunk
+	New     bool // Untracked or added file (staged as a whole)
+	Deleted bool
+	Binary  bool // No hunks; staged as a whole
+	Staged  bool // From the index (git diff --cached) rather than the working tree
+}
+
+// Added returns the added lines of the file
+func (f FileDiff) Added() int {
+	n := 0
+	for _, h := range f.Hunks {
+		n += h.Added
+	}
+	return n
+}
+
+// Removed returns the removed lines of the file
+func (f FileDiff) Removed() int {
+	n := 0
+	for _, h := range f.Hunks {
+		n += h.Removed
+	}
+	return n
+}
+
+// WholeFile reports whether the file can only be staged or unstaged as a whole
+func (f FileDiff) WholeFile() bool {
+	return f.New || f.Binary || len(f.Hunks) == 0
+}
+
+// Patch returns the patch of one hunk, with the file header, for git apply
+func (f FileDiff) Patch(hunk int) string {
+	var b strings.Builder
+	for _, line := range f.Header {
+		b.WriteString(line + "\n")
+	}
+	h := f.Hunks[hunk]
+	b.WriteString(h.Header + "\n")
+	for _, line := range h.Lines {
+		b.WriteString(line + "\n")
+	}
+	return b.String()
+}
+
+// unquotePath strips git's quoting and a/ b/ prefix from a diff path
+func unquotePath(path string) string {
+	if strings.HasPrefix(path, "\"") {
+		if unquoted, err := strconv.Unquote(path); err == nil {
+			path = unquoted
+		}
+	}
+	if strings.HasPrefix(path, "a/") || strings.HasPrefix(path, "b/") {
+		path = path[2:]
+	}
+	return path
+}
+
+// ParseDiff splits git diff output into files and hunks
+func ParseDiff(diff string, staged bool) []FileDiff {
+	var files []FileDiff
+	var file *FileDiff
+	var hunk *DiffHunk
+	for _, line := range strings.Split(strings.TrimRight(diff, "\n"), "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			files = append(files, FileDiff{Header: []string{line}, Staged: staged})
+			file = &files[len(files)-1]
+			hunk = nil
+			// Fallback path for diffs without ---/+++ lines (binary, mode changes)
+			if idx := strings.LastIndex(line, " b/"); idx != -1 {
+				file.Path = unquotePath(line[idx+1:])
+			}
+		case file == nil:
+			continue
+		case strings.HasPrefix(line, "@@"):
+			file.Hunks = append(file.Hunks, DiffHunk{Header: line})
+			hunk = &file.Hunks[len(file.Hunks)-1]
+		case hunk != nil:
+			hunk.Lines = append(hunk.Lines, line)
+			if strings.HasPrefix(line, "+") {
+				hunk.Added++
+			} else if strings.HasPrefix(line, "-") {
+				hunk.Removed++
+			}
+		default:
+			file.Header = append(file.Header, line)
+			switch {
+			case strings.HasPrefix(line, "new file"):
+				file.New = true
+			case strings.HasPrefix(line, "deleted file"):
+				file.Deleted = true
+			case strings.HasPrefix(line, "Binary files"):
+				file.Binary = true
+			case strings.HasPrefix(line, "+++ ") && line != "+++ /dev/null":
+				file.Path = unquotePath(strings.TrimPrefix(line, "+++ "))
+			case strings.HasPrefix(line, "--- ") && line != "--- /dev/null" && file.Deleted:
+				file.Path = unquotePath(strings.TrimPrefix(line, "--- "))
+			}
+		}
+	}
+	return files
+}
+
+// StagingDiff returns the unstaged and the staged changes of the session's repo, file by file
+func (i *Instance) StagingDiff() ([]FileDiff, error) {
+	if !i.isGitRepo() {
+		return nil, fmt.Errorf("not a git repository")
+	}
+	// Untracked files show up as new files, like in the diff view
+	i.stageUntrackedFiles()
+	unstaged, err := exec.Command("git", "-C", i.Path, "--no-pager", "diff").Output()
+	if err != nil {
+		return nil, fmt.Errorf("git diff failed: %w", err)
+	}
+	staged, err := exec.Command("git", "-C", i.Path, "--no-pager", "diff", "--cached").Output()
+	if err != nil {
+		return nil, fmt.Errorf("git diff --cached failed: %w", err)
+	}
+	return append(ParseDiff(string(unstaged), false), ParseDiff(string(staged), true)...), nil
+}
+
+// gitApply applies a patch to the index
+func (i *Instance) gitApply(patch string, reverse bool) error {
+	args := []string{"-C", i.Path, "apply", "--cached"}
+	if reverse {
+		args = append(args, "--reverse")
+	}
+	cmd := exec.Command("git", append(args, "-")...)
+	cmd.Stdin = strings.NewReader(patch)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git apply failed: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// ToggleStaged stages an unstaged hunk or unstages a staged one. A negative
+// hunk (or a file that only goes as a whole) moves the whole file.
+func (i *Instance) ToggleStaged(f FileDiff, hunk int) error {
+	if hunk >= 0 && hunk < len(f.Hunks) && !f.WholeFile() {
+		return i.gitApply(f.Patch(hunk), f.Staged)
+	}
+	var cmd *exec.Cmd
+	switch {
+	case !f.Staged:
+		cmd = exec.Command("git", "-C", i.Path, "add", "-A", "--", f.Path)
+	case f.New:
+		// Back to an untracked file (shown again as new in the working tree diff)
+		cmd = exec.Command("git", "-C", i.Path, "rm", "-q", "--cached", "--", f.Path)
+	default:
+		cmd = exec.Command("git", "-C", i.Path, "reset", "-q", "--", f.Path)
+	}
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git %s failed: %s", cmd.Args[3], strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// Commit commits the staged changes and returns git's summary line
+func (i *Instance) Commit(message string) (string, error) {
+	out, err := exec.Command("git", "-C", i.Path, "commit", "-q", "-m", message).CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git commit failed: %s", strings.TrimSpace(string(out)))
+	}
+	return commandLine(exec.Command("git", "-C", i.Path, "log", "-1", "--format=%h %s")), nil
+}
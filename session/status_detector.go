@@ -2,7 +2,6 @@ package session
 
 import (
 	"fmt"
-	"os/exec"
 	"strings"
 )
 
@@ -15,6 +14,18 @@ const (
 	ActivityWaiting                        // Agent needs user input/permission
 )
 
+// String returns the activity name used in events and reports
+func (a SessionActivity) String() string {
+	switch a {
+	case ActivityBusy:
+		return "busy"
+	case ActivityWaiting:
+		return "waiting"
+	default:
+		return "idle"
+	}
+}
+
 // AgentPatterns holds detection patterns for a specific agent
 type AgentPatterns struct {
 	WaitingPatterns []string // Patterns that indicate waiting for user input
@@ -161,8 +172,7 @@ func (i *Instance) DetectActivityForWindow(windowIdx int) SessionActivity {
 		}
 	}
 
-	cmd := exec.Command("tmux", "capture-pane", "-t", target, "-p", "-S", "-50")
-	output, err := cmd.Output()
+	output, err := i.Tmux().Output("capture-pane", "-t", target, "-p", "-S", "-50")
 	if err != nil {
 		return ActivityIdle
 	}
@@ -15,6 +15,16 @@ type Storage struct {
 	configPath string
 	projectID  string // Active project ID ("" = default)
 	lockPath   string // Current lock file path
+
+	global bool              // Cross-project list: Load returns all projects' sessions
+	owners map[string]string // Session ID -> project ID in the cross-project list
+
+	defaults Settings // Settings of projects that have none saved yet
+
+	watchPath string // Session file guarded against overwriting changes made by other processes ("" = none)
+	base      []byte // Contents of the watched file as last loaded or written by this process
+	refused   bool   // A save was refused with ErrExternalChange since RefusedSave was last called
+	helper    bool   // Saves are made by a helper process (see MarkHelper)
 }
 
 // Group represents a session group for organizing sessions
@@ -25,6 +35,7 @@ type Group struct {
 	Color        string `json:"color,omitempty"`          // Group name color
 	BgColor      string `json:"bg_color,omitempty"`       // Background color
 	FullRowColor bool   `json:"full_row_color,omitempty"` // Extend background to full row
+	Notes        string `json:"notes,omitempty"`          // What the group's sessions are for
 }
 
 // Settings stores UI preferences
@@ -35,7 +46,12 @@ type Settings struct {
 	SplitView         bool   `json:"split_view,omitempty"`
 	MarkedSessionID   string `json:"marked_session_id,omitempty"`
 	Cursor            int    `json:"cursor,omitempty"`
+	SelectedID        string `json:"selected_id,omitempty"` // Selected row (session ID or "group:<id>"), wins over Cursor
 	SplitFocus        int    `json:"split_focus,omitempty"`
+	StuckMinutes      int    `json:"stuck_minutes,omitempty"`
+	GlobalList        bool   `json:"global_list,omitempty"` // Show sessions of all projects
+	AutoColor         string `json:"auto_color,omitempty"`  // Color new sessions/groups: "", "round-robin" or "hash"
+	NestForks         bool   `json:"nest_forks,omitempty"`  // Show forks and parallel sessions under their origin
 }
 
 type StorageData struct {
@@ -64,14 +80,18 @@ func NewStorage() (*Storage, error) {
 
 // SetActiveProject switches to a different project
 func (s *Storage) SetActiveProject(projectID string) error {
-	s.projectID = projectID
 	if projectID == "" {
+		s.projectID = projectID
 		s.configPath = filepath.Join(s.configDir, "sessions.json")
 	} else {
+		if err := ValidateProjectID(projectID); err != nil {
+			return err
+		}
 		projectDir := filepath.Join(s.configDir, "projects", projectID)
 		if err := os.MkdirAll(projectDir, 0755); err != nil {
 			return fmt.Errorf("failed to create project directory: %w", err)
 		}
+		s.projectID = projectID
 		s.configPath = filepath.Join(projectDir, "sessions.json")
 	}
 	return nil
@@ -354,6 +374,9 @@ func (s *Storage) ImportDefaultSessions(projectID string) (int, error) {
 }
 
 func (s *Storage) Load() ([]*Instance, error) {
+	if s.global {
+		return s.LoadGlobal()
+	}
 	instances, _, err := s.LoadAll()
 	return instances, err
 }
@@ -368,7 +391,8 @@ func (s *Storage) LoadAll() ([]*Instance, []*Group, error) {
 func (s *Storage) LoadAllWithSettings() ([]*Instance, []*Group, *Settings, error) {
 	data, err := os.ReadFile(s.configPath)
 	if os.IsNotExist(err) {
-		return []*Instance{}, []*Group{}, &Settings{}, nil
+		defaults := s.defaults
+		return []*Instance{}, []*Group{}, &defaults, nil
 	}
 	if err != nil {
 		return nil, nil, nil, fmt.Errorf("failed to read config file: %w", err)
@@ -389,19 +413,31 @@ func (s *Storage) LoadAllWithSettings() ([]*Instance, []*Group, *Settings, error
 	}
 
 	if storageData.Settings == nil {
-		storageData.Settings = &Settings{}
+		defaults := s.defaults
+		storageData.Settings = &defaults
 	}
 
 	return storageData.Instances, storageData.Groups, storageData.Settings, nil
 }
 
+// SetDefaults sets the settings returned for projects that have none saved yet
+func (s *Storage) SetDefaults(settings Settings) {
+	s.defaults = settings
+}
+
 func (s *Storage) Save(instances []*Instance) error {
+	if s.global {
+		return s.saveGlobal(instances)
+	}
 	_, groups, settings, _ := s.LoadAllWithSettings()
 	return s.SaveAll(instances, groups, settings)
 }
 
 // SaveWithGroups saves instances and groups (preserves settings)
 func (s *Storage) SaveWithGroups(instances []*Instance, groups []*Group) error {
+	if s.global {
+		return s.saveGlobal(instances) // Groups are per project and not shown in the cross-project list
+	}
 	_, _, settings, _ := s.LoadAllWithSettings()
 	return s.SaveAll(instances, groups, settings)
 }
@@ -414,6 +450,10 @@ func (s *Storage) SaveSettings(settings *Settings) error {
 
 // SaveAll saves instances, groups, and settings
 func (s *Storage) SaveAll(instances []*Instance, groups []*Group, settings *Settings) error {
+	if change, _ := s.ExternalChanges(nil); change != nil {
+		s.refused = true
+		return ErrExternalChange
+	}
 	storageData := StorageData{
 		Instances: instances,
 		Groups:    groups,
@@ -428,11 +468,22 @@ func (s *Storage) SaveAll(instances []*Instance, groups []*Group, settings *Sett
 	if err := os.WriteFile(s.configPath, data, 0644); err != nil {
 		return fmt.Errorf("failed to write config file: %w", err)
 	}
+	if s.configPath == s.watchPath {
+		s.base = data
+	}
+	if s.helper {
+		s.stampHelperWrite(data)
+	}
 
 	return nil
 }
 
 func (s *Storage) AddInstance(instance *Instance) error {
+	if s.global {
+		// New sessions go to the active project
+		s.owners[instance.ID] = s.projectID
+		return s.inProject(s.projectID, func() error { return s.AddInstance(instance) })
+	}
 	instances, err := s.Load()
 	if err != nil {
 		return err
@@ -450,6 +501,9 @@ func (s *Storage) AddInstance(instance *Instance) error {
 }
 
 func (s *Storage) RemoveInstance(id string) error {
+	if projectID, ok := s.routeOf(id); ok {
+		return s.inProject(projectID, func() error { return s.RemoveInstance(id) })
+	}
 	instances, err := s.Load()
 	if err != nil {
 		return err
@@ -462,6 +516,7 @@ func (s *Storage) RemoveInstance(id string) error {
 			found = true
 			// Stop the instance if running
 			inst.Stop()
+			inst.removeMCPConfig()
 			continue
 		}
 		newInstances = append(newInstances, inst)
@@ -475,6 +530,9 @@ func (s *Storage) RemoveInstance(id string) error {
 }
 
 func (s *Storage) UpdateInstance(instance *Instance) error {
+	if projectID, ok := s.routeOf(instance.ID); ok {
+		return s.inProject(projectID, func() error { return s.UpdateInstance(instance) })
+	}
 	instances, err := s.Load()
 	if err != nil {
 		return err
@@ -520,6 +578,31 @@ func (s *Storage) GetInstanceByName(name string) (*Instance, error) {
 	return nil, fmt.Errorf("instance not found")
 }
 
+// FindInstanceAnyProject looks up a session by name, ID or tmux session name in the
+// default session list and then in every project (the matching project stays active)
+func (s *Storage) FindInstanceAnyProject(key string) (*Instance, error) {
+	matches := func(inst *Instance) bool {
+		return inst.Name == key || inst.ID == key || inst.TmuxSessionName() == key
+	}
+
+	projectIDs := []string{""}
+	if projectsData, err := s.LoadProjects(); err == nil && projectsData != nil {
+		for _, project := range projectsData.Projects {
+			projectIDs = append(projectIDs, project.ID)
+		}
+	}
+	for _, id := range projectIDs {
+		s.SetActiveProject(id)
+		instances, _, _ := s.LoadAll()
+		for _, inst := range instances {
+			if matches(inst) {
+				return inst, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("session not found: %s", key)
+}
+
 
 // GetGroups returns all groups
 func (s *Storage) GetGroups() ([]*Group, error) {
@@ -555,40 +638,25 @@ func (s *Storage) AddGroup(name string) (*Group, error) {
 	return group, nil
 }
 
-// RemoveGroup removes a group (sessions become ungrouped)
-func (s *Storage) RemoveGroup(id string) error {
+// RenameGroup renames a group
+func (s *Storage) RenameGroup(id, name string) error {
 	instances, groups, err := s.LoadAll()
 	if err != nil {
 		return err
 	}
 
-	// Ungroup all sessions in this group
-	for _, inst := range instances {
-		if inst.GroupID == id {
-			inst.GroupID = ""
-		}
-	}
-
-	// Remove the group
-	newGroups := make([]*Group, 0, len(groups))
-	found := false
 	for _, g := range groups {
 		if g.ID == id {
-			found = true
-			continue
+			g.Name = name
+			return s.SaveWithGroups(instances, groups)
 		}
-		newGroups = append(newGroups, g)
-	}
-
-	if !found {
-		return fmt.Errorf("group not found")
 	}
 
-	return s.SaveWithGroups(instances, newGroups)
+	return fmt.Errorf("group not found")
 }
 
-// RenameGroup renames a group
-func (s *Storage) RenameGroup(id, name string) error {
+// SetGroupNotes sets a group's description/notes
+func (s *Storage) SetGroupNotes(id, notes string) error {
 	instances, groups, err := s.LoadAll()
 	if err != nil {
 		return err
@@ -596,7 +664,7 @@ func (s *Storage) RenameGroup(id, name string) error {
 
 	for _, g := range groups {
 		if g.ID == id {
-			g.Name = name
+			g.Notes = notes
 			return s.SaveWithGroups(instances, groups)
 		}
 	}
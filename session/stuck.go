@@ -0,0 +1,104 @@
+package session
+
+import (
+	"fmt"
+	"hash/fnv"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DefaultStuckAfter is how long a busy session may go without new output before it is flagged
+const DefaultStuckAfter = 10 * time.Minute
+
+// StuckDetector tracks how long busy sessions have gone without new output
+type StuckDetector struct {
+	sigs    map[string]uint64    // Last output signature per session ID
+	changed map[string]time.Time // When the output last changed (or the session became busy)
+}
+
+// NewStuckDetector creates an empty stuck detector
+func NewStuckDetector() *StuckDetector {
+	return &StuckDetector{
+		sigs:    make(map[string]uint64),
+		changed: make(map[string]time.Time),
+	}
+}
+
+// Observe records the session's current output and returns how long it has been
+// busy without new output (0 while not busy or when the output just changed)
+func (d *StuckDetector) Observe(inst *Instance, activity SessionActivity, now time.Time) time.Duration {
+	if activity != ActivityBusy {
+		d.Reset(inst.ID)
+		return 0
+	}
+	sig, ok := inst.outputSignature()
+	if !ok {
+		return 0
+	}
+	if prev, seen := d.sigs[inst.ID]; !seen || prev != sig {
+		d.sigs[inst.ID] = sig
+		d.changed[inst.ID] = now
+		return 0
+	}
+	return now.Sub(d.changed[inst.ID])
+}
+
+// Reset forgets a session so its quiet period starts over
+func (d *StuckDetector) Reset(id string) {
+	delete(d.sigs, id)
+	delete(d.changed, id)
+}
+
+// outputSignature hashes the agent window's visible text, ignoring what changes
+// while an agent merely waits (spinners, elapsed-time and token counters)
+func (i *Instance) outputSignature() (uint64, bool) {
+	target := i.TmuxSessionName() + ":0"
+	out, err := i.Tmux().Output("capture-pane", "-t", target, "-p", "-J")
+	if err != nil {
+		return 0, false
+	}
+	h := fnv.New64a()
+	for _, line := range strings.Split(string(out), "\n") {
+		// The busy status line ticks even when nothing happens
+		if strings.Contains(strings.ToLower(line), "to interrupt") {
+			continue
+		}
+		h.Write([]byte(strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) {
+				return r
+			}
+			return -1
+		}, line)))
+		h.Write([]byte{'\n'})
+	}
+	return h.Sum64(), true
+}
+
+// LastResumeID returns the conversation a restart should resume ("" if unknown)
+func (i *Instance) LastResumeID() string {
+	if i.ResumeSessionID != "" {
+		return i.ResumeSessionID
+	}
+	if path := i.ClaudeTranscriptPath(); path != "" {
+		return strings.TrimSuffix(filepath.Base(path), ".jsonl")
+	}
+	return ""
+}
+
+// RestartAgent respawns the main agent window, resuming its last conversation when known
+func (i *Instance) RestartAgent() error {
+	if resumeID := i.LastResumeID(); resumeID != "" && i.GetAgentConfig().SupportsResume {
+		return i.RespawnWindowWithResume(0, resumeID)
+	}
+	return i.RespawnWindow(0)
+}
+
+// Nudge sends a key (e.g. "Enter" or "Escape") to the main agent window
+func (i *Instance) Nudge(key string) error {
+	if !i.IsAlive() {
+		return fmt.Errorf("session not running")
+	}
+	return i.Tmux().Run("send-keys", "-t", i.TmuxSessionName()+":0", key)
+}
@@ -1,7 +1,6 @@
 package session
 
 import (
-	"os/exec"
 	"strings"
 )
 
@@ -12,8 +11,7 @@ func (i *Instance) GetSuggestion() string {
 	}
 
 	sessionName := i.TmuxSessionName()
-	cmd := exec.Command("tmux", "capture-pane", "-t", sessionName, "-p", "-S", "-30")
-	output, err := cmd.Output()
+	output, err := i.Tmux().Output("capture-pane", "-t", sessionName, "-p", "-S", "-30")
 	if err != nil {
 		return ""
 	}
@@ -0,0 +1,151 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultSummaryPrompt asks for a one-paragraph summary of the session
+const DefaultSummaryPrompt = "Summarize in one paragraph what was accomplished in this session so far. Reply with the summary only, no preamble."
+
+// MaxSummaryInput caps the transcript text passed to a summarizer command (bytes, newest kept)
+const MaxSummaryInput = 100000
+
+// SummaryConfig configures automatic session summaries
+type SummaryConfig struct {
+	Command string `json:"command,omitempty"` // Summarizer command (gets prompt + transcript on stdin); empty = ask the session's own agent
+	Prompt  string `json:"prompt,omitempty"`  // Custom summary prompt
+	OnStop  bool   `json:"on_stop,omitempty"` // Summarize automatically when a session is stopped
+}
+
+// GetSummaryConfigPath returns the path to the summary config file
+func GetSummaryConfigPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "agent-session-manager", "summary.json")
+}
+
+// LoadSummaryConfig loads the summary configuration (defaults if missing)
+func LoadSummaryConfig() *SummaryConfig {
+	cfg := &SummaryConfig{}
+	data, err := os.ReadFile(GetSummaryConfigPath())
+	if err == nil {
+		json.Unmarshal(data, cfg)
+	}
+	if cfg.Prompt == "" {
+		cfg.Prompt = DefaultSummaryPrompt
+	}
+	return cfg
+}
+
+// SummaryInput collects the conversation text a summarizer command works from.
+// Claude sessions use the transcript, other agents the tmux scrollback.
+func (i *Instance) SummaryInput() string {
+	if path := i.ClaudeTranscriptPath(); path != "" {
+		entry := &HistoryEntry{Agent: AgentClaude, SessionFile: path}
+		if messages, err := entry.LoadConversation(); err == nil && len(messages) > 0 {
+			var b strings.Builder
+			for _, msg := range messages {
+				role := "User"
+				if msg.Role == "assistant" {
+					role = "Assistant"
+				}
+				fmt.Fprintf(&b, "%s: %s\n\n", role, msg.Content)
+			}
+			return tailBytes(b.String(), MaxSummaryInput)
+		}
+	}
+
+	if !i.IsAlive() {
+		return i.LastOutput
+	}
+	output, err := i.Tmux().Output("capture-pane", "-t", i.TmuxSessionName()+":0", "-p", "-J", "-S", "-2000")
+	if err != nil {
+		return ""
+	}
+	return tailBytes(strings.TrimSpace(string(output)), MaxSummaryInput)
+}
+
+// tailBytes keeps the last max bytes of s, starting at a line boundary
+func tailBytes(s string, max int) string {
+	if len(s) <= max {
+		return s
+	}
+	s = s[len(s)-max:]
+	if idx := strings.Index(s, "\n"); idx != -1 {
+		s = s[idx+1:]
+	}
+	return s
+}
+
+// Summarize produces a one-paragraph summary of the session.
+// Uses the configured command, otherwise asks Claude on a fork of the conversation.
+func (i *Instance) Summarize(cfg *SummaryConfig, input string) (string, error) {
+	var output []byte
+	var err error
+
+	switch {
+	case cfg.Command != "":
+		cmd := exec.Command("sh", "-c", cfg.Command)
+		cmd.Dir = i.Path
+		cmd.Stdin = strings.NewReader(cfg.Prompt + "\n\n" + input)
+		output, err = cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("summarizer command failed: %w", err)
+		}
+
+	case i.Agent == AgentClaude || i.Agent == "":
+		sessionID := i.conversationID()
+		if sessionID == "" {
+			return "", fmt.Errorf("no conversation found to summarize")
+		}
+		// Fork so the summary request doesn't end up in the session's own conversation
+		cmd := exec.Command("claude", "--resume", sessionID, "--fork-session", "--output-format", "json", "-p", cfg.Prompt)
+		cmd.Dir = i.Path
+		out, err := cmd.Output()
+		if err != nil {
+			return "", fmt.Errorf("claude summary failed: %w", err)
+		}
+		var result struct {
+			Result string `json:"result"`
+		}
+		if err := json.Unmarshal(out, &result); err != nil {
+			return "", fmt.Errorf("failed to parse summary output: %w", err)
+		}
+		output = []byte(result.Result)
+
+	default:
+		return "", fmt.Errorf("no summarizer for %s - set \"command\" in %s", i.Agent, GetSummaryConfigPath())
+	}
+
+	summary := strings.Join(strings.Fields(string(output)), " ")
+	if summary == "" {
+		return "", fmt.Errorf("summarizer returned no text")
+	}
+	return summary, nil
+}
+
+// AppendSummaryNote adds a timestamped summary to existing notes
+func AppendSummaryNote(notes, summary string, at time.Time) string {
+	entry := fmt.Sprintf("[%s] Summary: %s", at.Format("2006-01-02 15:04"), summary)
+	if strings.TrimSpace(notes) == "" {
+		return entry
+	}
+	return strings.TrimRight(notes, "\n") + "\n\n" + entry
+}
+
+// LatestSummary returns the most recent summary AppendSummaryNote added to notes ("" = none)
+func LatestSummary(notes string) string {
+	lines := strings.Split(notes, "\n")
+	for idx := len(lines) - 1; idx >= 0; idx-- {
+		line := lines[idx]
+		if pos := strings.Index(line, "] Summary: "); strings.HasPrefix(line, "[") && pos != -1 {
+			return strings.TrimSpace(line[pos+len("] Summary: "):])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,720 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SyncConfig is the sync setup from the [sync] section of config.toml
+type SyncConfig struct {
+	Dir     string `toml:"dir"`     // Shared directory: a git repo or a synced folder (Syncthing, Dropbox)
+	Machine string `toml:"machine"` // Name in sync commits (default: hostname)
+	Git     *bool  `toml:"git"`     // Pull, commit and push (default: when dir is a git repo)
+	Auto    bool   `toml:"auto"`    // Sync when the TUI starts and quits
+}
+
+// SyncReport tells what a sync changed
+type SyncReport struct {
+	Received  int      // Records and files taken from the shared directory
+	Sent      int      // Records and files written to it
+	Conflicts []string // Changed on both machines; the local version was kept
+	Skipped   []string // Projects open in another running asmgr
+}
+
+// syncedFiles are the config files shared as a whole (scripts/*.lua too)
+var syncedFiles = []string{"config.toml", "agents.json", "diff.json", "filters.json", "notify.json", "palettes.json", "sandbox.json", "summary.json"}
+
+// Fields that describe a run on this machine and never leave it
+var (
+	localInstanceFields = []string{"status", "updated_at", "resume_session_id", "followed_windows", "base_commit_sha", "last_output", "stopped_at", "environment", "children_collapsed"}
+	localGroupFields    = []string{"collapsed"}
+)
+
+// syncRecords are JSON objects merged by their "id" field
+type syncRecords []map[string]json.RawMessage
+
+// syncSessionsFile is one project's sessions in the shared directory
+type syncSessionsFile struct {
+	Instances syncRecords `json:"instances"`
+	Groups    syncRecords `json:"groups,omitempty"`
+}
+
+// syncProjectsFile is the project list in the shared directory
+type syncProjectsFile struct {
+	Projects syncRecords `json:"projects"`
+}
+
+// validate checks the [sync] section and fills in the machine name
+func (c *SyncConfig) validate() error {
+	if strings.TrimSpace(c.Dir) == "" {
+		return fmt.Errorf("no dir in [sync]")
+	}
+	if c.Machine == "" {
+		c.Machine, _ = os.Hostname()
+	}
+	return nil
+}
+
+// LoadSyncConfig reads the [sync] section of config.toml (nil when sync isn't set up)
+func LoadSyncConfig() (*SyncConfig, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return config.Sync, nil
+}
+
+// Sync merges projects, sessions, groups and shared config files with the
+// sync directory. Each side is compared with the state of the last sync, so
+// a change made on one machine wins over an unchanged value; a field changed
+// differently on both machines keeps the local value and is reported.
+func (s *Storage) Sync(config *SyncConfig) (*SyncReport, error) {
+	dir := expandTilde(config.Dir)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create sync directory: %w", err)
+	}
+	committedBase, err := s.syncBase(dir)
+	if err != nil {
+		return nil, err
+	}
+	// The new base replaces the last one only once the merge is shared
+	baseDir := committedBase + ".pending"
+	os.RemoveAll(baseDir)
+	if err := copyDir(committedBase, baseDir); err != nil {
+		return nil, fmt.Errorf("failed to copy sync state: %w", err)
+	}
+	defer os.RemoveAll(baseDir)
+	useGit := isGitDir(dir)
+	if config.Git != nil {
+		useGit = *config.Git
+	}
+	if useGit {
+		if err := gitReset(dir); err != nil {
+			return nil, err
+		}
+	}
+
+	report := &SyncReport{}
+	projectIDs, err := s.syncProjects(dir, baseDir, report)
+	if err != nil {
+		return report, err
+	}
+	originalProject := s.projectID
+	defer s.SetActiveProject(originalProject)
+	for _, id := range append([]string{""}, projectIDs...) {
+		if locked, pid := s.IsProjectLocked(id); locked && pid != os.Getpid() {
+			report.Skipped = append(report.Skipped, s.projectName(id))
+			continue
+		}
+		if err := s.syncSessions(id, dir, baseDir, report); err != nil {
+			return report, err
+		}
+	}
+	for _, name := range s.syncedFileNames(dir, baseDir) {
+		if err := syncFile(name, s.configDir, dir, baseDir, report); err != nil {
+			return report, err
+		}
+	}
+
+	s.logConflicts(report.Conflicts)
+
+	if useGit {
+		if err := gitPush(dir, config.Machine); err != nil {
+			return report, err
+		}
+	}
+	os.RemoveAll(committedBase)
+	return report, os.Rename(baseDir, committedBase)
+}
+
+// SyncConflictLogPath returns the log of the conflicts found by sync
+func SyncConflictLogPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "agent-session-manager", "sync-conflicts.log")
+}
+
+// logConflicts appends the conflicts of a sync to the conflict log
+func (s *Storage) logConflicts(conflicts []string) {
+	if len(conflicts) == 0 {
+		return
+	}
+	f, err := os.OpenFile(filepath.Join(s.configDir, "sync-conflicts.log"), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	stamp := time.Now().Format("2006-01-02 15:04:05")
+	for _, conflict := range conflicts {
+		fmt.Fprintf(f, "%s %s\n", stamp, conflict)
+	}
+}
+
+// syncBase returns the directory holding the state of the last sync. It is
+// reset when the sync directory changes, so a new directory only adds.
+func (s *Storage) syncBase(dir string) (string, error) {
+	baseDir := filepath.Join(s.configDir, "sync-base")
+	marker := filepath.Join(baseDir, "dir")
+	if data, err := os.ReadFile(marker); err != nil || string(data) != dir {
+		os.RemoveAll(baseDir)
+	}
+	if err := os.MkdirAll(baseDir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create sync state: %w", err)
+	}
+	return baseDir, os.WriteFile(marker, []byte(dir), 0644)
+}
+
+// projectName returns the display name of a project ID ("default" for "")
+func (s *Storage) projectName(id string) string {
+	if id == "" {
+		return "default"
+	}
+	if project, err := s.GetProject(id); err == nil {
+		return project.Name
+	}
+	return id
+}
+
+// syncProjects merges the project list and returns the project IDs after the merge
+func (s *Storage) syncProjects(dir, baseDir string, report *SyncReport) ([]string, error) {
+	local, err := s.LoadProjects()
+	if err != nil {
+		return nil, err
+	}
+	localRecords, err := toRecords(local.Projects)
+	if err != nil {
+		return nil, err
+	}
+	localRecords = shareRecords(localRecords, nil)
+	var remote, base syncProjectsFile
+	remoteExists := readSyncJSON(filepath.Join(dir, "projects.json"), &remote)
+	if remoteExists {
+		readSyncJSON(filepath.Join(baseDir, "projects.json"), &base)
+	}
+	for _, record := range remote.Projects {
+		if err := ValidateProjectID(recordID(record)); err != nil {
+			return nil, fmt.Errorf("projects.json in sync directory: %w", err)
+		}
+	}
+
+	merged := mergeRecords(localRecords, remote.Projects, base.Projects, "project", report)
+	var projects []*Project
+	if err := fromRecords(expandRecords(merged), &projects); err != nil {
+		return nil, err
+	}
+	local.Projects = projects
+	if local.Projects == nil {
+		local.Projects = []*Project{}
+	}
+	if err := s.SaveProjects(local); err != nil {
+		return nil, err
+	}
+	shared := syncProjectsFile{Projects: merged}
+	if err := writeSyncJSON(filepath.Join(dir, "projects.json"), shared); err != nil {
+		return nil, err
+	}
+	if err := writeSyncJSON(filepath.Join(baseDir, "projects.json"), shared); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(projects))
+	for i, p := range projects {
+		ids[i] = p.ID
+	}
+	return ids, nil
+}
+
+// syncSessions merges the sessions and groups of one project ("" = default)
+func (s *Storage) syncSessions(projectID, dir, baseDir string, report *SyncReport) error {
+	if err := s.SetActiveProject(projectID); err != nil {
+		return err
+	}
+	instances, groups, settings, err := s.LoadAllWithSettings()
+	if err != nil {
+		return err
+	}
+	fullInstances, err := toRecords(instances)
+	if err != nil {
+		return err
+	}
+	fullGroups, err := toRecords(groups)
+	if err != nil {
+		return err
+	}
+
+	name := projectID
+	if name == "" {
+		name = "default"
+	}
+	file := filepath.Join("sessions", name+".json")
+	var remote, base syncSessionsFile
+	if readSyncJSON(filepath.Join(dir, file), &remote) {
+		readSyncJSON(filepath.Join(baseDir, file), &base)
+	}
+	shared := syncSessionsFile{
+		Instances: mergeRecords(shareRecords(fullInstances, localInstanceFields), remote.Instances, base.Instances, "session", report),
+		Groups:    mergeRecords(shareRecords(fullGroups, localGroupFields), remote.Groups, base.Groups, "group", report),
+	}
+
+	var merged []*Instance
+	if err := fromRecords(withLocalFields(shared.Instances, fullInstances, localInstanceFields), &merged); err != nil {
+		return err
+	}
+	for _, inst := range merged {
+		if inst.Status == "" {
+			inst.Status = StatusStopped
+		}
+	}
+	var mergedGroups []*Group
+	if err := fromRecords(withLocalFields(shared.Groups, fullGroups, localGroupFields), &mergedGroups); err != nil {
+		return err
+	}
+	if len(merged) > 0 || len(instances) > 0 || len(mergedGroups) > 0 {
+		if err := s.SaveAll(merged, mergedGroups, settings); err != nil {
+			return err
+		}
+	}
+	if err := writeSyncJSON(filepath.Join(dir, file), shared); err != nil {
+		return err
+	}
+	return writeSyncJSON(filepath.Join(baseDir, file), shared)
+}
+
+// syncedFileNames returns the shared config files, with the Lua scripts found on either side
+func (s *Storage) syncedFileNames(dir, baseDir string) []string {
+	names := append([]string{}, syncedFiles...)
+	seen := make(map[string]bool)
+	for _, root := range []string{s.configDir, filepath.Join(dir, "config"), filepath.Join(baseDir, "config")} {
+		matches, _ := filepath.Glob(filepath.Join(root, "scripts", "*.lua"))
+		for _, match := range matches {
+			name := filepath.Join("scripts", filepath.Base(match))
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names[len(syncedFiles):])
+	return names
+}
+
+// syncFile merges one config file as a whole. When both machines changed it,
+// the local file is kept and the other version is saved next to it as
+// <name>.sync-conflict.
+func syncFile(name, configDir, dir, baseDir string, report *SyncReport) error {
+	localPath := filepath.Join(configDir, name)
+	remotePath := filepath.Join(dir, "config", name)
+	basePath := filepath.Join(baseDir, "config", name)
+	local, localErr := os.ReadFile(localPath)
+	remote, remoteErr := os.ReadFile(remotePath)
+	base, baseErr := os.ReadFile(basePath)
+	localExists, remoteExists, baseExists := localErr == nil, remoteErr == nil, baseErr == nil
+
+	sameFile := func(a []byte, aExists bool, b []byte, bExists bool) bool {
+		return aExists == bExists && string(a) == string(b)
+	}
+	result, resultExists := local, localExists
+	switch {
+	case sameFile(local, localExists, remote, remoteExists):
+	case sameFile(local, localExists, base, baseExists):
+		// Changed on another machine only
+		result, resultExists = remote, remoteExists
+		if err := writeSyncFile(localPath, remote, remoteExists); err != nil {
+			return err
+		}
+		report.Received++
+	case sameFile(remote, remoteExists, base, baseExists):
+		// Changed here only
+		if err := writeSyncFile(remotePath, local, localExists); err != nil {
+			return err
+		}
+		report.Sent++
+	default:
+		if remoteExists {
+			if err := writeSyncFile(localPath+".sync-conflict", remote, true); err != nil {
+				return err
+			}
+		}
+		if err := writeSyncFile(remotePath, local, localExists); err != nil {
+			return err
+		}
+		report.Sent++
+		report.Conflicts = append(report.Conflicts, fmt.Sprintf("%s: changed on both machines, the other version is in %s.sync-conflict", name, name))
+	}
+	return writeSyncFile(basePath, result, resultExists)
+}
+
+// writeSyncFile writes a file through a temporary file (or removes it), so a
+// folder sync tool never picks up half a file
+func writeSyncFile(path string, data []byte, exists bool) error {
+	if !exists {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// readSyncJSON reads a JSON file of the sync directory; false when it doesn't exist yet
+func readSyncJSON(path string, v interface{}) bool {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
+
+// writeSyncJSON writes a JSON file of the sync directory
+func writeSyncJSON(path string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeSyncFile(path, append(data, '\n'), true)
+}
+
+// toRecords converts a slice of structs to JSON objects
+func toRecords(v interface{}) (syncRecords, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var records syncRecords
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// fromRecords converts JSON objects back to a slice of structs
+func fromRecords(records syncRecords, v interface{}) error {
+	if records == nil {
+		records = syncRecords{}
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// shareRecords drops the machine-local fields and writes paths under the home directory as ~/...
+func shareRecords(records syncRecords, localFields []string) syncRecords {
+	shared := make(syncRecords, 0, len(records))
+	for _, record := range records {
+		out := make(map[string]json.RawMessage, len(record))
+		for k, v := range record {
+			out[k] = v
+		}
+		for _, k := range localFields {
+			delete(out, k)
+		}
+		shared = append(shared, convertPaths(out, tildePath))
+	}
+	return shared
+}
+
+// expandRecords expands the ~/ paths of shared records for this machine
+func expandRecords(records syncRecords) syncRecords {
+	expanded := make(syncRecords, 0, len(records))
+	for _, record := range records {
+		expanded = append(expanded, convertPaths(record, expandTilde))
+	}
+	return expanded
+}
+
+// withLocalFields puts the machine-local fields of the existing records back into merged ones
+func withLocalFields(merged, full syncRecords, localFields []string) syncRecords {
+	byID := make(map[string]map[string]json.RawMessage, len(full))
+	for _, record := range full {
+		byID[recordID(record)] = record
+	}
+	out := make(syncRecords, 0, len(merged))
+	for _, record := range expandRecords(merged) {
+		if existing, ok := byID[recordID(record)]; ok {
+			for _, k := range localFields {
+				if v, ok := existing[k]; ok {
+					record[k] = v
+				}
+			}
+		}
+		out = append(out, record)
+	}
+	return out
+}
+
+// convertPaths rewrites the path fields of a record (session, project and worktree paths)
+func convertPaths(record map[string]json.RawMessage, convert func(string) string) map[string]json.RawMessage {
+	out := make(map[string]json.RawMessage, len(record))
+	for k, v := range record {
+		out[k] = v
+	}
+	var path string
+	if raw, ok := out["path"]; ok && json.Unmarshal(raw, &path) == nil {
+		out["path"], _ = json.Marshal(convert(path))
+	}
+	var worktree map[string]interface{}
+	if raw, ok := out["worktree"]; ok && json.Unmarshal(raw, &worktree) == nil && worktree != nil {
+		for _, k := range []string{"repo", "dir"} {
+			if p, ok := worktree[k].(string); ok {
+				worktree[k] = convert(p)
+			}
+		}
+		out["worktree"], _ = json.Marshal(worktree)
+	}
+	return out
+}
+
+// tildePath writes a path under the home directory as ~/...
+func tildePath(path string) string {
+	homeDir, err := os.UserHomeDir()
+	if err != nil || homeDir == "" {
+		return path
+	}
+	if rel, err := filepath.Rel(homeDir, path); err == nil && rel != "." && !strings.HasPrefix(rel, "..") && filepath.IsAbs(path) {
+		return "~/" + filepath.ToSlash(rel)
+	}
+	return path
+}
+
+// recordID returns the "id" field of a record
+func recordID(record map[string]json.RawMessage) string {
+	var id string
+	json.Unmarshal(record["id"], &id)
+	return id
+}
+
+// recordName returns the "name" field of a record, or its ID
+func recordName(record map[string]json.RawMessage) string {
+	var name string
+	if json.Unmarshal(record["name"], &name) == nil && name != "" {
+		return name
+	}
+	return recordID(record)
+}
+
+// sameJSON compares two JSON values, ignoring formatting; nil is a missing field
+func sameJSON(a, b json.RawMessage) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	var va, vb interface{}
+	if json.Unmarshal(a, &va) != nil || json.Unmarshal(b, &vb) != nil {
+		return string(a) == string(b)
+	}
+	return reflect.DeepEqual(va, vb)
+}
+
+// sameRecord compares two records field by field
+func sameRecord(a, b map[string]json.RawMessage) bool {
+	for k := range a {
+		if !sameJSON(a[k], b[k]) {
+			return false
+		}
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeRecords merges records by ID against the last synced base. Records
+// keep the local order; new ones from the other machines go last.
+func mergeRecords(local, remote, base syncRecords, kind string, report *SyncReport) syncRecords {
+	index := func(records syncRecords) map[string]map[string]json.RawMessage {
+		byID := make(map[string]map[string]json.RawMessage, len(records))
+		for _, record := range records {
+			byID[recordID(record)] = record
+		}
+		return byID
+	}
+	remoteByID, baseByID := index(remote), index(base)
+	localByID := index(local)
+
+	var merged syncRecords
+	for _, l := range local {
+		id := recordID(l)
+		r, inRemote := remoteByID[id]
+		b, inBase := baseByID[id]
+		switch {
+		case inRemote:
+			merged = append(merged, mergeFields(l, r, b, kind, report))
+		case !inBase:
+			merged = append(merged, l)
+		case sameRecord(l, b):
+			// Deleted on another machine
+		default:
+			report.Conflicts = append(report.Conflicts, fmt.Sprintf("%s '%s': deleted on another machine but changed here, kept", kind, recordName(l)))
+			merged = append(merged, l)
+		}
+	}
+	for _, r := range remote {
+		id := recordID(r)
+		if _, ok := localByID[id]; ok {
+			continue
+		}
+		b, inBase := baseByID[id]
+		switch {
+		case !inBase:
+			merged = append(merged, r)
+		case sameRecord(r, b):
+			// Deleted here
+		default:
+			report.Conflicts = append(report.Conflicts, fmt.Sprintf("%s '%s': deleted here but changed on another machine, restored", kind, recordName(r)))
+			merged = append(merged, r)
+		}
+	}
+	report.Received += countChanges(local, merged)
+	report.Sent += countChanges(remote, merged)
+	return merged
+}
+
+// countChanges counts the records added, removed or changed from one list to another
+func countChanges(from, to syncRecords) int {
+	fromByID := make(map[string]map[string]json.RawMessage, len(from))
+	for _, record := range from {
+		fromByID[recordID(record)] = record
+	}
+	changes := 0
+	for _, record := range to {
+		old, ok := fromByID[recordID(record)]
+		if !ok || !sameRecord(old, record) {
+			changes++
+		}
+		delete(fromByID, recordID(record))
+	}
+	return changes + len(fromByID)
+}
+
+// mergeFields merges one record field by field: a field changed on one side
+// only takes that change, a field changed on both sides keeps the local value
+func mergeFields(local, remote, base map[string]json.RawMessage, kind string, report *SyncReport) map[string]json.RawMessage {
+	keys := make(map[string]bool)
+	for _, record := range []map[string]json.RawMessage{local, remote, base} {
+		for k := range record {
+			keys[k] = true
+		}
+	}
+	names := make([]string, 0, len(keys))
+	for k := range keys {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	merged := make(map[string]json.RawMessage, len(keys))
+	var conflicts []string
+	for _, k := range names {
+		l, r, b := local[k], remote[k], base[k]
+		value := l
+		switch {
+		case sameJSON(l, r), sameJSON(r, b):
+		case sameJSON(l, b):
+			value = r
+		default:
+			conflicts = append(conflicts, k)
+		}
+		if value != nil {
+			merged[k] = value
+		}
+	}
+	if len(conflicts) > 0 {
+		report.Conflicts = append(report.Conflicts, fmt.Sprintf("%s '%s': %s changed on both machines, kept the local value", kind, recordName(local), strings.Join(conflicts, ", ")))
+	}
+	return merged
+}
+
+// copyDir copies a directory tree (a missing source makes an empty copy)
+func copyDir(src, dst string) error {
+	if err := os.MkdirAll(dst, 0755); err != nil {
+		return err
+	}
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil || path == src {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return os.MkdirAll(filepath.Join(dst, rel), 0755)
+		}
+		return copyFile(path, filepath.Join(dst, rel))
+	})
+}
+
+// isGitDir reports whether dir is the top of a git repository
+func isGitDir(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, ".git"))
+	return err == nil
+}
+
+// gitRemote returns the first remote of the sync repo ("" = local only)
+func gitRemote(dir string) string {
+	return commandLine(exec.Command("git", "-C", dir, "remote"))
+}
+
+// gitBranch returns the checked out branch of the sync repo
+func gitBranch(dir string) string {
+	return commandLine(exec.Command("git", "-C", dir, "symbolic-ref", "--short", "HEAD"))
+}
+
+// gitReset moves the sync checkout to the remote branch before merging.
+// Commits that never got pushed are dropped; their changes are still in the
+// local state and merge again against the last shared base.
+func gitReset(dir string) error {
+	remote := gitRemote(dir)
+	if remote == "" {
+		return nil
+	}
+	if out, err := exec.Command("git", "-C", dir, "fetch", "-q", remote).CombinedOutput(); err != nil {
+		return fmt.Errorf("git fetch failed: %s", strings.TrimSpace(string(out)))
+	}
+	// Nothing to take before the first machine pushed
+	upstream := remote + "/" + gitBranch(dir)
+	if exec.Command("git", "-C", dir, "rev-parse", "-q", "--verify", "refs/remotes/"+upstream).Run() != nil {
+		return nil
+	}
+	if out, err := exec.Command("git", "-C", dir, "reset", "-q", "--hard", upstream).CombinedOutput(); err != nil {
+		return fmt.Errorf("git reset failed: %s", strings.TrimSpace(string(out)))
+	}
+	exec.Command("git", "-C", dir, "clean", "-qfd").Run()
+	return nil
+}
+
+// gitPush commits the merged state and pushes it
+func gitPush(dir, machine string) error {
+	if out, err := exec.Command("git", "-C", dir, "add", "-A").CombinedOutput(); err != nil {
+		return fmt.Errorf("git add failed: %s", strings.TrimSpace(string(out)))
+	}
+	if commandLine(exec.Command("git", "-C", dir, "status", "--porcelain")) == "" {
+		return nil
+	}
+	if out, err := exec.Command("git", "-C", dir, "commit", "-q", "-m", "Sync from "+machine).CombinedOutput(); err != nil {
+		return fmt.Errorf("git commit failed: %s", strings.TrimSpace(string(out)))
+	}
+	remote := gitRemote(dir)
+	if remote == "" {
+		return nil
+	}
+	if out, err := exec.Command("git", "-C", dir, "push", "-q", "-u", remote, gitBranch(dir)).CombinedOutput(); err != nil {
+		return fmt.Errorf("git push failed (run sync again to merge first): %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,23 @@
+package session
+
+import (
+	"regexp"
+	"strings"
+)
+
+// tagRe matches #tags in session notes (#prod, #experimental, ...)
+var tagRe = regexp.MustCompile(`(?:^|\s)#([\pL\pN_-]+)`)
+
+// Tags returns the #tags written in the session's notes, lowercased and without duplicates
+func (i *Instance) Tags() []string {
+	var tags []string
+	seen := make(map[string]bool)
+	for _, m := range tagRe.FindAllStringSubmatch(i.Notes, -1) {
+		tag := strings.ToLower(m[1])
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,154 @@
+package session
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TailInterval is how often a followed pane is polled for new output
+const TailInterval = 500 * time.Millisecond
+
+// TailOptions controls what Tail prints
+type TailOptions struct {
+	Window int  // Tab (tmux window index) to read
+	Lines  int  // Only print the last N lines of existing output (0 = all)
+	Follow bool // Keep printing new output until the session stops
+	Strip  bool // Strip ANSI colors
+}
+
+// paneCursor returns the scrollback size, scrollback limit and cursor row of a pane
+func (i *Instance) paneCursor(target string) (history, limit, cursorY int, err error) {
+	out, err := i.Tmux().Output("display-message", "-p", "-t", target, "#{history_size} #{history_limit} #{cursor_y}")
+	if err != nil {
+		return 0, 0, 0, fmt.Errorf("pane not found: %s", target)
+	}
+	fields := strings.Fields(string(out))
+	if len(fields) != 3 {
+		return 0, 0, 0, fmt.Errorf("unexpected tmux output: %q", out)
+	}
+	history, _ = strconv.Atoi(fields[0])
+	limit, _ = strconv.Atoi(fields[1])
+	cursorY, _ = strconv.Atoi(fields[2])
+	return history, limit, cursorY, nil
+}
+
+// capturePaneLines captures pane lines between start and end (tmux -S/-E line numbers)
+func (i *Instance) capturePaneLines(target string, start, end string, strip bool) ([]string, error) {
+	args := []string{"capture-pane", "-p", "-t", target, "-S", start, "-E", end}
+	if !strip {
+		args = append(args, "-e")
+	}
+	out, err := i.Tmux().Output(args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to capture pane: %w", err)
+	}
+	text := strings.TrimSuffix(string(out), "\n")
+	if text == "" {
+		return nil, nil
+	}
+	return strings.Split(text, "\n"), nil
+}
+
+// Tail writes a session's pane output to w. When following, lines are printed
+// once the cursor has moved past them, so each line is printed once even while
+// the agent keeps redrawing its input area.
+func (i *Instance) Tail(w io.Writer, opts TailOptions) error {
+	if !i.IsAlive() {
+		return fmt.Errorf("session '%s' is not running", i.Name)
+	}
+	target := fmt.Sprintf("%s:%d", i.TmuxSessionName(), opts.Window)
+
+	history, _, cursorY, err := i.paneCursor(target)
+	if err != nil {
+		return err
+	}
+
+	// Existing output - up to the cursor when following, the whole screen otherwise
+	end := "-"
+	if opts.Follow {
+		end = strconv.Itoa(cursorY - 1)
+	}
+	lines, err := i.capturePaneLines(target, "-", end, opts.Strip)
+	if err != nil {
+		return err
+	}
+	if !opts.Follow {
+		// Drop the blank rows below the last output
+		for len(lines) > 0 && strings.TrimSpace(stripANSI(lines[len(lines)-1])) == "" {
+			lines = lines[:len(lines)-1]
+		}
+	}
+	if opts.Lines > 0 && len(lines) > opts.Lines {
+		lines = lines[len(lines)-opts.Lines:]
+	}
+	for _, line := range lines {
+		fmt.Fprintln(w, line)
+	}
+	if !opts.Follow {
+		return nil
+	}
+
+	// Absolute line number (scrollback + screen row) of the next line to print
+	next := history + cursorY
+	last := ""
+	if len(lines) > 0 {
+		last = lines[len(lines)-1]
+	}
+	for {
+		time.Sleep(TailInterval)
+		h, limit, y, err := i.paneCursor(target)
+		if err != nil {
+			return nil // Session or tab closed
+		}
+
+		var lines []string
+		if h >= limit && limit > 0 {
+			// Full scrollback drops old lines, so line numbers stop growing -
+			// find the last printed line again instead
+			recent, err := i.capturePaneLines(target, strconv.Itoa(-tailResyncLines), strconv.Itoa(y-1), opts.Strip)
+			if err != nil {
+				return nil
+			}
+			lines = linesAfter(recent, last)
+		} else {
+			if h < history {
+				// Scrollback was cleared - continue from the top of the screen
+				next = h
+			}
+			if h+y > next {
+				lines, err = i.capturePaneLines(target, strconv.Itoa(next-h), strconv.Itoa(y-1), opts.Strip)
+				if err != nil {
+					return nil
+				}
+			}
+		}
+		history = h
+		next = h + y // Lines above the cursor are settled
+
+		for _, line := range lines {
+			fmt.Fprintln(w, line)
+		}
+		if len(lines) > 0 {
+			last = lines[len(lines)-1]
+		}
+	}
+}
+
+// tailResyncLines is how far back a full scrollback is searched for the last printed line
+const tailResyncLines = 500
+
+// linesAfter returns the lines following the last occurrence of anchor (all lines if not found)
+func linesAfter(lines []string, anchor string) []string {
+	if anchor == "" {
+		return lines
+	}
+	for idx := len(lines) - 1; idx >= 0; idx-- {
+		if lines[idx] == anchor {
+			return lines[idx+1:]
+		}
+	}
+	return lines
+}
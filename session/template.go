@@ -0,0 +1,138 @@
+package session
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// TemplateTimeout bounds external lookups (git, gh, clipboard) while resolving a prompt
+const TemplateTimeout = 5 * time.Second
+
+// TemplateVars lists the placeholders available in prompts
+var TemplateVars = []string{"branch", "path", "name", "issue", "issue_title", "clipboard", "date"}
+
+// templateVarRe matches {{name}} placeholders (spaces inside the braces are allowed)
+var templateVarRe = regexp.MustCompile(`\{\{\s*([a-z_]+)\s*\}\}`)
+
+// issueNumberRe finds an issue number in a branch name (feature/123-foo, fix-#42, gh-7)
+var issueNumberRe = regexp.MustCompile(`(?:^|[/_#-])(\d+)(?:[/_-]|$)`)
+
+// HasTemplateVars reports whether text contains any {{placeholder}}
+func HasTemplateVars(text string) bool {
+	return templateVarRe.MatchString(text)
+}
+
+// ResolvePrompt replaces template placeholders with values for this session.
+// Placeholders that can't be resolved are left in place and returned by name.
+func (i *Instance) ResolvePrompt(text string) (string, []string) {
+	if !HasTemplateVars(text) {
+		return text, nil
+	}
+
+	values := make(map[string]string)
+	resolved := make(map[string]bool)
+	var unresolved []string
+
+	lookup := func(name string) (string, bool) {
+		if done, ok := resolved[name]; ok {
+			return values[name], done
+		}
+		value, ok := i.templateValue(name, values)
+		values[name] = value
+		resolved[name] = ok
+		return value, ok
+	}
+
+	result := templateVarRe.ReplaceAllStringFunc(text, func(match string) string {
+		name := templateVarRe.FindStringSubmatch(match)[1]
+		if value, ok := lookup(name); ok {
+			return value
+		}
+		for _, u := range unresolved {
+			if u == name {
+				return match
+			}
+		}
+		unresolved = append(unresolved, name)
+		return match
+	})
+	return result, unresolved
+}
+
+// templateValue resolves a single placeholder (values caches earlier lookups)
+func (i *Instance) templateValue(name string, values map[string]string) (string, bool) {
+	switch name {
+	case "path":
+		return i.Path, true
+	case "name":
+		return i.Name, true
+	case "date":
+		return time.Now().Format("2006-01-02"), true
+	case "branch":
+		branch := runTemplateCommand(i.Path, "git", "rev-parse", "--abbrev-ref", "HEAD")
+		return branch, branch != "" && branch != "HEAD"
+	case "issue":
+		branch, ok := values["branch"]
+		if !ok {
+			branch, _ = i.templateValue("branch", values)
+			values["branch"] = branch
+		}
+		if match := issueNumberRe.FindStringSubmatch(branch); match != nil {
+			return match[1], true
+		}
+		return "", false
+	case "issue_title":
+		issue, ok := i.templateValue("issue", values)
+		if !ok {
+			return "", false
+		}
+		title := runTemplateCommand(i.Path, "gh", "issue", "view", issue, "--json", "title", "--jq", ".title")
+		return title, title != ""
+	case "clipboard":
+		text := readClipboard()
+		return text, text != ""
+	}
+	return "", false
+}
+
+// runTemplateCommand runs a lookup command in dir and returns its trimmed output ("" on failure)
+func runTemplateCommand(dir string, name string, args ...string) string {
+	ctx, cancel := context.WithTimeout(context.Background(), TemplateTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// readClipboard returns the clipboard text using the platform clipboard tool
+func readClipboard() string {
+	var candidates [][]string
+	if runtime.GOOS == "darwin" {
+		candidates = append(candidates, []string{"pbpaste"})
+	}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		candidates = append(candidates, []string{"wl-paste", "--no-newline"})
+	}
+	candidates = append(candidates,
+		[]string{"xclip", "-selection", "clipboard", "-o"},
+		[]string{"xsel", "--clipboard", "--output"})
+
+	for _, c := range candidates {
+		if _, err := exec.LookPath(c[0]); err != nil {
+			continue
+		}
+		if text := runTemplateCommand("", c[0], c[1:]...); text != "" {
+			return text
+		}
+	}
+	return ""
+}
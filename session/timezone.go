@@ -0,0 +1,140 @@
+package session
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ScheduledPrompt is a prompt queued to be sent to a session at a given time
+type ScheduledPrompt struct {
+	Text string    `json:"text"`
+	At   time.Time `json:"at"`
+}
+
+// Location returns the session's timezone (local time if unset or invalid)
+func (i *Instance) Location() *time.Location {
+	if i.Timezone == "" {
+		return time.Local
+	}
+	loc, err := time.LoadLocation(i.Timezone)
+	if err != nil {
+		return time.Local
+	}
+	return loc
+}
+
+// ValidateTimezone checks an IANA timezone name ("" clears the timezone)
+func ValidateTimezone(name string) error {
+	if name == "" {
+		return nil
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return fmt.Errorf("unknown timezone '%s' (use IANA names like Europe/Berlin)", name)
+	}
+	return nil
+}
+
+// LastActivity returns when the session last produced output (tmux session_activity)
+func (i *Instance) LastActivity() time.Time {
+	if i.Status != StatusRunning {
+		if !i.StoppedAt.IsZero() {
+			return i.StoppedAt
+		}
+		return i.UpdatedAt
+	}
+	out, err := i.Tmux().Output("display-message", "-p", "-t", i.TmuxSessionName(), "#{session_activity}")
+	if err != nil {
+		return i.UpdatedAt
+	}
+	secs, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64)
+	if err != nil {
+		return i.UpdatedAt
+	}
+	return time.Unix(secs, 0)
+}
+
+// clockTimeRe matches "9", "9am", "9:30", "09:30pm", "21:15"
+var clockTimeRe = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?\s*(am|pm)?$`)
+
+// ParseScheduleTime resolves a schedule spec to an absolute time.
+// Accepts a relative duration ("+30m", "+1h30m") or a clock time in loc ("9am", "14:30"),
+// which means the next occurrence of that time.
+func ParseScheduleTime(spec string, loc *time.Location, now time.Time) (time.Time, error) {
+	spec = strings.ToLower(strings.TrimSpace(spec))
+	if spec == "" {
+		return time.Time{}, fmt.Errorf("empty time")
+	}
+
+	if strings.HasPrefix(spec, "+") {
+		d, err := time.ParseDuration(spec[1:])
+		if err != nil || d <= 0 {
+			return time.Time{}, fmt.Errorf("invalid duration '%s'", spec)
+		}
+		return now.Add(d), nil
+	}
+
+	match := clockTimeRe.FindStringSubmatch(spec)
+	if match == nil {
+		return time.Time{}, fmt.Errorf("invalid time '%s' (use 9am, 14:30 or +30m)", spec)
+	}
+	hour, _ := strconv.Atoi(match[1])
+	minute := 0
+	if match[2] != "" {
+		minute, _ = strconv.Atoi(match[2])
+	}
+	if minute > 59 || hour > 23 || (match[3] != "" && (hour == 0 || hour > 12)) {
+		return time.Time{}, fmt.Errorf("invalid time '%s'", spec)
+	}
+	switch match[3] {
+	case "am":
+		if hour == 12 {
+			hour = 0
+		}
+	case "pm":
+		if hour < 12 {
+			hour += 12
+		}
+	}
+
+	local := now.In(loc)
+	at := time.Date(local.Year(), local.Month(), local.Day(), hour, minute, 0, 0, loc)
+	if !at.After(now) {
+		at = at.AddDate(0, 0, 1)
+	}
+	return at, nil
+}
+
+// SchedulePrompt queues a prompt to be sent at the given time
+func (i *Instance) SchedulePrompt(text string, at time.Time) {
+	i.ScheduledPrompts = append(i.ScheduledPrompts, ScheduledPrompt{Text: text, At: at})
+}
+
+// TakeDuePrompts removes and returns the scheduled prompts due at now
+func (i *Instance) TakeDuePrompts(now time.Time) []ScheduledPrompt {
+	var due, pending []ScheduledPrompt
+	for _, p := range i.ScheduledPrompts {
+		if !p.At.After(now) {
+			due = append(due, p)
+		} else {
+			pending = append(pending, p)
+		}
+	}
+	if len(due) > 0 {
+		i.ScheduledPrompts = pending
+	}
+	return due
+}
+
+// NextScheduledPrompt returns the earliest pending scheduled prompt (nil if none)
+func (i *Instance) NextScheduledPrompt() *ScheduledPrompt {
+	var next *ScheduledPrompt
+	for idx := range i.ScheduledPrompts {
+		if next == nil || i.ScheduledPrompts[idx].At.Before(next.At) {
+			next = &i.ScheduledPrompts[idx]
+		}
+	}
+	return next
+}
@@ -0,0 +1,37 @@
+package session
+
+import "os/exec"
+
+// Runner runs tmux commands. Everything except attaching (which needs the
+// terminal) goes through Tmux, so a FakeTmux can stand in for the tmux server.
+type Runner interface {
+	Run(args ...string) error
+	Output(args ...string) ([]byte, error)
+	CombinedOutput(args ...string) ([]byte, error)
+}
+
+// Tmux is the runner used for all tmux commands
+var Tmux Runner = execRunner{}
+
+// execRunner runs the tmux binary
+type execRunner struct{}
+
+// Run runs a tmux command
+func (execRunner) Run(args ...string) error {
+	return exec.Command("tmux", args...).Run()
+}
+
+// Output runs a tmux command and returns its standard output
+func (execRunner) Output(args ...string) ([]byte, error) {
+	return exec.Command("tmux", args...).Output()
+}
+
+// CombinedOutput runs a tmux command and returns its standard output and error
+func (execRunner) CombinedOutput(args ...string) ([]byte, error) {
+	return exec.Command("tmux", args...).CombinedOutput()
+}
+
+// ServerRunning reports whether the local tmux server is up with at least one session
+func ServerRunning() bool {
+	return Tmux.Run("list-sessions") == nil
+}
@@ -0,0 +1,508 @@
+package session
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeTmux is an in-memory tmux server. Assign it to Tmux to start, stop and
+// inspect sessions without a tmux binary: it understands the session, window,
+// pane and format commands asmgr uses, and records options, hooks and key
+// bindings without acting on them. Attaching still needs a real tmux.
+type FakeTmux struct {
+	mu       sync.Mutex
+	sessions map[string]*FakeSession
+	global   map[string]string // set-option -g values by name
+	nextPID  int
+	Calls    [][]string // Every command run, in order
+}
+
+// FakeSession is a session of the fake server
+type FakeSession struct {
+	Name     string
+	Path     string
+	Windows  []*FakeWindow
+	Options  map[string]string // set-option values by name
+	Activity time.Time
+}
+
+// FakeWindow is a window (with its single pane) of a fake session
+type FakeWindow struct {
+	Index        int
+	Name         string
+	Command      string
+	Active       bool
+	Dead         bool
+	ExitStatus   int // pane_dead_status once Dead
+	PID          int
+	HistoryLimit int      // history-limit when the window was created
+	Content      []string // Pane lines returned by capture-pane
+	Keys         []string // Everything sent with send-keys, in order
+}
+
+// fakeValueFlags are the tmux flags used by asmgr that take a value
+var fakeValueFlags = map[string]bool{
+	"-t": true, "-s": true, "-c": true, "-n": true, "-F": true,
+	"-S": true, "-E": true, "-x": true, "-y": true, "-e": true,
+}
+
+// fakeFormatRe matches #{variable} in tmux formats
+var fakeFormatRe = regexp.MustCompile(`#\{([a-z_]+)\}`)
+
+// NewFakeTmux returns an empty fake tmux server
+func NewFakeTmux() *FakeTmux {
+	return &FakeTmux{sessions: make(map[string]*FakeSession), global: map[string]string{"history-limit": "2000"}, nextPID: 1000}
+}
+
+// Run runs a tmux command against the fake server
+func (f *FakeTmux) Run(args ...string) error {
+	_, err := f.exec(args)
+	return err
+}
+
+// Output runs a tmux command and returns what tmux would print
+func (f *FakeTmux) Output(args ...string) ([]byte, error) {
+	out, err := f.exec(args)
+	return []byte(out), err
+}
+
+// CombinedOutput runs a tmux command and returns its output or error message
+func (f *FakeTmux) CombinedOutput(args ...string) ([]byte, error) {
+	out, err := f.exec(args)
+	if err != nil {
+		return []byte(err.Error() + "\n"), err
+	}
+	return []byte(out), nil
+}
+
+// Session returns a session of the fake server by name (nil when missing)
+func (f *FakeTmux) Session(name string) *FakeSession {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.sessions[name]
+}
+
+// SetContent replaces the lines of a pane ("session" or "session:window")
+func (f *FakeTmux) SetContent(target string, lines ...string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w, err := f.window(target)
+	if err != nil {
+		return err
+	}
+	w.Content = lines
+	if s := f.sessions[fakeSessionName(target)]; s != nil {
+		s.Activity = time.Now()
+	}
+	return nil
+}
+
+// Exit makes the process of a pane exit, leaving it dead like remain-on-exit does
+func (f *FakeTmux) Exit(target string) error {
+	return f.ExitWith(target, 0)
+}
+
+// ExitWith makes the process of a pane exit with an exit status
+func (f *FakeTmux) ExitWith(target string, code int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	w, err := f.window(target)
+	if err != nil {
+		return err
+	}
+	w.Dead = true
+	w.ExitStatus = code
+	return nil
+}
+
+// fakeSessionName returns the session part of a target ("=name:1.0" -> "name")
+func fakeSessionName(target string) string {
+	target = strings.TrimPrefix(target, "=")
+	name, _, _ := strings.Cut(target, ":")
+	return name
+}
+
+// window resolves a target to a window (the active one when no index is given)
+func (f *FakeTmux) window(target string) (*FakeWindow, error) {
+	s := f.sessions[fakeSessionName(target)]
+	if s == nil {
+		return nil, fmt.Errorf("can't find session: %s", fakeSessionName(target))
+	}
+	_, index, hasIndex := strings.Cut(strings.TrimPrefix(target, "="), ":")
+	index, _, _ = strings.Cut(index, ".")
+	for _, w := range s.Windows {
+		if hasIndex && index != "" && strconv.Itoa(w.Index) == index {
+			return w, nil
+		}
+		if (!hasIndex || index == "") && w.Active {
+			return w, nil
+		}
+	}
+	return nil, fmt.Errorf("can't find window: %s", index)
+}
+
+// parseFakeArgs splits tmux arguments into flags and positional arguments
+func parseFakeArgs(args []string) (map[string]string, []string) {
+	flags := make(map[string]string)
+	var positional []string
+	for n := 0; n < len(args); n++ {
+		arg := args[n]
+		if len(positional) == 0 && len(arg) > 1 && strings.HasPrefix(arg, "-") {
+			if fakeValueFlags[arg] && n+1 < len(args) {
+				flags[arg] = args[n+1]
+				n++
+			} else {
+				flags[arg] = ""
+			}
+			continue
+		}
+		positional = append(positional, arg)
+	}
+	return flags, positional
+}
+
+// addWindow adds a window running command to a session and makes it active
+func (f *FakeTmux) addWindow(s *FakeSession, name, command string) *FakeWindow {
+	index := 0
+	for _, w := range s.Windows {
+		w.Active = false
+		if w.Index >= index {
+			index = w.Index + 1
+		}
+	}
+	if name == "" {
+		name, _, _ = strings.Cut(command, " ")
+		if name == "" {
+			name = "bash"
+		}
+	}
+	// Like tmux, the pane keeps the history-limit it was created with
+	limit := s.Options["history-limit"]
+	if limit == "" {
+		limit = f.global["history-limit"]
+	}
+	historyLimit, _ := strconv.Atoi(limit)
+	f.nextPID++
+	w := &FakeWindow{Index: index, Name: name, Command: command, Active: true, PID: f.nextPID, HistoryLimit: historyLimit}
+	s.Windows = append(s.Windows, w)
+	return w
+}
+
+// sortedSessions returns the sessions ordered by name, like tmux lists them
+func (f *FakeTmux) sortedSessions() []*FakeSession {
+	var sessions []*FakeSession
+	for _, s := range f.sessions {
+		sessions = append(sessions, s)
+	}
+	sort.Slice(sessions, func(a, b int) bool { return sessions[a].Name < sessions[b].Name })
+	return sessions
+}
+
+// fakeFormat expands the #{...} variables of a tmux format for a window
+func fakeFormat(s *FakeSession, w *FakeWindow, layout string) string {
+	return fakeFormatRe.ReplaceAllStringFunc(layout, func(v string) string {
+		switch v[2 : len(v)-1] {
+		case "session_name":
+			return s.Name
+		case "session_windows":
+			return strconv.Itoa(len(s.Windows))
+		case "session_attached":
+			return "0"
+		case "session_activity":
+			return strconv.FormatInt(s.Activity.Unix(), 10)
+		case "pane_current_path":
+			return s.Path
+		}
+		if w == nil {
+			return ""
+		}
+		switch v[2 : len(v)-1] {
+		case "window_index":
+			return strconv.Itoa(w.Index)
+		case "window_name":
+			return w.Name
+		case "window_active":
+			if w.Active {
+				return "1"
+			}
+			return "0"
+		case "pane_dead":
+			if w.Dead {
+				return "1"
+			}
+			return "0"
+		case "pane_dead_status":
+			if w.Dead {
+				return strconv.Itoa(w.ExitStatus)
+			}
+			return ""
+		case "pane_pid":
+			return strconv.Itoa(w.PID)
+		case "pane_current_command":
+			command, _, _ := strings.Cut(w.Command, " ")
+			return command
+		case "pane_width":
+			return "80"
+		case "pane_height":
+			return "24"
+		case "history_size":
+			return "0"
+		case "history_limit":
+			return strconv.Itoa(w.HistoryLimit)
+		case "cursor_y":
+			if len(w.Content) == 0 {
+				return "0"
+			}
+			return strconv.Itoa(len(w.Content) - 1)
+		}
+		return ""
+	})
+}
+
+// exec runs a command, or commands separated by ";" arguments, and returns their output
+func (f *FakeTmux) exec(args []string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.Calls = append(f.Calls, append([]string{}, args...))
+	var out strings.Builder
+	start := 0
+	for n := 0; n <= len(args); n++ {
+		if n < len(args) && args[n] != ";" {
+			continue
+		}
+		printed, err := f.execOne(args[start:n])
+		out.WriteString(printed)
+		if err != nil {
+			return out.String(), err
+		}
+		start = n + 1
+	}
+	return out.String(), nil
+}
+
+// execOne runs one command and returns its output
+func (f *FakeTmux) execOne(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", fmt.Errorf("no command")
+	}
+	flags, positional := parseFakeArgs(args[1:])
+	target := flags["-t"]
+
+	switch args[0] {
+	case "has-session":
+		if f.sessions[fakeSessionName(target)] == nil {
+			return "", fmt.Errorf("can't find session: %s", fakeSessionName(target))
+		}
+
+	case "new-session":
+		name := flags["-s"]
+		if f.sessions[name] != nil {
+			return "", fmt.Errorf("duplicate session: %s", name)
+		}
+		s := &FakeSession{Name: name, Path: flags["-c"], Options: make(map[string]string), Activity: time.Now()}
+		f.sessions[name] = s
+		f.addWindow(s, flags["-n"], strings.Join(positional, " "))
+
+	case "kill-session":
+		name := fakeSessionName(target)
+		if f.sessions[name] == nil {
+			return "", fmt.Errorf("can't find session: %s", name)
+		}
+		delete(f.sessions, name)
+
+	case "rename-session":
+		s := f.sessions[fakeSessionName(target)]
+		if s == nil || len(positional) == 0 {
+			return "", fmt.Errorf("can't find session: %s", fakeSessionName(target))
+		}
+		delete(f.sessions, s.Name)
+		s.Name = positional[0]
+		f.sessions[s.Name] = s
+
+	case "new-window":
+		s := f.sessions[fakeSessionName(target)]
+		if s == nil {
+			return "", fmt.Errorf("can't find session: %s", fakeSessionName(target))
+		}
+		f.addWindow(s, flags["-n"], strings.Join(positional, " "))
+
+	case "kill-window":
+		s := f.sessions[fakeSessionName(target)]
+		w, err := f.window(target)
+		if err != nil {
+			return "", err
+		}
+		for n, other := range s.Windows {
+			if other == w {
+				s.Windows = append(s.Windows[:n], s.Windows[n+1:]...)
+				break
+			}
+		}
+		if len(s.Windows) == 0 {
+			delete(f.sessions, s.Name)
+		} else if w.Active {
+			s.Windows[0].Active = true
+		}
+
+	case "select-window", "next-window", "previous-window":
+		s := f.sessions[fakeSessionName(target)]
+		if s == nil {
+			return "", fmt.Errorf("can't find session: %s", fakeSessionName(target))
+		}
+		current := 0
+		for n, w := range s.Windows {
+			if w.Active {
+				current = n
+			}
+		}
+		next := current
+		switch args[0] {
+		case "next-window":
+			next = (current + 1) % len(s.Windows)
+		case "previous-window":
+			next = (current + len(s.Windows) - 1) % len(s.Windows)
+		default:
+			w, err := f.window(target)
+			if err != nil {
+				return "", err
+			}
+			for n, other := range s.Windows {
+				if other == w {
+					next = n
+				}
+			}
+		}
+		for n, w := range s.Windows {
+			w.Active = n == next
+		}
+
+	case "rename-window":
+		w, err := f.window(target)
+		if err != nil {
+			return "", err
+		}
+		if len(positional) > 0 {
+			w.Name = positional[0]
+		}
+
+	case "respawn-pane":
+		w, err := f.window(target)
+		if err != nil {
+			return "", err
+		}
+		if len(positional) > 0 {
+			w.Command = strings.Join(positional, " ")
+		}
+		f.nextPID++
+		w.PID = f.nextPID
+		w.Dead = false
+		w.ExitStatus = 0
+		w.Content = nil
+
+	case "send-keys":
+		w, err := f.window(target)
+		if err != nil {
+			return "", err
+		}
+		w.Keys = append(w.Keys, positional...)
+		f.sessions[fakeSessionName(target)].Activity = time.Now()
+
+	case "capture-pane":
+		w, err := f.window(target)
+		if err != nil {
+			return "", err
+		}
+		if len(w.Content) == 0 {
+			return "", nil
+		}
+		return strings.Join(w.Content, "\n") + "\n", nil
+
+	case "display-message":
+		if _, printed := flags["-p"]; !printed {
+			return "", nil // A status line message
+		}
+		if target == "" {
+			// Outside tmux there is no current client
+			return "", fmt.Errorf("no current client")
+		}
+		s := f.sessions[fakeSessionName(target)]
+		if s == nil {
+			return "", fmt.Errorf("can't find session: %s", fakeSessionName(target))
+		}
+		w, _ := f.window(target)
+		return fakeFormat(s, w, strings.Join(positional, " ")) + "\n", nil
+
+	case "list-sessions":
+		if len(f.sessions) == 0 {
+			return "", fmt.Errorf("no server running")
+		}
+		layout := flags["-F"]
+		if layout == "" {
+			layout = "#{session_name}: #{session_windows} windows"
+		}
+		var b strings.Builder
+		for _, s := range f.sortedSessions() {
+			w, _ := f.window(s.Name)
+			b.WriteString(fakeFormat(s, w, layout) + "\n")
+		}
+		return b.String(), nil
+
+	case "list-windows", "list-panes":
+		var sessions []*FakeSession
+		if _, all := flags["-a"]; all {
+			sessions = f.sortedSessions()
+		} else if s := f.sessions[fakeSessionName(target)]; s != nil {
+			sessions = []*FakeSession{s}
+		} else {
+			return "", fmt.Errorf("can't find session: %s", fakeSessionName(target))
+		}
+		layout := flags["-F"]
+		if layout == "" {
+			layout = "#{window_index}: #{window_name}"
+		}
+		var b strings.Builder
+		for _, s := range sessions {
+			for _, w := range s.Windows {
+				b.WriteString(fakeFormat(s, w, layout) + "\n")
+			}
+		}
+		return b.String(), nil
+
+	case "set-option":
+		if _, global := flags["-g"]; global && target == "" && len(positional) > 0 {
+			value := ""
+			if len(positional) > 1 {
+				value = positional[1]
+			}
+			f.global[positional[0]] = value
+		} else if s := f.sessions[fakeSessionName(target)]; s != nil && len(positional) > 0 {
+			value := ""
+			if len(positional) > 1 {
+				value = positional[1]
+			}
+			s.Options[positional[0]] = value
+		}
+
+	case "show-options":
+		if len(positional) == 0 {
+			return "", fmt.Errorf("option list not supported")
+		}
+		value := f.global[positional[0]]
+		if s := f.sessions[fakeSessionName(target)]; s != nil && s.Options[positional[0]] != "" {
+			value = s.Options[positional[0]]
+		}
+		return value + "\n", nil
+
+	case "start-server", "set-hook", "bind-key", "display-menu", "move-window", "pipe-pane", "resize-window", "run-shell":
+		// Recorded in Calls only
+
+	default:
+		return "", fmt.Errorf("unknown command: %s", args[0])
+	}
+	return "", nil
+}
@@ -0,0 +1,173 @@
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// MaxTopics is how many topic keywords are kept per session
+const MaxTopics = 3
+
+// topicStopwords are common words that never make useful topics
+var topicStopwords = map[string]bool{
+	"the": true, "and": true, "for": true, "that": true, "this": true, "with": true,
+	"you": true, "are": true, "can": true, "not": true, "but": true, "have": true,
+	"has": true, "was": true, "were": true, "will": true, "would": true, "should": true,
+	"could": true, "from": true, "into": true, "what": true, "when": true, "where": true,
+	"which": true, "how": true, "why": true, "all": true, "any": true, "some": true,
+	"there": true, "then": true, "than": true, "them": true, "they": true, "their": true,
+	"its": true, "also": true, "just": true, "like": true, "make": true, "use": true,
+	"using": true, "need": true, "want": true, "please": true, "let": true, "now": true,
+	"one": true, "two": true, "get": true, "set": true, "see": true, "yes": true,
+	"ok": true, "okay": true, "thanks": true, "thank": true, "does": true, "did": true,
+	"doesn": true, "don": true, "isn": true, "only": true, "more": true, "here": true,
+	"been": true, "being": true, "about": true, "other": true, "same": true, "still": true,
+	"file": true, "code": true, "work": true, "works": true, "it's": true, "i'm": true,
+	"command": true, "name": true, "message": true, "args": true, "stdout": true, "stderr": true,
+	"local": true, "caveat": true, "messages": true, "below": true, "generated": true, "user": true,
+}
+
+// ClaudeTranscriptPath returns the Claude transcript of an instance ("" if not found).
+// Uses the resume session ID, otherwise the most recently modified transcript in the project.
+func (i *Instance) ClaudeTranscriptPath() string {
+	if i.Agent != AgentClaude && i.Agent != "" {
+		return ""
+	}
+	claudeDir := GetClaudeProjectDir(i.Path)
+
+	if i.ResumeSessionID != "" {
+		path := filepath.Join(claudeDir, i.ResumeSessionID+".jsonl")
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+
+	return newestClaudeTranscript(claudeDir)
+}
+
+// newestClaudeTranscript returns the most recently written transcript in a Claude project directory
+func newestClaudeTranscript(claudeDir string) string {
+	entries, err := os.ReadDir(claudeDir)
+	if err != nil {
+		return ""
+	}
+	var newest string
+	var newestMod int64
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".jsonl") || !isValidUUID(strings.TrimSuffix(name, ".jsonl")) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		if mod := info.ModTime().UnixNano(); mod > newestMod {
+			newestMod = mod
+			newest = filepath.Join(claudeDir, name)
+		}
+	}
+	return newest
+}
+
+// LoadUserPrompts returns the user prompts of a Claude transcript
+func LoadUserPrompts(path string) []string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	buf := make([]byte, 0, 64*1024)
+	scanner.Buffer(buf, 1024*1024)
+
+	var prompts []string
+	for scanner.Scan() {
+		var entry claudeSessionEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil || entry.Type != "user" {
+			continue
+		}
+		content := strings.TrimSpace(getMessageContent(entry.Message.Content, entry.Type))
+		// Skip empty and system-injected messages (slash commands, caveats)
+		if content == "" || strings.HasPrefix(content, "<") {
+			continue
+		}
+		prompts = append(prompts, content)
+	}
+	return prompts
+}
+
+// tokenizeTopics splits text into lowercase candidate keywords
+func tokenizeTopics(text string) []string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_'
+	})
+	var tokens []string
+	for _, w := range words {
+		w = strings.Trim(w, "-_")
+		if len([]rune(w)) < 3 || topicStopwords[w] {
+			continue
+		}
+		// Skip pure numbers and hex-like IDs
+		if strings.IndexFunc(w, unicode.IsLetter) == -1 {
+			continue
+		}
+		tokens = append(tokens, w)
+	}
+	return tokens
+}
+
+// TopicKeywords computes the top tf-idf keywords for each document (key -> prompts)
+func TopicKeywords(docs map[string][]string, n int) map[string][]string {
+	// Term frequencies per document and document frequency per term
+	tf := make(map[string]map[string]int, len(docs))
+	totals := make(map[string]int, len(docs))
+	df := make(map[string]int)
+	for key, prompts := range docs {
+		counts := make(map[string]int)
+		for _, p := range prompts {
+			for _, tok := range tokenizeTopics(p) {
+				counts[tok]++
+				totals[key]++
+			}
+		}
+		for tok := range counts {
+			df[tok]++
+		}
+		tf[key] = counts
+	}
+
+	result := make(map[string][]string, len(docs))
+	numDocs := float64(len(docs))
+	for key, counts := range tf {
+		if totals[key] == 0 {
+			continue
+		}
+		type scored struct {
+			term  string
+			score float64
+		}
+		var terms []scored
+		for tok, c := range counts {
+			idf := math.Log((numDocs+1)/(float64(df[tok])+1)) + 1
+			terms = append(terms, scored{tok, float64(c) / float64(totals[key]) * idf})
+		}
+		sort.Slice(terms, func(a, b int) bool {
+			if terms[a].score != terms[b].score {
+				return terms[a].score > terms[b].score
+			}
+			return terms[a].term < terms[b].term
+		})
+		for j := 0; j < len(terms) && j < n; j++ {
+			result[key] = append(result[key], terms[j].term)
+		}
+	}
+	return result
+}
@@ -0,0 +1,112 @@
+package session
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// CanDeleteTranscript reports whether the entry's underlying transcript can be deleted
+func (e *HistoryEntry) CanDeleteTranscript() bool {
+	switch e.Agent {
+	case AgentClaude, AgentCodex, AgentGemini:
+		return e.SessionFile != ""
+	case AgentOpenCode:
+		return e.DBPath != "" && e.SessionID != ""
+	}
+	return false
+}
+
+// DeleteTranscript permanently removes the transcript the entry belongs to
+func (e *HistoryEntry) DeleteTranscript() error {
+	if !e.CanDeleteTranscript() {
+		return fmt.Errorf("%s history entries can't be deleted", e.Agent)
+	}
+
+	if e.Agent == AgentOpenCode {
+		db, err := sql.Open("sqlite3", e.DBPath)
+		if err != nil {
+			return fmt.Errorf("failed to open OpenCode database: %w", err)
+		}
+		defer db.Close()
+		// Files table only exists in some versions - ignore its error
+		db.Exec("DELETE FROM files WHERE session_id = ?", e.SessionID)
+		if _, err := db.Exec("DELETE FROM messages WHERE session_id = ?", e.SessionID); err != nil {
+			return fmt.Errorf("failed to delete messages: %w", err)
+		}
+		if _, err := db.Exec("DELETE FROM sessions WHERE id = ?", e.SessionID); err != nil {
+			return fmt.Errorf("failed to delete session: %w", err)
+		}
+		return nil
+	}
+
+	if err := os.Remove(e.SessionFile); err != nil {
+		return fmt.Errorf("failed to delete transcript: %w", err)
+	}
+	// Claude keeps subagent transcripts and tool results next to the session file
+	if e.Agent == AgentClaude {
+		os.RemoveAll(strings.TrimSuffix(e.SessionFile, ".jsonl"))
+	}
+	return nil
+}
+
+// sameTranscript reports whether two entries come from the same transcript
+func (e *HistoryEntry) sameTranscript(other *HistoryEntry) bool {
+	if e.Agent != other.Agent {
+		return false
+	}
+	if e.SessionFile != "" {
+		return e.SessionFile == other.SessionFile
+	}
+	return e.SessionID != "" && e.SessionID == other.SessionID && e.DBPath == other.DBPath
+}
+
+// RemoveTranscript drops all entries of the entry's transcript from the index
+func (h *HistoryIndex) RemoveTranscript(entry *HistoryEntry) {
+	h.entries = RemoveTranscriptEntries(h.entries, entry)
+}
+
+// RemoveTranscriptEntries returns entries without those of the given entry's transcript
+func RemoveTranscriptEntries(entries []HistoryEntry, entry *HistoryEntry) []HistoryEntry {
+	kept := entries[:0:0]
+	for i := range entries {
+		if !entries[i].sameTranscript(entry) {
+			kept = append(kept, entries[i])
+		}
+	}
+	return kept
+}
+
+// ConversationMarkdown renders a conversation as a Markdown document, with the
+// time of each message in its header (title defaults to "<agent> conversation")
+func ConversationMarkdown(title string, entry *HistoryEntry, messages []ConversationMessage) string {
+	if title == "" {
+		title = string(entry.Agent) + " conversation"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s\n\n", title)
+	fmt.Fprintf(&b, "- Agent: %s\n", entry.Agent)
+	if entry.Path != "" {
+		fmt.Fprintf(&b, "- Project: `%s`\n", entry.Path)
+	}
+	if entry.SessionID != "" {
+		fmt.Fprintf(&b, "- Session: `%s`\n", entry.SessionID)
+	}
+	fmt.Fprintf(&b, "- Last activity: %s\n\n", entry.Timestamp.Format("2006-01-02 15:04"))
+
+	if len(messages) == 0 {
+		// No transcript to load (e.g. shell history) - export the matched content
+		b.WriteString(entry.Content)
+		b.WriteString("\n")
+		return b.String()
+	}
+	for _, msg := range messages {
+		header := messageRole(msg)
+		if !msg.Timestamp.IsZero() {
+			header += " · " + msg.Timestamp.Local().Format("2006-01-02 15:04")
+		}
+		fmt.Fprintf(&b, "## %s\n\n%s\n\n", header, closeFences(strings.TrimSpace(msg.Content)))
+	}
+	return b.String()
+}
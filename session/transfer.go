@@ -0,0 +1,149 @@
+package session
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// TransferKind is what a transfer takes from the source session
+type TransferKind int
+
+const (
+	TransferChanges    TransferKind = iota // Uncommitted changes as a patch
+	TransferLastCommit                     // Patch of the last commit
+	TransferFile                           // One file, copied as is
+)
+
+// TransferItem is something that can be taken from one session's path to another's
+type TransferItem struct {
+	Kind  TransferKind
+	Path  string // File relative to the session path (TransferFile)
+	Label string // Shown in the picker
+}
+
+// TransferItems lists what the session can hand over: its uncommitted changes,
+// its last commit and the files it changed
+func (i *Instance) TransferItems() ([]TransferItem, error) {
+	if !i.isGitRepo() {
+		return nil, fmt.Errorf("'%s' is not in a git repository", i.Path)
+	}
+	var items []TransferItem
+	if patch, _ := i.transferPatch(TransferItem{Kind: TransferChanges}); patch != "" {
+		added, removed := i.countDiffLines(patch)
+		items = append(items, TransferItem{Kind: TransferChanges, Label: fmt.Sprintf("Uncommitted changes (+%d -%d)", added, removed)})
+	}
+	if subject := commandLine(exec.Command("git", "-C", i.Path, "log", "-1", "--format=%h %s")); subject != "" {
+		items = append(items, TransferItem{Kind: TransferLastCommit, Label: "Last commit: " + subject})
+	}
+
+	changed, _ := exec.Command("git", "-C", i.Path, "diff", "--name-only", "--relative", "HEAD").Output()
+	untracked, _ := exec.Command("git", "-C", i.Path, "ls-files", "--others", "--exclude-standard").Output()
+	seen := make(map[string]bool)
+	var files []string
+	for _, path := range strings.Split(string(changed)+"\n"+string(untracked), "\n") {
+		if path == "" || seen[path] {
+			continue
+		}
+		seen[path] = true
+		// Deleted files have nothing to copy
+		if info, err := os.Stat(filepath.Join(i.Path, path)); err == nil && info.Mode().IsRegular() {
+			files = append(files, path)
+		}
+	}
+	sort.Strings(files)
+	for _, path := range files {
+		items = append(items, TransferItem{Kind: TransferFile, Path: path, Label: "File: " + path})
+	}
+	return items, nil
+}
+
+// transferPatch returns the patch of a changes or last commit item, relative to the session path
+func (i *Instance) transferPatch(item TransferItem) (string, error) {
+	var cmd *exec.Cmd
+	if item.Kind == TransferLastCommit {
+		cmd = exec.Command("git", "-C", i.Path, "--no-pager", "show", "--format=", "--binary", "--relative", "HEAD")
+	} else {
+		// New files are included like in the diff view
+		i.stageUntrackedFiles()
+		cmd = exec.Command("git", "-C", i.Path, "--no-pager", "diff", "--binary", "--relative", "HEAD")
+	}
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("reading the patch failed: %w", err)
+	}
+	return string(out), nil
+}
+
+// TransferTo copies a file or applies a patch of this session in the target's path.
+// Patches that don't apply cleanly in a git repo are merged with --3way, leaving
+// conflict markers to resolve.
+func (i *Instance) TransferTo(target *Instance, item TransferItem) error {
+	if filepath.Clean(i.Path) == filepath.Clean(target.Path) {
+		return fmt.Errorf("'%s' works in the same directory", target.Name)
+	}
+	if item.Kind == TransferFile {
+		return copyFile(filepath.Join(i.Path, item.Path), filepath.Join(target.Path, item.Path))
+	}
+	patch, err := i.transferPatch(item)
+	if err != nil {
+		return err
+	}
+	if strings.TrimSpace(patch) == "" {
+		return fmt.Errorf("nothing to transfer")
+	}
+	if !target.isGitRepo() {
+		return applyPatch(target.Path, patch)
+	}
+	// git apply resolves paths from the repository root and skips files
+	// outside the working directory, so apply at the top with the prefix
+	top := commandLine(exec.Command("git", "-C", target.Path, "rev-parse", "--show-toplevel"))
+	var args []string
+	if prefix := commandLine(exec.Command("git", "-C", target.Path, "rev-parse", "--show-prefix")); prefix != "" {
+		args = append(args, "--directory="+prefix)
+	}
+	if err := applyPatch(top, patch, args...); err == nil {
+		return nil
+	}
+	return applyPatch(top, patch, append(args, "--3way")...)
+}
+
+// applyPatch applies a patch in dir (outside git too, like patch -p1)
+func applyPatch(dir, patch string, args ...string) error {
+	cmd := exec.Command("git", append([]string{"-C", dir, "apply"}, append(args, "-")...)...)
+	cmd.Stdin = strings.NewReader(patch)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		line, _, _ := strings.Cut(strings.TrimSpace(string(out)), "\n")
+		return fmt.Errorf("git apply failed: %s", strings.TrimPrefix(line, "error: "))
+	}
+	return nil
+}
+
+// copyFile copies a file with its permissions, creating the parent directories
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
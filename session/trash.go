@@ -0,0 +1,210 @@
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// MaxDeletedGroups is how many deleted groups are kept for undo
+const MaxDeletedGroups = 10
+
+// GroupDeleteMode is what happens to the sessions of a deleted group
+type GroupDeleteMode string
+
+const (
+	GroupDeleteMove    GroupDeleteMode = "move"    // Sessions move to another group
+	GroupDeleteUngroup GroupDeleteMode = "ungroup" // Sessions stay, without a group
+	GroupDeleteArchive GroupDeleteMode = "archive" // Sessions are deleted into the recently closed list
+)
+
+// DeletedGroup is a deleted group with what happened to its sessions, for undo
+type DeletedGroup struct {
+	Group     Group           `json:"group"`
+	Position  int             `json:"position"` // Index in the group list
+	Mode      GroupDeleteMode `json:"mode"`
+	TargetID  string          `json:"target_id,omitempty"` // Group the sessions were moved to
+	Sessions  []string        `json:"sessions,omitempty"`  // IDs of the sessions that were in the group
+	Archived  []Instance      `json:"archived,omitempty"`  // Sessions deleted with the group
+	DeletedAt time.Time       `json:"deleted_at"`
+}
+
+// deletedGroupsPath returns the group trash file of the active project
+func (s *Storage) deletedGroupsPath() string {
+	return filepath.Join(filepath.Dir(s.configPath), "deleted_groups.json")
+}
+
+// LoadDeletedGroups returns the deleted groups, most recent last
+func (s *Storage) LoadDeletedGroups() ([]DeletedGroup, error) {
+	data, err := os.ReadFile(s.deletedGroupsPath())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read deleted groups: %w", err)
+	}
+	var deleted []DeletedGroup
+	if err := json.Unmarshal(data, &deleted); err != nil {
+		return nil, fmt.Errorf("failed to parse deleted groups: %w", err)
+	}
+	return deleted, nil
+}
+
+// saveDeletedGroups writes the group trash
+func (s *Storage) saveDeletedGroups(deleted []DeletedGroup) error {
+	data, err := json.MarshalIndent(deleted, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal deleted groups: %w", err)
+	}
+	return os.WriteFile(s.deletedGroupsPath(), data, 0644)
+}
+
+// DeleteGroup removes a group and keeps it in the group trash. Its sessions
+// move to the target group, become ungrouped, or are archived as deleted
+// sessions (protected sessions are ungrouped instead).
+func (s *Storage) DeleteGroup(id string, mode GroupDeleteMode, targetID string) (*DeletedGroup, error) {
+	instances, groups, err := s.LoadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := &DeletedGroup{Position: -1, Mode: mode, DeletedAt: time.Now()}
+	newGroups := make([]*Group, 0, len(groups))
+	for i, g := range groups {
+		if g.ID == id {
+			deleted.Group = *g
+			deleted.Position = i
+			continue
+		}
+		newGroups = append(newGroups, g)
+	}
+	if deleted.Position == -1 {
+		return nil, fmt.Errorf("group not found")
+	}
+	if mode == GroupDeleteMove {
+		if targetID == id {
+			return nil, fmt.Errorf("cannot move sessions into the deleted group")
+		}
+		deleted.TargetID = targetID
+	}
+
+	kept := make([]*Instance, 0, len(instances))
+	for _, inst := range instances {
+		if inst.GroupID != id {
+			kept = append(kept, inst)
+			continue
+		}
+		deleted.Sessions = append(deleted.Sessions, inst.ID)
+		switch {
+		case mode == GroupDeleteMove:
+			inst.GroupID = targetID
+		case mode == GroupDeleteArchive && !inst.Protected:
+			// Archive first so the session can also be reopened on its own with u
+			s.PushClosed(inst, true)
+			inst.Stop()
+			inst.RemoveWorktree()
+			inst.removeMCPConfig()
+			deleted.Archived = append(deleted.Archived, *inst)
+			continue
+		default:
+			inst.GroupID = ""
+		}
+		kept = append(kept, inst)
+	}
+
+	if err := s.SaveWithGroups(kept, newGroups); err != nil {
+		return nil, err
+	}
+
+	trash, _ := s.LoadDeletedGroups()
+	trash = append(trash, *deleted)
+	if len(trash) > MaxDeletedGroups {
+		trash = trash[len(trash)-MaxDeletedGroups:]
+	}
+	return deleted, s.saveDeletedGroups(trash)
+}
+
+// LastDeletedGroup returns the most recently deleted group (nil if none)
+func (s *Storage) LastDeletedGroup() (*DeletedGroup, error) {
+	trash, err := s.LoadDeletedGroups()
+	if err != nil || len(trash) == 0 {
+		return nil, err
+	}
+	return &trash[len(trash)-1], nil
+}
+
+// RestoreGroup undoes the most recent group deletion: the group returns to
+// its place and gets back its sessions, unless they were regrouped since
+func (s *Storage) RestoreGroup() (*DeletedGroup, error) {
+	trash, err := s.LoadDeletedGroups()
+	if err != nil || len(trash) == 0 {
+		return nil, err
+	}
+	deleted := trash[len(trash)-1]
+
+	instances, groups, err := s.LoadAll()
+	if err != nil {
+		return nil, err
+	}
+	group := deleted.Group
+	pos := deleted.Position
+	if pos > len(groups) {
+		pos = len(groups)
+	}
+	groups = append(groups[:pos], append([]*Group{&group}, groups[pos:]...)...)
+
+	// Sessions still where the deletion put them come back
+	leftIn := ""
+	if deleted.Mode == GroupDeleteMove {
+		leftIn = deleted.TargetID
+	}
+	inGroup := make(map[string]bool)
+	for _, id := range deleted.Sessions {
+		inGroup[id] = true
+	}
+	existing := make(map[string]bool)
+	for _, inst := range instances {
+		existing[inst.ID] = true
+		if inGroup[inst.ID] && inst.GroupID == leftIn {
+			inst.GroupID = group.ID
+		}
+	}
+
+	// Archived sessions are recreated (stopped) unless reopened meanwhile
+	restored := make(map[string]bool)
+	for _, archived := range deleted.Archived {
+		if existing[archived.ID] {
+			continue
+		}
+		inst := archived
+		inst.Status = StatusStopped
+		inst.GroupID = group.ID
+		instances = append(instances, &inst)
+		restored[inst.ID] = true
+	}
+
+	if err := s.SaveWithGroups(instances, groups); err != nil {
+		return nil, err
+	}
+	if len(restored) > 0 {
+		s.dropClosed(restored)
+	}
+	return &deleted, s.saveDeletedGroups(trash[:len(trash)-1])
+}
+
+// dropClosed removes sessions from the recently closed list
+func (s *Storage) dropClosed(ids map[string]bool) error {
+	closed, err := s.LoadClosed()
+	if err != nil {
+		return err
+	}
+	kept := closed[:0]
+	for _, c := range closed {
+		if !ids[c.Instance.ID] {
+			kept = append(kept, c)
+		}
+	}
+	return s.saveClosed(kept)
+}
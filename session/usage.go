@@ -0,0 +1,432 @@
+package session
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TokenUsage is the tokens of one or more API calls and their estimated cost
+type TokenUsage struct {
+	Input      int64   // Uncached input tokens
+	Output     int64   // Output tokens (thinking included)
+	CacheWrite int64   // Input tokens written to the prompt cache
+	CacheRead  int64   // Input tokens read from the prompt cache
+	Cost       float64 // Estimated USD of the priced calls
+	Unpriced   int64   // Tokens of calls whose model has no known price
+}
+
+// Tokens returns all tokens together
+func (u TokenUsage) Tokens() int64 {
+	return u.Input + u.Output + u.CacheWrite + u.CacheRead
+}
+
+// Add sums another usage into u
+func (u *TokenUsage) Add(o TokenUsage) {
+	u.Input += o.Input
+	u.Output += o.Output
+	u.CacheWrite += o.CacheWrite
+	u.CacheRead += o.CacheRead
+	u.Cost += o.Cost
+	u.Unpriced += o.Unpriced
+}
+
+// FormatCost formats the estimated cost ("~$1.23", "~$1.23+" when some calls
+// are unpriced, "n/a" when none are)
+func (u TokenUsage) FormatCost() string {
+	switch {
+	case u.Cost == 0 && u.Unpriced > 0:
+		return "n/a"
+	case u.Unpriced > 0:
+		return fmt.Sprintf("~$%.2f+", u.Cost)
+	}
+	return fmt.Sprintf("~$%.2f", u.Cost)
+}
+
+// FormatTokens formats a token count as a short string (950, 12.3k, 4.5M)
+func FormatTokens(n int64) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1_000:
+		return fmt.Sprintf("%.1fk", float64(n)/1_000)
+	}
+	return fmt.Sprintf("%d", n)
+}
+
+// SessionUsage is the token usage of a session's conversations
+type SessionUsage struct {
+	Total TokenUsage
+	ByDay map[string]TokenUsage // Local date ("2006-01-02") -> usage
+}
+
+// usageCall is one API call read from a transcript
+type usageCall struct {
+	at    time.Time
+	model string
+	usage TokenUsage // Tokens only - priced when summed
+}
+
+// ModelPrice is the USD price per million tokens of a model
+type ModelPrice struct {
+	Input      float64 `toml:"input"`
+	Output     float64 `toml:"output"`
+	CacheWrite float64 `toml:"cache_write"`
+	CacheRead  float64 `toml:"cache_read"`
+}
+
+// defaultPrices are list prices by model name prefix (the longest matching prefix wins)
+var defaultPrices = map[string]ModelPrice{
+	"claude-opus-4-5":       {Input: 5, Output: 25, CacheWrite: 6.25, CacheRead: 0.5},
+	"claude-opus-4":         {Input: 15, Output: 75, CacheWrite: 18.75, CacheRead: 1.5},
+	"claude-sonnet-4":       {Input: 3, Output: 15, CacheWrite: 3.75, CacheRead: 0.3},
+	"claude-3-7-sonnet":     {Input: 3, Output: 15, CacheWrite: 3.75, CacheRead: 0.3},
+	"claude-3-5-sonnet":     {Input: 3, Output: 15, CacheWrite: 3.75, CacheRead: 0.3},
+	"claude-haiku-4-5":      {Input: 1, Output: 5, CacheWrite: 1.25, CacheRead: 0.1},
+	"claude-3-5-haiku":      {Input: 0.8, Output: 4, CacheWrite: 1, CacheRead: 0.08},
+	"gpt-5":                 {Input: 1.25, Output: 10, CacheRead: 0.125},
+	"gpt-5-mini":            {Input: 0.25, Output: 2, CacheRead: 0.025},
+	"gpt-4.1":               {Input: 2, Output: 8, CacheRead: 0.5},
+	"o3":                    {Input: 2, Output: 8, CacheRead: 0.5},
+	"o4-mini":               {Input: 1.1, Output: 4.4, CacheRead: 0.275},
+	"gemini-2.5-pro":        {Input: 1.25, Output: 10, CacheRead: 0.31},
+	"gemini-2.5-flash":      {Input: 0.3, Output: 2.5, CacheRead: 0.075},
+	"gemini-2.5-flash-lite": {Input: 0.1, Output: 0.4, CacheRead: 0.025},
+}
+
+// ModelPrices returns the built-in prices with custom ones ([prices] in config.toml) on top
+func ModelPrices(custom map[string]ModelPrice) map[string]ModelPrice {
+	prices := make(map[string]ModelPrice, len(defaultPrices)+len(custom))
+	for model, price := range defaultPrices {
+		prices[model] = price
+	}
+	for model, price := range custom {
+		prices[strings.ToLower(model)] = price
+	}
+	return prices
+}
+
+// priceOf returns the price of the longest prefix of model in prices
+func priceOf(prices map[string]ModelPrice, model string) (ModelPrice, bool) {
+	model = strings.ToLower(model)
+	best, found := "", false
+	for prefix := range prices {
+		if strings.HasPrefix(model, prefix) && len(prefix) >= len(best) {
+			best, found = prefix, true
+		}
+	}
+	return prices[best], found
+}
+
+// priced returns a call's usage with its estimated cost
+func (c usageCall) priced(prices map[string]ModelPrice) TokenUsage {
+	u := c.usage
+	price, ok := priceOf(prices, c.model)
+	if !ok {
+		u.Unpriced = u.Tokens()
+		return u
+	}
+	u.Cost = (float64(u.Input)*price.Input + float64(u.Output)*price.Output +
+		float64(u.CacheWrite)*price.CacheWrite + float64(u.CacheRead)*price.CacheRead) / 1_000_000
+	return u
+}
+
+// transcriptUsage is what a transcript file contributes
+type transcriptUsage struct {
+	id    string // Conversation ID
+	cwd   string // Working directory (Codex only)
+	calls []usageCall
+}
+
+// usageCacheEntry is a parsed transcript, valid while its size and mtime stay
+type usageCacheEntry struct {
+	size int64
+	mod  time.Time
+	file transcriptUsage
+}
+
+var (
+	usageMu    sync.Mutex
+	usageCache = make(map[string]usageCacheEntry) // Transcript path -> parsed calls
+)
+
+// cachedTranscript parses a transcript unless it is unchanged since the last call
+func cachedTranscript(path string, info os.FileInfo, parse func(string) transcriptUsage) transcriptUsage {
+	usageMu.Lock()
+	entry, ok := usageCache[path]
+	usageMu.Unlock()
+	if ok && entry.size == info.Size() && entry.mod.Equal(info.ModTime()) {
+		return entry.file
+	}
+	file := parse(path)
+	usageMu.Lock()
+	usageCache[path] = usageCacheEntry{size: info.Size(), mod: info.ModTime(), file: file}
+	usageMu.Unlock()
+	return file
+}
+
+// scanJSONLines calls fn with every line of a JSONL file
+func scanJSONLines(path string, fn func([]byte)) {
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		fn(scanner.Bytes())
+	}
+}
+
+// parseClaudeUsage reads the API calls of a Claude transcript. A response with
+// several content blocks is logged once per block, so calls are keyed by message ID.
+func parseClaudeUsage(path string) transcriptUsage {
+	file := transcriptUsage{id: strings.TrimSuffix(filepath.Base(path), ".jsonl")}
+	seen := make(map[string]bool)
+	scanJSONLines(path, func(line []byte) {
+		var entry struct {
+			Type      string `json:"type"`
+			Timestamp string `json:"timestamp"`
+			Message   struct {
+				ID    string `json:"id"`
+				Model string `json:"model"`
+				Usage *struct {
+					Input      int64 `json:"input_tokens"`
+					Output     int64 `json:"output_tokens"`
+					CacheWrite int64 `json:"cache_creation_input_tokens"`
+					CacheRead  int64 `json:"cache_read_input_tokens"`
+				} `json:"usage"`
+			} `json:"message"`
+		}
+		if json.Unmarshal(line, &entry) != nil || entry.Type != "assistant" || entry.Message.Usage == nil {
+			return
+		}
+		if entry.Message.ID != "" {
+			if seen[entry.Message.ID] {
+				return
+			}
+			seen[entry.Message.ID] = true
+		}
+		at, _ := time.Parse(time.RFC3339, entry.Timestamp)
+		u := entry.Message.Usage
+		file.calls = append(file.calls, usageCall{at: at, model: entry.Message.Model,
+			usage: TokenUsage{Input: u.Input, Output: u.Output, CacheWrite: u.CacheWrite, CacheRead: u.CacheRead}})
+	})
+	return file
+}
+
+// codexTokens is a token_count total in a Codex rollout
+type codexTokens struct {
+	Input  int64 `json:"input_tokens"`
+	Cached int64 `json:"cached_input_tokens"`
+	Output int64 `json:"output_tokens"`
+}
+
+// parseCodexUsage reads the API calls of a Codex rollout. token_count events
+// carry running totals, so each call is the difference to the previous one.
+func parseCodexUsage(path string) transcriptUsage {
+	var file transcriptUsage
+	var model string
+	var prev codexTokens
+	scanJSONLines(path, func(line []byte) {
+		var entry struct {
+			Type      string `json:"type"`
+			Timestamp string `json:"timestamp"`
+			Payload   struct {
+				Type  string `json:"type"`
+				ID    string `json:"id"`
+				CWD   string `json:"cwd"`
+				Model string `json:"model"`
+				Info  *struct {
+					Total codexTokens `json:"total_token_usage"`
+				} `json:"info"`
+			} `json:"payload"`
+		}
+		if json.Unmarshal(line, &entry) != nil {
+			return
+		}
+		switch {
+		case entry.Type == "session_meta":
+			file.id, file.cwd = entry.Payload.ID, entry.Payload.CWD
+		case entry.Type == "turn_context" && entry.Payload.Model != "":
+			model = entry.Payload.Model
+		case entry.Type == "event_msg" && entry.Payload.Type == "token_count" && entry.Payload.Info != nil:
+			total := entry.Payload.Info.Total
+			if total.Input < prev.Input || total.Output < prev.Output {
+				prev = codexTokens{} // Totals restarted
+			}
+			cached := total.Cached - prev.Cached
+			input := total.Input - prev.Input - cached // input_tokens include the cached ones
+			output := total.Output - prev.Output
+			prev = total
+			if input+cached+output <= 0 {
+				return
+			}
+			at, _ := time.Parse(time.RFC3339, entry.Timestamp)
+			file.calls = append(file.calls, usageCall{at: at, model: model,
+				usage: TokenUsage{Input: input, Output: output, CacheRead: cached}})
+		}
+	})
+	return file
+}
+
+// parseGeminiUsage reads the API calls of a Gemini CLI chat file
+func parseGeminiUsage(path string) transcriptUsage {
+	var file transcriptUsage
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return file
+	}
+	var chat struct {
+		SessionID string `json:"sessionId"`
+		Messages  []struct {
+			Type      string `json:"type"`
+			Timestamp string `json:"timestamp"`
+			Model     string `json:"model"`
+			Tokens    *struct {
+				Input    int64 `json:"input"`
+				Output   int64 `json:"output"`
+				Cached   int64 `json:"cached"`
+				Thoughts int64 `json:"thoughts"`
+			} `json:"tokens"`
+		} `json:"messages"`
+	}
+	if json.Unmarshal(data, &chat) != nil {
+		return file
+	}
+	file.id = chat.SessionID
+	for _, msg := range chat.Messages {
+		if msg.Type != "gemini" || msg.Tokens == nil {
+			continue
+		}
+		at, _ := time.Parse(time.RFC3339, msg.Timestamp)
+		t := msg.Tokens
+		file.calls = append(file.calls, usageCall{at: at, model: msg.Model,
+			usage: TokenUsage{Input: t.Input - t.Cached, Output: t.Output + t.Thoughts, CacheRead: t.Cached}})
+	}
+	return file
+}
+
+// geminiChatsDir returns where Gemini keeps the conversations of a project directory
+func geminiChatsDir(path string) string {
+	homeDir, _ := os.UserHomeDir()
+	hash := sha256.Sum256([]byte(path))
+	return filepath.Join(homeDir, ".gemini", "tmp", hex.EncodeToString(hash[:]), "chats")
+}
+
+// conversationIDs returns the conversation IDs the session's windows of an agent resume
+func (i *Instance) conversationIDs(agent AgentType) map[string]bool {
+	ids := make(map[string]bool)
+	if i.WindowAgent(0) == agent && i.ResumeSessionID != "" {
+		ids[i.ResumeSessionID] = true
+	}
+	for _, fw := range i.FollowedWindows {
+		if fw.Agent == agent && fw.ResumeSessionID != "" {
+			ids[fw.ResumeSessionID] = true
+		}
+	}
+	return ids
+}
+
+// usesAgent reports whether the main window or a tab of the session runs an agent
+func (i *Instance) usesAgent(agent AgentType) bool {
+	if i.WindowAgent(0) == agent {
+		return true
+	}
+	for _, fw := range i.FollowedWindows {
+		if fw.Agent == agent {
+			return true
+		}
+	}
+	return false
+}
+
+// Usage sums the token usage of the session's conversations: the Claude
+// transcripts it resumed or last wrote, and the Codex and Gemini conversations
+// in its directory that were active since it was created. Parsed transcripts
+// are cached until they change.
+func (i *Instance) Usage(prices map[string]ModelPrice) SessionUsage {
+	var calls []usageCall
+	if i.usesAgent(AgentClaude) {
+		claudeDir := GetClaudeProjectDir(i.Path)
+		paths := make(map[string]bool)
+		for id := range i.conversationIDs(AgentClaude) {
+			paths[filepath.Join(claudeDir, id+".jsonl")] = true
+		}
+		if path := i.ClaudeTranscriptPath(); path != "" {
+			paths[path] = true
+		}
+		for path := range paths {
+			if info, err := os.Stat(path); err == nil {
+				calls = append(calls, cachedTranscript(path, info, parseClaudeUsage).calls...)
+			}
+		}
+	}
+	if i.usesAgent(AgentCodex) {
+		ids := i.conversationIDs(AgentCodex)
+		key := PathKey(i.Path)
+		filepath.Walk(codexSessionsDir(), func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() || !strings.HasSuffix(path, ".jsonl") || info.ModTime().Before(i.CreatedAt) && len(ids) == 0 {
+				return nil
+			}
+			file := cachedTranscript(path, info, parseCodexUsage)
+			if ids[file.id] || (!info.ModTime().Before(i.CreatedAt) && file.cwd != "" && PathKey(file.cwd) == key) {
+				calls = append(calls, file.calls...)
+			}
+			return nil
+		})
+	}
+	if i.usesAgent(AgentGemini) {
+		ids := i.conversationIDs(AgentGemini)
+		chats := geminiChatsDir(i.Path)
+		entries, _ := os.ReadDir(chats)
+		for _, entry := range entries {
+			info, err := entry.Info()
+			if err != nil || !strings.HasPrefix(entry.Name(), "session-") || !strings.HasSuffix(entry.Name(), ".json") {
+				continue
+			}
+			file := cachedTranscript(filepath.Join(chats, entry.Name()), info, parseGeminiUsage)
+			if ids[file.id] || !info.ModTime().Before(i.CreatedAt) {
+				calls = append(calls, file.calls...)
+			}
+		}
+	}
+
+	usage := SessionUsage{ByDay: make(map[string]TokenUsage)}
+	for _, call := range calls {
+		u := call.priced(prices)
+		usage.Total.Add(u)
+		if !call.at.IsZero() {
+			day := call.at.Local().Format("2006-01-02")
+			d := usage.ByDay[day]
+			d.Add(u)
+			usage.ByDay[day] = d
+		}
+	}
+	return usage
+}
+
+// UsageDays returns the days of usage maps, newest first
+func UsageDays(byDay ...map[string]TokenUsage) []string {
+	seen := make(map[string]bool)
+	var days []string
+	for _, m := range byDay {
+		for day := range m {
+			if !seen[day] {
+				seen[day] = true
+				days = append(days, day)
+			}
+		}
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(days)))
+	return days
+}
@@ -0,0 +1,236 @@
+package session
+
+import (
+	"archive/zip"
+	"bufio"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// WebExportFile is the file holding the conversations in a claude.ai / Claude Desktop data export
+const WebExportFile = "conversations.json"
+
+// WebConversation is a conversation from a claude.ai or Claude Desktop data export
+type WebConversation struct {
+	UUID      string       `json:"uuid"`
+	Name      string       `json:"name"`
+	CreatedAt time.Time    `json:"created_at"`
+	UpdatedAt time.Time    `json:"updated_at"`
+	Messages  []WebMessage `json:"chat_messages"`
+}
+
+// WebMessage is one message of an exported conversation
+type WebMessage struct {
+	UUID      string    `json:"uuid"`
+	Sender    string    `json:"sender"` // "human" or "assistant"
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+	Content   []struct {
+		Type string `json:"type"`
+		Text string `json:"text"`
+	} `json:"content"`
+	Attachments []struct {
+		FileName         string `json:"file_name"`
+		ExtractedContent string `json:"extracted_content"`
+	} `json:"attachments"`
+}
+
+// Body returns the message text with the contents of attached files
+func (m WebMessage) Body() string {
+	var parts []string
+	for _, block := range m.Content {
+		if block.Type == "text" && strings.TrimSpace(block.Text) != "" {
+			parts = append(parts, block.Text)
+		}
+	}
+	if len(parts) == 0 && strings.TrimSpace(m.Text) != "" {
+		parts = append(parts, m.Text)
+	}
+	for _, a := range m.Attachments {
+		if a.ExtractedContent != "" {
+			parts = append(parts, fmt.Sprintf("<attachment name=%q>\n%s\n</attachment>", a.FileName, a.ExtractedContent))
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// ReadWebExport reads the conversations of a data export: the export's .zip or its conversations.json
+func ReadWebExport(path string) ([]WebConversation, error) {
+	var r io.Reader
+	if strings.EqualFold(filepath.Ext(path), ".zip") {
+		zr, err := zip.OpenReader(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", path, err)
+		}
+		defer zr.Close()
+		for _, f := range zr.File {
+			if filepath.Base(f.Name) != WebExportFile {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return nil, err
+			}
+			defer rc.Close()
+			r = rc
+			break
+		}
+		if r == nil {
+			return nil, fmt.Errorf("%s has no %s - is it a Claude data export?", path, WebExportFile)
+		}
+	} else {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	var conversations []WebConversation
+	if err := json.NewDecoder(r).Decode(&conversations); err != nil {
+		return nil, fmt.Errorf("not a Claude conversation export: %w", err)
+	}
+	return conversations, nil
+}
+
+// WebImportReport tells what an import of web conversations did
+type WebImportReport struct {
+	Imported []string // Session IDs of the written transcripts
+	Existing int      // Conversations imported before
+	Empty    int      // Conversations without messages
+}
+
+// ImportWebConversations writes each conversation as a Claude Code transcript
+// for projectPath, where it shows up in history search and can be resumed
+// with claude --resume <session ID>. Conversations imported before are skipped.
+func ImportWebConversations(conversations []WebConversation, projectPath string) (*WebImportReport, error) {
+	absPath, err := filepath.Abs(expandTilde(projectPath))
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(absPath); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("path does not exist: %s", absPath)
+	}
+	dir := GetClaudeProjectDir(absPath)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	report := &WebImportReport{}
+	for _, conv := range conversations {
+		sessionID := conv.UUID
+		if !isValidUUID(sessionID) {
+			sessionID = stableUUID(conv.UUID + conv.Name + conv.CreatedAt.String())
+		}
+		file := filepath.Join(dir, sessionID+".jsonl")
+		if _, err := os.Stat(file); err == nil {
+			report.Existing++
+			continue
+		}
+		lines := webTranscriptLines(conv, sessionID, absPath)
+		if len(lines) == 0 {
+			report.Empty++
+			continue
+		}
+		if err := writeJSONLines(file, lines); err != nil {
+			return report, fmt.Errorf("failed to write %s: %w", file, err)
+		}
+		report.Imported = append(report.Imported, sessionID)
+	}
+	return report, nil
+}
+
+// webTranscriptLines converts a conversation to the lines of a Claude Code transcript
+func webTranscriptLines(conv WebConversation, sessionID, cwd string) []map[string]interface{} {
+	var lines []map[string]interface{}
+	var parent interface{} // null for the first message
+	for idx, msg := range conv.Messages {
+		body := msg.Body()
+		if body == "" {
+			continue
+		}
+		id := msg.UUID
+		if !isValidUUID(id) {
+			id = stableUUID(fmt.Sprintf("%s/%d", sessionID, idx))
+		}
+		ts := msg.CreatedAt
+		if ts.IsZero() {
+			ts = conv.CreatedAt
+		}
+
+		line := map[string]interface{}{
+			"parentUuid":  parent,
+			"isSidechain": false,
+			"userType":    "external",
+			"cwd":         cwd,
+			"sessionId":   sessionID,
+			"version":     "imported",
+			"uuid":        id,
+			"timestamp":   ts.UTC().Format("2006-01-02T15:04:05.000Z"),
+		}
+		if msg.Sender == "human" {
+			line["type"] = "user"
+			line["message"] = map[string]interface{}{"role": "user", "content": body}
+		} else {
+			line["type"] = "assistant"
+			line["message"] = map[string]interface{}{
+				"role":    "assistant",
+				"type":    "message",
+				"content": []claudeContentBlock{{Type: "text", Text: body}},
+			}
+		}
+		lines = append(lines, line)
+		parent = id
+	}
+	if len(lines) > 0 && conv.Name != "" {
+		// Title of the conversation in Claude's resume picker
+		summary := map[string]interface{}{"type": "summary", "summary": conv.Name, "leafUuid": parent}
+		lines = append([]map[string]interface{}{summary}, lines...)
+	}
+	return lines
+}
+
+// writeJSONLines writes values as JSON lines through a temporary file
+func writeJSONLines(path string, lines []map[string]interface{}) error {
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	for _, line := range lines {
+		if err := enc.Encode(line); err != nil {
+			f.Close()
+			os.Remove(tmp)
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// stableUUID derives a UUID from seed, so importing the same export again
+// finds the transcripts it wrote before
+func stableUUID(seed string) string {
+	b := sha256.Sum256([]byte(seed))
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,110 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// WorktreeBranchPrefix is prepended to the branches created for worktree sessions
+const WorktreeBranchPrefix = "asm/"
+
+// branchUnsafe matches the characters left out of worktree branch and directory names
+var branchUnsafe = regexp.MustCompile(`[^a-z0-9._-]+`)
+
+// Worktree is the dedicated git worktree a parallel session works in
+type Worktree struct {
+	Repo   string `json:"repo"`   // Main working tree the worktree was created from
+	Dir    string `json:"dir"`    // Top directory of the worktree
+	Branch string `json:"branch"` // Branch created for the session
+}
+
+// IsGitRepo reports whether path is inside a git working tree
+func IsGitRepo(path string) bool {
+	return commandLine(exec.Command("git", "-C", path, "rev-parse", "--is-inside-work-tree")) == "true"
+}
+
+// worktreeSlug turns a session name into a branch and directory name
+func worktreeSlug(name string) string {
+	slug := strings.Trim(branchUnsafe.ReplaceAllString(strings.ToLower(name), "-"), "-.")
+	if slug == "" {
+		slug = "session"
+	}
+	return slug
+}
+
+// CreateWorktree creates a worktree on a new branch from HEAD of the repo at
+// path, next to the repo in <repo>-worktrees/. It returns the path the session
+// should use (the same subdirectory of the worktree when path is below the top).
+func CreateWorktree(path, name string) (string, *Worktree, error) {
+	out, err := exec.Command("git", "-C", path, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return "", nil, fmt.Errorf("not a git repository: %s", path)
+	}
+	top := strings.TrimSpace(string(out))
+	rel, err := filepath.Rel(top, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		rel = "."
+	}
+
+	// Branch and directory must both be free
+	base := worktreeSlug(name)
+	parent := filepath.Join(filepath.Dir(top), filepath.Base(top)+"-worktrees")
+	slug := base
+	for n := 2; ; n++ {
+		branchTaken := exec.Command("git", "-C", top, "show-ref", "--verify", "--quiet", "refs/heads/"+WorktreeBranchPrefix+slug).Run() == nil
+		_, dirErr := os.Stat(filepath.Join(parent, slug))
+		if !branchTaken && os.IsNotExist(dirErr) {
+			break
+		}
+		slug = fmt.Sprintf("%s-%d", base, n)
+	}
+
+	wt := &Worktree{Repo: top, Dir: filepath.Join(parent, slug), Branch: WorktreeBranchPrefix + slug}
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return "", nil, fmt.Errorf("failed to create worktree directory: %w", err)
+	}
+	if out, err := exec.Command("git", "-C", top, "worktree", "add", "-b", wt.Branch, wt.Dir, "HEAD").CombinedOutput(); err != nil {
+		return "", nil, fmt.Errorf("git worktree add failed: %s", strings.TrimSpace(string(out)))
+	}
+	return filepath.Join(wt.Dir, rel), wt, nil
+}
+
+// RemoveWorktree removes the session's worktree. A worktree with uncommitted
+// changes is kept; the branch always stays so no commits are lost.
+func (i *Instance) RemoveWorktree() error {
+	wt := i.Worktree
+	if wt == nil {
+		return nil
+	}
+	if _, err := os.Stat(wt.Dir); os.IsNotExist(err) {
+		exec.Command("git", "-C", wt.Repo, "worktree", "prune").Run()
+		return nil
+	}
+	if commandLine(exec.Command("git", "-C", wt.Dir, "status", "--porcelain")) != "" {
+		return fmt.Errorf("worktree %s has uncommitted changes - kept", wt.Dir)
+	}
+	if out, err := exec.Command("git", "-C", wt.Repo, "worktree", "remove", wt.Dir).CombinedOutput(); err != nil {
+		return fmt.Errorf("git worktree remove failed: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// RestoreWorktree checks the session's branch out again when its worktree was removed
+func (i *Instance) RestoreWorktree() error {
+	wt := i.Worktree
+	if wt == nil {
+		return nil
+	}
+	if _, err := os.Stat(wt.Dir); err == nil {
+		return nil
+	}
+	exec.Command("git", "-C", wt.Repo, "worktree", "prune").Run()
+	if out, err := exec.Command("git", "-C", wt.Repo, "worktree", "add", wt.Dir, wt.Branch).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to restore worktree: %s", strings.TrimSpace(string(out)))
+	}
+	return nil
+}
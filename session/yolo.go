@@ -0,0 +1,138 @@
+package session
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// YoloDurations are the time limits offered when enabling YOLO mode
+var YoloDurations = []time.Duration{15 * time.Minute, 30 * time.Minute, time.Hour}
+
+// YoloApprovals is how many permission prompts an approval-limited grant answers
+const YoloApprovals = 5
+
+// YoloExpiryGrace is how long after a time limit the TUI waits for the tmux
+// timer to switch auto-yes off before correcting the saved state itself
+const YoloExpiryGrace = 30 * time.Second
+
+// YoloGrant limits YOLO mode of a window. A time limit runs the agent with its
+// auto-yes flag until Until; an approval limit keeps the flag off and lets the
+// manager answer the next Approvals permission prompts with "yes" instead.
+type YoloGrant struct {
+	Until     time.Time `json:"until,omitempty"`     // Auto-yes is switched off at this time
+	Approvals int       `json:"approvals,omitempty"` // Permission prompts still answered with "yes"
+}
+
+// Expired reports whether a time limit has passed
+func (g *YoloGrant) Expired(now time.Time) bool {
+	return g != nil && !g.Until.IsZero() && !now.Before(g.Until)
+}
+
+// Remaining describes what is left of the grant ("12 min left", "3 approvals left")
+func (g *YoloGrant) Remaining(now time.Time) string {
+	switch {
+	case g == nil:
+		return ""
+	case !g.Until.IsZero():
+		left := g.Until.Sub(now)
+		if left < time.Minute {
+			return "<1 min left"
+		}
+		return fmt.Sprintf("%d min left", int(left.Minutes()))
+	case g.Approvals == 1:
+		return "1 approval left"
+	case g.Approvals > 0:
+		return fmt.Sprintf("%d approvals left", g.Approvals)
+	}
+	return ""
+}
+
+// WindowAutoYes reports whether a window (0 = main) runs its agent with the auto-yes flag
+func (i *Instance) WindowAutoYes(window int) bool {
+	if window == 0 {
+		return i.AutoYes
+	}
+	if fw := i.GetFollowedWindow(window); fw != nil {
+		return fw.AutoYes
+	}
+	return false
+}
+
+// WindowYoloGrant returns the limit of a window's YOLO mode (nil = none)
+func (i *Instance) WindowYoloGrant(window int) *YoloGrant {
+	if window == 0 {
+		return i.YoloGrant
+	}
+	if fw := i.GetFollowedWindow(window); fw != nil {
+		return fw.YoloGrant
+	}
+	return nil
+}
+
+// SetWindowYolo sets a window's auto-yes flag and its limit (nil = unlimited).
+// The agent has to be respawned for a flag change to take effect.
+func (i *Instance) SetWindowYolo(window int, autoYes bool, grant *YoloGrant) {
+	if window == 0 {
+		i.AutoYes = autoYes
+		i.YoloGrant = grant
+		return
+	}
+	for idx := range i.FollowedWindows {
+		if i.FollowedWindows[idx].Index == window {
+			i.FollowedWindows[idx].AutoYes = autoYes
+			i.FollowedWindows[idx].YoloGrant = grant
+			return
+		}
+	}
+}
+
+// ScheduleYoloExpiry starts a timer in the tmux server that switches a window's
+// auto-yes off when its time limit ends, so it expires even while the TUI is
+// not running or is attached to a session
+func (i *Instance) ScheduleYoloExpiry(window int) error {
+	grant := i.WindowYoloGrant(window)
+	if grant == nil || grant.Until.IsZero() {
+		return nil
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	wait := int(time.Until(grant.Until).Seconds()) + 1
+	if wait < 1 {
+		wait = 1
+	}
+	command := fmt.Sprintf("sleep %d; %s yolo-expire %s %d", wait, ShellQuote(exe), ShellQuote(i.TmuxSessionName()), window)
+	return i.Tmux().Run("run-shell", "-b", command)
+}
+
+// scheduleYoloExpiries starts the expiry timers of all timed grants (after a start)
+func (i *Instance) scheduleYoloExpiries() {
+	i.ScheduleYoloExpiry(0)
+	for _, fw := range i.FollowedWindows {
+		i.ScheduleYoloExpiry(fw.Index)
+	}
+}
+
+// YoloLogPath returns the path of the log of YOLO mode changes
+func YoloLogPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "agent-session-manager", "yolo.log")
+}
+
+// LogYolo appends a YOLO mode change of a window to the YOLO log
+func (i *Instance) LogYolo(window int, change string) error {
+	label := i.Name
+	if fw := i.GetFollowedWindow(window); window != 0 && fw != nil {
+		label += " › " + fw.Name
+	}
+	f, err := os.OpenFile(YoloLogPath(), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = fmt.Fprintf(f, "%s  %s  %s\n", time.Now().Format("2006-01-02 15:04:05"), label, change)
+	return err
+}
@@ -0,0 +1,125 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// aboutRows returns the label/value rows of the about dialog
+func aboutRows(inst *session.Instance) [][2]string {
+	rows := [][2]string{
+		{"Session", inst.Name},
+		{"Agent", agentLabel(inst.Agent, inst.CustomCommand)},
+		{"Path", inst.Path},
+	}
+	env := inst.Environment
+	if env == nil {
+		return rows
+	}
+
+	git := "-"
+	if env.GitCommit != "" {
+		git = env.GitBranch + " @ " + shortSHA(env.GitCommit)
+		if env.GitDirty {
+			git += " (uncommitted changes)"
+		}
+	}
+	rows = append(rows,
+		[2]string{"Started", env.CapturedAt.Local().Format("2006-01-02 15:04") + " (" + formatTimeAgo(env.CapturedAt) + ")"},
+		[2]string{"Agent version", orDash(env.AgentVersion)},
+		[2]string{"Model", orDash(env.Model)},
+		[2]string{"Git", git},
+		[2]string{"OS", env.OS},
+		[2]string{"Shell", orDash(env.Shell)},
+		[2]string{"tmux", orDash(env.TmuxVersion)},
+		[2]string{"Manager", orDash(env.ManagerVersion)},
+	)
+	for _, name := range env.EnvNames() {
+		rows = append(rows, [2]string{name, env.Env[name]})
+	}
+	return rows
+}
+
+// shortSHA returns the abbreviated form of a commit hash
+func shortSHA(sha string) string {
+	if len(sha) > 10 {
+		return sha[:10]
+	}
+	return sha
+}
+
+// orDash returns s, or "-" when it is empty
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// aboutText returns the about dialog as plain text (for the clipboard)
+func aboutText(inst *session.Instance) string {
+	var b strings.Builder
+	for _, row := range aboutRows(inst) {
+		fmt.Fprintf(&b, "%-14s %s\n", row[0]+":", row[1])
+	}
+	return b.String()
+}
+
+// handleShowAbout opens the about dialog of a session
+func (m *Model) handleShowAbout(inst *session.Instance) {
+	if inst == nil {
+		return
+	}
+	m.aboutTarget = inst
+	m.state = stateAbout
+}
+
+// handleAboutKeys handles keyboard input in the about dialog
+func (m Model) handleAboutKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "enter", "A":
+		m.aboutTarget = nil
+		m.state = stateList
+	case "c":
+		method := m.copyToClipboard(aboutText(m.aboutTarget))
+		m.notifySuccess("Session details copied via %s", method)
+	}
+	return m, nil
+}
+
+// aboutView renders the about dialog as an overlay
+func (m Model) aboutView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 80
+	if m.width > 0 && m.width-4 < boxWidth {
+		boxWidth = m.width - 4
+	}
+	valueWidth := boxWidth - 24
+	if valueWidth < 10 {
+		valueWidth = 10
+	}
+
+	inst := m.aboutTarget
+	for i, row := range aboutRows(inst) {
+		if i == 3 {
+			boxContent.WriteString("\n")
+		}
+		boxContent.WriteString(fmt.Sprintf("  %s %s\n", dimStyle.Render(fmt.Sprintf("%-18s", truncateRunes(row[0], 18))), truncateRunes(row[1], valueWidth)))
+	}
+	if inst.Environment == nil {
+		boxContent.WriteString("\n")
+		boxContent.WriteString(dimStyle.Render("  No snapshot yet - it is recorded the next time the session starts."))
+		boxContent.WriteString("\n")
+	}
+
+	boxContent.WriteString("\n")
+	boxContent.WriteString(helpStyle.Render("  c: copy  esc: close"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" About this session ", boxContent.String(), boxWidth, ColorAccent)
+}
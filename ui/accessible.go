@@ -0,0 +1,163 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/izll/agent-session-manager/session"
+)
+
+// SetAccessible switches to the linear screen-reader mode: plain lines without
+// colors, boxes or panes, describing only the selected item
+func (m *Model) SetAccessible(on bool) {
+	m.accessible = on
+}
+
+// isBoxDrawing reports whether a rune only draws lines, boxes or bars
+func isBoxDrawing(r rune) bool {
+	return r >= 0x2500 && r <= 0x259F // Box Drawing and Block Elements
+}
+
+// linearize turns a rendered view into plain text lines: no colors, no box
+// drawing, no padding and no empty lines
+func linearize(view string) string {
+	var lines []string
+	for _, line := range strings.Split(stripANSI(view), "\n") {
+		line = strings.Map(func(r rune) rune {
+			if isBoxDrawing(r) {
+				return ' '
+			}
+			return r
+		}, line)
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// linearView renders the current state for screen readers and braille displays
+func (m Model) linearView() string {
+	if m.state == stateList {
+		return m.linearListView()
+	}
+	// Dialogs render without the list behind them (see renderOverlayDialogWithBackground)
+	return linearize(m.render())
+}
+
+// linearListView describes the project and the selected list row as plain lines.
+// Nothing in it changes on its own while the selection stays, except status.
+func (m Model) linearListView() string {
+	var lines []string
+	project := "default"
+	if m.activeProject != nil {
+		project = m.activeProject.Name
+	}
+	running, waiting := 0, 0
+	for _, inst := range m.instances {
+		if inst.Status == session.StatusRunning {
+			running++
+		}
+		if m.activityState[inst.ID] == session.ActivityWaiting {
+			waiting++
+		}
+	}
+	lines = append(lines, fmt.Sprintf("Project %s: %d sessions, %d running, %d waiting", project, len(m.instances), running, waiting))
+
+	rows := m.listLen()
+	switch {
+	case rows == 0:
+		lines = append(lines, fmt.Sprintf("No sessions. Press %s to create one.", m.keys.help("new_session")))
+	case m.getSelectedInstance() != nil:
+		lines = append(lines, m.linearSession(m.getSelectedInstance(), rows)...)
+	case m.getSelectedGroup() != nil:
+		group := m.getSelectedGroup()
+		count := len(m.getSessionsInGroup(group.ID))
+		state := "expanded"
+		if group.Collapsed {
+			state = "collapsed"
+		}
+		lines = append(lines, fmt.Sprintf("Row %d of %d: group %s, %d sessions, %s", m.cursor+1, rows, group.Name, count, state))
+	default:
+		lines = append(lines, fmt.Sprintf("Row %d of %d: separator", m.cursor+1, rows))
+	}
+
+	if m.toast != nil {
+		lines = append(lines, "Message: "+m.toast.text)
+	}
+	lines = append(lines, fmt.Sprintf("Keys: up/down select, enter attach, %s prompt, %s help, %s quit",
+		m.keys.help("prompt"), m.keys.help("help"), m.keys.help("quit")))
+	return strings.Join(lines, "\n")
+}
+
+// linearSession describes a session row, one fact per line
+func (m Model) linearSession(inst *session.Instance, rows int) []string {
+	lines := []string{fmt.Sprintf("Row %d of %d: session %s", m.cursor+1, rows, inst.Name)}
+
+	status := "stopped"
+	if exited := m.exitedSummary(inst); exited != "" {
+		status = "running, " + exited + ", " + m.keys.help("start") + " respawns it"
+	} else if inst.Status == session.StatusRunning {
+		switch m.activityState[inst.ID] {
+		case session.ActivityBusy:
+			status = "running, busy"
+			if net := m.netLabel(inst); net != "" {
+				status += ", " + net
+			}
+		case session.ActivityWaiting:
+			status = "running, waiting for input"
+		default:
+			status = "running, idle"
+		}
+	}
+	if m.isBusy(inst) {
+		status += ", " + m.pendingOps[inst.ID]
+	}
+	lines = append(lines, "Status: "+status)
+
+	agent := agentLabel(inst.Agent, inst.CustomCommand)
+	if yolo := strings.TrimPrefix(yoloLabel(inst, 0), " ! "); yolo != "" {
+		agent += ", " + yolo
+	}
+	lines = append(lines, "Agent: "+agent)
+	if inst.GroupID != "" {
+		for _, g := range m.groups {
+			if g.ID == inst.GroupID {
+				lines = append(lines, "Group: "+g.Name)
+			}
+		}
+	}
+	lines = append(lines, "Path: "+inst.Path)
+	if branch := m.sessionBranch(inst); branch != "" {
+		lines = append(lines, "Branch: "+branch)
+	}
+	if len(inst.FollowedWindows) > 0 {
+		lines = append(lines, fmt.Sprintf("Tabs: %d", len(inst.FollowedWindows)+1))
+	}
+
+	if q := m.pendingQuestions[inst.ID]; q != nil {
+		var options []string
+		for _, o := range q.Options {
+			options = append(options, o.Key+" "+o.Label)
+		}
+		lines = append(lines, "Question: "+q.Question, "Options: "+strings.Join(options, ", "))
+	} else if inst.Status == session.StatusRunning && m.activityState[inst.ID] != session.ActivityBusy {
+		// Busy output changes constantly - only read it once the agent settles
+		if last := linearize(m.lastLines[inst.ID]); last != "" {
+			lines = append(lines, "Last output: "+last)
+		}
+	}
+	if restart := m.restartSummary(inst); restart != "" {
+		lines = append(lines, "Restart: "+restart)
+	}
+	if idle := m.idleStopSummary(inst); idle != "" {
+		lines = append(lines, "Idle stop: "+idle)
+	}
+	if usage := m.usageSummary(inst); usage != "" {
+		lines = append(lines, "Cost: "+usage)
+	}
+	if notes := strings.TrimSpace(inst.Notes); notes != "" {
+		lines = append(lines, "Notes: "+strings.SplitN(notes, "\n", 2)[0])
+	}
+	return lines
+}
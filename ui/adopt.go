@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// openAdoptDialog lists the tmux sessions that can be brought under management
+func (m *Model) openAdoptDialog() {
+	sessions, err := m.storage.ForeignSessions()
+	if err != nil {
+		m.notify(toastError, "Cannot list tmux sessions: %v", err)
+		return
+	}
+	if len(sessions) == 0 {
+		m.notifyInfo("No other tmux sessions to import")
+		return
+	}
+	m.adoptSessions = sessions
+	m.adoptSelected = make(map[int]bool)
+	m.adoptAsCustom = make(map[int]bool)
+	for i, s := range sessions {
+		m.adoptAsCustom[i] = !s.IsShell()
+	}
+	m.adoptCursor = 0
+	m.state = stateAdopt
+}
+
+// adoptCommand returns the command an adopted session runs when restarted
+func (m *Model) adoptCommand(i int) string {
+	if m.adoptAsCustom[i] {
+		return m.adoptSessions[i].Command
+	}
+	return session.UserShell()
+}
+
+// adoptSelectedSessions imports the checked sessions (or the one under the cursor)
+func (m *Model) adoptSelectedSessions() {
+	picked := make([]int, 0, len(m.adoptSessions))
+	for i := range m.adoptSessions {
+		if m.adoptSelected[i] {
+			picked = append(picked, i)
+		}
+	}
+	if len(picked) == 0 {
+		picked = append(picked, m.adoptCursor)
+	}
+
+	groupID := m.getCurrentGroupID()
+	var adopted []string
+	for _, i := range picked {
+		f := m.adoptSessions[i]
+		inst, err := session.AdoptSession(f, m.adoptCommand(i))
+		if err == nil {
+			inst.GroupID = groupID
+			err = m.addNewInstance(inst)
+		}
+		if err != nil {
+			m.notify(toastError, "Failed to import '%s': %v", f.Name, err)
+			continue
+		}
+		adopted = append(adopted, f.Name)
+	}
+	m.adoptSessions = nil
+	m.state = stateList
+	if len(adopted) > 0 {
+		m.notifySuccess("Imported %s", strings.Join(adopted, ", "))
+	}
+}
+
+// handleAdoptKeys handles keyboard input in the tmux session import dialog
+func (m Model) handleAdoptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.adoptSessions = nil
+		m.state = stateList
+
+	case "up", "k":
+		if m.adoptCursor > 0 {
+			m.adoptCursor--
+		}
+
+	case "down", "j":
+		if m.adoptCursor < len(m.adoptSessions)-1 {
+			m.adoptCursor++
+		}
+
+	case " ":
+		m.adoptSelected[m.adoptCursor] = !m.adoptSelected[m.adoptCursor]
+
+	case "a":
+		// Select all, or none when all are selected
+		all := true
+		for i := range m.adoptSessions {
+			all = all && m.adoptSelected[i]
+		}
+		for i := range m.adoptSessions {
+			m.adoptSelected[i] = !all
+		}
+
+	case "t":
+		// Shell panes can only be imported as terminals
+		if !m.adoptSessions[m.adoptCursor].IsShell() {
+			m.adoptAsCustom[m.adoptCursor] = !m.adoptAsCustom[m.adoptCursor]
+		}
+
+	case "enter":
+		m.adoptSelectedSessions()
+	}
+	return m, nil
+}
+
+// adoptView renders the tmux session import dialog as an overlay
+func (m Model) adoptView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 96
+	if m.width > 0 && m.width-4 < boxWidth {
+		boxWidth = m.width - 4
+	}
+	pathWidth := boxWidth - 62
+	if pathWidth < 10 {
+		pathWidth = 10
+	}
+
+	header := fmt.Sprintf("      %-20s %4s  %-24s %s", "tmux session", "Win", "Import as", "Path")
+	boxContent.WriteString(dimStyle.Render(header))
+	boxContent.WriteString("\n")
+
+	for i, f := range m.adoptSessions {
+		check := "[ ]"
+		if m.adoptSelected[i] {
+			check = "[" + runningStyle.Render("x") + "]"
+		}
+		kind := "Terminal (" + session.UserShell() + ")"
+		if m.adoptAsCustom[i] {
+			kind = "Custom (" + f.Command + ")"
+		}
+		name := truncateRunes(f.Name, 20)
+		if f.Attached {
+			name = truncateRunes(f.Name, 18) + " ●"
+		}
+		line := fmt.Sprintf("%s %-20s %4d  %-24s %s", check, name, f.Windows, truncateRunes(kind, 24), dimStyle.Render(truncateRunes(f.Path, pathWidth)))
+		if i == m.adoptCursor {
+			boxContent.WriteString(" ❯" + line + "\n")
+		} else {
+			boxContent.WriteString("  " + line + "\n")
+		}
+	}
+
+	boxContent.WriteString("\n")
+	boxContent.WriteString(dimStyle.Render("  The tmux session keeps running; it is renamed and its other windows become terminal tabs."))
+	boxContent.WriteString("\n\n")
+	boxContent.WriteString(helpStyle.Render("  space: select  a: all  t: terminal/custom  enter: import  esc: cancel"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Import tmux Sessions ", boxContent.String(), boxWidth, ColorAccent)
+}
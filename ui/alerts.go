@@ -0,0 +1,259 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// alertEventLabels describes the alert events in the sounds dialog
+var alertEventLabels = map[session.AlertEvent]string{
+	session.AlertWaiting:  "Waiting for input",
+	session.AlertFinished: "Task finished",
+	session.AlertError:    "Error detected",
+}
+
+// playAlert plays the sound a session has configured for an event
+func (m *Model) playAlert(inst *session.Instance, event session.AlertEvent) {
+	sound := inst.Alert(event)
+	switch sound {
+	case "":
+		return
+	case session.AlertBell:
+		m.ringBell()
+	default:
+		if err := session.PlaySound(sound); err != nil {
+			// Fall back to the bell so the alert isn't lost
+			m.ringBell()
+		}
+	}
+}
+
+// handleShowAlerts opens the sound alerts dialog for the selected session
+func (m *Model) handleShowAlerts() {
+	if m.getSelectedInstance() == nil {
+		return
+	}
+	m.err = nil
+	m.alertCursor = 0
+	m.alertEditing = false
+	m.state = stateAlerts
+}
+
+// handleAlertsKeys handles keyboard input in the sound alerts dialog
+func (m Model) handleAlertsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		m.state = stateList
+		return m, nil
+	}
+	event := session.AlertEvents[m.alertCursor]
+
+	// Typing a sound file path for the event under the cursor
+	if m.alertEditing {
+		switch msg.String() {
+		case "esc":
+			m.err = nil
+			m.alertEditing = false
+			m.alertInput.Blur()
+			return m, nil
+		case "enter":
+			path := strings.TrimSpace(m.alertInput.Value())
+			if err := session.ValidateSoundFile(path); err != nil {
+				m.err = err
+				return m, nil
+			}
+			m.err = nil
+			m.alertEditing = false
+			m.alertInput.Blur()
+			inst.SetAlert(event, path)
+			m.storage.UpdateInstance(inst)
+			return m, nil
+		}
+		m.err = nil
+		var cmd tea.Cmd
+		m.alertInput, cmd = m.alertInput.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "esc", "q", "B":
+		m.err = nil
+		m.state = stateList
+	case "up", "k":
+		if m.alertCursor > 0 {
+			m.alertCursor--
+		}
+	case "down", "j":
+		if m.alertCursor < len(session.AlertEvents)-1 {
+			m.alertCursor++
+		}
+	case " ", "enter":
+		// Toggle between silent and the terminal bell
+		if inst.Alert(event) == "" {
+			inst.SetAlert(event, session.AlertBell)
+		} else {
+			inst.SetAlert(event, "")
+		}
+		m.storage.UpdateInstance(inst)
+	case "f":
+		current := inst.Alert(event)
+		if current == session.AlertBell {
+			current = ""
+		}
+		m.alertInput.SetValue(current)
+		m.alertInput.CursorEnd()
+		m.alertInput.Focus()
+		m.alertEditing = true
+		return m, textinput.Blink
+	case "t":
+		if inst.Alert(event) == "" {
+			m.notifyInfo("No sound set for %s", alertEventLabels[event])
+			return m, nil
+		}
+		m.playAlert(inst, event)
+	}
+	return m, nil
+}
+
+// alertsView renders the sound alerts dialog as an overlay
+func (m Model) alertsView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return m.listView()
+	}
+	boxContent.WriteString(fmt.Sprintf("  Sounds for '%s':\n\n", truncateRunes(inst.Name, 40)))
+
+	boxWidth := 70
+	offStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorDarkGray))
+	for idx, event := range session.AlertEvents {
+		prefix := "  "
+		if idx == m.alertCursor {
+			prefix = " ❯"
+		}
+		sound := inst.Alert(event)
+		value := offStyle.Render("off")
+		switch sound {
+		case "":
+		case session.AlertBell:
+			value = waitingStyle.Render("terminal bell")
+		default:
+			value = projectNameStyle.Render(truncateRunes(sound, boxWidth-30))
+		}
+		boxContent.WriteString(fmt.Sprintf("%s %-20s %s\n", prefix, alertEventLabels[event], value))
+	}
+
+	if m.alertEditing {
+		boxContent.WriteString("\n")
+		boxContent.WriteString("  " + m.alertInput.View() + "\n")
+		boxContent.WriteString(dimStyle.Render("  Sound file (wav/ogg/mp3), empty = off"))
+		boxContent.WriteString("\n")
+	}
+
+	if m.err != nil {
+		boxContent.WriteString("\n")
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555")).Bold(true)
+		boxContent.WriteString(errStyle.Render(fmt.Sprintf("  ⚠ %v", m.err)))
+		boxContent.WriteString("\n")
+	}
+
+	boxContent.WriteString("\n")
+	if m.alertEditing {
+		boxContent.WriteString(helpStyle.Render("  enter: save  esc: cancel"))
+	} else {
+		boxContent.WriteString(helpStyle.Render("  space: off/bell  f: sound file  t: test  esc: close"))
+	}
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Sound Alerts ", boxContent.String(), boxWidth, ColorAccent)
+}
+
+// attachedAlertInterval is how often other sessions are checked while attached
+const attachedAlertInterval = 2 * time.Second
+
+// watchWhileAttached announces windows of other sessions that start waiting for
+// input while the TUI is suspended for an attach. The returned func stops it.
+func (m *Model) watchWhileAttached(attached *session.Instance) func() {
+	alerts := m.config.Attached
+	if !alerts.Enabled() {
+		return func() {}
+	}
+	// Start from the states the list last saw, so a window that went busy
+	// before attaching still alerts when it stops
+	prev := make(map[string]session.SessionActivity)
+	var watched []*session.Instance
+	for _, inst := range m.instances {
+		if inst.ID == attached.ID || inst.Status != session.StatusRunning {
+			continue
+		}
+		watched = append(watched, inst)
+		for idx, activity := range m.windowActivityState[inst.ID] {
+			prev[fmt.Sprintf("%s:%d", inst.ID, idx)] = activity
+		}
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(attachedAlertInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+			}
+			for _, inst := range watched {
+				windows := []int{0}
+				for _, fw := range inst.FollowedWindows {
+					windows = append(windows, fw.Index)
+				}
+				for _, idx := range windows {
+					key := fmt.Sprintf("%s:%d", inst.ID, idx)
+					activity := inst.DetectActivityForWindow(idx)
+					if activity == session.ActivityWaiting && prev[key] != session.ActivityWaiting {
+						announceWaiting(alerts, attached, inst, idx)
+					}
+					prev[key] = activity
+				}
+			}
+		}
+	}()
+	// Wait for the goroutine so it no longer reads the instances the UI updates
+	return func() {
+		close(stop)
+		<-done
+	}
+}
+
+// announceWaiting plays the attached alerts for a window that needs input
+func announceWaiting(alerts session.AttachedAlerts, attached, inst *session.Instance, windowIdx int) {
+	if alerts.Message {
+		name := inst.Name
+		for _, fw := range inst.FollowedWindows {
+			if fw.Index == windowIdx {
+				name += " › " + fw.Name
+			}
+		}
+		// '#' would start a tmux format
+		text := strings.ReplaceAll("⏳ "+name+" is waiting for input", "#", "##")
+		attached.Tmux().Run("display-message", "-t", attached.TmuxSessionName(), text)
+	}
+	switch {
+	case alerts.Sound != "":
+		if err := session.PlaySound(alerts.Sound); err != nil && alerts.Bell {
+			terminalBell()
+		}
+	case alerts.Bell:
+		terminalBell()
+	}
+}
@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"hash/fnv"
+
+	"github.com/izll/agent-session-manager/session"
+)
+
+// Auto color modes for new sessions and groups
+const (
+	AutoColorOff        = ""            // Keep the default look
+	AutoColorRoundRobin = "round-robin" // Least used color of the palette, in palette order
+	AutoColorHash       = "hash"        // Color derived from the session path / group name
+)
+
+// autoColorModes is the cycle order of the auto color setting
+var autoColorModes = []string{AutoColorOff, AutoColorRoundRobin, AutoColorHash}
+
+// autoColors are the distinct foreground colors handed out automatically
+var autoColors = []string{
+	"#FF6B6B", // red
+	"#FFA500", // orange
+	"#FFD93D", // yellow
+	"#ADFF2F", // lime
+	"#6BCB77", // green
+	"#20B2AA", // teal
+	"#4DD0E1", // cyan
+	"#6C9EFF", // blue
+	"#7B68EE", // indigo
+	"#B388FF", // purple
+	"#FF00FF", // magenta
+	"#FF8FAB", // pink
+	"#FF7F50", // coral
+	"#FFD700", // gold
+}
+
+// pickAutoColor chooses a color for a new item: by hash of key, or the least
+// used palette color among the colors already in use
+func pickAutoColor(mode, key string, used []string) string {
+	switch mode {
+	case AutoColorHash:
+		h := fnv.New32a()
+		h.Write([]byte(key))
+		return autoColors[h.Sum32()%uint32(len(autoColors))]
+	case AutoColorRoundRobin:
+		counts := make(map[string]int)
+		for _, c := range used {
+			counts[c]++
+		}
+		best := autoColors[0]
+		for _, c := range autoColors[1:] {
+			if counts[c] < counts[best] {
+				best = c
+			}
+		}
+		return best
+	}
+	return ""
+}
+
+// autoColorInstance gives a new session a color when auto color is on and it has none
+func (m *Model) autoColorInstance(inst *session.Instance) {
+	if m.autoColor == AutoColorOff || inst.Color != "" || inst.BgColor != "" {
+		return
+	}
+	used := make([]string, 0, len(m.instances))
+	for _, other := range m.instances {
+		used = append(used, other.Color)
+	}
+	inst.Color = pickAutoColor(m.autoColor, session.PathKey(inst.Path), used)
+}
+
+// autoColorGroup gives a new group a color when auto color is on
+func (m *Model) autoColorGroup(group *session.Group) {
+	if m.autoColor == AutoColorOff || group.Color != "" || group.BgColor != "" {
+		return
+	}
+	used := make([]string, 0, len(m.groups))
+	for _, other := range m.groups {
+		used = append(used, other.Color)
+	}
+	group.Color = pickAutoColor(m.autoColor, group.Name, used)
+}
+
+// cycleAutoColor switches to the next auto color mode
+func (m *Model) cycleAutoColor() {
+	next := 0
+	for i, mode := range autoColorModes {
+		if mode == m.autoColor {
+			next = (i + 1) % len(autoColorModes)
+			break
+		}
+	}
+	m.autoColor = autoColorModes[next]
+	m.saveSettings()
+	m.notifyInfo("Auto color for new sessions and groups: %s", autoColorLabel(m.autoColor))
+}
+
+// autoColorLabel describes an auto color mode
+func autoColorLabel(mode string) string {
+	if mode == AutoColorOff {
+		return "off"
+	}
+	return mode
+}
@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// benchWords make up the generated session names and output
+var benchWords = strings.Fields("refactor the parser add tests fix flaky login handler update docs migrate database " +
+	"schema review pull request bump dependencies cache invalidation retry logic rate limit error budget")
+
+// benchModel builds a list view with n generated sessions in groups. It runs
+// against a fake tmux server and an empty config directory, so real sessions
+// are never touched.
+func benchModel(b *testing.B, n int) Model {
+	b.Helper()
+	b.Setenv("HOME", b.TempDir())
+	tmux := session.Tmux
+	session.Tmux = session.NewFakeTmux()
+	b.Cleanup(func() { session.Tmux = tmux })
+
+	m, err := NewModel()
+	if err != nil {
+		b.Fatal(err)
+	}
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 200, Height: 50})
+	m = updated.(Model)
+	m.state = stateList
+
+	activities := []session.SessionActivity{session.ActivityIdle, session.ActivityBusy, session.ActivityWaiting}
+	for g := 0; g < n/20+1; g++ {
+		m.groups = append(m.groups, &session.Group{ID: fmt.Sprintf("group-%d", g), Name: fmt.Sprintf("Group %d", g)})
+	}
+	for i := 0; i < n; i++ {
+		inst := &session.Instance{
+			ID:        fmt.Sprintf("bench-%d", i),
+			Name:      fmt.Sprintf("%s-%d", benchWords[i%len(benchWords)], i),
+			Path:      fmt.Sprintf("/tmp/bench/%s", benchWords[(i*7)%len(benchWords)]),
+			Agent:     session.AgentClaude,
+			Status:    session.StatusStopped,
+			CreatedAt: time.Now().Add(-time.Duration(i) * time.Minute),
+			Favorite:  i%25 == 0,
+			Notes:     "#bench " + benchWords[(i*3)%len(benchWords)],
+		}
+		if i%4 != 0 {
+			inst.GroupID = m.groups[i%len(m.groups)].ID
+		}
+		if i%2 == 0 {
+			// Running on the fake tmux server
+			inst.Status = session.StatusRunning
+			session.Tmux.Run("new-session", "-d", "-s", inst.TmuxSessionName(), "-c", inst.Path, "claude")
+			m.activityState[inst.ID] = activities[i%len(activities)]
+		}
+		m.lastLines[inst.ID] = strings.Join(benchWords[i%5:i%5+8], " ")
+		m.instances = append(m.instances, inst)
+	}
+	m.buildVisibleItems()
+
+	var preview []string
+	for i := 0; i < PreviewLineCount; i++ {
+		preview = append(preview, fmt.Sprintf("\x1b[32m●\x1b[0m %s", strings.Join(benchWords[i%10:i%10+6], " ")))
+	}
+	m.preview = strings.Join(preview, "\n")
+	return m
+}
+
+// BenchmarkView times rendering the list view with 10, 100 and 1000 sessions;
+// a render slower than FrameBudget fails the benchmark
+func BenchmarkView(b *testing.B) {
+	for _, n := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("%d-sessions", n), func(b *testing.B) {
+			m := benchModel(b, n)
+			m.View() // Warm-up frame: the first render fills the style caches
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				m.View()
+			}
+			// The N=1 probe run is too noisy to hold to the budget
+			if perOp := b.Elapsed() / time.Duration(b.N); b.N > 1 && perOp > FrameBudget {
+				b.Errorf("%s per frame, over the %s frame budget", perOp, FrameBudget)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// BranchInterval is how often the git branches of the session paths are polled
+const BranchInterval = 5 * time.Second
+
+// branchesMsg carries freshly polled git branches (path -> branch, "" = not a repo)
+type branchesMsg map[string]string
+
+// pollBranches reads the branch of every session path in the background
+func (m *Model) pollBranches() tea.Cmd {
+	if m.branchPolling || len(m.instances) == 0 || time.Since(m.branchesAt) < BranchInterval {
+		return nil
+	}
+	m.branchPolling = true
+	m.branchesAt = time.Now()
+	paths := make(map[string]bool, len(m.instances))
+	for _, inst := range m.instances {
+		if !inst.IsRemote() { // The path is on another host
+			paths[inst.Path] = true
+		}
+	}
+	return func() tea.Msg {
+		branches := make(branchesMsg, len(paths))
+		for path := range paths {
+			branches[path] = session.CurrentBranch(path)
+		}
+		return branches
+	}
+}
+
+// branchBadge returns the branch label of a session row ("" outside git)
+func (m Model) branchBadge(inst *session.Instance) string {
+	if inst.IsRemote() {
+		return ""
+	}
+	branch := m.branches[inst.Path]
+	if branch == "" {
+		return ""
+	}
+	return " ⎇ " + truncateRunes(branch, 12)
+}
+
+// sessionBranch returns the polled branch of a session, or its worktree branch before the first poll
+func (m Model) sessionBranch(inst *session.Instance) string {
+	if branch := m.branches[inst.Path]; branch != "" {
+		return branch
+	}
+	if inst.Worktree != nil {
+		return inst.Worktree.Branch
+	}
+	return ""
+}
+
+// openBranchPicker lists the local branches of the selected session's repo
+func (m *Model) openBranchPicker() {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return
+	}
+	if inst.IsRemote() {
+		m.notifyWarn("Branches of sessions on %s can't be switched from here", inst.Host)
+		return
+	}
+	if inst.Status == session.StatusRunning {
+		m.notifyWarn("Stop '%s' first - its agent works in this checkout", inst.Name)
+		return
+	}
+	branches, err := session.Branches(inst.Path)
+	if err != nil || len(branches) == 0 {
+		m.notifyWarn("'%s' is not in a git repository", inst.Path)
+		return
+	}
+	m.branchTarget = inst
+	m.branchList = branches
+	m.branchCursor = 0
+	current := session.CurrentBranch(inst.Path)
+	if m.branches == nil {
+		m.branches = make(map[string]string)
+	}
+	m.branches[inst.Path] = current
+	for i, b := range branches {
+		if b == current {
+			m.branchCursor = i
+		}
+	}
+	m.state = stateBranchPicker
+}
+
+// sharedCheckout returns how many other sessions work in the target's directory, and how many of them run
+func (m Model) sharedCheckout() (others, running int) {
+	for _, inst := range m.instances {
+		if inst.ID == m.branchTarget.ID || inst.Path != m.branchTarget.Path {
+			continue
+		}
+		others++
+		if inst.Status == session.StatusRunning {
+			running++
+		}
+	}
+	return others, running
+}
+
+// checkoutPickedBranch checks out the branch under the cursor, then starts the session if asked
+func (m *Model) checkoutPickedBranch(start bool) tea.Cmd {
+	inst := m.branchTarget
+	branch := m.branchList[m.branchCursor]
+	m.branchTarget = nil
+	m.state = stateList
+
+	if branch != session.CurrentBranch(inst.Path) {
+		if err := session.CheckoutBranch(inst.Path, branch); err != nil {
+			m.showError(err)
+			return nil
+		}
+		if m.branches == nil {
+			m.branches = make(map[string]string)
+		}
+		m.branches[inst.Path] = branch
+		m.notifySuccess("Checked out %s for '%s'", branch, inst.Name)
+	}
+	if !start {
+		return nil
+	}
+	if err := session.CheckAgentCommand(inst); err != nil {
+		m.showError(err)
+		return nil
+	}
+	return m.startSessionAsync(inst, "", false)
+}
+
+// handleBranchPickerKeys handles keyboard input in the branch picker
+func (m Model) handleBranchPickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.branchCursor > 0 {
+			m.branchCursor--
+		}
+	case "down", "j":
+		if m.branchCursor < len(m.branchList)-1 {
+			m.branchCursor++
+		}
+	case "enter":
+		return m, m.checkoutPickedBranch(false)
+	case "s":
+		return m, m.checkoutPickedBranch(true)
+	case "esc":
+		m.branchTarget = nil
+		m.state = stateList
+	}
+	return m, nil
+}
+
+// branchPickerView renders the branch picker as an overlay
+func (m Model) branchPickerView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 60
+	if m.width > 0 && m.width-4 < boxWidth {
+		boxWidth = m.width - 4
+	}
+
+	inst := m.branchTarget
+	if inst == nil {
+		return ""
+	}
+	current := m.branches[inst.Path]
+	boxContent.WriteString(fmt.Sprintf("  Session: %s\n", inst.Name))
+	boxContent.WriteString(dimStyle.Render("  " + truncateRunes(inst.Path, boxWidth-6)))
+	boxContent.WriteString("\n\n")
+
+	const maxRows = 12
+	start := 0
+	if m.branchCursor >= maxRows {
+		start = m.branchCursor - maxRows + 1
+	}
+	for i := start; i < len(m.branchList) && i < start+maxRows; i++ {
+		name := truncateRunes(m.branchList[i], boxWidth-20)
+		if m.branchList[i] == current {
+			name += dimStyle.Render(" (current)")
+		}
+		if i == m.branchCursor {
+			boxContent.WriteString("  ❯ ⎇ " + name + "\n")
+		} else {
+			boxContent.WriteString("    ⎇ " + name + "\n")
+		}
+	}
+	if len(m.branchList) > maxRows {
+		boxContent.WriteString(dimStyle.Render(fmt.Sprintf("    %d branches", len(m.branchList))))
+		boxContent.WriteString("\n")
+	}
+
+	if others, running := m.sharedCheckout(); others > 0 {
+		boxContent.WriteString("\n")
+		boxContent.WriteString(dimStyle.Render(fmt.Sprintf("  Also switches %d other session(s) here (%d running)", others, running)))
+		boxContent.WriteString("\n")
+	}
+
+	boxContent.WriteString("\n")
+	boxContent.WriteString(helpStyle.Render("  enter: checkout  s: checkout & start  esc: cancel"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Switch Branch ", boxContent.String(), boxWidth, ColorAccent)
+}
@@ -0,0 +1,210 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// churnTopRows is how many of the busiest sessions the churn view highlights
+const churnTopRows = 3
+
+// churnLoadedMsg is sent when the diff stats of the running sessions are counted
+type churnLoadedMsg struct {
+	churn map[string]session.Churn // Instance ID -> churn
+}
+
+// churnCmd counts the diff stats of sessions off the UI loop (one git diff each)
+func churnCmd(instances []*session.Instance) tea.Cmd {
+	return func() tea.Msg {
+		churn := make(map[string]session.Churn, len(instances))
+		for _, inst := range instances {
+			churn[inst.ID] = inst.Churn()
+		}
+		return churnLoadedMsg{churn: churn}
+	}
+}
+
+// handleShowChurn opens the diff stats view for the selected group (the group
+// row or the selected session's group), or the whole project outside groups
+func (m *Model) handleShowChurn() tea.Cmd {
+	m.churnGroup = m.getSelectedGroup()
+	if inst := m.getSelectedInstance(); inst != nil && inst.GroupID != "" {
+		for _, g := range m.groups {
+			if g.ID == inst.GroupID {
+				m.churnGroup = g
+			}
+		}
+	}
+	m.churnAll = m.churnGroup == nil
+	m.churnCursor = 0
+	m.state = stateChurn
+	return m.loadChurn()
+}
+
+// loadChurn starts counting the diff stats of all running sessions of the project
+func (m *Model) loadChurn() tea.Cmd {
+	var running []*session.Instance
+	for _, inst := range m.instances {
+		if inst.Status == session.StatusRunning {
+			running = append(running, inst)
+		}
+	}
+	m.churn = nil
+	m.churnLoading = true
+	return churnCmd(running)
+}
+
+// churnScope returns the running sessions the churn view sums up
+func (m Model) churnScope() []*session.Instance {
+	instances := m.instances
+	if !m.churnAll && m.churnGroup != nil {
+		instances = m.getSessionsInGroup(m.churnGroup.ID)
+	}
+	var scope []*session.Instance
+	for _, inst := range instances {
+		if inst.Status == session.StatusRunning {
+			scope = append(scope, inst)
+		}
+	}
+	return scope
+}
+
+// churnRows returns the counted sessions of the scope, most lines changed first
+func (m Model) churnRows() []*session.Instance {
+	var rows []*session.Instance
+	for _, inst := range m.churnScope() {
+		if _, ok := m.churn[inst.ID]; ok {
+			rows = append(rows, inst)
+		}
+	}
+	sort.SliceStable(rows, func(a, b int) bool {
+		ca, cb := m.churn[rows[a].ID], m.churn[rows[b].ID]
+		if (ca.Err == nil) != (cb.Err == nil) {
+			return ca.Err == nil
+		}
+		return ca.Lines() > cb.Lines()
+	})
+	return rows
+}
+
+// handleChurnKeys handles keyboard input in the diff stats view
+func (m Model) handleChurnKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	rows := m.churnRows()
+	switch msg.String() {
+	case "esc", "q", "=":
+		m.state = stateList
+	case "up", "k":
+		if m.churnCursor > 0 {
+			m.churnCursor--
+		}
+	case "down", "j":
+		if m.churnCursor < len(rows)-1 {
+			m.churnCursor++
+		}
+	case "tab", "a":
+		// Between the group and the whole project
+		if m.churnGroup != nil {
+			m.churnAll = !m.churnAll
+			m.churnCursor = 0
+		}
+	case "r":
+		if !m.churnLoading {
+			return m, m.loadChurn()
+		}
+	case "enter":
+		// Jump to the session in the list
+		if m.churnCursor < len(rows) {
+			m.selectInstance(rows[m.churnCursor])
+		}
+		m.state = stateList
+	}
+	return m, nil
+}
+
+// churnView renders the diff stats view as an overlay
+func (m Model) churnView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 70
+	scope := "project"
+	if m.activeProject != nil {
+		scope = "project " + m.activeProject.Name
+	}
+	if !m.churnAll && m.churnGroup != nil {
+		scope = "group " + m.churnGroup.Name
+	}
+	boxContent.WriteString("  Changes since start, running sessions of " + truncateRunes(scope, 40) + "\n\n")
+
+	rows := m.churnRows()
+	switch {
+	case m.churnLoading:
+		boxContent.WriteString(dimStyle.Render("  Counting changes…"))
+		boxContent.WriteString("\n")
+	case len(rows) == 0:
+		boxContent.WriteString(dimStyle.Render("  No running sessions"))
+		boxContent.WriteString("\n")
+	default:
+		total := session.ChurnTotal(rows, m.churn)
+		nameWidth := boxWidth - 42
+		header := fmt.Sprintf("   %-*s %6s %8s %8s %6s", nameWidth, "Session", "Files", "Added", "Removed", "Share")
+		boxContent.WriteString(dimStyle.Render(header))
+		boxContent.WriteString("\n")
+
+		maxVisible := m.height - 16
+		if maxVisible < 5 {
+			maxVisible = 5
+		}
+		start := 0
+		if m.churnCursor >= maxVisible {
+			start = m.churnCursor - maxVisible + 1
+		}
+		hot := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorOrange))
+		for i := start; i < len(rows) && i < start+maxVisible; i++ {
+			inst := rows[i]
+			c := m.churn[inst.ID]
+			name := truncateRunes(inst.Name, nameWidth)
+			var line string
+			switch {
+			case c.Err != nil:
+				line = fmt.Sprintf(" %-*s ", nameWidth, name) + dimStyle.Render(truncateRunes(c.Err.Error(), 34))
+			default:
+				share := 0
+				if total.Lines() > 0 {
+					share = c.Lines() * 100 / total.Lines()
+				}
+				line = fmt.Sprintf(" %-*s %6d %8s %8s %5d%%", nameWidth, name, c.Files, fmt.Sprintf("+%d", c.Added), fmt.Sprintf("-%d", c.Removed), share)
+				if i < churnTopRows && c.Lines() > 0 {
+					line = hot.Render(line)
+				}
+			}
+			if i == m.churnCursor {
+				boxContent.WriteString(" ❯" + line + "\n")
+			} else {
+				boxContent.WriteString("  " + line + "\n")
+			}
+		}
+
+		boxContent.WriteString("\n")
+		totalLine := fmt.Sprintf("   %-*s %6d %8s %8s", nameWidth, "Total", total.Files, fmt.Sprintf("+%d", total.Added), fmt.Sprintf("-%d", total.Removed))
+		boxContent.WriteString(lipgloss.NewStyle().Bold(true).Render(totalLine))
+		boxContent.WriteString("\n")
+		boxContent.WriteString(dimStyle.Render("  Sessions sharing a directory are counted once in the total"))
+		boxContent.WriteString("\n")
+	}
+
+	boxContent.WriteString("\n")
+	help := "  r: refresh  enter: select  esc: close"
+	if m.churnGroup != nil {
+		help = "  tab: group/project  " + strings.TrimPrefix(help, "  ")
+	}
+	boxContent.WriteString(helpStyle.Render(help))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Diff Stats ", boxContent.String(), boxWidth, ColorCyan)
+}
@@ -0,0 +1,391 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// codeSearchDoneMsg is sent when ripgrep finished in every directory of the scope
+type codeSearchDoneMsg struct {
+	pattern string
+	repos   []session.RepoMatches // Directories with matches or errors, in scope order
+}
+
+// codeSearchCmd runs ripgrep in each directory off the UI loop
+func codeSearchCmd(pattern string, paths []string) tea.Cmd {
+	return func() tea.Msg {
+		msg := codeSearchDoneMsg{pattern: pattern}
+		for _, path := range paths {
+			repo := session.SearchRepo(path, pattern)
+			if repo.Total > 0 || repo.Err != nil {
+				msg.repos = append(msg.repos, repo)
+			}
+		}
+		return msg
+	}
+}
+
+// handleShowCodeSearch opens the cross-repository search for the selected group
+// (the group row or the selected session's group), or the whole project outside groups
+func (m *Model) handleShowCodeSearch() tea.Cmd {
+	m.codeSearchGroup = m.getSelectedGroup()
+	if inst := m.getSelectedInstance(); inst != nil && inst.GroupID != "" {
+		for _, g := range m.groups {
+			if g.ID == inst.GroupID {
+				m.codeSearchGroup = g
+			}
+		}
+	}
+	m.codeSearchAll = m.codeSearchGroup == nil
+	m.codeSearchInput.CursorEnd()
+	m.codeSearchInput.Focus()
+	m.err = nil
+	m.state = stateCodeSearch
+	return textinput.Blink
+}
+
+// codeSearchScope returns the sessions of the searched group or project
+func (m Model) codeSearchScope() []*session.Instance {
+	if !m.codeSearchAll && m.codeSearchGroup != nil {
+		return m.getSessionsInGroup(m.codeSearchGroup.ID)
+	}
+	return m.instances
+}
+
+// codeSearchPaths returns the directories of the scope, each once
+func (m Model) codeSearchPaths() []string {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, inst := range m.codeSearchScope() {
+		if inst.Path != "" && !seen[inst.Path] {
+			seen[inst.Path] = true
+			paths = append(paths, inst.Path)
+		}
+	}
+	return paths
+}
+
+// codeSearchTarget returns the session a directory's fix prompt goes to: the
+// first running agent session working there, otherwise the first session (nil if none)
+func (m Model) codeSearchTarget(path string) *session.Instance {
+	var first *session.Instance
+	for _, inst := range m.codeSearchScope() {
+		if inst.Path != path {
+			continue
+		}
+		if inst.Status == session.StatusRunning && inst.Agent != session.AgentTerminal {
+			return inst
+		}
+		if first == nil {
+			first = inst
+		}
+	}
+	return first
+}
+
+// codeSearchScopeName describes the scope in the dialog
+func (m Model) codeSearchScopeName() string {
+	if !m.codeSearchAll && m.codeSearchGroup != nil {
+		return "group " + m.codeSearchGroup.Name
+	}
+	if m.activeProject != nil {
+		return "project " + m.activeProject.Name
+	}
+	return "project"
+}
+
+// handleCodeSearchDone shows the matches of a finished search
+func (m *Model) handleCodeSearchDone(msg codeSearchDoneMsg) {
+	m.codeSearchLoading = false
+	m.codeSearchPattern = msg.pattern
+	m.codeSearchRepos = msg.repos
+	m.codeSearchSkip = make(map[string]bool)
+	m.codeSearchCursor = 0
+	for _, repo := range msg.repos {
+		if repo.Err != nil {
+			m.codeSearchSkip[repo.Path] = true
+		}
+	}
+}
+
+// handleCodeSearchKeys handles keyboard input in the cross-repository search
+func (m Model) handleCodeSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.codeSearchInput.Focused() {
+		switch msg.String() {
+		case "esc":
+			m.codeSearchInput.Blur()
+			if m.codeSearchRepos == nil {
+				m.state = stateList
+			}
+			return m, nil
+		case "tab":
+			// Between the group and the whole project
+			if m.codeSearchGroup != nil && !m.codeSearchLoading {
+				m.codeSearchAll = !m.codeSearchAll
+				m.codeSearchRepos = nil
+			}
+			return m, nil
+		case "enter":
+			pattern := strings.TrimSpace(m.codeSearchInput.Value())
+			if pattern == "" || m.codeSearchLoading {
+				return m, nil
+			}
+			paths := m.codeSearchPaths()
+			if len(paths) == 0 {
+				m.err = fmt.Errorf("no sessions in the %s", m.codeSearchScopeName())
+				return m, nil
+			}
+			m.err = nil
+			m.codeSearchInput.Blur()
+			m.codeSearchLoading = true
+			m.codeSearchRepos = nil
+			return m, codeSearchCmd(pattern, paths)
+		}
+		m.err = nil
+		var cmd tea.Cmd
+		m.codeSearchInput, cmd = m.codeSearchInput.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		m.state = stateList
+	case "/", "e":
+		// Edit the search again
+		m.codeSearchInput.Focus()
+		return m, textinput.Blink
+	case "tab", "a":
+		if m.codeSearchGroup != nil && !m.codeSearchLoading {
+			m.codeSearchAll = !m.codeSearchAll
+			m.codeSearchRepos = nil
+			m.codeSearchInput.Focus()
+			return m, textinput.Blink
+		}
+	case "up", "k":
+		if m.codeSearchCursor > 0 {
+			m.codeSearchCursor--
+		}
+	case "down", "j":
+		if m.codeSearchCursor < len(m.codeSearchRepos)-1 {
+			m.codeSearchCursor++
+		}
+	case " ":
+		// Include or leave out the directory when sending the fix prompt
+		if m.codeSearchCursor < len(m.codeSearchRepos) {
+			repo := m.codeSearchRepos[m.codeSearchCursor]
+			if repo.Err == nil {
+				m.codeSearchSkip[repo.Path] = !m.codeSearchSkip[repo.Path]
+			}
+		}
+	case "enter":
+		// Jump to the session of the directory
+		if m.codeSearchCursor < len(m.codeSearchRepos) {
+			if inst := m.codeSearchTarget(m.codeSearchRepos[m.codeSearchCursor].Path); inst != nil {
+				m.selectInstance(inst)
+			}
+		}
+		m.state = stateList
+	case "p":
+		// Write the fix prompt for the included directories
+		if len(m.codeSearchDispatch()) == 0 {
+			m.notifyWarn("No running session to send a fix prompt to")
+			return m, nil
+		}
+		if m.codePromptInput.Value() == "" {
+			m.codePromptInput.SetValue(session.DefaultFixPrompt)
+		}
+		m.codePromptInput.CursorEnd()
+		m.codePromptInput.Focus()
+		m.state = stateCodeSearchPrompt
+		return m, textinput.Blink
+	}
+	return m, nil
+}
+
+// codeSearchDispatch returns the included directories' matches by the running session they go to
+func (m Model) codeSearchDispatch() map[*session.Instance]session.RepoMatches {
+	targets := make(map[*session.Instance]session.RepoMatches)
+	for _, repo := range m.codeSearchRepos {
+		if repo.Err != nil || m.codeSearchSkip[repo.Path] {
+			continue
+		}
+		if inst := m.codeSearchTarget(repo.Path); inst != nil && inst.Status == session.StatusRunning && inst.Agent != session.AgentTerminal {
+			targets[inst] = repo
+		}
+	}
+	return targets
+}
+
+// handleCodeSearchPromptKeys handles keyboard input while writing the fix prompt
+func (m Model) handleCodeSearchPromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.codePromptInput.Blur()
+		m.state = stateCodeSearch
+		return m, nil
+
+	case "enter":
+		template := strings.TrimSpace(m.codePromptInput.Value())
+		if template == "" {
+			return m, nil
+		}
+		m.codePromptInput.Blur()
+		sent, failed := 0, 0
+		for inst, repo := range m.codeSearchDispatch() {
+			if m.isBusy(inst) {
+				failed++
+				continue
+			}
+			text := inst.FixPrompt(template, m.codeSearchPattern, repo)
+			if err := inst.SendPrompt(text); err != nil {
+				m.notify(toastError, "Fix prompt for '%s' failed: %v", inst.Name, err)
+				failed++
+				continue
+			}
+			// The matches can't be filled in again later, so the sent text is kept
+			m.rememberPrompt(inst, text)
+			sent++
+		}
+		if failed > 0 {
+			m.notifyWarn("Fix prompt sent to %d session(s), %d skipped", sent, failed)
+		} else {
+			m.notifySuccess("Fix prompt sent to %d session(s)", sent)
+		}
+		m.state = stateList
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.codePromptInput, cmd = m.codePromptInput.Update(msg)
+	return m, cmd
+}
+
+// codeSearchView renders the cross-repository search as an overlay
+func (m Model) codeSearchView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+	boxWidth := 90
+	boxContent.WriteString("  Search the directories of the " + truncateRunes(m.codeSearchScopeName(), 40) + dimStyle.Render(fmt.Sprintf("  (%d)", len(m.codeSearchPaths()))) + "\n\n")
+	boxContent.WriteString("  rg " + m.codeSearchInput.View() + "\n")
+
+	if m.err != nil {
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555")).Bold(true)
+		boxContent.WriteString("\n" + errStyle.Render(fmt.Sprintf("  ⚠ %v", m.err)) + "\n")
+	}
+	boxContent.WriteString("\n")
+
+	switch {
+	case m.codeSearchLoading:
+		boxContent.WriteString(dimStyle.Render("  Searching…"))
+		boxContent.WriteString("\n")
+	case m.codeSearchRepos == nil:
+		boxContent.WriteString(dimStyle.Render("  A regex (smart case, .gitignore respected) - matches can be sent to the agents"))
+		boxContent.WriteString("\n")
+	case len(m.codeSearchRepos) == 0:
+		boxContent.WriteString(dimStyle.Render("  No matches for '" + truncateRunes(m.codeSearchPattern, 40) + "'"))
+		boxContent.WriteString("\n")
+	default:
+		m.renderCodeSearchRepos(&boxContent, boxWidth)
+	}
+
+	boxContent.WriteString("\n")
+	help := "  enter: search  esc: close"
+	if m.codeSearchGroup != nil {
+		help = "  tab: group/project  " + strings.TrimPrefix(help, "  ")
+	}
+	if !m.codeSearchInput.Focused() && len(m.codeSearchRepos) > 0 {
+		help = "  space: include  p: send fix prompt  enter: select  /: edit search  esc: close"
+	}
+	boxContent.WriteString(helpStyle.Render(help))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Search Across Sessions ", boxContent.String(), boxWidth, ColorCyan)
+}
+
+// renderCodeSearchRepos lists the directories with matches and the matches of the selected one
+func (m Model) renderCodeSearchRepos(b *strings.Builder, boxWidth int) {
+	total := 0
+	for _, repo := range m.codeSearchRepos {
+		total += repo.Total
+	}
+	b.WriteString(dimStyle.Render(fmt.Sprintf("  %d matches in %d directories", total, len(m.codeSearchRepos))))
+	b.WriteString("\n")
+
+	nameWidth := 28
+	for i, repo := range m.codeSearchRepos {
+		check := "[x]"
+		if m.codeSearchSkip[repo.Path] {
+			check = "[ ]"
+		}
+		name := dimStyle.Render("no session")
+		if inst := m.codeSearchTarget(repo.Path); inst != nil {
+			name = truncateRunes(inst.Name, nameWidth)
+			if inst.Status != session.StatusRunning || inst.Agent == session.AgentTerminal {
+				name += dimStyle.Render(" (not running)")
+			}
+		}
+		info := fmt.Sprintf("%d", repo.Total)
+		if repo.Err != nil {
+			check = "   "
+			info = truncateRunes(repo.Err.Error(), 30)
+		}
+		line := fmt.Sprintf("%s %-*s  %s  %s", check, nameWidth, name, info, dimStyle.Render(truncateRunes(repo.Path, boxWidth-nameWidth-24)))
+		if i == m.codeSearchCursor {
+			b.WriteString(" ❯ " + line + "\n")
+		} else {
+			b.WriteString("   " + line + "\n")
+		}
+	}
+
+	if m.codeSearchCursor >= len(m.codeSearchRepos) {
+		return
+	}
+	repo := m.codeSearchRepos[m.codeSearchCursor]
+	if repo.Err != nil {
+		return
+	}
+	b.WriteString("\n")
+	maxLines := m.height - 20 - len(m.codeSearchRepos)
+	if maxLines < 3 {
+		maxLines = 3
+	}
+	for i, match := range repo.Matches {
+		if i == maxLines {
+			b.WriteString(dimStyle.Render(fmt.Sprintf("    … %d more", repo.Total-i)))
+			b.WriteString("\n")
+			break
+		}
+		where := projectLabelStyle.Render(fmt.Sprintf("%s:%d", match.File, match.Line))
+		b.WriteString("    " + where + " " + truncateRunes(match.Text, boxWidth-lipgloss.Width(where)-10) + "\n")
+	}
+}
+
+// codeSearchPromptView renders the fix prompt dialog as an overlay
+func (m Model) codeSearchPromptView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+	targets := m.codeSearchDispatch()
+	boxContent.WriteString(fmt.Sprintf("  Fix prompt for %d session(s), matches of '%s':\n\n", len(targets), truncateRunes(m.codeSearchPattern, 40)))
+	boxContent.WriteString("  " + m.codePromptInput.View() + "\n")
+	boxContent.WriteString(dimStyle.Render("  Filled per session: {{" + strings.Join(session.CodeSearchVars, "}} {{") + "}}"))
+	boxContent.WriteString("\n")
+	boxContent.WriteString(dimStyle.Render("  and the prompt template placeholders ({{branch}}, {{name}}, {{issue}}, ...)"))
+	boxContent.WriteString("\n\n")
+	for _, repo := range m.codeSearchRepos {
+		for inst, r := range targets {
+			if r.Path == repo.Path {
+				boxContent.WriteString(fmt.Sprintf("    %s %s\n", truncateRunes(inst.Name, 30), dimStyle.Render(fmt.Sprintf("%d matches", r.Total))))
+			}
+		}
+	}
+	boxContent.WriteString("\n")
+	boxContent.WriteString(helpStyle.Render("  enter: send to all  esc: back"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Send Fix Prompt ", boxContent.String(), 90, ColorAccent)
+}
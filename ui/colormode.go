@@ -0,0 +1,154 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// colorProfile is the color depth the UI renders in (color_mode in config.toml, NO_COLOR)
+var colorProfile = termenv.TrueColor
+
+// colorModes maps the color_mode values of config.toml to color depths
+var colorModes = map[string]termenv.Profile{
+	"truecolor": termenv.TrueColor,
+	"256":       termenv.ANSI256,
+	"16":        termenv.ANSI,
+	"none":      termenv.Ascii,
+}
+
+// ansiAccents are the 16-color stand-ins for the theme accents
+var ansiAccents = map[string]string{
+	ColorPurple: "5",
+	"#3B6FE0":   "4",
+	"#0E8A80":   "6",
+	"#2E8B57":   "2",
+	"#D9661F":   "3",
+	"#C7365F":   "1",
+	"#C2408F":   "5",
+	"#5A5F6B":   "8",
+}
+
+// applyColorMode sets the color depth of the UI: a color_mode from config.toml,
+// or for "" and "auto" what the terminal reports (NO_COLOR turns colors off).
+// Below 256 colors gradients are drawn in their first color and the shared
+// styles use the 16 ANSI colors; without colors selections are reversed.
+func applyColorMode(mode string) error {
+	profile := lipgloss.ColorProfile()
+	var err error
+	if mode != "" && mode != "auto" {
+		if p, ok := colorModes[strings.ToLower(mode)]; ok {
+			profile = p
+		} else {
+			err = fmt.Errorf("unknown color_mode '%s' (use auto, truecolor, 256, 16 or none)", mode)
+		}
+	}
+	colorProfile = profile
+	lipgloss.SetColorProfile(profile)
+
+	switch profile {
+	case termenv.ANSI:
+		projectLabelStyle = projectLabelStyle.Foreground(lipgloss.Color("7"))
+		projectNameStyle = projectNameStyle.Foreground(lipgloss.Color("13"))
+		titleStyle = titleStyle.Foreground(lipgloss.Color("15"))
+		selectedStyle = selectedStyle.Foreground(lipgloss.Color("15"))
+		listSelectedStyle = listSelectedStyle.Foreground(lipgloss.Color("15"))
+		runningStyle = runningStyle.Foreground(lipgloss.Color("2"))
+		activeStyle = activeStyle.Foreground(lipgloss.Color("3"))
+		waitingStyle = waitingStyle.Foreground(lipgloss.Color("6"))
+		idleStyle = idleStyle.Foreground(lipgloss.Color("7"))
+		stoppedStyle = stoppedStyle.Foreground(lipgloss.Color("1"))
+		helpStyle = helpStyle.Foreground(lipgloss.Color("8"))
+		errorStyle = errorStyle.Foreground(lipgloss.Color("9"))
+		dimStyle = dimStyle.Foreground(lipgloss.Color("8"))
+		sessionStyle = sessionStyle.Foreground(lipgloss.Color("11"))
+		listPaneStyle = listPaneStyle.BorderForeground(lipgloss.Color("8"))
+		searchBoxStyle = searchBoxStyle.BorderForeground(lipgloss.Color("8")).Foreground(lipgloss.Color("8"))
+		metaStyle = metaStyle.Foreground(lipgloss.Color("7"))
+	case termenv.Ascii:
+		titleStyle = titleStyle.Reverse(true)
+		selectedStyle = selectedStyle.Reverse(true)
+		listSelectedStyle = listSelectedStyle.Reverse(true)
+	}
+	return err
+}
+
+// styleAccent returns the accent color for styles: the 16-color stand-in of a theme below 256 colors
+func styleAccent(accent string) string {
+	if colorProfile == termenv.ANSI {
+		if ansi, ok := ansiAccents[accent]; ok {
+			return ansi
+		}
+	}
+	return accent
+}
+
+// gradientsEnabled reports whether gradients are drawn color by color (256 colors or more)
+func gradientsEnabled() bool {
+	return colorProfile <= termenv.ANSI256
+}
+
+// sgrPattern matches SGR escape sequences (colors and text attributes)
+var sgrPattern = regexp.MustCompile(`\x1b\[([0-9;]*)m`)
+
+// degradeANSI converts the colors of captured pane output to the color depth
+// of the UI (drops them with no colors); other attributes are kept
+func degradeANSI(s string) string {
+	if colorProfile == termenv.TrueColor || !strings.Contains(s, "\x1b[") {
+		return s
+	}
+	return sgrPattern.ReplaceAllStringFunc(s, func(seq string) string {
+		params := sgrPattern.FindStringSubmatch(seq)[1]
+		if params == "" {
+			return seq // Reset
+		}
+		fields := strings.Split(params, ";")
+		var out []string
+		for idx := 0; idx < len(fields); idx++ {
+			n, err := strconv.Atoi(fields[idx])
+			if err != nil {
+				continue
+			}
+			switch {
+			case (n == 38 || n == 48) && idx+1 < len(fields):
+				var color termenv.Color
+				switch fields[idx+1] {
+				case "2": // 38;2;r;g;b
+					if idx+4 < len(fields) {
+						r, _ := strconv.Atoi(fields[idx+2])
+						g, _ := strconv.Atoi(fields[idx+3])
+						b, _ := strconv.Atoi(fields[idx+4])
+						color = colorProfile.Color(fmt.Sprintf("#%02x%02x%02x", r, g, b))
+					}
+					idx += 4
+				case "5": // 38;5;n
+					if idx+2 < len(fields) {
+						color = colorProfile.Color(fields[idx+2])
+					}
+					idx += 2
+				default:
+					idx = len(fields)
+				}
+				if color != nil {
+					if code := color.Sequence(n == 48); code != "" {
+						out = append(out, code)
+					}
+				}
+			case (n >= 30 && n <= 37) || (n >= 40 && n <= 47) || (n >= 90 && n <= 97) || (n >= 100 && n <= 107) || n == 39 || n == 49:
+				if colorProfile != termenv.Ascii {
+					out = append(out, fields[idx])
+				}
+			default:
+				out = append(out, fields[idx])
+			}
+		}
+		if len(out) == 0 {
+			return ""
+		}
+		return "\x1b[" + strings.Join(out, ";") + "m"
+	})
+}
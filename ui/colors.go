@@ -132,9 +132,26 @@ func interpolateColor(colors []string, position float64) string {
 // applyGradientText applies a gradient to text with optional background color and bold
 func applyGradientText(text, gradientName, bgColor string, bold bool) string {
 	colors, ok := gradients[gradientName]
-	if !ok || len(text) == 0 {
+	if !ok {
 		return text
 	}
+	return applyGradientStops(text, colors, bgColor, bold)
+}
+
+// applyGradientStops applies gradient stop colors to text with optional background color and bold
+func applyGradientStops(text string, colors []string, bgColor string, bold bool) string {
+	if len(text) == 0 {
+		return text
+	}
+
+	if !gradientsEnabled() {
+		// Too few colors for a smooth gradient - use its first color
+		style := lipgloss.NewStyle().Foreground(lipgloss.Color(colors[0]))
+		if bgColor != "" {
+			style = style.Background(lipgloss.Color(bgColor))
+		}
+		return style.Bold(bold).Render(text)
+	}
 
 	runes := []rune(text)
 	var result strings.Builder
@@ -190,6 +207,12 @@ func applyTmuxGradient(text, gradientName string, bold bool) string {
 	if !ok || len(text) == 0 {
 		return text
 	}
+	if !gradientsEnabled() {
+		if bold {
+			return fmt.Sprintf("#[fg=%s,bold]%s", colors[0], text)
+		}
+		return fmt.Sprintf("#[fg=%s]%s", colors[0], text)
+	}
 
 	runes := []rune(text)
 	var result strings.Builder
@@ -252,7 +275,7 @@ func formatTmuxSessionName(name, fgColor, bgColor string) string {
 	}
 
 	// Default: white on purple
-	return fmt.Sprintf("#[fg=#FAFAFA,bg=%s,bold]%s#[default]", ColorPurple, name)
+	return fmt.Sprintf("#[fg=#FAFAFA,bg=%s,bold]%s#[default]", ColorAccent, name)
 }
 
 // formatSessionNameLipgloss formats session name for UI display with lipgloss
@@ -301,7 +324,7 @@ func formatSessionNameLipgloss(name, fgColor, bgColor string) string {
 	}
 
 	// Default: white on purple
-	style = style.Foreground(lipgloss.Color("#FAFAFA")).Background(lipgloss.Color(ColorPurple))
+	style = style.Foreground(lipgloss.Color("#FAFAFA")).Background(lipgloss.Color(ColorAccent))
 	return style.Render(name)
 }
 
@@ -310,6 +333,9 @@ func applyLipglossGradient(text string, colors []string) string {
 	if len(colors) == 0 || len(text) == 0 {
 		return text
 	}
+	if !gradientsEnabled() {
+		return lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(colors[0])).Render(text)
+	}
 
 	runes := []rune(text)
 	var result strings.Builder
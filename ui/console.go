@@ -0,0 +1,282 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// Console constants
+const (
+	MaxConsoleLog     = 500 // Max output lines kept in the tmux console
+	MaxConsoleHistory = 50  // Max commands kept for up/down recall
+)
+
+// untargetedTmuxCommands are tmux commands that don't accept -t
+var untargetedTmuxCommands = map[string]bool{
+	"list-sessions": true, "ls": true,
+	"list-keys": true, "lsk": true,
+	"list-commands": true, "lscm": true,
+	"list-buffers": true, "lsb": true,
+	"kill-server": true, "start-server": true, "start": true,
+	"info": true, "server-info": true,
+	"source-file": true, "source": true,
+	"set-buffer": true, "setb": true,
+	"show-buffer": true, "showb": true,
+}
+
+// consoleResultMsg is sent when a console command finishes
+type consoleResultMsg struct {
+	cmdLine string
+	output  string
+	err     error
+}
+
+// openConsole opens the tmux console for the selected session
+func (m *Model) openConsole() tea.Cmd {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return nil
+	}
+	if inst.Status != session.StatusRunning {
+		m.notifyWarn("Session not running - start it first")
+		return nil
+	}
+
+	// Target the active window of the session
+	target := inst.TmuxSessionName()
+	for _, w := range inst.GetWindowList() {
+		if w.Active {
+			target = fmt.Sprintf("%s:%d", target, w.Index)
+			break
+		}
+	}
+
+	m.consoleTarget = target
+	m.consoleTmux = inst.Tmux()
+	m.consoleInput.SetValue("")
+	m.consoleInput.Focus()
+	m.consoleHistoryIdx = len(m.consoleHistory)
+	m.consoleScroll = 0
+	m.state = stateTmuxConsole
+	return textinput.Blink
+}
+
+// runConsoleCmd runs a tmux command off the UI loop
+func runConsoleCmd(tmux session.Runner, args []string) tea.Cmd {
+	cmdLine := "tmux"
+	for _, arg := range args {
+		cmdLine += " " + session.ShellQuote(arg)
+	}
+	return func() tea.Msg {
+		out, err := tmux.CombinedOutput(args...)
+		return consoleResultMsg{cmdLine: cmdLine, output: string(out), err: err}
+	}
+}
+
+// consoleArgs parses a console line and targets it at the selected session/window.
+// {target} is replaced with the target; otherwise -t is inserted unless already given.
+func consoleArgs(line, target string) ([]string, error) {
+	args, err := session.SplitCommandLine(line)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, fmt.Errorf("empty command")
+	}
+	// Allow "tmux ..." prefix out of habit
+	if args[0] == "tmux" {
+		args = args[1:]
+		if len(args) == 0 {
+			return nil, fmt.Errorf("empty command")
+		}
+	}
+
+	hasTarget := false
+	for i, arg := range args {
+		if strings.Contains(arg, "{target}") {
+			args[i] = strings.ReplaceAll(arg, "{target}", target)
+			hasTarget = true
+		} else if arg == "-t" || strings.HasPrefix(arg, "-t") && len(arg) > 2 && !strings.HasPrefix(arg, "--") {
+			hasTarget = true
+		}
+	}
+	if !hasTarget && !untargetedTmuxCommands[args[0]] {
+		args = append([]string{args[0], "-t", target}, args[1:]...)
+	}
+	return args, nil
+}
+
+// appendConsoleLog adds lines to the console output, trimming the oldest
+func (m *Model) appendConsoleLog(lines ...string) {
+	m.consoleLog = append(m.consoleLog, lines...)
+	if len(m.consoleLog) > MaxConsoleLog {
+		m.consoleLog = m.consoleLog[len(m.consoleLog)-MaxConsoleLog:]
+	}
+	m.consoleScroll = 0 // Follow newest output
+}
+
+// handleConsoleResult shows the output of a finished console command
+func (m Model) handleConsoleResult(msg consoleResultMsg) (tea.Model, tea.Cmd) {
+	m.appendConsoleLog("$ " + msg.cmdLine)
+	output := strings.TrimRight(msg.output, "\n")
+	if output != "" {
+		m.appendConsoleLog(strings.Split(output, "\n")...)
+	}
+	if msg.err != nil {
+		m.appendConsoleLog("! " + msg.err.Error())
+	}
+	return m, nil
+}
+
+// handleConsoleKeys handles keyboard input in the tmux console
+func (m Model) handleConsoleKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.consoleInput.Blur()
+		m.state = stateList
+		return m, nil
+
+	case "enter":
+		line := strings.TrimSpace(m.consoleInput.Value())
+		if line == "" {
+			return m, nil
+		}
+		switch line {
+		case "clear":
+			m.consoleLog = nil
+			m.consoleInput.SetValue("")
+			return m, nil
+		case "exit", "quit":
+			m.consoleInput.Blur()
+			m.state = stateList
+			return m, nil
+		}
+
+		// Remember command for up/down recall (skip repeats)
+		if len(m.consoleHistory) == 0 || m.consoleHistory[len(m.consoleHistory)-1] != line {
+			m.consoleHistory = append(m.consoleHistory, line)
+			if len(m.consoleHistory) > MaxConsoleHistory {
+				m.consoleHistory = m.consoleHistory[1:]
+			}
+		}
+		m.consoleHistoryIdx = len(m.consoleHistory)
+		m.consoleInput.SetValue("")
+
+		args, err := consoleArgs(line, m.consoleTarget)
+		if err != nil {
+			m.appendConsoleLog("$ "+line, "! "+err.Error())
+			return m, nil
+		}
+		return m, runConsoleCmd(m.consoleTmux, args)
+
+	case "up":
+		if m.consoleHistoryIdx > 0 {
+			m.consoleHistoryIdx--
+			m.consoleInput.SetValue(m.consoleHistory[m.consoleHistoryIdx])
+			m.consoleInput.CursorEnd()
+		}
+		return m, nil
+
+	case "down":
+		if m.consoleHistoryIdx < len(m.consoleHistory)-1 {
+			m.consoleHistoryIdx++
+			m.consoleInput.SetValue(m.consoleHistory[m.consoleHistoryIdx])
+			m.consoleInput.CursorEnd()
+		} else {
+			m.consoleHistoryIdx = len(m.consoleHistory)
+			m.consoleInput.SetValue("")
+		}
+		return m, nil
+
+	case "pgup":
+		m.consoleScroll += 5
+		if maxScroll := len(m.consoleLog) - 1; m.consoleScroll > maxScroll {
+			m.consoleScroll = maxScroll
+		}
+		if m.consoleScroll < 0 {
+			m.consoleScroll = 0
+		}
+		return m, nil
+
+	case "pgdown":
+		m.consoleScroll -= 5
+		if m.consoleScroll < 0 {
+			m.consoleScroll = 0
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.consoleInput, cmd = m.consoleInput.Update(msg)
+	return m, cmd
+}
+
+// consoleView renders the tmux console as an overlay
+func (m Model) consoleView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 80
+	if m.width > 120 {
+		boxWidth = 110
+	}
+	lineWidth := boxWidth - 6
+
+	boxContent.WriteString("  " + dimStyle.Render("Target: ") + m.consoleTarget + "\n\n")
+
+	// Output area (bottom aligned, scrollable)
+	outputHeight := m.height - 16
+	if outputHeight < 5 {
+		outputHeight = 5
+	}
+	cmdStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorCyan))
+	errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorRed))
+
+	end := len(m.consoleLog) - m.consoleScroll
+	start := end - outputHeight
+	if start < 0 {
+		start = 0
+	}
+	shown := 0
+	if len(m.consoleLog) == 0 {
+		boxContent.WriteString(dimStyle.Render("  Run tmux commands against the target, e.g. list-panes, resize-pane -Z"))
+		boxContent.WriteString("\n")
+		boxContent.WriteString(dimStyle.Render("  -t is added automatically; use {target} to place it yourself"))
+		boxContent.WriteString("\n")
+		shown = 2
+	} else {
+		for _, line := range m.consoleLog[start:end] {
+			line = truncateRunes(stripANSI(line), lineWidth)
+			switch {
+			case strings.HasPrefix(line, "$ "):
+				line = cmdStyle.Render(line)
+			case strings.HasPrefix(line, "! "):
+				line = errStyle.Render(line)
+			}
+			boxContent.WriteString("  " + line + "\n")
+			shown++
+		}
+	}
+	for ; shown < outputHeight; shown++ {
+		boxContent.WriteString("\n")
+	}
+
+	boxContent.WriteString("\n")
+	m.consoleInput.Width = lineWidth - 4
+	boxContent.WriteString("  " + m.consoleInput.View())
+	boxContent.WriteString("\n\n")
+
+	scrollInfo := ""
+	if m.consoleScroll > 0 {
+		scrollInfo = fmt.Sprintf("  [↑%d]", m.consoleScroll)
+	}
+	boxContent.WriteString(helpStyle.Render("  enter: run  ↑/↓: history  PgUp/PgDn: scroll  clear: clear output  esc: close" + scrollInfo))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" tmux Console ", boxContent.String(), boxWidth, ColorAccent)
+}
@@ -0,0 +1,173 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// convExportDoneMsg is sent when a background conversation export is written
+type convExportDoneMsg struct {
+	inst  *session.Instance
+	path  string
+	count int // Messages written
+	err   error
+}
+
+// convExportCmd loads a conversation and writes it to path off the UI loop
+func convExportCmd(inst *session.Instance, entry *session.HistoryEntry, path string) tea.Cmd {
+	return func() tea.Msg {
+		messages, err := entry.LoadConversation()
+		if err != nil {
+			return convExportDoneMsg{inst: inst, err: err}
+		}
+		path, err := session.ExportConversation(path, inst.Name, entry, messages)
+		return convExportDoneMsg{inst: inst, path: path, count: len(messages), err: err}
+	}
+}
+
+// conversationFileName returns a file name for an exported conversation of a session
+func conversationFileName(name, ext string) string {
+	slug := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return '-'
+	}, name)
+	slug = strings.Trim(slug, "-")
+	if slug == "" {
+		slug = "session"
+	}
+	return fmt.Sprintf("asmgr-%s-%s%s", slug, time.Now().Format("20060102-150405"), ext)
+}
+
+// handleExportConversation opens the export dialog for the conversation of the
+// selected session's active window
+func (m *Model) handleExportConversation() tea.Cmd {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return nil
+	}
+	windowIdx := activeWindowIndex(inst)
+	entry, err := inst.ConversationEntry(windowIdx)
+	if err != nil {
+		m.notifyWarn("Cannot export '%s': %v", inst.Name, err)
+		return nil
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		dir, _ = os.UserHomeDir()
+	}
+	m.err = nil
+	m.convExportTarget = inst
+	m.convExportEntry = entry
+	m.convExportInput.SetValue(filepath.Join(dir, conversationFileName(inst.Name, ".md")))
+	m.convExportInput.CursorEnd()
+	m.convExportInput.Focus()
+	m.state = stateExportConversation
+	return textinput.Blink
+}
+
+// toggleConversationFormat swaps the output path between .md and .html
+func (m *Model) toggleConversationFormat() {
+	path := m.convExportInput.Value()
+	ext := ".html"
+	if session.IsHTMLPath(path) {
+		ext = ".md"
+	}
+	m.convExportInput.SetValue(strings.TrimSuffix(path, filepath.Ext(path)) + ext)
+	m.convExportInput.CursorEnd()
+}
+
+// handleExportConversationKeys handles keyboard input in the conversation export dialog
+func (m Model) handleExportConversationKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.err = nil
+		m.convExportInput.Blur()
+		m.convExportTarget = nil
+		m.convExportEntry = nil
+		m.state = stateList
+		return m, nil
+
+	case "tab":
+		m.toggleConversationFormat()
+		return m, nil
+
+	case "enter":
+		inst, entry := m.convExportTarget, m.convExportEntry
+		path := strings.TrimSpace(m.convExportInput.Value())
+		if inst == nil || entry == nil {
+			m.state = stateList
+			return m, nil
+		}
+		if path == "" {
+			m.err = fmt.Errorf("enter a file path")
+			return m, nil
+		}
+		m.err = nil
+		m.convExportInput.Blur()
+		m.convExportTarget = nil
+		m.convExportEntry = nil
+		m.state = stateList
+		return m, tea.Batch(m.beginOperation(inst, "Exporting conversation"), convExportCmd(inst, entry, path))
+	}
+
+	// Clear error when typing
+	m.err = nil
+
+	var cmd tea.Cmd
+	m.convExportInput, cmd = m.convExportInput.Update(msg)
+	return m, cmd
+}
+
+// handleConvExportDone reports a written conversation export
+func (m *Model) handleConvExportDone(msg convExportDoneMsg) {
+	m.endOperation(msg.inst)
+	if msg.err != nil {
+		m.notify(toastError, "Export of '%s' failed: %v", msg.inst.Name, msg.err)
+		return
+	}
+	m.notifySuccess("%d messages of '%s' exported to %s", msg.count, msg.inst.Name, msg.path)
+}
+
+// exportConversationView renders the conversation export dialog as an overlay
+func (m Model) exportConversationView() string {
+	inst, entry := m.convExportTarget, m.convExportEntry
+	if inst == nil || entry == nil {
+		return m.listView()
+	}
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+	boxContent.WriteString(fmt.Sprintf("  Export the %s conversation of '%s'\n", entry.Agent, truncateRunes(inst.Name, 40)))
+	boxContent.WriteString(dimStyle.Render(fmt.Sprintf("  %s · last activity %s", filepath.Base(entry.SessionFile), entry.Timestamp.Format("Jan 2 15:04"))))
+	boxContent.WriteString("\n\n")
+	boxContent.WriteString("  " + m.convExportInput.View() + "\n")
+	format := "Markdown"
+	if session.IsHTMLPath(m.convExportInput.Value()) {
+		format = "HTML (standalone page)"
+	}
+	boxContent.WriteString(dimStyle.Render("  Format: " + format + " - from the file extension"))
+	boxContent.WriteString("\n")
+
+	if m.err != nil {
+		boxContent.WriteString("\n")
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555")).Bold(true)
+		boxContent.WriteString(errStyle.Render(fmt.Sprintf("  ⚠ %v", m.err)))
+		boxContent.WriteString("\n")
+	}
+
+	boxContent.WriteString("\n")
+	boxContent.WriteString(helpStyle.Render("  enter: export  tab: markdown/html  esc: cancel"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Export Conversation ", boxContent.String(), 80, ColorAccent)
+}
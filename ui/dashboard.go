@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// DashboardRefresh is how often the empty-state dashboard re-reads waiting sessions
+const DashboardRefresh = 10 * time.Second
+
+// maxDashboardWaiting is how many waiting sessions the dashboard lists (1-9 jump to them)
+const maxDashboardWaiting = 9
+
+// maxDashboardSearches is how many recent global searches the dashboard lists
+const maxDashboardSearches = 5
+
+// dashboardTips are shown one a day on the dashboard
+var dashboardTips = []string{
+	"Enter starts a stopped session and attaches; Ctrl+Q detaches again.",
+	"1-9 answer a waiting agent's numbered question without attaching.",
+	"Y attaches read-only - watch an autonomous run without typing into it.",
+	"t opens a second agent or a shell as a tab of the same session.",
+	"Ctrl+K restarts crashed agents automatically, resuming the conversation.",
+	"Ctrl+T stops a session that sits idle; Enter resumes it later.",
+	"N keeps notes per session; #tags in them drive notification rules.",
+	"O writes a handover document and can start a fresh session from it.",
+	"$ shows tokens and the estimated cost per group and day.",
+	"Remap list keys in the [keys] table of config.toml.",
+}
+
+// dashboardLoadedMsg is sent when the dashboard's data is read
+type dashboardLoadedMsg struct {
+	waiting  []session.SessionState // Sessions waiting for input, across projects
+	searches []session.PastSearch
+}
+
+// dashboardCmd reads the waiting sessions of every project and the search history
+// off the UI loop (with its own storage, the model's stays on the active project)
+func dashboardCmd() tea.Cmd {
+	return func() tea.Msg {
+		var msg dashboardLoadedMsg
+		msg.searches, _ = session.LoadSearchHistory()
+		storage, err := session.NewStorage()
+		if err != nil {
+			return msg
+		}
+		snapshot, err := storage.Snapshot()
+		if err != nil {
+			return msg
+		}
+		for _, p := range snapshot.Projects {
+			for _, s := range p.Sessions {
+				if s.Activity == session.ActivityWaiting.String() {
+					msg.waiting = append(msg.waiting, s)
+				}
+			}
+		}
+		return msg
+	}
+}
+
+// showsDashboard reports whether the preview has nothing selected to show
+func (m Model) showsDashboard() bool {
+	if m.getSelectedInstance() != nil {
+		return false
+	}
+	if len(m.groups) > 0 {
+		m.buildVisibleItems()
+		if m.cursor >= 0 && m.cursor < len(m.visibleItems) && m.visibleItems[m.cursor].isGroup {
+			return false // The group summary is shown instead
+		}
+	}
+	return true
+}
+
+// refreshDashboard reloads the dashboard's data while it is visible
+func (m *Model) refreshDashboard() tea.Cmd {
+	if m.dashboardLoading || time.Since(m.dashboardAt) < DashboardRefresh || !m.showsDashboard() {
+		return nil
+	}
+	m.dashboardLoading = true
+	return dashboardCmd()
+}
+
+// handleDashboardLoaded stores the dashboard's data
+func (m *Model) handleDashboardLoaded(msg dashboardLoadedMsg) {
+	m.dashboardLoading = false
+	m.dashboardAt = time.Now()
+	m.dashboardWaiting = msg.waiting
+	if len(m.dashboardWaiting) > maxDashboardWaiting {
+		m.dashboardWaiting = m.dashboardWaiting[:maxDashboardWaiting]
+	}
+	m.dashboardSearches = msg.searches
+}
+
+// jumpToWaiting selects the n-th waiting session of the dashboard (1-based),
+// switching to the all projects list when it is in another project
+func (m *Model) jumpToWaiting(n int) {
+	if n < 1 || n > len(m.dashboardWaiting) {
+		return
+	}
+	target := m.dashboardWaiting[n-1]
+	if m.findInstanceIndex(target.ID) < 0 && !m.globalList {
+		if err := m.setGlobalList(true); err != nil {
+			m.showError(err)
+			return
+		}
+		m.saveSettings()
+	}
+	idx := m.findInstanceIndex(target.ID)
+	if idx < 0 {
+		m.notifyWarn("'%s' is gone", target.Name)
+		return
+	}
+	m.searchActive = false
+	m.searchQuery = ""
+	m.selectInstance(m.instances[idx])
+	if m.globalList {
+		m.notifyInfo("Jumped to '%s' in %s (g a to go back)", target.Name, target.ProjectName)
+	}
+}
+
+// dashboardView renders the empty-state dashboard of the preview pane
+func (m Model) dashboardView(width, height int) string {
+	headingStyle := projectLabelStyle.Bold(true)
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorCyan)).Bold(true)
+	line := func(key, desc string) string {
+		return "    " + keyStyle.Render(fmt.Sprintf("%-8s", key)) + " " + desc
+	}
+
+	var lines []string
+	switch {
+	case len(m.instances) == 0 && m.activeProject != nil:
+		lines = append(lines, "  No sessions in "+projectNameStyle.Render(m.activeProject.Name)+" yet")
+	case len(m.instances) == 0:
+		lines = append(lines, "  No sessions yet")
+	case m.searchActive:
+		lines = append(lines, "  No session matches '"+truncateRunes(m.searchQuery, width-30)+"'")
+	default:
+		lines = append(lines, "  No session selected")
+	}
+	lines = append(lines, "")
+
+	lines = append(lines, "  "+headingStyle.Render("Quick actions"))
+	lines = append(lines,
+		line(m.keys.help("new_session"), "New session"),
+		line(m.keys.help("presets"), "Presets gallery - ready-made setups"),
+		line(m.keys.help("import_tmux"), "Import tmux sessions started elsewhere"),
+		line(m.keys.help("leader")+" p", "Open another project"),
+		line(m.keys.help("leader")+" a", "Sessions of all projects"),
+		line(m.keys.help("global_search"), "Search the conversation history of every agent"),
+		line(m.keys.help("help"), "All keys"),
+	)
+
+	if len(m.dashboardWaiting) > 0 {
+		lines = append(lines, "", "  "+headingStyle.Render("Waiting for input")+dimStyle.Render("  (1-9 jump to it)"))
+		for idx, s := range m.dashboardWaiting {
+			where := s.ProjectName
+			if s.GroupName != "" {
+				where += " › " + s.GroupName
+			}
+			lines = append(lines, line(fmt.Sprintf("%d", idx+1), waitingStyle.Render(truncateRunes(s.Name, 30))+dimStyle.Render("  "+truncateRunes(where, width-50))))
+		}
+	}
+
+	if len(m.dashboardSearches) > 0 {
+		lines = append(lines, "", "  "+headingStyle.Render("Recent searches")+dimStyle.Render("  ("+m.keys.help("global_search")+")"))
+		for idx, s := range m.dashboardSearches {
+			if idx == maxDashboardSearches {
+				break
+			}
+			lines = append(lines, "    "+truncateRunes(s.Query, width-30)+dimStyle.Render(fmt.Sprintf("  %d results · %s", s.Results, s.SearchedAt.Format("Jan 2 15:04"))))
+		}
+	}
+
+	tip := dashboardTips[time.Now().YearDay()%len(dashboardTips)]
+	lines = append(lines, "", "  "+headingStyle.Render("Tip"))
+	for _, l := range strings.Split(wrapText(tip, width-6), "\n") {
+		lines = append(lines, "    "+dimStyle.Render(l))
+	}
+
+	if height > 0 && len(lines) > height {
+		lines = lines[:height]
+	}
+	return strings.Join(lines, "\n")
+}
@@ -16,6 +16,8 @@ var (
 	diffHunkStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#0ea5e9")) // Cyan
 	diffMetaStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#888888")) // Gray
 	diffFileStyle     = lipgloss.NewStyle().Foreground(lipgloss.Color("#f59e0b")).Bold(true) // Orange bold
+	diffOwnStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("#888888")).Italic(true) // Gray italic
+	diffHumanStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#f59e0b")).Italic(true) // Orange italic
 )
 
 // DiffMode represents the type of diff to display
@@ -30,9 +32,29 @@ const (
 type DiffPane struct {
 	viewport viewport.Model
 	stats    *session.DiffStats
+	inst     *session.Instance
 	mode     DiffMode
 	width    int
 	height   int
+
+	// External renderer (delta, difftastic) - nil uses the built-in colorizer
+	renderer      *session.DiffRenderer
+	renderedFrom  string // Raw diff the cached output was rendered from
+	renderedWidth int    // Pane width the cached output was rendered for
+	rendered      string // Cached renderer output
+	renderErr     error  // Error of the last render (built-in view is shown instead)
+
+	// Blame annotations for the built-in view
+	originsFrom string                     // Raw diff the origins were computed for
+	originsInst string                     // Session the origins were computed for
+	origins     map[int]session.HunkOrigin // Hunk header line index -> who wrote the replaced lines
+
+	// File navigation
+	files       []diffFile      // Files of the current diff
+	fileOffsets []int           // Content line where each file starts
+	collapsed   map[string]bool // Folded files (by path, kept across refreshes)
+	jumpFile    int             // File last jumped to (-1 = none)
+	jumpOffset  int             // Viewport offset of that jump
 }
 
 // NewDiffPane creates a new diff pane
@@ -41,7 +63,10 @@ func NewDiffPane() *DiffPane {
 	vp.Style = lipgloss.NewStyle()
 	return &DiffPane{
 		viewport: vp,
-		mode:     DiffModeFull,
+		mode:      DiffModeFull,
+		renderer:  session.LoadDiffRenderer(),
+		collapsed: make(map[string]bool),
+		jumpFile:  -1,
 	}
 }
 
@@ -56,6 +81,7 @@ func (d *DiffPane) SetSize(width, height int) {
 
 // SetDiff updates the diff content from an instance
 func (d *DiffPane) SetDiff(inst *session.Instance) {
+	d.inst = inst
 	if inst == nil {
 		d.stats = nil
 		d.updateContent()
@@ -93,6 +119,14 @@ func (d *DiffPane) GetModeLabel() string {
 	return "Full"
 }
 
+// GetRendererLabel returns the name of the renderer in use
+func (d *DiffPane) GetRendererLabel() string {
+	if d.renderer == nil || d.renderErr != nil {
+		return "built-in"
+	}
+	return d.renderer.Name
+}
+
 // ScrollUp scrolls the viewport up
 func (d *DiffPane) ScrollUp() {
 	d.viewport.LineUp(1)
@@ -123,13 +157,18 @@ func (d *DiffPane) GotoBottom() {
 	d.viewport.GotoBottom()
 }
 
-// View renders the diff pane
+// View renders the diff pane, with the file list when there is room
 func (d *DiffPane) View() string {
+	if width := d.sidebarWidth(); width > 0 {
+		return lipgloss.JoinHorizontal(lipgloss.Top, d.sidebarView(width), d.viewport.View())
+	}
 	return d.viewport.View()
 }
 
 // updateContent refreshes the viewport content
 func (d *DiffPane) updateContent() {
+	d.files, d.fileOffsets = nil, nil
+	d.viewport.Width = d.width
 	if d.stats == nil {
 		d.viewport.SetContent(dimStyle.Render("No diff available"))
 		return
@@ -149,24 +188,104 @@ func (d *DiffPane) updateContent() {
 	additions := diffAdditionStyle.Render(fmt.Sprintf("+%d", d.stats.Added))
 	deletions := diffDeletionStyle.Render(fmt.Sprintf("-%d", d.stats.Removed))
 	statsLine := " " + lipgloss.JoinHorizontal(lipgloss.Center, additions, "  ", deletions)
+	d.files = parseDiffFiles(d.stats.Content)
+	if len(d.files) > 1 {
+		statsLine += dimStyle.Render(fmt.Sprintf("  %d files", len(d.files)))
+	}
+	d.viewport.Width = d.width - d.sidebarWidth()
 
-	// Colorized diff content
-	diffContent := colorizeDiff(d.stats.Content)
+	// External renderer output, falling back to the built-in colorizer
+	diffContent := d.renderExternal()
+	if diffContent != "" {
+		d.fileOffsets = locateFiles(diffContent, d.files, 2)
+	} else {
+		var offsets []int
+		diffContent, offsets = colorizeDiff(d.stats.Content, d.hunkOrigins(), d.files, d.collapsed)
+		first := 2 // Stats line and the blank line
+		if d.renderErr != nil {
+			diffContent = dimStyle.Render(" "+truncateRunes(d.renderErr.Error(), d.viewport.Width-2)) + "\n" + diffContent
+			first++
+		}
+		for _, offset := range offsets {
+			d.fileOffsets = append(d.fileOffsets, first+offset)
+		}
+	}
 
 	// Join stats and diff vertically
 	d.viewport.SetContent(lipgloss.JoinVertical(lipgloss.Left, statsLine, "", diffContent))
 }
 
-// colorizeDiff applies syntax highlighting to diff content
-func colorizeDiff(diff string) string {
-	if diff == "" {
+// renderExternal returns the configured renderer's output ("" to use the built-in view),
+// re-running the tool only when the diff or the pane width changed
+func (d *DiffPane) renderExternal() string {
+	if d.renderer == nil || d.inst == nil {
+		return ""
+	}
+	width := d.viewport.Width - 1
+	if d.stats.Content != d.renderedFrom || width != d.renderedWidth {
+		d.renderedFrom = d.stats.Content
+		d.renderedWidth = width
+		d.rendered, d.renderErr = d.renderer.Render(d.inst, d.stats, width)
+	}
+	if d.renderErr != nil {
 		return ""
 	}
+	return d.rendered
+}
+
+// hunkOrigins returns the blame classification of the current diff's hunks,
+// re-running git blame only when the diff changed
+func (d *DiffPane) hunkOrigins() map[int]session.HunkOrigin {
+	if d.inst == nil {
+		return nil
+	}
+	if d.stats.Content != d.originsFrom || d.inst.ID != d.originsInst {
+		d.originsFrom = d.stats.Content
+		d.originsInst = d.inst.ID
+		d.origins = d.inst.HunkOrigins(d.stats)
+	}
+	return d.origins
+}
+
+// hunkOriginTag renders the annotation shown after a hunk header
+func hunkOriginTag(origin session.HunkOrigin) string {
+	switch origin {
+	case session.HunkOwn:
+		return diffOwnStyle.Render("↻ revises this session's change")
+	case session.HunkHuman:
+		return diffHumanStyle.Render("✎ rewrites pre-session code")
+	case session.HunkMixed:
+		return diffHumanStyle.Render("✎ rewrites pre-session code + this session's change")
+	}
+	return ""
+}
+
+// colorizeDiff applies syntax highlighting to diff content, tagging hunks with their origin.
+// Each file starts with a header line; collapsed files show only that line.
+// Returns the output line where each file starts.
+func colorizeDiff(diff string, origins map[int]session.HunkOrigin, files []diffFile, collapsed map[string]bool) (string, []int) {
+	if diff == "" {
+		return "", nil
+	}
 
 	var result strings.Builder
 	lines := strings.Split(diff, "\n")
-
-	for _, line := range lines {
+	offsets := make([]int, 0, len(files))
+	out, file := 0, -1
+
+	for idx, line := range lines {
+		if file+1 < len(files) && idx == files[file+1].line {
+			// File header replaces the "diff --git" line
+			file++
+			offsets = append(offsets, out)
+			result.WriteString(" " + diffFileHeader(files[file], collapsed[files[file].path]) + "\n")
+			out++
+			continue
+		}
+		if file >= 0 && collapsed[files[file].path] {
+			continue
+		}
+		out++
 		if len(line) == 0 {
 			result.WriteString("\n")
 			continue
@@ -175,10 +294,13 @@ func colorizeDiff(diff string) string {
 		// Add space padding for a more spacious look
 		coloredLine := " " + colorDiffLine(line) + " "
 		result.WriteString(coloredLine)
+		if tag := hunkOriginTag(origins[idx]); tag != "" {
+			result.WriteString(" " + tag)
+		}
 		result.WriteString("\n")
 	}
 
-	return result.String()
+	return result.String(), offsets
 }
 
 // colorDiffLine applies color to a single diff line
@@ -0,0 +1,201 @@
+package ui
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DiffSidebarMinWidth is the narrowest diff pane that still gets the file list
+const DiffSidebarMinWidth = 70
+
+// diffFile is one file of the raw diff shown in the diff pane
+type diffFile struct {
+	path    string
+	line    int // Raw diff line of its "diff --git" header
+	added   int
+	removed int
+}
+
+// parseDiffFiles finds the files of a raw git diff and counts their lines
+func parseDiffFiles(diff string) []diffFile {
+	var files []diffFile
+	for idx, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			f := diffFile{line: idx}
+			if i := strings.LastIndex(line, " b/"); i != -1 {
+				f.path = line[i+3:]
+			}
+			files = append(files, f)
+		case len(files) == 0:
+		case strings.HasPrefix(line, "+++ b/"):
+			files[len(files)-1].path = strings.TrimPrefix(line, "+++ b/")
+		case strings.HasPrefix(line, "+++ ") || strings.HasPrefix(line, "--- "):
+		case strings.HasPrefix(line, "+"):
+			files[len(files)-1].added++
+		case strings.HasPrefix(line, "-"):
+			files[len(files)-1].removed++
+		}
+	}
+	return files
+}
+
+// diffFileHeader renders the line that starts a file section in the built-in view
+func diffFileHeader(f diffFile, collapsed bool) string {
+	icon := "▾ "
+	if collapsed {
+		icon = "▸ "
+	}
+	counts := diffAdditionStyle.Render(fmt.Sprintf("+%d", f.added)) + " " + diffDeletionStyle.Render(fmt.Sprintf("-%d", f.removed))
+	return diffFileStyle.Render(icon+f.path) + "  " + counts
+}
+
+// sidebarWidth returns the width of the file list (0 = hidden)
+func (d *DiffPane) sidebarWidth() int {
+	if len(d.files) < 2 || d.width < DiffSidebarMinWidth {
+		return 0
+	}
+	w := d.width / 3
+	if w > 32 {
+		w = 32
+	}
+	return w
+}
+
+// externalView reports whether the diff is shown by an external renderer
+func (d *DiffPane) externalView() bool {
+	return d.renderer != nil && d.renderErr == nil && d.inst != nil
+}
+
+// locateFiles finds the file sections in rendered output by their paths
+// (external renderers print their own file headers)
+func locateFiles(content string, files []diffFile, first int) []int {
+	lines := strings.Split(content, "\n")
+	offsets := make([]int, len(files))
+	from := 0
+	for i, f := range files {
+		offsets[i] = first + from
+		for n := from; n < len(lines); n++ {
+			if strings.Contains(stripANSI(lines[n]), f.path) {
+				offsets[i] = first + n
+				from = n + 1
+				break
+			}
+		}
+	}
+	return offsets
+}
+
+// currentFile returns the file at the top of the viewport, or the one jumped
+// to when the viewport couldn't scroll it to the top (files near the end)
+func (d *DiffPane) currentFile() int {
+	if d.jumpFile >= 0 && d.jumpFile < len(d.fileOffsets) && d.viewport.YOffset == d.jumpOffset {
+		return d.jumpFile
+	}
+	current := 0
+	for i, offset := range d.fileOffsets {
+		if offset <= d.viewport.YOffset {
+			current = i
+		}
+	}
+	return current
+}
+
+// NextFile scrolls to the next file of the diff
+func (d *DiffPane) NextFile() {
+	if len(d.fileOffsets) == 0 {
+		return
+	}
+	next := d.currentFile() + 1
+	if d.viewport.YOffset < d.fileOffsets[0] {
+		next = 0
+	}
+	if next < len(d.fileOffsets) {
+		d.jumpTo(next)
+	}
+}
+
+// jumpTo scrolls a file to the top of the viewport (as far as it goes)
+func (d *DiffPane) jumpTo(file int) {
+	d.viewport.SetYOffset(d.fileOffsets[file])
+	d.jumpFile = file
+	d.jumpOffset = d.viewport.YOffset
+}
+
+// PrevFile scrolls to the start of the current file, or to the previous one when already there
+func (d *DiffPane) PrevFile() {
+	if len(d.fileOffsets) == 0 {
+		return
+	}
+	current := d.currentFile()
+	if d.viewport.YOffset <= d.fileOffsets[current] && current > 0 {
+		current--
+	}
+	d.jumpTo(current)
+}
+
+// ToggleFold collapses or expands the current file. External renderers
+// can't fold, so it reports false for them.
+func (d *DiffPane) ToggleFold() bool {
+	if len(d.files) == 0 || d.externalView() {
+		return false
+	}
+	current := d.currentFile()
+	path := d.files[current].path
+	d.collapsed[path] = !d.collapsed[path]
+	d.updateContent()
+	d.jumpTo(current)
+	return true
+}
+
+// sidebarView renders the file list, grouped by directory, next to the diff
+func (d *DiffPane) sidebarView(width int) string {
+	current := d.currentFile()
+	var lines []string
+	currentLine := 0
+	dir := ""
+	for i, f := range d.files {
+		if fileDir := path.Dir(f.path) + "/"; fileDir != dir {
+			dir = fileDir
+			lines = append(lines, dimStyle.Render(" "+truncateRunes(dir, width-2)))
+		}
+		marker := "  "
+		if i == current {
+			marker = "❯ "
+			currentLine = len(lines)
+		}
+		icon := "▾ "
+		if d.collapsed[f.path] {
+			icon = "▸ "
+		}
+		counts := fmt.Sprintf(" +%d -%d", f.added, f.removed)
+		name := truncateRunes(path.Base(f.path), width-len(counts)-7)
+		row := marker + icon + name
+		if i == current {
+			row = marker + icon + projectNameStyle.Render(name)
+		}
+		lines = append(lines, row+dimStyle.Render(counts))
+	}
+
+	// Keep the current file in view
+	start := 0
+	if currentLine >= d.height {
+		start = currentLine - d.height + 1
+	}
+	cell := lipgloss.NewStyle().Width(width - 1).MaxWidth(width - 1)
+	var b strings.Builder
+	for n := 0; n < d.height; n++ {
+		line := ""
+		if start+n < len(lines) {
+			line = lines[start+n]
+		}
+		b.WriteString(cell.Render(line) + dimStyle.Render("│"))
+		if n < d.height-1 {
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,229 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// digestSummaryLines caps the summary shown under the digest list
+const digestSummaryLines = 4
+
+// digestLoadedMsg is sent when the diff stats of the digest's sessions are counted
+type digestLoadedMsg struct {
+	churn map[string]session.Churn // Instance ID -> churn
+}
+
+// digestChurnCmd counts the diff stats of the digest's sessions off the UI loop
+func digestChurnCmd(entries []session.DigestEntry) tea.Cmd {
+	return func() tea.Msg {
+		churn := make(map[string]session.Churn, len(entries))
+		for _, e := range entries {
+			churn[e.Instance.ID] = e.Instance.Churn()
+		}
+		return digestLoadedMsg{churn: churn}
+	}
+}
+
+// formatTimeSpent formats a session's running time ("2h 05m", "40m", "-" when unknown)
+func formatTimeSpent(d time.Duration) string {
+	switch {
+	case d <= 0:
+		return "-"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", max(int(d.Minutes()), 1))
+	}
+	return fmt.Sprintf("%dh %02dm", int(d.Hours()), int(d.Minutes())%60)
+}
+
+// openDigest opens the weekly digest of the project's finished sessions (leader d)
+func (m *Model) openDigest() tea.Cmd {
+	closed, _ := m.storage.LoadClosed()
+	since := time.Now().AddDate(0, 0, -session.DigestDays)
+	m.digestEntries = session.CollectDigest(m.instances, closed, since)
+	m.digestCursor = 0
+	m.digestChurn = nil
+	m.state = stateDigest
+	if len(m.digestEntries) == 0 {
+		return nil
+	}
+	m.digestLoading = true
+	return digestChurnCmd(m.digestEntries)
+}
+
+// digestChurnText formats an entry's diff stats for the view and the export
+func (m Model) digestChurnText(e session.DigestEntry) string {
+	c, ok := m.digestChurn[e.Instance.ID]
+	if !ok || c.Err != nil {
+		return "-"
+	}
+	return fmt.Sprintf("%d files +%d -%d", c.Files, c.Added, c.Removed)
+}
+
+// buildDigestReport renders the digest as Markdown: a table, then the summaries
+func (m Model) buildDigestReport() string {
+	var b strings.Builder
+	title := "Weekly digest"
+	if m.activeProject != nil {
+		title = m.activeProject.Name + " weekly digest"
+	}
+	now := time.Now()
+	fmt.Fprintf(&b, "### %s - %s to %s\n\n", title, now.AddDate(0, 0, -session.DigestDays).Format("2006-01-02"), now.Format("2006-01-02"))
+	if len(m.digestEntries) == 0 {
+		b.WriteString("_No sessions stopped or deleted this week._\n")
+		return b.String()
+	}
+
+	var spent time.Duration
+	b.WriteString("| Session | Agent | Ended | Time spent | Changes |\n")
+	b.WriteString("|---|---|---|---|---|\n")
+	for _, e := range m.digestEntries {
+		name := markdownCell(e.Instance.Name, 40)
+		if e.Archived {
+			name += " (deleted)"
+		}
+		agent := string(e.Instance.Agent)
+		if agent == "" {
+			agent = string(session.AgentClaude)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s |\n",
+			name, agent, e.EndedAt.Format("Mon 2006-01-02 15:04"), formatTimeSpent(e.TimeSpent), m.digestChurnText(e))
+		spent += e.TimeSpent
+	}
+	fmt.Fprintf(&b, "\n%d session(s), %s in total\n", len(m.digestEntries), formatTimeSpent(spent))
+
+	var summaries strings.Builder
+	for _, e := range m.digestEntries {
+		if e.Summary != "" {
+			fmt.Fprintf(&summaries, "- **%s**: %s\n", e.Instance.Name, e.Summary)
+		}
+	}
+	if summaries.Len() > 0 {
+		b.WriteString("\n#### Summaries\n\n")
+		b.WriteString(summaries.String())
+	}
+	return b.String()
+}
+
+// handleDigestKeys handles keyboard input in the weekly digest
+func (m Model) handleDigestKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.state = stateList
+	case "up", "k":
+		if m.digestCursor > 0 {
+			m.digestCursor--
+		}
+	case "down", "j":
+		if m.digestCursor < len(m.digestEntries)-1 {
+			m.digestCursor++
+		}
+	case "r":
+		if !m.digestLoading {
+			return m, m.openDigest()
+		}
+	case "c":
+		method := m.copyToClipboard(m.buildDigestReport())
+		m.notifySuccess("Weekly digest copied via %s", method)
+	case "f":
+		path, err := writeMarkdownFile("asmgr-digest", m.buildDigestReport())
+		if err != nil {
+			m.notify(toastError, "Failed to write digest: %v", err)
+		} else {
+			m.notifySuccess("Weekly digest saved to %s", path)
+		}
+	case "enter":
+		// Jump to the session in the list (deleted ones reopen with u)
+		if m.digestCursor >= len(m.digestEntries) {
+			return m, nil
+		}
+		e := m.digestEntries[m.digestCursor]
+		if e.Archived {
+			m.notifyInfo("'%s' was deleted - u reopens the most recently closed session", e.Instance.Name)
+			return m, nil
+		}
+		m.selectInstance(e.Instance)
+		m.state = stateList
+	}
+	return m, nil
+}
+
+// digestView renders the weekly digest as an overlay
+func (m Model) digestView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 84
+	scope := "this project"
+	if m.activeProject != nil {
+		scope = m.activeProject.Name
+	}
+	boxContent.WriteString(fmt.Sprintf("  Sessions of %s stopped or deleted in the past %d days\n\n", truncateRunes(scope, 30), session.DigestDays))
+
+	if len(m.digestEntries) == 0 {
+		boxContent.WriteString(dimStyle.Render("  Nothing finished this week"))
+		boxContent.WriteString("\n")
+	} else {
+		nameWidth := boxWidth - 60
+		header := fmt.Sprintf("   %-*s %-16s %10s  %s", nameWidth, "Session", "Ended", "Time spent", "Changes")
+		boxContent.WriteString(dimStyle.Render(header))
+		boxContent.WriteString("\n")
+
+		maxVisible := max(m.height-20-digestSummaryLines, 5)
+		start := 0
+		if m.digestCursor >= maxVisible {
+			start = m.digestCursor - maxVisible + 1
+		}
+		var spent time.Duration
+		for _, e := range m.digestEntries {
+			spent += e.TimeSpent
+		}
+		for idx := start; idx < len(m.digestEntries) && idx < start+maxVisible; idx++ {
+			e := m.digestEntries[idx]
+			name := truncateRunes(e.Instance.Name, nameWidth)
+			changes := m.digestChurnText(e)
+			if m.digestLoading {
+				changes = "…"
+			}
+			line := fmt.Sprintf(" %-*s %-16s %10s  %s", nameWidth, name, e.EndedAt.Format("Mon 01-02 15:04"), formatTimeSpent(e.TimeSpent), changes)
+			if e.Archived {
+				line += dimStyle.Render("  deleted")
+			}
+			if idx == m.digestCursor {
+				boxContent.WriteString(" ❯" + line + "\n")
+			} else {
+				boxContent.WriteString("  " + line + "\n")
+			}
+		}
+		boxContent.WriteString("\n")
+		boxContent.WriteString(dimStyle.Render(fmt.Sprintf("   %d session(s), %s in total", len(m.digestEntries), formatTimeSpent(spent))))
+		boxContent.WriteString("\n\n")
+
+		// Summary of the session under the cursor
+		if m.digestCursor < len(m.digestEntries) {
+			summary := m.digestEntries[m.digestCursor].Summary
+			if summary == "" {
+				boxContent.WriteString(dimStyle.Render("  No summary - S summarizes a session, or on stop with summary.json"))
+				boxContent.WriteString("\n")
+			} else {
+				lines := strings.Split(wrapText(summary, boxWidth-6), "\n")
+				if len(lines) > digestSummaryLines {
+					lines = lines[:digestSummaryLines]
+					lines[digestSummaryLines-1] += " …"
+				}
+				for _, line := range lines {
+					boxContent.WriteString("  " + line + "\n")
+				}
+			}
+		}
+	}
+
+	boxContent.WriteString("\n")
+	boxContent.WriteString(helpStyle.Render("  c: copy Markdown  f: save to file  r: refresh  enter: select  esc: close"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Weekly Digest ", boxContent.String(), boxWidth, ColorCyan)
+}
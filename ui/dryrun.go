@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// pendingStart is a session start held back for dry-run confirmation
+type pendingStart struct {
+	inst     *session.Instance
+	resumeID string
+	attach   bool // Attach once running
+	restart  bool // Stop the running session first
+}
+
+// confirmDryRun shows the commands of a pending start and waits for confirmation
+func (m *Model) confirmDryRun(p pendingStart) tea.Cmd {
+	var lines []string
+	if p.restart {
+		lines = append(lines, p.inst.StopCommandLines()...)
+	}
+	lines = append(lines, p.inst.StartCommandLines(p.resumeID)...)
+
+	m.dryRunStart = &p
+	m.dryRunCommands = lines
+	m.dryRunScroll = 0
+	m.state = stateConfirmDryRun
+	return nil
+}
+
+// restartSessionAsync stops a running session and starts it fresh in the background
+func (m *Model) restartSessionAsync(inst *session.Instance) tea.Cmd {
+	if m.dryRun {
+		return m.confirmDryRun(pendingStart{inst: inst, restart: inst.Status == session.StatusRunning})
+	}
+	if inst.Status == session.StatusRunning {
+		inst.Stop()
+	}
+	return m.runStart(inst, "", false)
+}
+
+// handleDryRunKeys handles keyboard input in the dry-run confirmation dialog
+func (m Model) handleDryRunKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.answer(msg) {
+	case answerYes, "enter":
+		p := m.dryRunStart
+		m.dryRunStart = nil
+		m.dryRunCommands = nil
+		m.state = stateList
+		if p == nil {
+			return m, nil
+		}
+		if p.restart {
+			p.inst.Stop()
+			m.storage.UpdateInstance(p.inst)
+		}
+		return m, m.runStart(p.inst, p.resumeID, p.attach)
+	case answerNo, "esc":
+		if m.dryRunStart != nil {
+			m.notifyInfo("Start of '%s' cancelled", m.dryRunStart.inst.Name)
+		}
+		m.dryRunStart = nil
+		m.dryRunCommands = nil
+		m.state = stateList
+	case "up", "k":
+		if m.dryRunScroll > 0 {
+			m.dryRunScroll--
+		}
+	case "down", "j":
+		if m.dryRunScroll < len(m.dryRunCommands)-1 {
+			m.dryRunScroll++
+		}
+	}
+	return m, nil
+}
+
+// renderCommandLines renders command lines wrapped to fit a dialog width
+func renderCommandLines(lines []string, width int) string {
+	cmdStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorCyan))
+	var b strings.Builder
+	for _, line := range lines {
+		wrapped := lipgloss.NewStyle().Width(width - 6).Render(line)
+		for j, part := range strings.Split(wrapped, "\n") {
+			prefix := "  $ "
+			if j > 0 {
+				prefix = "    "
+			}
+			b.WriteString(dimStyle.Render(prefix) + cmdStyle.Render(strings.TrimRight(part, " ")) + "\n")
+		}
+	}
+	return b.String()
+}
+
+// dryRunView renders the dry-run confirmation dialog as an overlay
+func (m Model) dryRunView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 80
+	if m.width > 120 {
+		boxWidth = 110
+	}
+
+	if m.dryRunStart != nil {
+		action := "Start"
+		if m.dryRunStart.restart {
+			action = "Restart"
+		}
+		boxContent.WriteString("  " + action + " '" + m.dryRunStart.inst.Name + "' will run:\n\n")
+	}
+
+	// Only the visible window of wrapped lines
+	rendered := strings.Split(strings.TrimRight(renderCommandLines(m.dryRunCommands[m.dryRunScroll:], boxWidth), "\n"), "\n")
+	maxVisible := m.height - 14
+	if maxVisible < 5 {
+		maxVisible = 5
+	}
+	if len(rendered) > maxVisible {
+		rendered = append(rendered[:maxVisible], dimStyle.Render("  ↓ more"))
+	}
+	boxContent.WriteString(strings.Join(rendered, "\n"))
+	boxContent.WriteString("\n\n")
+
+	boxContent.WriteString(helpStyle.Render("  y/enter: run  n/esc: cancel  ↑/↓: scroll"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Dry Run ", boxContent.String(), boxWidth, ColorYellow)
+}
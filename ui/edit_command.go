@@ -0,0 +1,274 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// currentCustomCommand returns the custom command of a window ("" if it isn't a custom agent)
+func currentCustomCommand(inst *session.Instance, windowIdx int) (string, bool) {
+	if windowIdx == 0 {
+		return inst.CustomCommand, inst.Agent == session.AgentCustom
+	}
+	for _, fw := range inst.FollowedWindows {
+		if fw.Index == windowIdx {
+			return fw.CustomCommand, fw.Agent == session.AgentCustom
+		}
+	}
+	return "", false
+}
+
+// handleEditCustomCommand opens the command editor for the active tab of a
+// Custom agent session, or the launch flags editor for other agent tabs
+func (m *Model) handleEditCustomCommand() tea.Cmd {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return nil
+	}
+	windowIdx := activeWindowIndex(inst)
+	command, ok := currentCustomCommand(inst, windowIdx)
+	if !ok {
+		return m.openLaunchFlags(inst, windowIdx)
+	}
+
+	m.editCmdTarget = inst
+	m.editCmdWindow = windowIdx
+	m.err = nil
+	m.customCmdInput.SetValue(command)
+	m.customCmdInput.CursorEnd()
+	m.customCmdInput.Focus()
+	m.state = stateEditCustomCmd
+	return textinput.Blink
+}
+
+// handleEditCustomCmdKeys handles keyboard input in the edit command dialog
+func (m Model) handleEditCustomCmdKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.err = nil
+		m.editCmdTarget = nil
+		m.customCmdInput.Blur()
+		m.state = stateList
+		return m, nil
+
+	case "enter":
+		command := strings.TrimSpace(m.customCmdInput.Value())
+		parts := strings.Fields(command)
+		if len(parts) == 0 {
+			return m, nil
+		}
+		if _, err := exec.LookPath(parts[0]); err != nil {
+			m.err = fmt.Errorf("'%s' not found - is it installed?", parts[0])
+			return m, nil
+		}
+		m.err = nil
+		m.customCmdInput.Blur()
+
+		inst := m.editCmdTarget
+		if m.editCmdWindow == 0 {
+			inst.CustomCommand = command
+		} else {
+			for idx := range inst.FollowedWindows {
+				if inst.FollowedWindows[idx].Index == m.editCmdWindow {
+					inst.FollowedWindows[idx].CustomCommand = command
+					break
+				}
+			}
+		}
+		m.storage.UpdateInstance(inst)
+
+		// Running window keeps the old command until respawned
+		if inst.Status == session.StatusRunning {
+			m.state = stateConfirmRespawnCmd
+			return m, nil
+		}
+		m.notifySuccess("Command updated for '%s'", inst.Name)
+		m.editCmdTarget = nil
+		m.state = stateList
+		return m, nil
+	}
+
+	// Clear error when typing
+	m.err = nil
+
+	var cmd tea.Cmd
+	m.customCmdInput, cmd = m.customCmdInput.Update(msg)
+	return m, cmd
+}
+
+// openLaunchFlags opens the editor of the flags appended to a window's agent command
+func (m *Model) openLaunchFlags(inst *session.Instance, windowIdx int) tea.Cmd {
+	if windowIdx != 0 && inst.GetFollowedWindow(windowIdx) == nil {
+		m.notifyWarn("This window is not tracked as a tab")
+		return nil
+	}
+	if inst.WindowAgent(windowIdx) == session.AgentTerminal {
+		m.notifyWarn("Terminal tabs have no agent command")
+		return nil
+	}
+
+	m.editCmdTarget = inst
+	m.editCmdWindow = windowIdx
+	m.err = nil
+	m.customCmdInput.SetValue(session.FormatLaunchFlags(inst.WindowLaunchFlags(windowIdx)))
+	m.customCmdInput.CursorEnd()
+	m.customCmdInput.Focus()
+	m.state = stateEditFlags
+	return textinput.Blink
+}
+
+// handleEditFlagsKeys handles keyboard input in the launch flags editor
+func (m Model) handleEditFlagsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	inst := m.editCmdTarget
+	switch msg.String() {
+	case "esc":
+		m.err = nil
+		m.editCmdTarget = nil
+		m.customCmdInput.Blur()
+		m.state = stateList
+		return m, nil
+
+	case "enter":
+		flags, err := session.ParseLaunchFlags(inst.WindowAgent(m.editCmdWindow), m.customCmdInput.Value())
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.err = nil
+		m.customCmdInput.Blur()
+		inst.SetWindowLaunchFlags(m.editCmdWindow, flags)
+		m.storage.UpdateInstance(inst)
+
+		// Running window keeps the old flags until respawned
+		if inst.Status == session.StatusRunning {
+			m.state = stateConfirmRespawnCmd
+			return m, nil
+		}
+		m.notifySuccess("Launch flags updated for '%s'", inst.Name)
+		m.editCmdTarget = nil
+		m.state = stateList
+		return m, nil
+
+	case "ctrl+d":
+		m.customCmdInput.SetValue("")
+		m.err = nil
+		return m, nil
+	}
+
+	// Clear error when typing
+	m.err = nil
+
+	var cmd tea.Cmd
+	m.customCmdInput, cmd = m.customCmdInput.Update(msg)
+	return m, cmd
+}
+
+// handleConfirmRespawnCmdKeys asks whether to restart the window with the edited command
+func (m Model) handleConfirmRespawnCmdKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.answer(msg) {
+	case answerYes:
+		inst := m.editCmdTarget
+		if err := inst.RespawnWindowWithResume(m.editCmdWindow, ""); err != nil {
+			m.notify(toastError, "Failed to respawn window: %v", err)
+		} else {
+			m.notifySuccess("Restarted '%s' with the new command", inst.Name)
+		}
+		m.editCmdTarget = nil
+		m.state = stateList
+	case answerNo, "esc":
+		m.notifyInfo("Command saved - applies on next start")
+		m.editCmdTarget = nil
+		m.state = stateList
+	}
+	return m, nil
+}
+
+// editCustomCmdView renders the edit command dialog as an overlay
+func (m Model) editCustomCmdView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+	if m.editCmdTarget != nil {
+		target := m.editCmdTarget.Name
+		if m.editCmdWindow != 0 {
+			for _, fw := range m.editCmdTarget.FollowedWindows {
+				if fw.Index == m.editCmdWindow {
+					target += " / " + fw.Name
+					break
+				}
+			}
+		}
+		boxContent.WriteString(fmt.Sprintf("  Command for '%s':\n\n", target))
+	}
+	boxContent.WriteString("  " + m.customCmdInput.View() + "\n")
+
+	// Show error if any
+	if m.err != nil {
+		boxContent.WriteString("\n")
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555")).Bold(true)
+		boxContent.WriteString(errStyle.Render(fmt.Sprintf("  ⚠ %v", m.err)))
+		boxContent.WriteString("\n")
+	}
+
+	boxContent.WriteString("\n")
+	boxContent.WriteString(helpStyle.Render("  enter: save  esc: cancel"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Edit Command ", boxContent.String(), 60, ColorAccent)
+}
+
+// editFlagsView renders the launch flags editor with the resulting command
+func (m Model) editFlagsView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 72
+	inst := m.editCmdTarget
+	target := inst.Name
+	if fw := inst.GetFollowedWindow(m.editCmdWindow); m.editCmdWindow != 0 && fw != nil {
+		target += " / " + fw.Name
+	}
+	boxContent.WriteString(fmt.Sprintf("  Flags appended to the %s command of '%s':\n\n", inst.WindowAgent(m.editCmdWindow), truncateRunes(target, 30)))
+	boxContent.WriteString("  " + m.customCmdInput.View() + "\n\n")
+
+	// Live result, or why the flags are rejected
+	agent := inst.WindowAgent(m.editCmdWindow)
+	if flags, err := session.ParseLaunchFlags(agent, m.customCmdInput.Value()); err != nil || m.err != nil {
+		if m.err != nil {
+			err = m.err
+		}
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555")).Bold(true)
+		boxContent.WriteString(errStyle.Render(fmt.Sprintf("  ⚠ %v", err)))
+	} else {
+		boxContent.WriteString(dimStyle.Render("  Runs: " + truncateRunes(inst.LaunchCommandPreview(m.editCmdWindow, flags), boxWidth-12)))
+	}
+	boxContent.WriteString("\n")
+	if inst.Profile != "" {
+		boxContent.WriteString(dimStyle.Render(fmt.Sprintf("  After the flags of profile '%s'", truncateRunes(inst.Profile, 30))))
+		boxContent.WriteString("\n")
+	}
+
+	boxContent.WriteString("\n")
+	boxContent.WriteString(helpStyle.Render("  enter: save  ctrl+d: clear  esc: cancel"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Launch Flags ", boxContent.String(), boxWidth, ColorAccent)
+}
+
+// confirmRespawnCmdView renders the respawn confirmation after a command edit
+func (m Model) confirmRespawnCmdView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+	boxContent.WriteString("  Restart the window now to apply the new command?\n")
+	boxContent.WriteString(dimStyle.Render("  The running process will be killed."))
+	boxContent.WriteString("\n\n")
+	boxContent.WriteString(helpStyle.Render("  y: restart now  n: apply on next start"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Apply Command ", boxContent.String(), 56, "#FFB86C")
+}
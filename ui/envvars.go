@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// openEnvVars opens the environment variables dialog of the selected session,
+// on the active tab's own variables when a followed tab is active
+func (m *Model) openEnvVars() {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return
+	}
+	window := 0
+	if inst.Status == session.StatusRunning {
+		if idx := inst.GetCurrentWindowIndex(); inst.GetFollowedWindow(idx) != nil {
+			window = idx
+		}
+	}
+	m.envTarget = inst
+	m.loadEnvVars(window)
+	m.state = stateEnvVars
+}
+
+// loadEnvVars fills the dialog with the variables of a scope (0 = the session)
+func (m *Model) loadEnvVars(window int) {
+	m.envWindow = window
+	env := m.envTarget.Env
+	if window != 0 {
+		env = m.envTarget.TabEnv(window)
+	}
+	m.envInput.SetValue(session.FormatEnvVars(env))
+	m.envInput.Focus()
+}
+
+// envScopeName returns the name of the scope being edited
+func (m Model) envScopeName() string {
+	if fw := m.envTarget.GetFollowedWindow(m.envWindow); m.envWindow != 0 && fw != nil {
+		return "tab " + fw.Name
+	}
+	return "session " + m.envTarget.Name
+}
+
+// handleEnvVarsKeys handles keyboard input in the environment variables dialog
+func (m Model) handleEnvVarsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	inst := m.envTarget
+	switch msg.String() {
+	case "esc":
+		m.envTarget = nil
+		m.envInput.Blur()
+		m.state = stateList
+		return m, nil
+
+	case "tab":
+		// Switch between the session's variables and the active tab's own ones
+		if m.envWindow != 0 {
+			m.loadEnvVars(0)
+		} else if inst.Status == session.StatusRunning {
+			if idx := inst.GetCurrentWindowIndex(); inst.GetFollowedWindow(idx) != nil {
+				m.loadEnvVars(idx)
+			}
+		}
+		return m, nil
+
+	case "ctrl+s":
+		env, err := session.ParseEnvVars(m.envInput.Value())
+		if err != nil {
+			m.notify(toastError, "Environment variables: %v", err)
+			return m, nil
+		}
+		inst.SetTabEnv(m.envWindow, env)
+		m.storage.UpdateInstance(inst)
+		scope := m.envScopeName()
+		m.envTarget = nil
+		m.envInput.Blur()
+		m.state = stateList
+		if inst.Status == session.StatusRunning {
+			m.notifySuccess("Saved %d variable(s) of %s (applies when it is restarted)", len(env), scope)
+		} else {
+			m.notifySuccess("Saved %d variable(s) of %s", len(env), scope)
+		}
+		return m, nil
+
+	case "ctrl+d":
+		m.envInput.SetValue("")
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.envInput, cmd = m.envInput.Update(msg)
+	return m, cmd
+}
+
+// envVarsView renders the environment variables dialog
+func (m Model) envVarsView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 74
+	inst := m.envTarget
+	boxContent.WriteString(fmt.Sprintf("  Exported into %s\n", truncateRunes(m.envScopeName(), 50)))
+	if m.envWindow != 0 && len(inst.Env) > 0 {
+		// The tab inherits the session's variables; show which ones it overrides
+		boxContent.WriteString(dimStyle.Render(fmt.Sprintf("  Also inherits %d session variable(s); names set here win", len(inst.Env))))
+		boxContent.WriteString("\n")
+	}
+	boxContent.WriteString("\n")
+
+	m.envInput.SetWidth(boxWidth - 8)
+	for _, line := range strings.Split(m.envInput.View(), "\n") {
+		boxContent.WriteString("  " + line + "\n")
+	}
+	boxContent.WriteString("\n")
+
+	var secrets []string
+	if env, err := session.ParseEnvVars(m.envInput.Value()); err == nil {
+		for name := range env {
+			if session.SecretEnvName(name) {
+				secrets = append(secrets, name)
+			}
+		}
+	}
+	if len(secrets) > 0 {
+		boxContent.WriteString(dimStyle.Render(fmt.Sprintf("  %d secret(s) masked in the dry-run command list", len(secrets))))
+		boxContent.WriteString("\n")
+	}
+	if inst.Runtime == session.RuntimeDocker || inst.Runtime == session.RuntimeDevcontainer {
+		boxContent.WriteString(dimStyle.Render("  Forwarded into the container by name"))
+		boxContent.WriteString("\n")
+	}
+
+	helpText := "  ctrl+s: save  tab: session/tab  ctrl+d: clear  esc: cancel"
+	boxContent.WriteString(helpStyle.Render(helpText))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Environment Variables ", boxContent.String(), boxWidth, ColorAccent)
+}
@@ -0,0 +1,28 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/izll/agent-session-manager/session"
+)
+
+// agentExited reports whether a running session's main agent has exited while
+// its tmux session lives on (the pane is kept dead by remain-on-exit)
+func (m Model) agentExited(inst *session.Instance) bool {
+	return inst.Status == session.StatusRunning && m.paneDead[inst.ID]
+}
+
+// exitedSummary describes how the main agent exited ("" while it runs)
+func (m Model) exitedSummary(inst *session.Instance) string {
+	if !m.agentExited(inst) {
+		return ""
+	}
+	code, ok := m.paneExit[inst.ID]
+	switch {
+	case !ok || code < 0:
+		return "agent exited"
+	case code == 0:
+		return "agent exited normally"
+	}
+	return fmt.Sprintf("agent crashed (exit %d)", code)
+}
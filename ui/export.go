@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// reportInstances returns the sessions shown in the list (respecting the search filter)
+func (m Model) reportInstances() []*session.Instance {
+	var result []*session.Instance
+	for _, inst := range m.instances {
+		if m.searchActive && !m.matchesSearch(inst) {
+			continue
+		}
+		result = append(result, inst)
+	}
+	return result
+}
+
+// reportStatus describes a session's state for the status report
+func (m Model) reportStatus(inst *session.Instance) string {
+	if inst.Status != session.StatusRunning {
+		return "stopped"
+	}
+	switch m.activityState[inst.ID] {
+	case session.ActivityBusy:
+		return "busy"
+	case session.ActivityWaiting:
+		return "waiting"
+	default:
+		return "idle"
+	}
+}
+
+// markdownCell makes text safe for a single Markdown table cell
+func markdownCell(s string, maxLen int) string {
+	s = strings.Join(strings.Fields(stripANSI(s)), " ")
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return truncateRunes(s, maxLen)
+}
+
+// buildStatusReport renders the visible sessions as a Markdown table
+func (m Model) buildStatusReport() string {
+	groupNames := make(map[string]string, len(m.groups))
+	for _, g := range m.groups {
+		groupNames[g.ID] = g.Name
+	}
+
+	var b strings.Builder
+	title := "Sessions"
+	if m.activeProject != nil {
+		title = m.activeProject.Name + " sessions"
+	}
+	fmt.Fprintf(&b, "### %s - %s\n\n", title, time.Now().Format("2006-01-02 15:04"))
+	if m.searchActive {
+		fmt.Fprintf(&b, "_Filter: `%s`_\n\n", m.searchQuery)
+	}
+
+	b.WriteString("| Session | Group | Agent | Status | Notes | Last output |\n")
+	b.WriteString("|---|---|---|---|---|---|\n")
+	for _, inst := range m.reportInstances() {
+		notes := inst.Notes
+		if idx := strings.Index(notes, "\n"); idx != -1 {
+			notes = notes[:idx]
+		}
+		lastLine := m.lastLines[inst.ID]
+		if inst.Status != session.StatusRunning && inst.LastOutput != "" {
+			lines := strings.Split(inst.LastOutput, "\n")
+			lastLine = lines[len(lines)-1]
+		}
+		agent := string(inst.Agent)
+		if agent == "" {
+			agent = string(session.AgentClaude)
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s | %s | %s |\n",
+			markdownCell(inst.Name, 40),
+			markdownCell(groupNames[inst.GroupID], 30),
+			agent,
+			m.reportStatus(inst),
+			markdownCell(notes, 80),
+			markdownCell(lastLine, 80))
+	}
+	return b.String()
+}
+
+// copyToClipboard copies text using the platform clipboard tool, falling back to OSC 52
+func (m *Model) copyToClipboard(text string) string {
+	var candidates [][]string
+	if runtime.GOOS == "darwin" {
+		candidates = append(candidates, []string{"pbcopy"})
+	}
+	if os.Getenv("WAYLAND_DISPLAY") != "" {
+		candidates = append(candidates, []string{"wl-copy"})
+	}
+	candidates = append(candidates,
+		[]string{"xclip", "-selection", "clipboard"},
+		[]string{"xsel", "--clipboard", "--input"})
+
+	for _, c := range candidates {
+		if _, err := exec.LookPath(c[0]); err != nil {
+			continue
+		}
+		cmd := exec.Command(c[0], c[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err == nil {
+			return c[0]
+		}
+	}
+
+	// Terminal clipboard (works over SSH in most modern terminals)
+	m.emitOSC("\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\x07")
+	return "terminal (OSC 52)"
+}
+
+// writeStatusReport saves the report as a Markdown file in the current directory
+func writeStatusReport(text string) (string, error) {
+	return writeMarkdownFile("asmgr-status", text)
+}
+
+// writeMarkdownFile saves text as <prefix>-<timestamp>.md in the current directory
+func writeMarkdownFile(prefix, text string) (string, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		dir, _ = os.UserHomeDir()
+	}
+	path := filepath.Join(dir, fmt.Sprintf("%s-%s.md", prefix, time.Now().Format("20060102-150405")))
+	if err := os.WriteFile(path, []byte(text), 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// handleExportReportKeys handles keyboard input in the export dialog
+func (m Model) handleExportReportKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "c", "C", "enter":
+		method := m.copyToClipboard(m.buildStatusReport())
+		m.notifySuccess("Status report copied via %s", method)
+		m.state = stateList
+	case "f", "F":
+		path, err := writeStatusReport(m.buildStatusReport())
+		if err != nil {
+			m.notify(toastError, "Failed to write report: %v", err)
+		} else {
+			m.notifySuccess("Status report saved to %s", path)
+		}
+		m.state = stateList
+	case "esc", "q":
+		m.state = stateList
+	}
+	return m, nil
+}
+
+// exportReportView renders the export dialog as an overlay
+func (m Model) exportReportView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+	count := len(m.reportInstances())
+	boxContent.WriteString(fmt.Sprintf("  Export %d session(s) as Markdown table\n", count))
+	if m.searchActive {
+		boxContent.WriteString(dimStyle.Render(fmt.Sprintf("  (filtered by \"%s\")", m.searchQuery)))
+		boxContent.WriteString("\n")
+	}
+	boxContent.WriteString("\n")
+	boxContent.WriteString("  c: Copy to clipboard\n")
+	boxContent.WriteString("  f: Save to file (current directory)\n\n")
+	boxContent.WriteString(helpStyle.Render("  esc: cancel"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Export Status Report ", boxContent.String(), 50, ColorCyan)
+}
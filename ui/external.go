@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// ExternalCheckTicks is how often (in ticks) sessions.json is checked for changes of other processes
+const ExternalCheckTicks = 20
+
+// ExternalSnooze is how long "decide later" keeps the merge dialog closed
+const ExternalSnooze = time.Minute
+
+// externalChangeRows caps the sessions listed in the merge dialog
+const externalChangeRows = 12
+
+// checkExternalChanges opens the merge dialog when another process changed
+// the project's sessions.json since it was loaded or saved here. Changes of
+// the YOLO helpers run from tmux are merged without asking.
+func (m *Model) checkExternalChanges() {
+	if m.globalList {
+		return
+	}
+	change, err := m.storage.ExternalChanges(m.instances)
+	if err != nil || change == nil {
+		return
+	}
+	if change.Helper {
+		anchor := m.rememberSelection()
+		instances, groups, err := m.storage.MergeExternal(change, m.instances, m.groups)
+		if err != nil {
+			m.notify(toastError, "Failed to merge sessions.json: %v", err)
+			return
+		}
+		m.instances, m.groups = instances, groups
+		m.afterExternalChange(anchor)
+		return
+	}
+	if time.Now().Before(m.externalSnoozeUntil) {
+		return
+	}
+	m.externalChange = change
+	m.state = stateExternalChange
+}
+
+// checkRefusedSave tells the user when a save was refused because of changes
+// of another process, and opens the merge dialog for them
+func (m *Model) checkRefusedSave() {
+	if !m.storage.RefusedSave() {
+		return
+	}
+	m.notify(toastError, "Not saved: %v", session.ErrExternalChange)
+	if m.state == stateList {
+		m.externalSnoozeUntil = time.Time{}
+		m.checkExternalChanges()
+	}
+}
+
+// handleExternalChangeKeys handles keyboard input in the merge dialog
+func (m Model) handleExternalChangeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	change := m.externalChange
+	anchor := m.rememberSelection()
+	switch msg.String() {
+	case "enter", "m":
+		instances, groups, err := m.storage.MergeExternal(change, m.instances, m.groups)
+		if err != nil {
+			m.showError(fmt.Errorf("failed to merge sessions.json: %w", err))
+			return m, nil
+		}
+		m.instances, m.groups = instances, groups
+		m.notifySuccess("Merged the changes to sessions.json")
+	case "k":
+		if err := m.storage.KeepLocal(change, m.instances, m.groups); err != nil {
+			m.showError(fmt.Errorf("failed to save sessions.json: %w", err))
+			return m, nil
+		}
+		m.notifyInfo("Kept this list - the other changes were overwritten")
+	case "t":
+		instances, groups, err := m.storage.TakeExternal(change)
+		if err != nil {
+			m.showError(fmt.Errorf("failed to reload sessions.json: %w", err))
+			return m, nil
+		}
+		m.instances, m.groups = instances, groups
+		m.notifyInfo("Reloaded sessions.json - changes made here since the last save were dropped")
+	case "esc", "q":
+		m.externalSnoozeUntil = time.Now().Add(ExternalSnooze)
+		m.externalChange = nil
+		m.state = stateList
+		m.notifyWarn("Saving is paused until the changes to sessions.json are merged")
+		return m, nil
+	default:
+		return m, nil
+	}
+	m.afterExternalChange(anchor)
+	m.externalChange = nil
+	m.state = stateList
+	return m, nil
+}
+
+// afterExternalChange refreshes the sessions taken over from the file
+func (m *Model) afterExternalChange(anchor selectionAnchor) {
+	for _, inst := range m.instances {
+		inst.UpdateStatus()
+	}
+	m.detectMissingPaths()
+	m.restoreSelection(anchor)
+}
+
+// externalChangeView renders the merge dialog as an overlay
+func (m Model) externalChangeView() string {
+	change := m.externalChange
+	if change == nil {
+		return m.listView()
+	}
+	addedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorGreen))
+	removedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorRed))
+	conflictStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorOrange))
+
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+	boxWidth := 70
+	boxContent.WriteString("  sessions.json was changed by another process:\n\n")
+
+	for idx, c := range change.Sessions {
+		if idx == externalChangeRows {
+			boxContent.WriteString(dimStyle.Render(fmt.Sprintf("  … and %d more", len(change.Sessions)-idx)))
+			boxContent.WriteString("\n")
+			break
+		}
+		name := truncateRunes(c.Name, 30)
+		switch c.Kind {
+		case session.ChangeAdded:
+			boxContent.WriteString(addedStyle.Render("  + " + name))
+		case session.ChangeRemoved:
+			boxContent.WriteString(removedStyle.Render("  - " + name))
+		default:
+			boxContent.WriteString("  ~ " + name + dimStyle.Render(" "+truncateRunes(strings.Join(c.Fields, ", "), 34)))
+		}
+		boxContent.WriteString("\n")
+		if len(c.Conflicts) > 0 {
+			boxContent.WriteString(conflictStyle.Render("      also changed here: " + truncateRunes(strings.Join(c.Conflicts, ", "), 40)))
+			boxContent.WriteString("\n")
+		}
+	}
+	if change.Groups > 0 {
+		boxContent.WriteString(dimStyle.Render(fmt.Sprintf("  %d group(s) changed", change.Groups)))
+		boxContent.WriteString("\n")
+	}
+
+	boxContent.WriteString("\n")
+	boxContent.WriteString("  enter  Merge - fields changed on both sides keep the value from here\n")
+	boxContent.WriteString("  k      Keep this list and overwrite the other changes\n")
+	boxContent.WriteString("  t      Take the file and drop changes made here since the last save\n\n")
+	boxContent.WriteString(dimStyle.Render("  Nothing is saved until you choose"))
+	boxContent.WriteString("\n\n")
+	boxContent.WriteString(helpStyle.Render("  enter/m: merge  k: keep mine  t: take theirs  esc: later"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" sessions.json Changed ", boxContent.String(), boxWidth, ColorOrange)
+}
@@ -0,0 +1,353 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// FilePreviewLines is how many lines of the file under the cursor are read
+const FilePreviewLines = 400
+
+// fileEditedMsg is sent when the editor opened from the file browser exits
+type fileEditedMsg struct {
+	err error
+}
+
+// inFiles reports whether the preview pane shows the file browser
+func (m Model) inFiles() bool {
+	return m.state == stateFiles && m.filesTarget != nil
+}
+
+// openFiles switches the preview pane of the selected session to its file browser
+func (m *Model) openFiles() {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return
+	}
+	if inst.IsRemote() {
+		m.notifyWarn("The file browser shows local directories - '%s' runs on %s", inst.Name, inst.Host)
+		return
+	}
+	if info, err := os.Stat(inst.Path); err != nil || !info.IsDir() {
+		m.notifyWarn("'%s' does not exist", inst.Path)
+		return
+	}
+	m.filesTarget = inst
+	m.filesExpanded = make(map[string]bool)
+	m.filesCursor = 0
+	m.filesMentions = false
+	if !m.reloadFiles() {
+		m.filesTarget = nil
+		return
+	}
+	m.state = stateFiles
+}
+
+// reloadFiles re-reads the tree, keeping the cursor on the same entry when it still exists
+func (m *Model) reloadFiles() bool {
+	current := ""
+	if node := m.currentFile(); node != nil {
+		current = node.Path
+	}
+	nodes, err := session.FileTree(m.filesTarget.Path, m.filesExpanded, m.filesHidden)
+	if err != nil {
+		m.notify(toastError, "Reading '%s' failed: %v", m.filesTarget.Path, err)
+		return false
+	}
+	m.fileNodes = nodes
+	for idx, node := range nodes {
+		if node.Path == current {
+			m.filesCursor = idx
+		}
+	}
+	if m.filesCursor >= len(nodes) {
+		m.filesCursor = len(nodes) - 1
+	}
+	if m.filesCursor < 0 {
+		m.filesCursor = 0
+	}
+	m.loadFileDetail()
+	return true
+}
+
+// currentFile returns the entry under the cursor (nil for an empty directory)
+func (m Model) currentFile() *session.FileNode {
+	if m.filesCursor < 0 || m.filesCursor >= len(m.fileNodes) {
+		return nil
+	}
+	return &m.fileNodes[m.filesCursor]
+}
+
+// loadFileDetail reads what the right column shows for the entry under the
+// cursor: the file's first lines, or where the conversation mentions it
+func (m *Model) loadFileDetail() {
+	m.filesDetail = nil
+	node := m.currentFile()
+	if node == nil {
+		return
+	}
+	if node.Dir {
+		nodes, err := session.FileTree(filepath.Join(m.filesTarget.Path, node.Path), nil, m.filesHidden)
+		if err != nil {
+			m.filesDetail = []string{dimStyle.Render(err.Error())}
+			return
+		}
+		m.filesDetail = []string{dimStyle.Render(fmt.Sprintf("%d entries", len(nodes)))}
+		for _, child := range nodes {
+			m.filesDetail = append(m.filesDetail, fileNodeLabel(child))
+		}
+		return
+	}
+
+	if m.filesMentions {
+		mentions, err := m.filesTarget.FileMentions(node.Path)
+		switch {
+		case err != nil:
+			m.filesDetail = []string{dimStyle.Render(err.Error())}
+		case len(mentions) == 0:
+			m.filesDetail = []string{dimStyle.Render("Not mentioned in the conversation")}
+		}
+		for idx := len(mentions) - 1; idx >= 0; idx-- { // Newest first
+			mention := mentions[idx]
+			label := mention.Role
+			if mention.Tool != "" {
+				label = mention.Tool
+			}
+			line := dimStyle.Render(mention.Time.Local().Format("Jan 2 15:04")+" ") + projectLabelStyle.Render(label)
+			if mention.Snippet != "" {
+				line += " " + mention.Snippet
+			}
+			m.filesDetail = append(m.filesDetail, line)
+		}
+		return
+	}
+
+	lines, err := session.FilePreview(filepath.Join(m.filesTarget.Path, node.Path), FilePreviewLines)
+	if err != nil {
+		m.filesDetail = []string{dimStyle.Render(err.Error())}
+		return
+	}
+	m.filesDetail = highlightCode(strings.Join(lines, "\n"), node.Name)
+}
+
+// fileNodeLabel returns the name of a tree entry, directories with a trailing slash
+func fileNodeLabel(node session.FileNode) string {
+	if node.Dir {
+		return projectNameStyle.Render(node.Name + "/")
+	}
+	return node.Name
+}
+
+// editorCommand opens path in $VISUAL or $EDITOR (vi when neither is set)
+func editorCommand(path, dir string) *exec.Cmd {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+	args := strings.Fields(editor)
+	cmd := exec.Command(args[0], append(args[1:], path)...)
+	cmd.Dir = dir
+	return cmd
+}
+
+// openInEditor runs the editor on the entry under the cursor, suspending the TUI
+func (m *Model) openInEditor() tea.Cmd {
+	node := m.currentFile()
+	if node == nil {
+		return nil
+	}
+	cmd := editorCommand(filepath.Join(m.filesTarget.Path, node.Path), m.filesTarget.Path)
+	return execProcess(cmd, func(err error) tea.Msg {
+		return fileEditedMsg{err: err}
+	})
+}
+
+// handleFileEdited refreshes the browser after the editor exits
+func (m Model) handleFileEdited(msg fileEditedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.notify(toastError, "Editor failed: %v", msg.err)
+	}
+	if m.inFiles() {
+		m.reloadFiles()
+	}
+	return m, nil
+}
+
+// handleFilesKeys handles keyboard input in the file browser
+func (m Model) handleFilesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	node := m.currentFile()
+	switch msg.String() {
+	case "esc", "q":
+		m.filesTarget = nil
+		m.fileNodes = nil
+		m.filesDetail = nil
+		m.state = stateList
+		return m, nil
+	case "up", "k":
+		if m.filesCursor > 0 {
+			m.filesCursor--
+			m.loadFileDetail()
+		}
+	case "down", "j":
+		if m.filesCursor < len(m.fileNodes)-1 {
+			m.filesCursor++
+			m.loadFileDetail()
+		}
+	case "pgup":
+		m.filesCursor = max(m.filesCursor-10, 0)
+		m.loadFileDetail()
+	case "pgdown":
+		m.filesCursor = max(min(m.filesCursor+10, len(m.fileNodes)-1), 0)
+		m.loadFileDetail()
+	case "home":
+		m.filesCursor = 0
+		m.loadFileDetail()
+	case "end":
+		m.filesCursor = max(len(m.fileNodes)-1, 0)
+		m.loadFileDetail()
+	case "right", "l":
+		if node != nil && node.Dir && !m.filesExpanded[node.Path] {
+			m.filesExpanded[node.Path] = true
+			m.reloadFiles()
+		}
+	case "left", "h":
+		if node == nil {
+			break
+		}
+		if node.Dir && m.filesExpanded[node.Path] {
+			delete(m.filesExpanded, node.Path)
+			m.reloadFiles()
+			break
+		}
+		// Go to the parent directory
+		parent := filepath.Dir(node.Path)
+		for idx := m.filesCursor - 1; idx >= 0; idx-- {
+			if m.fileNodes[idx].Path == parent {
+				m.filesCursor = idx
+				m.loadFileDetail()
+				break
+			}
+		}
+	case "enter":
+		if node == nil {
+			break
+		}
+		if node.Dir {
+			if m.filesExpanded[node.Path] {
+				delete(m.filesExpanded, node.Path)
+			} else {
+				m.filesExpanded[node.Path] = true
+			}
+			m.reloadFiles()
+			break
+		}
+		return m, m.openInEditor()
+	case "e":
+		return m, m.openInEditor()
+	case "tab":
+		if m.filesTarget.Agent != session.AgentClaude && m.filesTarget.Agent != "" {
+			m.notifyInfo("Transcript mentions need a Claude session")
+			break
+		}
+		m.filesMentions = !m.filesMentions
+		m.loadFileDetail()
+	case ".":
+		m.filesHidden = !m.filesHidden
+		m.reloadFiles()
+	case "r":
+		m.reloadFiles()
+	}
+	return m, nil
+}
+
+// filesContent renders the file browser in the preview pane: the tree on the
+// left, the contents or transcript mentions of the entry under the cursor on the right
+func (m Model) filesContent(width, height int) string {
+	if len(m.fileNodes) == 0 {
+		return dimStyle.Render("  Empty directory")
+	}
+	treeWidth := width * 2 / 5
+	if treeWidth < 24 {
+		treeWidth = 24
+	}
+	detailWidth := width - treeWidth - 3
+
+	// Keep the cursor in the upper third of the tree
+	start := m.filesCursor - height/3
+	if start > len(m.fileNodes)-height {
+		start = len(m.fileNodes) - height
+	}
+	if start < 0 {
+		start = 0
+	}
+
+	var tree []string
+	for idx := start; idx < len(m.fileNodes) && len(tree) < height; idx++ {
+		node := m.fileNodes[idx]
+		marker := "  "
+		if node.Dir {
+			marker = "▸ "
+			if m.filesExpanded[node.Path] {
+				marker = "▾ "
+			}
+		}
+		nameWidth := max(treeWidth-4-2*node.Depth, 4)
+		label := strings.Repeat("  ", node.Depth) + marker + truncateRunes(node.Name, nameWidth)
+		if node.Dir {
+			label = strings.Repeat("  ", node.Depth) + marker + projectNameStyle.Render(truncateRunes(node.Name+"/", nameWidth))
+		}
+		prefix := "  "
+		if idx == m.filesCursor {
+			prefix = "❯ "
+		}
+		tree = append(tree, prefix+label)
+	}
+
+	var detail []string
+	for _, line := range m.filesDetail {
+		if len(detail) == height {
+			break
+		}
+		detail = append(detail, ansi.Truncate(line, detailWidth, "…")+"\x1b[0m")
+	}
+
+	left := lipgloss.NewStyle().Width(treeWidth).Render(strings.Join(tree, "\n"))
+	sep := dimStyle.Render(strings.TrimSuffix(strings.Repeat(" │ \n", max(len(tree), len(detail))), "\n"))
+	right := strings.Join(detail, "\n")
+	return lipgloss.JoinHorizontal(lipgloss.Top, left, sep, right)
+}
+
+// filesHeader returns the View line hint of the file browser
+func (m Model) filesHeader() string {
+	mode := "Files"
+	if m.filesMentions {
+		mode = "Files · mentions"
+	}
+	hint := " enter/e: open in editor · tab: contents/mentions · .: hidden · esc: back"
+	if node := m.currentFile(); node != nil && !node.Dir {
+		hint = fmt.Sprintf(" %s · %s", node.Path, formatFileSize(node.Size)) + hint
+	}
+	return projectLabelStyle.Render("View: ") + projectNameStyle.Render(mode) + dimStyle.Render(hint)
+}
+
+// formatFileSize formats a file size in B, KB or MB
+func formatFileSize(size int64) string {
+	switch {
+	case size < 1024:
+		return fmt.Sprintf("%d B", size)
+	case size < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	}
+	return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+}
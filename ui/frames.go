@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// FrameBudget is how long one View() call may take (a 60 fps frame)
+const FrameBudget = 16 * time.Millisecond
+
+// frameWindow is how many recent frames the debug summary covers
+const frameWindow = 120
+
+// frameSummaryInterval is how often the debug log gets a frame summary
+const frameSummaryInterval = 10 * time.Second
+
+// frameStats times View() in debug mode and logs slow frames to debug.log
+type frameStats struct {
+	times  []time.Duration // The last frameWindow frame times (ring)
+	next   int             // Ring position of the next frame
+	total  int             // Frames rendered
+	slow   int             // Frames over FrameBudget
+	logged time.Time       // Last summary written
+	log    *os.File
+}
+
+// DebugLogPath returns the path of the debug log
+func DebugLogPath() string {
+	homeDir, _ := os.UserHomeDir()
+	return filepath.Join(homeDir, ".config", "agent-session-manager", "debug.log")
+}
+
+// newFrameStats starts frame timing when ASMGR_DEBUG is set (nil otherwise)
+func newFrameStats() *frameStats {
+	if os.Getenv("ASMGR_DEBUG") == "" {
+		return nil
+	}
+	log, err := os.OpenFile(DebugLogPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil
+	}
+	fmt.Fprintf(log, "%s debug mode, frame budget %s\n", time.Now().Format(time.RFC3339), FrameBudget)
+	return &frameStats{log: log, logged: time.Now()}
+}
+
+// add records one frame, logging it when it went over the budget
+func (f *frameStats) add(d time.Duration, s state) {
+	if len(f.times) < frameWindow {
+		f.times = append(f.times, d)
+	} else {
+		f.times[f.next] = d
+	}
+	f.next = (f.next + 1) % frameWindow
+	f.total++
+
+	now := time.Now()
+	if d > FrameBudget {
+		f.slow++
+		fmt.Fprintf(f.log, "%s slow frame: %s (state %d)\n", now.Format(time.RFC3339), d.Round(time.Microsecond), s)
+	}
+	if now.Sub(f.logged) >= frameSummaryInterval {
+		f.logged = now
+		fmt.Fprintf(f.log, "%s %s\n", now.Format(time.RFC3339), f.summary())
+	}
+}
+
+// summary describes the recent frame times
+func (f *frameStats) summary() string {
+	if len(f.times) == 0 {
+		return "no frames"
+	}
+	sorted := append([]time.Duration{}, f.times...)
+	sort.Slice(sorted, func(a, b int) bool { return sorted[a] < sorted[b] })
+	var sum time.Duration
+	for _, d := range sorted {
+		sum += d
+	}
+	avg := sum / time.Duration(len(sorted))
+	p95 := sorted[len(sorted)*95/100]
+	return fmt.Sprintf("last %d frames: avg %s p95 %s max %s, %d of %d frames over budget",
+		len(sorted), avg.Round(time.Microsecond), p95.Round(time.Microsecond),
+		sorted[len(sorted)-1].Round(time.Microsecond), f.slow, f.total)
+}
@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"github.com/izll/agent-session-manager/session"
+)
+
+// setGlobalList switches between the active project's sessions and the sessions
+// of all projects; actions in the cross-project list are saved to each session's own project
+func (m *Model) setGlobalList(on bool) error {
+	selected := m.getSelectedInstance()
+
+	var instances []*session.Instance
+	var groups []*session.Group
+	var err error
+	if on {
+		instances, err = m.storage.LoadGlobal()
+	} else {
+		m.storage.LeaveGlobal()
+		m.storage.WatchExternalChanges()
+		instances, groups, err = m.storage.LoadAll()
+	}
+	if err != nil {
+		return err
+	}
+
+	m.globalList = on
+	m.instances = instances
+	m.groups = groups // Groups are per project - the cross-project list is flat
+	for _, inst := range m.instances {
+		if _, ok := m.lastLines[inst.ID]; !ok {
+			inst.UpdateStatus()
+			m.lastLines[inst.ID] = inst.GetLastLine()
+		}
+	}
+
+	m.detectMissingPaths()
+
+	m.cursor = 0
+	if selected != nil {
+		m.selectInstance(selected)
+	}
+	m.resetScroll()
+	return nil
+}
+
+// toggleGlobalList turns the cross-project list on or off (leader a)
+func (m *Model) toggleGlobalList() {
+	if err := m.setGlobalList(!m.globalList); err != nil {
+		m.showError(err)
+		return
+	}
+	m.saveSettings()
+	if !m.globalList {
+		m.notifyInfo("Showing this project's sessions")
+		return
+	}
+
+	// Other asmgr instances may be editing the same session files
+	openElsewhere := 0
+	for _, p := range m.projects {
+		if m.activeProject != nil && p.ID == m.activeProject.ID {
+			continue
+		}
+		if locked, _ := m.storage.IsProjectLocked(p.ID); locked {
+			openElsewhere++
+		}
+	}
+	if m.activeProject != nil {
+		if locked, _ := m.storage.IsProjectLocked(""); locked {
+			openElsewhere++
+		}
+	}
+	if openElsewhere > 0 {
+		m.notifyWarn("Showing all projects - %d of them open in another asmgr, changes there may be overwritten", openElsewhere)
+		return
+	}
+	m.notifyInfo("Showing sessions of all projects (g a to go back)")
+}
+
+// sessionProjectName returns the name of the project a session is stored in ("" = no project)
+func (m Model) sessionProjectName(inst *session.Instance) string {
+	projectID := m.storage.ProjectOf(inst.ID)
+	for _, p := range m.projects {
+		if p.ID == projectID {
+			return p.Name
+		}
+	}
+	return projectID
+}
+
+// projectBadge returns the owning project label of a session in the cross-project list
+func (m Model) projectBadge(inst *session.Instance) string {
+	if !m.globalList {
+		return ""
+	}
+	name := m.sessionProjectName(inst)
+	if name == "" {
+		name = "no project"
+	}
+	return " [" + truncateRunes(name, 14) + "]"
+}
@@ -11,7 +11,6 @@ import (
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/izll/agent-session-manager/session"
-	"github.com/izll/agent-session-manager/updater"
 )
 
 // handleNewNameKeys handles keyboard input in the new session name dialog
@@ -21,6 +20,7 @@ func (m Model) handleNewNameKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.pendingInstance = nil
 		m.isParallelSession = false
 		m.parallelOriginalID = ""
+		m.parallelWorktree = false
 		m.state = stateList
 		return m, nil
 	case "enter":
@@ -39,9 +39,26 @@ func (m Model) handleNewNameKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					m.isParallelSession = false
 					m.pendingInstance = nil
 					m.parallelOriginalID = ""
+					m.parallelWorktree = false
 					return m, nil
 				}
 
+				// Own worktree on a new branch, so the sessions don't share a working tree
+				if m.parallelWorktree {
+					path, wt, err := session.CreateWorktree(inst.Path, inst.Name)
+					m.isParallelSession = false
+					m.pendingInstance = nil
+					m.parallelWorktree = false
+					if err != nil {
+						m.parallelOriginalID = ""
+						m.state = stateList
+						m.showError(err)
+						return m, nil
+					}
+					inst.Path = path
+					inst.Worktree = wt
+				}
+
 				// Find the original instance and insert after it
 				currentIdx := m.findInstanceIndex(m.parallelOriginalID)
 				if currentIdx >= 0 {
@@ -51,142 +68,121 @@ func (m Model) handleNewNameKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 					m.instances = append(m.instances, inst)
 				}
 
-				// Start the new instance
-				if err := inst.Start(); err != nil {
-					m.err = err
-					m.previousState = stateList
-					m.state = stateError
-				} else {
-					m.storage.Save(m.instances)
-
-					// Rebuild visible items and find the new instance
-					if len(m.groups) > 0 {
-						m.buildVisibleItems()
-						for i, item := range m.visibleItems {
-							if !item.isGroup && item.instance != nil && item.instance.ID == inst.ID {
-								m.cursor = i
-								break
-							}
-						}
-					} else {
-						// Non-grouped mode: find instance index
-						for i, existingInst := range m.instances {
-							if existingInst.ID == inst.ID {
-								m.cursor = i
-								break
-							}
-						}
-					}
-				}
+				m.storage.Save(m.instances)
+				m.selectInstance(inst)
 
 				m.pendingInstance = nil
 				m.isParallelSession = false
 				m.parallelOriginalID = ""
 				m.state = stateList
-				return m, nil
-			}
+				if inst.Worktree != nil {
+					m.notifySuccess("Created worktree on branch %s", inst.Worktree.Branch)
+				}
 
-			// Normal session creation: create new instance
-			inst, err := session.NewInstance(m.nameInput.Value(), m.pathInput.Value(), m.autoYes, m.pendingAgent)
-			if err != nil {
-				m.err = err
-				m.previousState = stateList
-				m.state = stateError
-				return m, nil
+				// Start the new instance in the background
+				return m, m.startSessionAsync(inst, "", false)
 			}
 
-			// Set custom command for custom agent
-			if m.pendingAgent == session.AgentCustom {
-				inst.CustomCommand = m.customCmdInput.Value()
+			// Docker runtime: ask for the container next
+			if m.pendingRuntime == session.RuntimeDocker {
+				m.nameInput.Blur()
+				m.containerInput.Focus()
+				m.state = stateNewContainer
+				return m, textinput.Blink
 			}
+			return m.createNewInstance()
+		}
+	case "tab":
+		// Cycle where the agent runs (parallel sessions keep the original's)
+		if !m.isParallelSession {
+			m.pendingRuntime = nextRuntime(m.pendingRuntime)
+		}
+		return m, nil
+	}
 
-			// Assign to current group if any
-			if m.pendingGroupID != "" {
-				inst.GroupID = m.pendingGroupID
-			}
+	var cmd tea.Cmd
+	m.nameInput, cmd = m.nameInput.Update(msg)
+	return m, cmd
+}
 
-			// Check if the agent command exists before creating session
-			if err := session.CheckAgentCommand(inst); err != nil {
-				m.err = err
-				m.previousState = stateList
-				m.state = stateError
-				return m, nil
-			}
+// handleNewContainerKeys handles keyboard input in the new session container dialog
+func (m Model) handleNewContainerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.containerInput.Blur()
+		m.nameInput.Focus()
+		m.state = stateNewName
+		return m, textinput.Blink
+	case "enter":
+		if strings.TrimSpace(m.containerInput.Value()) != "" {
+			m.containerInput.Blur()
+			return m.createNewInstance()
+		}
+		return m, nil
+	}
 
-			// Check for existing agent sessions (for agents that support resume)
-			agentConfig := session.AgentConfigs[m.pendingAgent]
-			if agentConfig.SupportsResume {
-				var sessions []session.AgentSession
-				var err error
-
-				switch m.pendingAgent {
-				case session.AgentGemini:
-					sessions, err = session.ListGeminiSessions(inst.Path)
-				case session.AgentCodex:
-					sessions, err = session.ListCodexSessions(inst.Path)
-				case session.AgentOpenCode:
-					sessions, err = session.ListOpenCodeSessions(inst.Path)
-				case session.AgentAmazonQ:
-					sessions, err = session.ListAmazonQSessions(inst.Path)
-				default:
-					// Claude and others
-					sessions, err = session.ListAgentSessions(inst.Path)
-				}
+	var cmd tea.Cmd
+	m.containerInput, cmd = m.containerInput.Update(msg)
+	return m, cmd
+}
 
-				if err != nil {
-					// Non-fatal: just continue without session selection
-					sessions = nil
-				}
-				if len(sessions) > 0 {
-					m.pendingInstance = inst
-					m.agentSessions = sessions
-					m.sessionCursor = 1 // Start with first session selected (0 is "new session")
-					m.state = stateSelectAgentSession
-					return m, nil
-				}
-			}
+// nextRuntime returns the runtime after r in session.Runtimes
+func nextRuntime(r string) string {
+	for idx, runtime := range session.Runtimes {
+		if runtime == r {
+			return session.Runtimes[(idx+1)%len(session.Runtimes)]
+		}
+	}
+	return ""
+}
 
-			// No existing sessions or agent doesn't support resume, just create new
-			if err := m.storage.AddInstance(inst); err != nil {
-				m.err = err
-				m.previousState = stateList
-				m.state = stateError
-				return m, nil
-			}
+// createNewInstance creates the session entered in the new session dialogs
+func (m Model) createNewInstance() (tea.Model, tea.Cmd) {
+	inst, err := session.NewInstance(m.nameInput.Value(), m.pathInput.Value(), m.autoYes, m.pendingAgent)
+	if err != nil {
+		m.err = err
+		m.previousState = stateList
+		m.state = stateError
+		return m, nil
+	}
 
-			// Auto-start the new instance
-			if err := inst.Start(); err != nil {
-				m.err = err
-				m.previousState = stateList
-				m.state = stateError
-			} else {
-				m.storage.UpdateInstance(inst)
-			}
+	inst.Runtime = m.pendingRuntime
+	if inst.Runtime == session.RuntimeDocker {
+		inst.Container = strings.TrimSpace(m.containerInput.Value())
+	}
 
-			m.instances = append(m.instances, inst)
+	// Set custom command for custom agent
+	if m.pendingAgent == session.AgentCustom {
+		inst.CustomCommand = m.customCmdInput.Value()
+	}
+	inst.Profile = m.pendingProfile
+	inst.Model = m.pendingModel
 
-			// Set cursor to the new instance
-			if len(m.groups) > 0 {
-				// In grouped mode, find the instance in visibleItems
-				m.buildVisibleItems()
-				for i, item := range m.visibleItems {
-					if !item.isGroup && item.instance != nil && item.instance.ID == inst.ID {
-						m.cursor = i
-						break
-					}
-				}
-			} else {
-				m.cursor = len(m.instances) - 1
-			}
+	// Agents, tabs and restart policy of the preset it is created from
+	if m.pendingPreset != nil {
+		m.pendingPreset.Apply(inst)
+		m.pendingPreset = nil
+	}
 
-			m.state = stateList
-			return m, nil
-		}
+	// Assign to current group if any
+	if m.pendingGroupID != "" {
+		inst.GroupID = m.pendingGroupID
 	}
 
-	var cmd tea.Cmd
-	m.nameInput, cmd = m.nameInput.Update(msg)
-	return m, cmd
+	// Check if the agent command exists before creating session
+	if err := session.CheckAgentCommand(inst); err != nil {
+		m.err = err
+		m.previousState = stateList
+		m.state = stateError
+		return m, nil
+	}
+
+	// Path inside another project's directories - offer to file it there
+	m.fileUnder = nil
+	if m.offerProjectInference(inst) {
+		return m, nil
+	}
+	return m.continueNewSession(inst)
 }
 
 // handleNewPathKeys handles keyboard input in the new session path dialog
@@ -197,8 +193,11 @@ func (m Model) handleNewPathKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m, nil
 	case "enter":
 		if m.pathInput.Value() != "" {
+			// Relative paths are looked up in the configured path roots
+			path := m.config.ResolvePath(m.pathInput.Value())
+			m.pathInput.SetValue(path)
+
 			// Extract folder name as default session name
-			path := m.pathInput.Value()
 			folderName := filepath.Base(path)
 			if folderName == "." || folderName == "/" {
 				folderName = "session"
@@ -206,6 +205,8 @@ func (m Model) handleNewPathKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 			m.nameInput.SetValue(folderName)
 			m.nameInput.Focus()
+			m.pendingRuntime = ""
+			m.containerInput.SetValue("")
 			m.state = stateNewName
 			return m, textinput.Blink
 		}
@@ -257,7 +258,38 @@ func (m Model) handleSelectSessionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "end":
 		m.sessionCursor = maxIdx
 
+	case "f":
+		// Fork the chosen conversation before resuming (keeps the original untouched)
+		if m.canForkResume() {
+			m.resumeFork = !m.resumeFork
+		}
+
+	case "t":
+		// Open the chosen conversation in a new tab instead of replacing this one
+		inst := m.getSelectedInstance()
+		if m.pendingInstance != nil || inst == nil || m.sessionCursor == 0 {
+			return m, nil
+		}
+		if inst.Status != session.StatusRunning {
+			m.notifyWarn("Session must be running to add tabs")
+			return m, nil
+		}
+		picked := m.agentSessions[m.sessionCursor-1]
+		tabName := resumeTabName(m.resumeAgentType, picked)
+		m.agentSessions = nil
+		m.state = stateList
+		if m.resumeFork && m.canForkResume() {
+			return m, tea.Batch(m.beginOperation(inst, "Forking"), forkAndResumeCmd(inst, 0, tabName, picked.SessionID))
+		}
+		if err := m.openResumedTab(inst, m.resumeAgentType, tabName, picked.SessionID); err != nil {
+			m.showError(fmt.Errorf("failed to open tab: %w", err))
+			return m, nil
+		}
+		m.notifySuccess("Opened conversation in tab '%s'", tabName)
+		return m, nil
+
 	case "enter":
+		var cmd tea.Cmd
 		var resumeID string
 		if m.sessionCursor > 0 && m.sessionCursor <= len(m.agentSessions) {
 			// Selected an existing session
@@ -270,7 +302,7 @@ func (m Model) handleSelectSessionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			inst := m.pendingInstance
 			inst.ResumeSessionID = resumeID
 
-			if err := m.storage.AddInstance(inst); err != nil {
+			if err := m.addNewInstance(inst); err != nil {
 				m.err = err
 				m.previousState = stateList
 				m.state = stateError
@@ -278,62 +310,22 @@ func (m Model) handleSelectSessionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.agentSessions = nil
 				return m, nil
 			}
-
-			// Auto-start the new instance
-			if err := inst.StartWithResume(resumeID); err != nil {
-				m.err = err
-			} else {
-				m.storage.UpdateInstance(inst)
-			}
-
-			m.instances = append(m.instances, inst)
-
-			// Set cursor to the new instance
-			if len(m.groups) > 0 {
-				m.buildVisibleItems()
-				for i, item := range m.visibleItems {
-					if !item.isGroup && item.instance != nil && item.instance.ID == inst.ID {
-						m.cursor = i
-						break
-					}
-				}
-			} else {
-				m.cursor = len(m.instances) - 1
-			}
-
 			m.pendingInstance = nil
+
+			// Auto-start the new instance in the background
+			cmd = m.startSessionAsync(inst, resumeID, false)
 		} else if inst := m.getSelectedInstance(); inst != nil {
 			// Resuming existing instance - apply to specific window
-			if m.resumeWindowIndex == 0 {
-				// Main window
-				inst.ResumeSessionID = resumeID
-				if inst.Status == session.StatusRunning {
-					// Respawn just the main window with new resume ID
-					inst.RespawnWindowWithResume(0, resumeID)
-				} else {
-					if err := inst.StartWithResume(resumeID); err != nil {
-						m.err = err
-					}
-				}
+			if m.resumeFork && resumeID != "" && m.canForkResume() {
+				cmd = tea.Batch(m.beginOperation(inst, "Forking"), forkAndResumeCmd(inst, m.resumeWindowIndex, "", resumeID))
 			} else {
-				// Followed window
-				for idx, fw := range inst.FollowedWindows {
-					if fw.Index == m.resumeWindowIndex {
-						inst.FollowedWindows[idx].ResumeSessionID = resumeID
-						if inst.Status == session.StatusRunning {
-							// Respawn just this window with new resume ID
-							inst.RespawnWindowWithResume(fw.Index, resumeID)
-						}
-						break
-					}
-				}
+				cmd = m.applyWindowResume(inst, m.resumeWindowIndex, resumeID)
 			}
-			m.storage.UpdateInstance(inst)
 		}
 
 		m.agentSessions = nil
 		m.state = stateList
-		return m, nil
+		return m, cmd
 	}
 
 	return m, nil
@@ -341,43 +333,84 @@ func (m Model) handleSelectSessionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleConfirmDeleteKeys handles keyboard input in the delete confirmation dialog
 func (m Model) handleConfirmDeleteKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "y", "Y":
-		if m.deleteTarget != nil {
-			if err := m.storage.RemoveInstance(m.deleteTarget.ID); err != nil {
-				m.err = fmt.Errorf("failed to remove instance: %w", err)
-			}
-			// Reload instances
-			instances, err := m.storage.Load()
-			if err != nil {
-				m.err = fmt.Errorf("failed to reload instances: %w", err)
-			} else {
-				m.instances = instances
-			}
-			if m.cursor >= len(m.instances) && m.cursor > 0 {
-				m.cursor--
-			}
-		}
+	if m.deleteTarget != nil && m.deleteTarget.Protected {
+		return m.handleConfirmProtectedDeleteKeys(msg)
+	}
+	switch m.keys.answer(msg) {
+	case answerYes:
+		m.deleteSession()
+	case answerNo, "esc":
 		m.deleteTarget = nil
 		m.state = stateList
-	case "n", "N", "esc":
+	}
+	return m, nil
+}
+
+// handleConfirmProtectedDeleteKeys requires typing the session name to delete a protected session
+func (m Model) handleConfirmProtectedDeleteKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		if m.nameInput.Value() != m.deleteTarget.Name {
+			m.notifyWarn("Name does not match - '%s' not deleted", m.deleteTarget.Name)
+			return m, nil
+		}
+		m.nameInput.Blur()
+		m.deleteSession()
+		return m, nil
+	case "esc":
+		m.nameInput.Blur()
 		m.deleteTarget = nil
 		m.state = stateList
+		return m, nil
 	}
-	return m, nil
+	var cmd tea.Cmd
+	m.nameInput, cmd = m.nameInput.Update(msg)
+	return m, cmd
+}
+
+// deleteSession removes the delete target and reloads the instance list
+func (m *Model) deleteSession() {
+	if m.deleteTarget != nil {
+		anchor := m.rememberSelection()
+		// Archive first so the session can be reopened with u
+		m.storage.PushClosed(m.deleteTarget, true)
+		if err := m.storage.RemoveInstance(m.deleteTarget.ID); err != nil {
+			m.err = fmt.Errorf("failed to remove instance: %w", err)
+		} else if err := m.deleteTarget.RemoveWorktree(); err != nil {
+			m.notifyWarn("%v", err)
+		}
+		// Reload instances
+		instances, err := m.storage.Load()
+		if err != nil {
+			m.err = fmt.Errorf("failed to reload instances: %w", err)
+		} else {
+			m.instances = instances
+		}
+		m.restoreSelection(anchor)
+	}
+	m.deleteTarget = nil
+	m.state = stateList
 }
 
 // handleConfirmStopKeys handles keyboard input in the stop confirmation dialog
 func (m Model) handleConfirmStopKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "y", "Y":
+	switch m.keys.answer(msg) {
+	case answerYes:
+		var cmd tea.Cmd
 		if m.stopTarget != nil {
+			// On-stop summary: grab the conversation while the session still exists
+			if cfg := session.LoadSummaryConfig(); cfg.OnStop {
+				input := m.stopTarget.SummaryInput()
+				cmd = m.summarizeSession(m.stopTarget, input)
+			}
 			m.stopTarget.Stop()
 			m.storage.UpdateInstance(m.stopTarget)
+			m.storage.PushClosed(m.stopTarget, false)
 		}
 		m.stopTarget = nil
 		m.state = stateList
-	case "n", "N", "esc":
+		return m, cmd
+	case answerNo, "esc":
 		m.stopTarget = nil
 		m.state = stateList
 	}
@@ -386,18 +419,10 @@ func (m Model) handleConfirmStopKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleConfirmStartKeys handles keyboard input in the auto-start confirmation dialog
 func (m Model) handleConfirmStartKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "y", "Y":
+	switch m.keys.answer(msg) {
+	case answerYes:
 		inst := m.getSelectedInstance()
 		if inst != nil {
-			// Stop if already running
-			if inst.Status == session.StatusRunning {
-				inst.Stop()
-			}
-
-			// Clear any resume session ID to ensure we start fresh
-			inst.ResumeSessionID = ""
-
 			// Check if command exists before starting
 			if err := session.CheckAgentCommand(inst); err != nil {
 				m.err = err
@@ -406,17 +431,15 @@ func (m Model) handleConfirmStartKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
-			// Start completely new session (no resume)
-			if err := inst.Start(); err != nil {
-				m.err = err
-				m.previousState = stateList
-				m.state = stateError
-			} else {
-				m.storage.UpdateInstance(inst)
-			}
+			// Clear any resume session ID to ensure we start fresh
+			inst.ResumeSessionID = ""
+
+			// Stop if already running, then start completely new session (no resume)
+			m.state = stateList
+			return m, m.restartSessionAsync(inst)
 		}
 		m.state = stateList
-	case "n", "N", "esc":
+	case answerNo, "esc":
 		m.state = stateList
 	}
 	return m, nil
@@ -428,12 +451,16 @@ func (m Model) handleSelectStartModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "1", "r", "R":
 		// Replace current session - go to confirm dialog
 		m.state = stateConfirmStart
-	case "2", "n", "N":
+	case "2", "n", "N", "3", "w", "W":
 		// Start parallel session - ask for name first
+		worktree := strings.Contains("3wW", msg.String())
 		inst := m.getSelectedInstance()
+		if worktree && !m.startModeGit {
+			return m, nil
+		}
 		if inst != nil {
 			// Create a new instance based on the current one
-			newInst, err := session.NewInstance(inst.Name, inst.Path, inst.AutoYes, inst.Agent)
+			newInst, err := session.NewInstance(inst.Name, inst.DisplayPath(), inst.AutoYes, inst.Agent)
 			if err != nil {
 				m.err = err
 				m.previousState = stateList
@@ -447,11 +474,19 @@ func (m Model) handleSelectStartModeKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			newInst.Color = inst.Color
 			newInst.BgColor = inst.BgColor
 			newInst.FullRowColor = inst.FullRowColor
+			newInst.ParentID = inst.ID
+			newInst.Runtime = inst.Runtime
+			newInst.Container = inst.Container
+			newInst.Env = inst.Env
+			newInst.Profile = inst.Profile
+			newInst.LaunchFlags = inst.LaunchFlags
+			newInst.Model = inst.Model
 
 			// Store as pending instance for name input
 			m.pendingInstance = newInst
 			m.isParallelSession = true
 			m.parallelOriginalID = inst.ID
+			m.parallelWorktree = worktree
 
 			// Set default name to current session name and ask for name
 			m.nameInput.SetValue(inst.Name)
@@ -491,7 +526,7 @@ func (m Model) handleRenameKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 // handleHelpKeys handles keyboard input in the help view
 func (m Model) handleHelpKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Get actual line count from help content
-	_, totalLines := buildHelpContent(m.width)
+	_, totalLines := buildHelpContent(m.width, m.keys)
 	maxLines := m.height - 3
 	if maxLines < 10 {
 		maxLines = 10
@@ -536,23 +571,61 @@ func (m Model) handlePromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.promptInput.SetValue(m.promptSuggestion)
 			return m, nil
 		}
+	case "ctrl+t":
+		// Schedule the message instead of sending it now
+		return m, m.handleSchedulePrompt()
+	case "up", "down":
+		// Recall prompts sent to this session (on the first/last row only)
+		if m.recallPrompt(msg.String() == "up") {
+			m.promptResolved = ""
+			m.promptUnresolved = nil
+			return m, nil
+		}
+	case "ctrl+r":
+		// Search the prompts sent to any session
+		m.searchPromptHistory()
+		m.promptResolved = ""
+		m.promptUnresolved = nil
+		return m, nil
 	case "ctrl+s", "ctrl+enter":
 		// Send message with Ctrl+S or Ctrl+Enter
 		if m.promptInput.Value() != "" {
-			if inst := m.getSelectedInstance(); inst != nil && inst.Status == session.StatusRunning {
+			inst := m.getSelectedInstance()
+			text := m.promptInput.Value()
+
+			// Templates are previewed once before sending
+			if inst != nil && session.HasTemplateVars(text) && m.promptResolved == "" {
+				m.promptResolved, m.promptUnresolved = inst.ResolvePrompt(text)
+				return m, nil
+			}
+			if m.promptResolved != "" {
+				text = m.promptResolved
+			}
+
+			if inst != nil && inst.Status == session.StatusRunning {
 				// Send prompt text followed by Enter in a single command
-				text := m.promptInput.Value()
 				if err := inst.SendPrompt(text); err != nil {
 					m.err = err
+				} else {
+					// Templates are kept unresolved, to resolve again when reused
+					m.rememberPrompt(inst, m.promptInput.Value())
 				}
 			}
+			m.promptResolved = ""
 			m.state = stateList
 			return m, nil
 		}
 	}
 
 	var cmd tea.Cmd
+	prev := m.promptInput.Value()
 	m.promptInput, cmd = m.promptInput.Update(msg)
+	if m.promptInput.Value() != prev {
+		// Edited after the preview - resolve again on send
+		m.promptResolved = ""
+		m.promptUnresolved = nil
+		m.promptSearching = false
+	}
 	return m, cmd
 }
 
@@ -625,6 +698,17 @@ func (m Model) handleColorPickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "end":
 		m.colorCursor = maxItems - 1
 
+	case "a":
+		// Cycle automatic colors for new sessions and groups
+		m.cycleAutoColor()
+
+	case "g":
+		// Manage custom gradients
+		if m.paletteCursor >= len(m.palettes) {
+			m.paletteCursor = 0
+		}
+		m.state = stateGradients
+
 	case "f":
 		// Toggle full row color
 		if m.editingGroup != nil {
@@ -703,7 +787,10 @@ func (m Model) handleColorPickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleErrorKeys handles keyboard input in the error overlay
 func (m Model) handleErrorKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Any key closes the error dialog
+	// Any key closes the error dialog - keep it in the message history
+	if m.err != nil {
+		m.logMessage(toast{level: toastError, text: m.err.Error(), createdAt: time.Now()})
+	}
 	m.err = nil
 	// Return to appropriate state based on previous state
 	switch m.previousState {
@@ -733,27 +820,16 @@ func (m Model) handleUpdateSuccessKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleConfirmUpdateKeys handles keyboard input in the update confirmation overlay
 func (m Model) handleConfirmUpdateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "y", "Y":
+	switch m.keys.answer(msg) {
+	case answerYes:
 		// If we already know the version, start download immediately
 		if m.updateAvailable != "" {
-			if updater.IsPackageManaged() {
-				// Check if deb
-				if _, err := os.Stat("/var/lib/dpkg/info/asmgr.list"); err == nil {
-					m.state = stateDownloadingDeb
-					return m, runDebDownload(m.updateAvailable)
-				}
-				// Otherwise rpm
-				m.state = stateDownloadingRpm
-				return m, runRpmDownload(m.updateAvailable)
-			}
-			m.state = stateUpdating
-			return m, runUpdateCmd(m.updateAvailable)
+			return m, m.startUpdateDownload(m.updateAvailable)
 		}
 		// Otherwise check for updates first (force check, ignore 24h timer)
 		m.state = stateCheckingUpdate
 		return m, forceCheckForUpdateCmd()
-	case "n", "N", "esc":
+	case answerNo, "esc":
 		// Cancel - go back to list
 		m.state = stateList
 		return m, nil
@@ -761,6 +837,20 @@ func (m Model) handleConfirmUpdateKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleUpdatingKeys handles keyboard input while an update is downloading
+func (m Model) handleUpdatingKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if msg.String() == "esc" {
+		// Hide the dialog - the download keeps running in the background
+		if m.previousState == stateProjectSelect {
+			m.state = stateProjectSelect
+		} else {
+			m.state = stateList
+		}
+		m.notifyInfo("Downloading %s in the background...", m.updateAvailable)
+	}
+	return m, nil
+}
+
 // agentTypes defines the available agent types in order
 var agentTypes = []session.AgentType{
 	session.AgentClaude,
@@ -773,6 +863,18 @@ var agentTypes = []session.AgentType{
 	session.AgentCustom,
 }
 
+// defaultAgentIndex returns the position of the configured default agent in agentTypes
+func (m Model) defaultAgentIndex() int {
+	if m.config != nil {
+		for i, agent := range agentTypes {
+			if agent == m.config.DefaultAgent {
+				return i
+			}
+		}
+	}
+	return 0
+}
+
 // handleSelectAgentKeys handles keyboard input in the agent selection dialog
 func (m Model) handleSelectAgentKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Clear error on navigation
@@ -792,12 +894,19 @@ func (m Model) handleSelectAgentKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 
 	case "down", "j":
-		if m.agentCursor < len(agentTypes)-1 {
+		if m.agentCursor < len(agentTypes)+len(m.config.ProfileNames())-1 {
 			m.agentCursor++
 		}
 
 	case "enter":
-		m.pendingAgent = agentTypes[m.agentCursor]
+		// Profiles are listed after the agents and launch their own agent
+		m.pendingProfile = ""
+		if profiles := m.config.ProfileNames(); m.agentCursor >= len(agentTypes) {
+			m.pendingProfile = profiles[m.agentCursor-len(agentTypes)]
+			m.pendingAgent = m.config.Profiles[m.pendingProfile].ProfileAgent()
+		} else {
+			m.pendingAgent = agentTypes[m.agentCursor]
+		}
 
 		// If custom agent, ask for command first (can't check yet)
 		if m.pendingAgent == session.AgentCustom {
@@ -815,8 +924,15 @@ func (m Model) handleSelectAgentKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		// Command exists, proceed to path input
+		// Agents with a model list pick one first, unless the profile sets it
 		m.err = nil
+		m.pendingModel = ""
+		if len(config.Models) > 0 && (m.pendingProfile == "" || m.config.Profiles[m.pendingProfile].Model == "") {
+			m.modelTarget = nil
+			return m, m.showModelPicker(m.pendingAgent, "")
+		}
+
+		// Command exists, proceed to path input
 		m.pathInput.SetValue("")
 		m.pathInput.Focus()
 		m.state = stateNewPath
@@ -881,10 +997,24 @@ func (m Model) handleNotesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
 		// Cancel without saving
+		m.notesGroupID = ""
 		m.state = stateList
 		return m, nil
 
 	case "ctrl+s":
+		// Save notes to group
+		if m.notesGroupID != "" {
+			notes := m.notesInput.Value()
+			groupID := m.notesGroupID
+			m.notesGroupID = ""
+			m.state = stateList
+			if err := m.storage.SetGroupNotes(groupID, notes); err != nil {
+				m.showError(err)
+			} else if idx := m.findGroupIndex(groupID); idx >= 0 {
+				m.groups[idx].Notes = notes
+			}
+			return m, nil
+		}
 		// Save notes to session or tab
 		if inst := m.getSelectedInstance(); inst != nil {
 			notes := m.notesInput.Value()
@@ -925,7 +1055,7 @@ func (m Model) handleNewTabChoiceKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case "a", "A":
 		// Agent tab - first select which agent
 		m.newTabIsAgent = true
-		m.newTabAgentCursor = 0
+		m.newTabAgentCursor = m.defaultAgentIndex()
 		m.err = nil
 		m.state = stateNewTabAgent
 		return m, nil
@@ -1066,8 +1196,7 @@ func (m Model) handleDeleteChoiceKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "s", "S":
 		// Delete session
-		m.state = stateConfirmDelete
-		return m, nil
+		return m, m.confirmDeleteSession()
 	case "t", "T":
 		// Delete tab - check if on main window (can't delete)
 		if m.deleteTarget != nil {
@@ -1075,9 +1204,8 @@ func (m Model) handleDeleteChoiceKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			for _, w := range windows {
 				if w.Active {
 					if w.Index == 0 {
-						m.err = fmt.Errorf("cannot close main agent tab")
-						m.previousState = stateList
-						m.state = stateError
+						m.notifyWarn("Cannot close main agent tab")
+						m.state = stateList
 						m.deleteTarget = nil
 						return m, nil
 					}
@@ -1098,8 +1226,8 @@ func (m Model) handleDeleteChoiceKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleConfirmDeleteTabKeys handles keyboard input in the tab deletion confirmation dialog
 func (m Model) handleConfirmDeleteTabKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "y", "Y":
+	switch m.keys.answer(msg) {
+	case answerYes:
 		if m.deleteTarget != nil {
 			windows := m.deleteTarget.GetWindowList()
 			for _, w := range windows {
@@ -1119,7 +1247,7 @@ func (m Model) handleConfirmDeleteTabKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.deleteTarget = nil
 		m.state = stateList
-	case "n", "N", "esc":
+	case answerNo, "esc":
 		m.deleteTarget = nil
 		m.state = stateList
 	}
@@ -1150,12 +1278,15 @@ func (m Model) handleStopChoiceKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleConfirmStopTabKeys handles keyboard input in the tab stop confirmation dialog
 func (m Model) handleConfirmStopTabKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "y", "Y":
+	switch m.keys.answer(msg) {
+	case answerYes:
 		if m.stopTarget != nil {
 			windows := m.stopTarget.GetWindowList()
 			for _, w := range windows {
 				if w.Active {
+					if w.Index == 0 {
+						m.expectExit(m.stopTarget)
+					}
 					if err := m.stopTarget.StopWindow(w.Index); err != nil {
 						m.err = err
 						m.previousState = stateList
@@ -1167,72 +1298,60 @@ func (m Model) handleConfirmStopTabKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.stopTarget = nil
 		m.state = stateList
-	case "n", "N", "esc":
+	case answerNo, "esc":
 		m.stopTarget = nil
 		m.state = stateList
 	}
 	return m, nil
 }
 
-// handleConfirmYoloKeys handles keyboard input in the YOLO mode confirmation dialog
+// handleConfirmYoloKeys handles keyboard input in the YOLO mode confirmation dialog.
+// When enabling, the number keys pick a time limit or a number of approvals.
 func (m Model) handleConfirmYoloKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "y", "Y":
-		if m.yoloTarget != nil {
-			inst := m.yoloTarget
-			wasRunning := inst.Status == session.StatusRunning
-
-			// Toggle YOLO for the correct window
-			if m.yoloWindowIndex == 0 {
-				inst.AutoYes = m.yoloNewState
-			} else {
-				for idx, fw := range inst.FollowedWindows {
-					if fw.Index == m.yoloWindowIndex {
-						inst.FollowedWindows[idx].AutoYes = m.yoloNewState
-						break
-					}
-				}
-			}
-
-			m.storage.UpdateInstance(inst)
-
-			// If running, respawn the window with new flag
-			if wasRunning {
-				if m.yoloWindowIndex == 0 {
-					// Main window - restart session
-					inst.Stop()
-					if err := inst.Start(); err != nil {
-						m.err = fmt.Errorf("failed to restart session: %w", err)
-						m.previousState = stateList
-						m.state = stateError
-						m.yoloTarget = nil
-						return m, nil
-					}
-					m.storage.UpdateInstance(inst)
-				} else {
-					// Tab window - respawn just that window
-					inst.RespawnWindow(m.yoloWindowIndex)
-				}
-				// Refresh tmux status bar
-				RefreshTmuxStatusBarFull(inst.TmuxSessionName(), inst.Name, inst.Color, inst.BgColor, inst)
+	inst := m.yoloTarget
+	if inst == nil {
+		m.state = stateList
+		return m, nil
+	}
+	window := m.yoloWindowIndex
+	var cmd tea.Cmd
+	answer := m.keys.answer(msg)
+	switch {
+	case answer == answerYes && m.yoloNewState:
+		cmd = m.applyYoloChange(inst, window, true, nil, "YOLO on")
+	case answer == answerYes:
+		cmd = m.applyYoloChange(inst, window, false, nil, "YOLO off")
+	case answer == answerNo || answer == "esc":
+	case m.yoloNewState && answer == yoloApprovalsKey():
+		cmd = m.applyYoloChange(inst, window, false, &session.YoloGrant{Approvals: session.YoloApprovals},
+			fmt.Sprintf("YOLO on for %d approvals", session.YoloApprovals))
+	default:
+		limit := time.Duration(0)
+		for idx, d := range session.YoloDurations {
+			if answer == yoloLimitKey(idx) {
+				limit = d
 			}
 		}
-		m.yoloTarget = nil
-		m.state = stateList
-	case "n", "N", "esc":
-		m.yoloTarget = nil
-		m.state = stateList
+		if !m.yoloNewState || limit == 0 {
+			return m, nil
+		}
+		cmd = m.applyYoloChange(inst, window, true, &session.YoloGrant{Until: time.Now().Add(limit)},
+			fmt.Sprintf("YOLO on for %d min", int(limit.Minutes())))
 	}
-	return m, nil
+	m.yoloTarget = nil
+	m.state = stateList
+	return m, cmd
 }
 
 // handleSearchKeys handles keyboard input in the search mode
 func (m Model) handleSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "esc":
-		// Cancel search and clear filter
+		// Cancel search and clear filter, keeping the match that was selected
+		anchor := m.rememberSelection()
 		m.searchQuery = ""
 		m.searchActive = false
+		m.restoreSelection(anchor)
 		m.state = stateList
 		return m, nil
 
@@ -1242,11 +1361,11 @@ func (m Model) handleSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if query != "" {
 			m.searchQuery = strings.ToLower(query)
 			m.searchActive = true
-			m.cursor = 0
 		} else {
 			m.searchQuery = ""
 			m.searchActive = false
 		}
+		m.restoreSelection(m.searchAnchor)
 		m.state = stateList
 		return m, nil
 	}
@@ -1265,12 +1384,22 @@ func (m Model) handleSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.searchQuery = ""
 		m.searchActive = false
 	}
+	// Follow the session selected before searching, or its nearest match
+	m.restoreSelection(m.searchAnchor)
 
 	return m, cmd
 }
 
 // handleGlobalSearchKeys handles keyboard input in the global search mode
 func (m Model) handleGlobalSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "enter", "ctrl+o":
+		// Remember queries that found something for the dashboard
+		if len(m.globalSearchResults) > 0 {
+			session.RecordSearch(m.globalSearchInput.Value(), len(m.globalSearchResults))
+			m.dashboardAt = time.Time{}
+		}
+	}
 	switch msg.String() {
 	case "esc":
 		// Close global search
@@ -1284,6 +1413,16 @@ func (m Model) handleGlobalSearchKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.state = stateList
 		return m, nil
 
+	case "ctrl+o":
+		// Action menu for the selected result (open, copy, export, delete)
+		if m.globalSearchCursor < len(m.globalSearchResults) {
+			entry := m.globalSearchResults[m.globalSearchCursor]
+			m.globalSearchSelectedEntry = &entry
+			m.globalSearchActionCursor = 0
+			m.state = stateGlobalSearchAction
+		}
+		return m, nil
+
 	case "ctrl+r":
 		// Reload history index
 		m.globalSearchResults = nil
@@ -1441,7 +1580,7 @@ func (m *Model) findBestMatchForEntry(entry session.HistoryEntry) (*session.Inst
 	if entry.Agent == session.AgentTerminal {
 		for _, inst := range m.instances {
 			if (entry.SessionID != "" && inst.ResumeSessionID == entry.SessionID) ||
-				(entry.Path != "" && inst.Path == entry.Path) {
+				(entry.Path != "" && session.SamePath(inst.Path, entry.Path)) {
 				for i, tab := range inst.FollowedWindows {
 					if tab.Agent == session.AgentTerminal {
 						return inst, i
@@ -1469,11 +1608,12 @@ func (m *Model) findBestMatchForEntry(entry session.HistoryEntry) (*session.Inst
 	// Priority 2: Match by path + agent (good specificity)
 	if entry.Path != "" {
 		for _, inst := range m.instances {
-			if inst.Path == entry.Path && inst.Agent == entry.Agent {
+			samePath := session.SamePath(inst.Path, entry.Path)
+			if samePath && inst.Agent == entry.Agent {
 				return inst, -1
 			}
 			for i, tab := range inst.FollowedWindows {
-				if inst.Path == entry.Path && tab.Agent == entry.Agent {
+				if samePath && tab.Agent == entry.Agent {
 					return inst, i
 				}
 			}
@@ -1805,7 +1945,7 @@ func (m Model) handleGlobalSearchNewNameKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd
 
 // handleGlobalSearchActionKeys handles keyboard input in the global search action dialog
 func (m Model) handleGlobalSearchActionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	maxCursor := 2 // 0=new session, 1=to group, 2=as tab
+	maxCursor := 5 // 0=new session, 1=to group, 2=as tab, 3=copy, 4=export, 5=delete transcript
 
 	switch msg.String() {
 	case "esc":
@@ -1838,6 +1978,18 @@ func (m Model) handleGlobalSearchActionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 		m.globalSearchActionCursor = 2
 		return m, nil
 
+	case "4":
+		m.globalSearchActionCursor = 3
+		return m, nil
+
+	case "5":
+		m.globalSearchActionCursor = 4
+		return m, nil
+
+	case "6":
+		m.globalSearchActionCursor = 5
+		return m, nil
+
 	case "enter":
 		if m.globalSearchSelectedEntry == nil {
 			m.state = stateList
@@ -1884,6 +2036,9 @@ func (m Model) handleGlobalSearchActionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 			// Use session cursor for selection, transition to a custom selector
 			m.sessionCursor = 0
 			return m.addSearchEntryAsTab(entry)
+
+		case 3, 4, 5:
+			return m.handleSearchEntryAction(entry)
 		}
 	}
 
@@ -1892,9 +2047,9 @@ func (m Model) handleGlobalSearchActionKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd)
 
 // createSessionFromSearchEntry creates a new session from a global search entry
 func (m *Model) createSessionFromSearchEntry(entry *session.HistoryEntry, groupID string, customName string) (Model, tea.Cmd) {
-	// Only Claude entries can be resumed
-	if entry.Agent != session.AgentClaude || entry.SessionID == "" {
-		m.err = fmt.Errorf("only Claude sessions can be opened")
+	// Only entries of agents that support resume can be opened
+	if !isResumableEntry(entry) {
+		m.err = fmt.Errorf("%s conversations can't be resumed", entry.Agent)
 		m.previousState = stateGlobalSearchAction
 		m.state = stateError
 		return *m, nil
@@ -1913,7 +2068,7 @@ func (m *Model) createSessionFromSearchEntry(entry *session.HistoryEntry, groupI
 	// Use custom name if provided, otherwise generate from snippet
 	name := customName
 	if name == "" {
-		name = "claude"
+		name = string(entry.Agent)
 		if entry.Snippet != "" {
 			name = entry.Snippet
 			if len(name) > 30 {
@@ -1926,7 +2081,7 @@ func (m *Model) createSessionFromSearchEntry(entry *session.HistoryEntry, groupI
 	}
 
 	// Create new instance
-	inst, err := session.NewInstance(name, path, false, session.AgentClaude)
+	inst, err := session.NewInstance(name, path, false, entry.Agent)
 	if err != nil {
 		m.err = err
 		m.previousState = stateGlobalSearchAction
@@ -1942,6 +2097,8 @@ func (m *Model) createSessionFromSearchEntry(entry *session.HistoryEntry, groupI
 		inst.GroupID = groupID
 	}
 
+	m.autoColorInstance(inst)
+
 	// Add to storage
 	if err := m.storage.AddInstance(inst); err != nil {
 		m.err = err
@@ -1950,15 +2107,6 @@ func (m *Model) createSessionFromSearchEntry(entry *session.HistoryEntry, groupI
 		return *m, nil
 	}
 
-	// Start the session with resume
-	if err := inst.StartWithResume(entry.SessionID); err != nil {
-		m.err = err
-		m.previousState = stateGlobalSearchAction
-		m.state = stateError
-		return *m, nil
-	}
-	m.storage.UpdateInstance(inst)
-
 	m.instances = append(m.instances, inst)
 
 	// Clear global search state
@@ -1970,27 +2118,23 @@ func (m *Model) createSessionFromSearchEntry(entry *session.HistoryEntry, groupI
 	m.globalSearchSelectedEntry = nil
 
 	// Move cursor to new session
-	if len(m.groups) > 0 {
-		m.buildVisibleItems()
-		for i, item := range m.visibleItems {
-			if !item.isGroup && item.instance != nil && item.instance.ID == inst.ID {
-				m.cursor = i
-				break
-			}
-		}
-	} else {
-		m.cursor = len(m.instances) - 1
-	}
-
+	m.selectInstance(inst)
 	m.state = stateList
-	return *m, nil
+
+	// Start the session with resume in the background
+	return *m, m.startSessionAsync(inst, entry.SessionID, false)
+}
+
+// isResumableEntry reports whether a global search entry can be reopened by its agent
+func isResumableEntry(entry *session.HistoryEntry) bool {
+	return entry.SessionID != "" && session.AgentConfigs[entry.Agent].SupportsResume
 }
 
 // addSearchEntryAsTab adds a global search entry as a new tab to the currently selected session
 func (m *Model) addSearchEntryAsTab(entry *session.HistoryEntry) (Model, tea.Cmd) {
-	// Only Claude entries can be resumed
-	if entry.Agent != session.AgentClaude || entry.SessionID == "" {
-		m.err = fmt.Errorf("only Claude sessions can be opened as tabs")
+	// Only entries of agents that support resume can be opened
+	if !isResumableEntry(entry) {
+		m.err = fmt.Errorf("%s conversations can't be opened as tabs", entry.Agent)
 		m.previousState = stateGlobalSearchAction
 		m.state = stateError
 		return *m, nil
@@ -2014,7 +2158,7 @@ func (m *Model) addSearchEntryAsTab(entry *session.HistoryEntry) (Model, tea.Cmd
 	}
 
 	// Generate tab name from snippet
-	tabName := "claude"
+	tabName := string(entry.Agent)
 	if entry.Snippet != "" {
 		tabName = entry.Snippet
 		if len(tabName) > 20 {
@@ -2024,8 +2168,8 @@ func (m *Model) addSearchEntryAsTab(entry *session.HistoryEntry) (Model, tea.Cmd
 		tabName = strings.ReplaceAll(tabName, "\t", " ")
 	}
 
-	// Create a new tab with the resume session ID (uses existing NewForkedTab which does exactly this)
-	if err := inst.NewForkedTab(tabName, entry.SessionID); err != nil {
+	// Create a new tab resuming the conversation with the entry's agent
+	if err := inst.NewResumedTab(tabName, entry.Agent, entry.SessionID, ""); err != nil {
 		m.err = err
 		m.previousState = stateGlobalSearchAction
 		m.state = stateError
@@ -2084,83 +2228,14 @@ func (m Model) handleForkDialogKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			forkName = m.forkTarget.Name + " (fork)"
 		}
 
-		// Execute fork with --fork-session
-		newSessionID, err := m.forkTarget.ForkSession()
-		if err != nil {
-			m.err = fmt.Errorf("fork failed: %w", err)
-			m.previousState = stateList
-			m.state = stateError
-			m.forkTarget = nil
-			return m, nil
-		}
-
-		if m.forkToTab {
-			// Fork to new tab in same session
-			if err := m.forkTarget.NewForkedTab(forkName, newSessionID); err != nil {
-				m.err = fmt.Errorf("failed to create fork tab: %w", err)
-				m.previousState = stateList
-				m.state = stateError
-			} else {
-				// Update status bar
-				configureTmuxStatusBar(m.forkTarget.TmuxSessionName(), m.forkTarget.Name, m.forkTarget.Color, m.forkTarget.BgColor, m.forkTarget.AutoYes)
-				m.storage.UpdateInstance(m.forkTarget)
-			}
-		} else {
-			// Fork to new session
-			newInst, err := session.NewInstance(forkName, m.forkTarget.Path, false, session.AgentClaude)
-			if err != nil {
-				m.err = fmt.Errorf("failed to create fork session: %w", err)
-				m.previousState = stateList
-				m.state = stateError
-				m.forkTarget = nil
-				return m, nil
-			}
-
-			// Copy settings from original
-			newInst.GroupID = m.forkTarget.GroupID
-			newInst.Color = m.forkTarget.Color
-			newInst.BgColor = m.forkTarget.BgColor
-			newInst.FullRowColor = m.forkTarget.FullRowColor
-			newInst.ResumeSessionID = newSessionID
-			newInst.Notes = fmt.Sprintf("Forked from: %s", m.forkTarget.Name)
-
-			// Add to storage
-			if err := m.storage.AddInstance(newInst); err != nil {
-				m.err = fmt.Errorf("failed to save fork session: %w", err)
-				m.previousState = stateList
-				m.state = stateError
-				m.forkTarget = nil
-				return m, nil
-			}
-
-			// Start the forked session
-			if err := newInst.StartWithResume(newSessionID); err != nil {
-				m.err = fmt.Errorf("failed to start fork session: %w", err)
-				m.previousState = stateList
-				m.state = stateError
-			} else {
-				m.storage.UpdateInstance(newInst)
-			}
-
-			m.instances = append(m.instances, newInst)
-
-			// Move cursor to new session
-			if len(m.groups) > 0 {
-				m.buildVisibleItems()
-				for i, item := range m.visibleItems {
-					if !item.isGroup && item.instance != nil && item.instance.ID == newInst.ID {
-						m.cursor = i
-						break
-					}
-				}
-			} else {
-				m.cursor = len(m.instances) - 1
-			}
-		}
-
+		// Execute fork with --fork-session in the background
+		target := m.forkTarget
 		m.forkTarget = nil
 		m.state = stateList
-		return m, nil
+		if m.isBusy(target) {
+			return m, nil
+		}
+		return m, tea.Batch(m.beginOperation(target, "Forking"), forkSessionCmd(target, forkName, m.forkToTab))
 	}
 
 	// Update name input
@@ -17,7 +17,11 @@ func (m Model) handleNewGroupKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			if err != nil {
 				m.err = err
 			} else {
+				m.autoColorGroup(group)
 				m.groups = append(m.groups, group)
+				if group.Color != "" {
+					m.storage.SaveWithGroups(m.instances, m.groups)
+				}
 				m.buildVisibleItems()
 			}
 			m.state = stateList
@@ -65,6 +69,7 @@ func (m Model) handleSelectGroupKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	switch msg.String() {
 	case "esc":
+		m.batchMoving = false
 		m.state = stateList
 		return m, nil
 
@@ -79,6 +84,17 @@ func (m Model) handleSelectGroupKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 
 	case "enter":
+		// Target group of a batch move - confirm before moving
+		if m.batchMoving {
+			m.batchMoving = false
+			m.batchGroupID = ""
+			if m.groupCursor > 0 && m.groupCursor <= len(m.groups) {
+				m.batchGroupID = m.groups[m.groupCursor-1].ID
+			}
+			m.state = stateBatchConfirm
+			return m, nil
+		}
+
 		// Find current session (works in both grouped and ungrouped modes)
 		var inst *session.Instance
 		if len(m.groups) > 0 {
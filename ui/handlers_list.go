@@ -1,7 +1,6 @@
 package ui
 
 import (
-	"fmt"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -144,7 +143,12 @@ func (m *Model) saveSettings() {
 		SplitView:       m.splitView,
 		MarkedSessionID: m.markedSessionID,
 		Cursor:          m.cursor,
+		SelectedID:      m.selectedKey(),
 		SplitFocus:      m.splitFocus,
+		StuckMinutes:    m.stuckMinutes,
+		GlobalList:      m.globalList,
+		AutoColor:       m.autoColor,
+		NestForks:       m.nestForks,
 	})
 }
 
@@ -162,7 +166,8 @@ func (m *Model) scrollPreviewUp(lines int) {
 	if m.scrollContent == "" {
 		inst := m.getSelectedInstance()
 		if inst != nil && inst.Status == session.StatusRunning {
-			m.scrollContent, _ = inst.GetPreview(ScrollbackLines)
+			captured, _ := inst.GetPreview(ScrollbackLines)
+			m.scrollContent = m.filterPreview(inst, captured)
 		}
 	}
 	content := m.getScrollableContent()
@@ -222,7 +227,7 @@ func (m *Model) navigatePinned(direction int) {
 	}
 
 	// If groups or favorites exist, navigate through ALL session positions (including duplicates)
-	if len(m.groups) > 0 || m.hasFavorites() {
+	if m.usesVisibleItems() {
 		m.buildVisibleItems()
 
 		// Build list of all session indices in visual order (no deduplication)
@@ -306,42 +311,45 @@ func (m *Model) navigatePinned(direction int) {
 
 // handleListKeys handles keyboard input in the main list view
 func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	// Second key of a leader sequence
+	if m.leaderPending {
+		return m.handleLeaderFollowup(msg)
+	}
+	// Route the key through the keymap (config.toml [keys])
+	action := m.keys.action(msg)
+	if action == "" {
+		m.err = nil
+		return m, nil
+	}
+	// The leader key waits for the second key of a chord
+	if action == "leader" {
+		return m, m.startLeader()
+	}
+	return m.handleListAction(action, msg)
+}
+
+// handleListAction runs a list view action; msg is the key that triggered it
+func (m Model) handleListAction(action string, msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Clear error on any key press
 	m.err = nil
 
-	switch msg.String() {
-	case "ctrl+c":
+	// Start, stop, delete and group assignment act on all selected sessions
+	if m.hasMultiSelect() {
+		if batch, ok := batchActions[action]; ok {
+			m.openBatchAction(batch)
+			return m, nil
+		}
+	}
+
+	switch action {
+	case "exit":
 		m.saveSettings() // Save cursor position on quit
 		m.storage.UnlockProject()
 		return m, tea.Quit
 
-	case "q":
+	case "quit":
 		// Go back to project selector
-		m.saveSettings()
-		currentProjectID := ""
-		if m.activeProject != nil {
-			currentProjectID = m.activeProject.ID
-		}
-		m.storage.UnlockProject()
-		// Reload projects to refresh session counts
-		projectsData, _ := m.storage.LoadProjects()
-		m.projects = projectsData.Projects
-		// Find and select the project we just left
-		// List structure: projects (0..n-1), "No project" (n), "New Project" (n+1)
-		if currentProjectID == "" {
-			// Default/no project
-			m.projectCursor = len(m.projects)
-		} else {
-			// Find project index
-			m.projectCursor = len(m.projects) // fallback to "No project"
-			for i, p := range m.projects {
-				if p.ID == currentProjectID {
-					m.projectCursor = i
-					break
-				}
-			}
-		}
-		m.state = stateProjectSelect
+		m.leaveProject()
 		return m, nil
 
 	case "up":
@@ -349,7 +357,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.splitView && m.splitFocus == 1 && m.markedSessionID != "" {
 			m.navigatePinned(-1)
 			m.saveSettings()
-		} else if len(m.groups) > 0 || m.hasFavorites() {
+		} else if m.usesVisibleItems() {
 			m.buildVisibleItems()
 			if m.cursor > 0 {
 				m.cursor--
@@ -371,7 +379,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.splitView && m.splitFocus == 1 && m.markedSessionID != "" {
 			m.navigatePinned(1)
 			m.saveSettings()
-		} else if len(m.groups) > 0 || m.hasFavorites() {
+		} else if m.usesVisibleItems() {
 			m.buildVisibleItems()
 			if m.cursor < len(m.visibleItems)-1 {
 				m.cursor++
@@ -395,13 +403,13 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case "ctrl+up":
+	case "move_up":
 		m.handleMoveSessionUp()
 
-	case "ctrl+down":
+	case "move_down":
 		m.handleMoveSessionDown()
 
-	case "ctrl+left", "alt+left", "[":
+	case "prev_window":
 		// Switch to previous tmux window/tab
 		if inst := m.getSelectedInstance(); inst != nil {
 			if inst.Status == session.StatusRunning {
@@ -409,7 +417,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case "ctrl+right", "alt+right", "]":
+	case "next_window":
 		// Switch to next tmux window/tab
 		if inst := m.getSelectedInstance(); inst != nil {
 			if inst.Status == session.StatusRunning {
@@ -417,7 +425,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case "alt+up":
+	case "scroll_up":
 		// Scroll diff pane or preview up (1 line)
 		if m.showDiff {
 			m.diffPane.ScrollUp()
@@ -425,7 +433,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.scrollPreviewUp(1)
 
-	case "alt+down":
+	case "scroll_down":
 		// Scroll diff pane or preview down (1 line)
 		if m.showDiff {
 			m.diffPane.ScrollDown()
@@ -433,7 +441,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.scrollPreviewDown(1)
 
-	case "pgup", "alt+pgup":
+	case "page_up":
 		// Scroll diff pane or preview up (half page)
 		if m.showDiff {
 			m.diffPane.PageUp()
@@ -445,7 +453,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.scrollPreviewUp(halfPage)
 
-	case "pgdown", "alt+pgdown":
+	case "page_down":
 		// Scroll diff pane or preview down (half page)
 		if m.showDiff {
 			m.diffPane.PageDown()
@@ -457,7 +465,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.scrollPreviewDown(halfPage)
 
-	case "home":
+	case "scroll_top":
 		// Scroll to top
 		if m.showDiff {
 			m.diffPane.GotoTop()
@@ -465,7 +473,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.scrollPreviewUp(10000) // Large number to go to top
 
-	case "end":
+	case "scroll_bottom":
 		// Scroll to bottom
 		if m.showDiff {
 			m.diffPane.GotoBottom()
@@ -473,7 +481,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.previewScroll = 0
 
-	case "enter":
+	case "open":
 		// Check if a group is selected
 		m.buildVisibleItems()
 		if m.cursor >= 0 && m.cursor < len(m.visibleItems) {
@@ -495,15 +503,25 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
-	case "n":
-		// Start new session flow: agent selection -> path -> name
-		m.agentCursor = 0
-		m.pendingAgent = session.AgentClaude
-		m.pendingGroupID = m.getCurrentGroupID()
-		m.state = stateSelectAgent
+	case "new_session":
+		if !m.checkProjectLimit() {
+			m.openNewSession()
+		}
 		return m, nil
 
-	case "N":
+	case "notes":
+		// Open notes editor for selected group
+		if len(m.groups) > 0 {
+			m.buildVisibleItems()
+			if group := m.getSelectedGroup(); group != nil && group.ID != FavoritesGroupID {
+				m.notesGroupID = group.ID
+				m.notesInput.SetValue(group.Notes)
+				m.notesInput.Placeholder = "Describe what this group is for..."
+				m.notesInput.Focus()
+				m.state = stateNotes
+				return m, nil
+			}
+		}
 		// Open notes editor for selected session or tab
 		if inst := m.getSelectedInstance(); inst != nil {
 			// Get current window index (0 = main, >0 = tab)
@@ -512,6 +530,8 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				windowIdx = inst.GetCurrentWindowIndex()
 			}
 			m.notesWindowIndex = windowIdx
+			m.notesGroupID = ""
+			m.notesInput.Placeholder = "Add notes about this session..."
 
 			// Load notes for the current window
 			if windowIdx == 0 {
@@ -530,33 +550,27 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-	case "r":
-		// Resume only works for agents that support it
-		if inst := m.getSelectedInstance(); inst != nil {
-			config := inst.GetAgentConfig()
-			if !config.SupportsResume {
-				m.err = fmt.Errorf("resume not supported for %s agent", inst.Agent)
-				m.previousState = m.state // Save current state to return after error
-				m.state = stateError
-				return m, nil
-			}
-		}
+	case "resume":
+		// Resume a past conversation in the active tab (support is checked per tab agent)
 		if err := m.handleResumeSession(); err != nil {
 			m.err = err
 			m.previousState = m.state // Save current state to return after error
 			m.state = stateError
 		}
 
-	case "s":
-		m.handleStartSession()
+	case "start":
+		if cmd := m.handleStartSession(); cmd != nil {
+			return m, cmd
+		}
 
-	case "a":
-		// Show start mode selection (replace or parallel)
+	case "replace_start":
+		// Show start mode selection (replace, parallel or parallel in a worktree)
 		if inst := m.getSelectedInstance(); inst != nil {
+			m.startModeGit = session.IsGitRepo(inst.Path)
 			m.state = stateSelectStartMode
 		}
 
-	case "x":
+	case "stop":
 		// Stop session or tab
 		if inst := m.getSelectedInstance(); inst != nil {
 			if inst.Status == session.StatusRunning {
@@ -572,7 +586,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.handleStopSession()
 		}
 
-	case "d":
+	case "delete":
 		// Check if a group is selected
 		m.buildVisibleItems()
 		if m.cursor >= 0 && m.cursor < len(m.visibleItems) {
@@ -582,18 +596,8 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				if item.group.ID == FavoritesGroupID {
 					break
 				}
-				// Delete group
-				if err := m.storage.RemoveGroup(item.group.ID); err != nil {
-					m.err = err
-				} else {
-					// Reload groups
-					groups, _ := m.storage.GetGroups()
-					m.groups = groups
-					m.buildVisibleItems()
-					if m.cursor >= len(m.visibleItems) && m.cursor > 0 {
-						m.cursor--
-					}
-				}
+				// Delete group, asking what happens to its sessions
+				m.openDeleteGroup(item.group)
 				return m, nil
 			}
 		}
@@ -609,15 +613,15 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				}
 			}
 			// Otherwise just confirm session delete
-			m.state = stateConfirmDelete
+			return m, m.confirmDeleteSession()
 		}
 
-	case "ctrl+y":
+	case "yolo":
 		if cmd := m.handleToggleAutoYes(); cmd != nil {
 			return m, cmd
 		}
 
-	case "e":
+	case "rename":
 		// Check if a group is selected
 		m.buildVisibleItems()
 		if m.cursor >= 0 && m.cursor < len(m.visibleItems) {
@@ -639,28 +643,29 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
-	case "?", "f1":
+	case "help":
 		m.state = stateHelp
 
-	case "f":
+	case "fork_tab":
+		return m, m.forkActiveTab()
+
+	case "fork":
 		// Fork session (Claude only)
 		if inst := m.getSelectedInstance(); inst != nil {
 			if inst.Agent != session.AgentClaude {
-				m.err = fmt.Errorf("fork is only supported for Claude sessions")
-				m.previousState = stateList
-				m.state = stateError
+				m.notifyWarn("Fork is only supported for Claude sessions")
+				return m, nil
+			}
+			if inst.IsRemote() {
+				m.notifyWarn("Fork is not available for sessions on %s", inst.Host)
 				return m, nil
 			}
 			if inst.Status != session.StatusRunning {
-				m.err = fmt.Errorf("session must be running to fork")
-				m.previousState = stateList
-				m.state = stateError
+				m.notifyWarn("Session must be running to fork")
 				return m, nil
 			}
 			if inst.ResumeSessionID == "" {
-				m.err = fmt.Errorf("no session ID to fork - session may not have started yet")
-				m.previousState = stateList
-				m.state = stateError
+				m.notifyWarn("No session ID to fork - session may not have started yet")
 				return m, nil
 			}
 			// Set up fork dialog
@@ -684,7 +689,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			return m, textinput.Blink
 		}
 
-	case "c":
+	case "colors":
 		// Check if a group is selected
 		m.buildVisibleItems()
 		if m.cursor >= 0 && m.cursor < len(m.visibleItems) {
@@ -700,15 +705,65 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.handleColorPicker()
 
-	case "l":
+	case "summarize":
+		// Summarize what was done in the session into its notes
+		if cmd := m.summarizeSession(m.getSelectedInstance(), ""); cmd != nil {
+			return m, cmd
+		}
+
+	case "handover":
+		m.handleShowHandover()
+
+	case "export":
+		// Export the (filtered) session list as a Markdown status report
+		if len(m.instances) > 0 {
+			m.state = stateExportReport
+		}
+
+	case "console":
+		// Open tmux console for the selected session
+		if cmd := m.openConsole(); cmd != nil {
+			return m, cmd
+		}
+
+	case "recording":
+		// Start/stop recording the agent pane (asciinema cast)
+		m.handleToggleRecording()
+
+	case "output_log":
+		// Start/stop appending the agent pane's output lines to a log file
+		m.handleToggleOutputLog()
+
+	case "restart_policy":
+		// Cycle the restart policy: never, on-crash, always
+		m.handleCycleRestartPolicy()
+
+	case "idle_stop":
+		// Cycle the idle auto-stop timeout: default, 15 min ... 4 h, never
+		m.handleCycleIdleStop()
+
+	case "export_conversation":
+		// Export the selected session's conversation to Markdown/HTML
+		return m, m.handleExportConversation()
+
+	case "dry_run":
+		// Toggle dry-run: lifecycle actions show their commands before running
+		m.dryRun = !m.dryRun
+		if m.dryRun {
+			m.notifyWarn("Dry-run ON - commands are shown for confirmation before running")
+		} else {
+			m.notifyInfo("Dry-run OFF")
+		}
+
+	case "compact":
 		m.compactList = !m.compactList
 		m.saveSettings()
 
-	case "o":
+	case "status_lines":
 		m.hideStatusLines = !m.hideStatusLines
 		m.saveSettings()
 
-	case "t":
+	case "new_tab":
 		// Open new tmux tab/window - ask Agent or Terminal
 		if inst := m.getSelectedInstance(); inst != nil {
 			if inst.Status == session.StatusRunning {
@@ -717,7 +772,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case "T":
+	case "rename_tab":
 		// Rename current tmux tab/window (only if multiple windows)
 		if inst := m.getSelectedInstance(); inst != nil {
 			if inst.Status == session.StatusRunning {
@@ -737,7 +792,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case "W":
+	case "close_tab":
 		// Close current tmux tab/window (not window 0)
 		if inst := m.getSelectedInstance(); inst != nil {
 			if inst.Status == session.StatusRunning {
@@ -759,7 +814,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case "D":
+	case "diff":
 		// Toggle diff view in preview pane
 		m.showDiff = !m.showDiff
 		if m.showDiff {
@@ -768,7 +823,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case "F":
+	case "full_diff":
 		// Toggle diff mode (Session/Full) when in diff view
 		if m.showDiff {
 			m.diffPane.ToggleMode()
@@ -777,16 +832,38 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case "I":
+	case "next_file":
+		// Jump to the next file of the diff
+		if m.showDiff {
+			m.diffPane.NextFile()
+		}
+
+	case "prev_file":
+		// Jump to the previous file of the diff
+		if m.showDiff {
+			m.diffPane.PrevFile()
+		}
+
+	case "fold_file":
+		// Collapse or expand the file at the top of the diff
+		if m.showDiff && !m.diffPane.ToggleFold() {
+			m.notifyInfo("Folding works in the built-in diff view (set \"renderer\": \"builtin\" in diff.json)")
+		}
+
+	case "stage":
+		// Stage hunks and commit from the diff pane
+		m.openStage()
+
+	case "icons":
 		m.showAgentIcons = !m.showAgentIcons
 		m.saveSettings()
 
-	case "v":
+	case "split":
 		m.splitView = !m.splitView
 		m.splitFocus = 0 // Reset focus when toggling
 		m.saveSettings()
 
-	case "tab":
+	case "switch_focus":
 		// In split view: switch focus between panels
 		if m.splitView && m.markedSessionID != "" {
 			m.splitFocus = 1 - m.splitFocus // Toggle between 0 and 1
@@ -810,7 +887,7 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case "m":
+	case "mark":
 		// Mark current session for split view
 		inst := m.getSelectedInstance()
 		if inst != nil {
@@ -825,35 +902,154 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.saveSettings()
 		}
 
-	case "*":
+	case "favorite":
 		// Toggle favorite
 		inst := m.getSelectedInstance()
 		if inst != nil {
+			anchor := m.rememberSelection()
 			inst.Favorite = !inst.Favorite
 			m.storage.UpdateInstance(inst)
-			m.buildVisibleItems()
+			m.restoreSelection(anchor)
 		}
 
-	case "p":
+	case "protect":
+		m.handleToggleProtected()
+
+	case "edit_command":
+		// Edit the command of a Custom agent session or tab
+		return m, m.handleEditCustomCommand()
+
+	case "model":
+		// Pick the model of the active tab's agent
+		return m, m.openModelPicker()
+
+	case "resources":
+		// Sessions sorted by CPU/memory usage
+		m.handleShowResources()
+
+	case "churn":
+		// Diff stats summed across the group/project
+		return m, m.handleShowChurn()
+
+	case "usage":
+		// Token usage and estimated cost per group and day
+		return m, m.handleShowUsage()
+
+	case "presets":
+		// Presets gallery: install and start from ready-made setups
+		return m, m.handleShowPresets()
+
+	case "attach_readonly":
+		// Watch the session without the risk of typing into it
+		return m, m.handleAttachReadOnly()
+
+	case "reply":
+		// Answer the waiting question shown in the preview, or jump to a
+		// waiting session listed on the dashboard when nothing is selected
+		if m.showsDashboard() {
+			m.jumpToWaiting(int(msg.String()[0] - '0'))
+			return m, nil
+		}
+		m.handleQuickReply(msg.String())
+
+	case "stuck":
+		m.handleShowStuck()
+
+	case "permissions":
+		m.handleShowPermissions()
+
+	case "alerts":
+		m.handleShowAlerts()
+
+	case "scrollback":
+		m.handleShowScrollback()
+
+	case "preview_filter":
+		m.cyclePreviewFilter()
+
+	case "processes":
+		// Processes inside the session (signal/kill individual ones)
+		m.handleShowProcesses(m.getSelectedInstance())
+
+	case "windows":
+		// Pick a window of the session to switch to or attach
+		m.openWindowPicker(m.getSelectedInstance())
+
+	case "split_right":
+		// Terminal pane beside the agent of the active window (again: close it)
+		m.toggleSplit(session.SplitHorizontal)
+
+	case "split_below":
+		// Terminal pane below the agent of the active window (again: close it)
+		m.toggleSplit(session.SplitVertical)
+
+	case "import_tmux":
+		// Import tmux sessions started outside the manager
+		m.openAdoptDialog()
+
+	case "about":
+		// Agent version, model, git and OS the session was started with
+		m.handleShowAbout(m.getSelectedInstance())
+
+	case "branch":
+		// Check out another branch before starting the agent
+		m.openBranchPicker()
+
+	case "transfer":
+		// Copy a file or apply a patch in another session's directory
+		m.openTransfer()
+
+	case "select":
+		// Check the session for a batch action
+		m.toggleMultiSelect()
+
+	case "select_range":
+		// Range selection: V at both ends of the range
+		m.toggleVisualSelect()
+
+	case "timezone":
+		// Set the session's timezone
+		return m, m.handleSetTimezone()
+
+	case "reopen":
+		// Reopen the most recently stopped/deleted session
+		return m, m.reopenLastClosed()
+
+	case "prompt":
 		m.handleSendPrompt()
 
-	case "R":
+	case "resize":
 		m.handleForceResize()
 
-	case "U":
+	case "update":
 		// Show update confirmation
+		m.previousState = stateList
 		m.state = stateConfirmUpdate
 		return m, nil
 
-	case "g":
+	case "history":
+		// Show message history
+		m.messagesScroll = 0
+		m.state = stateMessages
+		return m, nil
+
+	case "new_group":
 		// Create new group
+		if m.globalList {
+			m.notifyWarn("Groups are per project - leave the all projects list (%s a) first", m.keys.help("leader"))
+			return m, nil
+		}
 		m.groupInput.SetValue("")
 		m.groupInput.Focus()
 		m.state = stateNewGroup
 		return m, textinput.Blink
 
-	case "G":
+	case "assign_group":
 		// Assign session to group
+		if m.globalList {
+			m.notifyWarn("Groups are per project - leave the all projects list (%s a) first", m.keys.help("leader"))
+			return m, nil
+		}
 		if len(m.instances) > 0 {
 			// Find current session
 			var inst *session.Instance
@@ -882,7 +1078,11 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.state = stateSelectGroup
 		}
 
-	case "right":
+	case "expand":
+		// Expand the sessions nested under a session
+		if m.toggleNestedChildren(true) {
+			break
+		}
 		// Expand group
 		m.buildVisibleItems()
 		if m.cursor >= 0 && m.cursor < len(m.visibleItems) {
@@ -899,7 +1099,11 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case "left":
+	case "collapse":
+		// Collapse the sessions nested under a session (or go to their origin)
+		if m.toggleNestedChildren(false) {
+			break
+		}
 		// Collapse group
 		m.buildVisibleItems()
 		if m.cursor >= 0 && m.cursor < len(m.visibleItems) {
@@ -916,14 +1120,15 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			}
 		}
 
-	case "/":
+	case "search":
 		// Start search mode
+		m.searchAnchor = m.rememberSelection()
 		m.searchInput.SetValue("")
 		m.searchInput.Focus()
 		m.state = stateSearch
 		return m, textinput.Blink
 
-	case "ctrl+f":
+	case "global_search":
 		// Start global search mode (search all agent histories)
 		m.globalSearchInput.SetValue("")
 		m.globalSearchResults = nil
@@ -945,7 +1150,12 @@ func (m Model) handleListKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.state = stateGlobalSearchLoading
 		return m, m.loadHistoryCmd()
 
-	case "esc":
+	case "back":
+		// Drop the multi-selection first, then the search filter
+		if m.hasMultiSelect() {
+			m.clearMultiSelect()
+			return m, nil
+		}
 		// Clear active search filter
 		if m.searchActive {
 			m.searchQuery = ""
@@ -39,6 +39,7 @@ func (m Model) handleProjectSelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			m.state = stateList
+			return m, m.refreshTopics()
 		} else if m.projectCursor == len(m.projects) {
 			// "Continue without project"
 			if err := m.switchToProject(nil); err != nil {
@@ -48,6 +49,7 @@ func (m Model) handleProjectSelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			m.state = stateList
+			return m, m.refreshTopics()
 		} else {
 			// "New Project" (last option)
 			m.projectInput.Reset()
@@ -86,17 +88,13 @@ func (m Model) handleProjectSelectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// Check if there are sessions to import
 			defaultCount := m.storage.GetProjectSessionCount("")
 			if defaultCount == 0 {
-				m.previousState = stateProjectSelect
-				m.err = fmt.Errorf("no sessions to import (default is empty)")
-				m.state = stateError
+				m.notifyWarn("No sessions to import (default is empty)")
 				return m, nil
 			}
 			m.importTarget = m.projects[m.projectCursor]
 			m.state = stateConfirmImport
 		} else {
-			m.previousState = stateProjectSelect
-			m.err = fmt.Errorf("select a project first to import sessions into")
-			m.state = stateError
+			m.notifyWarn("Select a project first to import sessions into")
 		}
 
 	case "U":
@@ -146,7 +144,7 @@ func (m Model) handleNewProjectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 
 		m.state = stateList
-		return m, nil
+		return m, m.refreshTopics()
 	}
 
 	var cmd tea.Cmd
@@ -192,8 +190,8 @@ func (m Model) handleRenameProjectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 // handleConfirmDeleteProjectKeys handles keyboard input in the project deletion confirmation
 func (m Model) handleConfirmDeleteProjectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "y", "Y":
+	switch m.keys.answer(msg) {
+	case answerYes:
 		if m.deleteProjectTarget != nil {
 			if err := m.storage.RemoveProject(m.deleteProjectTarget.ID); err != nil {
 				m.previousState = stateProjectSelect
@@ -214,7 +212,7 @@ func (m Model) handleConfirmDeleteProjectKeys(msg tea.KeyMsg) (tea.Model, tea.Cm
 		m.deleteProjectTarget = nil
 		m.state = stateProjectSelect
 
-	case "n", "N", "esc":
+	case answerNo, "esc":
 		m.deleteProjectTarget = nil
 		m.state = stateProjectSelect
 	}
@@ -224,8 +222,8 @@ func (m Model) handleConfirmDeleteProjectKeys(msg tea.KeyMsg) (tea.Model, tea.Cm
 
 // handleConfirmImportKeys handles keyboard input in the import confirmation
 func (m Model) handleConfirmImportKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	switch msg.String() {
-	case "y", "Y":
+	switch m.keys.answer(msg) {
+	case answerYes:
 		if m.importTarget != nil {
 			count, err := m.storage.ImportDefaultSessions(m.importTarget.ID)
 			m.previousState = stateProjectSelect
@@ -233,13 +231,13 @@ func (m Model) handleConfirmImportKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				m.err = err
 				m.state = stateError
 			} else {
-				m.err = fmt.Errorf("successfully imported %d sessions into '%s'", count, m.importTarget.Name)
-				m.state = stateError // Use dialog for success message too
+				m.notifySuccess("Imported %d sessions into '%s'", count, m.importTarget.Name)
+				m.state = stateProjectSelect
 			}
 		}
 		m.importTarget = nil
 
-	case "n", "N", "esc":
+	case answerNo, "esc":
 		m.importTarget = nil
 		m.state = stateProjectSelect
 	}
@@ -2,7 +2,6 @@ package ui
 
 import (
 	"fmt"
-	"os/exec"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
@@ -20,42 +19,55 @@ func isGradientColor(color string) bool {
 func RefreshTmuxStatusBar(sessionName, instanceName, fgColor, bgColor string, autoYes bool) {
 	// Simple version for backward compatibility - only main window YOLO
 	windowYolo := map[int]bool{0: autoYes}
-	configureTmuxStatusBarWithYolo(sessionName, instanceName, fgColor, bgColor, windowYolo)
+	configureTmuxStatusBarWithYolo(session.Tmux, sessionName, instanceName, fgColor, bgColor, windowYolo)
 }
 
 // RefreshTmuxStatusBarFull is the full version with per-window YOLO support
 func RefreshTmuxStatusBarFull(sessionName, instanceName, fgColor, bgColor string, inst *session.Instance) {
+	// Called outside the TUI, so custom gradients and the theme are not loaded yet
+	if palettes, err := session.LoadPalettes(); err == nil {
+		registerPalettes(palettes)
+	}
+	if config, err := session.LoadConfig(); err == nil {
+		// Not run in the terminal, so only an explicit color_mode applies
+		if config.ColorMode != "" && config.ColorMode != "auto" {
+			applyColorMode(config.ColorMode)
+		}
+		applyTheme(config.Theme)
+	}
 	// Build map of window index -> autoYes
 	windowYolo := map[int]bool{0: inst.AutoYes}
 	for _, fw := range inst.FollowedWindows {
 		windowYolo[fw.Index] = fw.AutoYes
 	}
-	configureTmuxStatusBarWithYolo(sessionName, instanceName, fgColor, bgColor, windowYolo)
+	configureTmuxStatusBarWithYolo(inst.Tmux(), sessionName, instanceName, fgColor, bgColor, windowYolo)
 }
 
 // configureTmuxStatusBar is a backward compatible wrapper
 func configureTmuxStatusBar(sessionName, instanceName, fgColor, bgColor string, autoYes bool) {
 	windowYolo := map[int]bool{0: autoYes}
-	configureTmuxStatusBarWithYolo(sessionName, instanceName, fgColor, bgColor, windowYolo)
+	configureTmuxStatusBarWithYolo(session.Tmux, sessionName, instanceName, fgColor, bgColor, windowYolo)
 }
 
 // configureTmuxStatusBarWithYolo sets up the tmux status bar with per-window YOLO support
-func configureTmuxStatusBarWithYolo(sessionName, instanceName, fgColor, bgColor string, windowYolo map[int]bool) {
+func configureTmuxStatusBarWithYolo(tmux session.Runner, sessionName, instanceName, fgColor, bgColor string, windowYolo map[int]bool) {
 	target := sessionName + ":"
 
 	// Enable status bar
-	exec.Command("tmux", "set-option", "-t", target, "status", "on").Run()
+	tmux.Run("set-option", "-t", target, "status", "on")
 
 	// Status bar style - dark background
-	exec.Command("tmux", "set-option", "-t", target, "status-style", "bg=#1a1a2e,fg=#888888").Run()
+	tmux.Run("set-option", "-t", target, "status-style", "bg=#1a1a2e,fg=#888888")
 
 	// Get window list with names, index, active status, and dead status
-	windowListOutput, _ := exec.Command("tmux", "list-windows", "-t", sessionName, "-F", "#{window_index}:#{window_name}:#{window_active}:#{pane_dead}").Output()
+	windowListOutput, _ := tmux.Output("list-windows", "-t", sessionName, "-F", "#{window_index}:#{window_name}:#{window_active}:#{pane_dead}")
 	windowLines := strings.Split(strings.TrimSpace(string(windowListOutput)), "\n")
 
 	// Build status line with session name and tabs
 	formattedName := formatTmuxSessionName(instanceName, fgColor, bgColor)
 	var statusLeft strings.Builder
+	// Read-only clients (Y in the list) get a marker; evaluated per client
+	statusLeft.WriteString("#{?client_readonly,#[fg=##1a1a2e#,bg=##FFA500#,bold] READ-ONLY #[nobold],}")
 	statusLeft.WriteString(fmt.Sprintf("#[default,bg=#1a1a2e] %s ", formattedName))
 
 	windowCount := 0
@@ -104,30 +116,30 @@ func configureTmuxStatusBarWithYolo(sessionName, instanceName, fgColor, bgColor
 	}
 
 	// Set status-left with our tab list
-	exec.Command("tmux", "set-option", "-t", target, "status-left", statusLeft.String()).Run()
-	exec.Command("tmux", "set-option", "-t", target, "status-left-length", "500").Run()
+	tmux.Run("set-option", "-t", target, "status-left", statusLeft.String())
+	tmux.Run("set-option", "-t", target, "status-left-length", "500")
 
 	// Hide tmux's built-in window list
-	exec.Command("tmux", "set-option", "-t", target, "window-status-format", "").Run()
-	exec.Command("tmux", "set-option", "-t", target, "window-status-current-format", "").Run()
-	exec.Command("tmux", "set-option", "-t", target, "window-status-separator", "").Run()
+	tmux.Run("set-option", "-t", target, "window-status-format", "")
+	tmux.Run("set-option", "-t", target, "window-status-current-format", "")
+	tmux.Run("set-option", "-t", target, "window-status-separator", "")
 
 	// Use status-format to hide window list completely
 	statusFormat := fmt.Sprintf("#[align=left]%s#[align=right]#[fg=#555555]Alt+</>: tabs | Ctrl+Q: detach ", statusLeft.String())
-	exec.Command("tmux", "set-option", "-t", target, "status-format[0]", statusFormat).Run()
+	tmux.Run("set-option", "-t", target, "status-format[0]", statusFormat)
 
 	// Right side (backup, status-format overrides this)
-	exec.Command("tmux", "set-option", "-t", target, "status-right", "").Run()
+	tmux.Run("set-option", "-t", target, "status-right", "")
 
 	// Hook to refresh status bar when window changes
 	refreshCmd := fmt.Sprintf("asmgr refresh-status %s", sessionName)
-	exec.Command("tmux", "set-hook", "-t", sessionName, "window-linked", fmt.Sprintf("run-shell '%s'", refreshCmd)).Run()
-	exec.Command("tmux", "set-hook", "-t", sessionName, "window-unlinked", fmt.Sprintf("run-shell '%s'", refreshCmd)).Run()
-	exec.Command("tmux", "set-hook", "-t", sessionName, "session-window-changed", fmt.Sprintf("run-shell '%s'", refreshCmd)).Run()
+	tmux.Run("set-hook", "-t", sessionName, "window-linked", fmt.Sprintf("run-shell '%s'", refreshCmd))
+	tmux.Run("set-hook", "-t", sessionName, "window-unlinked", fmt.Sprintf("run-shell '%s'", refreshCmd))
+	tmux.Run("set-hook", "-t", sessionName, "session-window-changed", fmt.Sprintf("run-shell '%s'", refreshCmd))
 
 	// Key bindings for tab switching
-	exec.Command("tmux", "bind-key", "-n", "M-Left", "previous-window").Run()
-	exec.Command("tmux", "bind-key", "-n", "M-Right", "next-window").Run()
+	tmux.Run("bind-key", "-n", "M-Left", "previous-window")
+	tmux.Run("bind-key", "-n", "M-Right", "next-window")
 }
 
 // handleEnterSession starts (if needed) and attaches to the selected session
@@ -157,45 +169,78 @@ func (m *Model) handleEnterSession() tea.Cmd {
 			m.state = stateError
 			return nil
 		}
-		if err := inst.Start(); err != nil {
-			m.err = err
-			m.previousState = stateList
-			m.state = stateError
-			return nil
-		}
-		m.storage.UpdateInstance(inst)
-	} else {
-		// Session is running - check if active tab is dead and respawn it
-		windows := inst.GetWindowList()
-		for _, w := range windows {
-			if w.Active && w.Dead {
-				inst.RespawnWindow(w.Index)
-				break
-			}
+		// Start in the background, attach once it is running
+		return m.startSessionAsync(inst, "", true)
+	}
+	if m.isBusy(inst) {
+		return nil
+	}
+	// Session is running - check if active tab is dead and respawn it
+	windows := inst.GetWindowList()
+	for _, w := range windows {
+		if w.Active && w.Dead {
+			inst.RespawnWindow(w.Index)
+			break
 		}
 	}
+	return m.attachSession(inst)
+}
+
+// attachSession configures tmux for the session and attaches to it
+func (m *Model) attachSession(inst *session.Instance) tea.Cmd {
+	return m.attachTmux(inst, false)
+}
+
+// handleAttachReadOnly attaches to the selected running session in read-only
+// mode: keystrokes other than Ctrl+Q never reach the agent
+func (m *Model) handleAttachReadOnly() tea.Cmd {
+	inst := m.getSelectedInstance()
+	if inst == nil || m.isBusy(inst) {
+		return nil
+	}
+	inst.UpdateStatus()
+	if inst.Status != session.StatusRunning {
+		m.notifyWarn("'%s' is not running - nothing to watch", inst.Name)
+		return nil
+	}
+	return m.attachTmux(inst, true)
+}
+
+// attachTmux configures tmux for the session and attaches to it, read-only when asked
+func (m *Model) attachTmux(inst *session.Instance, readOnly bool) tea.Cmd {
 	sessionName := inst.TmuxSessionName()
 	// Configure tmux for proper terminal resize following (ignore errors - non-critical)
-	exec.Command("tmux", "set-option", "-t", sessionName, "window-size", "largest").Run()
-	exec.Command("tmux", "set-option", "-t", sessionName, "aggressive-resize", "on").Run()
+	inst.Tmux().Run("set-option", "-t", sessionName, "window-size", "largest")
+	inst.Tmux().Run("set-option", "-t", sessionName, "aggressive-resize", "on")
 	// Enable focus events for hooks to work
-	exec.Command("tmux", "set-option", "-t", sessionName, "focus-events", "on").Run()
+	inst.Tmux().Run("set-option", "-t", sessionName, "focus-events", "on")
 	// Set up hook to resize window on focus gain (fixes Konsole tab switch issue)
-	exec.Command("tmux", "set-hook", "-t", sessionName, "client-focus-in", "resize-window -A").Run()
-	exec.Command("tmux", "set-hook", "-t", sessionName, "pane-focus-in", "resize-window -A").Run()
+	inst.Tmux().Run("set-hook", "-t", sessionName, "client-focus-in", "resize-window -A")
+	inst.Tmux().Run("set-hook", "-t", sessionName, "pane-focus-in", "resize-window -A")
 
 	// Update window 0 name to agent type (session name is shown in status bar)
-	exec.Command("tmux", "rename-window", "-t", sessionName+":0", inst.WindowName()).Run()
+	inst.Tmux().Run("rename-window", "-t", sessionName+":0", inst.WindowName())
 
 	// Configure tmux status bar to show tabs with per-window YOLO support
 	RefreshTmuxStatusBarFull(sessionName, inst.Name, inst.Color, inst.BgColor, inst)
 
-	// Set up Ctrl+Q to resize to preview size before detach
-	tmuxWidth, tmuxHeight := m.calculateTmuxDimensions()
-	inst.UpdateDetachBinding(tmuxWidth, tmuxHeight)
-	cmd := exec.Command("tmux", "attach-session", "-t", sessionName)
-	return tea.ExecProcess(cmd, func(err error) tea.Msg {
-		return reattachMsg{}
+	// Show session name in terminal window/tab title while attached
+	configureTmuxTitle(inst.Tmux(), sessionName, inst.Name)
+
+	args := []string{"attach-session", "-t", sessionName}
+	if readOnly {
+		// Read-only clients don't resize the window, so detach needs no resize
+		inst.ReadOnlyDetachBinding()
+		args = []string{"attach-session", "-r", "-t", sessionName}
+	} else {
+		// Set up Ctrl+Q to resize to preview size before detach
+		tmuxWidth, tmuxHeight := m.calculateTmuxDimensions()
+		inst.UpdateDetachBinding(tmuxWidth, tmuxHeight)
+	}
+	m.stopAttachedAlerts = m.watchWhileAttached(inst)
+	cmd := inst.TmuxCommand(args...)
+	return execProcess(cmd, func(err error) tea.Msg {
+		return reattachMsg{inst: inst}
 	})
 }
 
@@ -205,76 +250,14 @@ func (m *Model) handleResumeSession() error {
 	if inst == nil {
 		return nil
 	}
-
-	// Determine agent type based on active window
-	agentType := inst.Agent
-	if agentType == "" {
-		agentType = session.AgentClaude
-	}
-	activeWindowIndex := 0
-
-	// Check if there's an active followed window
-	if inst.Status == session.StatusRunning {
-		windows := inst.GetWindowList()
-		for _, w := range windows {
-			if w.Active {
-				activeWindowIndex = w.Index
-				if w.Followed {
-					// Find the followed window's agent type
-					for _, fw := range inst.FollowedWindows {
-						if fw.Index == w.Index {
-							agentType = fw.Agent
-							break
-						}
-					}
-				}
-				break
-			}
-		}
-	}
-
-	// Terminal windows don't support resume
-	if agentType == session.AgentTerminal {
-		return fmt.Errorf("terminal windows don't support session resume")
-	}
-
-	// List sessions based on agent type
-	var sessions []session.AgentSession
-	var err error
-
-	switch agentType {
-	case session.AgentGemini:
-		sessions, err = session.ListGeminiSessions(inst.Path)
-	case session.AgentCodex:
-		sessions, err = session.ListCodexSessions(inst.Path)
-	case session.AgentOpenCode:
-		sessions, err = session.ListOpenCodeSessions(inst.Path)
-	case session.AgentAmazonQ:
-		sessions, err = session.ListAmazonQSessions(inst.Path)
-	default:
-		// Claude and others
-		sessions, err = session.ListAgentSessions(inst.Path)
-	}
-
-	if err != nil {
-		return err
-	}
-	if len(sessions) == 0 {
-		return fmt.Errorf("no previous %s sessions found", agentType)
-	}
-	m.agentSessions = sessions
-	m.resumeAgentType = agentType           // Store which agent type we're resuming
-	m.resumeWindowIndex = activeWindowIndex // Store which window to resume
-	m.sessionCursor = 1                     // Start with first session selected (0 is "new session")
-	m.state = stateSelectAgentSession
-	return nil
+	return m.openResumePicker(inst, activeWindowIndex(inst))
 }
 
 // handleStartSession starts the selected session without attaching
-func (m *Model) handleStartSession() {
+func (m *Model) handleStartSession() tea.Cmd {
 	inst := m.getSelectedInstance()
-	if inst == nil {
-		return
+	if inst == nil || m.isBusy(inst) {
+		return nil
 	}
 	// Update status based on actual tmux session state
 	inst.UpdateStatus()
@@ -287,36 +270,29 @@ func (m *Model) handleStartSession() {
 			m.err = err
 			m.previousState = stateList
 			m.state = stateError
-			return
-		}
-		if err := inst.Start(); err != nil {
-			m.err = err
-			m.previousState = stateList
-			m.state = stateError
-		} else {
-			m.storage.UpdateInstance(inst)
+			return nil
 		}
+		return m.startSessionAsync(inst, "", false)
 	} else {
 		// Session is running - check if window 0 (main agent) is dead
 		windows := inst.GetWindowList()
 		for _, w := range windows {
 			if w.Index == 0 && w.Dead {
-				// Window 0 is dead - respawn it with resume ID if available
-				var err error
-				if inst.ResumeSessionID != "" {
-					err = inst.RespawnWindowWithResume(0, inst.ResumeSessionID)
-				} else {
-					err = inst.RespawnWindow(0)
-				}
-				if err != nil {
+				// Window 0 is dead - respawn it, resuming its last conversation when known
+				if err := inst.RestartAgent(); err != nil {
 					m.err = err
 					m.previousState = stateList
 					m.state = stateError
+					return nil
 				}
-				return
+				m.paneDead[inst.ID] = false
+				delete(m.paneExit, inst.ID)
+				m.notifySuccess("Respawned the agent of '%s'", inst.Name)
+				return nil
 			}
 		}
 	}
+	return nil
 }
 
 // handleStopSession shows confirmation dialog for stopping the selected session
@@ -325,6 +301,9 @@ func (m *Model) handleStopSession() {
 	if inst == nil {
 		return
 	}
+	if m.cancelStartWait(inst) {
+		return
+	}
 	if inst.Status == session.StatusRunning {
 		m.stopTarget = inst
 		m.state = stateConfirmStop
@@ -391,12 +370,12 @@ func (m *Model) handleSendPrompt() {
 		return
 	}
 	if inst.Status != session.StatusRunning {
-		m.err = fmt.Errorf("session not running")
-		m.previousState = stateList
-		m.state = stateError
+		m.notifyWarn("Session not running")
 		return
 	}
 	m.promptInput.SetValue("")
+	m.promptResolved = ""
+	m.promptUnresolved = nil
 	inputWidth := PromptMinWidth
 	if m.width > 80 {
 		inputWidth = m.width/2 - 10
@@ -409,6 +388,7 @@ func (m *Model) handleSendPrompt() {
 
 	// Get suggestion from agent
 	m.promptSuggestion = inst.GetSuggestion()
+	m.loadPromptHistory(inst)
 
 	m.state = statePrompt
 }
@@ -427,6 +407,33 @@ func (m *Model) handleForceResize() {
 	}
 }
 
+// confirmDeleteSession opens the delete confirmation for m.deleteTarget.
+// Protected sessions need the session name typed in.
+func (m *Model) confirmDeleteSession() tea.Cmd {
+	m.state = stateConfirmDelete
+	if m.deleteTarget == nil || !m.deleteTarget.Protected {
+		return nil
+	}
+	m.nameInput.SetValue("")
+	m.nameInput.Focus()
+	return textinput.Blink
+}
+
+// handleToggleProtected toggles deletion protection on the selected session
+func (m *Model) handleToggleProtected() {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return
+	}
+	inst.Protected = !inst.Protected
+	m.storage.UpdateInstance(inst)
+	if inst.Protected {
+		m.notifyInfo("'%s' is protected from deletion", inst.Name)
+	} else {
+		m.notifyInfo("'%s' is no longer protected", inst.Name)
+	}
+}
+
 // handleToggleAutoYes shows confirmation dialog for toggling YOLO mode on the active tab
 // Returns a tea.Cmd (currently nil, confirmation happens in handleConfirmYoloKeys)
 func (m *Model) handleToggleAutoYes() tea.Cmd {
@@ -434,6 +441,10 @@ func (m *Model) handleToggleAutoYes() tea.Cmd {
 	if inst == nil {
 		return nil
 	}
+	if inst.Sandbox != "" {
+		m.notifyWarn("'%s' is sandboxed - YOLO mode is disabled", inst.Name)
+		return nil
+	}
 
 	// Determine active window and its agent type
 	activeWindowIndex := 0
@@ -441,7 +452,7 @@ func (m *Model) handleToggleAutoYes() tea.Cmd {
 	if agentType == "" {
 		agentType = session.AgentClaude
 	}
-	currentYolo := inst.AutoYes
+	currentYolo := yoloActive(inst, 0)
 
 	// Check if session is running and has an active followed window
 	if inst.Status == session.StatusRunning {
@@ -454,7 +465,7 @@ func (m *Model) handleToggleAutoYes() tea.Cmd {
 					for _, fw := range inst.FollowedWindows {
 						if fw.Index == w.Index {
 							agentType = fw.Agent
-							currentYolo = fw.AutoYes
+							currentYolo = yoloActive(inst, fw.Index)
 							break
 						}
 					}
@@ -478,18 +489,14 @@ func (m *Model) handleToggleAutoYes() tea.Cmd {
 
 	// Terminal windows don't support YOLO
 	if agentType == session.AgentTerminal {
-		m.err = fmt.Errorf("terminal windows don't support YOLO mode")
-		m.previousState = stateList
-		m.state = stateError
+		m.notifyWarn("Terminal windows don't support YOLO mode")
 		return nil
 	}
 
 	// Check if agent supports AutoYes
 	config := session.AgentConfigs[agentType]
 	if !config.SupportsAutoYes {
-		m.err = fmt.Errorf("yolo mode not supported for %s agent", agentType)
-		m.previousState = stateList
-		m.state = stateError
+		m.notifyWarn("YOLO mode not supported for %s agent", agentType)
 		return nil
 	}
 
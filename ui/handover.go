@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// HandoverSeedDelay is how long a session started from a handover gets to boot
+// its agent before the handover prompt is sent
+const HandoverSeedDelay = 8 * time.Second
+
+// handoverDoneMsg is sent when a background handover document is written
+type handoverDoneMsg struct {
+	inst *session.Instance
+	path string
+	seed bool // Start a new session that continues from the document
+	err  error
+}
+
+// handoverCmd writes a session's handover document off the UI loop,
+// asking the summarizer for a fresh summary first when summarize is set
+func handoverCmd(inst *session.Instance, summarize, seed bool) tea.Cmd {
+	return func() tea.Msg {
+		summary := ""
+		if summarize {
+			var err error
+			if summary, err = inst.Summarize(session.LoadSummaryConfig(), inst.SummaryInput()); err != nil {
+				return handoverDoneMsg{inst: inst, seed: seed, err: err}
+			}
+		}
+		path, err := inst.WriteHandover(summary)
+		return handoverDoneMsg{inst: inst, path: path, seed: seed, err: err}
+	}
+}
+
+// handleShowHandover opens the handover dialog for the selected session
+func (m *Model) handleShowHandover() {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return
+	}
+	m.handoverTarget = inst
+	m.state = stateHandover
+}
+
+// handleHandoverKeys handles keyboard input in the handover dialog
+func (m Model) handleHandoverKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	inst := m.handoverTarget
+	switch msg.String() {
+	case "esc", "q":
+		m.handoverTarget = nil
+		m.state = stateList
+	case " ":
+		m.handoverSummarize = !m.handoverSummarize
+	case "enter", "n":
+		if m.isBusy(inst) {
+			m.notifyInfo("'%s' is busy", inst.Name)
+			return m, nil
+		}
+		m.handoverTarget = nil
+		m.state = stateList
+		return m, tea.Batch(m.beginOperation(inst, "Writing handover"), handoverCmd(inst, m.handoverSummarize, msg.String() == "n"))
+	}
+	return m, nil
+}
+
+// handleHandoverDone reports a written handover and starts the seeded session if asked for
+func (m Model) handleHandoverDone(msg handoverDoneMsg) (tea.Model, tea.Cmd) {
+	m.endOperation(msg.inst)
+	if msg.err != nil {
+		m.notify(toastError, "Handover of '%s' failed: %v", msg.inst.Name, msg.err)
+		return m, nil
+	}
+	if !msg.seed {
+		m.notifySuccess("Handover written to %s", msg.path)
+		return m, nil
+	}
+
+	source := msg.inst
+	newInst, err := session.NewInstance(source.Name+" (handover)", source.Path, source.AutoYes, source.Agent)
+	if err != nil {
+		m.showError(fmt.Errorf("failed to create handover session: %w", err))
+		return m, nil
+	}
+	newInst.CustomCommand = source.CustomCommand
+	newInst.GroupID = source.GroupID
+	newInst.Color = source.Color
+	newInst.BgColor = source.BgColor
+	newInst.FullRowColor = source.FullRowColor
+	newInst.Notes = fmt.Sprintf("Handover from: %s (%s)", source.Name, msg.path)
+	// Sent by the scheduled prompt check once the agent is up
+	newInst.SchedulePrompt(fmt.Sprintf(session.HandoverPrompt, msg.path), time.Now().Add(HandoverSeedDelay))
+
+	if err := m.storage.AddInstance(newInst); err != nil {
+		m.showError(fmt.Errorf("failed to save handover session: %w", err))
+		return m, nil
+	}
+	m.instances = append(m.instances, newInst)
+	m.selectInstance(newInst)
+	m.notifySuccess("Handover written - starting '%s' with it", newInst.Name)
+	return m, m.startSessionAsync(newInst, "", false)
+}
+
+// handoverView renders the handover dialog as an overlay
+func (m Model) handoverView() string {
+	inst := m.handoverTarget
+	if inst == nil {
+		return m.listView()
+	}
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+	boxWidth := 66
+	boxContent.WriteString(fmt.Sprintf("  Handover for '%s'\n\n", truncateRunes(inst.Name, 40)))
+	boxContent.WriteString(dimStyle.Render("  Summary, todo list, branch, changes and resume command"))
+	boxContent.WriteString("\n\n")
+
+	check := "[ ]"
+	if m.handoverSummarize {
+		check = "[x]"
+	}
+	boxContent.WriteString(fmt.Sprintf("  %s Summarize the conversation first (slow)\n", check))
+	if !m.handoverSummarize {
+		boxContent.WriteString(dimStyle.Render("      otherwise the newest summary from the notes is used"))
+		boxContent.WriteString("\n")
+	}
+	boxContent.WriteString("\n")
+	boxContent.WriteString("  enter  Write the handover document\n")
+	boxContent.WriteString("  n      Write it and start a new session that continues from it\n\n")
+	boxContent.WriteString(dimStyle.Render("  Saved as " + filepath.Join("handovers", filepath.Base(inst.HandoverPath()))))
+	boxContent.WriteString("\n\n")
+	boxContent.WriteString(helpStyle.Render("  space: summarize  enter: write  n: write & new session  esc: close"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Handover ", boxContent.String(), boxWidth, ColorAccent)
+}
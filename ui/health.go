@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// HealthInterval is how often the project status cache is written and the selector badges refreshed
+const HealthInterval = 5 * time.Second
+
+// updateHealth writes the open project's status cache, or refreshes the
+// project selector badges from the caches of all projects
+func (m *Model) updateHealth() {
+	switch m.state {
+	case stateList, stateProjectSelect:
+	default:
+		return
+	}
+	// Refresh the badges right away when the selector opens
+	if time.Since(m.healthAt) < HealthInterval && !(m.state == stateProjectSelect && m.projectHealth == nil) {
+		return
+	}
+	m.healthAt = time.Now()
+	live := session.LiveSessions()
+
+	if m.state == stateProjectSelect {
+		m.projectHealth = make(map[string]session.HealthSummary)
+		for _, project := range m.projects {
+			m.projectHealth[project.ID] = m.storage.LoadProjectHealth(project.ID).Summary(live)
+		}
+		m.projectHealth[""] = m.storage.LoadProjectHealth("").Summary(live)
+		return
+	}
+
+	health := session.ProjectHealth{
+		Sessions:  make(map[string]session.SessionHealth),
+		UpdatedAt: m.healthAt,
+	}
+	projectID := m.storage.GetActiveProjectID()
+	for _, inst := range m.instances {
+		if m.storage.ProjectOf(inst.ID) != projectID {
+			continue // Other project in the all projects list
+		}
+		var sh session.SessionHealth
+		if active, ok := live[inst.TmuxSessionName()]; ok && inst.Status == session.StatusRunning {
+			sh.Activity = m.activityState[inst.ID].String()
+			sh.Error = session.IsErrorLine(m.lastLines[inst.ID])
+			sh.LastActive = active
+		} else if !inst.StoppedAt.IsZero() {
+			sh.LastActive = inst.StoppedAt
+		}
+		health.Sessions[inst.ID] = sh
+	}
+	m.storage.SaveProjectHealth(projectID, health)
+	m.projectHealth = nil
+}
+
+// projectBadges renders the running/waiting/error badges and last activity of a project
+// (plain text for the selected row)
+func (m Model) projectBadges(projectID string, plain bool) string {
+	summary, ok := m.projectHealth[projectID]
+	if !ok {
+		return ""
+	}
+	render := func(style lipgloss.Style, text string) string {
+		if plain {
+			return text
+		}
+		return style.Render(text)
+	}
+
+	var badges []string
+	if summary.Running > 0 {
+		badges = append(badges, render(runningStyle, fmt.Sprintf("▶%d", summary.Running)))
+	}
+	if summary.Waiting > 0 {
+		badges = append(badges, render(waitingStyle, fmt.Sprintf("●%d", summary.Waiting)))
+	}
+	if summary.Errors > 0 {
+		badges = append(badges, render(errorStyle, fmt.Sprintf("✗%d", summary.Errors)))
+	}
+	if !summary.LastActive.IsZero() {
+		badges = append(badges, render(dimStyle, formatTimeAgo(summary.LastActive)))
+	}
+	return strings.Join(badges, " ")
+}
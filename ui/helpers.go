@@ -19,6 +19,10 @@ func (m Model) renderOverlayDialog(title string, boxContent string, boxWidth int
 
 // renderOverlayDialogWithBackground renders a dialog box overlaid on a custom background
 func (m Model) renderOverlayDialogWithBackground(title string, boxContent string, boxWidth int, borderColor string, background string) string {
+	if m.accessible {
+		// Just the dialog - the screen reader shouldn't read the list around it
+		return title + "\n" + boxContent
+	}
 	bgLines := strings.Split(background, "\n")
 
 	// Ensure background has exactly m.height lines
@@ -177,6 +181,7 @@ type sessionStatusCounts struct {
 	waiting int
 	idle    int
 	stopped int
+	exited  int // Running, but the main agent exited
 }
 
 // countSessionStatuses counts sessions by their activity state
@@ -185,10 +190,12 @@ func (m *Model) countSessionStatuses() sessionStatusCounts {
 	for _, inst := range m.instances {
 		switch inst.Status {
 		case session.StatusRunning:
-			switch m.activityState[inst.ID] {
-			case session.ActivityBusy:
+			switch {
+			case m.agentExited(inst):
+				counts.exited++
+			case m.activityState[inst.ID] == session.ActivityBusy:
 				counts.active++
-			case session.ActivityWaiting:
+			case m.activityState[inst.ID] == session.ActivityWaiting:
 				counts.waiting++
 			default:
 				counts.idle++
@@ -214,6 +221,9 @@ func (m *Model) buildSessionListHeader(listWidth int) string {
 			waitingStyle.Render("●"), counts.waiting,
 			idleStyle.Render("●"), counts.idle,
 			stoppedStyle.Render("○"), counts.stopped)
+		if counts.exited > 0 {
+			countsStr += fmt.Sprintf(" %s %d", stoppedStyle.Render("⊗"), counts.exited)
+		}
 		header += dimStyle.Render(countsStr)
 	}
 	sb.WriteString(header)
@@ -226,11 +236,18 @@ func (m *Model) buildSessionListHeader(listWidth int) string {
 
 // buildProjectNameRow builds the project name display row
 func (m *Model) buildProjectNameRow(listWidth int) string {
-	if m.activeProject == nil {
+	if m.activeProject == nil && !m.globalList {
 		return ""
 	}
 
 	var sb strings.Builder
+	if m.globalList {
+		sb.WriteString(projectLabelStyle.Render(" Project: ") + projectNameStyle.Render("all projects"))
+		sb.WriteString("\n")
+		sb.WriteString(dimStyle.Render(strings.Repeat("─", listWidth)))
+		sb.WriteString("\n")
+		return sb.String()
+	}
 	projectName := m.activeProject.Name
 	maxLen := listWidth - 12
 	if len(projectName) > maxLen {
@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/alecthomas/chroma/v2"
+	"github.com/alecthomas/chroma/v2/formatters"
+	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/muesli/termenv"
+)
+
+// CodeStyle is the chroma style used for code blocks in conversation previews
+const CodeStyle = "monokai"
+
+// maxHighlightCache bounds the number of highlighted code blocks kept in memory
+const maxHighlightCache = 200
+
+// highlightCache avoids re-running chroma on every render (key: lang + "\x00" + code)
+var highlightCache = make(map[string][]string)
+
+// contentSegment is a run of plain text or a fenced code block
+type contentSegment struct {
+	text string
+	code bool
+	lang string
+}
+
+// splitCodeBlocks splits message content into plain text and fenced code blocks
+func splitCodeBlocks(content string) []contentSegment {
+	var segments []contentSegment
+	var buf []string
+	inCode := false
+	lang := ""
+	fence := ""
+
+	flush := func(code bool) {
+		if len(buf) > 0 || code {
+			text := strings.Join(buf, "\n")
+			if code {
+				text = strings.TrimRight(text, "\n")
+			}
+			segments = append(segments, contentSegment{text: text, code: code, lang: lang})
+		}
+		buf = nil
+	}
+
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !inCode && (strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~")) {
+			flush(false)
+			fence = trimmed[:3]
+			lang = strings.TrimSpace(strings.TrimLeft(trimmed, fence[:1]))
+			if idx := strings.IndexAny(lang, " {"); idx != -1 {
+				lang = lang[:idx]
+			}
+			inCode = true
+			continue
+		}
+		if inCode && strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]) == "" {
+			flush(true)
+			inCode = false
+			lang = ""
+			continue
+		}
+		buf = append(buf, line)
+	}
+	// Unterminated block (e.g. truncated message) still counts as code
+	flush(inCode)
+	return segments
+}
+
+// highlightCode renders code with chroma, one terminal line per source line
+func highlightCode(code, lang string) []string {
+	key := lang + "\x00" + code
+	if cached, ok := highlightCache[key]; ok {
+		return cached
+	}
+
+	code = strings.ReplaceAll(code, "\t", "    ")
+	lexer := lexers.Get(lang)
+	if lexer == nil {
+		lexer = lexers.Analyse(code)
+	}
+	if lexer == nil {
+		lexer = lexers.Fallback
+	}
+	lexer = chroma.Coalesce(lexer)
+
+	lines := strings.Split(code, "\n")
+	formatter := formatters.TTY256
+	switch colorProfile {
+	case termenv.ANSI:
+		formatter = formatters.TTY16
+	case termenv.Ascii:
+		formatter = nil // Plain code without colors
+	}
+	var b strings.Builder
+	iterator, err := lexer.Tokenise(nil, code)
+	if err == nil && formatter != nil && formatter.Format(&b, styles.Get(CodeStyle), iterator) == nil {
+		lines = strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+	}
+
+	if len(highlightCache) >= maxHighlightCache {
+		highlightCache = make(map[string][]string)
+	}
+	highlightCache[key] = lines
+	return lines
+}
+
+// codeBlockLabel returns the language label shown above a code block
+func codeBlockLabel(lang, code string) string {
+	if lang != "" {
+		return lang
+	}
+	if lexer := lexers.Analyse(code); lexer != nil {
+		return strings.ToLower(lexer.Config().Name)
+	}
+	return "code"
+}
+
+// renderCodeBlock renders a fenced code block with a language label, truncated to width
+func renderCodeBlock(seg contentSegment, width int) []string {
+	borderStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorDarkGray))
+	labelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorAccent)).Bold(true)
+
+	lines := []string{borderStyle.Render("┌─ ") + labelStyle.Render(codeBlockLabel(seg.lang, seg.text))}
+	codeWidth := width - 2
+	if codeWidth < 10 {
+		codeWidth = 10
+	}
+	for _, line := range highlightCode(seg.text, seg.lang) {
+		lines = append(lines, borderStyle.Render("│ ")+ansi.Truncate(line, codeWidth, "…")+"\x1b[0m")
+	}
+	lines = append(lines, borderStyle.Render("└─"))
+	return lines
+}
@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+	"github.com/izll/agent-session-manager/session/notify"
+)
+
+// idleStopMinutes returns idle_stop_minutes of config.toml (0 = off)
+func (m Model) idleStopMinutes() int {
+	if m.config == nil {
+		return 0
+	}
+	return m.config.IdleStopMinutes
+}
+
+// trackIdle records when a running session became idle (and forgets it once it isn't)
+func (m *Model) trackIdle(inst *session.Instance) {
+	if inst.Status != session.StatusRunning || m.activityState[inst.ID] != session.ActivityIdle {
+		delete(m.idleSince, inst.ID)
+		return
+	}
+	if _, ok := m.idleSince[inst.ID]; !ok {
+		m.idleSince[inst.ID] = time.Now()
+	}
+}
+
+// stopIdleTimedOut stops sessions that have been idle longer than their idle timeout
+func (m *Model) stopIdleTimedOut() tea.Cmd {
+	if m.state != stateList {
+		return nil
+	}
+	var cmds []tea.Cmd
+	for _, inst := range m.instances {
+		since, ok := m.idleSince[inst.ID]
+		after := inst.IdleStopAfter(m.idleStopMinutes())
+		if !ok || after == 0 || time.Since(since) < after || m.isBusy(inst) {
+			continue
+		}
+		delete(m.idleSince, inst.ID)
+		if inst.HasClients() {
+			continue // Someone is watching it from another terminal - count again from now
+		}
+		if m.dryRun {
+			m.notifyWarn("Dry-run: idle auto-stop of '%s' skipped", inst.Name)
+			continue
+		}
+		// On-stop summary: grab the conversation while the session still exists
+		if cfg := session.LoadSummaryConfig(); cfg.OnStop {
+			cmds = append(cmds, m.summarizeSession(inst, inst.SummaryInput()))
+		}
+		if err := inst.StopIdle(); err != nil {
+			m.notify(toastError, "Failed to stop idle '%s': %v", inst.Name, err)
+			continue
+		}
+		m.storage.UpdateInstance(inst)
+		m.storage.PushClosed(inst, false)
+		idle := formatQuiet(after)
+		m.notifyInfo("Stopped '%s' after %s idle - Enter resumes it", inst.Name, idle)
+		m.routeNotification(inst, notify.IdleStop, "Stopped after "+idle+" idle")
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleCycleIdleStop switches the selected session to the next idle timeout
+func (m *Model) handleCycleIdleStop() {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return
+	}
+	inst.IdleStopMinutes = inst.NextIdleStop()
+	m.storage.UpdateInstance(inst)
+	after := inst.IdleStopAfter(m.idleStopMinutes())
+	switch {
+	case after == 0:
+		m.notifyInfo("'%s' is not stopped when idle", inst.Name)
+	case inst.IdleStopMinutes == 0:
+		m.notifyInfo("'%s' stops after %s idle (idle_stop_minutes)", inst.Name, formatQuiet(after))
+	default:
+		m.notifyInfo("'%s' stops after %s idle", inst.Name, formatQuiet(after))
+	}
+}
+
+// idleStopSummary returns the preview line about the idle auto-stop ("" = off and never used)
+func (m Model) idleStopSummary(inst *session.Instance) string {
+	if inst.IdleStopped && inst.Status != session.StatusRunning {
+		return "stopped while idle · Enter resumes"
+	}
+	after := inst.IdleStopAfter(m.idleStopMinutes())
+	if after == 0 {
+		return ""
+	}
+	text := fmt.Sprintf("after %s idle", formatQuiet(after))
+	if since, ok := m.idleSince[inst.ID]; ok && inst.Status == session.StatusRunning {
+		left := (after - time.Since(since)).Round(time.Minute)
+		if left < time.Minute {
+			left = time.Minute
+		}
+		text += fmt.Sprintf(" · idle, stops in %s", formatQuiet(left))
+	}
+	return text
+}
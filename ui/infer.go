@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// offerProjectInference opens the filing dialog when a new session's path lies in
+// another project's directories, or in a group's directories of this project
+func (m *Model) offerProjectInference(inst *session.Instance) bool {
+	match, err := m.storage.InferProject(inst.Path)
+	if err != nil || match == nil {
+		return false
+	}
+	if match.ProjectID == m.storage.GetActiveProjectID() {
+		// Same project - only worth asking about a group the user did not pick
+		if match.GroupID == "" || m.pendingGroupID != "" || m.globalList {
+			return false
+		}
+	}
+	m.inferMatch = match
+	m.pendingInstance = inst
+	m.state = stateInferProject
+	return true
+}
+
+// handleInferProjectKeys handles keyboard input in the project filing dialog
+func (m Model) handleInferProjectKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	inst := m.pendingInstance
+	switch m.keys.answer(msg) {
+	case answerYes, "enter":
+		m.fileUnder = m.inferMatch
+		inst.GroupID = m.inferMatch.GroupID
+	case answerNo:
+		m.fileUnder = nil
+	case "esc":
+		m.inferMatch = nil
+		m.pendingInstance = nil
+		m.state = stateList
+		return m, nil
+	default:
+		return m, nil
+	}
+	m.inferMatch = nil
+	m.pendingInstance = nil
+	return m.continueNewSession(inst)
+}
+
+// continueNewSession offers existing agent conversations to resume, or adds and starts the new session
+func (m Model) continueNewSession(inst *session.Instance) (tea.Model, tea.Cmd) {
+	// Check for existing agent sessions (for agents that support resume)
+	agentConfig := session.AgentConfigs[m.pendingAgent]
+	if agentConfig.SupportsResume {
+		sessions, err := session.ListSessionsForAgent(m.pendingAgent, inst.Path)
+		if err != nil {
+			// Non-fatal: just continue without session selection
+			sessions = nil
+		}
+		if len(sessions) > 0 {
+			m.pendingInstance = inst
+			m.agentSessions = sessions
+			m.resumeAgentType = m.pendingAgent
+			m.resumeFork = false
+			m.sessionCursor = 1 // Start with first session selected (0 is "new session")
+			m.state = stateSelectAgentSession
+			return m, nil
+		}
+	}
+
+	// No existing sessions or agent doesn't support resume, just create new
+	if err := m.addNewInstance(inst); err != nil {
+		m.err = err
+		m.previousState = stateList
+		m.state = stateError
+		return m, nil
+	}
+	m.state = stateList
+
+	// Auto-start the new instance in the background
+	return m, m.startSessionAsync(inst, "", false)
+}
+
+// addNewInstance saves a new session to the project chosen in the filing dialog
+// (the active project by default) and selects it when it is in the shown list
+func (m *Model) addNewInstance(inst *session.Instance) error {
+	target := m.fileUnder
+	m.fileUnder = nil
+	m.autoColorInstance(inst)
+
+	activeID := m.storage.GetActiveProjectID()
+	if target == nil || target.ProjectID == activeID {
+		if err := m.storage.AddInstance(inst); err != nil {
+			return err
+		}
+	} else {
+		if err := m.storage.AddInstanceTo(target.ProjectID, inst); err != nil {
+			return err
+		}
+		if !m.globalList {
+			m.notifySuccess("Filed '%s' under project '%s'", inst.Name, target.ProjectName)
+			return nil
+		}
+	}
+	m.instances = append(m.instances, inst)
+	m.selectInstance(inst)
+	return nil
+}
+
+// inferProjectView renders the project filing dialog as an overlay
+func (m Model) inferProjectView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 64
+	match := m.inferMatch
+	if match != nil && m.pendingInstance != nil {
+		boxContent.WriteString(fmt.Sprintf("  '%s' is inside\n", truncateRunes(m.pendingInstance.Name, 40)))
+		boxContent.WriteString("  " + dimStyle.Render(truncateRunes(match.Dir, boxWidth-8)) + "\n\n")
+
+		var where string
+		switch {
+		case match.ProjectID == m.storage.GetActiveProjectID():
+			where = fmt.Sprintf("group '%s'", match.GroupName)
+		case match.GroupName != "":
+			where = fmt.Sprintf("project '%s', group '%s'", match.ProjectName, match.GroupName)
+		default:
+			where = fmt.Sprintf("project '%s'", match.ProjectName)
+		}
+		boxContent.WriteString("  which belongs to " + projectNameStyle.Render(truncateRunes(where, 44)) + "\n\n")
+		boxContent.WriteString("  y  File it there\n")
+		boxContent.WriteString("  n  Keep it here\n\n")
+	}
+	boxContent.WriteString(helpStyle.Render("  y/enter: file there  n: keep here  esc: cancel"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" File Session ", boxContent.String(), boxWidth, ColorAccent)
+}
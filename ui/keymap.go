@@ -0,0 +1,313 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// keyAction is an action whose keys can be remapped in config.toml
+type keyAction struct {
+	Name string   // Name used in the [keys] table and by the handlers
+	Keys []string // Default keys
+	Desc string
+}
+
+// keyActions are the list view actions. Actions on reserved keys (arrows,
+// enter, esc, tab, ctrl+c, 1-9) keep their keys; the others are remappable.
+var keyActions = []keyAction{
+	{"up", []string{"up"}, "Move up"},
+	{"down", []string{"down"}, "Move down"},
+	{"expand", []string{"right"}, "Expand group / nested sessions"},
+	{"collapse", []string{"left"}, "Collapse group / nested sessions"},
+	{"open", []string{"enter"}, "Attach / toggle group"},
+	{"switch_focus", []string{"tab"}, "Switch split focus / toggle group"},
+	{"back", []string{"esc"}, "Clear selection / search"},
+	{"exit", []string{"ctrl+c"}, "Quit"},
+	{"reply", []string{"1", "2", "3", "4", "5", "6", "7", "8", "9"}, "Answer waiting prompt"},
+	{"move_up", []string{"ctrl+up"}, "Move session up"},
+	{"move_down", []string{"ctrl+down"}, "Move session down"},
+	{"prev_window", []string{"[", "ctrl+left", "alt+left"}, "Previous tab"},
+	{"next_window", []string{"]", "ctrl+right", "alt+right"}, "Next tab"},
+	{"scroll_up", []string{"alt+up"}, "Scroll preview/diff up"},
+	{"scroll_down", []string{"alt+down"}, "Scroll preview/diff down"},
+	{"page_up", []string{"pgup", "alt+pgup"}, "Preview/diff half page up"},
+	{"page_down", []string{"pgdown", "alt+pgdown"}, "Preview/diff half page down"},
+	{"scroll_top", []string{"home"}, "Preview/diff top"},
+	{"scroll_bottom", []string{"end"}, "Preview/diff bottom"},
+	{"new_session", []string{"n"}, "New session"},
+	{"rename", []string{"e"}, "Rename session"},
+	{"start", []string{"s"}, "Start (background)"},
+	{"replace_start", []string{"a"}, "Replace/parallel start"},
+	{"stop", []string{"x"}, "Stop"},
+	{"delete", []string{"d"}, "Delete"},
+	{"resume", []string{"r"}, "Resume conversation"},
+	{"prompt", []string{"p"}, "Send prompt"},
+	{"reopen", []string{"u"}, "Reopen last closed"},
+	{"edit_command", []string{"C"}, "Edit custom command / launch flags"},
+	{"model", []string{"M"}, "Change model"},
+	{"timezone", []string{"Z"}, "Set timezone"},
+	{"resources", []string{"%"}, "Resource usage"},
+	{"churn", []string{"="}, "Diff stats across sessions"},
+	{"usage", []string{"$"}, "Token usage & cost"},
+	{"presets", []string{"+"}, "Presets gallery"},
+	{"attach_readonly", []string{"Y"}, "Attach read-only"},
+	{"processes", []string{"K"}, "Processes"},
+	{"stuck", []string{"!"}, "Stuck session actions"},
+	{"permissions", []string{"y"}, "Permission prompts & allow rules"},
+	{"alerts", []string{"B"}, "Sound alerts"},
+	{"scrollback", []string{"#"}, "Scrollback usage & limit"},
+	{"preview_filter", []string{"~"}, "Cycle preview filter"},
+	{"fork", []string{"f"}, "Fork session"},
+	{"fork_tab", []string{"&"}, "Fork active tab"},
+	{"summarize", []string{"S"}, "Summarize into notes"},
+	{"handover", []string{"O"}, "Write handover document"},
+	{"notes", []string{"N"}, "Edit notes"},
+	{"new_tab", []string{"t"}, "New tab"},
+	{"rename_tab", []string{"T"}, "Rename tab"},
+	{"close_tab", []string{"W"}, "Quick close tab"},
+	{"windows", []string{"w"}, "Window picker"},
+	{"split_right", []string{"|"}, "Split window: terminal beside"},
+	{"split_below", []string{"_"}, "Split window: terminal below"},
+	{"import_tmux", []string{"i"}, "Import tmux sessions"},
+	{"about", []string{"A"}, "About this session"},
+	{"branch", []string{"b"}, "Switch git branch"},
+	{"transfer", []string{"X"}, "Transfer file/patch to session"},
+	{"select", []string{" "}, "Select for batch action"},
+	{"select_range", []string{"V"}, "Select range"},
+	{"new_group", []string{"g"}, "Create group"},
+	{"assign_group", []string{"G"}, "Assign to group"},
+	{"favorite", []string{"*"}, "Toggle favorite"},
+	{"protect", []string{"L"}, "Toggle delete protection"},
+	{"colors", []string{"c"}, "Colors & gradients"},
+	{"compact", []string{"l"}, "Compact mode"},
+	{"status_lines", []string{"o"}, "Toggle status lines"},
+	{"icons", []string{"I"}, "Toggle icons"},
+	{"split", []string{"v"}, "Toggle split"},
+	{"mark", []string{"m"}, "Mark/pin session"},
+	{"diff", []string{"D"}, "Toggle preview/diff"},
+	{"full_diff", []string{"F"}, "Switch session/full diff"},
+	{"next_file", []string{"}"}, "Next file in diff"},
+	{"prev_file", []string{"{"}, "Previous file in diff"},
+	{"fold_file", []string{"z"}, "Fold/unfold diff file"},
+	{"stage", []string{"P"}, "Stage hunks & commit"},
+	{"search", []string{"/"}, "Search sessions"},
+	{"export", []string{"E"}, "Export status report"},
+	{"console", []string{":"}, "tmux console"},
+	{"resize", []string{"R"}, "Force resize preview"},
+	{"update", []string{"U"}, "Check for updates"},
+	{"history", []string{"H"}, "Message history"},
+	{"help", []string{"?", "f1"}, "Help"},
+	{"leader", []string{","}, "Leader key of two-key chords"},
+	{"quit", []string{"q"}, "Back to project selector"},
+	{"global_search", []string{"ctrl+f"}, "Search all conversations"},
+	{"yolo", []string{"ctrl+y"}, "Toggle YOLO mode"},
+	{"recording", []string{"ctrl+r"}, "Start/stop recording"},
+	{"output_log", []string{"ctrl+l"}, "Start/stop output log"},
+	{"restart_policy", []string{"ctrl+k"}, "Cycle restart policy"},
+	{"idle_stop", []string{"ctrl+t"}, "Cycle idle auto-stop"},
+	{"export_conversation", []string{"ctrl+e"}, "Export conversation"},
+	{"dry_run", []string{"ctrl+d"}, "Toggle dry-run"},
+}
+
+// dialogActions are the answer keys of the yes/no dialogs (esc cancels too)
+var dialogActions = []keyAction{
+	{"confirm", []string{"y", "Y"}, "Confirm (y/n dialogs)"},
+	{"cancel", []string{"n", "N"}, "Cancel (y/n dialogs)"},
+}
+
+// reservedKeys are keys that can't be bound to another action; the actions
+// on them can't be remapped either
+var reservedKeys = map[string]bool{
+	"up": true, "down": true, "left": true, "right": true, "enter": true, "esc": true,
+	"tab": true, "ctrl+c": true, "1": true, "2": true, "3": true, "4": true, "5": true,
+	"6": true, "7": true, "8": true, "9": true,
+}
+
+// keymap holds the key bindings of the list view and the yes/no dialogs
+type keymap struct {
+	list    []key.Binding   // Bindings of keyActions, in the same order
+	custom  map[string]bool // Actions rebound in config.toml
+	Confirm key.Binding
+	Cancel  key.Binding
+}
+
+// defaultKeymap returns the built-in key bindings
+func defaultKeymap() keymap {
+	k, _ := buildKeymap(nil)
+	return k
+}
+
+// newKeymap builds the keymap from the [keys] table of config.toml. Invalid
+// bindings are reported and the built-in keymap is returned instead.
+func newKeymap(bindings map[string]session.KeyList) (keymap, error) {
+	k, err := buildKeymap(bindings)
+	if err != nil {
+		return defaultKeymap(), err
+	}
+	return k, nil
+}
+
+// buildKeymap applies the [keys] table on top of the default keys
+func buildKeymap(bindings map[string]session.KeyList) (keymap, error) {
+	byName := make(map[string]keyAction)
+	for _, a := range keyActions {
+		byName[a.Name] = a
+	}
+	for _, a := range dialogActions {
+		byName[a.Name] = a
+	}
+
+	names := make([]string, 0, len(bindings))
+	for name := range bindings {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	keysOf := make(map[string][]string)  // Action -> keys from config.toml
+	listBound := make(map[string]string) // List key -> action bound to it
+	for _, name := range names {
+		a, ok := byName[name]
+		if !ok {
+			return keymap{}, fmt.Errorf("unknown action '%s' in [keys]", name)
+		}
+		if isFixedAction(a) {
+			return keymap{}, fmt.Errorf("action '%s' can't be remapped", name)
+		}
+		if len(bindings[name]) == 0 {
+			return keymap{}, fmt.Errorf("no key for '%s' in [keys]", name)
+		}
+		for _, raw := range bindings[name] {
+			bound := strings.TrimSpace(raw)
+			if raw == " " || bound == "space" {
+				bound = " "
+			} else if bound == "" {
+				return keymap{}, fmt.Errorf("empty key for '%s' in [keys]", name)
+			}
+			if reservedKeys[bound] {
+				return keymap{}, fmt.Errorf("key '%s' of '%s' is reserved", bound, name)
+			}
+			keysOf[name] = append(keysOf[name], bound)
+			if isDialogAction(name) {
+				continue
+			}
+			if other, ok := listBound[bound]; ok && other != name {
+				return keymap{}, fmt.Errorf("key '%s' is bound to both '%s' and '%s'", bound, other, name)
+			}
+			listBound[bound] = name
+		}
+	}
+
+	// A key taken from an action that keeps its default keys would shadow it
+	for _, a := range keyActions {
+		if _, moved := keysOf[a.Name]; moved {
+			continue
+		}
+		for _, def := range a.Keys {
+			if name, ok := listBound[def]; ok {
+				return keymap{}, fmt.Errorf("key '%s' of '%s' is already used by '%s'", def, name, a.Name)
+			}
+		}
+	}
+
+	k := keymap{custom: make(map[string]bool)}
+	binding := func(a keyAction) key.Binding {
+		keys, ok := keysOf[a.Name]
+		if ok {
+			k.custom[a.Name] = true
+		} else {
+			keys = a.Keys
+		}
+		labels := make([]string, len(keys))
+		for i, k := range keys {
+			labels[i] = keyLabel(k)
+		}
+		return key.NewBinding(key.WithKeys(keys...), key.WithHelp(strings.Join(labels, "/"), a.Desc))
+	}
+	for _, a := range keyActions {
+		k.list = append(k.list, binding(a))
+	}
+	k.Confirm = binding(dialogActions[0])
+	k.Cancel = binding(dialogActions[1])
+	for _, c := range k.Confirm.Keys() {
+		if key.Matches(runeKey(c), k.Cancel) {
+			return keymap{}, fmt.Errorf("key '%s' is bound to both 'confirm' and 'cancel'", c)
+		}
+	}
+	return k, nil
+}
+
+// keyLabel returns how a key is shown in hints
+func keyLabel(k string) string {
+	if k == " " {
+		return "space"
+	}
+	return k
+}
+
+// isFixedAction reports whether an action is on reserved keys, which keep their action
+func isFixedAction(a keyAction) bool {
+	for _, k := range a.Keys {
+		if !reservedKeys[k] {
+			return false
+		}
+	}
+	return true
+}
+
+// isDialogAction reports whether an action belongs to the yes/no dialogs
+func isDialogAction(name string) bool {
+	for _, a := range dialogActions {
+		if a.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// action returns the list view action bound to a key ("" = none)
+func (k keymap) action(msg tea.KeyMsg) string {
+	for i, b := range k.list {
+		if key.Matches(msg, b) {
+			return keyActions[i].Name
+		}
+	}
+	return ""
+}
+
+// Answers of the yes/no dialogs, see keymap.answer
+const (
+	answerYes = "answer:yes"
+	answerNo  = "answer:no"
+)
+
+// answer routes a yes/no dialog key through the keymap: the confirm and cancel
+// keys become answerYes and answerNo, other keys pass through unchanged
+func (k keymap) answer(msg tea.KeyMsg) string {
+	switch {
+	case key.Matches(msg, k.Confirm):
+		return answerYes
+	case key.Matches(msg, k.Cancel):
+		return answerNo
+	}
+	return msg.String()
+}
+
+// binding returns the binding of a list view action
+func (k keymap) binding(name string) key.Binding {
+	for i, a := range keyActions {
+		if a.Name == name && i < len(k.list) {
+			return k.list[i]
+		}
+	}
+	return key.NewBinding(key.WithDisabled())
+}
+
+// help returns the keys of a list view action as shown in hints
+func (k keymap) help(name string) string {
+	return k.binding(name).Help().Key
+}
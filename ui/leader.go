@@ -0,0 +1,253 @@
+package ui
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// LeaderTimeout is how long the leader key waits for the second key
+const LeaderTimeout = 2 * time.Second
+
+// leaderChords lists the second keys available after the leader key. The
+// leader has no action of its own, so the keys of other actions never wait.
+var leaderChords = map[string]string{
+	"g": "go to top",
+	"p": "project selector",
+	"a": "all projects list",
+	"r": "rg across session repos",
+	"n": "nest forks under origin",
+	"f": "file browser",
+	"d": "weekly digest",
+	"S": "start all in group",
+	"e": "environment variables",
+	"m": "MCP servers",
+	"s": "start sandboxed",
+	"w": "services to wait for",
+}
+
+// leaderTimeoutMsg is sent when the leader key was not followed by a second key in time
+type leaderTimeoutMsg struct {
+	seq int
+}
+
+// startLeader waits for the second key of a chord after the leader key
+func (m *Model) startLeader() tea.Cmd {
+	m.leaderPending = true
+	m.leaderSeq++
+	seq := m.leaderSeq
+	return tea.Tick(LeaderTimeout, func(time.Time) tea.Msg {
+		return leaderTimeoutMsg{seq: seq}
+	})
+}
+
+// handleLeaderTimeout drops the pending leader when no second key came
+func (m Model) handleLeaderTimeout(msg leaderTimeoutMsg) (tea.Model, tea.Cmd) {
+	if msg.seq == m.leaderSeq {
+		m.leaderPending = false
+	}
+	return m, nil
+}
+
+// handleLeaderFollowup handles the key pressed after the leader key; keys
+// that don't form a chord (esc among them) only cancel the leader
+func (m Model) handleLeaderFollowup(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	m.leaderPending = false
+
+	switch msg.String() {
+	case "g":
+		m.gotoTop()
+	case "p":
+		m.leaveProject()
+	case "a":
+		m.toggleGlobalList()
+	case "r":
+		return m, m.handleShowCodeSearch()
+	case "n":
+		m.toggleNestForks()
+	case "f":
+		m.openFiles()
+	case "d":
+		return m, m.openDigest()
+	case "S":
+		return m, m.startAllInGroup()
+	case "e":
+		m.openEnvVars()
+	case "m":
+		m.openMCP()
+	case "s":
+		m.openSandboxPicker()
+	case "w":
+		return m, m.openReadiness()
+	}
+	return m, nil
+}
+
+// runeKey builds a key message for a single-character key
+func runeKey(key string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}
+}
+
+// leaderHint renders the available second keys for the pending leader
+func (m Model) leaderHint() string {
+	keys := make([]string, 0, len(leaderChords))
+	for k := range leaderChords {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+	for _, k := range keys {
+		parts = append(parts, m.keys.help("leader")+" "+k+": "+leaderChords[k])
+	}
+	return strings.Join(parts, "  ")
+}
+
+// gotoTop moves the cursor to the first item in the list
+func (m *Model) gotoTop() {
+	m.cursor = 0
+	m.resetScroll()
+	m.resizeSelectedPane()
+}
+
+// leaveProject saves state and returns to the project selector
+func (m *Model) leaveProject() {
+	m.saveSettings()
+	currentProjectID := ""
+	if m.activeProject != nil {
+		currentProjectID = m.activeProject.ID
+	}
+	m.storage.UnlockProject()
+	// Reload projects to refresh session counts
+	projectsData, _ := m.storage.LoadProjects()
+	m.projects = projectsData.Projects
+	// Find and select the project we just left
+	// List structure: projects (0..n-1), "No project" (n), "New Project" (n+1)
+	if currentProjectID == "" {
+		// Default/no project
+		m.projectCursor = len(m.projects)
+	} else {
+		// Find project index
+		m.projectCursor = len(m.projects) // fallback to "No project"
+		for i, p := range m.projects {
+			if p.ID == currentProjectID {
+				m.projectCursor = i
+				break
+			}
+		}
+	}
+	m.state = stateProjectSelect
+}
+
+// startAllInGroup starts every stopped session in the group under the cursor
+func (m *Model) startAllInGroup() tea.Cmd {
+	if len(m.groups) == 0 {
+		m.notifyWarn("No groups - create one with g")
+		return nil
+	}
+
+	// Group of the selected header or session
+	var group *session.Group
+	m.buildVisibleItems()
+	if m.cursor >= 0 && m.cursor < len(m.visibleItems) {
+		item := m.visibleItems[m.cursor]
+		if item.isGroup {
+			group = item.group
+		} else if item.instance != nil {
+			for _, g := range m.groups {
+				if g.ID == item.instance.GroupID {
+					group = g
+					break
+				}
+			}
+		}
+	}
+	if group == nil {
+		m.notifyWarn("Select a group or a session in a group")
+		return nil
+	}
+	if m.dryRun {
+		m.notifyWarn("Dry-run: start sessions one by one to review their commands")
+		return nil
+	}
+
+	var starts []pendingStart
+	failed := 0
+	for _, inst := range m.instances {
+		if inst.GroupID != group.ID || inst.Status == session.StatusRunning || m.isBusy(inst) {
+			continue
+		}
+		if err := session.CheckAgentCommand(inst); err != nil || inst.PathMissing() {
+			failed++
+			continue
+		}
+		starts = append(starts, pendingStart{inst: inst})
+	}
+	if m.checkRunningLimit(starts) {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	started := len(starts)
+	for _, p := range starts {
+		cmds = append(cmds, m.runStart(p.inst, "", false))
+	}
+
+	switch {
+	case started == 0 && failed == 0:
+		m.notifyInfo("All sessions in '%s' are already running", group.Name)
+	case failed > 0:
+		m.notifyWarn("Starting %d session(s) in '%s', %d skipped (command or directory not found)", started, group.Name, failed)
+	default:
+		m.notifyInfo("Starting %d session(s) in '%s'", started, group.Name)
+	}
+	return tea.Batch(cmds...)
+}
+
+// forkActiveTab forks the conversation of the selected session's active tab
+// into a new tab next to it, without the fork dialog
+func (m *Model) forkActiveTab() tea.Cmd {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return nil
+	}
+	if inst.Status != session.StatusRunning {
+		m.notifyWarn("Session must be running to fork a tab")
+		return nil
+	}
+	if inst.IsRemote() {
+		m.notifyWarn("Fork is not available for sessions on %s", inst.Host)
+		return nil
+	}
+
+	agent, resumeID, name := inst.Agent, inst.ResumeSessionID, inst.Name
+	if idx := inst.GetCurrentWindowIndex(); idx != 0 {
+		fw := inst.GetFollowedWindow(idx)
+		if fw == nil {
+			m.notifyWarn("The active tab is not an agent tab")
+			return nil
+		}
+		agent, resumeID, name = fw.Agent, fw.ResumeSessionID, fw.Name
+	} else {
+		for _, w := range inst.GetWindowList() {
+			if w.Index == 0 && w.Name != "" {
+				name = w.Name
+			}
+		}
+	}
+	if agent != session.AgentClaude && agent != "" {
+		m.notifyWarn("Fork is only supported for Claude tabs")
+		return nil
+	}
+	if resumeID == "" {
+		m.notifyWarn("No conversation ID to fork - the tab may not have started yet")
+		return nil
+	}
+	if m.isBusy(inst) {
+		return nil
+	}
+	return tea.Batch(m.beginOperation(inst, "Forking"), forkTabCmd(inst, resumeID, name+"-fork"))
+}
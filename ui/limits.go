@@ -0,0 +1,232 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// limitCandidate is an idle running session offered for stopping in the limit dialog
+type limitCandidate struct {
+	inst       *session.Instance
+	lastActive time.Time
+}
+
+// limits returns the session limits from config.toml
+func (m Model) limits() session.Limits {
+	if m.config == nil {
+		return session.Limits{}
+	}
+	return m.config.Limits
+}
+
+// checkRunningLimit holds starts back behind the limit dialog when they would
+// take the running sessions over max_running
+func (m *Model) checkRunningLimit(starts []pendingStart) bool {
+	limit := m.limits().MaxRunning
+	if limit == 0 || len(starts) == 0 {
+		return false
+	}
+	live := session.LiveSessions()
+	running := session.RunningSessionCount(live)
+	if running+len(starts) <= limit {
+		return false
+	}
+	m.limitPending = starts
+	m.limitNewSession = false
+	m.openLimitWarning(fmt.Sprintf("%d running, starting %d more goes over the limit of %d", running, len(starts), limit), live)
+	return true
+}
+
+// checkProjectLimit holds the new session flow back when the project is at max_per_project
+func (m *Model) checkProjectLimit() bool {
+	limit := m.limits().MaxPerProject
+	if limit == 0 || m.globalList || len(m.instances) < limit {
+		return false
+	}
+	m.limitPending = nil
+	m.limitNewSession = true
+	m.openLimitWarning(fmt.Sprintf("This project has %d sessions - the limit is %d", len(m.instances), limit), session.LiveSessions())
+	return true
+}
+
+// openLimitWarning opens the limit dialog with the idle running sessions, longest idle first
+func (m *Model) openLimitWarning(message string, live map[string]time.Time) {
+	m.limitMessage = message
+	m.limitIdle = nil
+	for _, inst := range m.instances {
+		if inst.Status != session.StatusRunning || m.isBusy(inst) {
+			continue
+		}
+		if activity := m.activityState[inst.ID]; activity == session.ActivityBusy || activity == session.ActivityWaiting {
+			continue
+		}
+		m.limitIdle = append(m.limitIdle, limitCandidate{inst: inst, lastActive: live[inst.TmuxSessionName()]})
+	}
+	sort.SliceStable(m.limitIdle, func(a, b int) bool {
+		return m.limitIdle[a].lastActive.Before(m.limitIdle[b].lastActive)
+	})
+	m.limitChecked = make(map[string]bool)
+	m.limitCursor = 0
+	m.state = stateLimitWarning
+}
+
+// stopIdleSessions stops the checked idle sessions, or the one under the cursor
+func (m *Model) stopIdleSessions() tea.Cmd {
+	var targets []*session.Instance
+	for _, c := range m.limitIdle {
+		if m.limitChecked[c.inst.ID] {
+			targets = append(targets, c.inst)
+		}
+	}
+	if len(targets) == 0 && m.limitCursor < len(m.limitIdle) {
+		targets = append(targets, m.limitIdle[m.limitCursor].inst)
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	summarize := session.LoadSummaryConfig().OnStop
+	stopped := make(map[string]bool)
+	for _, inst := range targets {
+		if summarize {
+			cmds = append(cmds, m.summarizeSession(inst, inst.SummaryInput()))
+		}
+		if err := inst.Stop(); err != nil {
+			continue
+		}
+		m.storage.UpdateInstance(inst)
+		m.storage.PushClosed(inst, false)
+		stopped[inst.ID] = true
+	}
+
+	kept := m.limitIdle[:0]
+	for _, c := range m.limitIdle {
+		if !stopped[c.inst.ID] {
+			kept = append(kept, c)
+		}
+	}
+	m.limitIdle = kept
+	m.limitChecked = make(map[string]bool)
+	if m.limitCursor >= len(m.limitIdle) && m.limitCursor > 0 {
+		m.limitCursor = len(m.limitIdle) - 1
+	}
+	m.notifySuccess("Stopped %d idle session(s)", len(stopped))
+	return tea.Batch(cmds...)
+}
+
+// continueOverLimit runs what the limit dialog held back
+func (m *Model) continueOverLimit() tea.Cmd {
+	m.state = stateList
+	if m.limitNewSession {
+		m.limitNewSession = false
+		m.openNewSession()
+		return nil
+	}
+	pending := m.limitPending
+	m.limitPending = nil
+	if len(pending) == 1 {
+		return m.startPending(pending[0])
+	}
+	var cmds []tea.Cmd
+	for _, p := range pending {
+		cmds = append(cmds, m.runStart(p.inst, p.resumeID, p.attach))
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleLimitKeys handles keyboard input in the limit warning dialog
+func (m Model) handleLimitKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.limitCursor > 0 {
+			m.limitCursor--
+		}
+	case "down", "j":
+		if m.limitCursor < len(m.limitIdle)-1 {
+			m.limitCursor++
+		}
+	case " ":
+		if m.limitCursor < len(m.limitIdle) {
+			id := m.limitIdle[m.limitCursor].inst.ID
+			m.limitChecked[id] = !m.limitChecked[id]
+			if m.limitCursor < len(m.limitIdle)-1 {
+				m.limitCursor++
+			}
+		}
+	case "x":
+		return m, m.stopIdleSessions()
+	case "enter":
+		return m, m.continueOverLimit()
+	case "esc":
+		m.limitPending = nil
+		m.limitNewSession = false
+		m.state = stateList
+	}
+	return m, nil
+}
+
+// limitView renders the limit warning dialog as an overlay
+func (m Model) limitView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 64
+	if m.width > 0 && m.width-4 < boxWidth {
+		boxWidth = m.width - 4
+	}
+
+	boxContent.WriteString("  " + m.limitMessage + "\n")
+	boxContent.WriteString(dimStyle.Render("  (soft limit from config.toml [limits])"))
+	boxContent.WriteString("\n\n")
+
+	if len(m.limitIdle) == 0 {
+		boxContent.WriteString(dimStyle.Render("  No idle sessions to stop here"))
+		boxContent.WriteString("\n")
+	} else {
+		boxContent.WriteString("  Idle sessions you could stop:\n\n")
+		const maxRows = 10
+		start := 0
+		if m.limitCursor >= maxRows {
+			start = m.limitCursor - maxRows + 1
+		}
+		for i := start; i < len(m.limitIdle) && i < start+maxRows; i++ {
+			c := m.limitIdle[i]
+			check := "[ ]"
+			if m.limitChecked[c.inst.ID] {
+				check = "[✓]"
+			}
+			idle := "idle"
+			if !c.lastActive.IsZero() {
+				idle += " " + strings.TrimSuffix(formatTimeAgo(c.lastActive), " ago")
+			}
+			name := truncateRunes(c.inst.Name, boxWidth-30)
+			line := fmt.Sprintf("%s %s  %s", check, name, dimStyle.Render(idle))
+			if i == m.limitCursor {
+				boxContent.WriteString("  ❯ " + line + "\n")
+			} else {
+				boxContent.WriteString("    " + line + "\n")
+			}
+		}
+		if !m.globalList {
+			boxContent.WriteString("\n")
+			boxContent.WriteString(dimStyle.Render("  Only this project's sessions are listed"))
+			boxContent.WriteString("\n")
+		}
+	}
+
+	action := "start anyway"
+	if m.limitNewSession {
+		action = "create anyway"
+	}
+	boxContent.WriteString("\n")
+	boxContent.WriteString(helpStyle.Render(fmt.Sprintf("  space: check  x: stop  enter: %s  esc: cancel", action)))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Session Limit ", boxContent.String(), boxWidth, ColorRed)
+}
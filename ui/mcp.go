@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// mcpRows caps the servers listed in the MCP dialog
+const mcpRows = 12
+
+// claudeWindows returns the session's windows running Claude (0 = main)
+func claudeWindows(inst *session.Instance) []int {
+	var windows []int
+	if inst.WindowAgent(0) == session.AgentClaude {
+		windows = append(windows, 0)
+	}
+	for _, fw := range inst.FollowedWindows {
+		if fw.Agent == session.AgentClaude {
+			windows = append(windows, fw.Index)
+		}
+	}
+	return windows
+}
+
+// openMCP opens the MCP servers dialog of the selected session (leader m)
+func (m *Model) openMCP() {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return
+	}
+	switch {
+	case inst.IsRemote():
+		m.notifyWarn("MCP servers of sessions on %s are set on that host", inst.Host)
+		return
+	case inst.Runtime != "":
+		m.notifyWarn("MCP servers can't be switched for sessions in a container")
+		return
+	case len(claudeWindows(inst)) == 0:
+		m.notifyWarn("'%s' has no Claude window to start MCP servers in", inst.Name)
+		return
+	}
+	servers, err := inst.MCPServers()
+	if err != nil {
+		m.showError(err)
+		return
+	}
+	if len(servers) == 0 {
+		m.notifyInfo("No MCP servers in .mcp.json or ~/.claude.json for this directory")
+		return
+	}
+	m.mcpTarget = inst
+	m.mcpServers = servers
+	m.mcpDisabled = make(map[string]bool)
+	for _, s := range servers {
+		m.mcpDisabled[s.Name] = inst.MCPDisabledServer(s.Name)
+	}
+	m.mcpCursor = 0
+	m.state = stateMCP
+}
+
+// saveMCP stores the switched off servers and restarts the session's Claude
+// windows on their conversations when it is running
+func (m *Model) saveMCP() {
+	inst := m.mcpTarget
+	m.mcpTarget = nil
+	m.state = stateList
+
+	var disabled []string
+	for _, s := range m.mcpServers {
+		if m.mcpDisabled[s.Name] {
+			disabled = append(disabled, s.Name)
+		}
+	}
+	if strings.Join(disabled, "\n") == strings.Join(inst.MCPDisabled, "\n") {
+		return
+	}
+	inst.SetMCPDisabled(disabled)
+	m.storage.UpdateInstance(inst)
+
+	if inst.Status != session.StatusRunning {
+		m.notifySuccess("MCP servers of '%s' apply on next start", inst.Name)
+		return
+	}
+	restarted := 0
+	for _, window := range claudeWindows(inst) {
+		if err := inst.RestartWindow(window); err != nil {
+			m.notify(toastError, "Failed to restart '%s' with its MCP servers: %v", inst.Name, err)
+			return
+		}
+		restarted++
+	}
+	m.notifySuccess("Restarted %d Claude window(s) of '%s' with its MCP servers", restarted, inst.Name)
+}
+
+// handleMCPKeys handles keyboard input in the MCP servers dialog
+func (m Model) handleMCPKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.mcpTarget = nil
+		m.state = stateList
+	case "up", "k":
+		if m.mcpCursor > 0 {
+			m.mcpCursor--
+		}
+	case "down", "j":
+		if m.mcpCursor < len(m.mcpServers)-1 {
+			m.mcpCursor++
+		}
+	case " ", "x":
+		if m.mcpCursor < len(m.mcpServers) {
+			name := m.mcpServers[m.mcpCursor].Name
+			m.mcpDisabled[name] = !m.mcpDisabled[name]
+		}
+	case "a":
+		// All on again
+		for name := range m.mcpDisabled {
+			m.mcpDisabled[name] = false
+		}
+	case "enter":
+		m.saveMCP()
+	}
+	return m, nil
+}
+
+// mcpView renders the MCP servers dialog
+func (m Model) mcpView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 76
+	inst := m.mcpTarget
+	boxContent.WriteString(fmt.Sprintf("  MCP servers of '%s':\n\n", truncateRunes(inst.Name, 40)))
+
+	start := 0
+	if m.mcpCursor >= mcpRows {
+		start = m.mcpCursor - mcpRows + 1
+	}
+	for idx := start; idx < len(m.mcpServers) && idx < start+mcpRows; idx++ {
+		s := m.mcpServers[idx]
+		prefix := "    "
+		if idx == m.mcpCursor {
+			prefix = "  ❯ "
+		}
+		check := "[✓]"
+		if m.mcpDisabled[s.Name] {
+			check = "[ ]"
+		}
+		line := fmt.Sprintf("%s %-20s %-8s ", check, truncateRunes(s.Name, 20), s.Scope)
+		boxContent.WriteString(prefix + line + dimStyle.Render(truncateRunes(s.Target(), boxWidth-42)))
+		boxContent.WriteString("\n")
+	}
+	if hidden := len(m.mcpServers) - start - mcpRows; hidden > 0 {
+		boxContent.WriteString(dimStyle.Render(fmt.Sprintf("    … and %d more", hidden)))
+		boxContent.WriteString("\n")
+	}
+
+	boxContent.WriteString("\n")
+	boxContent.WriteString(dimStyle.Render("  Applies to this session's Claude windows only; the JSON files stay as they are"))
+	boxContent.WriteString("\n\n")
+	help := "  space: toggle  a: all on  enter: save  esc: cancel"
+	if inst.Status == session.StatusRunning {
+		help = "  space: toggle  a: all on  enter: save & restart Claude  esc: cancel"
+	}
+	boxContent.WriteString(helpStyle.Render(help))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" MCP Servers ", boxContent.String(), boxWidth, ColorAccent)
+}
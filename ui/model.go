@@ -5,12 +5,17 @@ import (
 	"os"
 	"os/exec"
 	"strings"
+	"syscall"
 	"time"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/izll/agent-session-manager/session"
+	"github.com/izll/agent-session-manager/session/events"
+	"github.com/izll/agent-session-manager/session/notify"
+	"github.com/izll/agent-session-manager/session/scripts"
 	"github.com/izll/agent-session-manager/updater"
 )
 
@@ -55,6 +60,7 @@ const (
 	GradientColorCount   = 15  // Number of gradient options (for background exclusion)
 	PromptMinWidth       = 50  // Minimum prompt input width
 	PromptMaxWidth       = 70  // Maximum prompt input width
+	PromptPreviewLines   = 6   // Lines of resolved template shown before sending
 	TickInterval         = 100 * time.Millisecond // UI refresh interval for selected
 	SlowTickInterval     = 500 * time.Millisecond // UI refresh interval for others
 	FavoritesGroupID     = "__favorites__"        // Virtual group ID for favorites
@@ -69,6 +75,7 @@ const (
 	stateList
 	stateNewName
 	stateNewPath
+	stateNewContainer        // Entering the docker container of a new session
 	stateSelectAgentSession  // Selecting agent session to resume
 	stateConfirmDelete
 	stateConfirmStop          // Confirm session stop
@@ -111,6 +118,53 @@ const (
 	stateGlobalSearchConfirmJump // Confirm jump to existing session
 	stateGlobalSearchNewName     // Entering name for new session from global search
 	stateGlobalSearchSelectMatch // Selecting from multiple matching sessions/tabs
+	stateMessages                // Message history panel
+	stateConfirmDryRun           // Dry-run command confirmation
+	stateTmuxConsole             // tmux command console for selected session
+	stateExportReport            // Export session list as Markdown
+	stateConfirmDeleteTranscript // Confirm deleting a global search result's transcript
+	stateEditCustomCmd           // Editing the command of a Custom agent session/tab
+	stateConfirmRespawnCmd       // Offer to respawn the window after a command edit
+	stateEditFlags               // Editing the flags appended to an agent's command
+	stateSetTimezone             // Setting the timezone of a session
+	stateReadiness               // Editing the readiness checks of a session
+	stateSchedulePrompt          // Picking the send time of a scheduled message
+	stateResources               // Sessions sorted by CPU/memory usage
+	stateProcesses               // Processes inside a session, with signal actions
+	stateSandboxPicker           // Choosing the sandbox wrapper for a sandboxed start
+	stateStuck                   // Nudge/restart actions for a session that looks hung
+	stateAlerts                  // Per-session sound alerts for activity events
+	stateInferProject            // Offer to file a new session under the project its path belongs to
+	stateRelink                  // Point a session whose directory moved at its new path
+	stateGradients               // Custom gradient list (from the color picker)
+	stateGradientEdit            // Creating or editing a custom gradient
+	stateModelPicker             // Choosing the model of a new session or a session's tab
+	stateDigest                  // Sessions stopped or deleted in the past week
+	stateMCP                     // Switching the MCP servers of a session
+	stateGradientImport          // Importing a well-known palette as a gradient
+	stateWindowPicker            // Windows of a session, to switch to or attach
+	stateAdopt                   // Importing tmux sessions started outside the manager
+	stateAbout                   // Environment a session was started with
+	stateBatchConfirm            // Confirming an action on all multi-selected sessions
+	stateDeleteGroup             // Choosing what happens to the sessions of a deleted group
+	stateLimitWarning            // A start or new session goes over a limit from config.toml
+	stateBranchPicker            // Checking out another git branch for a session
+	stateStage                   // Staging hunks in the diff pane
+	stateCommitMessage           // Entering a commit message for the staged changes
+	stateTransfer                // Taking a file or patch to another session
+	stateScrollback              // Scrollback usage and history-limit of a session
+	stateHandover                // Writing a handover document, optionally seeding a new session
+	stateExternalChange          // Merging changes another process made to sessions.json
+	stateChurn                   // Diff stats summed across the running sessions of a group/project
+	stateUsage                   // Token usage and estimated cost per group and day
+	statePresets                 // Gallery of session presets to install and start from
+	stateExportConversation      // Writing a session's conversation to a Markdown/HTML file
+	stateCodeSearch              // ripgrep across the directories of a group/project's sessions
+	stateCodeSearchPrompt        // Writing the fix prompt sent to the sessions with matches
+	statePermissions             // Permission prompt breakdown and allow rule suggestions of a session
+	stateFiles                   // Browsing the session directory in the preview pane
+	stateEnvVars                 // Editing the environment variables of a session or tab
+	stateServerLost              // Restoring the sessions that went away with the tmux server
 )
 
 // Model represents the main TUI application state for Agent Session Manager.
@@ -127,6 +181,15 @@ type Model struct {
 	pathInput       textinput.Model
 	promptInput     textarea.Model            // Textarea for sending multi-line text to session
 	promptSuggestion string                    // Autocomplete suggestion from agent
+	promptResolved   string                    // Prompt with {{placeholders}} resolved, shown before sending ("" = not previewed)
+	promptUnresolved []string                  // Placeholders that could not be resolved in the preview
+	promptHistory    []session.SentPrompt      // Sent prompts of all sessions, loaded when the dialog opens
+	promptRecall     []string                  // Prompts sent to the selected session (up/down)
+	promptRecallIdx  int                       // Position in promptRecall (len = the draft)
+	promptDraft      string                    // Text typed before recalling
+	promptSearching  bool                      // Ctrl+R search through promptHistory is active
+	promptSearch     string                    // Ctrl+R query
+	promptSearchIdx  int                       // Current match in promptHistory (-1 = none)
 	autoYes         bool
 	deleteTarget    *session.Instance
 	stopTarget      *session.Instance
@@ -139,15 +202,23 @@ type Model struct {
 	agentSessions       []session.AgentSession    // Agent sessions for current instance
 	resumeAgentType     session.AgentType         // Agent type for resume (active tab's agent)
 	resumeWindowIndex   int                       // Window index for resume (active tab's index)
+	resumeFork          bool                      // Fork the picked conversation before resuming it
+	editCmdTarget       *session.Instance         // Session whose custom command or launch flags are being edited
+	editCmdWindow       int                       // Window index of the edited command (0 = main)
 	sessionCursor       int                       // Cursor for Claude session selection
 	pendingInstance     *session.Instance         // Instance being created
 	isParallelSession   bool                      // True if creating parallel session (don't show resume)
 	parallelOriginalID  string                    // Original instance ID when creating parallel session
+	parallelWorktree    bool                      // Parallel session gets its own git worktree
+	startModeGit        bool                      // Selected session is in a git repo (worktree start offered)
 	lastLines           map[string]string                   // Last output line for each instance (by ID)
 	prevContent        map[string]string                            // Previous content hash to detect activity
 	isActive           map[string]bool                              // Whether instance has recent activity
 	activityState      map[string]session.SessionActivity           // Activity state (idle/busy/waiting)
 	windowActivityState map[string]map[int]session.SessionActivity  // Window-level activity (session ID -> window index -> activity)
+	pendingQuestions    map[string]*session.PendingQuestion         // Choice menu a waiting session shows (session ID -> question)
+	autoApprovedAt      map[string]time.Time                        // Last prompt answered by an approval-limited YOLO grant (session ID)
+	seenPermissions     map[string]string                           // Permission prompt already counted, until it goes away (session ID -> signature)
 	colorCursor     int                       // Cursor for color picker
 	colorMode       int                       // 0 = foreground, 1 = background
 	previewFg       string                    // Preview foreground color
@@ -168,7 +239,22 @@ type Model struct {
 	editingGroup    *session.Group            // Group being edited in color picker (nil = editing session)
 	agentCursor     int                       // Cursor for agent selection
 	pendingAgent    session.AgentType         // Agent type for new session
+	pendingProfile  string                    // Agent profile from config.toml for new session ("" = none)
+	pendingModel    string                    // Model for new session ("" = agent or profile default)
+	modelTarget     *session.Instance         // Session whose model is being changed (nil = new session)
+	modelWindow     int                       // Window index of the changed model (0 = main)
+	modelAgent      session.AgentType         // Agent whose models the picker lists
+	modelCursor     int                       // Cursor in the model picker
+	modelTyping     bool                      // Typing another model name in the picker
+	modelInput      textinput.Model           // Input for a model not in the agent's list
+	pendingRuntime  string                    // Runtime for new session ("" = host)
+	containerInput  textinput.Model           // Input for the new session's docker container
 	customCmdInput  textinput.Model           // Input for custom command
+	timezoneInput   textinput.Model           // Input for a session's timezone
+	scheduleInput   textinput.Model           // Input for a scheduled message's send time
+	alertInput      textinput.Model           // Input for an alert's sound file path
+	alertCursor     int                       // Cursor in the sound alerts dialog
+	alertEditing    bool                      // Typing a sound file path in the alerts dialog
 	tickCount       int                       // Counter for slow tick (update others every 5th tick)
 	updateAvailable string                    // New version available (empty if up to date)
 	previewScroll   int                       // Preview scroll offset (0 = bottom, positive = scroll up)
@@ -183,6 +269,7 @@ type Model struct {
 	previousState       state                 // Previous state to return to from error dialog
 	notesInput          textarea.Model        // Textarea for editing session notes
 	notesWindowIndex    int                   // Window index for notes editing (-1 = session, >=0 = tab)
+	notesGroupID        string                // Group whose notes are edited ("" = session or tab)
 	newTabIsAgent       bool                  // Whether new tab should run agent (true) or shell (false)
 	newTabAgent         session.AgentType     // Agent type for new tab
 	newTabAgentCursor   int                   // Cursor for agent selection in new tab dialog
@@ -222,6 +309,269 @@ type Model struct {
 	globalSearchMatchedTabIndex int                              // Matched tab index (-1 = main session, >=0 = tab index)
 	globalSearchMatches         []globalSearchMatch              // All matching sessions/tabs for selection
 	globalSearchMatchCursor     int                              // Cursor for match selection
+
+	// Notifications
+	toast          *toast  // Active transient toast (nil = none)
+	messageHistory []toast // All notifications shown this run (oldest first)
+	messagesScroll int     // Scroll offset in message history panel
+
+	// Background operations
+	spinner          spinner.Model     // Spinner shown while background work runs
+	pendingOps       map[string]string // Instance ID -> running operation label (e.g. "Starting")
+	updateInProgress bool              // Update download running in background
+
+	// Resource usage
+	resourceSampler *session.ResourceSampler          // Measures pane process trees
+	netSampler      *session.NetworkSampler           // Watches remote connections of busy sessions (network_indicator)
+	netStates       map[string]session.NetState       // Instance ID -> network state at the last sample (busy sessions only)
+	resources       map[string]session.ResourceUsage  // Instance ID -> last measured usage
+	resourcesAt     time.Time                         // When resources were last sampled
+	resourceCursor  int                               // Cursor in the resource usage view
+	resourceSortMem bool                              // Sort the resource view by memory instead of CPU
+	processTarget   *session.Instance                 // Session whose processes are listed
+	processes       []session.Process                 // Process list of processTarget
+	processCursor   int                               // Cursor in the process list
+	processesAt     time.Time                         // When the process list was last read
+	pendingSignal   syscall.Signal                    // Signal awaiting confirmation (0 = none)
+
+	// Readiness checks
+	readinessInput textinput.Model           // Input for the selected session's readiness checks
+	startWaits     map[string]*readinessWait // Instance ID -> start waiting for its services
+	promptWaits    map[string]*readinessWait // Instance ID -> scheduled prompts waiting for its services
+
+	// Sandboxed start
+	sandboxWrappers []session.SandboxWrapper // Wrappers offered in the sandbox picker
+	sandboxCursor   int                      // Cursor in the sandbox picker
+
+	// Stuck detection
+	stuckDetector *session.StuckDetector   // Tracks busy sessions without new output
+	stuckFor      map[string]time.Duration // Instance ID -> how long it has been busy without output
+	stuckAt       time.Time                // When stuck detection last ran
+	stuckMinutes  int                      // Threshold setting (0 = default, -1 = off)
+	stuckTarget   *session.Instance        // Session shown in the stuck dialog
+	permissionsTarget *session.Instance    // Session shown in the permission prompts dialog
+	permissionsCursor int                  // Selected allow rule suggestion
+	permissionRules   []session.PermissionRule // Allow rules suggested in the permission prompts dialog
+	envTarget         *session.Instance    // Session whose environment variables are edited
+	envWindow         int                  // Tab whose variables are edited (0 = the session's)
+	envInput          textarea.Model       // NAME=value lines of the edited scope
+	lostSessions      []lostSession        // Sessions that were running when the tmux server went away
+	serverLostCursor  int                  // Selected session in the restore workspace dialog
+	mcpTarget         *session.Instance    // Session whose MCP servers are switched
+	mcpServers        []session.MCPServer  // Servers Claude starts in the session's directory
+	mcpDisabled       map[string]bool      // Server name -> switched off in the dialog
+	mcpCursor         int                  // Selected server in the MCP dialog
+
+	// Relink wizard for sessions whose directory moved
+	pathMissing       map[string]bool // Sessions whose path no longer exists
+	relinkTarget      *session.Instance
+	relinkSuggestions []string      // Directories that look like the moved path
+	relinkCursor      int           // Selected suggestion
+	relinkStart       *pendingStart // Start to resume after relinking
+
+	// Custom gradients (palettes.json)
+	palettes            []session.Palette
+	paletteCursor       int             // Selected custom gradient
+	paletteImportCursor int             // Selected palette in the import list
+	paletteEditIdx      int             // Gradient in the form (-1 = new)
+	paletteNameInput    textinput.Model // Gradient name
+	paletteStopsInput   textinput.Model // Stop colors, space separated
+
+	// Window picker
+	windowTarget  *session.Instance
+	windowEntries []windowEntry // Windows of windowTarget
+	windowCursor  int
+
+	// Import of tmux sessions started outside the manager
+	adoptSessions []session.ForeignSession
+	adoptSelected map[int]bool // Checked rows
+	adoptAsCustom map[int]bool // Rows imported with their pane command instead of a shell
+	adoptCursor   int
+
+	// About dialog
+	aboutTarget *session.Instance
+
+	// Multi-select for batch actions
+	multiSelected map[string]bool // Checked session IDs
+	visualActive  bool            // V range selection in progress
+	visualAnchor  int             // List row the range started at
+	batchAction   batchAction     // Action awaiting confirmation
+	batchGroupID  string          // Target group of a batch move
+	batchMoving   bool            // Group picker is choosing the batch move target
+
+	// Group deletion
+	deleteGroupTarget  *session.Group
+	deleteGroupPicking bool // Choosing the group its sessions move to
+	deleteGroupCursor  int  // Selected group in the move picker
+
+	// Session limit warning
+	limitMessage    string           // Which limit is exceeded
+	limitPending    []pendingStart   // Starts held back by the running limit
+	limitNewSession bool             // The new session flow is held back by the project limit
+	limitIdle       []limitCandidate // Idle running sessions that could be stopped
+	limitChecked    map[string]bool  // Idle sessions checked for stopping
+	limitCursor     int
+
+	// Git branch per session path
+	branches      map[string]string // Path -> checked out branch ("" = not a repo)
+	branchesAt    time.Time         // Last branch poll
+	branchPolling bool              // A poll is running in the background
+	branchTarget  *session.Instance // Session whose branch is being switched
+	branchList    []string          // Local branches of its repo, most recent first
+	branchCursor  int
+
+	// Staging from the diff pane
+	stageTarget *session.Instance  // Session whose repo is being staged
+	stageFiles  []session.FileDiff // Unstaged files first, then staged ones
+	stageCursor int                // Hunk (or whole file) under the cursor
+	commitInput textinput.Model    // Commit message
+
+	// File browser in the preview pane
+	filesTarget   *session.Instance  // Session whose directory is browsed
+	fileNodes     []session.FileNode // Tree with the expanded directories opened
+	filesExpanded map[string]bool    // Expanded directories (relative paths)
+	filesCursor   int
+	filesHidden   bool     // Show dot files
+	filesMentions bool     // Right column shows transcript mentions instead of contents
+	filesDetail   []string // Right column lines for the entry under the cursor
+
+	// Transfer between sessions
+	transferSource  *session.Instance      // Session the file or patch comes from
+	transferItems   []session.TransferItem // Its changes, last commit and changed files
+	transferTargets []*session.Instance    // Sessions in other directories
+	transferItem    int                    // Picked item (-1 = still picking)
+	transferCursor  int
+
+	// User config (config.toml)
+	config *session.Config // Defaults: agent, path roots, theme, compact mode
+	keys   keymap          // Remapped list keys
+
+	// Project inference for new sessions
+	inferMatch *session.ProjectMatch // Match shown in the filing dialog
+	fileUnder  *session.ProjectMatch // Accepted match for the session being created (nil = active project)
+
+	// Automatic colors
+	autoColor string // Auto color mode for new sessions and groups (AutoColor*)
+
+	// Stable selection
+	searchAnchor selectionAnchor // Selection when the search was opened, followed while filtering
+
+	// Cross-project list
+	globalList bool // Sessions of all projects in one list (leader a)
+
+	// Nested forks
+	nestForks bool // Forks and parallel sessions shown under their origin (leader n)
+
+	// Project health
+	healthAt      time.Time                        // Last status cache write / badge refresh
+	projectHealth map[string]session.HealthSummary // Selector badges by project ID ("" = no project)
+
+	// Notification routing
+	notifyConfig *notify.Config           // Rules from notify.json
+	notifyErrs   chan error               // Failures of background channels (Slack, system), shown as toasts
+	terminalSeqs []string                 // Bells and OSC sequences to write once the message is handled
+	paneDead     map[string]bool          // Whether each running session's main pane was dead at the last check
+	remotePolls  map[string]bool          // Remote sessions whose tick read over SSH is still running
+	paneExit     map[string]int           // Exit status of dead main panes (-1 = unknown)
+	restarts     map[string]*restartState // Automatic restarts by session ID (restart policy)
+	idleSince    map[string]time.Time     // When running sessions became idle (idle auto-stop)
+
+	// Scrollback
+	scrollback       map[string][]session.Scrollback // Scrollback usage per window, by session ID (selected session only)
+	scrollbackTarget *session.Instance               // Session shown in the scrollback dialog
+
+	// Handover
+	handoverTarget    *session.Instance // Session shown in the handover dialog
+	handoverSummarize bool              // Ask the summarizer for a fresh summary first
+
+	// External changes to sessions.json
+	externalChange      *session.ExternalChange // Change of another process shown in the merge dialog
+	externalSnoozeUntil time.Time               // Don't ask again before this ("decide later")
+
+	// Diff stats across sessions
+	churn        map[string]session.Churn // Instance ID -> lines changed since start (running sessions)
+	churnLoading bool                     // Diff stats are being counted in the background
+	churnGroup   *session.Group           // Group the view was opened for (nil = project)
+	churnAll     bool                     // Sum the whole project instead of churnGroup
+	churnCursor  int                      // Cursor in the diff stats view
+
+	// Weekly digest (leader d)
+	digestEntries []session.DigestEntry    // Sessions stopped or deleted in the past week
+	digestChurn   map[string]session.Churn // Instance ID -> lines changed since start
+	digestLoading bool                     // Diff stats are being counted in the background
+	digestCursor  int                      // Cursor in the digest
+
+	// Token usage and cost
+	usage        map[string]session.SessionUsage // Instance ID -> usage read from the agent transcripts
+	usageLoading bool                            // Usage is being summed in the background
+	usageAt      time.Time                       // When the last sum was started
+
+	// Session presets
+	presetGallery    []session.Preset // Presets of the gallery (fetched or bundled)
+	presetsInstalled []session.Preset // Presets installed in config.toml
+	presetsLoading   bool             // The gallery is being fetched
+	presetsErr       error            // Fetching the gallery failed (the bundled one is shown)
+	presetCursor     int              // Cursor in the gallery
+	pendingPreset    *session.Preset  // Preset the session being created is set up from
+
+	// Empty-state dashboard
+	dashboardWaiting  []session.SessionState // Waiting sessions across projects (1-9 jump to them)
+	dashboardSearches []session.PastSearch   // Recent global searches
+	dashboardLoading  bool                   // Being read in the background
+	dashboardAt       time.Time              // When it was last read
+
+	// Conversation export
+	convExportInput  textinput.Model       // Output path of the conversation export
+	convExportTarget *session.Instance     // Session shown in the export dialog
+	convExportEntry  *session.HistoryEntry // Conversation being exported
+
+	// Search across session directories
+	codeSearchInput   textinput.Model       // ripgrep pattern
+	codePromptInput   textinput.Model       // Fix prompt template sent to the agents
+	codeSearchGroup   *session.Group        // Group the search was opened for (nil = project)
+	codeSearchAll     bool                  // Search the whole project instead of codeSearchGroup
+	codeSearchLoading bool                  // ripgrep is running
+	codeSearchPattern string                // Pattern of the shown matches
+	codeSearchRepos   []session.RepoMatches // Directories with matches (nil = not searched yet)
+	codeSearchSkip    map[string]bool       // Directories left out of the fix prompt
+	codeSearchCursor  int                   // Cursor in the directories
+
+	// Attached alerts
+	stopAttachedAlerts func() // Stops watching the other sessions (set while attached)
+
+	// Debug mode
+	frames *frameStats // View() timings (nil unless ASMGR_DEBUG is set)
+
+	// Screen-reader mode
+	accessible bool // Linear plain-text rendering (--accessible)
+
+	// User scripts
+	scripts *scripts.Engine // Lua hooks loaded from the scripts directory
+
+	// Dry-run mode
+	dryRun         bool          // Show commands for confirmation before lifecycle actions
+	dryRunStart    *pendingStart // Start waiting for dry-run confirmation
+	dryRunCommands []string      // Command lines shown in the dry-run dialog
+	dryRunScroll   int           // Scroll offset in the dry-run dialog
+
+	// tmux console
+	consoleInput      textinput.Model // Command input
+	consoleTarget     string          // tmux target (session:window) commands run against
+	consoleTmux       session.Runner  // tmux of the target's session (local or over ssh)
+	consoleLog        []string        // Commands and their output
+	consoleHistory    []string        // Previously run commands for up/down recall
+	consoleHistoryIdx int             // Position in consoleHistory while recalling
+	consoleScroll     int             // Lines scrolled up from the newest output
+
+	// Leader key sequences
+	leaderPending bool // Leader key pressed, waiting for the second key
+	leaderSeq     int  // Incremented per leader press to ignore stale timeouts
+
+	// Topic keywords per session (instance ID -> keywords)
+	topics map[string][]string
+
+	// Sessions with a summary being generated (instance ID -> true)
+	summarizing map[string]bool
 }
 
 // globalSearchMatch represents a matched session/tab for selection
@@ -236,13 +586,17 @@ type visibleItem struct {
 	isGroup  bool              // true if this is a group header
 	group    *session.Group    // The group (if isGroup is true)
 	instance *session.Instance // The session instance (if isGroup is false)
+	depth    int               // Nesting level under the origin session (nest_forks)
+	hidden   int               // Sessions nested under this one that are collapsed
 }
 
 // tickMsg is sent periodically to update the UI
 type tickMsg time.Time
 
 // reattachMsg is sent when returning from an attached session
-type reattachMsg struct{}
+type reattachMsg struct {
+	inst *session.Instance
+}
 
 // globalSearchDebounceMsg triggers delayed search after typing stops
 type globalSearchDebounceMsg struct{}
@@ -270,6 +624,10 @@ func NewModel() (Model, error) {
 	pathInput.Placeholder = "/path/to/project"
 	pathInput.CharLimit = 256
 
+	containerInput := textinput.New()
+	containerInput.Placeholder = "container name or ID"
+	containerInput.CharLimit = 128
+
 	promptInput := textarea.New()
 	promptInput.Placeholder = "Enter message to send..."
 	promptInput.CharLimit = 5000
@@ -286,6 +644,49 @@ func NewModel() (Model, error) {
 	customCmdInput.Placeholder = "command --flags"
 	customCmdInput.CharLimit = 500
 
+	modelInput := textinput.New()
+	modelInput.Placeholder = "model name"
+	modelInput.CharLimit = 100
+	modelInput.Width = 30
+
+	timezoneInput := textinput.New()
+	timezoneInput.Placeholder = "Europe/Berlin"
+	timezoneInput.CharLimit = 64
+
+	readinessInput := textinput.New()
+	readinessInput.Placeholder = "port 5432; url http://localhost:3000/health; cmd pg_isready"
+	readinessInput.CharLimit = 500
+
+	scheduleInput := textinput.New()
+	scheduleInput.Placeholder = "9am"
+	scheduleInput.CharLimit = 20
+
+	alertInput := textinput.New()
+	alertInput.Placeholder = "~/sounds/done.wav"
+	alertInput.CharLimit = 256
+
+	convExportInput := textinput.New()
+	convExportInput.Placeholder = "~/conversation.md"
+	convExportInput.CharLimit = 512
+	convExportInput.Width = 70
+
+	codeSearchInput := textinput.New()
+	codeSearchInput.Placeholder = `oldApi\.call\(`
+	codeSearchInput.CharLimit = 200
+	codeSearchInput.Width = 70
+
+	codePromptInput := textinput.New()
+	codePromptInput.CharLimit = 1000
+	codePromptInput.Width = 80
+
+	paletteNameInput := textinput.New()
+	paletteNameInput.Placeholder = "my-gradient"
+	paletteNameInput.CharLimit = 32
+
+	paletteStopsInput := textinput.New()
+	paletteStopsInput.Placeholder = "#FF512F #DD2476 #8E2DE2"
+	paletteStopsInput.CharLimit = 120
+
 	projectInput := textinput.New()
 	projectInput.Placeholder = "Project name"
 	projectInput.CharLimit = 50
@@ -298,6 +699,14 @@ func NewModel() (Model, error) {
 	notesInput.SetWidth(70)
 	notesInput.SetHeight(9)
 
+	envInput := textarea.New()
+	envInput.Placeholder = "NAME=value, one per line"
+	envInput.CharLimit = 5000
+	envInput.ShowLineNumbers = false
+	envInput.Prompt = ""
+	envInput.SetWidth(66)
+	envInput.SetHeight(8)
+
 	searchInput := textinput.New()
 	searchInput.Placeholder = "Search..."
 	searchInput.CharLimit = 100
@@ -314,6 +723,15 @@ func NewModel() (Model, error) {
 	forkNameInput.Placeholder = "Fork name"
 	forkNameInput.CharLimit = 50
 
+	consoleInput := textinput.New()
+	consoleInput.Placeholder = "tmux command"
+	consoleInput.CharLimit = 500
+	consoleInput.Prompt = ": "
+
+	commitInput := textinput.New()
+	commitInput.Placeholder = "Commit message"
+	commitInput.CharLimit = 200
+
 	// Load projects
 	projectsData, err := storage.LoadProjects()
 	if err != nil {
@@ -326,16 +744,29 @@ func NewModel() (Model, error) {
 		state:           stateProjectSelect, // Start with project selection
 		nameInput:       nameInput,
 		pathInput:       pathInput,
+		containerInput:  containerInput,
 		promptInput:     promptInput,
 		groupInput:      groupInput,
 		customCmdInput:  customCmdInput,
+		modelInput:      modelInput,
+		timezoneInput:   timezoneInput,
+		scheduleInput:   scheduleInput,
+		alertInput:      alertInput,
+		convExportInput: convExportInput,
+		codeSearchInput: codeSearchInput,
+		codePromptInput: codePromptInput,
 		projectInput:    projectInput,
 		notesInput:      notesInput,
+		envInput:        envInput,
+		paletteNameInput:    paletteNameInput,
+		paletteStopsInput:   paletteStopsInput,
 		searchInput:     searchInput,
 		globalSearchInput:   globalSearchInput,
 		globalSearchExpanded: -1,
 		historyIndex:        session.NewHistoryIndex(),
 		forkNameInput:       forkNameInput,
+		consoleInput:        consoleInput,
+		commitInput:         commitInput,
 		projects:        projectsData.Projects,
 		projectCursor:   0,
 		groups:          []*session.Group{},
@@ -344,8 +775,63 @@ func NewModel() (Model, error) {
 		isActive:            make(map[string]bool),
 		activityState:       make(map[string]session.SessionActivity),
 		windowActivityState: make(map[string]map[int]session.SessionActivity),
+		pendingQuestions:    make(map[string]*session.PendingQuestion),
+		autoApprovedAt:      make(map[string]time.Time),
+		seenPermissions:     make(map[string]string),
 		diffPane:            NewDiffPane(),
 		updateAvailable:     updater.GetCachedAvailableUpdate(), // Load cached update
+		spinner:             newProgressSpinner(),
+		pendingOps:          make(map[string]string),
+		startWaits:          make(map[string]*readinessWait),
+		promptWaits:         make(map[string]*readinessWait),
+		readinessInput:      readinessInput,
+		summarizing:         make(map[string]bool),
+		scripts:             scripts.Load(),
+		resourceSampler:     session.NewResourceSampler(),
+		netSampler:          session.NewNetworkSampler(),
+		stuckDetector:       session.NewStuckDetector(),
+		stuckFor:            make(map[string]time.Duration),
+		pathMissing:         make(map[string]bool),
+		notifyErrs:          make(chan error, 1),
+		paneDead:            make(map[string]bool),
+		remotePolls:         make(map[string]bool),
+		paneExit:            make(map[string]int),
+		restarts:            make(map[string]*restartState),
+		idleSince:           make(map[string]time.Time),
+		scrollback:          make(map[string][]session.Scrollback),
+		frames:              newFrameStats(),
+	}
+
+	notifyConfig, err := notify.Load()
+	if err != nil {
+		m.notifyWarn("Notification rules ignored: %v", err)
+	}
+	m.notifyConfig = notifyConfig
+
+	if m.palettes, err = session.LoadPalettes(); err != nil {
+		m.notifyWarn("Custom gradients ignored: %v", err)
+	}
+	registerPalettes(m.palettes)
+
+	if agentDisplay, err = session.LoadAgentDisplay(); err != nil {
+		m.notifyWarn("Agent icon overrides ignored: %v", err)
+	}
+
+	if m.config, err = session.LoadConfig(); err != nil {
+		m.notifyWarn("config.toml ignored: %v", err)
+	}
+	session.UseConfig(m.config)
+	if err := applyColorMode(m.config.ColorMode); err != nil {
+		m.notifyWarn("Color mode ignored: %v", err)
+	}
+	if err := applyTheme(m.config.Theme); err != nil {
+		m.notifyWarn("Theme ignored: %v", err)
+	}
+	if m.keys, err = newKeymap(m.config.Keys); err != nil {
+		m.notifyWarn("Key bindings ignored: %v", err)
+	}
+	if m.config.CompactMode != nil {
+		storage.SetDefaults(session.Settings{CompactList: *m.config.CompactMode})
 	}
 
 	return m, nil
@@ -354,18 +840,25 @@ func NewModel() (Model, error) {
 // Init implements tea.Model and returns the initial command for the program.
 // It sets up the terminal appearance and starts the tick timer.
 func (m Model) Init() tea.Cmd {
-	// Set terminal tab color (works in some terminals like iTerm2, Konsole)
-	// Purple color to match the theme
-	fmt.Print("\033]6;1;bg;red;brightness;125\007")
-	fmt.Print("\033]6;1;bg;green;brightness;86\007")
-	fmt.Print("\033]6;1;bg;blue;brightness;244\007")
-
+	// Take what other machines changed without holding up the first frame
+	var syncStartup tea.Cmd
+	if m.config.Sync != nil && m.config.Sync.Auto {
+		syncStartup = syncCmd(m.config.Sync)
+	}
 	return tea.Batch(
+		// Set terminal tab color (works in some terminals like iTerm2, Konsole)
+		// Purple color to match the theme
+		emitTerminal([]string{
+			"\033]6;1;bg;red;brightness;125\007",
+			"\033]6;1;bg;green;brightness;86\007",
+			"\033]6;1;bg;blue;brightness;244\007",
+		}),
 		tickCmd(),
 		tea.EnterAltScreen,
-		tea.SetWindowTitle("Agent Session Manager"),
+		tea.SetWindowTitle(AppTitle),
 		tea.EnableMouseCellMotion,
 		checkForUpdateCmd(),
+		syncStartup,
 	)
 }
 
@@ -434,9 +927,24 @@ func tickCmd() tea.Cmd {
 	})
 }
 
-// Update implements tea.Model and handles all incoming messages.
-// It delegates to specialized handlers based on the current state.
+// Update implements tea.Model and handles all incoming messages, then writes
+// the bells and OSC sequences queued while handling them
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+	if mm, ok := model.(Model); ok && mm.storage != nil {
+		mm.checkRefusedSave()
+		model = mm
+	}
+	if mm, ok := model.(Model); ok && len(mm.terminalSeqs) > 0 {
+		seqs := mm.terminalSeqs
+		mm.terminalSeqs = nil
+		return mm, tea.Batch(cmd, emitTerminal(seqs))
+	}
+	return model, cmd
+}
+
+// update handles a message, delegating to specialized handlers based on the current state
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	var cmds []tea.Cmd
 
@@ -477,9 +985,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case fileEditedMsg:
+		return m.handleFileEdited(msg)
+
 	case reattachMsg:
+		if m.stopAttachedAlerts != nil {
+			m.stopAttachedAlerts()
+			m.stopAttachedAlerts = nil
+		}
+		// Exiting the agent while attached is not worth a notification
+		msg.inst.UpdateStatus()
+		m.checkExited(msg.inst, false)
+		// YOLO toggles made from tmux while attached saved sessions.json
+		if m.state == stateList {
+			m.checkExternalChanges()
+		}
 		// Request window size to refresh dimensions after reattach
-		return m, tea.Batch(tea.ClearScreen, tea.EnableMouseCellMotion, tea.WindowSize())
+		return m, tea.Batch(tea.ClearScreen, tea.EnableMouseCellMotion, tea.WindowSize(), restoreAppTitle(), m.refreshTopics())
 
 	case updateCheckMsg:
 		newVersion := string(msg)
@@ -492,30 +1014,21 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.state == stateCheckingUpdate {
 			if newVersion != "" {
 				// Update available - start download based on package type
-				if updater.IsPackageManaged() {
-					// Check if deb
-					if _, err := os.Stat("/var/lib/dpkg/info/asmgr.list"); err == nil {
-						m.state = stateDownloadingDeb
-						return m, runDebDownload(newVersion)
-					}
-					// Otherwise rpm
-					m.state = stateDownloadingRpm
-					return m, runRpmDownload(newVersion)
-				}
-				// Tarball update
-				m.state = stateUpdating
-				return m, runUpdateCmd(newVersion)
+				return m, m.startUpdateDownload(newVersion)
 			} else {
 				// Already up to date
-				m.err = fmt.Errorf("already up to date (v%s)", AppVersion)
-				m.previousState = stateList
-				m.state = stateError
+				m.notifyInfo("Already up to date (v%s)", AppVersion)
+				m.state = m.previousState
+				if m.state != stateProjectSelect {
+					m.state = stateList
+				}
 				return m, nil
 			}
 		}
 		return m, nil
 
 	case debDownloadDoneMsg:
+		m.updateInProgress = false
 		if msg.err != nil {
 			m.err = msg.err
 			m.previousState = m.state
@@ -524,7 +1037,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// Deb downloaded - now run sudo dpkg -i via tea.ExecProcess
 		cmd := exec.Command("sudo", "dpkg", "-i", msg.debPath)
-		return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return m, execProcess(cmd, func(err error) tea.Msg {
 			// Clean up temp file
 			os.Remove(msg.debPath)
 			if err != nil {
@@ -534,6 +1047,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		})
 
 	case rpmDownloadDoneMsg:
+		m.updateInProgress = false
 		if msg.err != nil {
 			m.err = msg.err
 			m.previousState = m.state
@@ -542,7 +1056,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		// Rpm downloaded - now run sudo rpm -Uvh via tea.ExecProcess
 		cmd := exec.Command("sudo", "rpm", "-Uvh", msg.rpmPath)
-		return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return m, execProcess(cmd, func(err error) tea.Msg {
 			// Clean up temp file
 			os.Remove(msg.rpmPath)
 			if err != nil {
@@ -552,6 +1066,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		})
 
 	case updateDoneMsg:
+		m.updateInProgress = false
 		if msg.err != nil {
 			m.err = msg.err
 			m.previousState = m.state
@@ -586,6 +1101,89 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tickMsg:
 		return m.handleTick()
 
+	case spinner.TickMsg:
+		return m.handleSpinnerTick(msg)
+
+	case sessionStartedMsg:
+		return m.handleSessionStarted(msg)
+
+	case readinessMsg:
+		return m.handleReadiness(msg)
+
+	case promptReadinessMsg:
+		return m.handlePromptReadiness(msg)
+
+	case relinkNeededMsg:
+		return m.handleRelinkNeeded(msg)
+
+	case resumeForkedMsg:
+		return m.handleResumeForked(msg)
+
+	case forkDoneMsg:
+		return m.handleForkDone(msg)
+
+	case consoleResultMsg:
+		return m.handleConsoleResult(msg)
+
+	case leaderTimeoutMsg:
+		return m.handleLeaderTimeout(msg)
+
+	case topicsMsg:
+		m.topics = msg
+		return m, nil
+
+	case branchesMsg:
+		m.branches = msg
+		m.branchPolling = false
+		return m, nil
+
+	case handoverDoneMsg:
+		return m.handleHandoverDone(msg)
+
+	case convExportDoneMsg:
+		m.handleConvExportDone(msg)
+		return m, nil
+
+	case codeSearchDoneMsg:
+		m.handleCodeSearchDone(msg)
+		return m, nil
+
+	case digestLoadedMsg:
+		m.digestChurn = msg.churn
+		m.digestLoading = false
+		return m, nil
+
+	case remotePolledMsg:
+		m.handleRemotePolled(msg)
+		return m, nil
+
+	case syncDoneMsg:
+		m.handleSyncDone(msg)
+		return m, nil
+
+	case churnLoadedMsg:
+		m.churn = msg.churn
+		m.churnLoading = false
+		return m, nil
+
+	case usageLoadedMsg:
+		m.handleUsageLoaded(msg)
+		return m, nil
+
+	case dashboardLoadedMsg:
+		m.handleDashboardLoaded(msg)
+		return m, nil
+
+	case presetsLoadedMsg:
+		m.handlePresetsLoaded(msg)
+		return m, nil
+
+	case summaryDoneMsg:
+		return m.handleSummaryDone(msg)
+
+	case commitDoneMsg:
+		return m.handleCommitDone(msg)
+
 	case globalSearchDebounceMsg:
 		return m.handleGlobalSearchDebounce()
 
@@ -610,6 +1208,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleNewNameKeys(msg)
 		case stateNewPath:
 			return m.handleNewPathKeys(msg)
+		case stateNewContainer:
+			return m.handleNewContainerKeys(msg)
 		case stateSelectAgentSession:
 			return m.handleSelectSessionKeys(msg)
 		case stateConfirmDelete:
@@ -642,6 +1242,100 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleErrorKeys(msg)
 		case stateConfirmUpdate:
 			return m.handleConfirmUpdateKeys(msg)
+		case stateUpdating, stateDownloadingDeb, stateDownloadingRpm:
+			return m.handleUpdatingKeys(msg)
+		case stateConfirmDryRun:
+			return m.handleDryRunKeys(msg)
+		case stateTmuxConsole:
+			return m.handleConsoleKeys(msg)
+		case stateExportReport:
+			return m.handleExportReportKeys(msg)
+		case stateConfirmDeleteTranscript:
+			return m.handleConfirmDeleteTranscriptKeys(msg)
+		case stateEditCustomCmd:
+			return m.handleEditCustomCmdKeys(msg)
+		case stateEditFlags:
+			return m.handleEditFlagsKeys(msg)
+		case stateModelPicker:
+			return m.handleModelPickerKeys(msg)
+		case stateDigest:
+			return m.handleDigestKeys(msg)
+		case stateMCP:
+			return m.handleMCPKeys(msg)
+		case stateConfirmRespawnCmd:
+			return m.handleConfirmRespawnCmdKeys(msg)
+		case stateReadiness:
+			return m.handleReadinessKeys(msg)
+		case stateSetTimezone:
+			return m.handleSetTimezoneKeys(msg)
+		case stateSchedulePrompt:
+			return m.handleSchedulePromptKeys(msg)
+		case stateResources:
+			return m.handleResourcesKeys(msg)
+		case stateProcesses:
+			return m.handleProcessesKeys(msg)
+		case stateWindowPicker:
+			return m.handleWindowPickerKeys(msg)
+		case stateAdopt:
+			return m.handleAdoptKeys(msg)
+		case stateAbout:
+			return m.handleAboutKeys(msg)
+		case stateBatchConfirm:
+			return m.handleBatchConfirmKeys(msg)
+		case stateDeleteGroup:
+			return m.handleDeleteGroupKeys(msg)
+		case stateLimitWarning:
+			return m.handleLimitKeys(msg)
+		case stateBranchPicker:
+			return m.handleBranchPickerKeys(msg)
+		case stateStage:
+			return m.handleStageKeys(msg)
+		case stateFiles:
+			return m.handleFilesKeys(msg)
+		case stateCommitMessage:
+			return m.handleCommitMessageKeys(msg)
+		case stateTransfer:
+			return m.handleTransferKeys(msg)
+		case stateSandboxPicker:
+			return m.handleSandboxPickerKeys(msg)
+		case stateStuck:
+			return m.handleStuckKeys(msg)
+		case statePermissions:
+			return m.handlePermissionsKeys(msg)
+		case stateEnvVars:
+			return m.handleEnvVarsKeys(msg)
+		case stateServerLost:
+			return m.handleServerLostKeys(msg)
+		case stateScrollback:
+			return m.handleScrollbackKeys(msg)
+		case stateHandover:
+			return m.handleHandoverKeys(msg)
+		case stateExternalChange:
+			return m.handleExternalChangeKeys(msg)
+		case stateChurn:
+			return m.handleChurnKeys(msg)
+		case stateUsage:
+			return m.handleUsageKeys(msg)
+		case statePresets:
+			return m.handlePresetsKeys(msg)
+		case stateExportConversation:
+			return m.handleExportConversationKeys(msg)
+		case stateCodeSearch:
+			return m.handleCodeSearchKeys(msg)
+		case stateCodeSearchPrompt:
+			return m.handleCodeSearchPromptKeys(msg)
+		case stateRelink:
+			return m.handleRelinkKeys(msg)
+		case stateInferProject:
+			return m.handleInferProjectKeys(msg)
+		case stateGradients:
+			return m.handleGradientsKeys(msg)
+		case stateGradientEdit:
+			return m.handleGradientEditKeys(msg)
+		case stateGradientImport:
+			return m.handleGradientImportKeys(msg)
+		case stateAlerts:
+			return m.handleAlertsKeys(msg)
 		case stateUpdateSuccess:
 			return m.handleUpdateSuccessKeys(msg)
 		case stateNotes:
@@ -685,6 +1379,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m.handleGlobalSearchNewNameKeys(msg)
 		case stateGlobalSearchSelectMatch:
 			return m.handleGlobalSearchSelectMatchKeys(msg)
+		case stateMessages:
+			return m.handleMessagesKeys(msg)
 		}
 	}
 
@@ -696,6 +1392,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.pathInput, cmd = m.pathInput.Update(msg)
 		cmds = append(cmds, cmd)
 	}
+	if m.state == stateNewContainer {
+		m.containerInput, cmd = m.containerInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
 	if m.state == statePrompt {
 		m.promptInput, cmd = m.promptInput.Update(msg)
 		cmds = append(cmds, cmd)
@@ -704,10 +1404,42 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.groupInput, cmd = m.groupInput.Update(msg)
 		cmds = append(cmds, cmd)
 	}
-	if m.state == stateCustomCmd {
+	if m.state == stateCustomCmd || m.state == stateEditCustomCmd || m.state == stateEditFlags {
 		m.customCmdInput, cmd = m.customCmdInput.Update(msg)
 		cmds = append(cmds, cmd)
 	}
+	if m.state == stateModelPicker && m.modelTyping {
+		m.modelInput, cmd = m.modelInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	if m.state == stateSetTimezone {
+		m.timezoneInput, cmd = m.timezoneInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	if m.state == stateReadiness {
+		m.readinessInput, cmd = m.readinessInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	if m.state == stateSchedulePrompt {
+		m.scheduleInput, cmd = m.scheduleInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	if m.state == stateExportConversation {
+		m.convExportInput, cmd = m.convExportInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	if m.state == stateCodeSearch && m.codeSearchInput.Focused() {
+		m.codeSearchInput, cmd = m.codeSearchInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	if m.state == stateCodeSearchPrompt {
+		m.codePromptInput, cmd = m.codePromptInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	if m.state == stateAlerts && m.alertEditing {
+		m.alertInput, cmd = m.alertInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
 	if m.state == stateNewProject || m.state == stateRenameProject {
 		m.projectInput, cmd = m.projectInput.Update(msg)
 		cmds = append(cmds, cmd)
@@ -716,6 +1448,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.notesInput, cmd = m.notesInput.Update(msg)
 		cmds = append(cmds, cmd)
 	}
+	if m.state == stateEnvVars {
+		m.envInput, cmd = m.envInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
 	if m.state == stateSearch {
 		m.searchInput, cmd = m.searchInput.Update(msg)
 		cmds = append(cmds, cmd)
@@ -728,79 +1464,190 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.forkNameInput, cmd = m.forkNameInput.Update(msg)
 		cmds = append(cmds, cmd)
 	}
+	if m.state == stateGradientEdit {
+		m.paletteNameInput, cmd = m.paletteNameInput.Update(msg)
+		cmds = append(cmds, cmd)
+		m.paletteStopsInput, cmd = m.paletteStopsInput.Update(msg)
+		cmds = append(cmds, cmd)
+	}
 
 	return m, tea.Batch(cmds...)
 }
 
 // handleTick processes tick messages for periodic UI updates
 func (m Model) handleTick() (tea.Model, tea.Cmd) {
+	m.expireToast()
+
+	// Scheduled messages and script actions are applied even while a dialog is open
+	prompts := m.sendDuePrompts()
+	m.applyScriptActions()
+	m.sampleResources()
+	m.tickProcesses()
+	m.checkStuck()
+	m.checkYoloExpiry()
+	m.reportNotifyErrors()
+	m.updateHealth()
+	restart := m.restartDue()
+	usage := m.refreshUsage()
+	idle := m.stopIdleTimedOut()
+
 	// Skip heavy processing during dialogs - only update in list view
 	if m.state != stateList {
-		return m, tickCmd()
+		return m, tea.Batch(tickCmd(), prompts, restart, usage, idle)
 	}
 
 	m.tickCount++
 	slowTick := m.tickCount%5 == 0 // Every 5th tick (500ms) for non-selected
+	if m.tickCount%ExternalCheckTicks == 0 {
+		m.checkExternalChanges()
+	}
+	m.openServerLost()
 
 	selectedInst := m.getSelectedInstance()
 
 	// Update instance statuses and last lines
+	var remote []tea.Cmd
 	for _, inst := range m.instances {
 		// Only update non-selected instances on slow tick
 		isSelected := selectedInst != nil && inst.ID == selectedInst.ID
 		if !isSelected && !slowTick {
 			continue
 		}
+		// Instance is being started in the background - leave it alone
+		if m.isBusy(inst) {
+			continue
+		}
+		// Sessions on SSH hosts are read off the UI loop
+		if inst.IsRemote() {
+			remote = append(remote, m.pollRemote(inst, isSelected, slowTick))
+			continue
+		}
 
+		wasRunning := inst.Status == session.StatusRunning
 		inst.UpdateStatus()
-		currentLine := inst.GetLastLine()
-		m.lastLines[inst.ID] = currentLine
+		if wasRunning && inst.Status != session.StatusRunning && m.serverLost(inst) {
+			continue
+		}
+		if slowTick || inst.Status != session.StatusRunning {
+			m.checkExited(inst, wasRunning)
+		}
+		m.applyPoll(inst, pollInstance(inst, inst.Status == session.StatusRunning))
+	}
 
-		// Detect activity by comparing with previous content
-		if inst.Status == session.StatusRunning {
-			prevLine := m.prevContent[inst.ID]
-			if currentLine != prevLine && prevLine != "" {
-				m.isActive[inst.ID] = true
-			} else {
-				m.isActive[inst.ID] = false
-			}
-			m.prevContent[inst.ID] = currentLine
+	// Update preview for selected instance
+	if selectedInst != nil && !selectedInst.IsRemote() {
+		preview, err := selectedInst.GetPreview(PreviewLineCount)
+		m.applyPreview(selectedInst, preview, err)
+
+		if slowTick {
+			m.refreshScrollback(selectedInst)
+		}
+	}
+	// Update diff content if showing diff tab (only on slow tick to avoid git overload)
+	if selectedInst != nil && m.showDiff && slowTick {
+		m.diffPane.SetDiff(selectedInst)
+	}
+	poll := m.pollBranches()
+	dashboard := m.refreshDashboard()
+	return m, tea.Batch(append(remote, tickCmd(), prompts, poll, restart, usage, idle, dashboard)...)
+}
 
-			// Detect detailed activity state (busy/waiting/idle) across all followed windows
-			m.activityState[inst.ID] = inst.DetectAggregatedActivity()
+// instancePoll is what a tick reads from tmux about a session
+type instancePoll struct {
+	lastLine string
+	activity session.SessionActivity         // Highest activity of its windows
+	windows  map[int]session.SessionActivity // Activity by window index
+	question *session.PendingQuestion        // Choice menu of the first waiting window
+}
 
-			// Detect per-window activity for status line coloring
-			if m.windowActivityState[inst.ID] == nil {
-				m.windowActivityState[inst.ID] = make(map[int]session.SessionActivity)
-			}
-			// Main window (0)
-			m.windowActivityState[inst.ID][0] = inst.DetectActivityForWindow(0)
-			// Followed windows
-			for _, fw := range inst.FollowedWindows {
-				m.windowActivityState[inst.ID][fw.Index] = inst.DetectActivityForWindow(fw.Index)
-			}
+// pollInstance reads a session's last line and, while it runs, the activity of
+// its windows and its pending question
+func pollInstance(inst *session.Instance, running bool) instancePoll {
+	poll := instancePoll{lastLine: inst.GetLastLine()}
+	if !running {
+		return poll
+	}
+	poll.activity = inst.DetectAggregatedActivity()
+	poll.windows = map[int]session.SessionActivity{0: inst.DetectActivityForWindow(0)}
+	for _, fw := range inst.FollowedWindows {
+		poll.windows[fw.Index] = inst.DetectActivityForWindow(fw.Index)
+	}
+	if poll.activity == session.ActivityWaiting {
+		poll.question = readPendingQuestion(inst, poll.windows)
+	}
+	return poll
+}
+
+// applyPoll stores what a tick read about a session and publishes its output,
+// activity changes and notifications
+func (m *Model) applyPoll(inst *session.Instance, poll instancePoll) {
+	currentLine := poll.lastLine
+	m.lastLines[inst.ID] = currentLine
+
+	// Detect activity by comparing with previous content
+	if inst.Status == session.StatusRunning {
+		prevLine := m.prevContent[inst.ID]
+		if currentLine != prevLine && prevLine != "" {
+			m.isActive[inst.ID] = true
 		} else {
 			m.isActive[inst.ID] = false
-			m.activityState[inst.ID] = session.ActivityIdle
-			m.windowActivityState[inst.ID] = nil
 		}
-	}
+		m.prevContent[inst.ID] = currentLine
+		if currentLine != prevLine && currentLine != "" {
+			e := inst.Event(events.OutputLine)
+			e.Text = currentLine
+			events.Publish(e)
+			if prevLine != "" && session.IsErrorLine(currentLine) {
+				m.playAlert(inst, session.AlertError)
+				m.routeNotification(inst, notify.Error, truncateRunes(stripANSI(currentLine), 200))
+			}
+		}
 
-	// Update preview for selected instance
-	if selectedInst != nil {
-		preview, err := selectedInst.GetPreview(PreviewLineCount)
-		if err != nil {
-			m.preview = "(error loading preview)"
-		} else {
-			m.preview = preview
+		// Detailed activity state (busy/waiting/idle) across all followed windows
+		prevActivity := m.activityState[inst.ID]
+		m.activityState[inst.ID] = poll.activity
+
+		if prevActivity != m.activityState[inst.ID] {
+			e := inst.Event(events.ActivityChanged)
+			e.Activity = m.activityState[inst.ID].String()
+			events.Publish(e)
 		}
 
-		// Update diff content if showing diff tab (only on slow tick to avoid git overload)
-		if m.showDiff && slowTick {
-			m.diffPane.SetDiff(selectedInst)
+		// Agent finished working and now needs input - notify via terminal (OSC 9/777)
+		if prevActivity == session.ActivityBusy && m.activityState[inst.ID] == session.ActivityWaiting {
+			m.routeNotification(inst, notify.Waiting, "Waiting for input")
+			m.playAlert(inst, session.AlertWaiting)
+		}
+		if prevActivity == session.ActivityBusy && m.activityState[inst.ID] == session.ActivityIdle {
+			m.routeNotification(inst, notify.Finished, "Finished")
+			m.playAlert(inst, session.AlertFinished)
 		}
+
+		// Per-window activity for status line coloring
+		m.windowActivityState[inst.ID] = poll.windows
+		m.setPendingQuestion(inst, poll.question)
+		m.trackPermission(inst)
+		m.autoApprove(inst)
+	} else {
+		m.isActive[inst.ID] = false
+		m.activityState[inst.ID] = session.ActivityIdle
+		m.windowActivityState[inst.ID] = nil
+		delete(m.pendingQuestions, inst.ID)
+	}
+	m.trackIdle(inst)
+}
+
+// applyPreview shows a capture of the selected session in the preview pane
+func (m *Model) applyPreview(inst *session.Instance, preview string, err error) {
+	if err != nil {
+		m.preview = "(error loading preview)"
+	} else if inst.Status != session.StatusRunning && inst.LastOutput != "" {
+		// Stopped - show the snapshot taken at stop time
+		header := fmt.Sprintf("── last output before stop (%s) ──", inst.StoppedAt.Format("2006-01-02 15:04"))
+		m.preview = dimStyle.Render(header) + "\n" + m.filterPreview(inst, inst.LastOutput)
+	} else {
+		m.preview = m.filterPreview(inst, preview)
 	}
-	return m, tickCmd()
 }
 
 // calculatePreviewWidth returns the width for the preview panel
@@ -902,7 +1749,7 @@ func (m *Model) buildVisibleItems() {
 		if m.searchActive && !m.matchesSearch(inst) {
 			continue
 		}
-		if inst.GroupID == "" {
+		if inst.GroupID == "" || m.globalList {
 			ungroupedSessions = append(ungroupedSessions, inst)
 		} else {
 			groupedSessions[inst.GroupID] = append(groupedSessions[inst.GroupID], inst)
@@ -920,22 +1767,12 @@ func (m *Model) buildVisibleItems() {
 			group:   group,
 		})
 		if !group.Collapsed {
-			for _, inst := range groupedSessions[group.ID] {
-				m.visibleItems = append(m.visibleItems, visibleItem{
-					isGroup:  false,
-					instance: inst,
-				})
-			}
+			m.appendSessionItems(groupedSessions[group.ID])
 		}
 	}
 
 	// Add ungrouped sessions
-	for _, inst := range ungroupedSessions {
-		m.visibleItems = append(m.visibleItems, visibleItem{
-			isGroup:  false,
-			instance: inst,
-		})
-	}
+	m.appendSessionItems(ungroupedSessions)
 }
 
 // hasFavorites returns true if there are any favorite sessions
@@ -951,12 +1788,17 @@ func (m *Model) hasFavorites() bool {
 // getSelectedInstance returns the currently selected instance, or nil if a group is selected
 // Works in both grouped and non-grouped modes
 func (m *Model) getSelectedInstance() *session.Instance {
-	if len(m.groups) > 0 || m.hasFavorites() {
+	return m.instanceAt(m.cursor)
+}
+
+// instanceAt returns the session at a list row, or nil for group rows
+func (m *Model) instanceAt(index int) *session.Instance {
+	if m.usesVisibleItems() {
 		m.buildVisibleItems()
-		if m.cursor < 0 || m.cursor >= len(m.visibleItems) {
+		if index < 0 || index >= len(m.visibleItems) {
 			return nil
 		}
-		item := m.visibleItems[m.cursor]
+		item := m.visibleItems[index]
 		if item.isGroup || item.instance == nil {
 			return nil
 		}
@@ -965,15 +1807,15 @@ func (m *Model) getSelectedInstance() *session.Instance {
 	// Non-grouped mode - use filtered list when search is active
 	if m.searchActive {
 		filtered := m.getFilteredInstances()
-		if m.cursor < 0 || m.cursor >= len(filtered) {
+		if index < 0 || index >= len(filtered) {
 			return nil
 		}
-		return filtered[m.cursor]
+		return filtered[index]
 	}
-	if m.cursor < 0 || m.cursor >= len(m.instances) {
+	if index < 0 || index >= len(m.instances) {
 		return nil
 	}
-	return m.instances[m.cursor]
+	return m.instances[index]
 }
 
 // getSelectedGroup returns the currently selected group, or nil if a session is selected
@@ -1018,6 +1860,17 @@ func (m *Model) getCurrentGroupID() string {
 	return ""
 }
 
+// openNewSession starts the new session flow: agent selection -> path -> name
+func (m *Model) openNewSession() {
+	m.agentCursor = m.defaultAgentIndex()
+	m.pendingAgent = agentTypes[m.agentCursor]
+	m.pendingProfile = ""
+	m.pendingModel = ""
+	m.pendingPreset = nil
+	m.pendingGroupID = m.getCurrentGroupID()
+	m.state = stateSelectAgent
+}
+
 // isLastInGroup checks if the session at the given visibleItems index is the last one in its group
 func (m *Model) isLastInGroup(index int) bool {
 	if index < 0 || index >= len(m.visibleItems) {
@@ -1073,6 +1926,7 @@ func (m *Model) switchToProject(project *session.Project) error {
 
 	// Release current lock before switching
 	m.storage.UnlockProject()
+	m.storage.LeaveGlobal()
 
 	// Switch storage to new project
 	if err := m.storage.SetActiveProject(projectID); err != nil {
@@ -1084,7 +1938,8 @@ func (m *Model) switchToProject(project *session.Project) error {
 		return err
 	}
 
-	// Load the new project's sessions
+	// Load the new project's sessions (watched for changes of other processes from here on)
+	m.storage.WatchExternalChanges()
 	instances, groups, settings, err := m.storage.LoadAllWithSettings()
 	if err != nil {
 		return err
@@ -1100,6 +1955,9 @@ func (m *Model) switchToProject(project *session.Project) error {
 	m.splitView = settings.SplitView
 	m.markedSessionID = settings.MarkedSessionID
 	m.splitFocus = settings.SplitFocus
+	m.stuckMinutes = settings.StuckMinutes
+	m.autoColor = settings.AutoColor
+	m.nestForks = settings.NestForks
 	m.markedVisibleIndex = -1 // Will be found after buildVisibleItems
 
 	// Reset maps
@@ -1108,12 +1966,34 @@ func (m *Model) switchToProject(project *session.Project) error {
 	m.isActive = make(map[string]bool)
 	m.activityState = make(map[string]session.SessionActivity)
 	m.windowActivityState = make(map[string]map[int]session.SessionActivity)
+	m.pendingQuestions = make(map[string]*session.PendingQuestion)
+	m.seenPermissions = make(map[string]string)
+	m.stuckFor = make(map[string]time.Duration)
 
 	// Initialize status and last lines for all instances
+	var savedRunning []*session.Instance
 	for _, inst := range m.instances {
+		if inst.Status == session.StatusRunning {
+			savedRunning = append(savedRunning, inst)
+		}
 		inst.UpdateStatus()
 		m.lastLines[inst.ID] = inst.GetLastLine()
 	}
+	m.lostSessions = nil
+	m.checkLostOnLoad(savedRunning)
+
+	m.detectMissingPaths()
+
+	// Reopen the cross-project list if it was left on
+	m.globalList = false
+	if settings.GlobalList {
+		if err := m.setGlobalList(true); err != nil {
+			m.notifyWarn("Cross-project list unavailable: %v", err)
+		}
+	}
+	if settings.SelectedID != "" {
+		m.restoreSelection(selectionAnchor{keys: []string{settings.SelectedID}, cursor: settings.Cursor})
+	}
 
 	// Initialize preview
 	if len(m.instances) > 0 {
@@ -1124,7 +2004,7 @@ func (m *Model) switchToProject(project *session.Project) error {
 		if err != nil {
 			m.preview = "(error loading preview)"
 		} else {
-			m.preview = preview
+			m.preview = m.filterPreview(m.instances[m.cursor], preview)
 		}
 	} else {
 		m.preview = ""
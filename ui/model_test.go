@@ -0,0 +1,260 @@
+package ui
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/exp/teatest"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// flowTimeout caps how long a flow waits for the UI or the fake tmux server
+const flowTimeout = 5 * time.Second
+
+// testEnv runs the TUI against a fake tmux server, an empty config directory
+// and a stub claude binary, so real sessions and agents are never touched
+type testEnv struct {
+	home string
+	tmux *session.FakeTmux
+}
+
+// newTestEnv sets up the fake tmux server, HOME and PATH for a test
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	bin := filepath.Join(home, "bin")
+	if err := os.MkdirAll(bin, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(bin, "claude"), []byte("#!/bin/sh\nexit 0\n"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	fake := session.NewFakeTmux()
+	tmux := session.Tmux
+	session.Tmux = fake
+	t.Cleanup(func() { session.Tmux = tmux })
+	return &testEnv{home: home, tmux: fake}
+}
+
+// addSessions stores stopped Claude sessions in the default project before the TUI starts
+func (e *testEnv) addSessions(t *testing.T, names ...string) []*session.Instance {
+	t.Helper()
+	storage, err := session.NewStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var instances []*session.Instance
+	for _, name := range names {
+		dir := filepath.Join(e.home, "src", name)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatal(err)
+		}
+		inst, err := session.NewInstance(name, dir, false, session.AgentClaude)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := storage.AddInstance(inst); err != nil {
+			t.Fatal(err)
+		}
+		instances = append(instances, inst)
+	}
+	return instances
+}
+
+// start runs the TUI and opens the default project
+func (e *testEnv) start(t *testing.T) *teatest.TestModel {
+	t.Helper()
+	m, err := NewModel()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tm := teatest.NewTestModel(t, m, teatest.WithInitialTermSize(160, 50))
+	t.Cleanup(func() { tm.Quit() })
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter}) // Continue without project
+	return tm
+}
+
+// running reports whether the fake tmux server has a session for inst
+func (e *testEnv) running(inst *session.Instance) bool {
+	return e.tmux.Session(inst.TmuxSessionName()) != nil
+}
+
+// waitUntil polls cond until it holds or the flow times out
+func waitUntil(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(flowTimeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
+
+// waitForOutput waits until the rendered output contains text
+func waitForOutput(t *testing.T, tm *teatest.TestModel, text string) {
+	t.Helper()
+	teatest.WaitFor(t, tm.Output(), func(out []byte) bool {
+		return bytes.Contains(out, []byte(text))
+	}, teatest.WithDuration(flowTimeout))
+}
+
+// press sends single-character keys one at a time
+func press(tm *teatest.TestModel, keys ...string) {
+	for _, k := range keys {
+		tm.Send(runeKey(k))
+	}
+}
+
+// finalModel quits the TUI and returns its last state
+func finalModel(t *testing.T, tm *teatest.TestModel) Model {
+	t.Helper()
+	if err := tm.Quit(); err != nil {
+		t.Fatal(err)
+	}
+	return tm.FinalModel(t, teatest.WithFinalTimeout(flowTimeout)).(Model)
+}
+
+func TestNewSessionDialog(t *testing.T) {
+	env := newTestEnv(t)
+	dir := filepath.Join(env.home, "src", "webapp")
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	tm := env.start(t)
+
+	// Agent (Claude is the default), model (default), directory, name
+	press(tm, "n")
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	tm.Type(dir)
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	waitForOutput(t, tm, "Started 'webapp'")
+
+	m := finalModel(t, tm)
+	if len(m.instances) != 1 {
+		t.Fatalf("got %d sessions, want 1", len(m.instances))
+	}
+	inst := m.instances[0]
+	if inst.Name != "webapp" || inst.Path != dir || inst.Agent != session.AgentClaude {
+		t.Errorf("created %q in %s with %s, want webapp in %s with claude", inst.Name, inst.Path, inst.Agent, dir)
+	}
+	if !env.running(inst) {
+		t.Error("new session is not running on the tmux server")
+	}
+}
+
+func TestNewSessionDialogCancel(t *testing.T) {
+	env := newTestEnv(t)
+	tm := env.start(t)
+
+	press(tm, "n")
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	tm.Send(tea.KeyMsg{Type: tea.KeyEnter})
+	tm.Type("/nowhere")
+	tm.Send(tea.KeyMsg{Type: tea.KeyEsc})
+
+	m := finalModel(t, tm)
+	if m.state != stateList {
+		t.Errorf("esc in the directory dialog left state %d, want the list", m.state)
+	}
+	if len(m.instances) != 0 {
+		t.Errorf("cancelled dialog created %d session(s)", len(m.instances))
+	}
+}
+
+func TestStartStopDelete(t *testing.T) {
+	env := newTestEnv(t)
+	inst := env.addSessions(t, "api")[0]
+	tm := env.start(t)
+
+	press(tm, "s")
+	waitForOutput(t, tm, "Started 'api'")
+	waitUntil(t, "the session to start", func() bool { return env.running(inst) })
+
+	press(tm, "x", "y")
+	waitUntil(t, "the session to stop", func() bool { return !env.running(inst) })
+
+	// The first start's toast may still be shown, so wait for the server
+	press(tm, "s")
+	waitUntil(t, "the session to start again", func() bool { return env.running(inst) })
+
+	press(tm, "d", "y")
+	waitUntil(t, "the session to be killed", func() bool { return !env.running(inst) })
+
+	m := finalModel(t, tm)
+	if len(m.instances) != 0 {
+		t.Errorf("got %d sessions after delete, want 0", len(m.instances))
+	}
+	storage, err := session.NewStorage()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stored, err := storage.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(stored) != 0 {
+		t.Errorf("deleted session is still stored")
+	}
+}
+
+func TestMultiSelectBatchStart(t *testing.T) {
+	env := newTestEnv(t)
+	instances := env.addSessions(t, "alpha", "beta", "gamma")
+	tm := env.start(t)
+
+	// Check alpha and beta (space moves down), then start both
+	press(tm, " ", " ", "s")
+	waitForOutput(t, tm, "Start 2 session(s)?")
+	press(tm, "y")
+	waitUntil(t, "the selected sessions to start", func() bool {
+		return env.running(instances[0]) && env.running(instances[1])
+	})
+
+	m := finalModel(t, tm)
+	if env.running(instances[2]) {
+		t.Error("unselected session was started")
+	}
+	if m.hasMultiSelect() {
+		t.Error("selection kept after the batch action")
+	}
+}
+
+func TestGlobalSearch(t *testing.T) {
+	env := newTestEnv(t)
+	inst := env.addSessions(t, "billing")[0]
+
+	// A Claude conversation in the session's directory
+	projects := filepath.Join(env.home, ".claude", "projects", strings.ReplaceAll(inst.Path, "/", "-"))
+	if err := os.MkdirAll(projects, 0755); err != nil {
+		t.Fatal(err)
+	}
+	line := `{"type":"user","sessionId":"c0ffee","cwd":"` + inst.Path + `","timestamp":"2026-01-02T10:00:00Z","message":{"role":"user","content":"retry the payment webhook on timeout"}}` + "\n"
+	if err := os.WriteFile(filepath.Join(projects, "c0ffee.jsonl"), []byte(line), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tm := env.start(t)
+
+	tm.Send(tea.KeyMsg{Type: tea.KeyCtrlF})
+	tm.Type("webhook")
+	waitForOutput(t, tm, "payment")
+
+	m := finalModel(t, tm)
+	if m.state != stateGlobalSearch {
+		t.Fatalf("state %d, want global search", m.state)
+	}
+	if len(m.globalSearchResults) != 1 || m.globalSearchResults[0].SessionID != "c0ffee" {
+		t.Errorf("got %d result(s), want the c0ffee conversation", len(m.globalSearchResults))
+	}
+}
@@ -0,0 +1,198 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// modelOptions returns the rows of the model picker: the default, the
+// agent's models, then a row to type another one ("" = default row)
+func modelOptions(agent session.AgentType) []string {
+	return append(append([]string{""}, session.AgentConfigs[agent].Models...), "…")
+}
+
+// openModelPicker opens the model picker for the active tab of the selected session (M)
+func (m *Model) openModelPicker() tea.Cmd {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return nil
+	}
+	window := activeWindowIndex(inst)
+	if window != 0 && inst.GetFollowedWindow(window) == nil {
+		m.notifyWarn("This window is not tracked as a tab")
+		return nil
+	}
+	agent := inst.WindowAgent(window)
+	if session.AgentConfigs[agent].ModelFlag == "" {
+		m.notifyWarn("%s has no model flag - pass its options with C", agentLabel(agent, ""))
+		return nil
+	}
+	m.modelTarget = inst
+	m.modelWindow = window
+	return m.showModelPicker(agent, inst.WindowModel(window))
+}
+
+// showModelPicker opens the picker on the current model of agent
+func (m *Model) showModelPicker(agent session.AgentType, current string) tea.Cmd {
+	m.modelAgent = agent
+	m.modelCursor = 0
+	m.modelTyping = false
+	m.modelInput.SetValue("")
+	options := modelOptions(agent)
+	if current != "" {
+		m.modelCursor = len(options) - 1 // Typed before - show it in the input
+		for idx, model := range options {
+			if model == current {
+				m.modelCursor = idx
+			}
+		}
+		if m.modelCursor == len(options)-1 {
+			m.modelInput.SetValue(current)
+		}
+	}
+	m.state = stateModelPicker
+	return nil
+}
+
+// pickModel applies the chosen model: to the new session being created, or
+// to the window of an existing one, restarting it on its conversation
+func (m Model) pickModel(model string) (tea.Model, tea.Cmd) {
+	m.modelTyping = false
+	m.modelInput.Blur()
+	inst := m.modelTarget
+	if inst == nil {
+		m.pendingModel = model
+		m.pathInput.SetValue("")
+		m.pathInput.Focus()
+		m.state = stateNewPath
+		return m, textinput.Blink
+	}
+
+	m.modelTarget = nil
+	m.state = stateList
+	if model == inst.WindowModel(m.modelWindow) {
+		return m, nil
+	}
+	inst.SetWindowModel(m.modelWindow, model)
+	m.storage.UpdateInstance(inst)
+	label := model
+	if label == "" {
+		label = "the default model"
+	}
+	if inst.Status != session.StatusRunning {
+		m.notifySuccess("'%s' starts with %s next time", inst.Name, label)
+		return m, nil
+	}
+	if err := inst.RestartWindow(m.modelWindow); err != nil {
+		m.notify(toastError, "Failed to restart with %s: %v", label, err)
+		return m, nil
+	}
+	m.notifySuccess("Restarted '%s' with %s", inst.Name, label)
+	return m, nil
+}
+
+// handleModelPickerKeys handles keyboard input in the model picker
+func (m Model) handleModelPickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	options := modelOptions(m.modelAgent)
+	if m.modelTyping {
+		switch msg.String() {
+		case "esc":
+			m.modelTyping = false
+			m.modelInput.Blur()
+			return m, nil
+		case "enter":
+			model := strings.TrimSpace(m.modelInput.Value())
+			if model == "" || strings.ContainsAny(model, " \t") {
+				m.notifyWarn("Type a model name without spaces")
+				return m, nil
+			}
+			return m.pickModel(model)
+		}
+		var cmd tea.Cmd
+		m.modelInput, cmd = m.modelInput.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "esc", "q":
+		m.modelInput.Blur()
+		if m.modelTarget == nil {
+			m.state = stateSelectAgent // Back in the new session flow
+			return m, nil
+		}
+		m.modelTarget = nil
+		m.state = stateList
+	case "up", "k":
+		if m.modelCursor > 0 {
+			m.modelCursor--
+		}
+	case "down", "j":
+		if m.modelCursor < len(options)-1 {
+			m.modelCursor++
+		}
+	case "enter":
+		if m.modelCursor == len(options)-1 {
+			m.modelTyping = true
+			m.modelInput.CursorEnd()
+			m.modelInput.Focus()
+			return m, textinput.Blink
+		}
+		return m.pickModel(options[m.modelCursor])
+	}
+	return m, nil
+}
+
+// modelPickerView renders the model picker
+func (m Model) modelPickerView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	if inst := m.modelTarget; inst != nil {
+		target := inst.Name
+		if fw := inst.GetFollowedWindow(m.modelWindow); m.modelWindow != 0 && fw != nil {
+			target += " / " + fw.Name
+		}
+		boxContent.WriteString(fmt.Sprintf("  Model of '%s':\n\n", truncateRunes(target, 36)))
+	} else {
+		boxContent.WriteString(fmt.Sprintf("  Model for the new %s session:\n\n", agentLabel(m.modelAgent, "")))
+	}
+
+	options := modelOptions(m.modelAgent)
+	for idx, model := range options {
+		prefix := "    "
+		if idx == m.modelCursor {
+			prefix = "  ❯ "
+		}
+		switch {
+		case model == "":
+			boxContent.WriteString(prefix + "Default" + dimStyle.Render(" (agent or profile setting)"))
+		case idx == len(options)-1:
+			if m.modelTyping || m.modelInput.Value() != "" {
+				boxContent.WriteString(prefix + "Other: " + m.modelInput.View())
+			} else {
+				boxContent.WriteString(prefix + "Other…")
+			}
+		default:
+			boxContent.WriteString(prefix + model)
+		}
+		boxContent.WriteString("\n")
+	}
+
+	boxContent.WriteString("\n")
+	if m.modelTarget != nil && m.modelTarget.Status == session.StatusRunning {
+		boxContent.WriteString(dimStyle.Render("  The window restarts on its conversation with the new model"))
+		boxContent.WriteString("\n\n")
+	}
+	help := "  enter: select  esc: cancel"
+	if m.modelTyping {
+		help = "  enter: use this model  esc: back to the list"
+	}
+	boxContent.WriteString(helpStyle.Render(help))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Model ", boxContent.String(), 60, ColorAccent)
+}
@@ -0,0 +1,348 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// batchAction is an action applied to all multi-selected sessions
+type batchAction int
+
+const (
+	batchStart batchAction = iota
+	batchStop
+	batchDelete
+	batchMove
+)
+
+// batchVerbs are the dialog titles of the batch actions
+var batchVerbs = map[batchAction]string{
+	batchStart:  "Start",
+	batchStop:   "Stop",
+	batchDelete: "Delete",
+	batchMove:   "Move",
+}
+
+// batchResults describe a finished batch action in toasts
+var batchResults = map[batchAction]string{
+	batchStart:  "Starting",
+	batchStop:   "Stopped",
+	batchDelete: "Deleted",
+	batchMove:   "Moved",
+}
+
+// batchActions are the list actions that act on the selection while sessions are selected
+var batchActions = map[string]batchAction{"start": batchStart, "stop": batchStop, "delete": batchDelete, "assign_group": batchMove}
+
+// toggleMultiSelect checks or unchecks the session under the cursor and moves down
+func (m *Model) toggleMultiSelect() {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return
+	}
+	if m.multiSelected == nil {
+		m.multiSelected = make(map[string]bool)
+	}
+	if m.multiSelected[inst.ID] {
+		delete(m.multiSelected, inst.ID)
+	} else {
+		m.multiSelected[inst.ID] = true
+	}
+	if m.cursor < m.listLen()-1 {
+		m.cursor++
+		m.resetScroll()
+	}
+}
+
+// toggleVisualSelect starts a range selection at the cursor, or checks the
+// sessions of the range when one is in progress
+func (m *Model) toggleVisualSelect() {
+	if !m.visualActive {
+		m.visualActive = true
+		m.visualAnchor = m.cursor
+		return
+	}
+	if m.multiSelected == nil {
+		m.multiSelected = make(map[string]bool)
+	}
+	for _, inst := range m.visualRange() {
+		m.multiSelected[inst.ID] = true
+	}
+	m.visualActive = false
+}
+
+// clearMultiSelect drops the checked sessions and any range in progress
+func (m *Model) clearMultiSelect() {
+	m.multiSelected = nil
+	m.visualActive = false
+}
+
+// hasMultiSelect reports whether sessions are checked or a range is in progress
+func (m Model) hasMultiSelect() bool {
+	return len(m.multiSelected) > 0 || m.visualActive
+}
+
+// inVisualRange reports whether a list row is inside the range in progress
+func (m Model) inVisualRange(index int) bool {
+	if !m.visualActive {
+		return false
+	}
+	lo, hi := m.visualAnchor, m.cursor
+	if lo > hi {
+		lo, hi = hi, lo
+	}
+	return index >= lo && index <= hi
+}
+
+// isMultiSelected reports whether a session row is checked or inside the range
+func (m Model) isMultiSelected(inst *session.Instance, index int) bool {
+	return m.multiSelected[inst.ID] || m.inVisualRange(index)
+}
+
+// listLen returns the number of rows in the session list
+func (m *Model) listLen() int {
+	if m.usesVisibleItems() {
+		m.buildVisibleItems()
+		return len(m.visibleItems)
+	}
+	if m.searchActive {
+		return len(m.getFilteredInstances())
+	}
+	return len(m.instances)
+}
+
+// visualRange returns the sessions inside the range in progress
+func (m *Model) visualRange() []*session.Instance {
+	var sessions []*session.Instance
+	for i := 0; i < m.listLen(); i++ {
+		if inst := m.instanceAt(i); inst != nil && m.inVisualRange(i) {
+			sessions = append(sessions, inst)
+		}
+	}
+	return sessions
+}
+
+// multiSelectedInstances returns the checked sessions and those in the range, in list order
+func (m *Model) multiSelectedInstances() []*session.Instance {
+	inRange := make(map[string]bool)
+	for _, inst := range m.visualRange() {
+		inRange[inst.ID] = true
+	}
+	var sessions []*session.Instance
+	for _, inst := range m.instances {
+		if m.multiSelected[inst.ID] || inRange[inst.ID] {
+			sessions = append(sessions, inst)
+		}
+	}
+	return sessions
+}
+
+// batchTargets splits the selected sessions into those the action applies to
+// and those it skips, with the reason for skipping
+func (m *Model) batchTargets(action batchAction) (targets []*session.Instance, skipped map[string]string) {
+	skipped = make(map[string]string)
+	for _, inst := range m.multiSelectedInstances() {
+		switch {
+		case m.isBusy(inst):
+			skipped[inst.ID] = "busy"
+		case action == batchStart && inst.Status == session.StatusRunning:
+			skipped[inst.ID] = "already running"
+		case action == batchStop && inst.Status != session.StatusRunning:
+			skipped[inst.ID] = "not running"
+		case action == batchDelete && inst.Protected:
+			skipped[inst.ID] = "protected"
+		default:
+			targets = append(targets, inst)
+		}
+	}
+	return targets, skipped
+}
+
+// openBatchAction asks for confirmation of a batch action (move picks the group first)
+func (m *Model) openBatchAction(action batchAction) {
+	if m.globalList && action == batchMove {
+		m.notifyWarn("Groups are per project - leave the all projects list (%s a) first", m.keys.help("leader"))
+		return
+	}
+	if action == batchStart && m.dryRun {
+		m.notifyWarn("Dry-run: start sessions one by one to review their commands")
+		return
+	}
+	m.batchAction = action
+	if action == batchMove {
+		m.groupCursor = 0
+		m.batchMoving = true
+		m.state = stateSelectGroup
+		return
+	}
+	m.state = stateBatchConfirm
+}
+
+// runBatchAction applies the confirmed batch action and clears the selection
+func (m *Model) runBatchAction() tea.Cmd {
+	targets, skipped := m.batchTargets(m.batchAction)
+	anchor := m.rememberSelection()
+	var cmds []tea.Cmd
+	done, failed := 0, 0
+
+	switch m.batchAction {
+	case batchStart:
+		var starts []pendingStart
+		for _, inst := range targets {
+			if err := session.CheckAgentCommand(inst); err != nil || inst.PathMissing() {
+				failed++
+				continue
+			}
+			starts = append(starts, pendingStart{inst: inst})
+		}
+		if m.checkRunningLimit(starts) {
+			// The limit dialog takes over; the selection stays for another try
+			return nil
+		}
+		for _, p := range starts {
+			cmds = append(cmds, m.runStart(p.inst, "", false))
+			done++
+		}
+
+	case batchStop:
+		summarize := session.LoadSummaryConfig().OnStop
+		for _, inst := range targets {
+			if summarize {
+				cmds = append(cmds, m.summarizeSession(inst, inst.SummaryInput()))
+			}
+			if err := inst.Stop(); err != nil {
+				failed++
+				continue
+			}
+			m.storage.UpdateInstance(inst)
+			m.storage.PushClosed(inst, false)
+			done++
+		}
+
+	case batchDelete:
+		for _, inst := range targets {
+			// Archive first so the sessions can be reopened with u
+			m.storage.PushClosed(inst, true)
+			if err := m.storage.RemoveInstance(inst.ID); err != nil {
+				failed++
+				continue
+			}
+			if err := inst.RemoveWorktree(); err != nil {
+				m.notifyWarn("%v", err)
+			}
+			done++
+		}
+		if instances, err := m.storage.Load(); err == nil {
+			m.instances = instances
+		}
+
+	case batchMove:
+		for _, inst := range targets {
+			inst.GroupID = m.batchGroupID
+			m.storage.UpdateInstance(inst)
+			done++
+		}
+	}
+
+	m.clearMultiSelect()
+	m.restoreSelection(anchor)
+	m.state = stateList
+
+	result := batchResults[m.batchAction]
+	switch {
+	case failed > 0:
+		m.notifyWarn("%s %d session(s), %d failed, %d skipped", result, done, failed, len(skipped))
+	case len(skipped) > 0:
+		m.notifyInfo("%s %d session(s), %d skipped", result, done, len(skipped))
+	default:
+		m.notifySuccess("%s %d session(s)", result, done)
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleBatchConfirmKeys handles keyboard input in the batch confirmation dialog
+func (m Model) handleBatchConfirmKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.answer(msg) {
+	case answerYes, "enter":
+		if targets, _ := m.batchTargets(m.batchAction); len(targets) == 0 {
+			m.state = stateList
+			return m, nil
+		}
+		return m, m.runBatchAction()
+	case answerNo, "esc":
+		m.state = stateList
+	}
+	return m, nil
+}
+
+// batchGroupName returns the name of the batch move's target group
+func (m Model) batchGroupName() string {
+	for _, g := range m.groups {
+		if g.ID == m.batchGroupID {
+			return g.Name
+		}
+	}
+	return "No Group"
+}
+
+// batchConfirmView renders the batch confirmation dialog as an overlay
+func (m Model) batchConfirmView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 64
+	if m.width > 0 && m.width-4 < boxWidth {
+		boxWidth = m.width - 4
+	}
+
+	targets, skipped := m.batchTargets(m.batchAction)
+	verb := batchVerbs[m.batchAction]
+	question := fmt.Sprintf("  %s %d session(s)?", verb, len(targets))
+	if m.batchAction == batchMove {
+		question = fmt.Sprintf("  Move %d session(s) to '%s'?", len(targets), m.batchGroupName())
+	}
+	boxContent.WriteString(question)
+	boxContent.WriteString("\n\n")
+
+	// Affected sessions first, then the skipped ones
+	const maxRows = 12
+	rows := 0
+	for _, inst := range m.multiSelectedInstances() {
+		if rows == maxRows {
+			boxContent.WriteString(dimStyle.Render(fmt.Sprintf("    … %d more", len(targets)+len(skipped)-maxRows)))
+			boxContent.WriteString("\n")
+			break
+		}
+		name := truncateRunes(inst.Name, boxWidth-26)
+		if reason, ok := skipped[inst.ID]; ok {
+			boxContent.WriteString(dimStyle.Render(fmt.Sprintf("    – %s (skipped: %s)", name, reason)))
+		} else {
+			boxContent.WriteString(fmt.Sprintf("    • %s", name))
+		}
+		boxContent.WriteString("\n")
+		rows++
+	}
+
+	if len(targets) == 0 {
+		boxContent.WriteString("\n")
+		boxContent.WriteString(dimStyle.Render("  Nothing to do"))
+		boxContent.WriteString("\n")
+	} else if m.batchAction == batchDelete {
+		boxContent.WriteString("\n")
+		boxContent.WriteString(dimStyle.Render("  Deleted sessions can be reopened with u"))
+		boxContent.WriteString("\n")
+	}
+
+	boxContent.WriteString("\n")
+	boxContent.WriteString(helpStyle.Render(fmt.Sprintf("  %s: confirm  %s/esc: cancel", m.keys.Confirm.Help().Key, m.keys.Cancel.Help().Key)))
+	boxContent.WriteString("\n")
+
+	color := ColorAccent
+	if m.batchAction == batchDelete || m.batchAction == batchStop {
+		color = ColorRed
+	}
+	return m.renderOverlayDialog(" "+verb+" Sessions ", boxContent.String(), boxWidth, color)
+}
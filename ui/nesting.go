@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"github.com/izll/agent-session-manager/session"
+)
+
+// usesVisibleItems reports whether list rows come from visibleItems (groups,
+// favorites or nested sessions) instead of indexing m.instances directly
+func (m *Model) usesVisibleItems() bool {
+	return len(m.groups) > 0 || m.hasFavorites() || m.hasNestedSessions()
+}
+
+// hasNestedSessions returns true if nesting is on and a session's origin is still in the list
+func (m *Model) hasNestedSessions() bool {
+	if !m.nestForks {
+		return false
+	}
+	ids := make(map[string]bool, len(m.instances))
+	for _, inst := range m.instances {
+		ids[inst.ID] = true
+	}
+	for _, inst := range m.instances {
+		if inst.ParentID != "" && inst.ParentID != inst.ID && ids[inst.ParentID] {
+			return true
+		}
+	}
+	return false
+}
+
+// appendSessionItems adds the rows of a group's (or the ungrouped) sessions,
+// with forks and parallel sessions under their origin when nesting is on.
+// A session whose origin is elsewhere (deleted, another group) is a top level row.
+func (m *Model) appendSessionItems(insts []*session.Instance) {
+	if !m.nestForks {
+		for _, inst := range insts {
+			m.visibleItems = append(m.visibleItems, visibleItem{instance: inst})
+		}
+		return
+	}
+
+	inList := make(map[string]bool, len(insts))
+	for _, inst := range insts {
+		inList[inst.ID] = true
+	}
+	children := make(map[string][]*session.Instance)
+	var roots []*session.Instance
+	for _, inst := range insts {
+		if inst.ParentID != "" && inst.ParentID != inst.ID && inList[inst.ParentID] {
+			children[inst.ParentID] = append(children[inst.ParentID], inst)
+		} else {
+			roots = append(roots, inst)
+		}
+	}
+
+	added := make(map[string]bool, len(insts))
+	var add func(inst *session.Instance, depth int)
+	add = func(inst *session.Instance, depth int) {
+		added[inst.ID] = true
+		item := visibleItem{instance: inst, depth: depth}
+		// Search results show matching children even under a collapsed origin
+		collapsed := inst.ChildrenCollapsed && !m.searchActive
+		if collapsed {
+			item.hidden = hideNested(inst, children, added)
+		}
+		m.visibleItems = append(m.visibleItems, item)
+		if collapsed {
+			return
+		}
+		for _, child := range children[inst.ID] {
+			if !added[child.ID] {
+				add(child, depth+1)
+			}
+		}
+	}
+	for _, inst := range roots {
+		add(inst, 0)
+	}
+	// Origins pointing at each other leave sessions without a root
+	for _, inst := range insts {
+		if !added[inst.ID] {
+			add(inst, 0)
+		}
+	}
+}
+
+// hideNested marks the sessions nested under a collapsed session (at any
+// depth) as placed and returns how many there are
+func hideNested(inst *session.Instance, children map[string][]*session.Instance, added map[string]bool) int {
+	count := 0
+	stack := append([]*session.Instance(nil), children[inst.ID]...)
+	for len(stack) > 0 {
+		child := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if added[child.ID] {
+			continue
+		}
+		added[child.ID] = true
+		count++
+		stack = append(stack, children[child.ID]...)
+	}
+	return count
+}
+
+// toggleNestForks switches between nesting forks and parallel sessions under
+// their origin and listing them as siblings
+func (m *Model) toggleNestForks() {
+	anchor := m.rememberSelection()
+	m.nestForks = !m.nestForks
+	m.restoreSelection(anchor)
+	m.saveSettings()
+	if m.nestForks {
+		m.notifyInfo("Forks and parallel sessions are nested under their origin (←/→ collapse)")
+	} else {
+		m.notifyInfo("Forks and parallel sessions are listed as siblings")
+	}
+}
+
+// toggleNestedChildren collapses (expand = false) or expands the sessions nested
+// under the selected row. Returns false when the row has nothing to toggle.
+func (m *Model) toggleNestedChildren(expand bool) bool {
+	if !m.nestForks {
+		return false
+	}
+	m.buildVisibleItems()
+	if m.cursor < 0 || m.cursor >= len(m.visibleItems) {
+		return false
+	}
+	item := m.visibleItems[m.cursor]
+	if item.isGroup || item.instance == nil {
+		return false
+	}
+	inst := item.instance
+	hasVisibleChildren := m.cursor+1 < len(m.visibleItems) && m.visibleItems[m.cursor+1].depth > item.depth
+	switch {
+	case expand && item.hidden > 0:
+		inst.ChildrenCollapsed = false
+	case !expand && hasVisibleChildren && !m.searchActive:
+		inst.ChildrenCollapsed = true
+	case !expand && item.depth > 0:
+		// Nested row without children of its own: jump to its origin
+		for i := m.cursor - 1; i >= 0; i-- {
+			if m.visibleItems[i].depth < item.depth {
+				m.cursor = i
+				m.resetScroll()
+				return true
+			}
+		}
+		return false
+	default:
+		return false
+	}
+	m.storage.UpdateInstance(inst)
+	m.buildVisibleItems()
+	return true
+}
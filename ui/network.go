@@ -0,0 +1,55 @@
+package ui
+
+import "github.com/izll/agent-session-manager/session"
+
+// sampleNetwork refreshes the network state of busy sessions when
+// network_indicator is on in config.toml (Linux only)
+func (m *Model) sampleNetwork() {
+	if m.config == nil || !m.config.NetworkIndicator || !session.NetworkSupported() {
+		return
+	}
+	var busy []*session.Instance
+	for _, inst := range m.instances {
+		if inst.Status == session.StatusRunning && m.activityState[inst.ID] == session.ActivityBusy {
+			busy = append(busy, inst)
+		}
+	}
+	if len(busy) == 0 {
+		// Nothing to watch - start over so old counters don't count as traffic later
+		m.netStates = nil
+		m.netSampler = session.NewNetworkSampler()
+		return
+	}
+	states, err := m.netSampler.Sample(busy)
+	if err != nil {
+		m.netStates = nil
+		return
+	}
+	m.netStates = states
+}
+
+// onAPI reports whether a busy session's agent is moving data over a remote connection
+func (m Model) onAPI(inst *session.Instance) bool {
+	return m.netLabel(inst) == netWaitingAPI
+}
+
+// Labels of netLabel
+const (
+	netWaitingAPI = "waiting on the API"
+	netLocal      = "working locally"
+)
+
+// netLabel tells what a busy session is waiting on ("" when not busy or not sampled)
+func (m Model) netLabel(inst *session.Instance) string {
+	if inst.Status != session.StatusRunning || m.activityState[inst.ID] != session.ActivityBusy {
+		return ""
+	}
+	state, ok := m.netStates[inst.ID]
+	switch {
+	case !ok:
+		return ""
+	case state == session.NetActive:
+		return netWaitingAPI
+	}
+	return netLocal
+}
@@ -0,0 +1,119 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/izll/agent-session-manager/session"
+	"github.com/izll/agent-session-manager/session/notify"
+)
+
+// notifyTarget describes a session for the notification rules
+func (m *Model) notifyTarget(inst *session.Instance) notify.Target {
+	target := notify.Target{
+		Session: inst.Name,
+		Project: "default",
+		Agent:   string(inst.Agent),
+		Tags:    inst.Tags(),
+	}
+	if name := m.sessionProjectName(inst); name != "" {
+		target.Project = name
+	}
+	if idx := m.findGroupIndex(inst.GroupID); idx >= 0 {
+		target.Group = m.groups[idx].Name
+	}
+	if target.Agent == "" {
+		target.Agent = string(session.AgentClaude)
+	}
+	return target
+}
+
+// routeNotification sends a session event to the channels its notification rules select
+func (m *Model) routeNotification(inst *session.Instance, event notify.Event, body string) {
+	target := m.notifyTarget(inst)
+	for _, channel := range m.notifyConfig.Route(target, event) {
+		switch channel {
+		case notify.Desktop:
+			m.terminalNotify(inst.Name, body)
+		case notify.System:
+			if err := notify.SendSystem(inst.Name, body); err != nil {
+				select {
+				case m.notifyErrs <- err:
+				default: // Already reporting a failure
+				}
+			}
+		case notify.Slack:
+			text := fmt.Sprintf("*%s* (%s): %s", inst.Name, target.Project, body)
+			config, errs := m.notifyConfig, m.notifyErrs
+			go func() {
+				if err := config.SendSlack(text); err != nil {
+					select {
+					case errs <- err:
+					default: // Already reporting a failure
+					}
+				}
+			}()
+		}
+	}
+}
+
+// reportNotifyErrors shows failures of background notification channels
+func (m *Model) reportNotifyErrors() {
+	select {
+	case err := <-m.notifyErrs:
+		m.notifyWarn("Notification failed: %v", err)
+	default:
+	}
+}
+
+// mainPane is the state of a running session's main pane
+type mainPane struct {
+	dead  bool // The agent exited and the pane was kept (remain-on-exit)
+	code  int  // Exit status of a dead pane
+	known bool // Whether code was read
+}
+
+// readMainPane reads whether a running session's main pane is dead and its exit status
+func readMainPane(inst *session.Instance) mainPane {
+	pane := mainPane{dead: inst.MainPaneDead()}
+	if pane.dead {
+		pane.code, pane.known = inst.MainPaneExitStatus()
+	}
+	return pane
+}
+
+// checkExited notifies when a session's agent exits without being stopped: the
+// tmux session disappears or the main pane is left dead (remain-on-exit)
+func (m *Model) checkExited(inst *session.Instance, wasRunning bool) {
+	var pane mainPane
+	if inst.Status == session.StatusRunning {
+		pane = readMainPane(inst)
+	}
+	m.applyExited(inst, wasRunning, pane)
+}
+
+// applyExited records a session's main pane state and notifies when its agent exited
+func (m *Model) applyExited(inst *session.Instance, wasRunning bool, pane mainPane) {
+	if inst.Status != session.StatusRunning {
+		delete(m.paneDead, inst.ID)
+		delete(m.paneExit, inst.ID)
+		if wasRunning {
+			m.routeNotification(inst, notify.Died, "Session ended")
+			m.scheduleRestart(inst, 0, false)
+		}
+		return
+	}
+	if pane.dead {
+		m.paneExit[inst.ID] = pane.code
+		if !pane.known {
+			m.paneExit[inst.ID] = -1
+		}
+	} else {
+		delete(m.paneExit, inst.ID)
+	}
+	// The first check only records the state, so panes dead at startup stay quiet
+	if wasDead, seen := m.paneDead[inst.ID]; seen && pane.dead && !wasDead {
+		m.routeNotification(inst, notify.Died, "Agent process exited")
+		m.scheduleRestart(inst, pane.code, pane.known)
+	}
+	m.paneDead[inst.ID] = pane.dead
+}
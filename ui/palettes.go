@@ -0,0 +1,327 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// builtinColorOptions are the compiled-in colors; custom gradients are appended after them
+var builtinColorOptions = colorOptions
+
+// gradientKey returns the color value sessions and groups store for a custom gradient
+func gradientKey(name string) string {
+	return "gradient-" + name
+}
+
+// isBuiltinColor reports whether a color value is one of the compiled-in options
+func isBuiltinColor(value string) bool {
+	for _, c := range builtinColorOptions {
+		if c.Color == value {
+			return true
+		}
+	}
+	return false
+}
+
+// registerPalettes makes custom gradients available to the color picker and renderers
+func registerPalettes(palettes []session.Palette) {
+	for _, c := range colorOptions[len(builtinColorOptions):] {
+		delete(gradients, c.Color)
+	}
+	colorOptions = append([]ColorOption(nil), builtinColorOptions...)
+	for _, p := range palettes {
+		key := gradientKey(p.Name)
+		if isBuiltinColor(key) {
+			continue
+		}
+		gradients[key] = p.Stops
+		colorOptions = append(colorOptions, ColorOption{Name: key, Color: key})
+	}
+}
+
+// gradientBar renders a bar of blocks colored along the given stops
+func gradientBar(stops []string, width int) string {
+	if len(stops) == 0 {
+		return strings.Repeat(" ", width)
+	}
+	var b strings.Builder
+	for i := 0; i < width; i++ {
+		position := 0.5
+		if width > 1 {
+			position = float64(i) / float64(width-1)
+		}
+		color := interpolateColor(stops, position)
+		b.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Render("█"))
+	}
+	return b.String()
+}
+
+// savePalettes persists the custom gradients and refreshes the color options
+func (m *Model) savePalettes() error {
+	if err := session.SavePalettes(m.palettes); err != nil {
+		return err
+	}
+	registerPalettes(m.palettes)
+	if items := m.getMaxColorItems(); m.colorCursor >= items {
+		m.colorCursor = items - 1
+	}
+	return nil
+}
+
+// openGradientEdit opens the gradient form for a custom gradient (-1 = new)
+func (m *Model) openGradientEdit(idx int) {
+	m.paletteEditIdx = idx
+	m.paletteNameInput.SetValue("")
+	m.paletteStopsInput.SetValue("")
+	if idx >= 0 && idx < len(m.palettes) {
+		m.paletteNameInput.SetValue(m.palettes[idx].Name)
+		m.paletteStopsInput.SetValue(strings.Join(m.palettes[idx].Stops, " "))
+	}
+	m.paletteNameInput.Focus()
+	m.paletteStopsInput.Blur()
+	m.err = nil
+	m.state = stateGradientEdit
+}
+
+// handleGradientsKeys handles keyboard input in the custom gradient list
+func (m Model) handleGradientsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.state = stateColorPicker
+
+	case "up", "k":
+		if m.paletteCursor > 0 {
+			m.paletteCursor--
+		}
+
+	case "down", "j":
+		if m.paletteCursor < len(m.palettes)-1 {
+			m.paletteCursor++
+		}
+
+	case "n":
+		m.openGradientEdit(-1)
+
+	case "e", "enter":
+		if m.paletteCursor < len(m.palettes) {
+			m.openGradientEdit(m.paletteCursor)
+		}
+
+	case "d":
+		if m.paletteCursor >= len(m.palettes) {
+			return m, nil
+		}
+		name := m.palettes[m.paletteCursor].Name
+		m.palettes = append(m.palettes[:m.paletteCursor:m.paletteCursor], m.palettes[m.paletteCursor+1:]...)
+		if m.paletteCursor > 0 && m.paletteCursor >= len(m.palettes) {
+			m.paletteCursor--
+		}
+		if err := m.savePalettes(); err != nil {
+			m.notify(toastError, "Failed to save gradients: %v", err)
+			return m, nil
+		}
+		m.notifySuccess("Deleted gradient '%s'", name)
+
+	case "i":
+		m.paletteImportCursor = 0
+		m.state = stateGradientImport
+	}
+	return m, nil
+}
+
+// handleGradientEditKeys handles keyboard input in the gradient form
+func (m Model) handleGradientEditKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.err = nil
+		m.state = stateGradients
+		return m, nil
+
+	case "tab", "shift+tab", "up", "down":
+		if m.paletteNameInput.Focused() {
+			m.paletteNameInput.Blur()
+			m.paletteStopsInput.Focus()
+		} else {
+			m.paletteStopsInput.Blur()
+			m.paletteNameInput.Focus()
+		}
+		return m, nil
+
+	case "enter":
+		stops, err := session.ParseStops(m.paletteStopsInput.Value())
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		palette := session.Palette{Name: strings.ToLower(strings.TrimSpace(m.paletteNameInput.Value())), Stops: stops}
+		if err := session.ValidatePalette(palette); err != nil {
+			m.err = err
+			return m, nil
+		}
+		if isBuiltinColor(gradientKey(palette.Name)) {
+			m.err = fmt.Errorf("'%s' is a built-in gradient", palette.Name)
+			return m, nil
+		}
+		for i, p := range m.palettes {
+			if p.Name == palette.Name && i != m.paletteEditIdx {
+				m.err = fmt.Errorf("a gradient named '%s' already exists", palette.Name)
+				return m, nil
+			}
+		}
+
+		if m.paletteEditIdx >= 0 && m.paletteEditIdx < len(m.palettes) {
+			m.palettes[m.paletteEditIdx] = palette
+			m.paletteCursor = m.paletteEditIdx
+		} else {
+			m.palettes = append(m.palettes, palette)
+			m.paletteCursor = len(m.palettes) - 1
+		}
+		if err := m.savePalettes(); err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.err = nil
+		m.state = stateGradients
+		m.notifySuccess("Saved gradient '%s'", gradientKey(palette.Name))
+		return m, nil
+	}
+
+	m.err = nil
+	var cmd tea.Cmd
+	if m.paletteNameInput.Focused() {
+		m.paletteNameInput, cmd = m.paletteNameInput.Update(msg)
+	} else {
+		m.paletteStopsInput, cmd = m.paletteStopsInput.Update(msg)
+	}
+	return m, cmd
+}
+
+// handleGradientImportKeys handles keyboard input in the palette import list
+func (m Model) handleGradientImportKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.state = stateGradients
+
+	case "up", "k":
+		if m.paletteImportCursor > 0 {
+			m.paletteImportCursor--
+		}
+
+	case "down", "j":
+		if m.paletteImportCursor < len(session.PopularPalettes)-1 {
+			m.paletteImportCursor++
+		}
+
+	case "enter":
+		palette := session.PopularPalettes[m.paletteImportCursor]
+		palette.Stops = append([]string(nil), palette.Stops...)
+		m.paletteCursor = len(m.palettes)
+		for i, p := range m.palettes {
+			if p.Name == palette.Name {
+				m.paletteCursor = i
+				break
+			}
+		}
+		if m.paletteCursor < len(m.palettes) {
+			m.palettes[m.paletteCursor] = palette
+		} else {
+			m.palettes = append(m.palettes, palette)
+		}
+		if err := m.savePalettes(); err != nil {
+			m.notify(toastError, "Failed to save gradients: %v", err)
+			return m, nil
+		}
+		m.state = stateGradients
+		m.notifySuccess("Imported '%s' as %s", palette.Name, gradientKey(palette.Name))
+	}
+	return m, nil
+}
+
+// gradientsView renders the custom gradient list
+func (m Model) gradientsView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(" Custom Gradients "))
+	b.WriteString("\n\n")
+
+	if len(m.palettes) == 0 {
+		b.WriteString(dimStyle.Render("  No custom gradients yet - create one or import a palette"))
+		b.WriteString("\n")
+	}
+	for i, p := range m.palettes {
+		cursor := "   "
+		if i == m.paletteCursor {
+			cursor = " ❯ "
+		}
+		stops := truncateRunes(strings.Join(p.Stops, " "), max(10, m.width-44))
+		b.WriteString(fmt.Sprintf("%s%s %-16s %s\n", cursor, gradientBar(p.Stops, 16), truncateRunes(p.Name, 16), dimStyle.Render(stops)))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("  n: new  e/enter: edit  d: delete  i: import palette  esc: back"))
+	return b.String()
+}
+
+// gradientEditView renders the gradient form with a live preview
+func (m Model) gradientEditView() string {
+	var b strings.Builder
+	if m.paletteEditIdx >= 0 {
+		b.WriteString(titleStyle.Render(" Edit Gradient "))
+	} else {
+		b.WriteString(titleStyle.Render(" New Gradient "))
+	}
+	b.WriteString("\n\n")
+
+	b.WriteString("  Name:  " + m.paletteNameInput.View() + "\n")
+	b.WriteString("  Stops: " + m.paletteStopsInput.View() + "\n\n")
+
+	stops, err := session.ParseStops(m.paletteStopsInput.Value())
+	if err == nil && len(stops) > 0 {
+		b.WriteString("  " + gradientBar(stops, 40) + "\n")
+		name := strings.TrimSpace(m.paletteNameInput.Value())
+		if name == "" {
+			name = "session name"
+		}
+		b.WriteString("  " + applyGradientStops(name, stops, "", true) + "\n")
+	} else {
+		b.WriteString(dimStyle.Render("  Stop colors as hex, e.g. #FF512F #DD2476 #8E2DE2") + "\n")
+	}
+
+	if m.err != nil {
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555")).Bold(true)
+		b.WriteString("\n" + errStyle.Render(fmt.Sprintf("  ⚠ %v", m.err)) + "\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("  tab: switch field  enter: save  esc: cancel"))
+	return b.String()
+}
+
+// gradientImportView renders the list of importable palettes
+func (m Model) gradientImportView() string {
+	var b strings.Builder
+	b.WriteString(titleStyle.Render(" Import Palette "))
+	b.WriteString("\n\n")
+
+	for i, p := range session.PopularPalettes {
+		cursor := "   "
+		if i == m.paletteImportCursor {
+			cursor = " ❯ "
+		}
+		imported := ""
+		for _, own := range m.palettes {
+			if own.Name == p.Name {
+				imported = dimStyle.Render(" (imported)")
+				break
+			}
+		}
+		b.WriteString(fmt.Sprintf("%s%s %s%s\n", cursor, gradientBar(p.Stops, 24), p.Name, imported))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(helpStyle.Render("  enter: import  esc: back"))
+	return b.String()
+}
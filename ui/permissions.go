@@ -0,0 +1,155 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// trackPermission counts a permission prompt once when it shows up; it counts
+// again only after the prompt went away and came back
+func (m *Model) trackPermission(inst *session.Instance) {
+	q := m.pendingQuestions[inst.ID]
+	if q == nil || !q.IsPermission() {
+		delete(m.seenPermissions, inst.ID)
+		return
+	}
+	sig := fmt.Sprintf("%d\x00%s\x00%s", q.Window, q.Question, strings.Join(q.Context, "\n"))
+	if m.seenPermissions[inst.ID] == sig {
+		return
+	}
+	m.seenPermissions[inst.ID] = sig
+	inst.RecordPermission(q, time.Now())
+	m.storage.UpdateInstance(inst)
+}
+
+// permissionRules returns the allow rules suggested for a session (Claude only)
+func permissionRules(inst *session.Instance) []session.PermissionRule {
+	if inst.Agent != session.AgentClaude && inst.Agent != "" {
+		return nil
+	}
+	return inst.Permissions.Suggestions(inst.AllowedPermissions())
+}
+
+// handleShowPermissions opens the permission prompts dialog for the selected session
+func (m *Model) handleShowPermissions() {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return
+	}
+	m.permissionsTarget = inst
+	m.permissionRules = permissionRules(inst)
+	m.permissionsCursor = 0
+	m.state = statePermissions
+}
+
+// handlePermissionsKeys handles keyboard input in the permission prompts dialog
+func (m Model) handlePermissionsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	inst := m.permissionsTarget
+	switch msg.String() {
+	case "esc", "q", "y":
+		m.permissionsTarget = nil
+		m.permissionRules = nil
+		m.state = stateList
+	case "up", "k":
+		if m.permissionsCursor > 0 {
+			m.permissionsCursor--
+		}
+	case "down", "j":
+		if m.permissionsCursor < len(m.permissionRules)-1 {
+			m.permissionsCursor++
+		}
+	case "enter":
+		if m.permissionsCursor >= len(m.permissionRules) {
+			return m, nil
+		}
+		rule := m.permissionRules[m.permissionsCursor]
+		if rule.Risky != "" {
+			m.notifyWarn("%s %s - add it to the settings yourself if you really want it", rule.Rule, rule.Risky)
+			return m, nil
+		}
+		if err := inst.AllowPermission(rule.Rule); err != nil {
+			m.notify(toastError, "Failed to add the rule: %v", err)
+			return m, nil
+		}
+		m.notifySuccess("Allowed %s in .claude/settings.local.json (applies on the next start)", rule.Rule)
+		m.permissionRules = permissionRules(inst)
+		if m.permissionsCursor >= len(m.permissionRules) && m.permissionsCursor > 0 {
+			m.permissionsCursor--
+		}
+	case "c":
+		inst.Permissions = nil
+		delete(m.seenPermissions, inst.ID)
+		m.storage.UpdateInstance(inst)
+		m.permissionRules = nil
+		m.permissionsCursor = 0
+		m.notifyInfo("Permission prompt counts of '%s' reset", inst.Name)
+	}
+	return m, nil
+}
+
+// permissionsView renders the permission prompt breakdown and allow rule suggestions
+func (m Model) permissionsView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 72
+	inst := m.permissionsTarget
+	stats := inst.Permissions
+	total := stats.Total()
+	if total == 0 {
+		boxContent.WriteString(fmt.Sprintf("  '%s' has not asked for permissions yet\n", truncateRunes(inst.Name, 40)))
+		boxContent.WriteString(dimStyle.Render("  Prompts are counted while the manager is running"))
+		boxContent.WriteString("\n\n")
+		boxContent.WriteString(helpStyle.Render("  esc: close"))
+		boxContent.WriteString("\n")
+		return m.renderOverlayDialog(" Permission Prompts ", boxContent.String(), boxWidth, ColorAccent)
+	}
+
+	header := fmt.Sprintf("  %d prompts since %s", total, stats.Since.Format("Jan 2 15:04"))
+	if stats.AutoApproved > 0 {
+		header += fmt.Sprintf(", %d auto-approved", stats.AutoApproved)
+	}
+	boxContent.WriteString(header + "\n\n")
+	for _, kind := range session.PermissionKinds {
+		n := stats.Counts[kind]
+		if n == 0 {
+			continue
+		}
+		boxContent.WriteString(fmt.Sprintf("  %-6s %5d  %s\n", kind, n, dimStyle.Render(fmt.Sprintf("%3d%%", n*100/total))))
+	}
+	boxContent.WriteString("\n")
+
+	switch {
+	case inst.Agent != session.AgentClaude && inst.Agent != "":
+		boxContent.WriteString(dimStyle.Render("  Allow rules are suggested for Claude sessions"))
+		boxContent.WriteString("\n")
+	case len(m.permissionRules) == 0:
+		boxContent.WriteString(dimStyle.Render(fmt.Sprintf("  No suggestions - nothing was asked %d+ times that is not allowed yet", session.PermissionSuggestMin)))
+		boxContent.WriteString("\n")
+	default:
+		boxContent.WriteString(fmt.Sprintf("  Suggested allow rules (asked %d+ times):\n", session.PermissionSuggestMin))
+		warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorRed))
+		for idx, rule := range m.permissionRules {
+			prefix := "    "
+			if idx == m.permissionsCursor {
+				prefix = "  ❯ "
+			}
+			line := fmt.Sprintf("%s%-40s %4d×", prefix, truncateRunes(rule.Rule, 40), rule.Count)
+			if rule.Risky != "" {
+				line += "  " + warnStyle.Render("keep asking: "+rule.Risky)
+			}
+			boxContent.WriteString(line + "\n")
+		}
+	}
+
+	boxContent.WriteString("\n")
+	boxContent.WriteString(helpStyle.Render("  enter: allow in .claude/settings.local.json  c: reset  esc: close"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Permission Prompts ", boxContent.String(), boxWidth, ColorAccent)
+}
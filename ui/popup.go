@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// PopupRefresh is how often the status popup re-reads the sessions
+const PopupRefresh = 2 * time.Second
+
+// popupTickMsg refreshes the status popup
+type popupTickMsg time.Time
+
+// popupModel is the fleet overview shown in a tmux popup by `asmgr status --popup`
+type popupModel struct {
+	storage  *session.Storage
+	current  string // tmux session the popup was opened from (left out of the list)
+	snapshot *session.StateSnapshot
+	err      error
+	width    int
+}
+
+// popupTick schedules the next refresh of the status popup
+func popupTick() tea.Cmd {
+	return tea.Tick(PopupRefresh, func(t time.Time) tea.Msg {
+		return popupTickMsg(t)
+	})
+}
+
+func (p popupModel) Init() tea.Cmd {
+	return popupTick()
+}
+
+func (p popupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		p.width = msg.Width
+	case tea.KeyMsg:
+		return p, tea.Quit
+	case popupTickMsg:
+		p.snapshot, p.err = p.storage.Snapshot()
+		return p, popupTick()
+	}
+	return p, nil
+}
+
+func (p popupModel) View() string {
+	if p.err != nil {
+		return stoppedStyle.Render(" " + p.err.Error())
+	}
+	return StatusPopup(p.snapshot, p.current, p.width)
+}
+
+// popupRank orders the sessions of the popup: waiting, busy, idle
+func popupRank(s session.SessionState) int {
+	switch s.Activity {
+	case session.ActivityWaiting.String():
+		return 0
+	case session.ActivityBusy.String():
+		return 1
+	}
+	return 2
+}
+
+// StatusPopup renders the running sessions of every project with their
+// activity (waiting first), leaving out the current tmux session
+func StatusPopup(snapshot *session.StateSnapshot, current string, width int) string {
+	if width <= 0 {
+		width = 80
+	}
+	var b strings.Builder
+	running, waiting, stopped := 0, 0, 0
+	var body strings.Builder
+	for _, p := range snapshot.Projects {
+		var live []session.SessionState
+		off := 0
+		for _, s := range p.Sessions {
+			switch {
+			case s.ID == current:
+			case s.Status != session.StatusRunning:
+				off++
+			default:
+				live = append(live, s)
+			}
+		}
+		stopped += off
+		if len(live) == 0 {
+			continue
+		}
+		sort.SliceStable(live, func(a, c int) bool { return popupRank(live[a]) < popupRank(live[c]) })
+
+		body.WriteString(" " + lipgloss.NewStyle().Bold(true).Render(p.Name))
+		if off > 0 {
+			body.WriteString(dimStyle.Render(fmt.Sprintf("  +%d stopped", off)))
+		}
+		body.WriteString("\n")
+		for _, s := range live {
+			running++
+			var dot, activity string
+			switch popupRank(s) {
+			case 0:
+				waiting++
+				dot, activity = waitingStyle.Render("●"), waitingStyle.Render("waiting")
+			case 1:
+				dot, activity = activeStyle.Render("●"), activeStyle.Render("busy   ")
+			default:
+				dot, activity = idleStyle.Render("●"), idleStyle.Render("idle   ")
+			}
+			nameWidth := width - 44
+			if nameWidth < 12 {
+				nameWidth = 12
+			}
+			name := fmt.Sprintf("%-*s", nameWidth, truncateRunes(s.Name, nameWidth))
+			agent := truncateRunes(agentLabel(s.Agent, s.CustomCommand), 14)
+			line := fmt.Sprintf("   %s %s  %s  %-14s", dot, name, activity, agent)
+			if s.LastActive != nil {
+				line += "  " + dimStyle.Render(formatTimeAgo(*s.LastActive))
+			}
+			body.WriteString(line + "\n")
+		}
+		body.WriteString("\n")
+	}
+
+	summary := fmt.Sprintf(" %d running · %d waiting · %d stopped", running, waiting, stopped)
+	b.WriteString(lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(ColorAccent)).Render(" "+AppName) + summary + "\n\n")
+	if running == 0 {
+		b.WriteString(dimStyle.Render(" No other sessions are running") + "\n\n")
+	}
+	b.WriteString(body.String())
+	b.WriteString(helpStyle.Render(" any key closes · refreshes every 2s"))
+	return b.String()
+}
+
+// RunStatusPopup shows the status popup until a key is pressed. current is
+// the tmux session the popup belongs to (left out of the list).
+func RunStatusPopup(current string) error {
+	if config, err := session.LoadConfig(); err == nil {
+		applyColorMode(config.ColorMode)
+		applyTheme(config.Theme)
+	}
+	storage, err := session.NewStorage()
+	if err != nil {
+		return fmt.Errorf("failed to load storage: %w", err)
+	}
+	snapshot, err := storage.Snapshot()
+	if err != nil {
+		return err
+	}
+	_, err = tea.NewProgram(popupModel{storage: storage, current: current, snapshot: snapshot}).Run()
+	return err
+}
@@ -0,0 +1,284 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+	"github.com/izll/agent-session-manager/session/scripts"
+)
+
+// presetsLoadedMsg is sent when the presets gallery is fetched
+type presetsLoadedMsg struct {
+	gallery []session.Preset
+	err     error // Fetching failed - gallery is the bundled one
+}
+
+// presetsCmd fetches the presets gallery off the UI loop
+func presetsCmd(url string) tea.Cmd {
+	return func() tea.Msg {
+		gallery, err := session.FetchPresetGallery(url)
+		return presetsLoadedMsg{gallery: gallery, err: err}
+	}
+}
+
+// presetRow is a preset in the gallery view: from the gallery, installed, or both
+type presetRow struct {
+	preset    session.Preset  // Gallery version, or the installed one for local presets
+	installed *session.Preset // Installed version (nil = not installed)
+	inGallery bool
+}
+
+// presetsURL returns the configured gallery URL ("" = bundled gallery)
+func (m Model) presetsURL() string {
+	if m.config == nil {
+		return ""
+	}
+	return m.config.PresetsURL
+}
+
+// handleShowPresets opens the presets gallery and fetches it
+func (m *Model) handleShowPresets() tea.Cmd {
+	m.loadInstalledPresets()
+	m.presetCursor = 0
+	m.state = statePresets
+	m.presetsLoading = true
+	return presetsCmd(m.presetsURL())
+}
+
+// loadInstalledPresets reads the presets installed in config.toml
+func (m *Model) loadInstalledPresets() {
+	installed, err := session.LoadPresets()
+	if err != nil {
+		m.notifyWarn("Presets: %v", err)
+	}
+	m.presetsInstalled = installed
+}
+
+// handlePresetsLoaded shows the fetched gallery
+func (m *Model) handlePresetsLoaded(msg presetsLoadedMsg) {
+	m.presetGallery = msg.gallery
+	m.presetsLoading = false
+	m.presetsErr = msg.err
+}
+
+// presetRows returns the gallery presets followed by installed presets not in the gallery
+func (m Model) presetRows() []presetRow {
+	installed := make(map[string]*session.Preset)
+	for idx := range m.presetsInstalled {
+		installed[m.presetsInstalled[idx].ID] = &m.presetsInstalled[idx]
+	}
+	var rows []presetRow
+	seen := make(map[string]bool)
+	for _, p := range m.presetGallery {
+		if seen[p.ID] {
+			continue
+		}
+		seen[p.ID] = true
+		rows = append(rows, presetRow{preset: p, installed: installed[p.ID], inGallery: true})
+	}
+	for idx := range m.presetsInstalled {
+		p := m.presetsInstalled[idx]
+		if !seen[p.ID] {
+			rows = append(rows, presetRow{preset: p, installed: &m.presetsInstalled[idx]})
+		}
+	}
+	return rows
+}
+
+// reloadScripts loads the scripts directory again after a preset changed its watcher rules
+func (m *Model) reloadScripts() {
+	if m.scripts != nil {
+		m.scripts.Close()
+	}
+	m.scripts = scripts.Load()
+}
+
+// handlePresetsKeys handles keyboard input in the presets gallery
+func (m Model) handlePresetsKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	rows := m.presetRows()
+	var row *presetRow
+	if m.presetCursor < len(rows) {
+		row = &rows[m.presetCursor]
+	}
+	switch msg.String() {
+	case "esc", "q", "+":
+		m.state = stateList
+	case "up", "k":
+		if m.presetCursor > 0 {
+			m.presetCursor--
+		}
+	case "down", "j":
+		if m.presetCursor < len(rows)-1 {
+			m.presetCursor++
+		}
+	case "r":
+		if !m.presetsLoading {
+			m.presetsLoading = true
+			return m, presetsCmd(m.presetsURL())
+		}
+	case "enter":
+		// Install, or update to the gallery version
+		if row == nil || !row.inGallery {
+			return m, nil
+		}
+		if row.installed != nil && row.installed.Equal(row.preset) {
+			m.notifyInfo("'%s' is already installed", row.preset.Name)
+			return m, nil
+		}
+		if err := session.InstallPreset(row.preset); err != nil {
+			m.showError(fmt.Errorf("failed to install preset: %w", err))
+			return m, nil
+		}
+		if row.preset.Script != "" || (row.installed != nil && row.installed.Script != "") {
+			m.reloadScripts()
+		}
+		verb := "Installed"
+		if row.installed != nil {
+			verb = "Updated"
+		}
+		m.loadInstalledPresets()
+		m.notifySuccess("%s preset '%s' - n starts a session with it", verb, row.preset.Name)
+	case "d":
+		if row == nil || row.installed == nil {
+			return m, nil
+		}
+		if err := session.UninstallPreset(row.preset.ID); err != nil {
+			m.showError(fmt.Errorf("failed to remove preset: %w", err))
+			return m, nil
+		}
+		if row.installed.Script != "" {
+			m.reloadScripts()
+		}
+		m.loadInstalledPresets()
+		if rows := m.presetRows(); m.presetCursor >= len(rows) && m.presetCursor > 0 {
+			m.presetCursor = len(rows) - 1
+		}
+		m.notifyInfo("Removed preset '%s'", row.preset.Name)
+	case "n":
+		// New session from the installed preset: path -> name as usual
+		if row == nil {
+			return m, nil
+		}
+		if row.installed == nil {
+			m.notifyWarn("Install '%s' first (Enter)", row.preset.Name)
+			return m, nil
+		}
+		preset := *row.installed
+		m.pendingPreset = &preset
+		m.pendingAgent = preset.Agent
+		m.pendingProfile = ""
+		m.pendingModel = ""
+		m.customCmdInput.SetValue(preset.CustomCommand)
+		m.pendingGroupID = m.getCurrentGroupID()
+		m.err = nil
+		m.pathInput.SetValue("")
+		m.pathInput.Focus()
+		m.state = stateNewPath
+		return m, textinput.Blink
+	}
+	return m, nil
+}
+
+// presetSummary describes what a preset sets up, one item per line
+func presetSummary(p session.Preset) []string {
+	agent := string(p.Agent)
+	if p.Agent == session.AgentCustom {
+		agent = "custom: " + p.CustomCommand
+	}
+	if p.AutoYes {
+		agent += " (auto-yes)"
+	}
+	lines := []string{"Agent: " + agent}
+	if len(p.Tabs) > 0 {
+		var tabs []string
+		for _, tab := range p.Tabs {
+			desc := string(tab.Agent)
+			if tab.Agent == session.AgentCustom {
+				desc = tab.CustomCommand
+			}
+			tabs = append(tabs, tab.Name+" ("+desc+")")
+		}
+		lines = append(lines, "Tabs: "+strings.Join(tabs, ", "))
+	}
+	if p.RestartPolicy != session.RestartNever {
+		lines = append(lines, "Restart: "+p.RestartPolicy.String())
+	}
+	if p.Script != "" {
+		lines = append(lines, fmt.Sprintf("Watcher rules: %d lines of Lua", strings.Count(strings.TrimRight(p.Script, "\n"), "\n")+1))
+	}
+	return lines
+}
+
+// presetsView renders the presets gallery as an overlay
+func (m Model) presetsView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 72
+	source := "bundled gallery"
+	if url := m.presetsURL(); url != "" {
+		source = truncateRunes(url, 50)
+	}
+	boxContent.WriteString("  Session presets from " + source + "\n")
+	if m.presetsErr != nil {
+		boxContent.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color(ColorOrange)).Render("  " + truncateRunes(m.presetsErr.Error(), boxWidth-34) + " - showing the bundled one"))
+		boxContent.WriteString("\n")
+	}
+	boxContent.WriteString("\n")
+
+	rows := m.presetRows()
+	switch {
+	case m.presetsLoading && len(rows) == 0:
+		boxContent.WriteString(dimStyle.Render("  Fetching presets…"))
+		boxContent.WriteString("\n")
+	case len(rows) == 0:
+		boxContent.WriteString(dimStyle.Render("  The gallery is empty"))
+		boxContent.WriteString("\n")
+	default:
+		installedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorGreen))
+		updateStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorOrange))
+		for i, row := range rows {
+			mark := "  "
+			switch {
+			case row.installed != nil && row.inGallery && !row.installed.Equal(row.preset):
+				mark = updateStyle.Render("↑ ")
+			case row.installed != nil:
+				mark = installedStyle.Render("✓ ")
+			}
+			name := truncateRunes(row.preset.Name, boxWidth-24)
+			if !row.inGallery {
+				name += dimStyle.Render(" (local)")
+			}
+			if i == m.presetCursor {
+				boxContent.WriteString(" ❯ " + mark + lipgloss.NewStyle().Bold(true).Render(name) + "\n")
+			} else {
+				boxContent.WriteString("   " + mark + name + "\n")
+			}
+		}
+
+		// Details of the preset under the cursor
+		if m.presetCursor < len(rows) {
+			p := rows[m.presetCursor].preset
+			boxContent.WriteString("\n")
+			if p.Description != "" {
+				for _, line := range strings.Split(wrapText(p.Description, boxWidth-8), "\n") {
+					boxContent.WriteString("  " + line + "\n")
+				}
+			}
+			for _, line := range presetSummary(p) {
+				boxContent.WriteString(dimStyle.Render("  "+truncateRunes(line, boxWidth-8)) + "\n")
+			}
+		}
+	}
+
+	boxContent.WriteString("\n")
+	help := "  enter: install  n: new session  d: remove  r: refresh  esc: close"
+	boxContent.WriteString(helpStyle.Render(help))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Presets ", boxContent.String(), boxWidth, ColorPurple)
+}
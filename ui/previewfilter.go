@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// filterPreview runs captured output through the session's preview filter pipeline
+func (m Model) filterPreview(inst *session.Instance, content string) string {
+	_, rules := m.config.PreviewFilter(inst)
+	if len(rules) == 0 || content == "" {
+		return content
+	}
+	filtered := session.ApplyPreviewFilter(rules, content)
+	lines := make([]string, len(filtered))
+	for i, line := range filtered {
+		switch {
+		case line.Style == nil:
+			lines[i] = line.Text
+		case line.Style.Action == session.FilterDim:
+			lines[i] = dimStyle.Render(line.Text)
+		default:
+			color, ok := resolveColorName(line.Style.Color)
+			if !ok {
+				color = ColorRed
+			}
+			lines[i] = lipgloss.NewStyle().Foreground(lipgloss.Color(color)).Bold(true).Render(line.Text)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// previewFilterLabel describes the preview filter of a session for the preview header
+func (m Model) previewFilterLabel(inst *session.Instance) string {
+	name, _ := m.config.PreviewFilter(inst)
+	switch {
+	case inst.PreviewFilter == session.PreviewFilterOff:
+		return "off"
+	case name == "":
+		return ""
+	case inst.PreviewFilter == "":
+		return name + " (agent default)"
+	}
+	return name
+}
+
+// cyclePreviewFilter switches the selected session to the next preview filter
+// pipeline: agent default, each pipeline of config.toml, then off
+func (m *Model) cyclePreviewFilter() {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return
+	}
+	names := m.config.PreviewFilterNames()
+	if len(names) == 0 {
+		m.notifyWarn("No preview filters - add [[preview_filters.<name>]] rules to config.toml")
+		return
+	}
+
+	choices := append(append([]string{""}, names...), session.PreviewFilterOff)
+	next := 0
+	for i, choice := range choices {
+		if choice == inst.PreviewFilter {
+			next = (i + 1) % len(choices)
+			break
+		}
+	}
+	inst.PreviewFilter = choices[next]
+	m.storage.UpdateInstance(inst)
+	m.resetScroll()
+
+	switch label := m.previewFilterLabel(inst); {
+	case inst.PreviewFilter == session.PreviewFilterOff:
+		m.notifyInfo("'%s' preview shows raw output", inst.Name)
+	case label == "":
+		m.notifyInfo("'%s' preview follows its agent (no default filter)", inst.Name)
+	default:
+		m.notifyInfo("'%s' preview filter: %s", inst.Name, label)
+	}
+}
@@ -0,0 +1,203 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// processSignals maps process view keys to the signals they send
+var processSignals = map[string]syscall.Signal{
+	"i": syscall.SIGINT,
+	"t": syscall.SIGTERM,
+	"k": syscall.SIGKILL,
+}
+
+// handleShowProcesses opens the process list of a session
+func (m *Model) handleShowProcesses(inst *session.Instance) {
+	if inst == nil {
+		return
+	}
+	if inst.Status != session.StatusRunning {
+		m.notifyWarn("Session not running")
+		return
+	}
+	m.processTarget = inst
+	m.processCursor = 0
+	m.pendingSignal = 0
+	m.state = stateProcesses
+	m.refreshProcesses()
+}
+
+// refreshProcesses re-reads the process list of the target session
+func (m *Model) refreshProcesses() {
+	m.processesAt = time.Now()
+	procs, err := m.resourceSampler.Processes(m.processTarget)
+	if err != nil {
+		m.notify(toastError, "Cannot list processes: %v", err)
+		m.processes = nil
+		m.state = stateList
+		return
+	}
+	m.processes = procs
+	if m.processCursor >= len(procs) {
+		m.processCursor = len(procs) - 1
+	}
+	if m.processCursor < 0 {
+		m.processCursor = 0
+	}
+}
+
+// tickProcesses keeps the open process list up to date
+func (m *Model) tickProcesses() {
+	if m.state == stateProcesses && m.pendingSignal == 0 && time.Since(m.processesAt) >= ResourceSampleInterval {
+		m.refreshProcesses()
+	}
+}
+
+// signalSelectedProcess sends sig to the process under the cursor
+func (m *Model) signalSelectedProcess(sig syscall.Signal) {
+	m.pendingSignal = 0
+	if m.processCursor >= len(m.processes) {
+		return
+	}
+	proc := m.processes[m.processCursor]
+	if err := m.processTarget.SignalProcess(proc.PID, sig); err != nil {
+		m.notify(toastError, "Failed to signal %d: %v", proc.PID, err)
+		return
+	}
+	m.notifySuccess("Sent %s to %d (%s)", signalName(sig), proc.PID, truncateRunes(proc.Command, 30))
+	// Give the process a moment to exit before re-listing
+	time.Sleep(100 * time.Millisecond)
+	m.refreshProcesses()
+}
+
+// signalName returns the short SIG name of a signal
+func signalName(sig syscall.Signal) string {
+	switch sig {
+	case syscall.SIGINT:
+		return "SIGINT"
+	case syscall.SIGTERM:
+		return "SIGTERM"
+	case syscall.SIGKILL:
+		return "SIGKILL"
+	}
+	return sig.String()
+}
+
+// handleProcessesKeys handles keyboard input in the process list
+func (m Model) handleProcessesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+
+	// Confirming a signal to a pane's own process
+	if m.pendingSignal != 0 {
+		switch m.keys.answer(msg) {
+		case answerYes:
+			m.signalSelectedProcess(m.pendingSignal)
+		case answerNo, "esc":
+			m.pendingSignal = 0
+		}
+		return m, nil
+	}
+
+	switch key {
+	case "esc", "q", "K":
+		m.processTarget = nil
+		m.processes = nil
+		m.state = stateList
+	// k is SIGKILL here, so no vim-style navigation
+	case "up":
+		if m.processCursor > 0 {
+			m.processCursor--
+		}
+	case "down":
+		if m.processCursor < len(m.processes)-1 {
+			m.processCursor++
+		}
+	case "r":
+		m.refreshProcesses()
+	case "i", "t", "k":
+		if m.processCursor >= len(m.processes) {
+			return m, nil
+		}
+		sig := processSignals[key]
+		// Pane roots are the agents/shells themselves - make sure that's intended
+		if m.processes[m.processCursor].IsPaneRoot() {
+			m.pendingSignal = sig
+			return m, nil
+		}
+		m.signalSelectedProcess(sig)
+	}
+	return m, nil
+}
+
+// processesView renders the process list as an overlay
+func (m Model) processesView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 90
+	if m.width > 0 && m.width-4 < boxWidth {
+		boxWidth = m.width - 4
+	}
+	cmdWidth := boxWidth - 34
+	if cmdWidth < 10 {
+		cmdWidth = 10
+	}
+
+	header := fmt.Sprintf("  %7s %4s %6s %9s  %s", "PID", "Win", "CPU", "Memory", "Command")
+	boxContent.WriteString(dimStyle.Render(header))
+	boxContent.WriteString("\n")
+
+	maxVisible := m.height - 14
+	if maxVisible < 5 {
+		maxVisible = 5
+	}
+	start := 0
+	if m.processCursor >= maxVisible {
+		start = m.processCursor - maxVisible + 1
+	}
+	hot := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorOrange))
+	for i := start; i < len(m.processes) && i < start+maxVisible; i++ {
+		p := m.processes[i]
+		cpu := fmt.Sprintf("%5.0f%%", p.CPU)
+		if p.CPU >= 80 {
+			cpu = hot.Render(cpu)
+		}
+		usage := session.ResourceUsage{MemKB: p.MemKB}
+		command := strings.Repeat("  ", p.Depth) + p.Command
+		line := fmt.Sprintf("%7d %4d %s %9s  %s", p.PID, p.Window, cpu, usage.FormatMem(), truncateRunes(command, cmdWidth))
+		if i == m.processCursor {
+			boxContent.WriteString(" ❯" + line + "\n")
+		} else {
+			boxContent.WriteString("  " + line + "\n")
+		}
+	}
+	if len(m.processes) > start+maxVisible {
+		boxContent.WriteString(dimStyle.Render(fmt.Sprintf("  ↓ %d more", len(m.processes)-start-maxVisible)))
+		boxContent.WriteString("\n")
+	}
+
+	boxContent.WriteString("\n")
+	if m.pendingSignal != 0 && m.processCursor < len(m.processes) {
+		p := m.processes[m.processCursor]
+		warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorOrange)).Bold(true)
+		boxContent.WriteString(warnStyle.Render(fmt.Sprintf("  Send %s to %d? It is the agent/shell of window %d.", signalName(m.pendingSignal), p.PID, p.Window)))
+		boxContent.WriteString("\n")
+		boxContent.WriteString(helpStyle.Render("  y: yes  n: no"))
+	} else {
+		boxContent.WriteString(helpStyle.Render("  t: SIGTERM  k: SIGKILL  i: SIGINT  r: refresh  esc: close"))
+	}
+	boxContent.WriteString("\n")
+
+	title := " Processes "
+	if m.processTarget != nil {
+		title = fmt.Sprintf(" Processes - %s ", m.processTarget.Name)
+	}
+	return m.renderOverlayDialog(title, boxContent.String(), boxWidth, ColorCyan)
+}
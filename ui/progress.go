@@ -0,0 +1,262 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+	"github.com/izll/agent-session-manager/updater"
+)
+
+// sessionStartedMsg is sent when a background session start finishes
+type sessionStartedMsg struct {
+	inst    *session.Instance
+	started *session.Instance // Snapshot the start ran on, applied to inst in Update
+	attach  bool              // Attach to the session once it is running
+	err     error
+}
+
+// forkDoneMsg is sent when a background fork finishes
+type forkDoneMsg struct {
+	target       *session.Instance
+	name         string // Name for the new tab or session
+	toTab        bool   // true = open fork in new tab, false = new session
+	newSessionID string
+	err          error
+}
+
+// newProgressSpinner creates the spinner used for background operations
+func newProgressSpinner() spinner.Model {
+	return spinner.New(spinner.WithSpinner(spinner.MiniDot), spinner.WithStyle(activeStyle))
+}
+
+// startSessionCmd starts a session (resuming resumeID if set) off the UI loop.
+// The start runs on a snapshot; handleSessionStarted applies its result.
+func startSessionCmd(inst *session.Instance, resumeID string, attach bool) tea.Cmd {
+	started := inst.Snapshot()
+	return func() tea.Msg {
+		err := started.StartWithResume(resumeID)
+		return sessionStartedMsg{inst: inst, started: started, attach: attach, err: err}
+	}
+}
+
+// forkSessionCmd forks a Claude conversation off the UI loop
+func forkSessionCmd(target *session.Instance, name string, toTab bool) tea.Cmd {
+	snapshot := target.Snapshot()
+	return func() tea.Msg {
+		newSessionID, err := snapshot.ForkSession()
+		return forkDoneMsg{target: target, name: name, toTab: toTab, newSessionID: newSessionID, err: err}
+	}
+}
+
+// forkTabCmd forks the conversation of one tab into a new tab off the UI loop
+func forkTabCmd(target *session.Instance, sessionID, name string) tea.Cmd {
+	snapshot := target.Snapshot()
+	return func() tea.Msg {
+		newSessionID, err := snapshot.ForkConversation(sessionID)
+		return forkDoneMsg{target: target, name: name, toTab: true, newSessionID: newSessionID, err: err}
+	}
+}
+
+// isBusy reports whether a background operation is running for an instance
+func (m Model) isBusy(inst *session.Instance) bool {
+	_, busy := m.pendingOps[inst.ID]
+	return busy
+}
+
+// hasBackgroundWork reports whether the spinner needs to keep animating
+func (m Model) hasBackgroundWork() bool {
+	return len(m.pendingOps) > 0 || m.updateInProgress
+}
+
+// beginOperation marks an instance as busy and starts the spinner if idle
+func (m *Model) beginOperation(inst *session.Instance, label string) tea.Cmd {
+	idle := !m.hasBackgroundWork()
+	m.pendingOps[inst.ID] = label
+	if idle {
+		return m.spinner.Tick
+	}
+	return nil
+}
+
+// endOperation clears the busy marker of an instance
+func (m *Model) endOperation(inst *session.Instance) {
+	delete(m.pendingOps, inst.ID)
+}
+
+// startSessionAsync starts a session in the background while the UI stays responsive
+func (m *Model) startSessionAsync(inst *session.Instance, resumeID string, attach bool) tea.Cmd {
+	p := pendingStart{inst: inst, resumeID: resumeID, attach: attach}
+	if inst.Status != session.StatusRunning && m.checkRunningLimit([]pendingStart{p}) {
+		return nil
+	}
+	return m.startPending(p)
+}
+
+// startPending starts a session, showing its commands first in dry-run mode
+func (m *Model) startPending(p pendingStart) tea.Cmd {
+	if m.dryRun {
+		return m.confirmDryRun(p)
+	}
+	return m.runStart(p.inst, p.resumeID, p.attach)
+}
+
+// runStart starts a session in the background without dry-run confirmation
+func (m *Model) runStart(inst *session.Instance, resumeID string, attach bool) tea.Cmd {
+	if m.isBusy(inst) {
+		return nil
+	}
+	if inst.Worktree != nil && inst.PathMissing() {
+		// Worktree removed with the deleted session - check its branch out again
+		if err := inst.RestoreWorktree(); err != nil {
+			m.showError(err)
+			return nil
+		}
+	}
+	if inst.PathMissing() {
+		return relinkNeededCmd(pendingStart{inst: inst, resumeID: resumeID, attach: attach})
+	}
+	if len(inst.ReadinessChecks) > 0 && inst.Status != session.StatusRunning {
+		return tea.Batch(m.beginOperation(inst, "Waiting for services"), m.waitReady(pendingStart{inst: inst, resumeID: resumeID, attach: attach}))
+	}
+	return tea.Batch(m.beginOperation(inst, "Starting"), startSessionCmd(inst, resumeID, attach))
+}
+
+// startUpdateDownload starts downloading an update in the background based on package type
+func (m *Model) startUpdateDownload(version string) tea.Cmd {
+	var download tea.Cmd
+	if updater.IsPackageManaged() {
+		// Check if deb
+		if _, err := os.Stat("/var/lib/dpkg/info/asmgr.list"); err == nil {
+			m.state = stateDownloadingDeb
+			download = runDebDownload(version)
+		} else {
+			// Otherwise rpm
+			m.state = stateDownloadingRpm
+			download = runRpmDownload(version)
+		}
+	} else {
+		// Tarball update
+		m.state = stateUpdating
+		download = runUpdateCmd(version)
+	}
+
+	var tick tea.Cmd
+	if !m.hasBackgroundWork() {
+		tick = m.spinner.Tick
+	}
+	m.updateInProgress = true
+	return tea.Batch(tick, download)
+}
+
+// handleSpinnerTick advances the spinner while background work is running
+func (m Model) handleSpinnerTick(msg spinner.TickMsg) (tea.Model, tea.Cmd) {
+	if !m.hasBackgroundWork() {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(msg)
+	return m, cmd
+}
+
+// handleSessionStarted finishes a background session start
+func (m Model) handleSessionStarted(msg sessionStartedMsg) (tea.Model, tea.Cmd) {
+	m.endOperation(msg.inst)
+	if msg.started != nil {
+		msg.inst.ApplyStart(msg.started)
+	}
+
+	if msg.err != nil {
+		// Don't interrupt a dialog the user opened in the meantime
+		if m.state == stateList {
+			m.showError(fmt.Errorf("failed to start '%s': %w", msg.inst.Name, msg.err))
+		} else {
+			m.notify(toastError, "Failed to start '%s': %v", msg.inst.Name, msg.err)
+		}
+		return m, nil
+	}
+
+	m.storage.UpdateInstance(msg.inst)
+
+	if msg.attach && m.state == stateList {
+		cmd := m.attachSession(msg.inst)
+		return m, cmd
+	}
+	if tabs := len(msg.inst.FollowedWindows); tabs > 0 {
+		m.notifySuccess("Started '%s' with %d tab(s) restored", msg.inst.Name, tabs)
+		return m, nil
+	}
+	m.notifySuccess("Started '%s'", msg.inst.Name)
+	return m, nil
+}
+
+// handleForkDone finishes a background fork by opening the new tab or session
+func (m Model) handleForkDone(msg forkDoneMsg) (tea.Model, tea.Cmd) {
+	target := msg.target
+	m.endOperation(target)
+
+	if msg.err != nil {
+		m.showError(fmt.Errorf("fork failed: %w", msg.err))
+		return m, nil
+	}
+
+	if msg.toTab {
+		// Fork to new tab in same session
+		if err := target.NewForkedTab(msg.name, msg.newSessionID); err != nil {
+			m.showError(fmt.Errorf("failed to create fork tab: %w", err))
+			return m, nil
+		}
+		// Update status bar
+		configureTmuxStatusBar(target.TmuxSessionName(), target.Name, target.Color, target.BgColor, target.AutoYes)
+		m.storage.UpdateInstance(target)
+		m.notifySuccess("Forked '%s' into tab '%s'", target.Name, msg.name)
+		return m, nil
+	}
+
+	// Fork to new session
+	newInst, err := session.NewInstance(msg.name, target.Path, false, session.AgentClaude)
+	if err != nil {
+		m.showError(fmt.Errorf("failed to create fork session: %w", err))
+		return m, nil
+	}
+
+	// Copy settings from original
+	newInst.GroupID = target.GroupID
+	newInst.Color = target.Color
+	newInst.BgColor = target.BgColor
+	newInst.FullRowColor = target.FullRowColor
+	newInst.ResumeSessionID = msg.newSessionID
+	newInst.Notes = fmt.Sprintf("Forked from: %s", target.Name)
+	newInst.ParentID = target.ID
+
+	// Add to storage
+	if err := m.storage.AddInstance(newInst); err != nil {
+		m.showError(fmt.Errorf("failed to save fork session: %w", err))
+		return m, nil
+	}
+
+	m.instances = append(m.instances, newInst)
+	m.selectInstance(newInst)
+
+	// Start the forked session in the background
+	return m, m.startSessionAsync(newInst, msg.newSessionID, false)
+}
+
+// selectInstance moves the cursor to the given instance
+func (m *Model) selectInstance(inst *session.Instance) {
+	if m.usesVisibleItems() {
+		m.buildVisibleItems()
+		for i, item := range m.visibleItems {
+			if !item.isGroup && item.instance != nil && item.instance.ID == inst.ID {
+				m.cursor = i
+				return
+			}
+		}
+		return
+	}
+	if idx := m.findInstanceIndex(inst.ID); idx >= 0 {
+		m.cursor = idx
+	}
+}
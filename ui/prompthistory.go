@@ -0,0 +1,98 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/izll/agent-session-manager/session"
+)
+
+// loadPromptHistory prepares the prompt dialog's history for a session
+func (m *Model) loadPromptHistory(inst *session.Instance) {
+	m.promptHistory, _ = session.LoadPromptHistory()
+	m.promptRecall = session.SessionPrompts(m.promptHistory, inst.ID)
+	m.promptRecallIdx = len(m.promptRecall)
+	m.promptDraft = ""
+	m.promptSearching = false
+	m.promptSearch = ""
+	m.promptSearchIdx = -1
+}
+
+// rememberPrompt adds a prompt written in the prompt dialog to the history
+func (m *Model) rememberPrompt(inst *session.Instance, text string) {
+	if err := session.RecordPrompt(inst.ID, text); err != nil {
+		m.notifyWarn("Prompt history not saved: %v", err)
+	}
+}
+
+// recallPrompt puts an older (up) or newer (down) prompt of the session into
+// the textarea. It only acts with the cursor on the first (up) or last (down)
+// row, so the arrows still move through a multi-line message.
+func (m *Model) recallPrompt(older bool) bool {
+	info := m.promptInput.LineInfo()
+	if older && (m.promptInput.Line() > 0 || info.RowOffset > 0) {
+		return false
+	}
+	if !older && (m.promptInput.Line() < m.promptInput.LineCount()-1 || info.RowOffset < info.Height-1) {
+		return false
+	}
+	if len(m.promptRecall) == 0 {
+		return false
+	}
+	if m.promptRecallIdx == len(m.promptRecall) {
+		m.promptDraft = m.promptInput.Value()
+	}
+	switch {
+	case older && m.promptRecallIdx > 0:
+		m.promptRecallIdx--
+	case !older && m.promptRecallIdx < len(m.promptRecall):
+		m.promptRecallIdx++
+	default:
+		return true
+	}
+	if m.promptRecallIdx == len(m.promptRecall) {
+		m.promptInput.SetValue(m.promptDraft)
+	} else {
+		m.promptInput.SetValue(m.promptRecall[m.promptRecallIdx])
+	}
+	m.promptSearching = false
+	return true
+}
+
+// searchPromptHistory steps to the next older prompt of any session containing
+// the text typed before the first Ctrl+R
+func (m *Model) searchPromptHistory() {
+	if !m.promptSearching {
+		m.promptSearching = true
+		m.promptSearch = strings.TrimSpace(m.promptInput.Value())
+		m.promptSearchIdx = len(m.promptHistory)
+	}
+	idx := session.SearchPrompts(m.promptHistory, m.promptSearch, m.promptSearchIdx)
+	if idx < 0 {
+		return // Stays on the oldest match
+	}
+	m.promptSearchIdx = idx
+	m.promptInput.SetValue(m.promptHistory[idx].Text)
+}
+
+// promptHistoryStatus returns the history line of the prompt dialog ("" = none)
+func (m Model) promptHistoryStatus() string {
+	switch {
+	case m.promptSearching && m.promptSearchIdx == len(m.promptHistory):
+		return fmt.Sprintf("ctrl+r: no prompt contains '%s'", truncateRunes(m.promptSearch, 30))
+	case m.promptSearching:
+		p := m.promptHistory[m.promptSearchIdx]
+		from := "a session of another project"
+		for _, inst := range m.instances {
+			if inst.ID == p.SessionID {
+				from = inst.Name
+			}
+		}
+		return fmt.Sprintf("ctrl+r '%s': sent to %s on %s (ctrl+r: older)", truncateRunes(m.promptSearch, 20), truncateRunes(from, 20), p.SentAt.Format("Jan 2 15:04"))
+	case m.promptRecallIdx < len(m.promptRecall):
+		return fmt.Sprintf("history %d/%d of this session (↑ older, ↓ newer)", m.promptRecallIdx+1, len(m.promptRecall))
+	case len(m.promptHistory) > 0 && m.promptInput.Value() == "":
+		return fmt.Sprintf("↑: %d previous prompt(s)  ctrl+r: search all sessions", len(m.promptRecall))
+	}
+	return ""
+}
@@ -0,0 +1,224 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// readinessWait tracks a session waiting for its readiness checks to pass
+type readinessWait struct {
+	since    time.Time
+	attempt  int       // Failed rounds so far
+	failed   string    // Last failed check and why
+	checking bool      // A round is running (scheduled prompts)
+	retryAt  time.Time // Earliest next round (scheduled prompts)
+}
+
+// readinessMsg carries one round of readiness checks before a session starts
+type readinessMsg struct {
+	start pendingStart
+	wait  *readinessWait
+	check *session.ReadinessCheck // First failed check (nil = all passed)
+	err   error
+}
+
+// promptReadinessMsg carries one round of readiness checks before scheduled prompts are sent
+type promptReadinessMsg struct {
+	inst  *session.Instance
+	check *session.ReadinessCheck
+	err   error
+}
+
+// readinessCmd runs a round of the session's checks off the UI loop after delay
+func readinessCmd(p pendingStart, w *readinessWait, delay time.Duration) tea.Cmd {
+	snapshot := p.inst.Snapshot()
+	check := func() tea.Msg {
+		failed, err := snapshot.CheckReadiness()
+		return readinessMsg{start: p, wait: w, check: failed, err: err}
+	}
+	if delay == 0 {
+		return check
+	}
+	return tea.Tick(delay, func(time.Time) tea.Msg { return check() })
+}
+
+// waitReady starts checking a session's services before it is started
+func (m *Model) waitReady(p pendingStart) tea.Cmd {
+	w := &readinessWait{since: time.Now()}
+	m.startWaits[p.inst.ID] = w
+	return readinessCmd(p, w, 0)
+}
+
+// handleReadiness starts the session once its checks pass, otherwise retries with backoff
+func (m Model) handleReadiness(msg readinessMsg) (tea.Model, tea.Cmd) {
+	inst := msg.start.inst
+	if m.startWaits[inst.ID] != msg.wait {
+		return m, nil // Waiting was cancelled
+	}
+	if msg.check == nil {
+		delete(m.startWaits, inst.ID)
+		m.pendingOps[inst.ID] = "Starting"
+		return m, startSessionCmd(inst, msg.start.resumeID, msg.start.attach)
+	}
+
+	w := msg.wait
+	w.attempt++
+	w.failed = fmt.Sprintf("%s (%v)", msg.check, msg.err)
+	if time.Since(w.since) >= session.ReadinessStartTimeout {
+		delete(m.startWaits, inst.ID)
+		err := fmt.Errorf("%s not ready after %s", w.failed, session.ReadinessStartTimeout)
+		return m.handleSessionStarted(sessionStartedMsg{inst: inst, err: err})
+	}
+	m.pendingOps[inst.ID] = fmt.Sprintf("Waiting for %s, try %d (x: cancel)", msg.check, w.attempt+1)
+	return m, readinessCmd(msg.start, w, session.ReadinessBackoff(w.attempt))
+}
+
+// cancelStartWait gives up a start that waits for services (false if not waiting)
+func (m *Model) cancelStartWait(inst *session.Instance) bool {
+	if _, waiting := m.startWaits[inst.ID]; !waiting {
+		return false
+	}
+	delete(m.startWaits, inst.ID)
+	m.endOperation(inst)
+	m.notifyInfo("Stopped waiting for the services of '%s'", inst.Name)
+	return true
+}
+
+// checkPromptReadiness runs a round of checks for a session with due scheduled prompts
+func (m *Model) checkPromptReadiness(inst *session.Instance, now time.Time) tea.Cmd {
+	w := m.promptWaits[inst.ID]
+	if w == nil {
+		w = &readinessWait{since: now}
+		m.promptWaits[inst.ID] = w
+	}
+	if w.checking || now.Before(w.retryAt) {
+		return nil
+	}
+	w.checking = true
+	snapshot := inst.Snapshot()
+	return func() tea.Msg {
+		failed, err := snapshot.CheckReadiness()
+		return promptReadinessMsg{inst: inst, check: failed, err: err}
+	}
+}
+
+// handlePromptReadiness sends the due prompts once the checks pass, otherwise schedules a retry
+func (m Model) handlePromptReadiness(msg promptReadinessMsg) (tea.Model, tea.Cmd) {
+	inst := msg.inst
+	w := m.promptWaits[inst.ID]
+	if w == nil {
+		return m, nil
+	}
+	w.checking = false
+	if msg.check == nil {
+		delete(m.promptWaits, inst.ID)
+		if inst.Status == session.StatusRunning && !m.isBusy(inst) {
+			m.sendPromptsDue(inst, time.Now())
+		}
+		return m, nil
+	}
+	w.attempt++
+	w.failed = fmt.Sprintf("%s (%v)", msg.check, msg.err)
+	w.retryAt = time.Now().Add(session.ReadinessBackoff(w.attempt))
+	return m, nil
+}
+
+// readinessSummary returns the preview line of a session with readiness checks
+func (m Model) readinessSummary(inst *session.Instance) string {
+	if len(inst.ReadinessChecks) == 0 {
+		return ""
+	}
+	summary := session.FormatReadinessChecks(inst.ReadinessChecks)
+	if w := m.promptWaits[inst.ID]; w != nil && w.failed != "" {
+		summary += " · scheduled messages wait for " + w.failed
+	}
+	return summary
+}
+
+// openReadiness opens the readiness checks dialog for the selected session
+func (m *Model) openReadiness() tea.Cmd {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return nil
+	}
+	m.err = nil
+	m.readinessInput.SetValue(session.FormatReadinessChecks(inst.ReadinessChecks))
+	m.readinessInput.CursorEnd()
+	m.readinessInput.Focus()
+	m.state = stateReadiness
+	return textinput.Blink
+}
+
+// handleReadinessKeys handles keyboard input in the readiness checks dialog
+func (m Model) handleReadinessKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.err = nil
+		m.readinessInput.Blur()
+		m.state = stateList
+		return m, nil
+
+	case "enter":
+		inst := m.getSelectedInstance()
+		if inst == nil {
+			m.state = stateList
+			return m, nil
+		}
+		checks, err := session.ParseReadinessChecks(m.readinessInput.Value())
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.err = nil
+		m.readinessInput.Blur()
+		inst.ReadinessChecks = checks
+		delete(m.promptWaits, inst.ID)
+		m.storage.UpdateInstance(inst)
+		if len(checks) == 0 {
+			m.notifyInfo("'%s' no longer waits for services", inst.Name)
+		} else {
+			m.notifySuccess("'%s' waits for %d service(s) before starting", inst.Name, len(checks))
+		}
+		m.state = stateList
+		return m, nil
+	}
+
+	// Clear error when typing
+	m.err = nil
+
+	var cmd tea.Cmd
+	m.readinessInput, cmd = m.readinessInput.Update(msg)
+	return m, cmd
+}
+
+// readinessView renders the readiness checks dialog as an overlay
+func (m Model) readinessView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+	if inst := m.getSelectedInstance(); inst != nil {
+		boxContent.WriteString(fmt.Sprintf("  Services '%s' waits for:\n\n", inst.Name))
+	}
+	boxContent.WriteString("  " + m.readinessInput.View() + "\n")
+	boxContent.WriteString(dimStyle.Render("  ';' separated: port [host:]N, url http://... (200), cmd <shell command>") + "\n")
+	boxContent.WriteString(dimStyle.Render("  Checked before the agent starts and before scheduled messages are sent"))
+	boxContent.WriteString("\n")
+
+	if m.err != nil {
+		boxContent.WriteString("\n")
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555")).Bold(true)
+		boxContent.WriteString(errStyle.Render(fmt.Sprintf("  ⚠ %v", m.err)))
+		boxContent.WriteString("\n")
+	}
+
+	boxContent.WriteString("\n")
+	boxContent.WriteString(helpStyle.Render("  enter: save (empty = none)  esc: cancel"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Readiness Checks ", boxContent.String(), 80, ColorAccent)
+}
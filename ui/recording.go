@@ -0,0 +1,67 @@
+package ui
+
+import (
+	"path/filepath"
+
+	"github.com/izll/agent-session-manager/session"
+)
+
+// handleToggleRecording starts or stops recording the selected session's agent pane
+func (m *Model) handleToggleRecording() {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return
+	}
+	if inst.Recording {
+		if err := inst.StopRecording(); err != nil {
+			m.notify(toastError, "Failed to stop recording: %v", err)
+			return
+		}
+		inst.Recording = false
+		m.storage.UpdateInstance(inst)
+		m.notifySuccess("Recording of '%s' stopped - export it with: asmgr cast %s", inst.Name, inst.Name)
+		return
+	}
+
+	if inst.Status != session.StatusRunning {
+		m.notifyWarn("Session not running")
+		return
+	}
+	if err := inst.StartRecording(); err != nil {
+		m.notify(toastError, "Failed to start recording: %v", err)
+		return
+	}
+	inst.Recording = true
+	m.storage.UpdateInstance(inst)
+	m.notifySuccess("Recording '%s' to %s", inst.Name, filepath.Join("recordings", inst.ID))
+}
+
+// handleToggleOutputLog starts or stops logging the selected session's output lines
+func (m *Model) handleToggleOutputLog() {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return
+	}
+	if inst.OutputLog {
+		if err := inst.StopOutputLog(); err != nil {
+			m.notify(toastError, "Failed to stop the output log: %v", err)
+			return
+		}
+		inst.OutputLog = false
+		m.storage.UpdateInstance(inst)
+		m.notifySuccess("Output log of '%s' stopped", inst.Name)
+		return
+	}
+
+	if inst.Status != session.StatusRunning {
+		m.notifyWarn("Session not running")
+		return
+	}
+	if err := inst.StartOutputLog(); err != nil {
+		m.notify(toastError, "Failed to start the output log: %v", err)
+		return
+	}
+	inst.OutputLog = true
+	m.storage.UpdateInstance(inst)
+	m.notifySuccess("Logging the output of '%s' to %s", inst.Name, filepath.Join("logs", inst.ID+".log"))
+}
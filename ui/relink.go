@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// relinkNeededMsg is sent when a session could not start because its path is gone
+type relinkNeededMsg struct {
+	start pendingStart
+}
+
+// relinkNeededCmd reports a start held back by a missing path
+func relinkNeededCmd(p pendingStart) tea.Cmd {
+	return func() tea.Msg {
+		return relinkNeededMsg{start: p}
+	}
+}
+
+// detectMissingPaths flags sessions whose working directory no longer exists
+func (m *Model) detectMissingPaths() {
+	m.pathMissing = make(map[string]bool)
+	for _, inst := range m.instances {
+		if inst.PathMissing() {
+			m.pathMissing[inst.ID] = true
+		}
+	}
+}
+
+// handleRelinkNeeded opens the relink wizard for a start that found its path missing
+func (m Model) handleRelinkNeeded(msg relinkNeededMsg) (tea.Model, tea.Cmd) {
+	m.pathMissing[msg.start.inst.ID] = true
+	if m.state != stateList {
+		m.notifyWarn("'%s' not started - %s no longer exists", msg.start.inst.Name, msg.start.inst.Path)
+		return m, nil
+	}
+	m.openRelink(msg.start.inst)
+	m.relinkStart = &msg.start
+	return m, textinput.Blink
+}
+
+// openRelink opens the relink wizard with directories that look like the moved path
+func (m *Model) openRelink(inst *session.Instance) {
+	m.relinkTarget = inst
+	m.relinkStart = nil
+	m.relinkSuggestions = session.RelinkSuggestions(inst.Path)
+	m.relinkCursor = 0
+	if len(m.relinkSuggestions) > 0 {
+		m.pathInput.SetValue(m.relinkSuggestions[0])
+	} else {
+		m.pathInput.SetValue(session.ExistingAncestor(inst.Path) + string(filepath.Separator))
+	}
+	m.pathInput.CursorEnd()
+	m.pathInput.Focus()
+	m.state = stateRelink
+}
+
+// handleRelinkKeys handles keyboard input in the relink wizard
+func (m Model) handleRelinkKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	inst := m.relinkTarget
+	switch msg.String() {
+	case "esc":
+		m.relinkTarget = nil
+		m.relinkStart = nil
+		m.state = stateList
+		return m, nil
+	case "up", "down":
+		if len(m.relinkSuggestions) == 0 {
+			return m, nil
+		}
+		if msg.String() == "up" && m.relinkCursor > 0 {
+			m.relinkCursor--
+		} else if msg.String() == "down" && m.relinkCursor < len(m.relinkSuggestions)-1 {
+			m.relinkCursor++
+		}
+		m.pathInput.SetValue(m.relinkSuggestions[m.relinkCursor])
+		m.pathInput.CursorEnd()
+		return m, nil
+	case "enter":
+		moved, err := inst.Relink(m.pathInput.Value())
+		if err != nil {
+			m.notify(toastError, "Relink failed: %v", err)
+			return m, nil
+		}
+		m.storage.UpdateInstance(inst)
+		delete(m.pathMissing, inst.ID)
+		if moved > 0 {
+			m.notifySuccess("Relinked '%s' to %s (%d conversation(s) moved)", inst.Name, inst.Path, moved)
+		} else {
+			m.notifySuccess("Relinked '%s' to %s", inst.Name, inst.Path)
+		}
+
+		start := m.relinkStart
+		m.relinkTarget = nil
+		m.relinkStart = nil
+		m.state = stateList
+		if start != nil {
+			return m, m.startSessionAsync(start.inst, start.resumeID, start.attach)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.pathInput, cmd = m.pathInput.Update(msg)
+	return m, cmd
+}
+
+// relinkView renders the relink wizard as an overlay
+func (m Model) relinkView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 70
+	inst := m.relinkTarget
+	if inst != nil {
+		boxContent.WriteString(fmt.Sprintf("  The directory of '%s' no longer exists:\n", truncateRunes(inst.Name, 30)))
+		boxContent.WriteString("  " + errorStyle.Render(truncateRunes(inst.Path, boxWidth-8)) + "\n\n")
+	}
+
+	if len(m.relinkSuggestions) > 0 {
+		boxContent.WriteString(dimStyle.Render("  Found elsewhere:") + "\n")
+		for i, path := range m.relinkSuggestions {
+			prefix := "   "
+			if i == m.relinkCursor && m.pathInput.Value() == path {
+				prefix = " ❯ "
+			}
+			boxContent.WriteString(prefix + truncateRunes(path, boxWidth-8) + "\n")
+		}
+		boxContent.WriteString("\n")
+	}
+
+	boxContent.WriteString("  New path:\n")
+	boxContent.WriteString("  " + m.pathInput.View() + "\n\n")
+	if inst != nil && (inst.Agent == session.AgentClaude || inst.Agent == "") {
+		boxContent.WriteString(dimStyle.Render("  Claude conversations move along, so resume keeps working") + "\n\n")
+	}
+	boxContent.WriteString(helpStyle.Render("  ↑/↓: pick found path  enter: relink  esc: cancel"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Relink Session ", boxContent.String(), boxWidth, ColorOrange)
+}
@@ -0,0 +1,89 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// hostBadge returns the host label of a session row ("" for local sessions)
+func hostBadge(inst *session.Instance) string {
+	if !inst.IsRemote() {
+		return ""
+	}
+	return " @" + truncateRunes(inst.Host, 16)
+}
+
+// remotePolledMsg carries one tick's tmux reads of a session on an SSH host
+type remotePolledMsg struct {
+	inst      *session.Instance
+	alive     bool
+	checkExit bool     // The main pane was read (slow tick)
+	pane      mainPane // State of the main pane
+	poll      instancePoll
+
+	withPreview    bool // Selected session: preview captured
+	preview        string
+	previewErr     error
+	withScrollback bool // Selected session on a slow tick: scrollback read
+	scrollback     []session.Scrollback
+	scrollbackErr  error
+}
+
+// remotePollCmd reads a remote session's status, output and activity (and the
+// preview of the selected one) over SSH off the UI loop. It works on a
+// snapshot; handleRemotePolled applies the result.
+func remotePollCmd(inst *session.Instance, selected, slowTick bool) tea.Cmd {
+	snapshot := inst.Snapshot()
+	return func() tea.Msg {
+		msg := remotePolledMsg{inst: inst, alive: snapshot.IsAlive(), checkExit: slowTick}
+		if msg.alive && slowTick {
+			msg.pane = readMainPane(snapshot)
+		}
+		msg.poll = pollInstance(snapshot, msg.alive)
+		if selected {
+			msg.withPreview = true
+			msg.preview, msg.previewErr = snapshot.GetPreview(PreviewLineCount)
+			if msg.alive && slowTick {
+				msg.withScrollback = true
+				msg.scrollback, msg.scrollbackErr = snapshot.Scrollback()
+			}
+		}
+		return msg
+	}
+}
+
+// pollRemote starts reading a remote session unless the last read is still running
+func (m *Model) pollRemote(inst *session.Instance, selected, slowTick bool) tea.Cmd {
+	if m.remotePolls[inst.ID] {
+		return nil
+	}
+	m.remotePolls[inst.ID] = true
+	return remotePollCmd(inst, selected, slowTick)
+}
+
+// handleRemotePolled applies what a remote session's poll read
+func (m *Model) handleRemotePolled(msg remotePolledMsg) {
+	delete(m.remotePolls, msg.inst.ID)
+	// Deleted, or being started in the meantime
+	idx := m.findInstanceIndex(msg.inst.ID)
+	if idx < 0 || m.isBusy(msg.inst) {
+		return
+	}
+	inst := m.instances[idx]
+
+	wasRunning := inst.Status == session.StatusRunning
+	inst.SetAlive(msg.alive)
+	if msg.checkExit || inst.Status != session.StatusRunning {
+		m.applyExited(inst, wasRunning, msg.pane)
+	}
+	m.applyPoll(inst, msg.poll)
+
+	if selected := m.getSelectedInstance(); msg.withPreview && selected != nil && selected.ID == inst.ID {
+		m.applyPreview(inst, msg.preview, msg.previewErr)
+		if inst.Status != session.StatusRunning {
+			delete(m.scrollback, inst.ID)
+		} else if msg.withScrollback {
+			m.applyScrollback(inst, msg.scrollback, msg.scrollbackErr)
+		}
+	}
+}
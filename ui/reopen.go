@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// uniqueInstanceName returns name, suffixed if another session already uses it
+func (m Model) uniqueInstanceName(name string) string {
+	taken := make(map[string]bool, len(m.instances))
+	for _, inst := range m.instances {
+		taken[inst.Name] = true
+	}
+	candidate := name
+	for n := 2; taken[candidate]; n++ {
+		candidate = fmt.Sprintf("%s (%d)", name, n)
+	}
+	return candidate
+}
+
+// reopenLastClosed recreates (if deleted) and restarts the most recently closed
+// session, or restores the last deleted group when that happened later
+func (m *Model) reopenLastClosed() tea.Cmd {
+	if group, _ := m.storage.LastDeletedGroup(); group != nil {
+		closed, _ := m.storage.LoadClosed()
+		if len(closed) == 0 || !closed[len(closed)-1].ClosedAt.After(group.DeletedAt) {
+			m.undoGroupDelete()
+			return nil
+		}
+	}
+	for {
+		closed, err := m.storage.PopClosed()
+		if err != nil {
+			m.showError(err)
+			return nil
+		}
+		if closed == nil {
+			m.notifyInfo("No recently closed sessions")
+			return nil
+		}
+
+		var inst *session.Instance
+		if idx := m.findInstanceIndex(closed.Instance.ID); idx != -1 {
+			inst = m.instances[idx]
+		}
+		if inst != nil && inst.Status == session.StatusRunning {
+			// Already reopened some other way - try the next one
+			continue
+		}
+
+		if inst == nil {
+			// Deleted - recreate from the archived metadata
+			restored := closed.Instance
+			inst = &restored
+			inst.Status = session.StatusStopped
+			inst.Name = m.uniqueInstanceName(inst.Name)
+			if inst.GroupID != "" && m.findGroupIndex(inst.GroupID) == -1 {
+				inst.GroupID = ""
+			}
+			if err := m.storage.AddInstance(inst); err != nil {
+				m.showError(fmt.Errorf("failed to restore session: %w", err))
+				return nil
+			}
+			m.instances = append(m.instances, inst)
+		}
+
+		if closed.ResumeID != "" {
+			inst.ResumeSessionID = closed.ResumeID
+		}
+		m.buildVisibleItems()
+		m.selectInstance(inst)
+		m.notifySuccess("Reopened '%s'", inst.Name)
+		if err := session.CheckAgentCommand(inst); err != nil {
+			m.showError(err)
+			return nil
+		}
+		return m.startSessionAsync(inst, inst.ResumeSessionID, false)
+	}
+}
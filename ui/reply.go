@@ -0,0 +1,89 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// readPendingQuestion reads the choice menu of the first waiting window of a session
+func readPendingQuestion(inst *session.Instance, activity map[int]session.SessionActivity) *session.PendingQuestion {
+	windows := []int{0}
+	for _, fw := range inst.FollowedWindows {
+		windows = append(windows, fw.Index)
+	}
+	for _, idx := range windows {
+		if activity[idx] != session.ActivityWaiting {
+			continue
+		}
+		if q := inst.PendingQuestion(idx); q != nil {
+			return q
+		}
+	}
+	return nil
+}
+
+// setPendingQuestion stores the choice menu a session waits on (nil = none)
+func (m *Model) setPendingQuestion(inst *session.Instance, q *session.PendingQuestion) {
+	if q == nil || m.activityState[inst.ID] != session.ActivityWaiting {
+		delete(m.pendingQuestions, inst.ID)
+		return
+	}
+	m.pendingQuestions[inst.ID] = q
+}
+
+// handleQuickReply answers the selected session's pending question with a number key
+func (m *Model) handleQuickReply(key string) {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return
+	}
+	q := m.pendingQuestions[inst.ID]
+	if q == nil {
+		return
+	}
+	for _, opt := range q.Options {
+		if opt.Key != key {
+			continue
+		}
+		if err := inst.SendReply(q.Window, key); err != nil {
+			m.notify(toastError, "Failed to reply: %v", err)
+			return
+		}
+		m.notifySuccess("Answered '%s' in '%s'", truncateRunes(opt.Label, 40), inst.Name)
+		delete(m.pendingQuestions, inst.ID)
+		return
+	}
+}
+
+// pendingQuestionLines renders the waiting question and its reply keys for the preview header
+func (m Model) pendingQuestionLines(inst *session.Instance, width int) []string {
+	q := m.pendingQuestions[inst.ID]
+	if q == nil {
+		return nil
+	}
+	keyStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorCyan)).Bold(true)
+	var lines []string
+	if q.Question != "" {
+		lines = append(lines, "  "+projectLabelStyle.Render("Waiting: ")+waitingStyle.Render(truncateRunes(q.Question, width-12)))
+	}
+
+	// Short labels so all choices fit on one line
+	labelWidth := (width - 6) / len(q.Options)
+	if labelWidth > 28 {
+		labelWidth = 28
+	}
+	var parts []string
+	for _, opt := range q.Options {
+		label := opt.Label
+		if idx := strings.Index(label, " ("); idx > 0 {
+			label = label[:idx] // Drop "(shift+tab)"-style key hints
+		}
+		parts = append(parts, keyStyle.Render(opt.Key)+" "+truncateRunes(label, labelWidth-3))
+	}
+	lines = append(lines, "  "+strings.Join(parts, dimStyle.Render(" · ")))
+	lines = append(lines, dimStyle.Render(fmt.Sprintf("  press %s-%s to answer without attaching", q.Options[0].Key, q.Options[len(q.Options)-1].Key)))
+	return lines
+}
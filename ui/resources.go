@@ -0,0 +1,156 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// ResourceSampleInterval is how often session CPU/memory is measured
+const ResourceSampleInterval = 2 * time.Second
+
+// sampleResources refreshes CPU/memory usage when the last sample is old enough
+func (m *Model) sampleResources() {
+	if m.state != stateList && m.state != stateResources {
+		return
+	}
+	if time.Since(m.resourcesAt) < ResourceSampleInterval {
+		return
+	}
+	m.resourcesAt = time.Now()
+	m.sampleNetwork()
+	usage, err := m.resourceSampler.Sample(m.instances)
+	if err != nil {
+		// No tmux server yet or ps unavailable - nothing to show
+		m.resources = nil
+		return
+	}
+	m.resources = usage
+}
+
+// resourceSummary returns the preview header text for a session ("" if not measured)
+func (m Model) resourceSummary(inst *session.Instance) string {
+	if inst.Status != session.StatusRunning {
+		return ""
+	}
+	u, ok := m.resources[inst.ID]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("CPU %.0f%% · %s · %d procs", u.CPU, u.FormatMem(), u.Procs)
+}
+
+// resourceRows returns the measured sessions, sorted by CPU or memory (highest first)
+func (m Model) resourceRows() []*session.Instance {
+	var rows []*session.Instance
+	for _, inst := range m.instances {
+		if _, ok := m.resources[inst.ID]; ok && inst.Status == session.StatusRunning {
+			rows = append(rows, inst)
+		}
+	}
+	sort.SliceStable(rows, func(a, b int) bool {
+		ua, ub := m.resources[rows[a].ID], m.resources[rows[b].ID]
+		if m.resourceSortMem {
+			return ua.MemKB > ub.MemKB
+		}
+		return ua.CPU > ub.CPU
+	})
+	return rows
+}
+
+// handleShowResources opens the resource usage view
+func (m *Model) handleShowResources() {
+	m.resourcesAt = time.Time{} // Sample right away
+	m.state = stateResources
+	m.sampleResources()
+	m.resourceCursor = 0
+}
+
+// handleResourcesKeys handles keyboard input in the resource usage view
+func (m Model) handleResourcesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	rows := m.resourceRows()
+	switch msg.String() {
+	case "esc", "q", "%":
+		m.state = stateList
+	case "up", "k":
+		if m.resourceCursor > 0 {
+			m.resourceCursor--
+		}
+	case "down", "j":
+		if m.resourceCursor < len(rows)-1 {
+			m.resourceCursor++
+		}
+	case "tab", "s":
+		m.resourceSortMem = !m.resourceSortMem
+		m.resourceCursor = 0
+	case "p":
+		// Processes of the highlighted session
+		if m.resourceCursor < len(rows) {
+			m.handleShowProcesses(rows[m.resourceCursor])
+		}
+	case "enter":
+		// Jump to the session in the list
+		if m.resourceCursor < len(rows) {
+			m.selectInstance(rows[m.resourceCursor])
+		}
+		m.state = stateList
+	}
+	return m, nil
+}
+
+// resourcesView renders the resource usage view as an overlay
+func (m Model) resourcesView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 70
+	rows := m.resourceRows()
+	if len(rows) == 0 {
+		boxContent.WriteString(dimStyle.Render("  No running sessions"))
+		boxContent.WriteString("\n")
+	} else {
+		nameWidth := boxWidth - 34
+		header := fmt.Sprintf("  %-*s %7s %9s %6s", nameWidth, "Session", "CPU", "Memory", "Procs")
+		boxContent.WriteString(dimStyle.Render(header))
+		boxContent.WriteString("\n")
+
+		maxVisible := m.height - 12
+		if maxVisible < 5 {
+			maxVisible = 5
+		}
+		start := 0
+		if m.resourceCursor >= maxVisible {
+			start = m.resourceCursor - maxVisible + 1
+		}
+		hot := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorOrange))
+		for i := start; i < len(rows) && i < start+maxVisible; i++ {
+			inst := rows[i]
+			u := m.resources[inst.ID]
+			cpu := fmt.Sprintf("%6.0f%%", u.CPU)
+			if u.CPU >= 80 {
+				cpu = hot.Render(cpu)
+			}
+			line := fmt.Sprintf(" %-*s %s %9s %6d", nameWidth, truncateRunes(inst.Name, nameWidth), cpu, u.FormatMem(), u.Procs)
+			if i == m.resourceCursor {
+				boxContent.WriteString(" ❯" + line + "\n")
+			} else {
+				boxContent.WriteString("  " + line + "\n")
+			}
+		}
+	}
+
+	boxContent.WriteString("\n")
+	sortBy := "CPU"
+	if m.resourceSortMem {
+		sortBy = "memory"
+	}
+	boxContent.WriteString(helpStyle.Render(fmt.Sprintf("  sorted by %s · tab: sort  p: processes  enter: select  esc: close", sortBy)))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Resource Usage ", boxContent.String(), boxWidth, ColorCyan)
+}
@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// restartState tracks the automatic restarts of a session's agent
+type restartState struct {
+	attempt   int       // Restarts in a row (reset once the agent keeps running)
+	due       time.Time // When the next restart runs (zero = none pending)
+	exited    time.Time // When the exit the pending restart is for was seen
+	restarted time.Time // When the last automatic restart ran
+	gaveUp    bool      // MaxRestartAttempts reached
+	expected  bool      // The next exit was asked for (main tab stopped) - don't restart
+}
+
+// restartFor returns the restart state of a session, creating it if needed
+func (m *Model) restartFor(inst *session.Instance) *restartState {
+	st := m.restarts[inst.ID]
+	if st == nil {
+		st = &restartState{}
+		m.restarts[inst.ID] = st
+	}
+	return st
+}
+
+// handleCycleRestartPolicy switches the selected session to the next restart policy
+func (m *Model) handleCycleRestartPolicy() {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return
+	}
+	inst.RestartPolicy = inst.RestartPolicy.Next()
+	delete(m.restarts, inst.ID)
+	m.storage.UpdateInstance(inst)
+	switch inst.RestartPolicy {
+	case session.RestartOnCrash:
+		m.notifyInfo("'%s' restarts when its agent crashes or the tmux session vanishes", inst.Name)
+	case session.RestartAlways:
+		m.notifyInfo("'%s' restarts whenever its agent exits", inst.Name)
+	default:
+		m.notifyInfo("'%s' is no longer restarted automatically", inst.Name)
+	}
+}
+
+// expectExit keeps the next exit of a session's agent from being restarted
+func (m *Model) expectExit(inst *session.Instance) {
+	if inst.RestartPolicy != session.RestartNever {
+		m.restartFor(inst).expected = true
+	}
+}
+
+// scheduleRestart queues a restart of an agent that exited, if its policy asks
+// for one. known is false when the exit status could not be read.
+func (m *Model) scheduleRestart(inst *session.Instance, code int, known bool) {
+	if inst.RestartPolicy == session.RestartNever {
+		return
+	}
+	st := m.restartFor(inst)
+	if st.expected {
+		st.expected = false
+		return
+	}
+	if !inst.RestartPolicy.ShouldRestart(code, known) {
+		return
+	}
+	if !st.restarted.IsZero() && time.Since(st.restarted) > session.RestartStableAfter {
+		st.attempt = 0
+	}
+	st.attempt++
+	if st.attempt > session.MaxRestartAttempts {
+		st.gaveUp = true
+		st.due = time.Time{}
+		m.notify(toastError, "'%s' exited %d times in a row - not restarting it again", inst.Name, session.MaxRestartAttempts)
+		return
+	}
+	delay := session.RestartBackoff(st.attempt)
+	st.gaveUp = false
+	st.exited = time.Now()
+	st.due = st.exited.Add(delay)
+	m.notifyWarn("'%s' exited - restarting in %s (attempt %d/%d)", inst.Name, delay, st.attempt, session.MaxRestartAttempts)
+}
+
+// restartDue runs the restarts whose backoff has passed
+func (m *Model) restartDue() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, inst := range m.instances {
+		st := m.restarts[inst.ID]
+		if st == nil || st.due.IsZero() || time.Now().Before(st.due) || m.isBusy(inst) {
+			continue
+		}
+		st.due = time.Time{}
+		if inst.StoppedAt.After(st.exited) {
+			continue // Stopped from here while the restart was pending
+		}
+		if m.dryRun {
+			m.notifyWarn("Dry-run: automatic restart of '%s' skipped", inst.Name)
+			continue
+		}
+		if inst.Status == session.StatusRunning && !inst.MainPaneDead() {
+			continue // Respawned by hand in the meantime
+		}
+		st.restarted = time.Now()
+		inst.Restarts++
+		if inst.Status == session.StatusRunning {
+			// The tmux session is still there with the dead pane - respawn it
+			if err := inst.RestartAgent(); err != nil {
+				m.notify(toastError, "Failed to restart '%s': %v", inst.Name, err)
+			} else {
+				m.notifyInfo("Restarted the agent of '%s' (attempt %d/%d)", inst.Name, st.attempt, session.MaxRestartAttempts)
+			}
+			m.storage.UpdateInstance(inst)
+			continue
+		}
+		m.storage.UpdateInstance(inst)
+		cmds = append(cmds, m.runStart(inst, inst.LastResumeID(), false))
+	}
+	return tea.Batch(cmds...)
+}
+
+// restartSummary returns the preview line about automatic restarts ("" = none)
+func (m Model) restartSummary(inst *session.Instance) string {
+	if inst.RestartPolicy == session.RestartNever && inst.Restarts == 0 {
+		return ""
+	}
+	text := "policy " + inst.RestartPolicy.String()
+	if inst.Restarts > 0 {
+		text += fmt.Sprintf(" · restarted %d×", inst.Restarts)
+	}
+	if st := m.restarts[inst.ID]; st != nil {
+		switch {
+		case st.gaveUp:
+			text += fmt.Sprintf(" · gave up after %d in a row", session.MaxRestartAttempts)
+		case !st.due.IsZero():
+			wait := time.Until(st.due).Round(time.Second)
+			if wait < 0 {
+				wait = 0
+			}
+			text += fmt.Sprintf(" · next in %s (%d/%d)", wait, st.attempt, session.MaxRestartAttempts)
+		}
+	}
+	return text
+}
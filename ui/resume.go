@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// resumeForkedMsg is sent when a conversation was forked before resuming it in a window
+type resumeForkedMsg struct {
+	inst         *session.Instance
+	windowIndex  int
+	tabName      string // Non-empty = open the fork in a new tab instead
+	newSessionID string
+	err          error
+}
+
+// activeWindowIndex returns the index of the active tab (0 when stopped)
+func activeWindowIndex(inst *session.Instance) int {
+	if inst.Status != session.StatusRunning {
+		return 0
+	}
+	for _, w := range inst.GetWindowList() {
+		if w.Active {
+			return w.Index
+		}
+	}
+	return 0
+}
+
+// openResumePicker lists past conversations of a window's agent to resume in that window
+func (m *Model) openResumePicker(inst *session.Instance, windowIdx int) error {
+	agentType := inst.WindowAgent(windowIdx)
+
+	// Terminal windows don't support resume
+	if agentType == session.AgentTerminal {
+		return fmt.Errorf("terminal windows don't support session resume")
+	}
+	if !session.AgentConfigs[agentType].SupportsResume {
+		return fmt.Errorf("resume not supported for %s agent", agentType)
+	}
+
+	sessions, err := session.ListSessionsForAgent(agentType, inst.Path)
+	if err != nil {
+		return err
+	}
+	if len(sessions) == 0 {
+		return fmt.Errorf("no previous %s sessions found", agentType)
+	}
+	m.agentSessions = sessions
+	m.resumeAgentType = agentType   // Store which agent type we're resuming
+	m.resumeWindowIndex = windowIdx // Store which window to resume
+	m.resumeFork = false
+	m.sessionCursor = 1 // Start with first session selected (0 is "new session")
+	m.state = stateSelectAgentSession
+	return nil
+}
+
+// canForkResume reports whether the open picker can fork the chosen conversation first
+func (m Model) canForkResume() bool {
+	return m.pendingInstance == nil && m.resumeAgentType == session.AgentClaude
+}
+
+// applyWindowResume points a window at a conversation and respawns it if running
+func (m *Model) applyWindowResume(inst *session.Instance, windowIdx int, resumeID string) tea.Cmd {
+	var cmd tea.Cmd
+	if windowIdx == 0 {
+		// Main window
+		inst.ResumeSessionID = resumeID
+		if inst.Status == session.StatusRunning {
+			// Respawn just the main window with new resume ID
+			inst.RespawnWindowWithResume(0, resumeID)
+		} else {
+			cmd = m.startSessionAsync(inst, resumeID, false)
+		}
+	} else {
+		// Followed window
+		for idx, fw := range inst.FollowedWindows {
+			if fw.Index == windowIdx {
+				inst.FollowedWindows[idx].ResumeSessionID = resumeID
+				if inst.Status == session.StatusRunning {
+					// Respawn just this window with new resume ID
+					inst.RespawnWindowWithResume(fw.Index, resumeID)
+				}
+				break
+			}
+		}
+	}
+	m.storage.UpdateInstance(inst)
+	return cmd
+}
+
+// forkAndResumeCmd forks a conversation off the UI loop before resuming it in a window or new tab
+func forkAndResumeCmd(inst *session.Instance, windowIdx int, tabName string, sessionID string) tea.Cmd {
+	snapshot := inst.Snapshot()
+	return func() tea.Msg {
+		newSessionID, err := snapshot.ForkConversation(sessionID)
+		return resumeForkedMsg{inst: inst, windowIndex: windowIdx, tabName: tabName, newSessionID: newSessionID, err: err}
+	}
+}
+
+// resumeTabName derives a tab name from a past conversation's prompt
+func resumeTabName(agent session.AgentType, as session.AgentSession) string {
+	name := as.LastPrompt
+	if name == "" || name == as.SessionID {
+		name = as.FirstPrompt
+	}
+	if name == "" || name == as.SessionID {
+		return string(agent)
+	}
+	name = strings.Join(strings.Fields(name), " ")
+	return truncateRunes(name, 20)
+}
+
+// openResumedTab resumes a past conversation in a new tab of a running session
+func (m *Model) openResumedTab(inst *session.Instance, agent session.AgentType, name string, sessionID string) error {
+	if err := inst.NewResumedTab(name, agent, sessionID, ""); err != nil {
+		return err
+	}
+	configureTmuxStatusBar(inst.TmuxSessionName(), inst.Name, inst.Color, inst.BgColor, inst.AutoYes)
+	m.storage.UpdateInstance(inst)
+	return nil
+}
+
+// handleResumeForked resumes the freshly forked conversation in its window
+func (m Model) handleResumeForked(msg resumeForkedMsg) (tea.Model, tea.Cmd) {
+	m.endOperation(msg.inst)
+	if msg.err != nil {
+		m.showError(fmt.Errorf("fork failed: %w", msg.err))
+		return m, nil
+	}
+	if msg.tabName != "" {
+		if err := m.openResumedTab(msg.inst, session.AgentClaude, msg.tabName, msg.newSessionID); err != nil {
+			m.showError(fmt.Errorf("failed to open tab: %w", err))
+			return m, nil
+		}
+		m.notifySuccess("Opened a fork of the conversation in tab '%s'", msg.tabName)
+		return m, nil
+	}
+	cmd := m.applyWindowResume(msg.inst, msg.windowIndex, msg.newSessionID)
+	m.notifySuccess("Resumed a fork of the conversation in '%s'", msg.inst.Name)
+	return m, cmd
+}
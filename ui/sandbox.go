@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// openSandboxPicker shows the sandbox wrappers for starting the selected session
+func (m *Model) openSandboxPicker() {
+	inst := m.getSelectedInstance()
+	if inst == nil || m.isBusy(inst) {
+		return
+	}
+	inst.UpdateStatus()
+	if inst.Status == session.StatusRunning {
+		m.notifyWarn("Stop '%s' first to start it sandboxed", inst.Name)
+		return
+	}
+	if inst.Runtime != "" && inst.Sandbox == "" {
+		m.notifyWarn("'%s' runs in a %s container - sandboxes can't be combined with a runtime", inst.Name, inst.Runtime)
+		return
+	}
+
+	m.sandboxWrappers = session.LoadSandboxWrappers()
+	m.sandboxCursor = 0
+	for idx, w := range m.sandboxWrappers {
+		if w.Name == inst.Sandbox {
+			m.sandboxCursor = idx
+		}
+	}
+	m.state = stateSandboxPicker
+}
+
+// sandboxOptionCount includes the "normal start" entry for already sandboxed sessions
+func (m Model) sandboxOptionCount() int {
+	if inst := m.getSelectedInstance(); inst != nil && inst.Sandbox != "" {
+		return len(m.sandboxWrappers) + 1
+	}
+	return len(m.sandboxWrappers)
+}
+
+// handleSandboxPickerKeys handles keyboard input in the sandbox picker
+func (m Model) handleSandboxPickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.state = stateList
+	case "up", "k":
+		if m.sandboxCursor > 0 {
+			m.sandboxCursor--
+		}
+	case "down", "j":
+		if m.sandboxCursor < m.sandboxOptionCount()-1 {
+			m.sandboxCursor++
+		}
+	case "enter":
+		inst := m.getSelectedInstance()
+		if inst == nil {
+			m.state = stateList
+			return m, nil
+		}
+		if m.sandboxCursor < len(m.sandboxWrappers) {
+			w := m.sandboxWrappers[m.sandboxCursor]
+			if !w.Available() {
+				m.notifyWarn("'%s' is not installed", w.Binary())
+				return m, nil
+			}
+			if inst.Runtime != "" {
+				m.notifyWarn("Sandboxes can't be combined with the %s runtime", inst.Runtime)
+				return m, nil
+			}
+			inst.Sandbox = w.Name
+		} else {
+			inst.Sandbox = ""
+		}
+		m.storage.UpdateInstance(inst)
+		m.state = stateList
+
+		if err := session.CheckAgentCommand(inst); err != nil {
+			m.showError(err)
+			return m, nil
+		}
+		return m, m.startSessionAsync(inst, "", false)
+	}
+	return m, nil
+}
+
+// sandboxPickerView renders the sandbox picker as an overlay
+func (m Model) sandboxPickerView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 76
+	inst := m.getSelectedInstance()
+	if inst != nil {
+		boxContent.WriteString(fmt.Sprintf("  Start '%s' without auto-yes, wrapped in:\n\n", inst.Name))
+	}
+
+	missing := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorDarkGray))
+	for idx, w := range m.sandboxWrappers {
+		prefix := "  "
+		if idx == m.sandboxCursor {
+			prefix = " ❯"
+		}
+		line := fmt.Sprintf("%s %-12s %s", prefix, w.Name, truncateRunes(w.Description, boxWidth-20))
+		if !w.Available() {
+			line = missing.Render(fmt.Sprintf("%s %-12s (%s not installed)", prefix, w.Name, w.Binary()))
+		}
+		boxContent.WriteString(line + "\n")
+	}
+	if inst != nil && inst.Sandbox != "" {
+		prefix := "  "
+		if m.sandboxCursor == len(m.sandboxWrappers) {
+			prefix = " ❯"
+		}
+		boxContent.WriteString(fmt.Sprintf("%s %-12s %s\n", prefix, "off", "Normal start (remove sandbox)"))
+	}
+
+	if m.sandboxCursor < len(m.sandboxWrappers) && inst != nil {
+		w := m.sandboxWrappers[m.sandboxCursor]
+		boxContent.WriteString("\n")
+		boxContent.WriteString(dimStyle.Render("  " + truncateRunes(w.Wrap("<agent>", inst.Path), boxWidth-6)))
+		boxContent.WriteString("\n")
+	}
+
+	boxContent.WriteString("\n")
+	boxContent.WriteString(helpStyle.Render("  enter: start  esc: cancel  (wrappers: sandbox.json)"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Start Sandboxed ", boxContent.String(), boxWidth, ColorOrange)
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/izll/agent-session-manager/session"
+	"github.com/izll/agent-session-manager/session/scripts"
+)
+
+// hexColorRe matches a #RRGGBB color
+var hexColorRe = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// resolveColorName maps a color option name (e.g. "teal") or #RRGGBB to a stored color value
+func resolveColorName(name string) (string, bool) {
+	name = strings.TrimSpace(name)
+	if hexColorRe.MatchString(name) {
+		return strings.ToUpper(name), true
+	}
+	for _, opt := range colorOptions {
+		if strings.EqualFold(opt.Name, name) {
+			return opt.Color, true
+		}
+	}
+	return "", false
+}
+
+// applyScriptActions performs the actions queued by user scripts
+func (m *Model) applyScriptActions() {
+	if m.scripts == nil {
+		return
+	}
+	for _, a := range m.scripts.Drain() {
+		if a.Kind == scripts.ActionError {
+			// Lua errors carry a stack traceback - the first line is enough for a toast
+			m.notify(toastError, "Script %s: %s", a.Script, strings.SplitN(a.Text, "\n", 2)[0])
+			continue
+		}
+		if a.Kind == scripts.ActionNotify {
+			m.notifyInfo("%s", a.Text)
+			continue
+		}
+
+		idx := m.findInstanceIndex(a.SessionID)
+		if idx < 0 {
+			m.notifyWarn("Script %s: unknown session '%s'", a.Script, a.SessionID)
+			continue
+		}
+		inst := m.instances[idx]
+
+		switch a.Kind {
+		case scripts.ActionSendPrompt:
+			if inst.Status != session.StatusRunning || m.isBusy(inst) {
+				m.notifyWarn("Script %s: '%s' is not running", a.Script, inst.Name)
+				continue
+			}
+			if err := inst.SendPrompt(a.Text); err != nil {
+				m.notify(toastError, "Script %s: %v", a.Script, err)
+			}
+		case scripts.ActionRename:
+			if strings.TrimSpace(a.Text) == "" || inst.Name == a.Text {
+				continue
+			}
+			inst.Name = a.Text
+			m.storage.UpdateInstance(inst)
+			m.buildVisibleItems()
+		case scripts.ActionSetColor:
+			color, ok := resolveColorName(a.Text)
+			if !ok {
+				m.notifyWarn("Script %s: unknown color '%s'", a.Script, a.Text)
+				continue
+			}
+			if inst.Color == color {
+				continue
+			}
+			inst.Color = color
+			m.storage.UpdateInstance(inst)
+		}
+	}
+}
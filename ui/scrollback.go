@@ -0,0 +1,167 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// formatLineCount formats a line count compactly (950, 12.3k, 50k)
+func formatLineCount(n int) string {
+	if n < 1000 {
+		return fmt.Sprintf("%d", n)
+	}
+	return strings.TrimSuffix(fmt.Sprintf("%.1f", float64(n)/1000), ".0") + "k"
+}
+
+// refreshScrollback reads a running session's scrollback usage
+func (m *Model) refreshScrollback(inst *session.Instance) {
+	if inst.Status != session.StatusRunning {
+		delete(m.scrollback, inst.ID)
+		return
+	}
+	usage, err := inst.Scrollback()
+	m.applyScrollback(inst, usage, err)
+}
+
+// applyScrollback stores a session's scrollback usage and warns once when its
+// main window starts dropping the oldest output
+func (m *Model) applyScrollback(inst *session.Instance, usage []session.Scrollback, err error) {
+	wasFull := m.scrollbackFull(inst)
+	if err != nil {
+		delete(m.scrollback, inst.ID)
+		return
+	}
+	m.scrollback[inst.ID] = usage
+	if !wasFull && m.scrollbackFull(inst) {
+		m.notifyWarn("Scrollback of '%s' is nearly full - oldest output will be dropped (%s to raise the limit)", inst.Name, m.keys.help("scrollback"))
+	}
+}
+
+// mainScrollback returns the last read scrollback usage of a session's main window
+func (m Model) mainScrollback(inst *session.Instance) (session.Scrollback, bool) {
+	for _, s := range m.scrollback[inst.ID] {
+		if s.Window == 0 {
+			return s, true
+		}
+	}
+	return session.Scrollback{}, false
+}
+
+// scrollbackFull reports whether the main window's scrollback is nearly full
+func (m Model) scrollbackFull(inst *session.Instance) bool {
+	s, ok := m.mainScrollback(inst)
+	return ok && s.Full()
+}
+
+// scrollbackSummary returns the preview text for the main window's scrollback ("" if unknown)
+func (m Model) scrollbackSummary(inst *session.Instance) string {
+	s, ok := m.mainScrollback(inst)
+	if !ok {
+		return ""
+	}
+	summary := fmt.Sprintf("%s / %s lines (%d%%)", formatLineCount(s.Used), formatLineCount(s.Limit), s.Percent())
+	if s.Full() {
+		return stoppedStyle.Render(summary+" - oldest output is dropped") + dimStyle.Render(fmt.Sprintf(" (%s raises)", m.keys.help("scrollback")))
+	}
+	return projectNameStyle.Render(summary)
+}
+
+// handleShowScrollback opens the scrollback dialog for the selected session
+func (m *Model) handleShowScrollback() {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return
+	}
+	m.refreshScrollback(inst)
+	m.scrollbackTarget = inst
+	m.state = stateScrollback
+}
+
+// stepHistoryLimit moves a scrollback limit to the next or previous choice
+func stepHistoryLimit(current int, up bool) int {
+	limits := session.HistoryLimits
+	if up {
+		for _, v := range limits {
+			if v > current {
+				return v
+			}
+		}
+		return limits[len(limits)-1]
+	}
+	for i := len(limits) - 1; i >= 0; i-- {
+		if limits[i] < current {
+			return limits[i]
+		}
+	}
+	return limits[0]
+}
+
+// handleScrollbackKeys handles keyboard input in the scrollback dialog
+func (m Model) handleScrollbackKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	inst := m.scrollbackTarget
+	limit := -1
+	switch msg.String() {
+	case "esc", "q", "enter", "#":
+		m.scrollbackTarget = nil
+		m.state = stateList
+	case "+", "=", "-":
+		limit = stepHistoryLimit(inst.EffectiveHistoryLimit(), msg.String() != "-")
+	case "d":
+		limit = 0
+	}
+	if limit < 0 {
+		return m, nil
+	}
+	if err := inst.SetHistoryLimit(limit); err != nil {
+		m.notify(toastError, "Failed to set the scrollback limit: %v", err)
+		return m, nil
+	}
+	m.storage.UpdateInstance(inst)
+	return m, nil
+}
+
+// scrollbackView renders the scrollback usage and limit dialog as an overlay
+func (m Model) scrollbackView() string {
+	inst := m.scrollbackTarget
+	if inst == nil {
+		return m.listView()
+	}
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+	boxWidth := 64
+	boxContent.WriteString(fmt.Sprintf("  Scrollback of '%s':\n\n", truncateRunes(inst.Name, 40)))
+
+	usage := m.scrollback[inst.ID]
+	if inst.Status != session.StatusRunning || len(usage) == 0 {
+		boxContent.WriteString(dimStyle.Render("  Not running - usage is shown while the session runs"))
+		boxContent.WriteString("\n")
+	}
+	warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorRed)).Bold(true)
+	for _, s := range usage {
+		row := fmt.Sprintf("  %d %-16s %7s / %-7s %3d%%", s.Window, truncateRunes(s.Name, 16), formatLineCount(s.Used), formatLineCount(s.Limit), s.Percent())
+		if s.Full() {
+			boxContent.WriteString(warnStyle.Render(row + "  full"))
+		} else {
+			boxContent.WriteString(row)
+		}
+		boxContent.WriteString("\n")
+	}
+
+	limit := formatLineCount(inst.EffectiveHistoryLimit()) + " lines"
+	if inst.HistoryLimit == 0 {
+		limit += dimStyle.Render(" (default)")
+	}
+	boxContent.WriteString("\n")
+	boxContent.WriteString(dimStyle.Render("  Limit for new windows: ") + limit)
+	boxContent.WriteString("\n")
+	boxContent.WriteString(dimStyle.Render("  Open windows keep their limit until the session restarts"))
+	boxContent.WriteString("\n\n")
+	boxContent.WriteString(helpStyle.Render("  +/-: change limit  d: default  esc: close"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Scrollback ", boxContent.String(), boxWidth, ColorAccent)
+}
@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// handleSearchEntryAction runs the copy/export/delete actions of the global search action menu
+func (m Model) handleSearchEntryAction(entry *session.HistoryEntry) (tea.Model, tea.Cmd) {
+	switch m.globalSearchActionCursor {
+	case 3:
+		// Copy the matched content
+		method := m.copyToClipboard(entry.Content)
+		m.notifySuccess("Copied %d characters via %s", len(entry.Content), method)
+		m.globalSearchSelectedEntry = nil
+		m.state = stateGlobalSearch
+
+	case 4:
+		// Export the whole conversation as Markdown
+		messages, err := entry.LoadConversation()
+		if err != nil {
+			m.notify(toastError, "Failed to load conversation: %v", err)
+			return m, nil
+		}
+		prefix := "asmgr-transcript-" + string(entry.Agent)
+		if id := entry.SessionID; id != "" {
+			if len(id) > 8 {
+				id = id[:8]
+			}
+			prefix += "-" + id
+		}
+		path, err := writeMarkdownFile(prefix, session.ConversationMarkdown("", entry, messages))
+		if err != nil {
+			m.notify(toastError, "Failed to export conversation: %v", err)
+			return m, nil
+		}
+		m.notifySuccess("Conversation exported to %s", path)
+		m.globalSearchSelectedEntry = nil
+		m.state = stateGlobalSearch
+
+	case 5:
+		// Delete transcript (asks for confirmation)
+		if !entry.CanDeleteTranscript() {
+			m.notifyWarn("%s history entries can't be deleted", entry.Agent)
+			return m, nil
+		}
+		m.state = stateConfirmDeleteTranscript
+	}
+	return m, nil
+}
+
+// handleConfirmDeleteTranscriptKeys handles keyboard input in the transcript delete confirmation
+func (m Model) handleConfirmDeleteTranscriptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.keys.answer(msg) {
+	case answerYes:
+		entry := m.globalSearchSelectedEntry
+		m.globalSearchSelectedEntry = nil
+		m.state = stateGlobalSearch
+		if entry == nil {
+			return m, nil
+		}
+		if err := entry.DeleteTranscript(); err != nil {
+			m.notify(toastError, "%v", err)
+			return m, nil
+		}
+
+		// Keep the index and current results in sync without a full reload
+		m.historyIndex.RemoveTranscript(entry)
+		m.globalSearchResults = session.RemoveTranscriptEntries(m.globalSearchResults, entry)
+		if m.globalSearchCursor >= len(m.globalSearchResults) && m.globalSearchCursor > 0 {
+			m.globalSearchCursor = len(m.globalSearchResults) - 1
+		}
+		m.globalSearchExpanded = -1
+		m.globalSearchConversation = nil
+		m.globalSearchScroll = 0
+		m.notifySuccess("Transcript deleted")
+		return m, m.loadConversationAsync()
+
+	case answerNo, "esc":
+		m.state = stateGlobalSearchAction
+	}
+	return m, nil
+}
+
+// confirmDeleteTranscriptView renders the transcript delete confirmation as an overlay
+func (m Model) confirmDeleteTranscriptView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+	if entry := m.globalSearchSelectedEntry; entry != nil {
+		boxContent.WriteString(fmt.Sprintf("  Permanently delete this %s transcript?\n", entry.Agent))
+		if entry.SessionFile != "" {
+			boxContent.WriteString(dimStyle.Render("  " + truncatePath(entry.SessionFile, 56)))
+		} else {
+			boxContent.WriteString(dimStyle.Render("  Session " + entry.SessionID))
+		}
+		boxContent.WriteString("\n\n")
+	}
+	boxContent.WriteString(helpStyle.Render("  y: yes  n: no"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Delete Transcript ", boxContent.String(), 64, "#FF5F87")
+}
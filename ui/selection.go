@@ -0,0 +1,101 @@
+package ui
+
+// selectionAnchor remembers the selected list item so the cursor can follow it
+// (or its nearest surviving neighbour) when the list is rebuilt
+type selectionAnchor struct {
+	keys   []string // Selected item first, then its neighbours nearest first (below before above)
+	cursor int      // Cursor position when remembered
+}
+
+// itemKey identifies a list row across rebuilds ("" = separator)
+func itemKey(item visibleItem) string {
+	switch {
+	case item.isGroup && item.group != nil:
+		return "group:" + item.group.ID
+	case item.instance != nil:
+		return item.instance.ID
+	}
+	return ""
+}
+
+// listKeys returns the keys of the rows the cursor currently indexes
+func (m *Model) listKeys() []string {
+	var keys []string
+	if m.usesVisibleItems() {
+		m.buildVisibleItems()
+		for _, item := range m.visibleItems {
+			keys = append(keys, itemKey(item))
+		}
+		return keys
+	}
+	instances := m.instances
+	if m.searchActive {
+		instances = m.getFilteredInstances()
+	}
+	for _, inst := range instances {
+		keys = append(keys, inst.ID)
+	}
+	return keys
+}
+
+// selectedKey returns the key of the selected row ("" = none)
+func (m *Model) selectedKey() string {
+	keys := m.listKeys()
+	if m.cursor < 0 || m.cursor >= len(keys) {
+		return ""
+	}
+	return keys[m.cursor]
+}
+
+// rememberSelection records the selected row and its neighbours
+func (m *Model) rememberSelection() selectionAnchor {
+	keys := m.listKeys()
+	anchor := selectionAnchor{cursor: m.cursor}
+	if m.cursor < 0 || m.cursor >= len(keys) {
+		return anchor
+	}
+	add := func(i int) {
+		if i >= 0 && i < len(keys) && keys[i] != "" {
+			anchor.keys = append(anchor.keys, keys[i])
+		}
+	}
+	add(m.cursor)
+	for d := 1; d < len(keys); d++ {
+		add(m.cursor + d)
+		add(m.cursor - d)
+	}
+	return anchor
+}
+
+// restoreSelection moves the cursor to the remembered row, or to its nearest
+// neighbour that is still listed; a row listed twice (favorites) resolves to
+// the occurrence closest to the old cursor
+func (m *Model) restoreSelection(anchor selectionAnchor) {
+	keys := m.listKeys()
+	for _, want := range anchor.keys {
+		best := -1
+		for i, key := range keys {
+			if key == want && (best < 0 || absInt(i-anchor.cursor) < absInt(best-anchor.cursor)) {
+				best = i
+			}
+		}
+		if best >= 0 {
+			m.cursor = best
+			return
+		}
+	}
+	if m.cursor >= len(keys) {
+		m.cursor = len(keys) - 1
+	}
+	if m.cursor < 0 {
+		m.cursor = 0
+	}
+}
+
+// absInt returns the absolute value of an int
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
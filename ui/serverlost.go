@@ -0,0 +1,207 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// serverLostRows caps the sessions listed in the restore dialog
+const serverLostRows = 12
+
+// serverLostMin is how many running sessions have to vanish together while
+// the manager runs to count as a lost tmux server; a single one ending is
+// reported (and restarted) on its own as before
+const serverLostMin = 2
+
+// lostSession is a session that went away with the tmux server
+type lostSession struct {
+	inst     *session.Instance
+	resumeID string // Conversation its main agent resumes ("" = starts fresh)
+}
+
+// markLost records a session that went away with the tmux server, dropping
+// the state the manager kept about its run
+func (m *Model) markLost(inst *session.Instance) {
+	if inst.Status == session.StatusRunning {
+		inst.EndRun(time.Now())
+	}
+	inst.Status = session.StatusStopped
+	delete(m.paneDead, inst.ID)
+	delete(m.paneExit, inst.ID)
+	delete(m.activityState, inst.ID)
+	delete(m.windowActivityState, inst.ID)
+	delete(m.pendingQuestions, inst.ID)
+	delete(m.stuckFor, inst.ID)
+	for _, lost := range m.lostSessions {
+		if lost.inst == inst {
+			return
+		}
+	}
+	m.lostSessions = append(m.lostSessions, lostSession{inst: inst, resumeID: inst.RestoreResumeID()})
+}
+
+// serverLost checks, when a running local session vanished, whether the tmux
+// server went with it. The other running local sessions are then marked lost
+// too, so they are offered for restoring instead of reported one by one.
+func (m *Model) serverLost(inst *session.Instance) bool {
+	if inst.IsRemote() {
+		return false
+	}
+	running := []*session.Instance{inst}
+	for _, other := range m.instances {
+		if other != inst && other.Status == session.StatusRunning && !other.IsRemote() && !m.isBusy(other) {
+			running = append(running, other)
+		}
+	}
+	if len(running) < serverLostMin || session.ServerRunning() {
+		return false
+	}
+	for _, lost := range running {
+		m.markLost(lost)
+	}
+	return true
+}
+
+// checkLostOnLoad marks the sessions saved as running that are gone because
+// no tmux server is running (after a reboot or a crash while the manager was closed)
+func (m *Model) checkLostOnLoad(savedRunning []*session.Instance) {
+	var gone []*session.Instance
+	for _, inst := range savedRunning {
+		if inst.Status != session.StatusRunning && !inst.IsRemote() {
+			gone = append(gone, inst)
+		}
+	}
+	if len(gone) == 0 || session.ServerRunning() {
+		return
+	}
+	for _, inst := range gone {
+		m.markLost(inst)
+	}
+}
+
+// openServerLost shows the restore dialog once the list is in front
+func (m *Model) openServerLost() {
+	if len(m.lostSessions) > 0 && m.state == stateList {
+		m.serverLostCursor = 0
+		m.state = stateServerLost
+	}
+}
+
+// restoreWorkspace starts the lost sessions again, resuming their conversations;
+// their tabs and splits come back with them
+func (m *Model) restoreWorkspace() tea.Cmd {
+	var starts []pendingStart
+	failed := 0
+	for _, lost := range m.lostSessions {
+		inst := lost.inst
+		if inst.Status == session.StatusRunning || m.isBusy(inst) {
+			continue
+		}
+		if err := session.CheckAgentCommand(inst); err != nil || inst.PathMissing() {
+			failed++
+			continue
+		}
+		starts = append(starts, pendingStart{inst: inst, resumeID: lost.resumeID})
+	}
+	m.lostSessions = nil
+	m.state = stateList
+	if m.checkRunningLimit(starts) {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	for _, p := range starts {
+		cmds = append(cmds, m.runStart(p.inst, p.resumeID, false))
+	}
+	if failed > 0 {
+		m.notifyWarn("Restoring %d session(s), %d skipped (command or directory not found)", len(starts), failed)
+	} else {
+		m.notifyInfo("Restoring %d session(s)", len(starts))
+	}
+	return tea.Batch(cmds...)
+}
+
+// handleServerLostKeys handles keyboard input in the restore workspace dialog
+func (m Model) handleServerLostKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter", "r":
+		if m.dryRun {
+			m.notifyWarn("Dry-run: start sessions one by one to review their commands")
+			return m, nil
+		}
+		return m, m.restoreWorkspace()
+	case "up", "k":
+		if m.serverLostCursor > 0 {
+			m.serverLostCursor--
+		}
+	case "down", "j":
+		if m.serverLostCursor < len(m.lostSessions)-1 {
+			m.serverLostCursor++
+		}
+	case "x":
+		// Leave one session stopped
+		if m.serverLostCursor < len(m.lostSessions) {
+			m.lostSessions = append(m.lostSessions[:m.serverLostCursor], m.lostSessions[m.serverLostCursor+1:]...)
+			if m.serverLostCursor >= len(m.lostSessions) && m.serverLostCursor > 0 {
+				m.serverLostCursor--
+			}
+		}
+		if len(m.lostSessions) == 0 {
+			m.state = stateList
+		}
+	case "esc", "q":
+		m.lostSessions = nil
+		m.state = stateList
+		m.storage.Save(m.instances) // Saved as stopped, so the next start doesn't ask again
+		m.notifyInfo("Sessions left stopped - start them with s")
+	}
+	return m, nil
+}
+
+// serverLostView renders the restore workspace dialog
+func (m Model) serverLostView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 70
+	boxContent.WriteString(fmt.Sprintf("  The tmux server is gone - %d session(s) were running:\n\n", len(m.lostSessions)))
+
+	start := 0
+	if m.serverLostCursor >= serverLostRows {
+		start = m.serverLostCursor - serverLostRows + 1
+	}
+	for idx := start; idx < len(m.lostSessions) && idx < start+serverLostRows; idx++ {
+		inst := m.lostSessions[idx].inst
+		prefix := "    "
+		if idx == m.serverLostCursor {
+			prefix = "  ❯ "
+		}
+		var details []string
+		if tabs := len(inst.FollowedWindows); tabs > 0 {
+			details = append(details, fmt.Sprintf("+%d tab(s)", tabs))
+		}
+		if id := m.lostSessions[idx].resumeID; id != "" {
+			details = append(details, "resumes "+truncateRunes(id, 8))
+		} else {
+			details = append(details, "starts fresh")
+		}
+		boxContent.WriteString(prefix + fmt.Sprintf("%-30s", truncateRunes(inst.Name, 30)) + dimStyle.Render(" "+strings.Join(details, " · ")))
+		boxContent.WriteString("\n")
+	}
+	if hidden := len(m.lostSessions) - start - serverLostRows; hidden > 0 {
+		boxContent.WriteString(dimStyle.Render(fmt.Sprintf("    … and %d more", hidden)))
+		boxContent.WriteString("\n")
+	}
+
+	boxContent.WriteString("\n")
+	boxContent.WriteString(dimStyle.Render("  Restoring starts them with their tabs, splits and conversations"))
+	boxContent.WriteString("\n\n")
+	boxContent.WriteString(helpStyle.Render("  enter/r: restore workspace  x: leave one stopped  esc: leave all"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" tmux Server Restarted ", boxContent.String(), boxWidth, ColorOrange)
+}
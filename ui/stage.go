@@ -0,0 +1,329 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// stageItem is one cursor stop of the stage view: a hunk, or a whole file (hunk -1)
+type stageItem struct {
+	file int
+	hunk int
+}
+
+// commitDoneMsg is sent when a background commit finishes
+type commitDoneMsg struct {
+	inst    *session.Instance
+	summary string
+	err     error
+}
+
+// inStage reports whether the diff pane shows the stage view
+func (m Model) inStage() bool {
+	return (m.state == stateStage || m.state == stateCommitMessage) && m.stageTarget != nil
+}
+
+// stageItems flattens the stage files into cursor stops
+func (m Model) stageItems() []stageItem {
+	var items []stageItem
+	for i, f := range m.stageFiles {
+		if f.WholeFile() {
+			items = append(items, stageItem{file: i, hunk: -1})
+			continue
+		}
+		for h := range f.Hunks {
+			items = append(items, stageItem{file: i, hunk: h})
+		}
+	}
+	return items
+}
+
+// openStage switches the diff pane of the selected session to staging
+func (m *Model) openStage() {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return
+	}
+	if !session.IsGitRepo(inst.Path) {
+		m.notifyWarn("'%s' is not in a git repository", inst.Path)
+		return
+	}
+	m.stageTarget = inst
+	m.stageCursor = 0
+	if !m.reloadStage() {
+		m.stageTarget = nil
+		return
+	}
+	m.showDiff = true
+	m.state = stateStage
+}
+
+// reloadStage re-reads the staged and unstaged changes, keeping the cursor in range
+func (m *Model) reloadStage() bool {
+	files, err := m.stageTarget.StagingDiff()
+	if err != nil {
+		m.notify(toastError, "Reading changes failed: %v", err)
+		return false
+	}
+	m.stageFiles = files
+	if n := len(m.stageItems()); m.stageCursor >= n {
+		m.stageCursor = n - 1
+	}
+	if m.stageCursor < 0 {
+		m.stageCursor = 0
+	}
+	return true
+}
+
+// closeStage goes back to the plain diff view
+func (m *Model) closeStage() {
+	inst := m.stageTarget
+	m.stageTarget = nil
+	m.stageFiles = nil
+	m.state = stateList
+	if inst != nil {
+		m.diffPane.SetDiff(inst)
+	}
+}
+
+// toggleStageItem stages or unstages the hunk under the cursor (or its whole file)
+func (m *Model) toggleStageItem(wholeFile bool) {
+	items := m.stageItems()
+	if len(items) == 0 {
+		return
+	}
+	item := items[m.stageCursor]
+	if wholeFile {
+		item.hunk = -1
+	}
+	if err := m.stageTarget.ToggleStaged(m.stageFiles[item.file], item.hunk); err != nil {
+		m.notify(toastError, "%v", err)
+	}
+	m.reloadStage()
+}
+
+// jumpStageFile moves the cursor to the first hunk of the next (dir 1) or previous (dir -1) file
+func (m *Model) jumpStageFile(dir int) {
+	items := m.stageItems()
+	if len(items) == 0 {
+		return
+	}
+	file := items[m.stageCursor].file + dir
+	if file < 0 || file >= len(m.stageFiles) {
+		return
+	}
+	for i, item := range items {
+		if item.file == file {
+			m.stageCursor = i
+			return
+		}
+	}
+}
+
+// hasStagedChanges reports whether there is anything to commit
+func (m Model) hasStagedChanges() bool {
+	for _, f := range m.stageFiles {
+		if f.Staged {
+			return true
+		}
+	}
+	return false
+}
+
+// commitCmd commits the staged changes off the UI loop
+func commitCmd(inst *session.Instance, message string) tea.Cmd {
+	return func() tea.Msg {
+		summary, err := inst.Commit(message)
+		return commitDoneMsg{inst: inst, summary: summary, err: err}
+	}
+}
+
+// handleCommitDone reports a finished commit and refreshes the stage view
+func (m Model) handleCommitDone(msg commitDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.notify(toastError, "%v", msg.err)
+		return m, nil
+	}
+	m.notifySuccess("Committed %s", msg.summary)
+	if m.inStage() && m.stageTarget.ID == msg.inst.ID {
+		m.reloadStage()
+	} else {
+		m.diffPane.SetDiff(msg.inst)
+	}
+	return m, nil
+}
+
+// handleStageKeys handles keyboard input in the stage view
+func (m Model) handleStageKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if m.stageCursor > 0 {
+			m.stageCursor--
+		}
+	case "down", "j":
+		if m.stageCursor < len(m.stageItems())-1 {
+			m.stageCursor++
+		}
+	case "right", "l", "tab":
+		m.jumpStageFile(1)
+	case "left", "h", "shift+tab":
+		m.jumpStageFile(-1)
+	case " ", "enter":
+		m.toggleStageItem(false)
+	case "a":
+		m.toggleStageItem(true)
+	case "r":
+		m.reloadStage()
+	case "c":
+		if !m.hasStagedChanges() {
+			m.notifyWarn("Nothing staged to commit")
+			return m, nil
+		}
+		m.commitInput.SetValue("")
+		m.commitInput.Focus()
+		m.state = stateCommitMessage
+	case "esc", "q", "P":
+		m.closeStage()
+	}
+	return m, nil
+}
+
+// handleCommitMessageKeys handles keyboard input in the commit message dialog
+func (m Model) handleCommitMessageKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.commitInput.Blur()
+		m.state = stateStage
+		return m, nil
+	case "enter":
+		message := strings.TrimSpace(m.commitInput.Value())
+		if message == "" {
+			m.notifyWarn("Commit message is empty")
+			return m, nil
+		}
+		m.commitInput.Blur()
+		m.state = stateStage
+		m.notifyInfo("Committing '%s'...", m.stageTarget.Name)
+		return m, commitCmd(m.stageTarget, message)
+	}
+	var cmd tea.Cmd
+	m.commitInput, cmd = m.commitInput.Update(msg)
+	return m, cmd
+}
+
+// stageContent renders the staged and unstaged files in the diff pane area.
+// The file under the cursor is expanded; the others show their line counts.
+func (m Model) stageContent(width, height int) string {
+	items := m.stageItems()
+	if len(items) == 0 {
+		return dimStyle.Render("  No changes to stage")
+	}
+	current := items[m.stageCursor]
+
+	var lines []string
+	cursorLine := 0
+	unstaged, staged := 0, 0
+	for _, f := range m.stageFiles {
+		if f.Staged {
+			staged++
+		} else {
+			unstaged++
+		}
+	}
+	for i, f := range m.stageFiles {
+		if i == 0 || f.Staged != m.stageFiles[i-1].Staged {
+			if i > 0 {
+				lines = append(lines, "")
+			}
+			if f.Staged {
+				lines = append(lines, " "+projectLabelStyle.Render(fmt.Sprintf("Staged (%d files)", staged)))
+			} else {
+				lines = append(lines, " "+projectLabelStyle.Render(fmt.Sprintf("Unstaged (%d files)", unstaged)))
+			}
+		}
+
+		label := f.Path
+		switch {
+		case f.New:
+			label += " (new)"
+		case f.Deleted:
+			label += " (deleted)"
+		case f.Binary:
+			label += " (binary)"
+		}
+		counts := dimStyle.Render(fmt.Sprintf(" +%d -%d", f.Added(), f.Removed()))
+		label = truncateRunes(label, width-16)
+		if i != current.file {
+			lines = append(lines, "   ▸ "+label+counts)
+			continue
+		}
+		marker := "   ▾ "
+		if current.hunk == -1 {
+			marker = " ❯ ▾ "
+			cursorLine = len(lines)
+		}
+		lines = append(lines, marker+projectNameStyle.Render(label)+counts)
+		for h, hunk := range f.Hunks {
+			prefix := "     "
+			if h == current.hunk {
+				prefix = "   ❯ "
+				cursorLine = len(lines)
+			}
+			lines = append(lines, prefix+colorDiffLine(truncateRunes(hunk.Header, width-6)))
+			for _, line := range hunk.Lines {
+				line = strings.ReplaceAll(line, "\t", "    ")
+				lines = append(lines, "     "+colorDiffLine(truncateRunes(line, width-6)))
+			}
+		}
+	}
+
+	// Keep the cursor in the upper third of the pane
+	start := cursorLine - height/3
+	if start > len(lines)-height {
+		start = len(lines) - height
+	}
+	if start < 0 {
+		start = 0
+	}
+	end := start + height
+	if end > len(lines) {
+		end = len(lines)
+	}
+	return strings.Join(lines[start:end], "\n")
+}
+
+// commitMessageView renders the commit message dialog as an overlay
+func (m Model) commitMessageView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 60
+	if m.width > 0 && m.width-4 < boxWidth {
+		boxWidth = m.width - 4
+	}
+
+	if m.stageTarget != nil {
+		boxContent.WriteString(fmt.Sprintf("  Session: %s\n", m.stageTarget.Name))
+	}
+	staged, added, removed := 0, 0, 0
+	for _, f := range m.stageFiles {
+		if f.Staged {
+			staged++
+			added += f.Added()
+			removed += f.Removed()
+		}
+	}
+	boxContent.WriteString(dimStyle.Render(fmt.Sprintf("  %d file(s) staged, +%d -%d", staged, added, removed)))
+	boxContent.WriteString("\n\n")
+
+	m.commitInput.Width = boxWidth - 8
+	boxContent.WriteString("  " + m.commitInput.View())
+	boxContent.WriteString("\n\n")
+	boxContent.WriteString(helpStyle.Render("  enter: commit  esc: cancel"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Commit ", boxContent.String(), boxWidth, ColorAccent)
+}
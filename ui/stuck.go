@@ -0,0 +1,182 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+	"github.com/izll/agent-session-manager/session/notify"
+)
+
+// StuckCheckInterval is how often busy sessions are checked for new output
+const StuckCheckInterval = 5 * time.Second
+
+// stuckThresholds are the choices offered by +/- in the stuck dialog (minutes, -1 = off)
+var stuckThresholds = []int{-1, 2, 5, 10, 15, 30, 60}
+
+// stuckAfter returns the configured quiet period before a busy session is flagged (0 = off)
+func (m Model) stuckAfter() time.Duration {
+	switch {
+	case m.stuckMinutes < 0:
+		return 0
+	case m.stuckMinutes == 0:
+		return session.DefaultStuckAfter
+	}
+	return time.Duration(m.stuckMinutes) * time.Minute
+}
+
+// isStuck reports whether a session has been busy without new output for too long
+func (m Model) isStuck(inst *session.Instance) bool {
+	after := m.stuckAfter()
+	return after > 0 && inst.Status == session.StatusRunning && m.stuckFor[inst.ID] >= after
+}
+
+// checkStuck measures how long busy sessions have gone quiet and warns once when one gets stuck
+func (m *Model) checkStuck() {
+	if m.state != stateList && m.state != stateStuck {
+		return
+	}
+	if m.stuckAfter() == 0 || time.Since(m.stuckAt) < StuckCheckInterval {
+		return
+	}
+	m.stuckAt = time.Now()
+	for _, inst := range m.instances {
+		if inst.Status != session.StatusRunning || m.isBusy(inst) {
+			m.stuckDetector.Reset(inst.ID)
+			delete(m.stuckFor, inst.ID)
+			continue
+		}
+		wasStuck := m.isStuck(inst)
+		m.stuckFor[inst.ID] = m.stuckDetector.Observe(inst, m.activityState[inst.ID], m.stuckAt)
+		if !wasStuck && m.isStuck(inst) {
+			m.notifyWarn("'%s' looks stuck - no output for %s (! to nudge)", inst.Name, formatQuiet(m.stuckFor[inst.ID]))
+			m.routeNotification(inst, notify.Stuck, "No output for "+formatQuiet(m.stuckFor[inst.ID]))
+		}
+	}
+}
+
+// formatQuiet formats a quiet period in whole minutes
+func formatQuiet(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+	return fmt.Sprintf("%d min", int(d.Minutes()))
+}
+
+// handleShowStuck opens the nudge/restart dialog for the selected session
+func (m *Model) handleShowStuck() {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return
+	}
+	m.stuckTarget = inst
+	m.state = stateStuck
+}
+
+// handleStuckKeys handles keyboard input in the stuck dialog
+func (m Model) handleStuckKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	inst := m.stuckTarget
+	switch msg.String() {
+	case "esc", "q", "!":
+		m.stuckTarget = nil
+		m.state = stateList
+	case "enter", "e":
+		key, label := "Enter", "Enter"
+		if msg.String() == "e" {
+			key, label = "Escape", "Esc"
+		}
+		if err := inst.Nudge(key); err != nil {
+			m.notify(toastError, "Failed to nudge: %v", err)
+			return m, nil
+		}
+		m.stuckDetector.Reset(inst.ID)
+		delete(m.stuckFor, inst.ID)
+		m.notifySuccess("Sent %s to '%s'", label, inst.Name)
+		m.stuckTarget = nil
+		m.state = stateList
+	case "r":
+		if inst.Status != session.StatusRunning {
+			m.notifyWarn("Session not running")
+			return m, nil
+		}
+		if err := inst.RestartAgent(); err != nil {
+			m.notify(toastError, "Failed to restart: %v", err)
+			return m, nil
+		}
+		m.stuckDetector.Reset(inst.ID)
+		delete(m.stuckFor, inst.ID)
+		m.notifySuccess("Restarted agent in '%s'", inst.Name)
+		m.stuckTarget = nil
+		m.state = stateList
+	case "+", "=", "-":
+		m.stuckMinutes = stepStuckThreshold(m.stuckMinutes, msg.String() != "-")
+		m.saveSettings()
+	}
+	return m, nil
+}
+
+// stepStuckThreshold moves the threshold setting to the next or previous choice
+func stepStuckThreshold(current int, up bool) int {
+	if current == 0 {
+		current = int(session.DefaultStuckAfter / time.Minute)
+	}
+	idx := 0
+	for i, v := range stuckThresholds {
+		if v <= current {
+			idx = i
+		}
+	}
+	if up && idx < len(stuckThresholds)-1 {
+		idx++
+	} else if !up && idx > 0 {
+		idx--
+	}
+	return stuckThresholds[idx]
+}
+
+// stuckSummary returns the preview header text for a stuck session ("" if not stuck)
+func (m Model) stuckSummary(inst *session.Instance) string {
+	if !m.isStuck(inst) {
+		return ""
+	}
+	return fmt.Sprintf("busy with no output for %s (! to nudge or restart)", formatQuiet(m.stuckFor[inst.ID]))
+}
+
+// stuckView renders the nudge/restart dialog as an overlay
+func (m Model) stuckView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 64
+	inst := m.stuckTarget
+	if inst != nil {
+		switch {
+		case m.isStuck(inst):
+			warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorRed)).Bold(true)
+			boxContent.WriteString(warnStyle.Render(fmt.Sprintf("  '%s' has been busy with no output for %s", truncateRunes(inst.Name, 24), formatQuiet(m.stuckFor[inst.ID]))))
+		case m.stuckFor[inst.ID] > 0:
+			boxContent.WriteString(fmt.Sprintf("  '%s' has been quiet for %s", truncateRunes(inst.Name, 30), formatQuiet(m.stuckFor[inst.ID])))
+		default:
+			boxContent.WriteString(fmt.Sprintf("  '%s' does not look stuck", truncateRunes(inst.Name, 40)))
+		}
+		boxContent.WriteString("\n\n")
+	}
+
+	boxContent.WriteString("  enter  Nudge - send Enter to the agent\n")
+	boxContent.WriteString("  e      Nudge - send Esc (interrupt the current step)\n")
+	boxContent.WriteString("  r      Restart the agent, resuming its conversation\n\n")
+
+	threshold := "off"
+	if after := m.stuckAfter(); after > 0 {
+		threshold = fmt.Sprintf("%d min", int(after.Minutes()))
+	}
+	boxContent.WriteString(dimStyle.Render("  Flag busy sessions after: ") + threshold)
+	boxContent.WriteString("\n\n")
+	boxContent.WriteString(helpStyle.Render("  enter/e: nudge  r: restart  +/-: threshold  esc: close"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Stuck Session ", boxContent.String(), boxWidth, ColorRed)
+}
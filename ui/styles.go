@@ -1,6 +1,12 @@
 package ui
 
-import "github.com/charmbracelet/lipgloss"
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
 
 // Color constants for consistent theming
 const (
@@ -18,6 +24,24 @@ const (
 	ColorProjectName  = "#A78BFA"
 )
 
+// ColorAccent is the accent color of titles, selections and dialogs (theme in config.toml)
+var ColorAccent = ColorPurple
+
+// themes are the named accent colors config.toml can select
+var themes = map[string]string{
+	"purple": ColorPurple,
+	"blue":   "#3B6FE0",
+	"teal":   "#0E8A80",
+	"green":  "#2E8B57",
+	"orange": "#D9661F",
+	"red":    "#C7365F",
+	"pink":   "#C2408F",
+	"gray":   "#5A5F6B",
+}
+
+// hexColorPattern matches a #rrggbb color
+var hexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
 // UI styles for the TUI components
 var (
 	// Project name styles
@@ -26,12 +50,12 @@ var (
 	titleStyle = lipgloss.NewStyle().
 			Bold(true).
 			Foreground(lipgloss.Color(ColorWhite)).
-			Background(lipgloss.Color(ColorPurple)).
+			Background(lipgloss.Color(ColorAccent)).
 			Padding(0, 1)
 
 	selectedStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color(ColorWhite)).
-			Background(lipgloss.Color(ColorPurple))
+			Background(lipgloss.Color(ColorAccent))
 
 	runningStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color(ColorGreen))
@@ -50,7 +74,7 @@ var (
 
 	previewStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
-			BorderForeground(lipgloss.Color(ColorPurple)).
+			BorderForeground(lipgloss.Color(ColorAccent)).
 			Padding(1)
 
 	helpStyle = lipgloss.NewStyle().
@@ -74,7 +98,7 @@ var (
 
 	listSelectedStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color(ColorWhite)).
-				Background(lipgloss.Color(ColorPurple)).
+				Background(lipgloss.Color(ColorAccent)).
 				Bold(true)
 
 	searchBoxStyle = lipgloss.NewStyle().
@@ -90,3 +114,24 @@ var (
 	metaStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color(ColorLightGray))
 )
+
+// applyTheme switches the accent color to a theme name or #rrggbb ("" = purple)
+func applyTheme(theme string) error {
+	accent := ColorPurple
+	if theme != "" {
+		var ok bool
+		if accent, ok = themes[strings.ToLower(theme)]; !ok {
+			if !hexColorPattern.MatchString(theme) {
+				return fmt.Errorf("unknown theme '%s' (use a theme name or #rrggbb)", theme)
+			}
+			accent = theme
+		}
+	}
+	ColorAccent = accent
+	accent = styleAccent(accent)
+	titleStyle = titleStyle.Background(lipgloss.Color(accent))
+	selectedStyle = selectedStyle.Background(lipgloss.Color(accent))
+	previewStyle = previewStyle.BorderForeground(lipgloss.Color(accent))
+	listSelectedStyle = listSelectedStyle.Background(lipgloss.Color(accent))
+	return nil
+}
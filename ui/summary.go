@@ -0,0 +1,54 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// summaryDoneMsg is sent when a background summary finishes
+type summaryDoneMsg struct {
+	inst    *session.Instance
+	summary string
+	err     error
+}
+
+// summarizeCmd generates a session summary off the UI loop.
+// input is the conversation text; empty means collect it in the background.
+func summarizeCmd(inst *session.Instance, cfg *session.SummaryConfig, input string) tea.Cmd {
+	return func() tea.Msg {
+		if input == "" {
+			input = inst.SummaryInput()
+		}
+		summary, err := inst.Summarize(cfg, input)
+		return summaryDoneMsg{inst: inst, summary: summary, err: err}
+	}
+}
+
+// summarizeSession starts summarizing a session into its notes
+func (m *Model) summarizeSession(inst *session.Instance, input string) tea.Cmd {
+	if inst == nil {
+		return nil
+	}
+	if m.summarizing[inst.ID] {
+		m.notifyInfo("Already summarizing '%s'...", inst.Name)
+		return nil
+	}
+	m.summarizing[inst.ID] = true
+	m.notifyInfo("Summarizing '%s'...", inst.Name)
+	return summarizeCmd(inst, session.LoadSummaryConfig(), input)
+}
+
+// handleSummaryDone stores a finished summary into the session notes
+func (m Model) handleSummaryDone(msg summaryDoneMsg) (tea.Model, tea.Cmd) {
+	delete(m.summarizing, msg.inst.ID)
+	if msg.err != nil {
+		m.notify(toastError, "Summary of '%s' failed: %v", msg.inst.Name, msg.err)
+		return m, nil
+	}
+	msg.inst.Notes = session.AppendSummaryNote(msg.inst.Notes, msg.summary, time.Now())
+	m.storage.UpdateInstance(msg.inst)
+	m.notifySuccess("Summary added to notes of '%s'", msg.inst.Name)
+	return m, nil
+}
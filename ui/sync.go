@@ -0,0 +1,40 @@
+package ui
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// syncDoneMsg is sent when the startup sync with the other machines finished
+type syncDoneMsg struct {
+	report *session.SyncReport
+	err    error
+}
+
+// syncCmd merges the local state with the sync directory off the UI loop. It
+// uses a storage of its own, as the sync switches between projects.
+func syncCmd(config *session.SyncConfig) tea.Cmd {
+	return func() tea.Msg {
+		storage, err := session.NewStorage()
+		if err != nil {
+			return syncDoneMsg{err: err}
+		}
+		report, err := storage.Sync(config)
+		return syncDoneMsg{report: report, err: err}
+	}
+}
+
+// handleSyncDone reports the startup sync and picks up what it received
+func (m *Model) handleSyncDone(msg syncDoneMsg) {
+	switch {
+	case msg.err != nil:
+		m.notifyWarn("Sync failed: %v", msg.err)
+	case msg.report != nil && len(msg.report.Conflicts) > 0:
+		m.notifyWarn("Synced with %d conflict(s), local values kept - see %s", len(msg.report.Conflicts), session.SyncConflictLogPath())
+	case msg.report != nil && msg.report.Received > 0:
+		m.notifyInfo("Synced %d change(s) from other machines", msg.report.Received)
+	}
+	if msg.report != nil && msg.report.Received > 0 {
+		m.reloadInstancesAndGroups()
+	}
+}
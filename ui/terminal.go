@@ -0,0 +1,110 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// AppTitle is the terminal title shown while the manager UI is in front
+const AppTitle = "Agent Session Manager"
+
+// oscWrap wraps an OSC sequence in a DCS passthrough when running inside tmux
+func oscWrap(seq string) string {
+	if os.Getenv("TMUX") == "" {
+		return seq
+	}
+	// tmux passthrough: ESC P tmux; <seq with ESC doubled> ESC \
+	return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+}
+
+// terminalOutput is the program's output (tea.WithOutput). The renderer's
+// frames and the sequences the manager emits itself go through it one write
+// at a time, so a sequence never lands inside a frame.
+type terminalOutput struct {
+	*os.File
+	mu sync.Mutex
+}
+
+func (t *terminalOutput) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.File.Write(p)
+}
+
+func (t *terminalOutput) WriteString(s string) (int, error) {
+	return t.Write([]byte(s))
+}
+
+// TerminalOutput is the terminal the program renders to
+var TerminalOutput = &terminalOutput{File: os.Stdout}
+
+// execProcess runs cmd on the terminal itself, suspending the program;
+// tea.ExecProcess would hand it TerminalOutput, which isn't an *os.File
+func execProcess(cmd *exec.Cmd, fn tea.ExecCallback) tea.Cmd {
+	if cmd.Stdout == nil {
+		cmd.Stdout = os.Stdout
+	}
+	return tea.ExecProcess(cmd, fn)
+}
+
+// emitTerminal returns a command writing raw sequences to the terminal
+func emitTerminal(seqs []string) tea.Cmd {
+	return func() tea.Msg {
+		TerminalOutput.WriteString(strings.Join(seqs, ""))
+		return nil
+	}
+}
+
+// emitOSC queues an OSC sequence; Update writes the queue once the message is handled
+func (m *Model) emitOSC(seq string) {
+	m.terminalSeqs = append(m.terminalSeqs, oscWrap(seq))
+}
+
+// sanitizeOSC strips control characters that would terminate an OSC sequence early
+func sanitizeOSC(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return ' '
+		}
+		return r
+	}, s)
+}
+
+// terminalNotify emits OSC 9 (iTerm2, WezTerm, ...) and OSC 777 (rxvt, foot, ...) notifications
+func (m *Model) terminalNotify(title, body string) {
+	title = sanitizeOSC(title)
+	body = sanitizeOSC(body)
+	m.emitOSC(fmt.Sprintf("\x1b]9;%s: %s\x07", title, body))
+	// OSC 777 uses ';' as separator
+	m.emitOSC(fmt.Sprintf("\x1b]777;notify;%s;%s\x07", strings.ReplaceAll(title, ";", ","), strings.ReplaceAll(body, ";", ",")))
+}
+
+// ringBell queues the terminal bell (tmux flags the window when asmgr runs in a background pane)
+func (m *Model) ringBell() {
+	m.terminalSeqs = append(m.terminalSeqs, "\a")
+}
+
+// terminalBell rings the terminal bell right away; for use while attached,
+// when the program has released the terminal
+func terminalBell() {
+	TerminalOutput.WriteString("\a")
+}
+
+// restoreAppTitle sets the terminal title back to the manager
+func restoreAppTitle() tea.Cmd {
+	return tea.SetWindowTitle(AppTitle)
+}
+
+// configureTmuxTitle makes tmux set the outer terminal title (OSC 0/2) to the session name while attached
+func configureTmuxTitle(tmux session.Runner, sessionName, instanceName string) {
+	// Escape '#' so tmux doesn't expand it as a format
+	title := strings.ReplaceAll(instanceName, "#", "##") + " - asmgr"
+	tmux.Run("set-option", "-t", sessionName, "set-titles", "on")
+	tmux.Run("set-option", "-t", sessionName, "set-titles-string", title)
+}
@@ -0,0 +1,244 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// formatZoneTime formats t in loc, adding the local time when the zones differ
+func formatZoneTime(t time.Time, loc *time.Location) string {
+	zoned, local := t.In(loc), t.In(time.Local)
+	text := zoned.Format("Mon 15:04 MST")
+	_, zoneOffset := zoned.Zone()
+	_, localOffset := local.Zone()
+	if zoneOffset != localOffset {
+		text += " (" + local.Format("15:04") + " local)"
+	}
+	return text
+}
+
+// handleSetTimezone opens the timezone dialog for the selected session
+func (m *Model) handleSetTimezone() tea.Cmd {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return nil
+	}
+	m.err = nil
+	m.timezoneInput.SetValue(inst.Timezone)
+	m.timezoneInput.CursorEnd()
+	m.timezoneInput.Focus()
+	m.state = stateSetTimezone
+	return textinput.Blink
+}
+
+// handleSetTimezoneKeys handles keyboard input in the timezone dialog
+func (m Model) handleSetTimezoneKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.err = nil
+		m.timezoneInput.Blur()
+		m.state = stateList
+		return m, nil
+
+	case "enter":
+		inst := m.getSelectedInstance()
+		if inst == nil {
+			m.state = stateList
+			return m, nil
+		}
+		name := strings.TrimSpace(m.timezoneInput.Value())
+		if err := session.ValidateTimezone(name); err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.err = nil
+		m.timezoneInput.Blur()
+		inst.Timezone = name
+		m.storage.UpdateInstance(inst)
+		if name == "" {
+			m.notifyInfo("Timezone cleared for '%s'", inst.Name)
+		} else {
+			m.notifySuccess("'%s' now shows times in %s", inst.Name, name)
+		}
+		m.state = stateList
+		return m, nil
+	}
+
+	// Clear error when typing
+	m.err = nil
+
+	var cmd tea.Cmd
+	m.timezoneInput, cmd = m.timezoneInput.Update(msg)
+	return m, cmd
+}
+
+// handleSchedulePrompt switches from the prompt dialog to picking a send time
+func (m *Model) handleSchedulePrompt() tea.Cmd {
+	if strings.TrimSpace(m.promptInput.Value()) == "" {
+		return nil
+	}
+	m.err = nil
+	m.scheduleInput.SetValue("")
+	m.scheduleInput.Focus()
+	m.state = stateSchedulePrompt
+	return textinput.Blink
+}
+
+// handleSchedulePromptKeys handles keyboard input in the schedule dialog
+func (m Model) handleSchedulePromptKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		// Back to the message, keeping what was typed
+		m.err = nil
+		m.scheduleInput.Blur()
+		m.promptInput.Focus()
+		m.state = statePrompt
+		return m, nil
+
+	case "enter":
+		inst := m.getSelectedInstance()
+		if inst == nil {
+			m.state = stateList
+			return m, nil
+		}
+		loc := inst.Location()
+		at, err := session.ParseScheduleTime(m.scheduleInput.Value(), loc, time.Now())
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.err = nil
+		m.scheduleInput.Blur()
+		inst.SchedulePrompt(m.promptInput.Value(), at)
+		m.rememberPrompt(inst, m.promptInput.Value())
+		m.storage.UpdateInstance(inst)
+		m.notifySuccess("Message for '%s' scheduled at %s", inst.Name, formatZoneTime(at, loc))
+		m.state = stateList
+		return m, nil
+	}
+
+	// Clear error when typing
+	m.err = nil
+
+	var cmd tea.Cmd
+	m.scheduleInput, cmd = m.scheduleInput.Update(msg)
+	return m, cmd
+}
+
+// sendDuePrompts delivers scheduled prompts whose time has come. Sessions with
+// readiness checks keep them queued until their services are up.
+func (m *Model) sendDuePrompts() tea.Cmd {
+	now := time.Now()
+	var cmds []tea.Cmd
+	for _, inst := range m.instances {
+		if len(inst.ScheduledPrompts) == 0 {
+			delete(m.promptWaits, inst.ID)
+			continue
+		}
+		if inst.Status != session.StatusRunning || m.isBusy(inst) || inst.NextScheduledPrompt().At.After(now) {
+			continue
+		}
+		if len(inst.ReadinessChecks) > 0 {
+			cmds = append(cmds, m.checkPromptReadiness(inst, now))
+			continue
+		}
+		m.sendPromptsDue(inst, now)
+	}
+	return tea.Batch(cmds...)
+}
+
+// sendPromptsDue sends the scheduled prompts of a session due at now
+func (m *Model) sendPromptsDue(inst *session.Instance, now time.Time) {
+	due := inst.TakeDuePrompts(now)
+	if len(due) == 0 {
+		return
+	}
+	for _, p := range due {
+		// Placeholders resolve at send time, not when scheduled
+		text, _ := inst.ResolvePrompt(p.Text)
+		if err := inst.SendPrompt(text); err != nil {
+			m.notify(toastError, "Scheduled message for '%s' failed: %v", inst.Name, err)
+			break
+		}
+	}
+	m.storage.UpdateInstance(inst)
+	m.notifyInfo("Sent %d scheduled message(s) to '%s'", len(due), inst.Name)
+}
+
+// timezoneSummary returns the preview line for a session with a timezone or scheduled prompts
+func timezoneSummary(inst *session.Instance) string {
+	var parts []string
+	loc := inst.Location()
+	if inst.Timezone != "" {
+		now := time.Now().In(loc)
+		parts = append(parts, fmt.Sprintf("%s · now %s", inst.Timezone, now.Format("Mon 15:04")))
+		if last := inst.LastActivity(); !last.IsZero() {
+			parts = append(parts, "last activity "+last.In(loc).Format("Mon 15:04"))
+		}
+	}
+	if next := inst.NextScheduledPrompt(); next != nil {
+		parts = append(parts, fmt.Sprintf("%d scheduled, next %s", len(inst.ScheduledPrompts), formatZoneTime(next.At, loc)))
+	}
+	return strings.Join(parts, " · ")
+}
+
+// setTimezoneView renders the timezone dialog as an overlay
+func (m Model) setTimezoneView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+	if inst := m.getSelectedInstance(); inst != nil {
+		boxContent.WriteString(fmt.Sprintf("  Timezone for '%s':\n\n", inst.Name))
+	}
+	boxContent.WriteString("  " + m.timezoneInput.View() + "\n")
+	boxContent.WriteString(dimStyle.Render("  IANA name, e.g. America/New_York (empty = local)"))
+	boxContent.WriteString("\n")
+
+	if m.err != nil {
+		boxContent.WriteString("\n")
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555")).Bold(true)
+		boxContent.WriteString(errStyle.Render(fmt.Sprintf("  ⚠ %v", m.err)))
+		boxContent.WriteString("\n")
+	}
+
+	boxContent.WriteString("\n")
+	boxContent.WriteString(helpStyle.Render("  enter: save  esc: cancel"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Session Timezone ", boxContent.String(), 60, ColorAccent)
+}
+
+// schedulePromptView renders the schedule dialog as an overlay
+func (m Model) schedulePromptView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+	if inst := m.getSelectedInstance(); inst != nil {
+		loc := inst.Location()
+		zone := inst.Timezone
+		if zone == "" {
+			zone = "local time"
+		}
+		boxContent.WriteString(fmt.Sprintf("  Send to '%s' at (%s, now %s):\n\n", inst.Name, zone, time.Now().In(loc).Format("15:04")))
+	}
+	boxContent.WriteString("  " + m.scheduleInput.View() + "\n")
+	boxContent.WriteString(dimStyle.Render("  9am, 14:30 or +30m - the session must be running then"))
+	boxContent.WriteString("\n")
+
+	if m.err != nil {
+		boxContent.WriteString("\n")
+		errStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#FF5555")).Bold(true)
+		boxContent.WriteString(errStyle.Render(fmt.Sprintf("  ⚠ %v", m.err)))
+		boxContent.WriteString("\n")
+	}
+
+	boxContent.WriteString("\n")
+	boxContent.WriteString(helpStyle.Render("  enter: schedule  esc: back to message"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Schedule Message ", boxContent.String(), 60, ColorAccent)
+}
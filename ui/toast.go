@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// toastLevel represents the severity of a notification
+type toastLevel int
+
+const (
+	toastInfo toastLevel = iota
+	toastSuccess
+	toastWarn
+	toastError
+)
+
+// Toast constants
+const (
+	ToastDuration     = 4 * time.Second // How long a toast stays visible
+	MaxMessageHistory = 200             // Max entries kept in the message history panel
+)
+
+// toast is a single notification message
+type toast struct {
+	level     toastLevel
+	text      string
+	createdAt time.Time
+}
+
+// icon returns the prefix icon for a toast level
+func (l toastLevel) icon() string {
+	switch l {
+	case toastSuccess:
+		return "✓"
+	case toastWarn:
+		return "⚠"
+	case toastError:
+		return "✗"
+	default:
+		return "ℹ"
+	}
+}
+
+// color returns the display color for a toast level
+func (l toastLevel) color() string {
+	switch l {
+	case toastSuccess:
+		return ColorGreen
+	case toastWarn:
+		return ColorOrange
+	case toastError:
+		return ColorRed
+	default:
+		return ColorCyan
+	}
+}
+
+// notify shows a transient toast and records it in the message history
+func (m *Model) notify(level toastLevel, format string, args ...interface{}) {
+	t := toast{
+		level:     level,
+		text:      fmt.Sprintf(format, args...),
+		createdAt: time.Now(),
+	}
+	m.toast = &t
+	m.logMessage(t)
+}
+
+// notifyInfo shows an informational toast
+func (m *Model) notifyInfo(format string, args ...interface{}) {
+	m.notify(toastInfo, format, args...)
+}
+
+// notifySuccess shows a success toast
+func (m *Model) notifySuccess(format string, args ...interface{}) {
+	m.notify(toastSuccess, format, args...)
+}
+
+// notifyWarn shows a warning toast
+func (m *Model) notifyWarn(format string, args ...interface{}) {
+	m.notify(toastWarn, format, args...)
+}
+
+// logMessage appends a message to the history, trimming the oldest entries
+func (m *Model) logMessage(t toast) {
+	m.messageHistory = append(m.messageHistory, t)
+	if len(m.messageHistory) > MaxMessageHistory {
+		m.messageHistory = m.messageHistory[len(m.messageHistory)-MaxMessageHistory:]
+	}
+}
+
+// expireToast clears the active toast once it has been visible long enough
+func (m *Model) expireToast() {
+	if m.toast != nil && time.Since(m.toast.createdAt) > ToastDuration {
+		m.toast = nil
+	}
+}
+
+// renderToast renders the active toast as a single line (empty if none)
+func (m Model) renderToast() string {
+	if m.toast == nil {
+		return ""
+	}
+	style := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(m.toast.level.color())).
+		Bold(true)
+	text := truncateRunes(strings.ReplaceAll(m.toast.text, "\n", " "), m.width-6)
+	return style.Render(m.toast.level.icon() + " " + text)
+}
+
+// messagesView renders the message history panel as an overlay
+func (m Model) messagesView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 70
+	if m.width > 100 {
+		boxWidth = 90
+	}
+
+	maxVisible := m.height - 12
+	if maxVisible < 5 {
+		maxVisible = 5
+	}
+
+	if len(m.messageHistory) == 0 {
+		boxContent.WriteString(dimStyle.Render("  No messages yet"))
+		boxContent.WriteString("\n")
+	} else {
+		// Newest first
+		total := len(m.messageHistory)
+		start := m.messagesScroll
+		if start > total-1 {
+			start = total - 1
+		}
+		shown := 0
+		for i := total - 1 - start; i >= 0 && shown < maxVisible; i-- {
+			t := m.messageHistory[i]
+			levelStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(t.level.color()))
+			text := truncateRunes(strings.ReplaceAll(t.text, "\n", " "), boxWidth-18)
+			boxContent.WriteString(fmt.Sprintf("  %s %s %s\n",
+				dimStyle.Render(t.createdAt.Format("15:04:05")),
+				levelStyle.Render(t.level.icon()),
+				text))
+			shown++
+		}
+		if total-start > maxVisible {
+			boxContent.WriteString(dimStyle.Render(fmt.Sprintf("  ↓ %d older", total-start-maxVisible)))
+			boxContent.WriteString("\n")
+		}
+	}
+
+	boxContent.WriteString("\n")
+	boxContent.WriteString(helpStyle.Render("  ↑/↓: scroll  c: clear  esc: close"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Messages ", boxContent.String(), boxWidth, ColorAccent)
+}
+
+// handleMessagesKeys handles keyboard input in the message history panel
+func (m Model) handleMessagesKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "H":
+		m.messagesScroll = 0
+		m.state = stateList
+	case "up", "k":
+		if m.messagesScroll > 0 {
+			m.messagesScroll--
+		}
+	case "down", "j":
+		if m.messagesScroll < len(m.messageHistory)-1 {
+			m.messagesScroll++
+		}
+	case "c":
+		m.messageHistory = nil
+		m.messagesScroll = 0
+	}
+	return m, nil
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// topicsMsg carries freshly computed topic keywords (instance ID -> keywords)
+type topicsMsg map[string][]string
+
+// refreshTopics recomputes topic keywords for all sessions in the background
+func (m *Model) refreshTopics() tea.Cmd {
+	if len(m.instances) == 0 {
+		return nil
+	}
+	// Work on copies so the goroutine doesn't race with the UI
+	insts := make([]session.Instance, 0, len(m.instances))
+	for _, inst := range m.instances {
+		insts = append(insts, *inst)
+	}
+	return func() tea.Msg {
+		docs := make(map[string][]string, len(insts))
+		for i := range insts {
+			if path := insts[i].ClaudeTranscriptPath(); path != "" {
+				docs[insts[i].ID] = session.LoadUserPrompts(path)
+			}
+		}
+		return topicsMsg(session.TopicKeywords(docs, session.MaxTopics))
+	}
+}
+
+// renderTopicChips renders the topic keywords of an instance as chips ("" if none)
+func (m Model) renderTopicChips(instID string) string {
+	topics := m.topics[instID]
+	if len(topics) == 0 {
+		return ""
+	}
+	chipStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#1a1a2e")).
+		Background(lipgloss.Color(ColorCyan)).
+		Padding(0, 1)
+	chips := make([]string, 0, len(topics))
+	for _, t := range topics {
+		chips = append(chips, chipStyle.Render(truncateRunes(t, 20)))
+	}
+	return strings.Join(chips, " ")
+}
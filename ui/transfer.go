@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// openTransfer lists what the selected session can hand over to another session
+func (m *Model) openTransfer() {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return
+	}
+	var targets []*session.Instance
+	for _, other := range m.instances {
+		if other.ID != inst.ID && filepath.Clean(other.Path) != filepath.Clean(inst.Path) {
+			targets = append(targets, other)
+		}
+	}
+	if len(targets) == 0 {
+		m.notifyWarn("No other session works in a different directory")
+		return
+	}
+	items, err := inst.TransferItems()
+	if err != nil {
+		m.notifyWarn("%v", err)
+		return
+	}
+	if len(items) == 0 {
+		m.notifyInfo("'%s' has no changes to transfer", inst.Name)
+		return
+	}
+	m.transferSource = inst
+	m.transferItems = items
+	m.transferTargets = targets
+	m.transferItem = -1
+	m.transferCursor = 0
+	m.state = stateTransfer
+}
+
+// runTransfer copies or applies the picked item in the session under the cursor
+func (m *Model) runTransfer() {
+	source := m.transferSource
+	item := m.transferItems[m.transferItem]
+	target := m.transferTargets[m.transferCursor]
+	m.transferSource = nil
+	m.state = stateList
+
+	what := "'" + item.Path + "'"
+	switch item.Kind {
+	case session.TransferChanges:
+		what = "the uncommitted changes"
+	case session.TransferLastCommit:
+		what = "the last commit"
+	}
+	if err := source.TransferTo(target, item); err != nil {
+		m.notify(toastError, "Transferring %s to '%s' failed: %v", what, target.Name, err)
+		return
+	}
+	m.notifySuccess("Transferred %s of '%s' to '%s'", what, source.Name, target.Name)
+}
+
+// handleTransferKeys handles keyboard input in the transfer dialog
+func (m Model) handleTransferKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	count := len(m.transferItems)
+	if m.transferItem >= 0 {
+		count = len(m.transferTargets)
+	}
+	switch msg.String() {
+	case "up", "k":
+		if m.transferCursor > 0 {
+			m.transferCursor--
+		}
+	case "down", "j":
+		if m.transferCursor < count-1 {
+			m.transferCursor++
+		}
+	case "enter":
+		if m.transferItem < 0 {
+			// Item picked, now the target
+			m.transferItem = m.transferCursor
+			m.transferCursor = 0
+			return m, nil
+		}
+		m.runTransfer()
+	case "esc":
+		if m.transferItem >= 0 {
+			m.transferCursor = m.transferItem
+			m.transferItem = -1
+			return m, nil
+		}
+		m.transferSource = nil
+		m.state = stateList
+	}
+	return m, nil
+}
+
+// transferView renders the transfer dialog as an overlay
+func (m Model) transferView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 70
+	if m.width > 0 && m.width-4 < boxWidth {
+		boxWidth = m.width - 4
+	}
+
+	source := m.transferSource
+	if source == nil {
+		return ""
+	}
+	boxContent.WriteString(fmt.Sprintf("  From: %s\n", source.Name))
+	boxContent.WriteString(dimStyle.Render("  " + truncateRunes(source.Path, boxWidth-6)))
+	boxContent.WriteString("\n\n")
+
+	var rows []string
+	if m.transferItem < 0 {
+		boxContent.WriteString("  What to transfer:\n")
+		for _, item := range m.transferItems {
+			rows = append(rows, truncateRunes(item.Label, boxWidth-10))
+		}
+	} else {
+		item := m.transferItems[m.transferItem]
+		boxContent.WriteString("  " + projectNameStyle.Render(truncateRunes(item.Label, boxWidth-6)) + "\n\n")
+		boxContent.WriteString("  To session:\n")
+		for _, target := range m.transferTargets {
+			row := truncateRunes(target.Name, 24)
+			if branch := m.sessionBranch(target); branch != "" {
+				row += dimStyle.Render(" ⎇ " + truncateRunes(branch, 16))
+			}
+			row += dimStyle.Render("  " + truncateRunes(target.Path, boxWidth-52))
+			rows = append(rows, row)
+		}
+	}
+
+	const maxRows = 12
+	start := 0
+	if m.transferCursor >= maxRows {
+		start = m.transferCursor - maxRows + 1
+	}
+	for i := start; i < len(rows) && i < start+maxRows; i++ {
+		if i == m.transferCursor {
+			boxContent.WriteString("  ❯ " + rows[i] + "\n")
+		} else {
+			boxContent.WriteString("    " + rows[i] + "\n")
+		}
+	}
+	if len(rows) > maxRows {
+		boxContent.WriteString(dimStyle.Render(fmt.Sprintf("    %d of %d", m.transferCursor+1, len(rows))))
+		boxContent.WriteString("\n")
+	}
+
+	boxContent.WriteString("\n")
+	if m.transferItem < 0 {
+		boxContent.WriteString(helpStyle.Render("  enter: pick target  esc: cancel"))
+	} else {
+		if item := m.transferItems[m.transferItem]; item.Kind == session.TransferFile {
+			boxContent.WriteString(dimStyle.Render("  Copied to the same relative path, replacing the target's file"))
+		} else {
+			boxContent.WriteString(dimStyle.Render("  Applied with git apply; conflicts are merged with --3way"))
+		}
+		boxContent.WriteString("\n")
+		boxContent.WriteString(helpStyle.Render("  enter: transfer  esc: back"))
+	}
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Transfer to Session ", boxContent.String(), boxWidth, ColorAccent)
+}
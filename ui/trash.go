@@ -0,0 +1,196 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// groupSessionCount returns how many sessions are in a group
+func (m Model) groupSessionCount(groupID string) int {
+	count := 0
+	for _, inst := range m.instances {
+		if inst.GroupID == groupID {
+			count++
+		}
+	}
+	return count
+}
+
+// otherGroups returns the groups the sessions of the deleted group can move to
+func (m Model) otherGroups() []*session.Group {
+	var groups []*session.Group
+	for _, g := range m.groups {
+		if m.deleteGroupTarget == nil || g.ID != m.deleteGroupTarget.ID {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+// openDeleteGroup asks what happens to a group's sessions (empty groups go right away)
+func (m *Model) openDeleteGroup(group *session.Group) {
+	if m.globalList {
+		m.notifyWarn("Groups are per project - leave the all projects list (%s a) first", m.keys.help("leader"))
+		return
+	}
+	m.deleteGroupTarget = group
+	if m.groupSessionCount(group.ID) == 0 {
+		m.applyGroupDelete(session.GroupDeleteUngroup, "")
+		return
+	}
+	m.deleteGroupPicking = false
+	m.deleteGroupCursor = 0
+	m.state = stateDeleteGroup
+}
+
+// applyGroupDelete deletes the target group into the group trash
+func (m *Model) applyGroupDelete(mode session.GroupDeleteMode, targetID string) {
+	group := m.deleteGroupTarget
+	m.deleteGroupTarget = nil
+	m.state = stateList
+	if group == nil {
+		return
+	}
+
+	anchor := m.rememberSelection()
+	deleted, err := m.storage.DeleteGroup(group.ID, mode, targetID)
+	if err != nil {
+		m.showError(fmt.Errorf("failed to delete group: %w", err))
+		return
+	}
+	m.reloadInstancesAndGroups()
+	m.restoreSelection(anchor)
+
+	undo := m.keys.help("reopen")
+	switch {
+	case len(deleted.Sessions) == 0:
+		m.notifySuccess("Deleted group '%s' - %s to undo", group.Name, undo)
+	case mode == session.GroupDeleteArchive:
+		m.notifySuccess("Deleted group '%s' and archived %d session(s) - %s to undo", group.Name, len(deleted.Archived), undo)
+	case mode == session.GroupDeleteMove:
+		m.notifySuccess("Deleted group '%s', sessions moved - %s to undo", group.Name, undo)
+	default:
+		m.notifySuccess("Deleted group '%s', sessions ungrouped - %s to undo", group.Name, undo)
+	}
+}
+
+// undoGroupDelete restores the most recently deleted group
+func (m *Model) undoGroupDelete() {
+	deleted, err := m.storage.RestoreGroup()
+	if err != nil {
+		m.showError(fmt.Errorf("failed to restore group: %w", err))
+		return
+	}
+	if deleted == nil {
+		return
+	}
+	m.reloadInstancesAndGroups()
+	m.buildVisibleItems()
+	for i, item := range m.visibleItems {
+		if item.isGroup && item.group.ID == deleted.Group.ID {
+			m.cursor = i
+		}
+	}
+	m.notifySuccess("Restored group '%s' with %d session(s)", deleted.Group.Name, len(deleted.Sessions))
+}
+
+// reloadInstancesAndGroups re-reads the sessions and groups of the project
+func (m *Model) reloadInstancesAndGroups() {
+	if instances, err := m.storage.Load(); err == nil {
+		m.instances = instances
+	}
+	if groups, err := m.storage.GetGroups(); err == nil {
+		m.groups = groups
+	}
+}
+
+// handleDeleteGroupKeys handles keyboard input in the group deletion dialog
+func (m Model) handleDeleteGroupKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.deleteGroupPicking {
+		targets := m.otherGroups()
+		switch msg.String() {
+		case "esc":
+			m.deleteGroupPicking = false
+		case "up", "k":
+			if m.deleteGroupCursor > 0 {
+				m.deleteGroupCursor--
+			}
+		case "down", "j":
+			if m.deleteGroupCursor < len(targets)-1 {
+				m.deleteGroupCursor++
+			}
+		case "enter":
+			if m.deleteGroupCursor < len(targets) {
+				m.applyGroupDelete(session.GroupDeleteMove, targets[m.deleteGroupCursor].ID)
+			}
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "m", "M":
+		if len(m.otherGroups()) == 0 {
+			m.notifyWarn("No other group to move the sessions to")
+			return m, nil
+		}
+		m.deleteGroupPicking = true
+		m.deleteGroupCursor = 0
+	case "u", "U":
+		m.applyGroupDelete(session.GroupDeleteUngroup, "")
+	case "a", "A":
+		m.applyGroupDelete(session.GroupDeleteArchive, "")
+	case "esc":
+		m.deleteGroupTarget = nil
+		m.state = stateList
+	}
+	return m, nil
+}
+
+// deleteGroupView renders the group deletion dialog as an overlay
+func (m Model) deleteGroupView() string {
+	keyStyle := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("#1a1a2e")).
+		Background(lipgloss.Color(ColorAccent)).
+		Bold(true).
+		Padding(0, 1)
+
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+	group := m.deleteGroupTarget
+	if group == nil {
+		return ""
+	}
+	boxContent.WriteString(fmt.Sprintf("  Group: %s (%d sessions)\n\n", group.Name, m.groupSessionCount(group.ID)))
+
+	if m.deleteGroupPicking {
+		boxContent.WriteString("  Move the sessions to:\n\n")
+		for i, g := range m.otherGroups() {
+			if i == m.deleteGroupCursor {
+				boxContent.WriteString(fmt.Sprintf("  ❯ 📁 %s\n", g.Name))
+			} else {
+				boxContent.WriteString(fmt.Sprintf("    📁 %s\n", g.Name))
+			}
+		}
+		boxContent.WriteString("\n")
+		boxContent.WriteString(helpStyle.Render("  enter: move & delete  esc: back"))
+		boxContent.WriteString("\n")
+		return m.renderOverlayDialog(" Delete Group ", boxContent.String(), 56, ColorRed)
+	}
+
+	boxContent.WriteString("  What happens to its sessions?\n\n")
+	boxContent.WriteString("  " + keyStyle.Render("m") + " Move     - to another group\n\n")
+	boxContent.WriteString("  " + keyStyle.Render("u") + " Ungroup  - keep them without a group\n\n")
+	boxContent.WriteString("  " + keyStyle.Render("a") + " Archive  - delete them with the group\n")
+	boxContent.WriteString(dimStyle.Render("               (protected sessions are ungrouped)"))
+	boxContent.WriteString("\n\n")
+	boxContent.WriteString(dimStyle.Render(fmt.Sprintf("  %s in the list undoes the deletion", m.keys.help("reopen"))))
+	boxContent.WriteString("\n\n")
+	boxContent.WriteString(helpStyle.Render("  esc: cancel"))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Delete Group ", boxContent.String(), 56, ColorRed)
+}
@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// UsageRefreshInterval is how often the token usage of sessions is re-read from their transcripts
+const UsageRefreshInterval = 30 * time.Second
+
+// usageDays is how many days the usage summary lists
+const usageDays = 7
+
+// usageLoadedMsg is sent when the token usage of the sessions is summed
+type usageLoadedMsg struct {
+	usage map[string]session.SessionUsage // Instance ID -> usage
+}
+
+// usageCmd sums the token usage of sessions off the UI loop (transcripts are
+// only re-parsed when they changed)
+func usageCmd(instances []*session.Instance, prices map[string]session.ModelPrice) tea.Cmd {
+	return func() tea.Msg {
+		usage := make(map[string]session.SessionUsage, len(instances))
+		for _, inst := range instances {
+			usage[inst.ID] = inst.Usage(prices)
+		}
+		return usageLoadedMsg{usage: usage}
+	}
+}
+
+// refreshUsage starts summing the token usage when the last sum is older than UsageRefreshInterval
+func (m *Model) refreshUsage() tea.Cmd {
+	if m.usageLoading || time.Since(m.usageAt) < UsageRefreshInterval || len(m.instances) == 0 {
+		return nil
+	}
+	return m.loadUsage()
+}
+
+// loadUsage starts summing the token usage of all sessions of the project
+func (m *Model) loadUsage() tea.Cmd {
+	m.usageLoading = true
+	m.usageAt = time.Now()
+	return usageCmd(append([]*session.Instance(nil), m.instances...), session.ModelPrices(m.config.Prices))
+}
+
+// handleUsageLoaded stores summed token usage
+func (m *Model) handleUsageLoaded(msg usageLoadedMsg) {
+	m.usage = msg.usage
+	m.usageLoading = false
+}
+
+// handleShowUsage opens the token usage and cost summary
+func (m *Model) handleShowUsage() tea.Cmd {
+	m.state = stateUsage
+	if m.usageLoading {
+		return nil
+	}
+	return m.loadUsage()
+}
+
+// usageSummary returns the preview line about a session's token usage ("" = none seen)
+func (m Model) usageSummary(inst *session.Instance) string {
+	u, ok := m.usage[inst.ID]
+	if !ok || u.Total.Tokens() == 0 {
+		return ""
+	}
+	text := fmt.Sprintf("%s · %s tokens", u.Total.FormatCost(), session.FormatTokens(u.Total.Tokens()))
+	if today, ok := u.ByDay[time.Now().Format("2006-01-02")]; ok && today.Tokens() < u.Total.Tokens() {
+		text += fmt.Sprintf(" (today %s)", today.FormatCost())
+	}
+	return text
+}
+
+// handleUsageKeys handles keyboard input in the usage summary
+func (m Model) handleUsageKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q", "$":
+		m.state = stateList
+	case "r":
+		if !m.usageLoading {
+			return m, m.loadUsage()
+		}
+	}
+	return m, nil
+}
+
+// usageRow formats one row of the usage summary
+func usageRow(nameWidth int, name string, u session.TokenUsage) string {
+	return fmt.Sprintf(" %-*s %8s %8s %8s %10s", nameWidth, truncateRunes(name, nameWidth),
+		session.FormatTokens(u.Input+u.CacheWrite+u.CacheRead), session.FormatTokens(u.Output),
+		session.FormatTokens(u.Tokens()), u.FormatCost())
+}
+
+// usageView renders the token usage and cost summary as an overlay
+func (m Model) usageView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 70
+	nameWidth := boxWidth - 46
+	scope := "project"
+	if m.activeProject != nil {
+		scope = "project " + m.activeProject.Name
+	}
+	boxContent.WriteString("  Token usage and estimated cost, " + truncateRunes(scope, 40) + "\n\n")
+
+	if m.usage == nil {
+		boxContent.WriteString(dimStyle.Render("  Reading transcripts…"))
+		boxContent.WriteString("\n")
+	} else {
+		columns := fmt.Sprintf(" %8s %8s %8s %10s", "Input", "Output", "Total", "Cost")
+		sectionStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorCyan)).Bold(true)
+
+		// Per group, sessions outside groups last
+		var total session.TokenUsage
+		var byDay []map[string]session.TokenUsage
+		groupUsage := make(map[string]session.TokenUsage)
+		for _, inst := range m.instances {
+			u := m.usage[inst.ID]
+			total.Add(u.Total)
+			byDay = append(byDay, u.ByDay)
+			g := groupUsage[inst.GroupID]
+			g.Add(u.Total)
+			groupUsage[inst.GroupID] = g
+		}
+		boxContent.WriteString("   " + sectionStyle.Render(fmt.Sprintf("%-*s", nameWidth, "By group")) + dimStyle.Render(columns))
+		boxContent.WriteString("\n")
+		for _, g := range m.groups {
+			if u, ok := groupUsage[g.ID]; ok {
+				boxContent.WriteString("  " + usageRow(nameWidth, g.Name, u) + "\n")
+			}
+		}
+		if u, ok := groupUsage[""]; ok {
+			boxContent.WriteString("  " + dimStyle.Render(usageRow(nameWidth, "(no group)", u)) + "\n")
+		}
+
+		// Per day, newest first
+		boxContent.WriteString("\n   " + sectionStyle.Render("By day"))
+		boxContent.WriteString("\n")
+		days := session.UsageDays(byDay...)
+		if len(days) == 0 {
+			boxContent.WriteString(dimStyle.Render("   No usage recorded yet"))
+			boxContent.WriteString("\n")
+		}
+		for idx, day := range days {
+			if idx == usageDays {
+				boxContent.WriteString(dimStyle.Render(fmt.Sprintf("   … %d earlier days", len(days)-usageDays)))
+				boxContent.WriteString("\n")
+				break
+			}
+			var u session.TokenUsage
+			for _, d := range byDay {
+				u.Add(d[day])
+			}
+			name := day
+			if t, err := time.ParseInLocation("2006-01-02", day, time.Local); err == nil {
+				name = t.Format("Mon 2006-01-02")
+			}
+			boxContent.WriteString("  " + usageRow(nameWidth, name, u) + "\n")
+		}
+
+		boxContent.WriteString("\n")
+		boxContent.WriteString(lipgloss.NewStyle().Bold(true).Render("  " + usageRow(nameWidth, "Total", total)))
+		boxContent.WriteString("\n")
+		boxContent.WriteString(dimStyle.Render("  Input includes cache reads/writes · list prices, see [prices] in config.toml"))
+		boxContent.WriteString("\n")
+		if total.Unpriced > 0 {
+			boxContent.WriteString(dimStyle.Render(fmt.Sprintf("  + %s tokens of models without a price", session.FormatTokens(total.Unpriced))))
+			boxContent.WriteString("\n")
+		}
+	}
+
+	boxContent.WriteString("\n")
+	help := "  r: refresh  esc: close"
+	if m.usageLoading {
+		help = "  refreshing…  esc: close"
+	}
+	boxContent.WriteString(helpStyle.Render(help))
+	boxContent.WriteString("\n")
+
+	return m.renderOverlayDialog(" Usage & Cost ", boxContent.String(), boxWidth, ColorCyan)
+}
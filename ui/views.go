@@ -2,6 +2,7 @@ package ui
 
 import (
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -16,8 +17,27 @@ func truncateRunes(s string, maxLen int) string {
 }
 
 // View implements tea.Model and renders the current UI state.
-// It returns different views based on the current application state.
+// In debug mode (ASMGR_DEBUG) every frame is timed.
 func (m Model) View() string {
+	if m.frames == nil {
+		return m.screen()
+	}
+	start := time.Now()
+	view := m.screen()
+	m.frames.add(time.Since(start), m.state)
+	return view
+}
+
+// screen renders the current state, as plain lines in screen-reader mode
+func (m Model) screen() string {
+	if m.accessible {
+		return m.linearView()
+	}
+	return m.render()
+}
+
+// render returns different views based on the current application state
+func (m Model) render() string {
 	switch m.state {
 	case stateProjectSelect:
 		return m.projectSelectView()
@@ -39,7 +59,7 @@ func (m Model) View() string {
 		return m.selectStartModeView()
 	case stateConfirmStart:
 		return m.confirmStartView()
-	case stateNewName, stateNewPath:
+	case stateNewName, stateNewPath, stateNewContainer:
 		return m.newInstanceView()
 	case stateRename:
 		return m.renameView()
@@ -65,7 +85,7 @@ func (m Model) View() string {
 		return m.confirmUpdateView()
 	case stateCheckingUpdate:
 		return m.checkingUpdateView()
-	case stateUpdating:
+	case stateUpdating, stateDownloadingDeb, stateDownloadingRpm:
 		return m.updatingView()
 	case stateUpdateSuccess:
 		return m.updateSuccessView()
@@ -105,6 +125,96 @@ func (m Model) View() string {
 		return m.globalSearchNewNameView()
 	case stateGlobalSearchSelectMatch:
 		return m.globalSearchSelectMatchView()
+	case stateMessages:
+		return m.messagesView()
+	case stateConfirmDryRun:
+		return m.dryRunView()
+	case stateTmuxConsole:
+		return m.consoleView()
+	case stateExportReport:
+		return m.exportReportView()
+	case stateConfirmDeleteTranscript:
+		return m.confirmDeleteTranscriptView()
+	case stateEditCustomCmd:
+		return m.editCustomCmdView()
+	case stateEditFlags:
+		return m.editFlagsView()
+	case stateModelPicker:
+		return m.modelPickerView()
+	case stateDigest:
+		return m.digestView()
+	case stateMCP:
+		return m.mcpView()
+	case stateConfirmRespawnCmd:
+		return m.confirmRespawnCmdView()
+	case stateSetTimezone:
+		return m.setTimezoneView()
+	case stateReadiness:
+		return m.readinessView()
+	case stateSchedulePrompt:
+		return m.schedulePromptView()
+	case stateResources:
+		return m.resourcesView()
+	case stateProcesses:
+		return m.processesView()
+	case stateWindowPicker:
+		return m.windowPickerView()
+	case stateAdopt:
+		return m.adoptView()
+	case stateAbout:
+		return m.aboutView()
+	case stateBatchConfirm:
+		return m.batchConfirmView()
+	case stateDeleteGroup:
+		return m.deleteGroupView()
+	case stateLimitWarning:
+		return m.limitView()
+	case stateBranchPicker:
+		return m.branchPickerView()
+	case stateCommitMessage:
+		return m.commitMessageView()
+	case stateTransfer:
+		return m.transferView()
+	case stateSandboxPicker:
+		return m.sandboxPickerView()
+	case stateStuck:
+		return m.stuckView()
+	case statePermissions:
+		return m.permissionsView()
+	case stateEnvVars:
+		return m.envVarsView()
+	case stateServerLost:
+		return m.serverLostView()
+	case stateScrollback:
+		return m.scrollbackView()
+	case stateHandover:
+		return m.handoverView()
+	case stateExternalChange:
+		return m.externalChangeView()
+	case stateChurn:
+		return m.churnView()
+	case stateUsage:
+		return m.usageView()
+	case statePresets:
+		return m.presetsView()
+	case stateExportConversation:
+		return m.exportConversationView()
+	case stateCodeSearch:
+		return m.codeSearchView()
+	case stateCodeSearchPrompt:
+		return m.codeSearchPromptView()
+	case stateAlerts:
+		return m.alertsView()
+	case stateRelink:
+		return m.relinkView()
+	case stateInferProject:
+		return m.inferProjectView()
+	case stateGradients:
+		return m.gradientsView()
+	case stateGradientEdit:
+		return m.gradientEditView()
+	case stateGradientImport:
+		return m.gradientImportView()
 	default:
 		return m.listView()
 	}
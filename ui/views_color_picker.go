@@ -212,7 +212,7 @@ func (m Model) colorPickerView() string {
 	}
 
 	b.WriteString("\n")
-	b.WriteString(helpStyle.Render("  enter: select  esc: cancel"))
+	b.WriteString(helpStyle.Render(fmt.Sprintf("  enter: select  g: gradients  a: auto color (%s)  esc: cancel", autoColorLabel(m.autoColor))))
 
 	return b.String()
 }
@@ -3,6 +3,7 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/izll/agent-session-manager/session"
@@ -12,6 +13,14 @@ import (
 func (m Model) confirmDeleteView() string {
 	var boxContent strings.Builder
 	boxContent.WriteString("\n\n")
+	if m.deleteTarget != nil && m.deleteTarget.Protected {
+		boxContent.WriteString(fmt.Sprintf("  🔒 '%s' is protected.\n", m.deleteTarget.Name))
+		boxContent.WriteString("  Type the session name to delete it:\n\n")
+		boxContent.WriteString("  " + m.nameInput.View() + "\n\n")
+		boxContent.WriteString(helpStyle.Render("  enter: delete  esc: cancel"))
+		boxContent.WriteString("\n")
+		return m.renderOverlayDialog(" Confirm Delete ", boxContent.String(), 50, "#FF5F87")
+	}
 	if m.deleteTarget != nil {
 		boxContent.WriteString(fmt.Sprintf("  Delete session '%s'?\n\n", m.deleteTarget.Name))
 	}
@@ -25,13 +34,20 @@ func (m Model) confirmDeleteView() string {
 func (m Model) confirmStopView() string {
 	var boxContent strings.Builder
 	boxContent.WriteString("\n\n")
+	boxWidth := 40
 	if m.stopTarget != nil {
 		boxContent.WriteString(fmt.Sprintf("  Stop session '%s'?\n\n", m.stopTarget.Name))
+		// Dry-run: show the exact commands that will run
+		if m.dryRun {
+			boxWidth = 80
+			boxContent.WriteString(renderCommandLines(m.stopTarget.StopCommandLines(), boxWidth))
+			boxContent.WriteString("\n")
+		}
 	}
 	boxContent.WriteString(helpStyle.Render("  y: yes  n: no"))
 	boxContent.WriteString("\n")
 
-	return m.renderOverlayDialog(" Confirm Stop ", boxContent.String(), 40, "#FFA500")
+	return m.renderOverlayDialog(" Confirm Stop ", boxContent.String(), boxWidth, "#FFA500")
 }
 
 // confirmStartView renders the auto-start confirmation dialog as an overlay
@@ -64,6 +80,11 @@ func (m Model) selectStartModeView() string {
 		boxContent.WriteString("  2/n: Start parallel session\n")
 		boxContent.WriteString(helpStyle.Render("       (new instance below current)"))
 		boxContent.WriteString("\n\n")
+		if m.startModeGit {
+			boxContent.WriteString("  3/w: Parallel session in a new worktree\n")
+			boxContent.WriteString(helpStyle.Render("       (own branch & working tree)"))
+			boxContent.WriteString("\n\n")
+		}
 	}
 	boxContent.WriteString(helpStyle.Render("  esc: cancel"))
 	boxContent.WriteString("\n")
@@ -79,14 +100,31 @@ func (m Model) newInstanceView() string {
 	if m.state == stateNewPath {
 		boxContent.WriteString("  Project Path:\n")
 		boxContent.WriteString("  " + m.pathInput.View() + "\n")
+		if m.config != nil && len(m.config.PathRoots) > 0 {
+			boxContent.WriteString(dimStyle.Render("  Relative paths are looked up in: "+strings.Join(m.config.PathRoots, ", ")) + "\n")
+		}
+		boxContent.WriteString(dimStyle.Render("  host:path runs the session on that host over SSH") + "\n")
+	} else if m.state == stateNewContainer {
+		boxContent.WriteString(fmt.Sprintf("  Path: %s\n", m.pathInput.Value()))
+		boxContent.WriteString(fmt.Sprintf("  Session Name: %s\n\n", m.nameInput.Value()))
+		boxContent.WriteString("  Docker Container:\n")
+		boxContent.WriteString("  " + m.containerInput.View() + "\n")
+		boxContent.WriteString(dimStyle.Render("  The agent runs via docker exec in this running container") + "\n")
 	} else {
 		boxContent.WriteString(fmt.Sprintf("  Path: %s\n\n", m.pathInput.Value()))
 		boxContent.WriteString("  Session Name:\n")
 		boxContent.WriteString("  " + m.nameInput.View() + "\n")
+		if !m.isParallelSession {
+			boxContent.WriteString("\n  Runtime: " + projectNameStyle.Render(session.RuntimeLabel(m.pendingRuntime)) + "\n")
+		}
 	}
 
+	help := "  enter: confirm  esc: cancel"
+	if m.state == stateNewName && !m.isParallelSession {
+		help += "  tab: runtime"
+	}
 	boxContent.WriteString("\n")
-	boxContent.WriteString(helpStyle.Render("  enter: confirm  esc: cancel"))
+	boxContent.WriteString(helpStyle.Render(help))
 	boxContent.WriteString("\n")
 
 	boxWidth := 60
@@ -97,7 +135,7 @@ func (m Model) newInstanceView() string {
 		boxWidth = 80
 	}
 
-	return m.renderOverlayDialog(" New Session ", boxContent.String(), boxWidth, "#7D56F4")
+	return m.renderOverlayDialog(" New Session ", boxContent.String(), boxWidth, ColorAccent)
 }
 
 // renameView renders the rename dialog as an overlay
@@ -123,7 +161,7 @@ func (m Model) renameView() string {
 		boxWidth = 60
 	}
 
-	return m.renderOverlayDialog(" Rename Session ", boxContent.String(), boxWidth, "#7D56F4")
+	return m.renderOverlayDialog(" Rename Session ", boxContent.String(), boxWidth, ColorAccent)
 }
 
 // promptView renders the prompt input dialog overlaid on the list view
@@ -166,16 +204,43 @@ func (m *Model) promptView() string {
 		boxContent.WriteString(suggestionStyle.Render(fmt.Sprintf("  → %s", m.promptSuggestion)) + "\n")
 	}
 
+	if status := m.promptHistoryStatus(); status != "" {
+		boxContent.WriteString(dimStyle.Render("  "+status) + "\n")
+	}
+
+	// Resolved template preview
+	if m.promptResolved != "" {
+		boxContent.WriteString("\n  Preview:\n")
+		lines := strings.Split(m.promptResolved, "\n")
+		for i, line := range lines {
+			if i == PromptPreviewLines {
+				boxContent.WriteString(dimStyle.Render(fmt.Sprintf("  … %d more line(s)", len(lines)-i)) + "\n")
+				break
+			}
+			boxContent.WriteString(dimStyle.Render("  "+truncateRunes(line, boxWidth-6)) + "\n")
+		}
+		if len(m.promptUnresolved) > 0 {
+			warnStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorOrange))
+			boxContent.WriteString(warnStyle.Render("  ⚠ unresolved: {{"+strings.Join(m.promptUnresolved, "}} {{")+"}}") + "\n")
+		}
+	} else if strings.Contains(m.promptInput.Value(), "{{") {
+		boxContent.WriteString(dimStyle.Render("  {{"+strings.Join(session.TemplateVars, "}} {{")+"}}") + "\n")
+	}
+
 	boxContent.WriteString("\n")
 
-	helpText := "  ctrl+s: send  esc: cancel"
-	if m.promptSuggestion != "" {
-		helpText = "  tab: accept  ctrl+s: send  esc: cancel"
+	helpText := "  ctrl+s: send  ctrl+t: schedule  esc: cancel"
+	if m.promptResolved != "" {
+		helpText = "  ctrl+s: send resolved  type: edit  esc: cancel"
+	} else if session.HasTemplateVars(m.promptInput.Value()) {
+		helpText = "  ctrl+s: preview  ctrl+t: schedule  esc: cancel"
+	} else if m.promptSuggestion != "" {
+		helpText = "  tab: accept  ctrl+s: send  ctrl+t: schedule  esc: cancel"
 	}
 	boxContent.WriteString(helpStyle.Render(helpText))
 	boxContent.WriteString("\n")
 
-	return m.renderOverlayDialog(" Send Message ", boxContent.String(), boxWidth, "#7D56F4")
+	return m.renderOverlayDialog(" Send Message ", boxContent.String(), boxWidth, ColorAccent)
 }
 
 // newGroupView renders the new group dialog as an overlay
@@ -188,7 +253,7 @@ func (m Model) newGroupView() string {
 	boxContent.WriteString(helpStyle.Render("  enter: create  esc: cancel"))
 	boxContent.WriteString("\n")
 
-	return m.renderOverlayDialog(" New Group ", boxContent.String(), 50, "#7D56F4")
+	return m.renderOverlayDialog(" New Group ", boxContent.String(), 50, ColorAccent)
 }
 
 // renameGroupView renders the rename group dialog as an overlay
@@ -210,7 +275,7 @@ func (m Model) renameGroupView() string {
 	boxContent.WriteString(helpStyle.Render("  enter: confirm  esc: cancel"))
 	boxContent.WriteString("\n")
 
-	return m.renderOverlayDialog(" Rename Group ", boxContent.String(), 50, "#7D56F4")
+	return m.renderOverlayDialog(" Rename Group ", boxContent.String(), 50, ColorAccent)
 }
 
 // selectGroupView renders the group selection dialog as an overlay
@@ -232,7 +297,9 @@ func (m *Model) selectGroupView() string {
 		inst = m.instances[m.cursor]
 	}
 
-	if inst != nil {
+	if m.batchMoving {
+		boxContent.WriteString(fmt.Sprintf("  Sessions: %d selected\n\n", len(m.multiSelectedInstances())))
+	} else if inst != nil {
 		boxContent.WriteString(fmt.Sprintf("  Session: %s\n\n", inst.Name))
 	}
 
@@ -258,7 +325,7 @@ func (m *Model) selectGroupView() string {
 	boxContent.WriteString(helpStyle.Render("  enter: select  esc: cancel"))
 	boxContent.WriteString("\n")
 
-	return m.renderOverlayDialog(" Assign to Group ", boxContent.String(), 50, "#7D56F4")
+	return m.renderOverlayDialog(" Assign to Group ", boxContent.String(), 50, ColorAccent)
 }
 
 // selectAgentView renders the agent type selection dialog as an overlay
@@ -270,27 +337,42 @@ func (m Model) selectAgentView() string {
 	// Agent options with descriptions
 	agents := []struct {
 		agent session.AgentType
-		icon  string
-		name  string
 		desc  string
 	}{
-		{session.AgentClaude, "🤖", "Claude Code", "Anthropic CLI (resume, auto-yes)"},
-		{session.AgentGemini, "✨", "Gemini", "Google AI CLI"},
-		{session.AgentAider, "🔧", "Aider", "AI pair programming (auto-yes)"},
-		{session.AgentCodex, "🧠", "Codex CLI", "OpenAI coding agent (auto-yes)"},
-		{session.AgentAmazonQ, "📦", "Amazon Q", "AWS AI assistant (auto-yes)"},
-		{session.AgentOpenCode, "💻", "OpenCode", "Terminal AI assistant"},
-		{session.AgentCursor, "🖱️", "Cursor", "AI-powered code editor"},
-		{session.AgentCustom, "⚙️", "Custom", "Custom command"},
+		{session.AgentClaude, "Anthropic CLI (resume, auto-yes)"},
+		{session.AgentGemini, "Google AI CLI"},
+		{session.AgentAider, "AI pair programming (auto-yes)"},
+		{session.AgentCodex, "OpenAI coding agent (auto-yes)"},
+		{session.AgentAmazonQ, "AWS AI assistant (auto-yes)"},
+		{session.AgentOpenCode, "Terminal AI assistant"},
+		{session.AgentCursor, "AI-powered code editor"},
+		{session.AgentCustom, "Custom command"},
 	}
 
 	for i, a := range agents {
+		icon, name := getAgentIcon(a.agent), agentLabel(a.agent, "")
 		if m.agentCursor == i {
-			boxContent.WriteString(fmt.Sprintf("  ❯ %s %s\n", a.icon, a.name))
+			boxContent.WriteString(fmt.Sprintf("  ❯ %s %s\n", icon, name))
 			boxContent.WriteString(dimStyle.Render(fmt.Sprintf("       %s", a.desc)))
 			boxContent.WriteString("\n")
 		} else {
-			boxContent.WriteString(fmt.Sprintf("    %s %s\n", a.icon, a.name))
+			boxContent.WriteString(fmt.Sprintf("    %s %s\n", icon, name))
+		}
+	}
+
+	// Profiles from config.toml, selected like agents
+	if profiles := m.config.ProfileNames(); len(profiles) > 0 {
+		boxContent.WriteString("\n  Profiles:\n")
+		for i, name := range profiles {
+			profile := m.config.Profiles[name]
+			icon := getAgentIcon(profile.ProfileAgent())
+			if m.agentCursor == len(agents)+i {
+				boxContent.WriteString(fmt.Sprintf("  ❯ %s %s\n", icon, truncateRunes(name, 36)))
+				boxContent.WriteString(dimStyle.Render(fmt.Sprintf("       %s", truncateRunes(profile.Describe(), 40))))
+				boxContent.WriteString("\n")
+			} else {
+				boxContent.WriteString(fmt.Sprintf("    %s %s\n", icon, truncateRunes(name, 36)))
+			}
 		}
 	}
 
@@ -306,7 +388,7 @@ func (m Model) selectAgentView() string {
 	boxContent.WriteString(helpStyle.Render("  enter: select  esc: cancel"))
 	boxContent.WriteString("\n")
 
-	return m.renderOverlayDialog(" New Session ", boxContent.String(), 50, "#7D56F4")
+	return m.renderOverlayDialog(" New Session ", boxContent.String(), 50, ColorAccent)
 }
 
 // customCmdView renders the custom command input dialog as an overlay
@@ -337,10 +419,10 @@ func (m Model) customCmdView() string {
 		boxWidth = 80
 	}
 
-	return m.renderOverlayDialog(" Custom Command ", boxContent.String(), boxWidth, "#7D56F4")
+	return m.renderOverlayDialog(" Custom Command ", boxContent.String(), boxWidth, ColorAccent)
 }
 
-// errorView renders the error/info overlay dialog
+// errorView renders the error overlay dialog
 func (m Model) errorView() string {
 	var boxContent strings.Builder
 	boxContent.WriteString("\n\n")
@@ -350,18 +432,10 @@ func (m Model) errorView() string {
 		errMsg = m.err.Error()
 	}
 
-	// Detect success messages
-	isSuccess := strings.HasPrefix(errMsg, "successfully")
 	title := " Error "
 	color := "#FF5555"
 	textColor := "#FF5555"
 
-	if isSuccess {
-		title = " Success "
-		color = "#04B575"
-		textColor = "#04B575"
-	}
-
 	textStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(textColor))
 	boxContent.WriteString(textStyle.Render(fmt.Sprintf("  %s", errMsg)))
 	boxContent.WriteString("\n\n")
@@ -409,10 +483,10 @@ func (m Model) checkingUpdateView() string {
 func (m Model) updatingView() string {
 	boxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color("#7D56F4")).
+		BorderForeground(lipgloss.Color(ColorAccent)).
 		Padding(2, 4)
 
-	content := fmt.Sprintf("Downloading %s...\n\nPlease wait...", m.updateAvailable)
+	content := fmt.Sprintf("%s Downloading %s...\n\n%s", m.spinner.View(), m.updateAvailable, helpStyle.Render("esc: continue in background"))
 	box := boxStyle.Render(content)
 
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
@@ -452,7 +526,10 @@ func (m *Model) notesView() string {
 
 	// Determine title and context based on window index
 	title := " Session Notes "
-	if inst := m.getSelectedInstance(); inst != nil {
+	if idx := m.findGroupIndex(m.notesGroupID); m.notesGroupID != "" && idx >= 0 {
+		title = " Group Notes "
+		boxContent.WriteString(fmt.Sprintf("  Group: %s\n\n", m.groups[idx].Name))
+	} else if inst := m.getSelectedInstance(); inst != nil {
 		// If there are multiple tabs, always show "Tab Notes"
 		hasTabs := len(inst.FollowedWindows) > 0
 		if hasTabs {
@@ -507,14 +584,14 @@ func (m *Model) notesView() string {
 	boxContent.WriteString(helpStyle.Render(helpText))
 	boxContent.WriteString("\n")
 
-	return m.renderOverlayDialog(title, boxContent.String(), boxWidth, "#7D56F4")
+	return m.renderOverlayDialog(title, boxContent.String(), boxWidth, ColorAccent)
 }
 
 // newTabChoiceView renders the Agent/Terminal choice dialog
 func (m Model) newTabChoiceView() string {
 	keyStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#1a1a2e")).
-		Background(lipgloss.Color(ColorPurple)).
+		Background(lipgloss.Color(ColorAccent)).
 		Bold(true).
 		Padding(0, 1)
 
@@ -530,7 +607,7 @@ func (m Model) newTabChoiceView() string {
 	boxContent.WriteString(helpStyle.Render("  esc: cancel"))
 	boxContent.WriteString("\n")
 
-	return m.renderOverlayDialog(" New Tab ", boxContent.String(), 50, "#7D56F4")
+	return m.renderOverlayDialog(" New Tab ", boxContent.String(), 50, ColorAccent)
 }
 
 // newTabView renders the new tab creation dialog
@@ -547,7 +624,7 @@ func (m Model) newTabView() string {
 	boxContent.WriteString(helpStyle.Render("  enter: create  esc: cancel"))
 	boxContent.WriteString("\n")
 
-	return m.renderOverlayDialog(" New Tab ", boxContent.String(), 50, "#7D56F4")
+	return m.renderOverlayDialog(" New Tab ", boxContent.String(), 50, ColorAccent)
 }
 
 // renameTabView renders the tab rename dialog
@@ -559,7 +636,7 @@ func (m Model) renameTabView() string {
 	boxContent.WriteString(helpStyle.Render("  enter: rename  esc: cancel"))
 	boxContent.WriteString("\n")
 
-	return m.renderOverlayDialog(" Rename Tab ", boxContent.String(), 50, "#7D56F4")
+	return m.renderOverlayDialog(" Rename Tab ", boxContent.String(), 50, ColorAccent)
 }
 
 // newTabAgentView renders the agent selection dialog for new tab
@@ -569,26 +646,23 @@ func (m Model) newTabAgentView() string {
 	boxContent.WriteString("  Select Agent for Tab:\n\n")
 
 	// Agent options (same as selectAgentView but for tab)
-	agents := []struct {
-		agent session.AgentType
-		icon  string
-		name  string
-	}{
-		{session.AgentClaude, "🤖", "Claude Code"},
-		{session.AgentGemini, "✨", "Gemini"},
-		{session.AgentAider, "🔧", "Aider"},
-		{session.AgentCodex, "🧠", "Codex CLI"},
-		{session.AgentAmazonQ, "📦", "Amazon Q"},
-		{session.AgentOpenCode, "💻", "OpenCode"},
-		{session.AgentCursor, "🖱️", "Cursor"},
-		{session.AgentCustom, "⚙️", "Custom"},
-	}
-
-	for i, a := range agents {
+	agents := []session.AgentType{
+		session.AgentClaude,
+		session.AgentGemini,
+		session.AgentAider,
+		session.AgentCodex,
+		session.AgentAmazonQ,
+		session.AgentOpenCode,
+		session.AgentCursor,
+		session.AgentCustom,
+	}
+
+	for i, agent := range agents {
+		icon, name := getAgentIcon(agent), agentLabel(agent, "")
 		if m.newTabAgentCursor == i {
-			boxContent.WriteString(fmt.Sprintf("  ❯ %s %s\n", a.icon, a.name))
+			boxContent.WriteString(fmt.Sprintf("  ❯ %s %s\n", icon, name))
 		} else {
-			boxContent.WriteString(fmt.Sprintf("    %s %s\n", a.icon, a.name))
+			boxContent.WriteString(fmt.Sprintf("    %s %s\n", icon, name))
 		}
 	}
 
@@ -604,14 +678,14 @@ func (m Model) newTabAgentView() string {
 	boxContent.WriteString(helpStyle.Render("  enter: select  esc: cancel"))
 	boxContent.WriteString("\n")
 
-	return m.renderOverlayDialog(" Agent Tab ", boxContent.String(), 40, "#7D56F4")
+	return m.renderOverlayDialog(" Agent Tab ", boxContent.String(), 40, ColorAccent)
 }
 
 // deleteChoiceView renders the delete choice dialog (session vs tab)
 func (m Model) deleteChoiceView() string {
 	keyStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#1a1a2e")).
-		Background(lipgloss.Color(ColorPurple)).
+		Background(lipgloss.Color(ColorAccent)).
 		Bold(true).
 		Padding(0, 1)
 
@@ -656,7 +730,7 @@ func (m Model) confirmDeleteTabView() string {
 func (m Model) stopChoiceView() string {
 	keyStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#1a1a2e")).
-		Background(lipgloss.Color(ColorPurple)).
+		Background(lipgloss.Color(ColorAccent)).
 		Bold(true).
 		Padding(0, 1)
 
@@ -723,11 +797,19 @@ func (m Model) confirmYoloView() string {
 	if m.yoloNewState {
 		boxContent.WriteString(fmt.Sprintf("\n\n Enable YOLO mode for:\n %s?\n\n", targetName))
 		boxContent.WriteString(" ⚠️  Agent will auto-approve all actions!\n\n")
+		boxContent.WriteString("  y  Until turned off\n")
+		for idx, d := range session.YoloDurations {
+			boxContent.WriteString(fmt.Sprintf("  %s  For %d minutes\n", yoloLimitKey(idx), int(d.Minutes())))
+		}
+		boxContent.WriteString(fmt.Sprintf("  %s  Approve the next %d prompts only\n\n", yoloApprovalsKey(), session.YoloApprovals))
+		boxContent.WriteString(helpStyle.Render(fmt.Sprintf("  y/1-%s: enable  n: cancel", yoloApprovalsKey())))
 	} else {
 		boxContent.WriteString(fmt.Sprintf("\n\n Disable YOLO mode for:\n %s?\n\n", targetName))
+		if left := inst.WindowYoloGrant(m.yoloWindowIndex).Remaining(time.Now()); left != "" {
+			boxContent.WriteString(fmt.Sprintf(" Currently on (%s)\n\n", left))
+		}
+		boxContent.WriteString(helpStyle.Render("  y: yes  n: no"))
 	}
-
-	boxContent.WriteString(helpStyle.Render("  y: yes  n: no"))
 	boxContent.WriteString("\n")
 
 	return m.renderOverlayDialog(" Confirm YOLO ", boxContent.String(), 45, "#FFA500")
@@ -68,5 +68,5 @@ func (m Model) forkDialogView() string {
 		boxWidth = 55
 	}
 
-	return m.renderOverlayDialog(" Fork Session ", content.String(), boxWidth, ColorPurple)
+	return m.renderOverlayDialog(" Fork Session ", content.String(), boxWidth, ColorAccent)
 }
@@ -32,7 +32,7 @@ func (m Model) globalSearchLoadingView() string {
 
 	boxWidth := 70
 
-	return m.renderOverlayDialog("Global Search", content.String(), boxWidth, ColorPurple)
+	return m.renderOverlayDialog("Global Search", content.String(), boxWidth, ColorAccent)
 }
 
 // globalSearchView renders the global search as a full-screen split view (like main window)
@@ -229,11 +229,11 @@ func (m Model) buildSearchPreviewPane(width, height int) string {
 	// Show matched session info
 	if m.globalSearchMatchedSession != nil {
 		matchStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorGreen))
-		sessionIcon := getAgentIcon(m.globalSearchMatchedSession.Agent)
+		sessionIcon := agentIcon(m.globalSearchMatchedSession.Agent, m.globalSearchMatchedSession.CustomCommand)
 		matchInfo := fmt.Sprintf(" → %s %s", sessionIcon, m.globalSearchMatchedSession.Name)
 		if m.globalSearchMatchedTabIndex >= 0 && m.globalSearchMatchedTabIndex < len(m.globalSearchMatchedSession.FollowedWindows) {
 			tab := m.globalSearchMatchedSession.FollowedWindows[m.globalSearchMatchedTabIndex]
-			tabIcon := getAgentIcon(tab.Agent)
+			tabIcon := agentIcon(tab.Agent, tab.CustomCommand)
 			tabName := tab.Name
 			if tabName == "" {
 				tabName = fmt.Sprintf("tab %d", m.globalSearchMatchedTabIndex+1)
@@ -341,15 +341,23 @@ func (m Model) formatConversationLines(messages []session.ConversationMessage, w
 			lines = append(lines, assistantStyle.Render(" 🤖 Assistant"))
 		}
 
-		// Message content - wrap and indent, with highlighting
-		wrapped := wrapText(msg.Content, width-4)
-		for _, line := range strings.Split(wrapped, "\n") {
-			if line == "" {
-				lines = append(lines, "")
-			} else {
-				// Apply highlighting to the content
-				highlighted := highlightMatch(line, query, contentStyle)
-				lines = append(lines, "    "+highlighted)
+		// Message content - code blocks syntax highlighted, text wrapped with match highlighting
+		for _, seg := range splitCodeBlocks(msg.Content) {
+			if seg.code {
+				for _, line := range renderCodeBlock(seg, width-4) {
+					lines = append(lines, "    "+line)
+				}
+				continue
+			}
+			wrapped := wrapText(seg.text, width-4)
+			for _, line := range strings.Split(wrapped, "\n") {
+				if line == "" {
+					lines = append(lines, "")
+				} else {
+					// Apply highlighting to the content
+					highlighted := highlightMatch(line, query, contentStyle)
+					lines = append(lines, "    "+highlighted)
+				}
 			}
 		}
 
@@ -362,9 +370,14 @@ func (m Model) formatConversationLines(messages []session.ConversationMessage, w
 
 // buildSearchStatusBar builds the status bar for global search
 func (m Model) buildSearchStatusBar() string {
+	// An active toast temporarily replaces the key hints
+	if toastLine := m.renderToast(); toastLine != "" {
+		return toastLine
+	}
+
 	keyStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#1a1a2e")).
-		Background(lipgloss.Color(ColorPurple)).
+		Background(lipgloss.Color(ColorAccent)).
 		Bold(true).
 		Padding(0, 1)
 
@@ -380,6 +393,7 @@ func (m Model) buildSearchStatusBar() string {
 		keyStyle.Render("↑↓") + descStyle.Render(" nav"),
 		keyStyle.Render("Enter") + descStyle.Render(" open"),
 		keyStyle.Render("[/] Alt+↑↓ PgUp/Dn") + descStyle.Render(" scroll"),
+		keyStyle.Render("^O") + descStyle.Render(" actions"),
 		keyStyle.Render("^R") + descStyle.Render(" reload"),
 		keyStyle.Render("ESC") + descStyle.Render(" close"),
 	}
@@ -523,7 +537,7 @@ func (m Model) globalSearchConfirmJumpView() string {
 		tabIndex := m.globalSearchMatchedTabIndex
 
 		// Session name with icon
-		icon := getAgentIcon(inst.Agent)
+		icon := agentIcon(inst.Agent, inst.CustomCommand)
 		nameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorCyan)).Bold(true)
 		content.WriteString(nameStyle.Render(fmt.Sprintf("%s %s", icon, inst.Name)))
 		content.WriteString("\n")
@@ -531,7 +545,7 @@ func (m Model) globalSearchConfirmJumpView() string {
 		// Show tab info if matched to a tab
 		if tabIndex >= 0 && tabIndex < len(inst.FollowedWindows) {
 			tab := inst.FollowedWindows[tabIndex]
-			tabIcon := getAgentIcon(tab.Agent)
+			tabIcon := agentIcon(tab.Agent, tab.CustomCommand)
 			tabStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorYellow))
 			tabName := tab.Name
 			if tabName == "" {
@@ -650,11 +664,14 @@ func (m Model) globalSearchActionView() string {
 		"1  New session",
 		"2  Add to group",
 		"3  Add as tab to current session",
+		"4  Copy content",
+		"5  Export conversation to file",
+		"6  Delete transcript",
 	}
 
 	selectedStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#FFFFFF")).
-		Background(lipgloss.Color(ColorPurple)).
+		Background(lipgloss.Color(ColorAccent)).
 		Bold(true).
 		Padding(0, 1)
 
@@ -679,7 +696,7 @@ func (m Model) globalSearchActionView() string {
 
 	boxWidth := 50
 
-	return m.renderOverlayDialog("Open Session", content.String(), boxWidth, ColorPurple)
+	return m.renderOverlayDialog("Open Session", content.String(), boxWidth, ColorAccent)
 }
 
 // globalSearchNewNameView renders the new session name input dialog
@@ -742,7 +759,7 @@ func (m Model) globalSearchNewNameView() string {
 
 	boxWidth := 50
 
-	return m.renderOverlayDialog("New Session", content.String(), boxWidth, ColorPurple)
+	return m.renderOverlayDialog("New Session", content.String(), boxWidth, ColorAccent)
 }
 
 // globalSearchSelectMatchView renders the match selection dialog
@@ -774,7 +791,7 @@ func (m Model) globalSearchSelectMatchView() string {
 	// List matches
 	selectedStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#FFFFFF")).
-		Background(lipgloss.Color(ColorPurple)).
+		Background(lipgloss.Color(ColorAccent)).
 		Bold(true).
 		Padding(0, 1)
 
@@ -783,9 +800,9 @@ func (m Model) globalSearchSelectMatchView() string {
 		Padding(0, 1)
 
 	for i, match := range m.globalSearchMatches {
-		icon := getAgentIcon(match.Session.Agent)
+		icon := agentIcon(match.Session.Agent, match.Session.CustomCommand)
 		if match.TabIndex >= 0 && match.TabIndex < len(match.Session.FollowedWindows) {
-			icon = getAgentIcon(match.Session.FollowedWindows[match.TabIndex].Agent)
+			icon = agentIcon(match.Session.FollowedWindows[match.TabIndex].Agent, match.Session.FollowedWindows[match.TabIndex].CustomCommand)
 		}
 
 		label := fmt.Sprintf("%s %s", icon, match.TabName)
@@ -824,5 +841,5 @@ func (m Model) globalSearchSelectMatchView() string {
 
 	boxWidth := 50
 
-	return m.renderOverlayDialog("Select Session", content.String(), boxWidth, ColorPurple)
+	return m.renderOverlayDialog("Select Session", content.String(), boxWidth, ColorAccent)
 }
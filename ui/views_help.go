@@ -4,21 +4,22 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/lipgloss"
 )
 
 // buildHelpContent generates the help content and returns the content string and line count
-func buildHelpContent(width int) (string, int) {
+func buildHelpContent(width int, keys keymap) (string, int) {
 	var b strings.Builder
 
 	// Styles
 	sectionStyle := lipgloss.NewStyle().
-		Foreground(lipgloss.Color(ColorPurple)).
+		Foreground(lipgloss.Color(ColorAccent)).
 		Bold(true)
 
 	keyStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#1a1a2e")).
-		Background(lipgloss.Color(ColorPurple)).
+		Background(lipgloss.Color(ColorAccent)).
 		Bold(true).
 		Padding(0, 1)
 
@@ -39,7 +40,7 @@ func buildHelpContent(width int) (string, int) {
 	// Title
 	title := lipgloss.NewStyle().
 		Foreground(lipgloss.Color(ColorWhite)).
-		Background(lipgloss.Color(ColorPurple)).
+		Background(lipgloss.Color(ColorAccent)).
 		Bold(true).
 		Padding(0, 3).
 		Render(" Agent Session Manager - Help ")
@@ -73,13 +74,32 @@ func buildHelpContent(width int) (string, int) {
 	b.WriteString("\n")
 	b.WriteString(separatorStyle.Render("  " + strings.Repeat("─", 65)))
 	b.WriteString("\n")
-	b.WriteString(renderRow("↑/↓", "Move up/down", "Ctrl+↑/↓", "Reorder session"))
+	b.WriteString(renderRow("↑/↓", "Move up/down", keys.help("move_up")+" "+keys.help("move_down"), "Reorder session"))
 	b.WriteString("\n")
-	b.WriteString(renderRow("Alt+↑/↓", "Scroll line", "PgUp/PgDn", "Scroll half page"))
+	b.WriteString(renderRow(keys.help("scroll_up")+" "+keys.help("scroll_down"), "Scroll line", keys.help("page_up")+" "+keys.help("page_down"), "Scroll half page"))
 	b.WriteString("\n")
-	b.WriteString(renderRow("Home/End", "Scroll to top/bottom", "/", "Search sessions"))
+	b.WriteString(renderRow(keys.help("scroll_top")+" "+keys.help("scroll_bottom"), "Scroll to top/bottom", keys.help("search"), "Search sessions"))
 	b.WriteString("\n")
-	b.WriteString("  " + renderKey("^F", "Global history search"))
+	leader := keys.help("leader")
+	b.WriteString(renderRow(keys.help("global_search"), "Global history search", leader+" g", "Go to top"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(leader+" p", "Project selector", leader+" S", "Start all in group"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(leader+" s", "Start sandboxed", leader+" a", "All projects list"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(leader+" r", "rg across session repos", leader+" n", "Nest forks under origin"))
+	b.WriteString("\n")
+	b.WriteString("  " + renderKey(leader+" f", "File browser of the session directory (preview pane)"))
+	b.WriteString("\n")
+	b.WriteString("  " + renderKey(leader+" d", "Weekly digest: finished sessions, time spent, changes"))
+	b.WriteString("\n")
+	b.WriteString("  " + renderKey(leader+" w", "Services to wait for (readiness checks)"))
+	b.WriteString("\n")
+	b.WriteString("  " + renderKey(leader+" e", "Environment variables of the session or tab"))
+	b.WriteString("\n")
+	b.WriteString("  " + renderKey(leader+" m", "MCP servers of the session's Claude windows"))
+	b.WriteString("\n")
+	b.WriteString("  " + noteStyle.Render("     ↳ "+leader+" is the leader key: it only waits for the second key"))
 	b.WriteString("\n")
 	b.WriteString("  " + noteStyle.Render("     ↳ ESC clears search filter"))
 	b.WriteString("\n\n")
@@ -93,19 +113,63 @@ func buildHelpContent(width int) (string, int) {
 	b.WriteString("\n")
 	b.WriteString("  " + renderKey("Enter", "Attach (starts if stopped)"))
 	b.WriteString("\n")
-	b.WriteString(renderRow("n", "New session", "e", "Rename session"))
+	b.WriteString(renderRow(keys.help("new_session"), "New session", keys.help("rename"), "Rename session"))
 	b.WriteString("\n")
-	b.WriteString(renderRow("s", "Start (background)", "a", "Replace/parallel start"))
+	b.WriteString(renderRow(keys.help("start"), "Start (background)", keys.help("replace_start"), "Replace/parallel start"))
 	b.WriteString("\n")
-	b.WriteString(renderRow("x", "Stop", "d", "Delete"))
+	b.WriteString("  " + noteStyle.Render("     ↳ parallel start can create its own git worktree and branch (3/w)"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(keys.help("stop"), "Stop", keys.help("delete"), "Delete"))
 	b.WriteString("\n")
 	b.WriteString("  " + noteStyle.Render("     ↳ x/d asks session or tab when multiple tabs exist"))
 	b.WriteString("\n")
-	b.WriteString(renderRow("r", "Resume conversation", "p", "Send prompt"))
+	b.WriteString(renderRow(keys.help("select"), "Select for batch action", keys.help("select_range"), "Select range (V … V)"))
+	b.WriteString("\n")
+	b.WriteString("  " + noteStyle.Render("     ↳ with sessions selected, s/x/d/G act on all of them after a summary; esc clears"))
+	b.WriteString("\n")
+	b.WriteString("  " + noteStyle.Render("     ↳ over a config.toml [limits] limit: warning lists idle sessions to stop"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(keys.help("resume"), "Resume conversation", keys.help("prompt"), "Send prompt"))
+	b.WriteString("\n")
+	b.WriteString("  " + noteStyle.Render("     ↳ in the prompt dialog ↑/↓ recall this session's prompts, ctrl+r searches all"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(keys.help("reopen"), "Reopen last closed", keys.help("edit_command"), "Edit command / flags"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(keys.help("import_tmux"), "Import tmux sessions (adopt)", keys.help("about"), "About (agent version, env)"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(keys.help("branch"), "Switch git branch (stopped)", keys.help("transfer"), "Transfer file/patch to session"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(keys.help("timezone"), "Set timezone", keys.help("resources"), "Resource usage"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(keys.help("processes"), "Processes (signal/kill)", "1-9", "Answer waiting prompt"))
+	b.WriteString("\n")
+	b.WriteString("  " + noteStyle.Render("     ↳ With nothing selected, 1-9 jump to the dashboard's waiting sessions"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(keys.help("stuck"), "Stuck session: nudge/restart", keys.help("alerts"), "Sound alerts"))
+	b.WriteString("\n")
+	b.WriteString("  " + renderKey(keys.help("model"), "Change the model (restarts the tab on its conversation)"))
+	b.WriteString("\n")
+	b.WriteString("  " + renderKey(keys.help("permissions"), "Permission prompts by kind & allow rule suggestions"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(keys.help("scrollback"), "Scrollback usage & limit", keys.help("churn"), "Diff stats across sessions"))
+	b.WriteString("\n")
+	b.WriteString("  " + noteStyle.Render("     ↳ ctrl+t in the prompt dialog schedules the message (9am, +30m)"))
+	b.WriteString("\n")
+	b.WriteString("  " + noteStyle.Render("     ↳ prompts may use {{branch}} {{path}} {{issue_title}} {{clipboard}} - previewed first"))
+	b.WriteString("\n")
+	b.WriteString("  " + noteStyle.Render("     ↳ r resumes in the active tab; f in picker forks first (Claude)"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(keys.help("fork"), "Fork session (Claude)", keys.help("summarize"), "Summarize into notes"))
 	b.WriteString("\n")
-	b.WriteString(renderRow("f", "Fork session (Claude)", "", ""))
+	b.WriteString(renderRow(keys.help("handover"), "Handover document (+ new session)", keys.help("usage"), "Token usage & cost"))
 	b.WriteString("\n")
 	b.WriteString("  " + noteStyle.Render("     ↳ Fork to new tab or new session"))
+	b.WriteString("\n")
+	b.WriteString("  " + renderKey(keys.help("fork_tab"), "Fork the active tab into a new tab (no dialog)"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(keys.help("presets"), "Presets gallery (install, new session)", keys.help("attach_readonly"), "Attach read-only (watch)"))
+	b.WriteString("\n")
+	b.WriteString("  " + renderKey(keys.help("preview_filter"), "Cycle preview filter (config.toml rules)"))
 	b.WriteString("\n\n")
 
 	// ═══════════════════════════════════════════════════════════════════
@@ -115,11 +179,15 @@ func buildHelpContent(width int) (string, int) {
 	b.WriteString("\n")
 	b.WriteString(separatorStyle.Render("  " + strings.Repeat("─", 65)))
 	b.WriteString("\n")
-	b.WriteString("  " + renderKey("t", "New tab (Agent or Terminal)"))
+	b.WriteString("  " + renderKey(keys.help("new_tab"), "New tab (Agent or Terminal)"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(keys.help("rename_tab"), "Rename tab", keys.help("close_tab"), "Quick close tab"))
 	b.WriteString("\n")
-	b.WriteString(renderRow("T", "Rename tab", "W", "Quick close tab"))
+	b.WriteString(renderRow(keys.help("prev_window")+" "+keys.help("next_window"), "Switch tabs", "Ctrl+F", "Toggle tracking"))
 	b.WriteString("\n")
-	b.WriteString(renderRow("Alt+←/→", "Switch tabs", "Ctrl+F", "Toggle tracking"))
+	b.WriteString("  " + renderKey(keys.help("windows"), "Window picker (switch/attach)"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(keys.help("split_right"), "Split: terminal beside", keys.help("split_below"), "Split: terminal below"))
 	b.WriteString("\n")
 	b.WriteString("  " + noteStyle.Render("     ↳ Stopped tabs show ○ indicator, remain visible"))
 	b.WriteString("\n\n")
@@ -131,11 +199,13 @@ func buildHelpContent(width int) (string, int) {
 	b.WriteString("\n")
 	b.WriteString(separatorStyle.Render("  " + strings.Repeat("─", 65)))
 	b.WriteString("\n")
-	b.WriteString(renderRow("g", "Create group", "G", "Assign to group"))
+	b.WriteString(renderRow(keys.help("new_group"), "Create group", keys.help("assign_group"), "Assign to group"))
 	b.WriteString("\n")
 	b.WriteString(renderRow("→", "Expand group", "←", "Collapse group"))
 	b.WriteString("\n")
-	b.WriteString("  " + renderKey("*", "Toggle favorite (⭐ group)"))
+	b.WriteString(renderRow(keys.help("favorite"), "Toggle favorite (⭐ group)", keys.help("protect"), "Toggle delete protection"))
+	b.WriteString("\n")
+	b.WriteString("  " + noteStyle.Render("     ↳ d on a group: move, ungroup or archive its sessions; u undoes"))
 	b.WriteString("\n\n")
 
 	// ═══════════════════════════════════════════════════════════════════
@@ -145,13 +215,13 @@ func buildHelpContent(width int) (string, int) {
 	b.WriteString("\n")
 	b.WriteString(separatorStyle.Render("  " + strings.Repeat("─", 65)))
 	b.WriteString("\n")
-	b.WriteString(renderRow("N", "Edit notes", "c", "Colors & gradients"))
+	b.WriteString(renderRow(keys.help("notes"), "Edit notes (session/group)", keys.help("colors"), "Colors & gradients"))
 	b.WriteString("\n")
 	b.WriteString("  " + noteStyle.Render("     ↳ N edits tab notes when multiple tabs exist"))
 	b.WriteString("\n")
-	b.WriteString(renderRow("l", "Compact mode", "o", "Toggle status lines"))
+	b.WriteString(renderRow(keys.help("compact"), "Compact mode", keys.help("status_lines"), "Toggle status lines"))
 	b.WriteString("\n")
-	b.WriteString(renderRow("I", "Toggle icons", "^Y", "Toggle YOLO mode"))
+	b.WriteString(renderRow(keys.help("icons"), "Toggle icons", keys.help("yolo"), "Toggle YOLO mode"))
 	b.WriteString("\n\n")
 
 	// ═══════════════════════════════════════════════════════════════════
@@ -161,7 +231,7 @@ func buildHelpContent(width int) (string, int) {
 	b.WriteString("\n")
 	b.WriteString(separatorStyle.Render("  " + strings.Repeat("─", 65)))
 	b.WriteString("\n")
-	b.WriteString(renderRow("v", "Toggle split", "m", "Mark/pin session"))
+	b.WriteString(renderRow(keys.help("split"), "Toggle split", keys.help("mark"), "Mark/pin session"))
 	b.WriteString("\n")
 	b.WriteString("  " + renderKey("Tab", "Switch focus between panes"))
 	b.WriteString("\n\n")
@@ -173,7 +243,11 @@ func buildHelpContent(width int) (string, int) {
 	b.WriteString("\n")
 	b.WriteString(separatorStyle.Render("  " + strings.Repeat("─", 65)))
 	b.WriteString("\n")
-	b.WriteString(renderRow("D", "Toggle Preview/Diff", "F", "Switch Session/Full diff"))
+	b.WriteString(renderRow(keys.help("diff"), "Toggle Preview/Diff", keys.help("full_diff"), "Switch Session/Full diff"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(keys.help("next_file")+"/"+keys.help("prev_file"), "Next/previous file", keys.help("fold_file"), "Fold/unfold file"))
+	b.WriteString("\n")
+	b.WriteString("  " + renderKey(keys.help("stage"), "Stage hunks & commit (space/a: stage, c: commit)"))
 	b.WriteString("\n")
 	b.WriteString("  " + noteStyle.Render("     ↳ Session diff: changes since session start"))
 	b.WriteString("\n")
@@ -187,11 +261,21 @@ func buildHelpContent(width int) (string, int) {
 	b.WriteString("\n")
 	b.WriteString(separatorStyle.Render("  " + strings.Repeat("─", 65)))
 	b.WriteString("\n")
-	b.WriteString(renderRow("q", "Quit to projects", "i", "Import sessions"))
+	b.WriteString(renderRow(keys.help("quit"), "Quit to projects", "i", "Import default (projects)"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(keys.help("update"), "Check updates", keys.help("resize"), "Force resize"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(keys.help("history"), "Message history", keys.help("help"), "Help"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(keys.help("dry_run"), "Toggle dry-run", keys.help("console"), "tmux console"))
 	b.WriteString("\n")
-	b.WriteString(renderRow("U", "Check updates", "R", "Force resize"))
+	b.WriteString(renderRow(keys.help("export"), "Export status report", keys.help("recording"), "Record session (cast)"))
 	b.WriteString("\n")
-	b.WriteString("  " + renderKey("?", "Help"))
+	b.WriteString(renderRow(keys.help("output_log"), "Log output lines to a file", keys.help("restart_policy"), "Restart policy (crash/always)"))
+	b.WriteString("\n")
+	b.WriteString(renderRow(keys.help("idle_stop"), "Idle auto-stop timeout", keys.help("export_conversation"), "Export conversation (md/html)"))
+	b.WriteString("\n")
+	b.WriteString("  " + noteStyle.Render("     ↳ Dry-run shows the exact tmux/agent commands before start/stop"))
 	b.WriteString("\n\n")
 
 	// ═══════════════════════════════════════════════════════════════════
@@ -204,6 +288,37 @@ func buildHelpContent(width int) (string, int) {
 	b.WriteString("  " + renderKey("Ctrl+q", "Quick detach (auto-resizes preview)"))
 	b.WriteString("\n")
 	b.WriteString("  " + renderKey("Ctrl+b d", "Standard tmux detach"))
+	b.WriteString("\n")
+	b.WriteString("  " + renderKey("Ctrl+b a", "Other sessions at a glance (popup)"))
+	b.WriteString("\n")
+	b.WriteString("  " + renderKey("Ctrl+y", "YOLO mode of this tab (time limits)"))
+	b.WriteString("\n\n")
+
+	// ═══════════════════════════════════════════════════════════════════
+	// KEY BINDINGS (current keymap, config.toml [keys])
+	// ═══════════════════════════════════════════════════════════════════
+	b.WriteString(sectionStyle.Render("  Key Bindings"))
+	b.WriteString("\n")
+	b.WriteString(separatorStyle.Render("  " + strings.Repeat("─", 65)))
+	b.WriteString("\n")
+	bindings := append(append([]key.Binding{}, keys.list...), keys.Confirm, keys.Cancel)
+	actions := append(append([]keyAction{}, keyActions...), dialogActions...)
+	bindingDesc := func(i int) string {
+		desc := bindings[i].Help().Desc
+		if keys.custom[actions[i].Name] {
+			desc += " ✎"
+		}
+		return desc
+	}
+	for i := 0; i < len(bindings); i += 2 {
+		if i+1 < len(bindings) {
+			b.WriteString(renderRow(bindings[i].Help().Key, bindingDesc(i), bindings[i+1].Help().Key, bindingDesc(i+1)))
+		} else {
+			b.WriteString("  " + renderKey(bindings[i].Help().Key, bindingDesc(i)))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("  " + noteStyle.Render("     ↳ ✎ rebound in config.toml [keys]; esc always cancels y/n dialogs"))
 	b.WriteString("\n\n")
 
 	// ═══════════════════════════════════════════════════════════════════
@@ -218,6 +333,11 @@ func buildHelpContent(width int) (string, int) {
 	b.WriteString("\n")
 	b.WriteString("  " + idleStyle.Render("●") + descStyle.Render(" Idle (ready)") + "      ")
 	b.WriteString(stoppedStyle.Render("○") + descStyle.Render(" Stopped"))
+	b.WriteString("\n")
+	b.WriteString("  " + stoppedStyle.Render("◉") + descStyle.Render(" Stuck") + "            ")
+	b.WriteString(stoppedStyle.Render("⊗") + descStyle.Render(" Agent exited ("+keys.help("start")+" respawns)"))
+	b.WriteString("\n")
+	b.WriteString("  " + dimStyle.Render("⇅") + descStyle.Render(" Busy on the API, not locally (network_indicator in config.toml)"))
 	b.WriteString("\n\n")
 
 	// ═══════════════════════════════════════════════════════════════════
@@ -242,7 +362,7 @@ func buildHelpContent(width int) (string, int) {
 // helpView renders the help screen
 func (m Model) helpView() string {
 	// Get help content
-	allContent, _ := buildHelpContent(m.width)
+	allContent, _ := buildHelpContent(m.width, m.keys)
 	allLines := strings.Split(allContent, "\n")
 
 	// Calculate visible area
@@ -2,9 +2,11 @@ package ui
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/izll/agent-session-manager/session"
 )
 
@@ -95,6 +97,15 @@ func (m Model) buildPreviewPane(contentHeight int) string {
 					rightPane.WriteString(runningStyle.Render(fmt.Sprintf(" (%d running)", runningCount)))
 				}
 				rightPane.WriteString("\n")
+
+				// Group description (full text, wrapped)
+				if item.group.Notes != "" {
+					notesStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorYellow)).Italic(true).Width(previewWidth - 4)
+					rightPane.WriteString("  " + projectLabelStyle.Render("Notes:") + "\n")
+					for _, line := range strings.Split(notesStyle.Render(item.group.Notes), "\n") {
+						rightPane.WriteString("  " + line + "\n")
+					}
+				}
 				rightPane.WriteString(dimStyle.Render(strings.Repeat("─", previewWidth)))
 				rightPane.WriteString("\n\n")
 
@@ -103,7 +114,9 @@ func (m Model) buildPreviewPane(contentHeight int) string {
 					for i, s := range sessions {
 						// Status indicator
 						var statusIcon string
-						if s.Status == session.StatusRunning {
+						if m.agentExited(s) {
+							statusIcon = stoppedStyle.Render("⊗")
+						} else if s.Status == session.StatusRunning {
 							switch m.activityState[s.ID] {
 							case session.ActivityBusy:
 								statusIcon = activeStyle.Render("●")
@@ -125,24 +138,10 @@ func (m Model) buildPreviewPane(contentHeight int) string {
 						rightPane.WriteString("\n")
 
 						// Agent
-						agentName := "Claude Code"
-						switch s.Agent {
-						case session.AgentGemini:
-							agentName = "Gemini"
-						case session.AgentAider:
-							agentName = "Aider"
-						case session.AgentCodex:
-							agentName = "Codex CLI"
-						case session.AgentAmazonQ:
-							agentName = "Amazon Q"
-						case session.AgentOpenCode:
-							agentName = "OpenCode"
-						case session.AgentCustom:
-							agentName = "Custom"
-						}
-						if (s.Agent == session.AgentClaude || s.Agent == "") && s.AutoYes {
+						agentName := agentLabel(s.Agent, s.CustomCommand)
+						if yolo := yoloLabel(s, 0); (s.Agent == session.AgentClaude || s.Agent == "") && yolo != "" {
 							yoloStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorOrange))
-							rightPane.WriteString("    " + projectLabelStyle.Render("Agent: ") + projectNameStyle.Render(agentName) + yoloStyle.Render(" ! YOLO"))
+							rightPane.WriteString("    " + projectLabelStyle.Render("Agent: ") + projectNameStyle.Render(agentName) + yoloStyle.Render(yolo))
 						} else {
 							rightPane.WriteString("    " + projectLabelStyle.Render("Agent: ") + projectNameStyle.Render(agentName))
 						}
@@ -177,7 +176,7 @@ func (m Model) buildPreviewPane(contentHeight int) string {
 				}
 
 				rightPane.WriteString("\n")
-				rightPane.WriteString(dimStyle.Render("  ↵ toggle • →/← expand/collapse"))
+				rightPane.WriteString(dimStyle.Render("  ↵ toggle • →/← expand/collapse • N notes"))
 				return rightPane.String()
 			}
 		}
@@ -186,14 +185,38 @@ func (m Model) buildPreviewPane(contentHeight int) string {
 	}
 
 	if inst == nil {
+		// Nothing selected - show the dashboard instead of a blank pane
+		rightPane.WriteString("\n")
+		rightPane.WriteString(m.dashboardView(previewWidth, contentHeight-3))
 		return rightPane.String()
 	}
 
+	// File browser - Path and View lines, then the tree and the file under the cursor
+	if m.inFiles() {
+		rightPane.WriteString("  " + projectLabelStyle.Render("Path: ") + projectNameStyle.Render(inst.DisplayPath()))
+		rightPane.WriteString("\n")
+		rightPane.WriteString("  " + ansi.Truncate(m.filesHeader(), previewWidth-2, "…"))
+		rightPane.WriteString("\n")
+		rightPane.WriteString(dimStyle.Render(strings.Repeat("─", previewWidth)))
+		rightPane.WriteString("\n")
+
+		headerLines := strings.Count(rightPane.String(), "\n") + 1
+		height := contentHeight - headerLines
+		if height < MinPreviewLines {
+			height = MinPreviewLines
+		}
+		return rightPane.String() + m.filesContent(previewWidth, height)
+	}
+
 	// Diff mode - simplified header with only Path, Notes, and View mode
 	if m.showDiff {
 		// Path
-		rightPane.WriteString("  " + projectLabelStyle.Render("Path: ") + projectNameStyle.Render(inst.Path))
+		rightPane.WriteString("  " + projectLabelStyle.Render("Path: ") + projectNameStyle.Render(inst.DisplayPath()))
 		rightPane.WriteString("\n")
+		if branch := m.sessionBranch(inst); branch != "" {
+			rightPane.WriteString("  " + projectLabelStyle.Render("Branch: ") + projectNameStyle.Render(branch))
+			rightPane.WriteString("\n")
+		}
 
 		// Notes if any
 		if inst.Notes != "" {
@@ -211,8 +234,16 @@ func (m Model) buildPreviewPane(contentHeight int) string {
 		}
 
 		// View mode with hint
-		diffModeLabel := m.diffPane.GetModeLabel()
-		rightPane.WriteString("  " + projectLabelStyle.Render("View: ") + projectNameStyle.Render(diffModeLabel) + dimStyle.Render(" (F to switch)"))
+		if m.inStage() {
+			rightPane.WriteString("  " + projectLabelStyle.Render("View: ") + projectNameStyle.Render("Stage") + dimStyle.Render(" space: hunk · a: file · tab: next file · c: commit · esc: back"))
+		} else {
+			diffModeLabel := m.diffPane.GetModeLabel()
+			hint := " (F to switch) · " + m.diffPane.GetRendererLabel()
+			if previewWidth >= 70 {
+				hint += " · {/}: files · z: fold"
+			}
+			rightPane.WriteString("  " + projectLabelStyle.Render("View: ") + projectNameStyle.Render(diffModeLabel) + dimStyle.Render(hint))
+		}
 		rightPane.WriteString("\n")
 
 		// Horizontal separator
@@ -290,10 +321,9 @@ func (m Model) buildPreviewPane(contentHeight int) string {
 	}
 
 	// Determine agent info based on active tab
-	agentName := "Claude Code"
 	agentType := inst.Agent
 	customCmd := inst.CustomCommand
-	autoYes := inst.AutoYes
+	yoloWindow := 0
 	resumeID := inst.ResumeSessionID
 	notes := inst.Notes
 
@@ -303,43 +333,44 @@ func (m Model) buildPreviewPane(contentHeight int) string {
 			if fw.Index == activeWindow.Index {
 				agentType = fw.Agent
 				customCmd = fw.CustomCommand
-				autoYes = fw.AutoYes
+				yoloWindow = fw.Index
 				resumeID = fw.ResumeSessionID
 				notes = fw.Notes // Tab-specific notes
 				break
 			}
 		}
 	}
-
-	// Get agent name from type
-	switch agentType {
-	case session.AgentGemini:
-		agentName = "Gemini"
-	case session.AgentAider:
-		agentName = "Aider"
-	case session.AgentCodex:
-		agentName = "Codex CLI"
-	case session.AgentAmazonQ:
-		agentName = "Amazon Q"
-	case session.AgentOpenCode:
-		agentName = "OpenCode"
-	case session.AgentCustom:
-		agentName = "Custom"
-	}
+	agentName := agentLabel(agentType, customCmd)
 
 	// Instance info with styled labels and values
-	rightPane.WriteString("  " + projectLabelStyle.Render("Path: ") + projectNameStyle.Render(inst.Path))
+	rightPane.WriteString("  " + projectLabelStyle.Render("Path: ") + projectNameStyle.Render(inst.DisplayPath()))
+	if m.pathMissing[inst.ID] {
+		// Directory moved or deleted
+		rightPane.WriteString(stoppedStyle.Render(" missing") + dimStyle.Render(" (start to relink)"))
+	}
 	rightPane.WriteString("\n")
+	if branch := m.sessionBranch(inst); branch != "" {
+		rightPane.WriteString("  " + projectLabelStyle.Render("Branch: ") + projectNameStyle.Render(branch))
+		if inst.Worktree != nil {
+			rightPane.WriteString(dimStyle.Render(" (worktree of " + filepath.Base(inst.Worktree.Repo) + ")"))
+		}
+		rightPane.WriteString("\n")
+	}
 
-	// Show yolo mode for Claude on same line
-	if (agentType == session.AgentClaude || agentType == "") && autoYes {
+	// Show yolo mode for Claude on same line (sandboxed sessions never run with auto-yes)
+	if yolo := yoloLabel(inst, yoloWindow); (agentType == session.AgentClaude || agentType == "") && yolo != "" {
 		yoloStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorOrange))
-		rightPane.WriteString("  " + projectLabelStyle.Render("Agent: ") + projectNameStyle.Render(agentName) + yoloStyle.Render(" ! YOLO"))
+		rightPane.WriteString("  " + projectLabelStyle.Render("Agent: ") + projectNameStyle.Render(agentName) + yoloStyle.Render(yolo))
 	} else {
 		rightPane.WriteString("  " + projectLabelStyle.Render("Agent: ") + projectNameStyle.Render(agentName))
 	}
 	rightPane.WriteString("\n")
 
+	if exited := m.exitedSummary(inst); exited != "" {
+		rightPane.WriteString("  " + projectLabelStyle.Render("Exited: ") + stoppedStyle.Render(exited) + dimStyle.Render(fmt.Sprintf(" (%s respawns it, resuming the conversation)", m.keys.help("start"))))
+		rightPane.WriteString("\n")
+	}
+
 	if agentType == session.AgentCustom && customCmd != "" {
 		rightPane.WriteString("  " + projectLabelStyle.Render("Command: ") + projectNameStyle.Render(customCmd))
 		rightPane.WriteString("\n")
@@ -350,6 +381,131 @@ func (m Model) buildPreviewPane(contentHeight int) string {
 		rightPane.WriteString("\n")
 	}
 
+	// Topic keywords from the conversation (main agent tab only)
+	if activeWindow == nil || !activeWindow.Followed {
+		if chips := m.renderTopicChips(inst.ID); chips != "" {
+			rightPane.WriteString("  " + projectLabelStyle.Render("Topics: ") + chips)
+			rightPane.WriteString("\n")
+		}
+	}
+
+	// Busy for too long without new output
+	if stuck := m.stuckSummary(inst); stuck != "" {
+		rightPane.WriteString("  " + projectLabelStyle.Render("Stuck: ") + stoppedStyle.Render(truncateRunes(stuck, previewWidth-11)))
+		rightPane.WriteString("\n")
+	}
+
+	// Question the agent is waiting on, answerable with number keys
+	for _, line := range m.pendingQuestionLines(inst, previewWidth) {
+		rightPane.WriteString(line + "\n")
+	}
+
+	if inst.Recording {
+		rightPane.WriteString("  " + projectLabelStyle.Render("Rec: ") + stoppedStyle.Render("● recording") + dimStyle.Render(" (Ctrl+R stops, asmgr cast exports)"))
+		rightPane.WriteString("\n")
+	}
+
+	if scrollback := m.scrollbackSummary(inst); scrollback != "" && inst.Status == session.StatusRunning {
+		rightPane.WriteString("  " + projectLabelStyle.Render("Scrollback: ") + scrollback)
+		rightPane.WriteString("\n")
+	}
+
+	if filter := m.previewFilterLabel(inst); filter != "" {
+		rightPane.WriteString("  " + projectLabelStyle.Render("Filter: ") + dimStyle.Render(filter))
+		rightPane.WriteString("\n")
+	}
+
+	if inst.OutputLog {
+		rightPane.WriteString("  " + projectLabelStyle.Render("Log: ") + projectNameStyle.Render(truncateRunes(filepath.Join("logs", inst.ID+".log"), previewWidth-30)) + dimStyle.Render(" (Ctrl+L stops)"))
+		rightPane.WriteString("\n")
+	}
+
+	if inst.Sandbox != "" {
+		sandboxStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorOrange))
+		rightPane.WriteString("  " + projectLabelStyle.Render("Sandbox: ") + sandboxStyle.Render(inst.Sandbox+" (auto-yes off)"))
+		rightPane.WriteString("\n")
+	}
+
+	if inst.Profile != "" {
+		profile := dimStyle.Render(" (not in config.toml)")
+		if m.config != nil {
+			if p, ok := m.config.Profiles[inst.Profile]; ok {
+				profile = dimStyle.Render(" (" + p.Describe() + ")")
+			}
+		}
+		rightPane.WriteString("  " + projectLabelStyle.Render("Profile: ") + projectNameStyle.Render(inst.Profile) + profile)
+		rightPane.WriteString("\n")
+	}
+
+	flagsWindow := 0
+	if activeWindow != nil {
+		flagsWindow = activeWindow.Index
+	}
+	if model := inst.WindowModel(flagsWindow); model != "" {
+		rightPane.WriteString("  " + projectLabelStyle.Render("Model: ") + projectNameStyle.Render(model))
+		rightPane.WriteString("\n")
+	} else if m.config != nil {
+		if p, ok := m.config.Profiles[inst.Profile]; ok && p.Model != "" && p.ProfileAgent() == inst.WindowAgent(flagsWindow) {
+			rightPane.WriteString("  " + projectLabelStyle.Render("Model: ") + projectNameStyle.Render(p.Model) + dimStyle.Render(" (profile)"))
+			rightPane.WriteString("\n")
+		}
+	}
+	if flags := inst.WindowLaunchFlags(flagsWindow); len(flags) > 0 {
+		rightPane.WriteString("  " + projectLabelStyle.Render("Flags: ") + projectNameStyle.Render(truncateRunes(session.FormatLaunchFlags(flags), previewWidth-20)))
+		rightPane.WriteString("\n")
+	}
+
+	if runtime := inst.RuntimeDescription(); runtime != "" {
+		rightPane.WriteString("  " + projectLabelStyle.Render("Runtime: ") + projectNameStyle.Render(truncateRunes(runtime, previewWidth-20)))
+		rightPane.WriteString("\n")
+	}
+
+	if services := m.readinessSummary(inst); services != "" {
+		rightPane.WriteString("  " + projectLabelStyle.Render("Waits for: ") + dimStyle.Render(truncateRunes(services, previewWidth-20)))
+		rightPane.WriteString("\n")
+	}
+
+	if inst.Preset != "" {
+		rightPane.WriteString("  " + projectLabelStyle.Render("Preset: ") + dimStyle.Render(inst.Preset))
+		rightPane.WriteString("\n")
+	}
+
+	// Restart policy and automatic restarts so far
+	if restart := m.restartSummary(inst); restart != "" {
+		rightPane.WriteString("  " + projectLabelStyle.Render("Restart: ") + dimStyle.Render(truncateRunes(restart, previewWidth-13)))
+		rightPane.WriteString("\n")
+	}
+
+	// Idle auto-stop timeout and countdown
+	if idle := m.idleStopSummary(inst); idle != "" {
+		rightPane.WriteString("  " + projectLabelStyle.Render("Idle stop: ") + dimStyle.Render(truncateRunes(idle, previewWidth-15)))
+		rightPane.WriteString("\n")
+	}
+
+	// CPU/memory of the processes in the session's panes
+	if usage := m.resourceSummary(inst); usage != "" {
+		rightPane.WriteString("  " + projectLabelStyle.Render("Usage: ") + projectNameStyle.Render(usage))
+		rightPane.WriteString("\n")
+	}
+
+	// Tokens and estimated cost read from the agent transcripts
+	if usage := m.usageSummary(inst); usage != "" {
+		rightPane.WriteString("  " + projectLabelStyle.Render("Cost: ") + dimStyle.Render(truncateRunes(usage, previewWidth-10)))
+		rightPane.WriteString("\n")
+	}
+
+	// Busy on the API or locally (network_indicator in config.toml)
+	if net := m.netLabel(inst); net != "" {
+		rightPane.WriteString("  " + projectLabelStyle.Render("Net: ") + dimStyle.Render(net))
+		rightPane.WriteString("\n")
+	}
+
+	// Client timezone and scheduled messages
+	if summary := timezoneSummary(inst); summary != "" {
+		rightPane.WriteString("  " + projectLabelStyle.Render("Time: ") + dimStyle.Render(truncateRunes(summary, previewWidth-10)))
+		rightPane.WriteString("\n")
+	}
+
 	// Display notes if any (truncated to fit)
 	if notes != "" {
 		// Show first line of notes or truncate if too long
@@ -375,6 +531,10 @@ func (m Model) buildPreviewPane(contentHeight int) string {
 
 	// Preview content
 	if m.preview == "" {
+		if headerInst != nil && m.isBusy(headerInst) {
+			rightPane.WriteString("  " + m.spinner.View() + dimStyle.Render(" "+m.pendingOps[headerInst.ID]+"..."))
+			return rightPane.String()
+		}
 		rightPane.WriteString(dimStyle.Render("  (no output yet)"))
 		return rightPane.String()
 	}
@@ -418,7 +578,7 @@ func (m Model) buildPreviewPane(contentHeight int) string {
 	}
 
 	for i := startIdx; i < endIdx; i++ {
-		line := lines[i]
+		line := degradeANSI(lines[i])
 		// Truncate to available width (previewWidth - 2 for left margin)
 		maxWidth := previewWidth - 2
 		if displayWidth(line) > maxWidth {
@@ -508,6 +668,10 @@ func (m *Model) buildDiffContent(header string, contentHeight, headerLines, prev
 	if diffHeight < MinPreviewLines {
 		diffHeight = MinPreviewLines
 	}
+	if m.inStage() {
+		result.WriteString(m.stageContent(previewWidth, diffHeight))
+		return result.String()
+	}
 	m.diffPane.SetSize(previewWidth, diffHeight)
 
 	// Get diff content from diff pane - viewport handles everything
@@ -550,6 +714,7 @@ func (m Model) buildMiniPreview(inst *session.Instance, height, width int, label
 			content = m.scrollContent
 		} else {
 			content, _ = inst.GetPreview(PreviewLineCount)
+			content = m.filterPreview(inst, content)
 		}
 	}
 
@@ -591,7 +756,7 @@ func (m Model) buildMiniPreview(inst *session.Instance, height, width int, label
 
 	displayedLines := 0
 	for i := startIdx; i < endIdx && displayedLines < maxLines; i++ {
-		line := lines[i]
+		line := degradeANSI(lines[i])
 		// Truncate to available width (width - 2 for left margin)
 		maxWidth := width - 2
 		if displayWidth(line) > maxWidth {
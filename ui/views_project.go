@@ -43,13 +43,16 @@ func (m Model) projectSelectView() string {
 	listContent.WriteString("\n") // Extra empty line after top border
 
 	// Projects first
-	projectNameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorPurple)).Bold(true)
+	projectNameStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorAccent)).Bold(true)
 	for i, project := range m.projects {
 		sessionCount := m.storage.GetProjectSessionCount(project.ID)
 		countStr := fmt.Sprintf("[%d]", sessionCount)
+		if badges := m.projectBadges(project.ID, true); badges != "" {
+			countStr = badges + "  " + countStr
+		}
 
 		// Pad to align counts
-		padding := boxWidth - len(project.Name) - len(countStr) - 6
+		padding := boxWidth - lipgloss.Width(project.Name) - lipgloss.Width(countStr) - 6
 		if padding < 1 {
 			padding = 1
 		}
@@ -57,7 +60,11 @@ func (m Model) projectSelectView() string {
 		if i == m.projectCursor {
 			listContent.WriteString(listSelectedStyle.Render(fmt.Sprintf("> %s%s%s", project.Name, strings.Repeat(" ", padding), countStr)))
 		} else {
-			listContent.WriteString(fmt.Sprintf("  %s%s%s", projectNameStyle.Render(project.Name), strings.Repeat(" ", padding), dimStyle.Render(countStr)))
+			styledCount := dimStyle.Render(fmt.Sprintf("[%d]", sessionCount))
+			if badges := m.projectBadges(project.ID, false); badges != "" {
+				styledCount = badges + "  " + styledCount
+			}
+			listContent.WriteString(fmt.Sprintf("  %s%s%s", projectNameStyle.Render(project.Name), strings.Repeat(" ", padding), styledCount))
 		}
 		listContent.WriteString("\n")
 	}
@@ -72,15 +79,22 @@ func (m Model) projectSelectView() string {
 	continueIdx := len(m.projects)
 	defaultCount := m.storage.GetProjectSessionCount("")
 	defaultCountStr := fmt.Sprintf("[%d]", defaultCount)
+	if badges := m.projectBadges("", true); badges != "" {
+		defaultCountStr = badges + "  " + defaultCountStr
+	}
 	defaultText := "No project"
-	defaultPadding := boxWidth - len(defaultText) - len(defaultCountStr) - 10
+	defaultPadding := boxWidth - len(defaultText) - lipgloss.Width(defaultCountStr) - 10
 	if defaultPadding < 1 {
 		defaultPadding = 1
 	}
 	if m.projectCursor == continueIdx {
 		listContent.WriteString(listSelectedStyle.Render(fmt.Sprintf("> [ ] %s%s%s", defaultText, strings.Repeat(" ", defaultPadding), defaultCountStr)))
 	} else {
-		listContent.WriteString(fmt.Sprintf("  [ ] %s%s%s", defaultText, strings.Repeat(" ", defaultPadding), dimStyle.Render(defaultCountStr)))
+		styledCount := dimStyle.Render(fmt.Sprintf("[%d]", defaultCount))
+		if badges := m.projectBadges("", false); badges != "" {
+			styledCount = badges + "  " + styledCount
+		}
+		listContent.WriteString(fmt.Sprintf("  [ ] %s%s%s", defaultText, strings.Repeat(" ", defaultPadding), styledCount))
 	}
 	listContent.WriteString("\n")
 
@@ -96,7 +110,7 @@ func (m Model) projectSelectView() string {
 	// Wrap in a box (without bottom border - we'll add it manually with version)
 	boxStyle := lipgloss.NewStyle().
 		Border(lipgloss.RoundedBorder()).
-		BorderForeground(lipgloss.Color(ColorPurple)).
+		BorderForeground(lipgloss.Color(ColorAccent)).
 		Padding(1, 2).
 		Width(boxWidth)
 
@@ -110,7 +124,7 @@ func (m Model) projectSelectView() string {
 
 		// Build custom bottom border with version
 		version := fmt.Sprintf(" v%s ", AppVersion)
-		borderColor := lipgloss.Color(ColorPurple)
+		borderColor := lipgloss.Color(ColorAccent)
 		borderStyle := lipgloss.NewStyle().Foreground(borderColor)
 		versionStyle := dimStyle
 
@@ -133,7 +147,7 @@ func (m Model) projectSelectView() string {
 	// Help text with styled keys (same as status bar)
 	keyStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#1a1a2e")).
-		Background(lipgloss.Color(ColorPurple)).
+		Background(lipgloss.Color(ColorAccent)).
 		Bold(true).
 		Padding(0, 1)
 
@@ -159,6 +173,12 @@ func (m Model) projectSelectView() string {
 	helpText := strings.Join(helpItems, sep)
 	content.WriteString(helpText)
 
+	// Transient notification below the help line
+	if toastLine := m.renderToast(); toastLine != "" {
+		content.WriteString("\n\n")
+		content.WriteString(toastLine)
+	}
+
 	// Center vertically and horizontally
 	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, content.String())
 }
@@ -174,7 +194,7 @@ func (m Model) newProjectView() string {
 
 	// Use project select view as background
 	background := m.projectSelectView()
-	return m.renderOverlayDialogWithBackground(" New Project ", boxContent.String(), 50, ColorPurple, background)
+	return m.renderOverlayDialogWithBackground(" New Project ", boxContent.String(), 50, ColorAccent, background)
 }
 
 // renameProjectView renders the project rename dialog
@@ -188,7 +208,7 @@ func (m Model) renameProjectView() string {
 
 	// Use project select view as background
 	background := m.projectSelectView()
-	return m.renderOverlayDialogWithBackground(" Rename Project ", boxContent.String(), 50, ColorPurple, background)
+	return m.renderOverlayDialogWithBackground(" Rename Project ", boxContent.String(), 50, ColorAccent, background)
 }
 
 // confirmDeleteProjectView renders the project deletion confirmation
@@ -228,7 +248,7 @@ func (m Model) confirmImportView() string {
 	// Create background showing default sessions
 	background := m.renderDefaultSessionsBackground(defaultInstances, defaultGroups)
 
-	return m.renderOverlayDialogWithBackground(" Confirm Import ", boxContent.String(), 50, ColorPurple, background)
+	return m.renderOverlayDialogWithBackground(" Confirm Import ", boxContent.String(), 50, ColorAccent, background)
 }
 
 // renderDefaultSessionsBackground renders a view of the default (no project) sessions
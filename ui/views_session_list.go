@@ -21,14 +21,55 @@ var agentIcons = map[session.AgentType]string{
 	session.AgentTerminal: "🖥️",
 }
 
+// agentLabels maps agent types to their display names
+var agentLabels = map[session.AgentType]string{
+	session.AgentClaude:   "Claude Code",
+	session.AgentGemini:   "Gemini",
+	session.AgentAider:    "Aider",
+	session.AgentCodex:    "Codex CLI",
+	session.AgentAmazonQ:  "Amazon Q",
+	session.AgentOpenCode: "OpenCode",
+	session.AgentCursor:   "Cursor",
+	session.AgentCustom:   "Custom",
+	session.AgentTerminal: "Terminal",
+}
+
+// agentDisplay holds the user's icon and label overrides from agents.json
+var agentDisplay *session.AgentDisplayConfig
+
 // getAgentIcon returns the icon for an agent type
 func getAgentIcon(agent session.AgentType) string {
+	return agentIcon(agent, "")
+}
+
+// agentIcon returns the icon for an agent, honouring overrides for custom commands
+func agentIcon(agent session.AgentType, customCommand string) string {
+	if agent == "" {
+		agent = session.AgentClaude
+	}
+	if icon := agentDisplay.Lookup(agent, customCommand).Icon; icon != "" {
+		return icon
+	}
 	if icon, ok := agentIcons[agent]; ok {
 		return icon
 	}
 	return "?"
 }
 
+// agentLabel returns the display name for an agent, honouring overrides for custom commands
+func agentLabel(agent session.AgentType, customCommand string) string {
+	if agent == "" {
+		agent = session.AgentClaude
+	}
+	if label := agentDisplay.Lookup(agent, customCommand).Label; label != "" {
+		return label
+	}
+	if label, ok := agentLabels[agent]; ok {
+		return label
+	}
+	return string(agent)
+}
+
 // buildAgentIconsInline builds a string of agent icons for inline display
 // maxWidth limits how many icons can be shown (each icon is ~2 chars wide)
 func (m Model) buildAgentIconsInline(inst *session.Instance, maxWidth int) string {
@@ -36,13 +77,13 @@ func (m Model) buildAgentIconsInline(inst *session.Instance, maxWidth int) strin
 		return ""
 	}
 
-	// Collect all agent types (main + tabs)
-	var agents []session.AgentType
-	agents = append(agents, inst.Agent)
+	// Collect the icons of all agents (main + tabs)
+	var agents []string
+	agents = append(agents, agentIcon(inst.Agent, inst.CustomCommand))
 
 	for _, fw := range inst.FollowedWindows {
 		if fw.Agent != session.AgentTerminal {
-			agents = append(agents, fw.Agent)
+			agents = append(agents, agentIcon(fw.Agent, fw.CustomCommand))
 		}
 	}
 
@@ -52,9 +93,8 @@ func (m Model) buildAgentIconsInline(inst *session.Instance, maxWidth int) strin
 	icons.WriteString(" ")
 	usedWidth := 1
 
-	for i, agent := range agents {
-		icon := getAgentIcon(agent)
-		iconWidth := 2 // emoji width approximation
+	for i, icon := range agents {
+		iconWidth := max(2, lipgloss.Width(icon)) // emoji width approximation
 
 		// Check if we have room for this icon (and maybe "..." indicator)
 		if i < len(agents)-1 && usedWidth+iconWidth+3 > maxWidth {
@@ -129,10 +169,16 @@ func (m Model) renderSessionRow(inst *session.Instance, index int, listWidth int
 
 	// Status indicator based on activity state
 	var status string
-	if inst.Status == session.StatusRunning {
+	if m.agentExited(inst) {
+		status = stoppedStyle.Render("⊗") // Red - agent exited, tmux session kept
+	} else if inst.Status == session.StatusRunning {
 		switch m.activityState[inst.ID] {
 		case session.ActivityBusy:
-			status = activeStyle.Render("●") // Orange - busy/working
+			if m.isStuck(inst) {
+				status = stoppedStyle.Render("◉") // Red - busy but no output for too long
+			} else {
+				status = activeStyle.Render("●") // Orange - busy/working
+			}
 		case session.ActivityWaiting:
 			status = waitingStyle.Render("●") // Yellow - waiting for input
 		default:
@@ -145,6 +191,9 @@ func (m Model) renderSessionRow(inst *session.Instance, index int, listWidth int
 	} else {
 		status = stoppedStyle.Render("○") // Red outline - stopped
 	}
+	if m.isBusy(inst) {
+		status = m.spinner.View() // Background operation in progress
+	}
 
 	// Add marker for split view
 	if m.markedSessionID == inst.ID {
@@ -152,13 +201,36 @@ func (m Model) renderSessionRow(inst *session.Instance, index int, listWidth int
 		status += " " + pinStyle.Render("◆")
 	}
 
+	// Checked for a batch action
+	if m.isMultiSelected(inst, index) {
+		checkStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorGreen)).Bold(true)
+		status += " " + checkStyle.Render("✓")
+	}
+
+	// Add lock for protected sessions
+	if inst.Protected {
+		lockStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorOrange))
+		status += " " + lockStyle.Render("⊘")
+	}
+
+	// Directory moved or deleted - starting opens the relink wizard
+	if m.pathMissing[inst.ID] {
+		status += " " + lipgloss.NewStyle().Foreground(lipgloss.Color(ColorYellow)).Render("⚠")
+	}
+
+	// Busy and moving data over a remote connection (network_indicator)
+	if m.onAPI(inst) {
+		status += " " + dimStyle.Render("⇅")
+	}
+
 	// Truncate name to fit
 	name := inst.Name
 	iconLen := 0
 	if m.showAgentIcons {
 		iconLen = 3 // space + emoji
 	}
-	maxNameLen := listWidth - 8 - iconLen // -2 extra for pin marker
+	badge := m.projectBadge(inst) + hostBadge(inst) + m.branchBadge(inst)
+	maxNameLen := listWidth - 8 - iconLen - lipgloss.Width(badge) // -2 extra for pin marker
 	if maxNameLen < 10 {
 		maxNameLen = 10
 	}
@@ -189,13 +261,19 @@ func (m Model) renderSessionRow(inst *session.Instance, index int, listWidth int
 			displayStyledName = styledName + icons
 		} else if agentTabCount == 0 {
 			// Single agent or status lines visible: show single icon
-			icon := " " + getAgentIcon(inst.Agent)
+			icon := " " + agentIcon(inst.Agent, inst.CustomCommand)
 			displayName = name + icon
 			displayStyledName = styledName + icon
 		}
 		// else: multiple agents with status lines visible - icons shown on each status line
 	}
 
+	// Owning project in the all projects list
+	if badge != "" {
+		displayName += badge
+		displayStyledName += dimStyle.Render(badge)
+	}
+
 	// Render the row
 	if selected {
 		row.WriteString(m.renderSelectedRow(inst, displayName, displayStyledName, status, listWidth))
@@ -240,7 +318,7 @@ func (m Model) renderSessionRow(inst *session.Instance, index int, listWidth int
 			if agent == "" {
 				agent = session.AgentClaude
 			}
-			mainIcon = " " + getAgentIcon(agent)
+			mainIcon = " " + agentIcon(agent, inst.CustomCommand)
 		}
 		row.WriteString(connectorStyle.Render("     "+mainConnector+" ") + mainTextStyle.Render(lastLine) + mainIcon)
 		row.WriteString("\n")
@@ -266,7 +344,7 @@ func (m Model) renderSessionRow(inst *session.Instance, index int, listWidth int
 			}
 			fwIcon := ""
 			if m.showAgentIcons {
-				fwIcon = " " + getAgentIcon(fw.Agent)
+				fwIcon = " " + agentIcon(fw.Agent, fw.CustomCommand)
 			}
 			row.WriteString(connectorStyle.Render("     "+connector+" ") + fwTextStyle.Render(fwLine) + fwIcon)
 			row.WriteString("\n")
@@ -399,6 +477,9 @@ func (m Model) getActivityTextStyle(activity session.SessionActivity, selected b
 // getLastLine returns the last line of output for a session
 func (m Model) getLastLine(inst *session.Instance) string {
 	lastLine := m.lastLines[inst.ID]
+	if exited := m.exitedSummary(inst); exited != "" {
+		lastLine = fmt.Sprintf("%s - %s respawns", exited, m.keys.help("start"))
+	}
 	if lastLine == "" {
 		if inst.Status == session.StatusRunning {
 			return "loading..."
@@ -443,8 +524,8 @@ func (m Model) buildSessionListPane(listWidth, contentHeight int) string {
 		return leftPane.String()
 	}
 
-	// If there are groups or favorites, use grouped view
-	if len(m.groups) > 0 || m.hasFavorites() {
+	// If there are groups, favorites or nested sessions, use grouped view
+	if m.usesVisibleItems() {
 		return m.buildGroupedSessionListPane(listWidth, contentHeight)
 	}
 
@@ -480,7 +561,7 @@ func (m Model) buildSessionListPane(listWidth, contentHeight int) string {
 	// Find start index by counting lines backwards from cursor
 	// Calculate fixed header overhead dynamically
 	headerHeight := 2 // Header with separator
-	if m.activeProject != nil {
+	if m.activeProject != nil || m.globalList {
 		headerHeight += 2 // Project name row with separator
 	}
 	headerHeight += 1 // Extra newline after header/project
@@ -607,7 +688,7 @@ func (m *Model) buildGroupedSessionListPane(listWidth, contentHeight int) string
 	// Calculate which items fit in view
 	// Calculate fixed header overhead dynamically
 	headerHeight := 2 // Header with separator
-	if m.activeProject != nil {
+	if m.activeProject != nil || m.globalList {
 		headerHeight += 2 // Project name row with separator
 	}
 	headerHeight += 1 // Extra newline after header/project
@@ -722,7 +803,7 @@ func (m Model) renderGroupRow(group *session.Group, index int, listWidth int) st
 	}
 
 	// Group style - use custom color if set, otherwise default purple (gold for favorites)
-	groupColor := ColorPurple
+	groupColor := ColorAccent
 	if isFavorites {
 		groupColor = ColorYellow // Gold color for favorites
 	} else if group.Color != "" && group.Color != "auto" {
@@ -810,12 +891,29 @@ func (m Model) renderGroupedSessionRow(inst *session.Instance, index int, listWi
 		lastLinePrefix = ""
 	}
 
+	// Forks and parallel sessions nested under their origin
+	var nest string
+	var depth, hidden int
+	if index >= 0 && index < len(m.visibleItems) && m.visibleItems[index].instance == inst {
+		depth, hidden = m.visibleItems[index].depth, m.visibleItems[index].hidden
+	}
+	if depth > 0 {
+		nest = strings.Repeat("  ", depth-1) + "↳ "
+		lastLinePrefix += strings.Repeat("  ", depth)
+	}
+
 	// Status indicator based on activity state
 	var status string
-	if inst.Status == session.StatusRunning {
+	if m.agentExited(inst) {
+		status = stoppedStyle.Render("⊗") // Red - agent exited, tmux session kept
+	} else if inst.Status == session.StatusRunning {
 		switch m.activityState[inst.ID] {
 		case session.ActivityBusy:
-			status = activeStyle.Render("●") // Orange - busy/working
+			if m.isStuck(inst) {
+				status = stoppedStyle.Render("◉") // Red - busy but no output for too long
+			} else {
+				status = activeStyle.Render("●") // Orange - busy/working
+			}
 		case session.ActivityWaiting:
 			status = waitingStyle.Render("●") // Yellow - waiting for input
 		default:
@@ -828,6 +926,9 @@ func (m Model) renderGroupedSessionRow(inst *session.Instance, index int, listWi
 	} else {
 		status = stoppedStyle.Render("○") // Red outline - stopped
 	}
+	if m.isBusy(inst) {
+		status = m.spinner.View() // Background operation in progress
+	}
 
 	// Add marker for split view
 	if m.markedSessionID == inst.ID {
@@ -835,13 +936,39 @@ func (m Model) renderGroupedSessionRow(inst *session.Instance, index int, listWi
 		status += " " + pinStyle.Render("◆")
 	}
 
+	// Checked for a batch action
+	if m.isMultiSelected(inst, index) {
+		checkStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorGreen)).Bold(true)
+		status += " " + checkStyle.Render("✓")
+	}
+
+	// Add lock for protected sessions
+	if inst.Protected {
+		lockStyle := lipgloss.NewStyle().Foreground(lipgloss.Color(ColorOrange))
+		status += " " + lockStyle.Render("⊘")
+	}
+
+	// Directory moved or deleted - starting opens the relink wizard
+	if m.pathMissing[inst.ID] {
+		status += " " + lipgloss.NewStyle().Foreground(lipgloss.Color(ColorYellow)).Render("⚠")
+	}
+
+	// Busy and moving data over a remote connection (network_indicator)
+	if m.onAPI(inst) {
+		status += " " + dimStyle.Render("⇅")
+	}
+
 	// Truncate name to fit (accounting for prefix and icon)
 	name := inst.Name
 	iconLen := 0
 	if m.showAgentIcons {
 		iconLen = 3 // space + emoji
 	}
-	maxNameLen := listWidth - 12 - iconLen // -2 extra for pin marker
+	badge := m.projectBadge(inst) + hostBadge(inst) + m.branchBadge(inst)
+	if hidden > 0 {
+		badge = fmt.Sprintf(" (+%d)", hidden) + badge
+	}
+	maxNameLen := listWidth - 12 - iconLen - lipgloss.Width(badge) - lipgloss.Width(nest) // -2 extra for pin marker
 	if maxNameLen < 8 {
 		maxNameLen = 8
 	}
@@ -872,26 +999,32 @@ func (m Model) renderGroupedSessionRow(inst *session.Instance, index int, listWi
 			displayStyledName = styledName + icons
 		} else if agentTabCount == 0 {
 			// Single agent or status lines visible: show single icon
-			icon := " " + getAgentIcon(inst.Agent)
+			icon := " " + agentIcon(inst.Agent, inst.CustomCommand)
 			displayName = name + icon
 			displayStyledName = styledName + icon
 		}
 		// else: multiple agents with status lines visible - icons shown on each status line
 	}
 
+	// Owning project in the all projects list
+	if badge != "" {
+		displayName += badge
+		displayStyledName += dimStyle.Render(badge)
+	}
+
 	// Render the row
 	treeStyle := dimStyle
 	if selected {
-		row.WriteString(fmt.Sprintf(" %s%s %s", listSelectedStyle.Render("▸"), treeStyle.Render(prefix[1:]), status))
+		row.WriteString(fmt.Sprintf(" %s%s %s%s", listSelectedStyle.Render("▸"), treeStyle.Render(prefix[1:]), treeStyle.Render(nest), status))
 		if inst.FullRowColor && inst.BgColor != "" {
-			row.WriteString(" " + m.renderSelectedRowContent(inst, displayName, listWidth-10-iconLen))
+			row.WriteString(" " + m.renderSelectedRowContent(inst, displayName, listWidth-10-iconLen-lipgloss.Width(nest)))
 		} else if inst.Color != "" || inst.BgColor != "" {
 			row.WriteString(" " + lipgloss.NewStyle().Bold(true).Render(displayStyledName))
 		} else {
 			row.WriteString(" " + lipgloss.NewStyle().Bold(true).Render(displayName))
 		}
 	} else {
-		row.WriteString(fmt.Sprintf(" %s %s %s", treeStyle.Render(prefix), status, displayStyledName))
+		row.WriteString(fmt.Sprintf(" %s %s%s %s", treeStyle.Render(prefix), treeStyle.Render(nest), status, displayStyledName))
 	}
 	row.WriteString("\n")
 
@@ -931,7 +1064,7 @@ func (m Model) renderGroupedSessionRow(inst *session.Instance, index int, listWi
 			if agent == "" {
 				agent = session.AgentClaude
 			}
-			mainIcon = " " + getAgentIcon(agent)
+			mainIcon = " " + agentIcon(agent, inst.CustomCommand)
 		}
 		row.WriteString(connectorStyle.Render(fmt.Sprintf(" %s  %s ", lastLinePrefix, mainConnector)) + mainTextStyle.Render(lastLine) + mainIcon)
 		row.WriteString("\n")
@@ -957,7 +1090,7 @@ func (m Model) renderGroupedSessionRow(inst *session.Instance, index int, listWi
 			}
 			fwIcon := ""
 			if m.showAgentIcons {
-				fwIcon = " " + getAgentIcon(fw.Agent)
+				fwIcon = " " + agentIcon(fw.Agent, fw.CustomCommand)
 			}
 			row.WriteString(connectorStyle.Render(fmt.Sprintf(" %s  %s ", lastLinePrefix, connector)) + fwTextStyle.Render(fwLine) + fwIcon)
 			row.WriteString("\n")
@@ -10,10 +10,25 @@ import (
 
 // buildStatusBar builds the status bar at the bottom
 func (m Model) buildStatusBar() string {
+	// Pending leader key shows its available chords
+	if m.leaderPending {
+		return "\n" + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, activeStyle.Render(m.leaderHint()))
+	}
+
+	// An active toast temporarily replaces the key hints
+	if toastLine := m.renderToast(); toastLine != "" {
+		return "\n" + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, toastLine)
+	}
+	// Show background update download progress
+	if m.updateInProgress {
+		line := m.spinner.View() + dimStyle.Render(fmt.Sprintf(" Downloading %s...", m.updateAvailable))
+		return "\n" + lipgloss.PlaceHorizontal(m.width, lipgloss.Center, line)
+	}
+
 	// Styles for status bar
 	keyStyle := lipgloss.NewStyle().
 		Foreground(lipgloss.Color("#1a1a2e")).
-		Background(lipgloss.Color(ColorPurple)).
+		Background(lipgloss.Color(ColorAccent)).
 		Bold(true).
 		Padding(0, 1)
 
@@ -35,32 +50,61 @@ func (m Model) buildStatusBar() string {
 	// Priority groups (high to low)
 	// P1: Essential - always shown
 	p1 := []string{
-		keyStyle.Render("n") + descStyle.Render(" new"),
+		keyStyle.Render(m.keys.help("new_session")) + descStyle.Render(" new"),
 		keyStyle.Render("enter") + descStyle.Render(" attach"),
-		keyStyle.Render("?") + descStyle.Render(" help"),
-		keyStyle.Render("q") + descStyle.Render(" quit"),
+		keyStyle.Render(m.keys.help("help")) + descStyle.Render(" help"),
+		keyStyle.Render(m.keys.help("quit")) + descStyle.Render(" quit"),
+	}
+
+	// Selected sessions replace the single-session hints with the batch keys
+	if m.hasMultiSelect() {
+		selectStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#1a1a2e")).
+			Background(lipgloss.Color(ColorGreen)).
+			Bold(true).
+			Padding(0, 1)
+		label := fmt.Sprintf("%d SELECTED", len(m.multiSelectedInstances()))
+		if m.visualActive {
+			label += " · V"
+		}
+		batchHint := strings.Join([]string{m.keys.help("start"), m.keys.help("stop"), m.keys.help("delete"), m.keys.help("assign_group")}, "/")
+		p1 = []string{
+			selectStyle.Render(label),
+			keyStyle.Render(batchHint) + descStyle.Render(" start/stop/delete/move"),
+			keyStyle.Render("esc") + descStyle.Render(" clear"),
+		}
+	}
+
+	// Dry-run indicator is always visible while enabled
+	if m.dryRun {
+		dryRunStyle := lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#1a1a2e")).
+			Background(lipgloss.Color(ColorYellow)).
+			Bold(true).
+			Padding(0, 1)
+		p1 = append([]string{dryRunStyle.Render("DRY-RUN")}, p1...)
 	}
 
 	// P2: Common actions
 	p2 := []string{
-		keyStyle.Render("s") + descStyle.Render(" start"),
-		keyStyle.Render("a") + descStyle.Render(" replace/start"),
-		keyStyle.Render("x") + descStyle.Render(" stop"),
-		keyStyle.Render("d") + descStyle.Render(" delete"),
-		keyStyle.Render("p") + descStyle.Render(" prompt"),
+		keyStyle.Render(m.keys.help("start")) + descStyle.Render(" start"),
+		keyStyle.Render(m.keys.help("replace_start")) + descStyle.Render(" replace/start"),
+		keyStyle.Render(m.keys.help("stop")) + descStyle.Render(" stop"),
+		keyStyle.Render(m.keys.help("delete")) + descStyle.Render(" delete"),
+		keyStyle.Render(m.keys.help("prompt")) + descStyle.Render(" prompt"),
 	}
 
 	// P3: Less common
 	p3 := []string{
-		keyStyle.Render("r") + descStyle.Render(" resume"),
-		keyStyle.Render("e") + descStyle.Render(" rename"),
-		keyStyle.Render("c") + descStyle.Render(" color"),
+		keyStyle.Render(m.keys.help("resume")) + descStyle.Render(" resume"),
+		keyStyle.Render(m.keys.help("rename")) + descStyle.Render(" rename"),
+		keyStyle.Render(m.keys.help("colors")) + descStyle.Render(" color"),
 	}
 
 	// P4: Group management
 	p4 := []string{
-		keyStyle.Render("g") + descStyle.Render(" group"),
-		keyStyle.Render("G") + descStyle.Render(" assign"),
+		keyStyle.Render(m.keys.help("new_group")) + descStyle.Render(" group"),
+		keyStyle.Render(m.keys.help("assign_group")) + descStyle.Render(" assign"),
 	}
 
 	// P5: Toggles
@@ -85,11 +129,11 @@ func (m Model) buildStatusBar() string {
 		iconsStatus = onStyle.Render("ON")
 	}
 	p5 := []string{
-		keyStyle.Render("l") + descStyle.Render(" compact ") + compactStatus,
-		keyStyle.Render("o") + descStyle.Render(" output ") + statusLinesStatus,
-		keyStyle.Render("I") + descStyle.Render(" icons ") + iconsStatus,
-		keyStyle.Render("^Y") + descStyle.Render(" yolo ") + autoYesStatus,
-		keyStyle.Render("v") + descStyle.Render(" split ") + splitStatus,
+		keyStyle.Render(m.keys.help("compact")) + descStyle.Render(" compact ") + compactStatus,
+		keyStyle.Render(m.keys.help("status_lines")) + descStyle.Render(" output ") + statusLinesStatus,
+		keyStyle.Render(m.keys.help("icons")) + descStyle.Render(" icons ") + iconsStatus,
+		keyStyle.Render(m.keys.help("yolo")) + descStyle.Render(" yolo ") + autoYesStatus,
+		keyStyle.Render(m.keys.help("split")) + descStyle.Render(" split ") + splitStatus,
 	}
 
 	// Calculate widths and determine what fits
@@ -237,7 +281,19 @@ func (m Model) selectSessionView() string {
 	}
 
 	b.WriteString("\n")
-	b.WriteString(helpStyle.Render("  ↑/↓ navigate • enter select • esc cancel"))
+	hint := "  ↑/↓ navigate • enter select • esc cancel"
+	if m.pendingInstance == nil {
+		hint = "  ↑/↓ navigate • enter resume here • t new tab • esc cancel"
+	}
+	if m.canForkResume() {
+		forkState := "off"
+		if m.resumeFork {
+			forkState = "on"
+		}
+		b.WriteString(fmt.Sprintf("  Fork before resume: %s\n\n", forkState))
+		hint = "  ↑/↓ navigate • enter resume here • t new tab • f fork • esc cancel"
+	}
+	b.WriteString(helpStyle.Render(hint))
 	b.WriteString("\n")
 
 	// Calculate box width based on content
@@ -246,7 +302,24 @@ func (m Model) selectSessionView() string {
 		boxWidth = 80
 	}
 
-	return m.renderOverlayDialog(" Resume Session ", b.String(), boxWidth, ColorPurple)
+	return m.renderOverlayDialog(m.resumePickerTitle(), b.String(), boxWidth, ColorAccent)
+}
+
+// resumePickerTitle names the tab the picked conversation will be resumed in
+func (m Model) resumePickerTitle() string {
+	inst := m.getSelectedInstance()
+	if m.pendingInstance != nil || inst == nil {
+		return " Resume Session "
+	}
+	if m.resumeWindowIndex == 0 {
+		return fmt.Sprintf(" Resume Session in '%s' ", inst.Name)
+	}
+	for _, fw := range inst.FollowedWindows {
+		if fw.Index == m.resumeWindowIndex {
+			return fmt.Sprintf(" Resume Session in tab '%s' ", fw.Name)
+		}
+	}
+	return " Resume Session "
 }
 
 // formatTimeAgo formats a time as a relative string (e.g., "5 min ago")
@@ -0,0 +1,242 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// windowEntry is a row of the window picker
+type windowEntry struct {
+	Index    int
+	Name     string
+	Agent    session.AgentType // AgentTerminal for windows that aren't tracked as agents
+	Command  string            // Command of a custom agent
+	Active   bool
+	Dead     bool
+	LastLine string
+}
+
+// openWindowPicker lists the windows of a session in the window picker
+func (m *Model) openWindowPicker(inst *session.Instance) {
+	if inst == nil {
+		return
+	}
+	if inst.Status != session.StatusRunning {
+		m.notifyWarn("Session not running")
+		return
+	}
+	m.windowTarget = inst
+	m.refreshWindowPicker()
+	if len(m.windowEntries) == 0 {
+		m.windowTarget = nil
+		m.notifyWarn("No windows found for %s", inst.Name)
+		return
+	}
+	m.windowCursor = 0
+	for i, w := range m.windowEntries {
+		if w.Active {
+			m.windowCursor = i
+		}
+	}
+	m.state = stateWindowPicker
+}
+
+// refreshWindowPicker re-reads the windows of the picker's session
+func (m *Model) refreshWindowPicker() {
+	inst := m.windowTarget
+	m.windowEntries = nil
+	for _, w := range inst.GetWindowList() {
+		agent, command := session.AgentTerminal, ""
+		if w.Index == 0 {
+			agent, command = inst.Agent, inst.CustomCommand
+		} else if fw := inst.GetFollowedWindow(w.Index); fw != nil {
+			agent, command = fw.Agent, fw.CustomCommand
+		}
+		entry := windowEntry{Index: w.Index, Name: w.Name, Agent: agent, Command: command, Active: w.Active, Dead: w.Dead}
+		if w.Dead {
+			entry.LastLine = "exited"
+		} else {
+			entry.LastLine = stripANSI(inst.GetLastLineForWindow(w.Index, agent))
+		}
+		m.windowEntries = append(m.windowEntries, entry)
+	}
+	if m.windowCursor >= len(m.windowEntries) {
+		m.windowCursor = len(m.windowEntries) - 1
+	}
+	if m.windowCursor < 0 {
+		m.windowCursor = 0
+	}
+}
+
+// selectPickedWindow switches the session to the window under the cursor
+func (m *Model) selectPickedWindow() (windowEntry, bool) {
+	if m.windowCursor >= len(m.windowEntries) {
+		return windowEntry{}, false
+	}
+	w := m.windowEntries[m.windowCursor]
+	if err := m.windowTarget.SelectWindow(w.Index); err != nil {
+		m.notify(toastError, "Failed to switch to window %d: %v", w.Index, err)
+		return w, false
+	}
+	m.resetScroll()
+	return w, true
+}
+
+// closeWindowPicker returns to the session list
+func (m *Model) closeWindowPicker() {
+	m.windowTarget = nil
+	m.windowEntries = nil
+	m.state = stateList
+}
+
+// handleWindowPickerKeys handles keyboard input in the window picker
+func (m Model) handleWindowPickerKeys(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	switch key {
+	case "esc", "q", "w":
+		m.closeWindowPicker()
+
+	case "up", "k":
+		if m.windowCursor > 0 {
+			m.windowCursor--
+		}
+
+	case "down", "j":
+		if m.windowCursor < len(m.windowEntries)-1 {
+			m.windowCursor++
+		}
+
+	case "r":
+		m.refreshWindowPicker()
+
+	case "enter":
+		if w, ok := m.selectPickedWindow(); ok {
+			m.notifyInfo("Switched to window %d (%s)", w.Index, w.Name)
+		}
+		m.closeWindowPicker()
+
+	case "a":
+		inst := m.windowTarget
+		w, ok := m.selectPickedWindow()
+		m.closeWindowPicker()
+		if !ok || m.isBusy(inst) {
+			return m, nil
+		}
+		if w.Dead {
+			inst.RespawnWindow(w.Index)
+		}
+		cmd := m.attachSession(inst)
+		return m, cmd
+
+	case "0", "1", "2", "3", "4", "5", "6", "7", "8", "9":
+		// Jump to the window with that index
+		for i, w := range m.windowEntries {
+			if fmt.Sprint(w.Index) == key {
+				m.windowCursor = i
+			}
+		}
+	}
+	return m, nil
+}
+
+// windowPickerView renders the window picker as an overlay
+func (m Model) windowPickerView() string {
+	var boxContent strings.Builder
+	boxContent.WriteString("\n\n")
+
+	boxWidth := 90
+	if m.width > 0 && m.width-4 < boxWidth {
+		boxWidth = m.width - 4
+	}
+	lineWidth := boxWidth - 47
+	if lineWidth < 10 {
+		lineWidth = 10
+	}
+
+	header := fmt.Sprintf("  %3s  %-16s %-15s %-8s %s", "#", "Name", "Agent", "Activity", "Last line")
+	boxContent.WriteString(dimStyle.Render(header))
+	boxContent.WriteString("\n")
+
+	var activities map[int]session.SessionActivity
+	if m.windowTarget != nil {
+		activities = m.windowActivityState[m.windowTarget.ID]
+	}
+	for i, w := range m.windowEntries {
+		activity := dimStyle.Render(fmt.Sprintf("%-8s", "-"))
+		switch {
+		case w.Dead:
+			activity = stoppedStyle.Render(fmt.Sprintf("%-8s", "exited"))
+		case w.Agent != session.AgentTerminal:
+			a := activities[w.Index]
+			style := idleStyle
+			switch a {
+			case session.ActivityBusy:
+				style = activeStyle
+			case session.ActivityWaiting:
+				style = waitingStyle
+			}
+			activity = style.Render(fmt.Sprintf("%-8s", a.String()))
+		}
+
+		agent := agentIcon(w.Agent, w.Command) + " " + agentLabel(w.Agent, w.Command)
+		agent += strings.Repeat(" ", max(0, 15-lipgloss.Width(agent)))
+		line := fmt.Sprintf("%3d  %-16s %s %s %s", w.Index, truncateRunes(w.Name, 16), agent, activity, dimStyle.Render(truncateRunes(w.LastLine, lineWidth)))
+		if w.Active {
+			line += " " + runningStyle.Render("◆")
+		}
+		if i == m.windowCursor {
+			boxContent.WriteString(" ❯" + line + "\n")
+		} else {
+			boxContent.WriteString("  " + line + "\n")
+		}
+	}
+
+	boxContent.WriteString("\n")
+	boxContent.WriteString(helpStyle.Render("  enter: switch  a: switch & attach  0-9: jump  r: refresh  esc: close"))
+	boxContent.WriteString("\n")
+
+	title := " Windows "
+	if m.windowTarget != nil {
+		title = fmt.Sprintf(" Windows - %s ", m.windowTarget.Name)
+	}
+	return m.renderOverlayDialog(title, boxContent.String(), boxWidth, ColorAccent)
+}
+
+// toggleSplit splits the active window of the selected session with a terminal
+// pane (horizontal: beside the agent, vertical: below it), or closes the pane
+// again when the window is already split
+func (m *Model) toggleSplit(dir string) {
+	inst := m.getSelectedInstance()
+	if inst == nil {
+		return
+	}
+	if inst.Status != session.StatusRunning {
+		m.notifyWarn("Session must be running to split a window")
+		return
+	}
+
+	idx := inst.GetCurrentWindowIndex()
+	if inst.WindowSplit(idx) != "" {
+		if err := inst.UnsplitWindow(idx); err != nil {
+			m.showError(err)
+			return
+		}
+		m.storage.UpdateInstance(inst)
+		m.notifyInfo("Closed the terminal pane of '%s' window %d", inst.Name, idx)
+		return
+	}
+	if err := inst.SplitWindow(idx, dir); err != nil {
+		m.showError(err)
+		return
+	}
+	m.storage.UpdateInstance(inst)
+	where := "beside"
+	if dir == session.SplitVertical {
+		where = "below"
+	}
+	m.notifySuccess("Terminal pane %s the agent in '%s' window %d (kept on restart)", where, inst.Name, idx)
+}
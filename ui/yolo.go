@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/izll/agent-session-manager/session"
+)
+
+// AutoApproveCooldown keeps a prompt from being answered twice before the agent redraws
+const AutoApproveCooldown = 2 * time.Second
+
+// yoloLimitKey returns the key that picks a time limit in the YOLO dialog ("1" = first duration)
+func yoloLimitKey(idx int) string {
+	return fmt.Sprintf("%d", idx+1)
+}
+
+// yoloApprovalsKey is the YOLO dialog key for approving the next prompts instead
+func yoloApprovalsKey() string {
+	return yoloLimitKey(len(session.YoloDurations))
+}
+
+// yoloActive reports whether a window has YOLO mode on in any form (flag or approval grant)
+func yoloActive(inst *session.Instance, window int) bool {
+	return inst.WindowAutoYes(window) || inst.WindowYoloGrant(window) != nil
+}
+
+// applyYoloChange saves and logs a window's new YOLO state, and restarts its
+// agent when the auto-yes flag changed (resuming the conversation)
+func (m *Model) applyYoloChange(inst *session.Instance, window int, autoYes bool, grant *session.YoloGrant, change string) tea.Cmd {
+	flagChanged := inst.WindowAutoYes(window) != autoYes
+	inst.SetWindowYolo(window, autoYes, grant)
+	m.storage.UpdateInstance(inst)
+	inst.LogYolo(window, change)
+
+	if !flagChanged || inst.Status != session.StatusRunning {
+		return nil
+	}
+	if window == 0 {
+		// Main window - restart session in the background (tabs come back with it)
+		return m.restartSessionAsync(inst)
+	}
+	resumeID := ""
+	if fw := inst.GetFollowedWindow(window); fw != nil {
+		resumeID = fw.ResumeSessionID
+	}
+	if err := inst.RespawnWindowWithResume(window, resumeID); err != nil {
+		m.notify(toastError, "Failed to restart the tab: %v", err)
+		return nil
+	}
+	inst.ScheduleYoloExpiry(window)
+	RefreshTmuxStatusBarFull(inst.TmuxSessionName(), inst.Name, inst.Color, inst.BgColor, inst)
+	return nil
+}
+
+// autoApprove answers a permission prompt with "yes" in a window that has
+// approvals left, and ends the grant when they are used up
+func (m *Model) autoApprove(inst *session.Instance) {
+	q := m.pendingQuestions[inst.ID]
+	if q == nil || !q.IsPermission() {
+		return
+	}
+	grant := inst.WindowYoloGrant(q.Window)
+	if grant == nil || grant.Approvals <= 0 || time.Since(m.autoApprovedAt[inst.ID]) < AutoApproveCooldown {
+		return
+	}
+	if err := inst.SendReply(q.Window, "1"); err != nil {
+		return
+	}
+	m.autoApprovedAt[inst.ID] = time.Now()
+	delete(m.pendingQuestions, inst.ID)
+	grant.Approvals--
+	if inst.Permissions != nil {
+		inst.Permissions.AutoApproved++
+	}
+	inst.LogYolo(q.Window, "approved: "+truncateRunes(q.Question, 120))
+	if grant.Approvals == 0 {
+		inst.SetWindowYolo(q.Window, inst.WindowAutoYes(q.Window), nil)
+		inst.LogYolo(q.Window, "YOLO approvals used up")
+		m.notifyInfo("Auto-approvals of '%s' used up - prompts wait for you again", inst.Name)
+	}
+	m.storage.UpdateInstance(inst)
+}
+
+// checkYoloExpiry ends time-limited YOLO grants the tmux timer did not get to.
+// Running sessions are switched off by that timer (asmgr yolo-expire), so only
+// the stored state is brought in line for them.
+func (m *Model) checkYoloExpiry() {
+	deadline := time.Now().Add(-session.YoloExpiryGrace)
+	for _, inst := range m.instances {
+		windows := []int{0}
+		for _, fw := range inst.FollowedWindows {
+			windows = append(windows, fw.Index)
+		}
+		for _, window := range windows {
+			if !inst.WindowYoloGrant(window).Expired(deadline) {
+				continue
+			}
+			inst.SetWindowYolo(window, false, nil)
+			if inst.Status != session.StatusRunning {
+				inst.LogYolo(window, "YOLO expired")
+			}
+			m.storage.UpdateInstance(inst)
+		}
+	}
+}
+
+// yoloLabel returns the YOLO marker of a window for the preview ("" when off)
+func yoloLabel(inst *session.Instance, window int) string {
+	grant := inst.WindowYoloGrant(window)
+	left := grant.Remaining(time.Now())
+	switch {
+	case inst.Sandbox != "":
+		return ""
+	case inst.WindowAutoYes(window) && left != "":
+		return " ! YOLO (" + left + ")"
+	case inst.WindowAutoYes(window):
+		return " ! YOLO"
+	case left != "":
+		return " ! auto-approve (" + left + ")"
+	}
+	return ""
+}